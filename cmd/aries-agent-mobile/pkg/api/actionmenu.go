@@ -0,0 +1,43 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import "github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+
+// ActionMenuController defines methods for the ActionMenu protocol controller.
+type ActionMenuController interface {
+
+	// Actions returns pending actions that have not yet to be executed or canceled.
+	Actions(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// RequestMenu is used by the Requester to ask the Responder for its root menu.
+	RequestMenu(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// SendMenu is used by the Responder to send a menu without being asked for it.
+	SendMenu(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// AcceptMenuRequest is used by the Responder to reply to a menu request with its root menu.
+	AcceptMenuRequest(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// DeclineMenuRequest is used when the Responder does not want to provide a menu.
+	DeclineMenuRequest(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// PerformAction is used by the Requester to select one of the options of the menu it received.
+	PerformAction(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// DeclineMenu is used when the Requester does not want to select any of the menu options.
+	DeclineMenu(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// AcceptPerform is used by the Responder to perform the selected option.
+	AcceptPerform(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// DeclinePerform is used when the Responder does not want to perform the selected option.
+	DeclinePerform(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// AcceptProblemReport is used for accepting problem report.
+	AcceptProblemReport(request *models.RequestEnvelope) *models.ResponseEnvelope
+}
@@ -39,6 +39,12 @@ type AriesController interface {
 	// GetKMSController returns an implementation of KMSController
 	GetKMSController() (KMSController, error)
 
+	// GetActionMenuController returns an implementation of ActionMenuController
+	GetActionMenuController() (ActionMenuController, error)
+
+	// GetQuestionAnswerController returns an implementation of QuestionAnswerController
+	GetQuestionAnswerController() (QuestionAnswerController, error)
+
 	// RegisterHandler registers handler for handling notifications
 	RegisterHandler(h Handler, topics string) string
 
@@ -16,4 +16,7 @@ type KMSController interface {
 
 	// ImportKey imports a key.
 	ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// ExportJWKS exports public keys as a JWK Set.
+	ExportJWKS(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
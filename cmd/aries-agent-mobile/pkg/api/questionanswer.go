@@ -0,0 +1,28 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import "github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+
+// QuestionAnswerController defines methods for the QuestionAnswer protocol controller.
+type QuestionAnswerController interface {
+
+	// Actions returns pending actions that have not yet to be executed or canceled.
+	Actions(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// SendQuestion is used by the Questioner to ask a question.
+	SendQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// AnswerQuestion is used by the Responder to answer the question.
+	AnswerQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// DeclineQuestion is used when the Responder does not want to answer the question.
+	DeclineQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// AcceptProblemReport is used for accepting problem report.
+	AcceptProblemReport(request *models.RequestEnvelope) *models.ResponseEnvelope
+}
@@ -69,13 +69,15 @@ type Store interface {
 	// be unmarshalled to a [][]byte.
 	GetBulk(keys []byte) ([]byte, error)
 
-	// Query returns all data that satisfies the expression. Expression format: TagName:TagValue.
-	// If TagValue is not provided, then all data associated with the TagName will be returned.
-	// For now, expression can only be a single tag Name + Value pair.
-	// PageSize sets the maximum page size for data retrievals done within the Iterator returned by the Query call.
-	// Paging is handled internally by the Iterator. Higher values may reduce CPU time and the number of database calls at
-	// the expense of higher memory usage.
-	Query(expression string, pageSize int) (Iterator, error)
+	// Query returns all data that satisfies the expression. The expression format is the one of
+	// aries-framework-go/spi/storage/Store.Query: TagName:TagValue conditions (TagValue being optional), range
+	// conditions on numbers and dates (TagName<Value, TagName<=Value, TagName>Value, TagName>=Value), combined
+	// with && and ||.
+	// The "queryOptions" argument must be JSON that can be unmarshalled to an
+	// aries-framework-go/spi/storage/QueryOptions. It sets the maximum page size for data retrievals done within
+	// the Iterator returned by the Query call (paging is handled internally by the Iterator), the sorting order
+	// and the bookmark the results resume after.
+	Query(expression string, queryOptions []byte) (Iterator, error)
 
 	// Delete deletes the key + value pair (and all tags) associated with key.
 	// If key is empty, then an error will be returned.
@@ -112,6 +114,10 @@ type Iterator interface {
 	// aries-framework-go/spi/storage/Tag.
 	Tags() ([]byte, error)
 
+	// Bookmark returns the bookmark of the current entry, which can be passed in the options of a Query call to
+	// resume the results after this entry.
+	Bookmark() (string, error)
+
 	// Close closes this iterator object, freeing resources.
 	Close() error
 }
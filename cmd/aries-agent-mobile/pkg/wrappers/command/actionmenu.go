@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+)
+
+// ActionMenu contains necessary fields for each of its operations.
+type ActionMenu struct {
+	handlers map[string]command.Exec
+}
+
+// Actions returns pending actions that have not yet to be executed or canceled.
+func (am *ActionMenu) Actions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	response, cmdErr := exec(am.handlers[cmdactionmenu.Actions], request.Payload)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// RequestMenu is used by the Requester to ask the Responder for its root menu.
+func (am *ActionMenu) RequestMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.RequestMenuArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.RequestMenu], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// SendMenu is used by the Responder to send a menu without being asked for it.
+func (am *ActionMenu) SendMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.SendMenuArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.SendMenu], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// AcceptMenuRequest is used by the Responder to reply to a menu request with its root menu.
+func (am *ActionMenu) AcceptMenuRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.AcceptMenuRequestArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.AcceptMenuRequest], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// DeclineMenuRequest is used when the Responder does not want to provide a menu.
+func (am *ActionMenu) DeclineMenuRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.DeclineMenuRequestArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.DeclineMenuRequest], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// PerformAction is used by the Requester to select one of the options of the menu it received.
+func (am *ActionMenu) PerformAction(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.PerformActionArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.PerformAction], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// DeclineMenu is used when the Requester does not want to select any of the menu options.
+func (am *ActionMenu) DeclineMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.DeclineMenuArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.DeclineMenu], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// AcceptPerform is used by the Responder to perform the selected option.
+func (am *ActionMenu) AcceptPerform(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.AcceptPerformArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.AcceptPerform], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// DeclinePerform is used when the Responder does not want to perform the selected option.
+func (am *ActionMenu) DeclinePerform(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.DeclinePerformArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.DeclinePerform], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// AcceptProblemReport is used for accepting problem report.
+func (am *ActionMenu) AcceptProblemReport(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdactionmenu.AcceptProblemReportArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(am.handlers[cmdactionmenu.AcceptProblemReport], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
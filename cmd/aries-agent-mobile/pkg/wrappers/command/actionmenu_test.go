@@ -0,0 +1,211 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+)
+
+func getActionMenuController(t *testing.T) *ActionMenu {
+	a, err := getAgent()
+	require.NotNil(t, a)
+	require.NoError(t, err)
+
+	controller, err := a.GetActionMenuController()
+	require.NoError(t, err)
+	require.NotNil(t, controller)
+
+	c, ok := controller.(*ActionMenu)
+	require.Equal(t, ok, true)
+
+	return c
+}
+
+func TestActionMenu_Actions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := `{"actions":[{"PIID":"ID1"},{"PIID":"ID2"}]}`
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.Actions] = fakeHandler.exec
+
+		payload := `{}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.Actions(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_RequestMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.RequestMenu] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.RequestMenu(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_SendMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.SendMenu] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id","menu":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.SendMenu(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_AcceptMenuRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.AcceptMenuRequest] = fakeHandler.exec
+
+		payload := `{"piid":"id","menu":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.AcceptMenuRequest(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_DeclineMenuRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.DeclineMenuRequest] = fakeHandler.exec
+
+		payload := `{"piid":"id","reason":"reason"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.DeclineMenuRequest(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_PerformAction(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.PerformAction] = fakeHandler.exec
+
+		payload := `{"piid":"id","perform":{"name":"option"}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.PerformAction(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_DeclineMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.DeclineMenu] = fakeHandler.exec
+
+		payload := `{"piid":"id","reason":"reason"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.DeclineMenu(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_AcceptPerform(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.AcceptPerform] = fakeHandler.exec
+
+		payload := `{"piid":"id"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.AcceptPerform(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_DeclinePerform(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.DeclinePerform] = fakeHandler.exec
+
+		payload := `{"piid":"id","reason":"reason"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.DeclinePerform(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_AcceptProblemReport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdactionmenu.AcceptProblemReport] = fakeHandler.exec
+
+		payload := `{"piid":"id"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.AcceptProblemReport(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
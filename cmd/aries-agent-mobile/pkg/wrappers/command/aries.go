@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
@@ -30,6 +31,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
@@ -108,6 +110,10 @@ func prepareFrameworkOptions(opts *config.Options) ([]aries.Option, error) {
 		options = append(options, aries.WithStoreProvider(mem.NewProvider()))
 	}
 
+	if opts.EncryptStorage {
+		options = append(options, aries.WithEncryptedStorage())
+	}
+
 	for _, transport := range opts.OutboundTransport {
 		switch transport {
 		case "http":
@@ -322,3 +328,23 @@ func (a *Aries) GetKMSController() (api.KMSController, error) {
 
 	return &KMS{handlers: handlers}, nil
 }
+
+// GetActionMenuController returns an ActionMenu instance.
+func (a *Aries) GetActionMenuController() (api.ActionMenuController, error) {
+	handlers, ok := a.handlers[actionmenu.CommandName]
+	if !ok {
+		return nil, fmt.Errorf("no handlers found for controller [%s]", actionmenu.CommandName)
+	}
+
+	return &ActionMenu{handlers: handlers}, nil
+}
+
+// GetQuestionAnswerController returns a QuestionAnswer instance.
+func (a *Aries) GetQuestionAnswerController() (api.QuestionAnswerController, error) {
+	handlers, ok := a.handlers[questionanswer.CommandName]
+	if !ok {
+		return nil, fmt.Errorf("no handlers found for controller [%s]", questionanswer.CommandName)
+	}
+
+	return &QuestionAnswer{handlers: handlers}, nil
+}
@@ -30,6 +30,12 @@ func TestNewAries(t *testing.T) {
 		require.NotNil(t, a.framework)
 		require.NotNil(t, a.handlers)
 	})
+	t.Run("test it creates an instance with encrypted storage", func(t *testing.T) {
+		a, err := NewAries(&config.Options{EncryptStorage: true})
+		require.NoError(t, err)
+		require.NotNil(t, a)
+		require.NotNil(t, a.framework)
+	})
 }
 
 type handlerFunc func(topic string, message []byte) error
@@ -207,3 +213,29 @@ func TestAries_GetKMSController(t *testing.T) {
 		require.NotNil(t, controller)
 	})
 }
+
+func TestAries_GetActionMenuController(t *testing.T) {
+	t.Run("it creates a controller", func(t *testing.T) {
+		opts := &config.Options{}
+		a, err := NewAries(opts)
+		require.NoError(t, err)
+		require.NotNil(t, a)
+
+		controller, err := a.GetActionMenuController()
+		require.NoError(t, err)
+		require.NotNil(t, controller)
+	})
+}
+
+func TestAries_GetQuestionAnswerController(t *testing.T) {
+	t.Run("it creates a controller", func(t *testing.T) {
+		opts := &config.Options{}
+		a, err := NewAries(opts)
+		require.NoError(t, err)
+		require.NotNil(t, a)
+
+		controller, err := a.GetQuestionAnswerController()
+		require.NoError(t, err)
+		require.NotNil(t, controller)
+	})
+}
@@ -35,6 +35,22 @@ func (k *KMS) CreateKeySet(request *models.RequestEnvelope) *models.ResponseEnve
 	return &models.ResponseEnvelope{Payload: response}
 }
 
+// ExportJWKS exports public keys as a JWK Set.
+func (k *KMS) ExportJWKS(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := kms.ExportJWKSRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(k.handlers[kms.ExportJWKSCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
 // ImportKey imports a key.
 func (k *KMS) ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelope {
 	response, cmdErr := exec(k.handlers[kms.ImportKeyCommandMethod], request.Payload)
@@ -72,3 +72,25 @@ func TestKMS_ImportKey(t *testing.T) {
 			string(resp.Payload))
 	})
 }
+
+func TestKMS_ExportJWKS(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		mockResponse := `{"keys":[{"use":"sig","kty":"OKP","kid":"kid","crv":"Ed25519",` +
+			`"x":"jXAvdkE8oHbFat1HYkdq3FXsuPdGtdl8NhKr163kikA"}]}`
+
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		controller.handlers[kms.ExportJWKSCommandMethod] = fakeHandler.exec
+
+		payload := `{"keys":[{"keyID":"keyID","keyType":"ED25519"}]}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := controller.ExportJWKS(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
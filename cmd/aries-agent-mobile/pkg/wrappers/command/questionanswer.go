@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	cmdqa "github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+)
+
+// QuestionAnswer contains necessary fields for each of its operations.
+type QuestionAnswer struct {
+	handlers map[string]command.Exec
+}
+
+// Actions returns pending actions that have not yet to be executed or canceled.
+func (qa *QuestionAnswer) Actions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	response, cmdErr := exec(qa.handlers[cmdqa.Actions], request.Payload)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// SendQuestion is used by the Questioner to ask a question.
+func (qa *QuestionAnswer) SendQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdqa.SendQuestionArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(qa.handlers[cmdqa.SendQuestion], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// AnswerQuestion is used by the Responder to answer the question.
+func (qa *QuestionAnswer) AnswerQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdqa.AnswerQuestionArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(qa.handlers[cmdqa.AnswerQuestion], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// DeclineQuestion is used when the Responder does not want to answer the question.
+func (qa *QuestionAnswer) DeclineQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdqa.DeclineQuestionArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(qa.handlers[cmdqa.DeclineQuestion], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// AcceptProblemReport is used for accepting problem report.
+func (qa *QuestionAnswer) AcceptProblemReport(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := cmdqa.AcceptProblemReportArgs{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(qa.handlers[cmdqa.AcceptProblemReport], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	cmdqa "github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+)
+
+func getQuestionAnswerController(t *testing.T) *QuestionAnswer {
+	a, err := getAgent()
+	require.NotNil(t, a)
+	require.NoError(t, err)
+
+	controller, err := a.GetQuestionAnswerController()
+	require.NoError(t, err)
+	require.NotNil(t, controller)
+
+	c, ok := controller.(*QuestionAnswer)
+	require.Equal(t, ok, true)
+
+	return c
+}
+
+func TestQuestionAnswer_Actions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		mockResponse := `{"actions":[{"PIID":"ID1"},{"PIID":"ID2"}]}`
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdqa.Actions] = fakeHandler.exec
+
+		payload := `{}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.Actions(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_SendQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		mockResponse := mockPIID
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdqa.SendQuestion] = fakeHandler.exec
+
+		payload := `{"my_did":"id","their_did":"id","question":{"question_text":"Alice?"}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.SendQuestion(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_AnswerQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdqa.AnswerQuestion] = fakeHandler.exec
+
+		payload := `{"piid":"id","response":"yes"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.AnswerQuestion(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_DeclineQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdqa.DeclineQuestion] = fakeHandler.exec
+
+		payload := `{"piid":"id","reason":"reason"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.DeclineQuestion(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_AcceptProblemReport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		mockResponse := emptyJSON
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		c.handlers[cmdqa.AcceptProblemReport] = fakeHandler.exec
+
+		payload := `{"piid":"id"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := c.AcceptProblemReport(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
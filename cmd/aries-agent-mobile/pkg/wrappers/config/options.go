@@ -19,6 +19,7 @@ type Options struct {
 	LogLevel             string
 	Logger               api.LoggerProvider
 	Storage              api.Provider
+	EncryptStorage       bool
 
 	// expected to be ignored by gomobile
 	// not intended to be used by golang code
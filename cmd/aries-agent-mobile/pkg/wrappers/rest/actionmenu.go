@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
+)
+
+// ActionMenu contains necessary fields for each of its operations.
+type ActionMenu struct {
+	httpClient httpClient
+	endpoints  map[string]*endpoint
+
+	URL   string
+	Token string
+}
+
+// Actions returns pending actions that have not yet to be executed or canceled.
+func (am *ActionMenu) Actions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.Actions)
+}
+
+// RequestMenu is used by the Requester to ask the Responder for its root menu.
+func (am *ActionMenu) RequestMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.RequestMenu)
+}
+
+// SendMenu is used by the Responder to send a menu without being asked for it.
+func (am *ActionMenu) SendMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.SendMenu)
+}
+
+// AcceptMenuRequest is used by the Responder to reply to a menu request with its root menu.
+func (am *ActionMenu) AcceptMenuRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.AcceptMenuRequest)
+}
+
+// DeclineMenuRequest is used when the Responder does not want to provide a menu.
+func (am *ActionMenu) DeclineMenuRequest(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.DeclineMenuRequest)
+}
+
+// PerformAction is used by the Requester to select one of the options of the menu it received.
+func (am *ActionMenu) PerformAction(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.PerformAction)
+}
+
+// DeclineMenu is used when the Requester does not want to select any of the menu options.
+func (am *ActionMenu) DeclineMenu(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.DeclineMenu)
+}
+
+// AcceptPerform is used by the Responder to perform the selected option.
+func (am *ActionMenu) AcceptPerform(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.AcceptPerform)
+}
+
+// DeclinePerform is used when the Responder does not want to perform the selected option.
+func (am *ActionMenu) DeclinePerform(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.DeclinePerform)
+}
+
+// AcceptProblemReport is used for accepting problem report.
+func (am *ActionMenu) AcceptProblemReport(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return am.createRespEnvelope(request, cmdactionmenu.AcceptProblemReport)
+}
+
+func (am *ActionMenu) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
+	return exec(&restOperation{
+		url:        am.URL,
+		token:      am.Token,
+		httpClient: am.httpClient,
+		endpoint:   am.endpoints[endpoint],
+		request:    request,
+	})
+}
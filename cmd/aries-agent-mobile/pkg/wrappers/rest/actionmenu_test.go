@@ -0,0 +1,256 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	opactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
+)
+
+func getActionMenuController(t *testing.T) *ActionMenu {
+	a, err := getAgent()
+	require.NotNil(t, a)
+	require.NoError(t, err)
+
+	controller, err := a.GetActionMenuController()
+	require.NoError(t, err)
+	require.NotNil(t, controller)
+
+	c, ok := controller.(*ActionMenu)
+	require.Equal(t, ok, true)
+
+	return c
+}
+
+func TestActionMenu_Actions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := `{"actions":[{"PIID":"ID1"},{"PIID":"ID2"}]}`
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockAgentURL + opactionmenu.Actions,
+		}
+
+		reqData := `{}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.Actions(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_RequestMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := mockPIID
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opactionmenu.RequestMenu,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.RequestMenu(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_SendMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		mockResponse := mockPIID
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opactionmenu.SendMenu,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id","menu":{}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.SendMenu(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_AcceptMenuRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		reqData := `{"piid":"id","menu":{}}`
+		mockURL, err := parseURL(mockAgentURL, opactionmenu.AcceptMenuRequest, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.AcceptMenuRequest(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_DeclineMenuRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		reqData := `{"piid":"id","reason":"reason"}`
+		mockURL, err := parseURL(mockAgentURL, opactionmenu.DeclineMenuRequest, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.DeclineMenuRequest(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_PerformAction(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		reqData := `{"piid":"id","perform":{"name":"option"}}`
+		mockURL, err := parseURL(mockAgentURL, opactionmenu.PerformAction, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.PerformAction(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_DeclineMenu(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		reqData := `{"piid":"id","reason":"reason"}`
+		mockURL, err := parseURL(mockAgentURL, opactionmenu.DeclineMenu, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.DeclineMenu(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_AcceptPerform(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		reqData := `{"piid":"id"}`
+		mockURL, err := parseURL(mockAgentURL, opactionmenu.AcceptPerform, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.AcceptPerform(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_DeclinePerform(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		reqData := `{"piid":"id","reason":"reason"}`
+		mockURL, err := parseURL(mockAgentURL, opactionmenu.DeclinePerform, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.DeclinePerform(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestActionMenu_AcceptProblemReport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getActionMenuController(t)
+
+		reqData := `{"piid":"id"}`
+		mockURL, err := parseURL(mockAgentURL, opactionmenu.AcceptProblemReport, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.AcceptProblemReport(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
@@ -9,6 +9,7 @@ package rest
 import (
 	"net/http"
 
+	cmdactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
 	cmddidexch "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
 	cmdintroduce "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	cmdisscred "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
@@ -17,8 +18,10 @@ import (
 	cmdmessaging "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	cmdoob "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	cmdpresproof "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	cmdqa "github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
 	cmdvdr "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	cmdverifiable "github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
+	opactionmenu "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
 	opdidexch "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
 	opintroduce "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	opisscred "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
@@ -27,6 +30,7 @@ import (
 	opmessaging "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
 	opoob "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	oppresproof "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
+	opqa "github.com/hyperledger/aries-framework-go/pkg/controller/rest/questionanswer"
 	opvdr "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	opverifiable "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
 )
@@ -50,6 +54,8 @@ func getControllerEndpoints() map[string]map[string]*endpoint {
 	allEndpoints[opmessaging.MsgServiceOperationID] = getMessagingEndpoints()
 	allEndpoints[opoob.OperationID] = getOutOfBandEndpoints()
 	allEndpoints[opkms.KmsOperationID] = getKMSEndpoints()
+	allEndpoints[opactionmenu.OperationID] = getActionMenuEndpoints()
+	allEndpoints[opqa.OperationID] = getQuestionAnswerEndpoints()
 
 	return allEndpoints
 }
@@ -437,5 +443,79 @@ func getKMSEndpoints() map[string]*endpoint {
 			Path:   opkms.ImportKeyPath,
 			Method: http.MethodPost,
 		},
+		cmdkms.ExportJWKSCommandMethod: {
+			Path:   opkms.ExportJWKSPath,
+			Method: http.MethodPost,
+		},
+	}
+}
+
+func getActionMenuEndpoints() map[string]*endpoint {
+	return map[string]*endpoint{
+		cmdactionmenu.Actions: {
+			Path:   opactionmenu.Actions,
+			Method: http.MethodGet,
+		},
+		cmdactionmenu.RequestMenu: {
+			Path:   opactionmenu.RequestMenu,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.SendMenu: {
+			Path:   opactionmenu.SendMenu,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.AcceptMenuRequest: {
+			Path:   opactionmenu.AcceptMenuRequest,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.DeclineMenuRequest: {
+			Path:   opactionmenu.DeclineMenuRequest,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.PerformAction: {
+			Path:   opactionmenu.PerformAction,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.DeclineMenu: {
+			Path:   opactionmenu.DeclineMenu,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.AcceptPerform: {
+			Path:   opactionmenu.AcceptPerform,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.DeclinePerform: {
+			Path:   opactionmenu.DeclinePerform,
+			Method: http.MethodPost,
+		},
+		cmdactionmenu.AcceptProblemReport: {
+			Path:   opactionmenu.AcceptProblemReport,
+			Method: http.MethodPost,
+		},
+	}
+}
+
+func getQuestionAnswerEndpoints() map[string]*endpoint {
+	return map[string]*endpoint{
+		cmdqa.Actions: {
+			Path:   opqa.Actions,
+			Method: http.MethodGet,
+		},
+		cmdqa.SendQuestion: {
+			Path:   opqa.SendQuestion,
+			Method: http.MethodPost,
+		},
+		cmdqa.AnswerQuestion: {
+			Path:   opqa.AnswerQuestion,
+			Method: http.MethodPost,
+		},
+		cmdqa.DeclineQuestion: {
+			Path:   opqa.DeclineQuestion,
+			Method: http.MethodPost,
+		},
+		cmdqa.AcceptProblemReport: {
+			Path:   opqa.AcceptProblemReport,
+			Method: http.MethodPost,
+		},
 	}
 }
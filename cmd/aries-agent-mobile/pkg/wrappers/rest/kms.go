@@ -30,6 +30,11 @@ func (k *KMS) ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelop
 	return k.createRespEnvelope(request, kms.ImportKeyCommandMethod)
 }
 
+// ExportJWKS exports public keys as a JWK Set.
+func (k *KMS) ExportJWKS(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.ExportJWKSCommandMethod)
+}
+
 func (k *KMS) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        k.URL,
@@ -73,3 +73,25 @@ func TestKMS_ImportKey(t *testing.T) {
 		require.Equal(t, mockResponse, string(resp.Payload))
 	})
 }
+
+func TestKMS_ExportJWKS(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		reqData := `{"keys":[{"keyID":"keyID","keyType":"ED25519"}]}`
+		mockResponse := `{"keys":[{"use":"sig","kty":"OKP","kid":"kid","crv":"Ed25519",` +
+			`"x":"jXAvdkE8oHbFat1HYkdq3FXsuPdGtdl8NhKr163kikA"}]}`
+
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + kms.ExportJWKSPath,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.ExportJWKS(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
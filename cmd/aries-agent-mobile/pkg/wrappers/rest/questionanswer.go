@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	cmdqa "github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+)
+
+// QuestionAnswer contains necessary fields for each of its operations.
+type QuestionAnswer struct {
+	httpClient httpClient
+	endpoints  map[string]*endpoint
+
+	URL   string
+	Token string
+}
+
+// Actions returns pending actions that have not yet to be executed or canceled.
+func (qa *QuestionAnswer) Actions(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return qa.createRespEnvelope(request, cmdqa.Actions)
+}
+
+// SendQuestion is used by the Questioner to ask a question.
+func (qa *QuestionAnswer) SendQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return qa.createRespEnvelope(request, cmdqa.SendQuestion)
+}
+
+// AnswerQuestion is used by the Responder to answer the question.
+func (qa *QuestionAnswer) AnswerQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return qa.createRespEnvelope(request, cmdqa.AnswerQuestion)
+}
+
+// DeclineQuestion is used when the Responder does not want to answer the question.
+func (qa *QuestionAnswer) DeclineQuestion(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return qa.createRespEnvelope(request, cmdqa.DeclineQuestion)
+}
+
+// AcceptProblemReport is used for accepting problem report.
+func (qa *QuestionAnswer) AcceptProblemReport(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return qa.createRespEnvelope(request, cmdqa.AcceptProblemReport)
+}
+
+func (qa *QuestionAnswer) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
+	return exec(&restOperation{
+		url:        qa.URL,
+		token:      qa.Token,
+		httpClient: qa.httpClient,
+		endpoint:   qa.endpoints[endpoint],
+		request:    request,
+	})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-mobile/pkg/wrappers/models"
+	opqa "github.com/hyperledger/aries-framework-go/pkg/controller/rest/questionanswer"
+)
+
+func getQuestionAnswerController(t *testing.T) *QuestionAnswer {
+	a, err := getAgent()
+	require.NotNil(t, a)
+	require.NoError(t, err)
+
+	controller, err := a.GetQuestionAnswerController()
+	require.NoError(t, err)
+	require.NotNil(t, controller)
+
+	c, ok := controller.(*QuestionAnswer)
+	require.Equal(t, ok, true)
+
+	return c
+}
+
+func TestQuestionAnswer_Actions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		mockResponse := `{"actions":[{"PIID":"ID1"},{"PIID":"ID2"}]}`
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockAgentURL + opqa.Actions,
+		}
+
+		reqData := `{}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.Actions(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_SendQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		mockResponse := mockPIID
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockAgentURL + opqa.SendQuestion,
+		}
+
+		reqData := `{"my_did":"id","their_did":"id","question":{"question_text":"Alice?"}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.SendQuestion(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_AnswerQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		reqData := `{"piid":"id","response":"yes"}`
+		mockURL, err := parseURL(mockAgentURL, opqa.AnswerQuestion, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.AnswerQuestion(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_DeclineQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		reqData := `{"piid":"id","reason":"reason"}`
+		mockURL, err := parseURL(mockAgentURL, opqa.DeclineQuestion, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.DeclineQuestion(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestQuestionAnswer_AcceptProblemReport(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c := getQuestionAnswerController(t)
+
+		reqData := `{"piid":"id"}`
+		mockURL, err := parseURL(mockAgentURL, opqa.AcceptProblemReport, reqData)
+		require.NoError(t, err, "failed to parse test url")
+
+		mockResponse := emptyJSON
+		c.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPost, url: mockURL,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := c.AcceptProblemReport(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
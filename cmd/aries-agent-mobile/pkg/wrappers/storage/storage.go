@@ -265,7 +265,12 @@ func (s *store) Query(expression string, options ...spi.QueryOption) (spi.Iterat
 		option(&queryOptions)
 	}
 
-	mobileBindingIterator, err := s.mobileBindingStore.Query(expression, queryOptions.PageSize)
+	queryOptionsBytes, err := json.Marshal(queryOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query options: %w", err)
+	}
+
+	mobileBindingIterator, err := s.mobileBindingStore.Query(expression, queryOptionsBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query mobile binding store: %w", err)
 	}
@@ -363,6 +368,15 @@ func (i *iterator) Tags() ([]spi.Tag, error) {
 	return tags, nil
 }
 
+func (i *iterator) Bookmark() (string, error) {
+	bookmark, err := i.mobileBindingIterator.Bookmark()
+	if err != nil {
+		return "", fmt.Errorf("failed to get bookmark from mobile binding iterator: %w", err)
+	}
+
+	return bookmark, nil
+}
+
 func (i *iterator) Close() error {
 	err := i.mobileBindingIterator.Close()
 	if err != nil {
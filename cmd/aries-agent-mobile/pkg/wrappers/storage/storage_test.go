@@ -89,8 +89,16 @@ func (s *spiStoreWrapper) GetBulk(keysBytes []byte) ([]byte, error) {
 	return json.Marshal(values)
 }
 
-func (s *spiStoreWrapper) Query(expression string, pageSize int) (api.Iterator, error) {
-	iterator, err := s.Store.Query(expression, spi.WithPageSize(pageSize))
+func (s *spiStoreWrapper) Query(expression string, queryOptionsBytes []byte) (api.Iterator, error) {
+	var queryOptions spi.QueryOptions
+
+	err := json.Unmarshal(queryOptionsBytes, &queryOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := s.Store.Query(expression, spi.WithPageSize(queryOptions.PageSize),
+		spi.WithSortingOrder(queryOptions.SortOptions), spi.WithBookmark(queryOptions.Bookmark))
 
 	return &spiIteratorWrapper{Iterator: iterator}, err
 }
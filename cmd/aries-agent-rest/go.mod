@@ -6,18 +6,34 @@ module github.com/hyperledger/aries-framework-go/cmd/aries-agent-rest
 
 replace github.com/hyperledger/aries-framework-go => ../..
 
+replace github.com/hyperledger/aries-framework-go/component/storage/sqldb => ../../component/storage/sqldb
+
+replace github.com/hyperledger/aries-framework-go/component/storage/leveldb => ../../component/storage/leveldb
+
+replace github.com/hyperledger/aries-framework-go/component/storageutil => ../../component/storageutil
+
+replace github.com/hyperledger/aries-framework-go/spi => ../../spi
+
 require (
 	github.com/cenkalti/backoff/v4 v4.1.0
+	github.com/go-sql-driver/mysql v1.5.0
 	github.com/golang/snappy v0.0.3 // indirect
 	github.com/gorilla/mux v1.7.3
 	github.com/hyperledger/aries-framework-go v0.1.6-0.20210304193329-f56b2cebc386
 	github.com/hyperledger/aries-framework-go/component/storage/leveldb v0.0.0-20210305152013-b276ca413681
-	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20210224230531-58e1368e5661
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210305152013-b276ca413681
+	github.com/hyperledger/aries-framework-go/component/storage/sqldb v0.0.0-00010101000000-000000000000
+	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20261019133114-510320220604
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261019084901-66f4e01095f2
+	github.com/lib/pq v1.10.0
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/prometheus/client_golang v1.9.0
 	github.com/rs/cors v1.7.0
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/otel v0.17.0
+	go.opentelemetry.io/otel/exporters/trace/jaeger v0.17.0
+	go.opentelemetry.io/otel/trace v0.17.0
 )
 
 go 1.15
@@ -26,7 +26,9 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-rest/resolvercmd"
 	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-rest/startcmd"
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-rest/storagecmd"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 )
 
@@ -46,7 +48,7 @@ func main() {
 		logger.Fatalf(err.Error())
 	}
 
-	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(startCmd, storagecmd.Cmd(), resolvercmd.Cmd(&startcmd.HTTPServer{}))
 
 	if err := rootCmd.Execute(); err != nil {
 		logger.Fatalf("Failed to run aries-agent-rest: %s", err)
@@ -0,0 +1,295 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resolvercmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-rest/startcmd"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/resolverservice"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
+)
+
+const (
+	hostFlagName      = "api-host"
+	hostEnvKey        = "ARIESD_RESOLVER_API_HOST"
+	hostFlagShorthand = "a"
+	hostFlagUsage     = "Host Name:Port of the resolver." +
+		" Alternatively, this can be set with the following environment variable: " + hostEnvKey
+
+	httpResolverFlagName      = "http-resolver-url"
+	httpResolverEnvKey        = "ARIESD_RESOLVER_HTTP_RESOLVER"
+	httpResolverFlagShorthand = "r"
+	httpResolverFlagUsage     = "HTTP binding DID resolver method and url, used in addition to the peer, key and" +
+		" web DID methods. Values should be in `method@url` format." +
+		" This flag can be repeated, allowing multiple http resolvers." +
+		" Alternatively, this can be set with the following environment variable (in CSV format): " +
+		httpResolverEnvKey
+
+	databaseTypeFlagName  = "database-type"
+	databaseTypeEnvKey    = "ARIESD_RESOLVER_DATABASE_TYPE"
+	databaseTypeFlagUsage = "The type of the database holding the peer DIDs to resolve." +
+		" Supported options: mem, leveldb, sqlite3, postgres, mysql. Default: mem." +
+		" Alternatively, this can be set with the following environment variable: " + databaseTypeEnvKey
+
+	databaseURLFlagName  = "database-url"
+	databaseURLEnvKey    = "ARIESD_RESOLVER_DATABASE_URL"
+	databaseURLFlagUsage = "The data source name of the database, required for the sqlite3, postgres and mysql" +
+		" database types. Alternatively, this can be set with the following environment variable: " +
+		databaseURLEnvKey
+
+	databasePrefixFlagName  = "database-prefix"
+	databasePrefixEnvKey    = "ARIESD_RESOLVER_DATABASE_PREFIX"
+	databasePrefixFlagUsage = "The prefix of the database (the path for the leveldb database type and the table" +
+		" prefix for the sqlite3, postgres and mysql database types). Use the same database as an agent to resolve" +
+		" its peer DIDs. Alternatively, this can be set with the following environment variable: " +
+		databasePrefixEnvKey
+
+	maxAgeFlagName  = "max-age"
+	maxAgeEnvKey    = "ARIESD_RESOLVER_MAX_AGE"
+	maxAgeFlagUsage = "Number of seconds clients may cache resolutions for, in the Cache-Control header of" +
+		" responses. Clients must revalidate resolutions if not set." +
+		" Alternatively, this can be set with the following environment variable: " + maxAgeEnvKey
+
+	tlsCertFileFlagName  = "tls-cert-file"
+	tlsCertFileEnvKey    = "ARIESD_RESOLVER_TLS_CERT_FILE"
+	tlsCertFileFlagUsage = "tls certificate file." +
+		" Alternatively, this can be set with the following environment variable: " + tlsCertFileEnvKey
+
+	tlsKeyFileFlagName  = "tls-key-file"
+	tlsKeyFileEnvKey    = "ARIESD_RESOLVER_TLS_KEY_FILE"
+	tlsKeyFileFlagUsage = "tls key file." +
+		" Alternatively, this can be set with the following environment variable: " + tlsKeyFileEnvKey
+
+	defaultDatabaseType = "mem"
+)
+
+var logger = log.New("aries-framework/agent-rest/resolver")
+
+type server interface {
+	ListenAndServe(host string, router http.Handler, certFile, keyFile string) error
+}
+
+// kmsProvider provides the key manager of the VDR registry, which is only used to create DIDs.
+type kmsProvider struct{}
+
+func (kmsProvider) KMS() kms.KeyManager {
+	return nil
+}
+
+// Cmd returns the Cobra resolver command.
+func Cmd(server server) *cobra.Command {
+	resolverCmd := &cobra.Command{
+		Use:   "resolver",
+		Short: "Start a DID resolver",
+		Long: `Serve the resolution of peer, key, web and HTTP binding DIDs at ` + resolverservice.IdentifiersPath +
+			`{did}, like a driver of the Universal Resolver`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			host, err := getUserSetVar(cmd, hostFlagName, hostEnvKey, false)
+			if err != nil {
+				return err
+			}
+
+			registry, err := createRegistry(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts, err := getServiceOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			tlsCertFile, err := getUserSetVar(cmd, tlsCertFileFlagName, tlsCertFileEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			tlsKeyFile, err := getUserSetVar(cmd, tlsKeyFileFlagName, tlsKeyFileEnvKey, true)
+			if err != nil {
+				return err
+			}
+
+			router := http.NewServeMux()
+			router.Handle(resolverservice.IdentifiersPath, resolverservice.New(registry, opts...))
+
+			logger.Infof("Starting DID resolver on host [%s]", host)
+
+			err = server.ListenAndServe(host, router, tlsCertFile, tlsKeyFile)
+			if err != nil {
+				return fmt.Errorf("failed to start DID resolver on [%s]: %w", host, err)
+			}
+
+			return nil
+		},
+	}
+
+	createFlags(resolverCmd)
+
+	return resolverCmd
+}
+
+func createFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(hostFlagName, hostFlagShorthand, "", hostFlagUsage)
+	cmd.Flags().StringSliceP(httpResolverFlagName, httpResolverFlagShorthand, []string{}, httpResolverFlagUsage)
+	cmd.Flags().StringP(databaseTypeFlagName, "", "", databaseTypeFlagUsage)
+	cmd.Flags().StringP(databaseURLFlagName, "", "", databaseURLFlagUsage)
+	cmd.Flags().StringP(databasePrefixFlagName, "", "", databasePrefixFlagUsage)
+	cmd.Flags().StringP(maxAgeFlagName, "", "", maxAgeFlagUsage)
+	cmd.Flags().StringP(tlsCertFileFlagName, "", "", tlsCertFileFlagUsage)
+	cmd.Flags().StringP(tlsKeyFileFlagName, "", "", tlsKeyFileFlagUsage)
+}
+
+// createRegistry returns the registry of the peer, key and web VDRs, along with the HTTP binding VDRs of the
+// http-resolver-url flag, which are tried first.
+func createRegistry(cmd *cobra.Command) (*vdr.Registry, error) {
+	httpResolvers, err := getUserSetVars(cmd, httpResolverFlagName, httpResolverEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := getHTTPResolverOpts(httpResolvers)
+	if err != nil {
+		return nil, err
+	}
+
+	dbType, err := getUserSetVar(cmd, databaseTypeFlagName, databaseTypeEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbType == "" {
+		dbType = defaultDatabaseType
+	}
+
+	url, err := getUserSetVar(cmd, databaseURLFlagName, databaseURLEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := getUserSetVar(cmd, databasePrefixFlagName, databasePrefixEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	storageProvider, err := startcmd.CreateStorageProvider(dbType, url, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	peerVDR, err := peer.New(storageProvider)
+	if err != nil {
+		return nil, fmt.Errorf("create new vdr peer failed: %w", err)
+	}
+
+	opts = append(opts, vdr.WithVDR(peerVDR), vdr.WithVDR(key.New()), vdr.WithVDR(web.New()))
+
+	return vdr.New(kmsProvider{}, opts...), nil
+}
+
+func getHTTPResolverOpts(httpResolvers []string) ([]vdr.Option, error) {
+	var opts []vdr.Option
+
+	const numPartsResolverOption = 2
+
+	for _, httpResolver := range httpResolvers {
+		r := strings.Split(httpResolver, "@")
+		if len(r) != numPartsResolverOption {
+			return nil, fmt.Errorf("invalid http resolver options found")
+		}
+
+		method := r[0]
+
+		httpVDR, err := httpbinding.New(r[1],
+			httpbinding.WithAccept(func(m string) bool { return m == method }))
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup http resolver :  %w", err)
+		}
+
+		opts = append(opts, vdr.WithVDR(httpVDR))
+	}
+
+	return opts, nil
+}
+
+func getServiceOptions(cmd *cobra.Command) ([]resolverservice.Option, error) {
+	maxAge, err := getUserSetVar(cmd, maxAgeFlagName, maxAgeEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAge == "" {
+		return nil, nil
+	}
+
+	seconds, err := strconv.Atoi(maxAge)
+	if err != nil || seconds < 0 {
+		return nil, fmt.Errorf("invalid %s value '%s': it must be a number of seconds", maxAgeFlagName, maxAge)
+	}
+
+	return []resolverservice.Option{resolverservice.WithMaxAge(time.Duration(seconds) * time.Second)}, nil
+}
+
+func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
+	if cmd.Flags().Changed(flagName) {
+		value, err := cmd.Flags().GetString(flagName)
+		if err != nil {
+			return "", fmt.Errorf(flagName+" flag not found: %s", err)
+		}
+
+		return value, nil
+	}
+
+	value, isSet := os.LookupEnv(envKey)
+
+	if isOptional || isSet {
+		return value, nil
+	}
+
+	return "", errors.New("Neither " + flagName + " (command line flag) nor " + envKey +
+		" (environment variable) have been set.")
+}
+
+func getUserSetVars(cmd *cobra.Command, flagName, envKey string, isOptional bool) ([]string, error) {
+	if cmd.Flags().Changed(flagName) {
+		value, err := cmd.Flags().GetStringSlice(flagName)
+		if err != nil {
+			return nil, fmt.Errorf(flagName+" flag not found: %s", err)
+		}
+
+		return value, nil
+	}
+
+	value, isSet := os.LookupEnv(envKey)
+
+	var values []string
+
+	if isSet {
+		values = strings.Split(value, ",")
+	}
+
+	if isOptional || isSet {
+		return values, nil
+	}
+
+	return nil, fmt.Errorf(" %s not set. "+
+		"It must be set via either command line or environment variable", flagName)
+}
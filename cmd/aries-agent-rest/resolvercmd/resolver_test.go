@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resolvercmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/resolverservice"
+)
+
+const didKey = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+type mockServer struct {
+	host    string
+	handler http.Handler
+	err     error
+}
+
+func (s *mockServer) ListenAndServe(host string, router http.Handler, _, _ string) error {
+	s.host = host
+	s.handler = router
+
+	return s.err
+}
+
+func TestResolverCmd(t *testing.T) {
+	t.Run("serves DID resolution", func(t *testing.T) {
+		server := &mockServer{}
+
+		cmd := Cmd(server)
+		cmd.SetArgs([]string{"--api-host", "localhost:8080", "--max-age", "30",
+			"--http-resolver-url", "example@http://localhost:9000/1.0/identifiers/"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "localhost:8080", server.host)
+
+		rec := httptest.NewRecorder()
+		server.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			resolverservice.IdentifiersPath+didKey, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "max-age=30", rec.Header().Get("Cache-Control"))
+
+		result := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.Equal(t, didKey, result["didDocument"].(map[string]interface{})["id"])
+
+		rec = httptest.NewRecorder()
+		server.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			resolverservice.IdentifiersPath+"did:peer:1zQmeuXAf2nfdXLJwRqi7tZMqGrLaKw6i91hhW1WvnW2CoRS", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("environment variables", func(t *testing.T) {
+		server := &mockServer{}
+
+		require.NoError(t, os.Setenv(hostEnvKey, "localhost:8081"))
+		require.NoError(t, os.Setenv(databaseTypeEnvKey, "leveldb"))
+		require.NoError(t, os.Setenv(databasePrefixEnvKey, t.TempDir()))
+
+		defer func() {
+			require.NoError(t, os.Unsetenv(hostEnvKey))
+			require.NoError(t, os.Unsetenv(databaseTypeEnvKey))
+			require.NoError(t, os.Unsetenv(databasePrefixEnvKey))
+		}()
+
+		cmd := Cmd(server)
+		cmd.SetArgs([]string{})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "localhost:8081", server.host)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for expected, args := range map[string][]string{
+			"Neither api-host (command line flag) nor ARIESD_RESOLVER_API_HOST (environment variable) have been" +
+				" set.": {},
+			"invalid http resolver options found": {"--api-host", "localhost:8080", "--http-resolver-url", "example"},
+			"failed to open database: unsupported database type other": {
+				"--api-host", "localhost:8080", "--database-type", "other",
+			},
+			"invalid max-age value '-1'": {"--api-host", "localhost:8080", "--max-age", "-1"},
+		} {
+			cmd := Cmd(&mockServer{})
+			cmd.SetArgs(args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), expected)
+		}
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		cmd := Cmd(&mockServer{err: errors.New("address in use")})
+		cmd.SetArgs([]string{"--api-host", "localhost:8080"})
+
+		err := cmd.Execute()
+		require.EqualError(t, err, "failed to start DID resolver on [localhost:8080]: address in use")
+	})
+}
@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+)
+
+const metricsPath = "/metrics"
+
+// prometheusProvider implements metrics.Provider using Prometheus collectors.
+type prometheusProvider struct {
+	registry   *prometheus.Registry
+	mu         sync.Mutex
+	counters   map[string]*prometheus.CounterVec
+	histograms map[string]*prometheus.HistogramVec
+}
+
+func newPrometheusProvider() *prometheusProvider {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGoCollector(), prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+
+	return &prometheusProvider{
+		registry:   registry,
+		counters:   map[string]*prometheus.CounterVec{},
+		histograms: map[string]*prometheus.HistogramVec{},
+	}
+}
+
+// Counter returns the counter with given name, registering it on first use.
+func (p *prometheusProvider) Counter(name, help string, labels ...string) metrics.Counter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c, ok := p.counters[name]
+	if !ok {
+		c = prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+		p.register(c)
+		p.counters[name] = c
+	}
+
+	return &promCounter{vec: c}
+}
+
+// Histogram returns the histogram with given name, registering it on first use.
+func (p *prometheusProvider) Histogram(name, help string, buckets []float64, labels ...string) metrics.Histogram {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h, ok := p.histograms[name]
+	if !ok {
+		h = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+		p.register(h)
+		p.histograms[name] = h
+	}
+
+	return &promHistogram{vec: h}
+}
+
+func (p *prometheusProvider) register(c prometheus.Collector) {
+	err := p.registry.Register(c)
+
+	var are prometheus.AlreadyRegisteredError
+	if err != nil && !errors.As(err, &are) {
+		logger.Warnf("failed to register metric: %s", err)
+	}
+}
+
+// Handler returns the http handler exposing the registered metrics.
+func (p *prometheusProvider) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+type promCounter struct {
+	vec *prometheus.CounterVec
+}
+
+func (c *promCounter) Inc(labelValues ...string) {
+	counter, err := c.vec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		logger.Warnf("failed to get counter: %s", err)
+
+		return
+	}
+
+	counter.Inc()
+}
+
+type promHistogram struct {
+	vec *prometheus.HistogramVec
+}
+
+func (h *promHistogram) Observe(value float64, labelValues ...string) {
+	histogram, err := h.vec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		logger.Warnf("failed to get histogram: %s", err)
+
+		return
+	}
+
+	histogram.Observe(value)
+}
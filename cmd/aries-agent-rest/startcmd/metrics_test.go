@@ -0,0 +1,37 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusProvider(t *testing.T) {
+	p := newPrometheusProvider()
+
+	p.Counter("test_messages_total", "Test messages.", "type").Inc("ping")
+	p.Counter("test_messages_total", "Test messages.", "type").Inc("ping")
+	p.Histogram("test_duration_seconds", "Test duration.", nil, "op").Observe(0.2, "sign")
+
+	// wrong number of label values is ignored
+	p.Counter("test_messages_total", "Test messages.", "type").Inc()
+	p.Histogram("test_duration_seconds", "Test duration.", nil, "op").Observe(0.2)
+
+	rr := httptest.NewRecorder()
+	p.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, metricsPath, nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	body, err := ioutil.ReadAll(rr.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `test_messages_total{type="ping"} 2`)
+	require.Contains(t, string(body), `test_duration_seconds_count{op="sign"} 1`)
+	require.Contains(t, string(body), "go_goroutines")
+}
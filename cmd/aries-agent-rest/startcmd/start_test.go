@@ -13,7 +13,9 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -22,6 +24,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	spi "github.com/hyperledger/aries-framework-go/spi/log"
 )
 
@@ -675,6 +680,67 @@ func TestStartAriesWithAutoAccept(t *testing.T) {
 	})
 }
 
+func TestStartAriesWithVDRCache(t *testing.T) {
+	args := func(size, ttl string) []string {
+		return []string{
+			"--" + agentHostFlagName,
+			randomURL(),
+			"--" + agentInboundHostFlagName,
+			httpProtocol + "@" + randomURL(),
+			"--" + databaseTypeFlagName,
+			databaseTypeMemOption,
+			"--" + agentAutoAcceptFlagName,
+			"true",
+			"--" + agentVDRCacheSizeFlagName,
+			size,
+			"--" + agentVDRCacheTTLFlagName,
+			ttl,
+		}
+	}
+
+	t.Run("start aries with vdr cache success", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(args("100", "1h"))
+
+		require.NoError(t, startCmd.Execute())
+	})
+
+	t.Run("start aries with invalid vdr cache size", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(args("many", "1h"))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse vdr cache size many")
+	})
+
+	t.Run("start aries with invalid vdr cache ttl", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(args("100", "forever"))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse vdr cache ttl forever")
+	})
+
+	t.Run("start aries with negative vdr cache ttl", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs(args("100", "-1h"))
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid vdr cache size 100 or ttl -1h0m0s")
+	})
+}
+
 func TestStartAriesTLS(t *testing.T) {
 	parameters := &agentParameters{
 		server:      &HTTPServer{},
@@ -735,12 +801,213 @@ func TestStartAriesWithAuthorization(t *testing.T) {
 	})
 }
 
+func TestStartAriesWithJWTAuthorization(t *testing.T) {
+	t.Run("api token and JWKS file together", func(t *testing.T) {
+		parameters := &agentParameters{
+			server:     &mockServer{},
+			host:       randomURL(),
+			token:      "ABCD",
+			authzParam: &authzParam{jwksFile: "jwks.json"},
+			dbParam:    &dbParam{dbType: databaseTypeMemOption},
+		}
+
+		err := startAgent(parameters)
+		require.Equal(t, errTokenAndJWKS, err)
+	})
+
+	t.Run("missing JWKS file", func(t *testing.T) {
+		parameters := &agentParameters{
+			server:     &mockServer{},
+			host:       randomURL(),
+			authzParam: &authzParam{jwksFile: "invalid"},
+			dbParam:    &dbParam{dbType: databaseTypeMemOption},
+		}
+
+		err := startAgent(parameters)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read JWKS file")
+	})
+
+	t.Run("invalid JWKS file", func(t *testing.T) {
+		file, err := ioutil.TempFile("", "jwks")
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, os.Remove(file.Name())) }()
+
+		_, err = file.WriteString(`{"keys":[]}`)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		parameters := &agentParameters{
+			server:     &mockServer{},
+			host:       randomURL(),
+			authzParam: &authzParam{jwksFile: file.Name()},
+			dbParam:    &dbParam{dbType: databaseTypeMemOption},
+		}
+
+		err = startAgent(parameters)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWKS contains no keys")
+	})
+
+	t.Run("valid JWKS file", func(t *testing.T) {
+		file, err := ioutil.TempFile("", "jwks")
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, os.Remove(file.Name())) }()
+
+		_, err = file.WriteString(`{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"k1",` +
+			`"x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		parameters := &agentParameters{
+			server:     &mockServer{},
+			host:       randomURL(),
+			authzParam: &authzParam{jwksFile: file.Name(), auditLogFile: file.Name() + ".audit"},
+			dbParam:    &dbParam{dbType: databaseTypeMemOption},
+		}
+
+		defer func() { require.NoError(t, os.Remove(file.Name()+".audit")) }()
+
+		require.NoError(t, startAgent(parameters))
+	})
+
+	t.Run("audit log is written until the server stops", func(t *testing.T) {
+		file, err := ioutil.TempFile("", "jwks")
+		require.NoError(t, err)
+
+		defer func() { require.NoError(t, os.Remove(file.Name())) }()
+
+		_, err = file.WriteString(`{"keys":[{"kty":"OKP","crv":"Ed25519","kid":"k1",` +
+			`"x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		auditLogFile := filepath.Join(t.TempDir(), "audit.log")
+
+		parameters := &agentParameters{
+			server:     &requestServer{path: "/connections"},
+			host:       randomURL(),
+			authzParam: &authzParam{jwksFile: file.Name(), auditLogFile: auditLogFile},
+			dbParam:    &dbParam{dbType: databaseTypeMemOption},
+		}
+
+		require.NoError(t, startAgent(parameters))
+
+		auditLog, err := ioutil.ReadFile(auditLogFile) // nolint:gosec
+		require.NoError(t, err)
+
+		var event map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(auditLog, &event))
+		require.Equal(t, "/connections", event["path"])
+		require.Equal(t, false, event["allowed"])
+	})
+}
+
+// requestServer serves a single unauthenticated GET request with the given path before stopping.
+type requestServer struct {
+	path string
+	code int
+}
+
+func (s *requestServer) ListenAndServe(host string, handler http.Handler, certFile, keyFile string) error {
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, s.path, nil))
+	s.code = rw.Code
+
+	return nil
+}
+
+func TestStartAriesWithPublishedKeys(t *testing.T) {
+	t.Run("JWK Set is served without authorization", func(t *testing.T) {
+		for path, code := range map[string]int{"/kms/jwks": http.StatusOK, "/connections": http.StatusUnauthorized} {
+			server := &requestServer{path: path}
+
+			parameters := &agentParameters{
+				server:  server,
+				host:    randomURL(),
+				token:   "ABCD",
+				dbParam: &dbParam{dbType: databaseTypeMemOption},
+			}
+
+			require.NoError(t, startAgent(parameters))
+			require.Equal(t, code, server.code, path)
+		}
+	})
+
+	t.Run("invalid published key", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + agentAutoAcceptFlagName, "true",
+			"--" + agentPublishedKeysFlagName, "k1",
+		})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid published key k1: expected <keyID>:<keyType>")
+	})
+
+	t.Run("published keys are parsed", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		require.NoError(t, startCmd.ParseFlags([]string{
+			"--" + agentPublishedKeysFlagName, "did:example:1#k1:ED25519,k2:ECDSAP256IEEEP1363",
+		}))
+
+		keys, err := getPublishedKeys(startCmd)
+		require.NoError(t, err)
+		require.Equal(t, []cmdkms.KeyReference{
+			{KeyID: "did:example:1#k1", KeyType: "ED25519"},
+			{KeyID: "k2", KeyType: "ECDSAP256IEEEP1363"},
+		}, keys)
+	})
+}
+
+func TestStartAriesWithObservability(t *testing.T) {
+	defer metrics.Initialize(nil)
+	defer tracing.Initialize(nil)
+
+	parameters := &agentParameters{
+		server:         &mockServer{},
+		host:           randomURL(),
+		dbParam:        &dbParam{dbType: databaseTypeMemOption},
+		metricsEnabled: true,
+		traceJaegerURL: "http://localhost:14268/api/traces",
+	}
+
+	require.NoError(t, startAgent(parameters))
+}
+
 func TestStoreProvider(t *testing.T) {
 	t.Run("test invalid database type", func(t *testing.T) {
 		_, err := createAriesAgent(&agentParameters{dbParam: &dbParam{dbType: "data1"}})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "database type not set to a valid type")
 	})
+
+	t.Run("test sqlite3 database type", func(t *testing.T) {
+		store, err := createStoreProviders(&agentParameters{dbParam: &dbParam{
+			dbType: databaseTypeSQLiteOption,
+			url:    filepath.Join(t.TempDir(), "aries.db"),
+			prefix: "agent_",
+		}})
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+	})
+
+	t.Run("test sql database type without url", func(t *testing.T) {
+		_, err := createStoreProviders(&agentParameters{dbParam: &dbParam{dbType: databaseTypePostgresOption}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), databaseURLFlagName+" (command line flag) or "+databaseURLEnvKey+
+			" (environment variable) is required for the postgres database type")
+	})
 }
 
 func waitForServerToStart(t *testing.T, host, inboundHost string) {
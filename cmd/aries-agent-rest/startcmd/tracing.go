@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/trace/jaeger"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
+)
+
+const tracerName = "github.com/hyperledger/aries-framework-go"
+
+// otelTracer implements tracing.Tracer using OpenTelemetry and W3C trace context propagation.
+type otelTracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// newJaegerTracer returns a tracer exporting spans to the Jaeger collector at collectorURL.
+func newJaegerTracer(collectorURL, serviceName string) (tracing.Tracer, func(), error) {
+	tp, flush, err := jaeger.NewExportPipeline(jaeger.WithCollectorEndpoint(collectorURL),
+		jaeger.WithProcess(jaeger.Process{ServiceName: serviceName}))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create jaeger export pipeline: %w", err)
+	}
+
+	return &otelTracer{
+		tracer:     tp.Tracer(tracerName),
+		propagator: propagation.TraceContext{},
+	}, flush, nil
+}
+
+func (t *otelTracer) Start(ctx context.Context, spanName string) (context.Context, tracing.Span) {
+	ctx, span := t.tracer.Start(ctx, spanName)
+
+	return ctx, &otelSpan{span: span}
+}
+
+func (t *otelTracer) Inject(ctx context.Context, carrier map[string]string) {
+	t.propagator.Inject(ctx, mapCarrier(carrier))
+}
+
+func (t *otelTracer) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return t.propagator.Extract(ctx, mapCarrier(carrier))
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s *otelSpan) SetAttribute(key, value string) {
+	s.span.SetAttributes(label.String(key, value))
+}
+
+func (s *otelSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *otelSpan) End() {
+	s.span.End()
+}
+
+// mapCarrier adapts a map to propagation.TextMapCarrier.
+type mapCarrier map[string]string
+
+func (c mapCarrier) Get(key string) string {
+	return c[key]
+}
+
+func (c mapCarrier) Set(key, value string) {
+	c[key] = value
+}
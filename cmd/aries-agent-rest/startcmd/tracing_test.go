@@ -0,0 +1,42 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestJaegerTracer(t *testing.T) {
+	tracer, flush, err := newJaegerTracer("http://localhost:14268/api/traces", agentServiceName)
+	require.NoError(t, err)
+	require.NotNil(t, flush)
+
+	ctx, span := tracer.Start(context.Background(), "parent")
+	span.SetAttribute("k", "v")
+	span.RecordError(nil)
+	span.RecordError(errors.New("test error"))
+
+	carrier := map[string]string{}
+	tracer.Inject(ctx, carrier)
+	require.NotEmpty(t, carrier["traceparent"])
+
+	extracted := tracer.Extract(context.Background(), carrier)
+	require.Equal(t, trace.SpanContextFromContext(ctx).TraceID,
+		trace.RemoteSpanContextFromContext(extracted).TraceID)
+
+	span.End()
+}
+
+func TestJaegerTracerError(t *testing.T) {
+	_, _, err := newJaegerTracer("", agentServiceName)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "create jaeger export pipeline")
+}
@@ -0,0 +1,439 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storagecmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/aries-framework-go/cmd/aries-agent-rest/startcmd"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	sourceRole = "source"
+	targetRole = "target"
+
+	databaseTypeFlagPattern  = "%s-database-type"
+	databaseTypeEnvPattern   = "ARIESD_%s_DATABASE_TYPE"
+	databaseTypeUsagePattern = "The type of the %s database." +
+		" Supported options: mem, leveldb, sqlite3, postgres, mysql." +
+		" Alternatively, this can be set with the following environment variable: %s"
+
+	databaseURLFlagPattern  = "%s-database-url"
+	databaseURLEnvPattern   = "ARIESD_%s_DATABASE_URL"
+	databaseURLUsagePattern = "The data source name of the %s database, required for the sqlite3, postgres and" +
+		" mysql database types. Alternatively, this can be set with the following environment variable: %s"
+
+	databasePrefixFlagPattern  = "%s-database-prefix"
+	databasePrefixEnvPattern   = "ARIESD_%s_DATABASE_PREFIX"
+	databasePrefixUsagePattern = "The prefix of the %s database (the path for the leveldb database type and the" +
+		" table prefix for the sqlite3, postgres and mysql database types)." +
+		" Alternatively, this can be set with the following environment variable: %s"
+
+	storesFlagName  = "store"
+	storesEnvKey    = "ARIESD_STORAGE_STORES"
+	storesFlagUsage = "Names of the stores to copy. Defaults to all the stores of the source, which is only" +
+		" supported for the mem and leveldb database types. Supports repeated usage of the flag or" +
+		" comma-separated values. Alternatively, this can be set with the following environment variable: " +
+		storesEnvKey
+
+	pageSizeFlagName  = "page-size"
+	pageSizeEnvKey    = "ARIESD_STORAGE_PAGE_SIZE"
+	pageSizeFlagUsage = "Number of entries read and written between two saves of the progress. Default: 100." +
+		" Alternatively, this can be set with the following environment variable: " + pageSizeEnvKey
+
+	snapshotFlagName  = "snapshot"
+	snapshotEnvKey    = "ARIESD_STORAGE_SNAPSHOT"
+	snapshotFlagUsage = "Copy a consistent snapshot of the source, starting over if it is modified during the copy." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + snapshotEnvKey
+
+	resumeFlagName  = "resume"
+	resumeEnvKey    = "ARIESD_STORAGE_RESUME"
+	resumeFlagUsage = "Resume an interrupted copy from the progress recorded in the target database." +
+		" Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + resumeEnvKey
+
+	archiveFlagName  = "archive"
+	archiveEnvKey    = "ARIESD_STORAGE_ARCHIVE"
+	archiveFlagUsage = "Path of the backup archive." +
+		" Alternatively, this can be set with the following environment variable: " + archiveEnvKey
+
+	passphraseFileFlagName  = "passphrase-file"
+	passphraseFileEnvKey    = "ARIESD_STORAGE_PASSPHRASE_FILE"
+	passphraseFileFlagUsage = "Path of a file holding the passphrase of an encrypted backup archive." +
+		" Backups are not encrypted if not set." +
+		" Alternatively, this can be set with the following environment variable: " + passphraseFileEnvKey
+
+	archiveFileMode = 0600
+)
+
+// Cmd returns the Cobra storage command.
+func Cmd() *cobra.Command {
+	storageCmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Migrate, back up and restore agent storage",
+		Long: `Copy all the stores of an Aries agent, along with their tag configurations, from one database ` +
+			`to another, or to and from a backup archive`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	storageCmd.AddCommand(createMigrateCmd(), createBackupCmd(), createRestoreCmd())
+
+	return storageCmd
+}
+
+func createMigrateCmd() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate storage to another database",
+		Long:  `Copy all the stores of the source database to the target database`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := getOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			source, err := getProvider(cmd, sourceRole)
+			if err != nil {
+				return err
+			}
+
+			defer source.Close() // nolint:errcheck // The report is already out.
+
+			target, err := getProvider(cmd, targetRole)
+			if err != nil {
+				return err
+			}
+
+			defer target.Close() // nolint:errcheck // The report is already out.
+
+			report, err := migrate.Migrate(source, target, opts...)
+			if err != nil {
+				return fmt.Errorf("failed to migrate storage: %w", err)
+			}
+
+			return printReport(cmd, report)
+		},
+	}
+
+	createDatabaseFlags(migrateCmd, sourceRole)
+	createDatabaseFlags(migrateCmd, targetRole)
+	createCopyFlags(migrateCmd)
+	migrateCmd.Flags().StringP(snapshotFlagName, "", "", snapshotFlagUsage)
+	migrateCmd.Flags().StringP(resumeFlagName, "", "", resumeFlagUsage)
+
+	return migrateCmd
+}
+
+func createBackupCmd() *cobra.Command {
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up storage to an archive",
+		Long:  `Write all the stores of the source database to a backup archive, encrypted if a passphrase is given`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := getOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			archivePath, err := getUserSetVar(cmd, archiveFlagName, archiveEnvKey, false)
+			if err != nil {
+				return err
+			}
+
+			source, err := getProvider(cmd, sourceRole)
+			if err != nil {
+				return err
+			}
+
+			defer source.Close() // nolint:errcheck // The report is already out.
+
+			report, err := backup(source, archivePath, opts)
+			if err != nil {
+				return err
+			}
+
+			return printReport(cmd, report)
+		},
+	}
+
+	createDatabaseFlags(backupCmd, sourceRole)
+	createArchiveFlags(backupCmd)
+	createCopyFlags(backupCmd)
+	backupCmd.Flags().StringP(snapshotFlagName, "", "", snapshotFlagUsage)
+
+	return backupCmd
+}
+
+// backup writes the backup archive, which is removed if the backup fails.
+func backup(source storage.Provider, archivePath string, opts []migrate.Option) (*migrate.Report, error) {
+	archive, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, archiveFileMode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup archive: %w", err)
+	}
+
+	writer := bufio.NewWriter(archive)
+
+	report, err := migrate.Backup(source, writer, opts...)
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	if err == nil {
+		err = archive.Close()
+	} else {
+		archive.Close() // nolint:errcheck,gosec // The backup error is returned.
+	}
+
+	if err != nil {
+		os.Remove(archivePath) // nolint:errcheck,gosec // The backup error is returned.
+
+		return nil, fmt.Errorf("failed to back up storage: %w", err)
+	}
+
+	return report, nil
+}
+
+func createRestoreCmd() *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore storage from an archive",
+		Long:  `Write all the stores of a backup archive to the target database`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := getOptions(cmd)
+			if err != nil {
+				return err
+			}
+
+			archivePath, err := getUserSetVar(cmd, archiveFlagName, archiveEnvKey, false)
+			if err != nil {
+				return err
+			}
+
+			archive, err := os.Open(archivePath) // nolint:gosec // The archive path is set by the operator.
+			if err != nil {
+				return fmt.Errorf("failed to open backup archive: %w", err)
+			}
+
+			defer archive.Close() // nolint:errcheck // The archive is only read.
+
+			target, err := getProvider(cmd, targetRole)
+			if err != nil {
+				return err
+			}
+
+			defer target.Close() // nolint:errcheck // The report is already out.
+
+			report, err := migrate.Restore(archive, target, opts...)
+			if err != nil {
+				return fmt.Errorf("failed to restore storage: %w", err)
+			}
+
+			return printReport(cmd, report)
+		},
+	}
+
+	createDatabaseFlags(restoreCmd, targetRole)
+	createArchiveFlags(restoreCmd)
+	createCopyFlags(restoreCmd)
+	restoreCmd.Flags().StringP(resumeFlagName, "", "", resumeFlagUsage)
+
+	return restoreCmd
+}
+
+func createDatabaseFlags(cmd *cobra.Command, role string) {
+	envRole := strings.ToUpper(role)
+
+	cmd.Flags().StringP(fmt.Sprintf(databaseTypeFlagPattern, role), "", "",
+		fmt.Sprintf(databaseTypeUsagePattern, role, fmt.Sprintf(databaseTypeEnvPattern, envRole)))
+	cmd.Flags().StringP(fmt.Sprintf(databaseURLFlagPattern, role), "", "",
+		fmt.Sprintf(databaseURLUsagePattern, role, fmt.Sprintf(databaseURLEnvPattern, envRole)))
+	cmd.Flags().StringP(fmt.Sprintf(databasePrefixFlagPattern, role), "", "",
+		fmt.Sprintf(databasePrefixUsagePattern, role, fmt.Sprintf(databasePrefixEnvPattern, envRole)))
+}
+
+func createArchiveFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(archiveFlagName, "", "", archiveFlagUsage)
+	cmd.Flags().StringP(passphraseFileFlagName, "", "", passphraseFileFlagUsage)
+}
+
+func createCopyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceP(storesFlagName, "", []string{}, storesFlagUsage)
+	cmd.Flags().StringP(pageSizeFlagName, "", "", pageSizeFlagUsage)
+}
+
+func getProvider(cmd *cobra.Command, role string) (storage.Provider, error) {
+	envRole := strings.ToUpper(role)
+
+	dbType, err := getUserSetVar(cmd, fmt.Sprintf(databaseTypeFlagPattern, role),
+		fmt.Sprintf(databaseTypeEnvPattern, envRole), false)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := getUserSetVar(cmd, fmt.Sprintf(databaseURLFlagPattern, role),
+		fmt.Sprintf(databaseURLEnvPattern, envRole), true)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := getUserSetVar(cmd, fmt.Sprintf(databasePrefixFlagPattern, role),
+		fmt.Sprintf(databasePrefixEnvPattern, envRole), true)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := startcmd.CreateStorageProvider(dbType, url, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s database: %w", role, err)
+	}
+
+	return provider, nil
+}
+
+func getOptions(cmd *cobra.Command) ([]migrate.Option, error) { // nolint:gocyclo // Flags are read one by one.
+	var opts []migrate.Option
+
+	stores, err := getUserSetVars(cmd, storesFlagName, storesEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stores) > 0 {
+		opts = append(opts, migrate.WithStoreNames(stores...))
+	}
+
+	pageSize, err := getUserSetVar(cmd, pageSizeFlagName, pageSizeEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if pageSize != "" {
+		size, err := strconv.Atoi(pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse page size %s: %w", pageSize, err)
+		}
+
+		opts = append(opts, migrate.WithPageSize(size))
+	}
+
+	snapshot, err := getBoolValue(cmd, snapshotFlagName, snapshotEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot {
+		opts = append(opts, migrate.WithSnapshot(0))
+	}
+
+	resume, err := getBoolValue(cmd, resumeFlagName, resumeEnvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if resume {
+		opts = append(opts, migrate.WithResume())
+	}
+
+	passphraseFile, err := getUserSetVar(cmd, passphraseFileFlagName, passphraseFileEnvKey, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if passphraseFile != "" {
+		passphrase, err := ioutil.ReadFile(passphraseFile) // nolint:gosec // The path is set by the operator.
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+
+		opts = append(opts, migrate.WithPassphrase(bytes.TrimRight(passphrase, "\r\n")))
+	}
+
+	return opts, nil
+}
+
+func printReport(cmd *cobra.Command, report *migrate.Report) error {
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(reportBytes))
+
+	return err
+}
+
+// getUserSetVar returns the value of a flag or of its environment variable, or nothing if the command does not have
+// the flag.
+func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
+	if cmd.Flags().Lookup(flagName) == nil {
+		return "", nil
+	}
+
+	if cmd.Flags().Changed(flagName) {
+		value, err := cmd.Flags().GetString(flagName)
+		if err != nil {
+			return "", fmt.Errorf(flagName+" flag not found: %s", err)
+		}
+
+		return value, nil
+	}
+
+	value, isSet := os.LookupEnv(envKey)
+
+	if isOptional || isSet {
+		return value, nil
+	}
+
+	return "", errors.New("Neither " + flagName + " (command line flag) nor " + envKey +
+		" (environment variable) have been set.")
+}
+
+func getUserSetVars(cmd *cobra.Command, flagName, envKey string) ([]string, error) {
+	if cmd.Flags().Changed(flagName) {
+		value, err := cmd.Flags().GetStringSlice(flagName)
+		if err != nil {
+			return nil, fmt.Errorf(flagName+" flag not found: %s", err)
+		}
+
+		return value, nil
+	}
+
+	value, isSet := os.LookupEnv(envKey)
+	if !isSet {
+		return nil, nil
+	}
+
+	return strings.Split(value, ","), nil
+}
+
+func getBoolValue(cmd *cobra.Command, flagName, envKey string) (bool, error) {
+	v, err := getUserSetVar(cmd, flagName, envKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storagecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
+	"github.com/hyperledger/aries-framework-go/component/storage/sqldb"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestStorageCmdContents(t *testing.T) {
+	storageCmd := Cmd()
+
+	require.Equal(t, "storage", storageCmd.Use)
+	require.Len(t, storageCmd.Commands(), 3)
+
+	storageCmd.SetArgs([]string{})
+	require.NoError(t, storageCmd.Execute())
+}
+
+func TestMigrateCmd(t *testing.T) {
+	t.Run("Migrate leveldb to sqlite3", func(t *testing.T) {
+		dir := t.TempDir()
+		levelDBPath := filepath.Join(dir, "leveldb")
+		sqlitePath := filepath.Join(dir, "aries.db")
+
+		putEntries(t, leveldb.NewProvider(levelDBPath))
+
+		report := execute(t, "migrate",
+			"--source-database-type", "leveldb", "--source-database-prefix", levelDBPath,
+			"--target-database-type", "sqlite3", "--target-database-url", sqlitePath,
+			"--snapshot", "true", "--page-size", "1")
+		require.Len(t, report.Stores, 2)
+
+		target, err := sqldb.NewProvider(sqldb.DriverSQLite, sqlitePath)
+		require.NoError(t, err)
+
+		defer target.Close() // nolint:errcheck // Test cleanup.
+
+		requireEntries(t, target)
+	})
+	t.Run("Invalid flags", func(t *testing.T) {
+		for expected, args := range map[string][]string{
+			"Neither source-database-type (command line flag) nor ARIESD_SOURCE_DATABASE_TYPE (environment" +
+				" variable) have been set.": {},
+			"failed to open source database: unsupported database type other": {"--source-database-type", "other"},
+			`failed to parse page size a: strconv.Atoi: parsing "a": invalid syntax`: {"--page-size", "a"},
+			`strconv.ParseBool: parsing "maybe": invalid syntax`:                     {"--resume", "maybe"},
+		} {
+			migrateCmd := createMigrateCmd()
+			migrateCmd.SetArgs(args)
+			require.EqualError(t, migrateCmd.Execute(), expected)
+		}
+	})
+	t.Run("Store names are required for sqlite3", func(t *testing.T) {
+		migrateCmd := createMigrateCmd()
+		migrateCmd.SetArgs([]string{
+			"--source-database-type", "sqlite3", "--source-database-url", filepath.Join(t.TempDir(), "aries.db"),
+			"--target-database-type", "mem",
+		})
+		require.EqualError(t, migrateCmd.Execute(), "failed to migrate storage: "+
+			"store names must be given for a provider that cannot list its stores")
+	})
+}
+
+func TestBackupAndRestoreCmds(t *testing.T) {
+	dir := t.TempDir()
+	levelDBPath := filepath.Join(dir, "leveldb")
+	archivePath := filepath.Join(dir, "backup")
+	passphrasePath := filepath.Join(dir, "passphrase")
+
+	require.NoError(t, ioutil.WriteFile(passphrasePath, []byte("passphrase\n"), 0600))
+
+	putEntries(t, leveldb.NewProvider(levelDBPath))
+
+	report := execute(t, "backup", "--source-database-type", "leveldb", "--source-database-prefix", levelDBPath,
+		"--archive", archivePath, "--passphrase-file", passphrasePath, "--store", "connections,keys")
+	require.Len(t, report.Stores, 2)
+
+	// An existing archive is never overwritten.
+	backupCmd := createBackupCmd()
+	backupCmd.SetArgs([]string{
+		"--source-database-type", "leveldb", "--source-database-prefix", levelDBPath, "--archive", archivePath,
+	})
+	require.Contains(t, backupCmd.Execute().Error(), "failed to create backup archive")
+
+	// A failed backup leaves no archive behind.
+	backupCmd = createBackupCmd()
+	backupCmd.SetArgs([]string{
+		"--source-database-type", "sqlite3", "--source-database-url", filepath.Join(dir, "aries.db"),
+		"--archive", filepath.Join(dir, "failed"),
+	})
+	require.EqualError(t, backupCmd.Execute(), "failed to back up storage: "+
+		"store names must be given for a provider that cannot list its stores")
+
+	_, err := os.Stat(filepath.Join(dir, "failed"))
+	require.True(t, os.IsNotExist(err))
+
+	restoreCmd := createRestoreCmd()
+	restoreCmd.SetArgs([]string{"--target-database-type", "mem", "--archive", archivePath})
+	require.EqualError(t, restoreCmd.Execute(),
+		"failed to restore storage: a passphrase is required to restore an encrypted archive")
+
+	restoredPath := filepath.Join(dir, "restored")
+
+	report = execute(t, "restore", "--target-database-type", "leveldb", "--target-database-prefix", restoredPath,
+		"--archive", archivePath, "--passphrase-file", passphrasePath, "--resume", "true")
+	require.Len(t, report.Stores, 2)
+
+	target := leveldb.NewProvider(restoredPath)
+
+	defer target.Close() // nolint:errcheck // Test cleanup.
+
+	requireEntries(t, target)
+}
+
+func execute(t *testing.T, args ...string) *migrate.Report {
+	t.Helper()
+
+	storageCmd := Cmd()
+
+	var out bytes.Buffer
+
+	storageCmd.SetOut(&out)
+	storageCmd.SetArgs(args)
+	require.NoError(t, storageCmd.Execute())
+
+	var report migrate.Report
+
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+
+	return &report
+}
+
+func putEntries(t *testing.T, provider storage.Provider) {
+	t.Helper()
+
+	connections, err := provider.OpenStore("connections")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig("connections", storage.StoreConfiguration{TagNames: []string{"state"}}))
+	require.NoError(t, connections.Put("conn1", []byte("connection"), storage.Tag{Name: "state", Value: "completed"}))
+
+	keys, err := provider.OpenStore("keys")
+	require.NoError(t, err)
+
+	require.NoError(t, keys.Put("key1", []byte("keyset")))
+	require.NoError(t, provider.Close())
+}
+
+func requireEntries(t *testing.T, provider storage.Provider) {
+	t.Helper()
+
+	connections, err := provider.OpenStore("connections")
+	require.NoError(t, err)
+
+	config, err := provider.GetStoreConfig("connections")
+	require.NoError(t, err)
+	require.Equal(t, []string{"state"}, config.TagNames)
+
+	tags, err := connections.GetTags("conn1")
+	require.NoError(t, err)
+	require.Equal(t, []storage.Tag{{Name: "state", Value: "completed"}}, tags)
+
+	keys, err := provider.OpenStore("keys")
+	require.NoError(t, err)
+
+	value, err := keys.Get("key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("keyset"), value)
+}
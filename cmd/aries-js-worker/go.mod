@@ -17,5 +17,7 @@ require (
 replace (
 	github.com/hyperledger/aries-framework-go => ../../
 	github.com/hyperledger/aries-framework-go/component/storage/indexeddb => ../../component/storage/indexeddb
+	github.com/hyperledger/aries-framework-go/component/storageutil => ../../component/storageutil
+	github.com/hyperledger/aries-framework-go/spi => ../../spi
 	github.com/hyperledger/aries-framework-go/test/component => ../../test/component
 )
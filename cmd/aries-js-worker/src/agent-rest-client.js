@@ -388,6 +388,80 @@ const pkgs = {
             pathParam:"piid"
         },
     },
+    actionmenu:{
+        Actions: {
+            path: "/actionmenu/actions",
+            method: "GET",
+        },
+        RequestMenu: {
+            path: "/actionmenu/request-menu",
+            method: "POST",
+        },
+        SendMenu: {
+            path: "/actionmenu/send-menu",
+            method: "POST",
+        },
+        AcceptMenuRequest: {
+            path: "/actionmenu/{piid}/accept-menu-request",
+            method: "POST",
+            pathParam:"piid"
+        },
+        DeclineMenuRequest: {
+            path: "/actionmenu/{piid}/decline-menu-request",
+            method: "POST",
+            pathParam:"piid"
+        },
+        PerformAction: {
+            path: "/actionmenu/{piid}/perform-action",
+            method: "POST",
+            pathParam:"piid"
+        },
+        DeclineMenu: {
+            path: "/actionmenu/{piid}/decline-menu",
+            method: "POST",
+            pathParam:"piid"
+        },
+        AcceptPerform: {
+            path: "/actionmenu/{piid}/accept-perform",
+            method: "POST",
+            pathParam:"piid"
+        },
+        DeclinePerform: {
+            path: "/actionmenu/{piid}/decline-perform",
+            method: "POST",
+            pathParam:"piid"
+        },
+        AcceptProblemReport: {
+            path: "/actionmenu/{piid}/accept-problem-report",
+            method: "POST",
+            pathParam:"piid"
+        },
+    },
+    questionanswer:{
+        Actions: {
+            path: "/questionanswer/actions",
+            method: "GET",
+        },
+        SendQuestion: {
+            path: "/questionanswer/send-question",
+            method: "POST",
+        },
+        AnswerQuestion: {
+            path: "/questionanswer/{piid}/answer-question",
+            method: "POST",
+            pathParam:"piid"
+        },
+        DeclineQuestion: {
+            path: "/questionanswer/{piid}/decline-question",
+            method: "POST",
+            pathParam:"piid"
+        },
+        AcceptProblemReport: {
+            path: "/questionanswer/{piid}/accept-problem-report",
+            method: "POST",
+            pathParam:"piid"
+        },
+    },
     kms: {
         CreateKeySet: {
             path: "/kms/keyset",
@@ -396,6 +470,10 @@ const pkgs = {
         ImportKey: {
             path: "/kms/import",
             method: "POST",
+        },
+        ExportJWKS: {
+            path: "/kms/jwks",
+            method: "POST",
         }
     },
 }
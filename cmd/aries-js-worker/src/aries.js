@@ -636,6 +636,155 @@ const Aries = function (opts) {
             },
         },
 
+        /**
+         * Action Menu methods - Refer to [OpenAPI spec](docs/rest/openapi_spec.md#generate-openapi-spec) for
+         * input params and output return json values.
+         */
+        actionmenu: {
+            pkgname: "actionmenu",
+            /**
+             * Returns pending actions that have not yet to be executed or cancelled.
+             *
+             * @returns {Promise<Object>}
+             */
+            actions: async function () {
+                return invoke(aw, pending, this.pkgname, "Actions", null, "timeout while getting actions")
+            },
+            /**
+             * Requests the root menu.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            requestMenu: function (req) {
+                return invoke(aw, pending, this.pkgname, "RequestMenu", req, "timeout while requesting a menu")
+            },
+            /**
+             * Sends a menu without being asked for it.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            sendMenu: function (req) {
+                return invoke(aw, pending, this.pkgname, "SendMenu", req, "timeout while sending a menu")
+            },
+            /**
+             * Accepts a menu request.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            acceptMenuRequest: function (req) {
+                return invoke(aw, pending, this.pkgname, "AcceptMenuRequest", req, "timeout while accepting a menu request")
+            },
+            /**
+             * Declines a menu request.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            declineMenuRequest: function (req) {
+                return invoke(aw, pending, this.pkgname, "DeclineMenuRequest", req, "timeout while declining a menu request")
+            },
+            /**
+             * Selects one of the menu options.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            performAction: function (req) {
+                return invoke(aw, pending, this.pkgname, "PerformAction", req, "timeout while performing an action")
+            },
+            /**
+             * Declines a menu.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            declineMenu: function (req) {
+                return invoke(aw, pending, this.pkgname, "DeclineMenu", req, "timeout while declining a menu")
+            },
+            /**
+             * Performs the selected option.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            acceptPerform: function (req) {
+                return invoke(aw, pending, this.pkgname, "AcceptPerform", req, "timeout while accepting a perform")
+            },
+            /**
+             * Declines the selected option.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            declinePerform: function (req) {
+                return invoke(aw, pending, this.pkgname, "DeclinePerform", req, "timeout while declining a perform")
+            },
+            /**
+             * Accepts a problem report.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            acceptProblemReport: function (req) {
+                return invoke(aw, pending, this.pkgname, "AcceptProblemReport", req, "timeout while accepting a problem report")
+            },
+        },
+
+        /**
+         * Question Answer methods - Refer to [OpenAPI spec](docs/rest/openapi_spec.md#generate-openapi-spec) for
+         * input params and output return json values.
+         */
+        questionanswer: {
+            pkgname: "questionanswer",
+            /**
+             * Returns pending actions that have not yet to be executed or cancelled.
+             *
+             * @returns {Promise<Object>}
+             */
+            actions: async function () {
+                return invoke(aw, pending, this.pkgname, "Actions", null, "timeout while getting actions")
+            },
+            /**
+             * Sends a question.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            sendQuestion: function (req) {
+                return invoke(aw, pending, this.pkgname, "SendQuestion", req, "timeout while sending a question")
+            },
+            /**
+             * Answers a question.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            answerQuestion: function (req) {
+                return invoke(aw, pending, this.pkgname, "AnswerQuestion", req, "timeout while answering a question")
+            },
+            /**
+             * Declines a question.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            declineQuestion: function (req) {
+                return invoke(aw, pending, this.pkgname, "DeclineQuestion", req, "timeout while declining a question")
+            },
+            /**
+             * Accepts a problem report.
+             *
+             * @param req - json document
+             * @returns {Promise<Object>}
+             */
+            acceptProblemReport: function (req) {
+                return invoke(aw, pending, this.pkgname, "AcceptProblemReport", req, "timeout while accepting a problem report")
+            },
+        },
+
         /**
          * DIDExchange methods - Refer to [OpenAPI spec](docs/rest/openapi_spec.md#generate-openapi-spec) for
          * input params and output return json values.
@@ -1080,6 +1229,15 @@ const Aries = function (opts) {
             importKey: async function (req) {
                 return invoke(aw, pending, this.pkgname, "ImportKey", req, "timeout while importing key")
             },
+
+            /**
+             * Export JWKS.
+             *
+             * @returns {Promise<Object>}
+             */
+            exportJWKS: async function (req) {
+                return invoke(aw, pending, this.pkgname, "ExportJWKS", req, "timeout while exporting JWKS")
+            },
         },
     }
 
@@ -0,0 +1,571 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edv
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("EDV-Provider")
+
+const (
+	contentTypeApplicationJSON = "application/json"
+	locationHeaderName         = "Location"
+
+	failResponseFromEDVServer = "status code %d was returned along with the following message: %s"
+	failSendPOSTRequest       = "failed to send POST request: %w"
+	failSendGETRequest        = "failed to send GET request: %w"
+	failCreateRequest         = "failed to create request: %w"
+)
+
+// addHeaders function supports adding custom HTTP headers.
+type addHeaders func(req *http.Request) (*http.Header, error)
+
+// ClientOption allows for configuration of a Client.
+type ClientOption func(client *Client)
+
+// WithClientTLSConfig is an option that allows for the definition of a secured HTTP transport using a tls.Config
+// instance.
+func WithClientTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(client *Client) {
+		client.httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+}
+
+// WithClientHeaders option is for setting additional http request headers (since it's a function, it can call a
+// remote authorization server to fetch the necessary info needed in these headers).
+func WithClientHeaders(addHeadersFunc addHeaders) ClientOption {
+	return func(client *Client) {
+		client.headersFunc = addHeadersFunc
+	}
+}
+
+// WithClientCapabilityInvoker option authorizes every request by invoking an authorization capability with invoker.
+func WithClientCapabilityInvoker(invoker CapabilityInvoker) ClientOption {
+	return func(client *Client) {
+		client.invoker = invoker
+	}
+}
+
+// Client is a client of a server supporting the data vault HTTP API as defined in
+// https://identity.foundation/confidential-storage/#http-api. It sends and receives encrypted documents, which
+// can be created with an EncryptedFormatter.
+type Client struct {
+	edvServerURL string
+	httpClient   *http.Client
+	headersFunc  addHeaders
+	invoker      CapabilityInvoker
+}
+
+// NewClient returns a new Client. edvServerURL is the base URL for the EDV server, under which vaults are created.
+func NewClient(edvServerURL string, options ...ClientOption) *Client {
+	client := &Client{
+		edvServerURL: edvServerURL,
+		httpClient:   &http.Client{},
+	}
+
+	for _, opt := range options {
+		opt(client)
+	}
+
+	return client
+}
+
+// CreateVault creates a new data vault with the given configuration and returns its location.
+func (c *Client) CreateVault(config *VaultConfiguration) (string, error) {
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal vault configuration: %w", err)
+	}
+
+	statusCode, hdr, respBytes, err := c.sendHTTPRequest(http.MethodPost, c.edvServerURL, configBytes, ActionWrite)
+	if err != nil {
+		return "", fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	if statusCode == http.StatusCreated {
+		return hdr.Get(locationHeaderName), nil
+	}
+
+	return "", fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+// GetVaultConfiguration returns the configuration of the vault with the given ID.
+func (c *Client) GetVaultConfiguration(vaultID string) (*VaultConfiguration, error) {
+	endpoint := fmt.Sprintf("%s/%s", c.edvServerURL, url.PathEscape(vaultID))
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodGet, endpoint, nil, ActionRead)
+	if err != nil {
+		return nil, fmt.Errorf(failSendGETRequest, err)
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+		var config VaultConfiguration
+
+		err = json.Unmarshal(respBytes, &config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal vault configuration: %w", err)
+		}
+
+		return &config, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("error: %w, status code %d was returned along with the following message: %s",
+			spi.ErrDataNotFound, statusCode, respBytes)
+	default:
+		return nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+	}
+}
+
+// CreateDocument stores a new encrypted document in the vault with the given ID and returns its location.
+func (c *Client) CreateDocument(vaultID string, document *EncryptedDocument) (string, error) {
+	docBytes, err := json.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal encrypted document: %w", err)
+	}
+
+	return c.createDocument(vaultID, docBytes)
+}
+
+// ReadDocument returns the encrypted document with the given ID. An error wrapping spi.ErrDataNotFound is returned
+// if it does not exist.
+func (c *Client) ReadDocument(vaultID, docID string) (*EncryptedDocument, error) {
+	docBytes, err := c.readDocument(vaultID, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	var document EncryptedDocument
+
+	err = json.Unmarshal(docBytes, &document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal encrypted document: %w", err)
+	}
+
+	return &document, nil
+}
+
+// UpdateDocument replaces the encrypted document with the given ID.
+func (c *Client) UpdateDocument(vaultID, docID string, document *EncryptedDocument) error {
+	docBytes, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("failed to marshal encrypted document: %w", err)
+	}
+
+	return c.updateDocument(vaultID, docID, docBytes)
+}
+
+// Query returns the locations of the documents matching the given query of the encrypted indexes.
+func (c *Client) Query(vaultID string, query *Query) ([]string, error) {
+	q := *query
+	q.ReturnFullDocuments = false
+
+	respBytes, err := c.sendQuery(vaultID, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	var docLocations []string
+
+	err = json.Unmarshal(respBytes, &docLocations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response bytes into document locations: %w", err)
+	}
+
+	return docLocations, nil
+}
+
+// QueryFullDocuments returns the documents matching the given query of the encrypted indexes. The EDV server must
+// support the TrustBloc EDV server extension as defined here:
+// https://github.com/trustbloc/edv/blob/main/docs/extensions.md#return-full-documents-on-query.
+func (c *Client) QueryFullDocuments(vaultID string, query *Query) ([]EncryptedDocument, error) {
+	q := *query
+	q.ReturnFullDocuments = true
+
+	respBytes, err := c.sendQuery(vaultID, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []EncryptedDocument
+
+	err = json.Unmarshal(respBytes, &documents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal encrypted documents: %w", err)
+	}
+
+	return documents, nil
+}
+
+// PutChunk stores a chunk of the data stream of the document with the given ID.
+func (c *Client) PutChunk(vaultID, docID string, chunk *EncryptedChunk) error {
+	chunkBytes, err := json.Marshal(chunk)
+	if err != nil {
+		return fmt.Errorf("failed to marshal encrypted chunk: %w", err)
+	}
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, c.chunkEndpoint(vaultID, docID, chunk.Index),
+		chunkBytes, ActionWrite)
+	if err != nil {
+		return fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	if statusCode == http.StatusOK || statusCode == http.StatusCreated || statusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+// ReadChunk returns the chunk with the given index of the data stream of the document with the given ID.
+func (c *Client) ReadChunk(vaultID, docID string, index int) (*EncryptedChunk, error) {
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodGet, c.chunkEndpoint(vaultID, docID, index), nil,
+		ActionRead)
+	if err != nil {
+		return nil, fmt.Errorf(failSendGETRequest, err)
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+		var chunk EncryptedChunk
+
+		err = json.Unmarshal(respBytes, &chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal encrypted chunk: %w", err)
+		}
+
+		return &chunk, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("error: %w, status code %d was returned along with the following message: %s",
+			spi.ErrDataNotFound, statusCode, respBytes)
+	default:
+		return nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+	}
+}
+
+// DeleteChunk deletes the chunk with the given index of the data stream of the document with the given ID. An error
+// wrapping spi.ErrDataNotFound is returned if it does not exist.
+func (c *Client) DeleteChunk(vaultID, docID string, index int) error {
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodDelete, c.chunkEndpoint(vaultID, docID, index), nil,
+		ActionWrite)
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("error: %w, status code %d was returned along with the following message: %s",
+			spi.ErrDataNotFound, statusCode, respBytes)
+	default:
+		return fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+	}
+}
+
+// Batch performs the given batch of operations in one request. The EDV server must support the TrustBloc EDV server
+// extension as defined here: https://github.com/trustbloc/edv/blob/main/docs/extensions.md#batch-endpoint.
+func (c *Client) Batch(vaultID string, batch Batch) ([]string, error) {
+	jsonToSend, err := json.Marshal(batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/batch", c.edvServerURL, url.PathEscape(vaultID))
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, jsonToSend, ActionWrite)
+	if err != nil {
+		return nil, fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	if statusCode == http.StatusOK {
+		var responses []string
+
+		err = json.Unmarshal(respBytes, &responses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal batch responses: %w", err)
+		}
+
+		return responses, nil
+	}
+
+	return nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+// DeleteDocument deletes the document with the given ID, along with its data stream. An error wrapping
+// spi.ErrDataNotFound is returned if it does not exist.
+func (c *Client) DeleteDocument(vaultID, docID string) error {
+	endpoint := fmt.Sprintf("%s/%s/documents/%s", c.edvServerURL, url.PathEscape(vaultID), url.PathEscape(docID))
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodDelete, endpoint, nil, ActionWrite)
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusOK {
+		return nil
+	} else if statusCode == http.StatusNotFound {
+		return fmt.Errorf("error: %w, status code %d was returned along with the following message: %s",
+			spi.ErrDataNotFound, statusCode, respBytes)
+	}
+
+	return fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+func (c *Client) createDocument(vaultID string, docBytes []byte) (string, error) {
+	logger.Debugf(`Sending request to vault with ID "%s" to create the following document: %s`, vaultID, docBytes)
+
+	endpoint := fmt.Sprintf("%s/%s/documents", c.edvServerURL, url.PathEscape(vaultID))
+
+	statusCode, hdr, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, docBytes, ActionWrite)
+	if err != nil {
+		return "", fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	if statusCode == http.StatusCreated {
+		return hdr.Get(locationHeaderName), nil
+	}
+
+	return "", fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+func (c *Client) updateDocument(vaultID, docID string, docBytes []byte) error {
+	endpoint := fmt.Sprintf("%s/%s/documents/%s", c.edvServerURL, url.PathEscape(vaultID), url.PathEscape(docID))
+
+	logger.Debugf(`Sending request to vault with ID "%s" to update a document with ID "%s". `+
+		`Document contents: %s`, vaultID, docID, docBytes)
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, docBytes, ActionWrite)
+	if err != nil {
+		return fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	// TODO (#2331): StatusNoContent added for now since Transmute's EDV implementation uses it
+	if statusCode == http.StatusOK || statusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+func (c *Client) readDocument(vaultID, docID string) ([]byte, error) {
+	endpoint := fmt.Sprintf("%s/%s/documents/%s", c.edvServerURL, url.PathEscape(vaultID), url.PathEscape(docID))
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodGet, endpoint, nil, ActionRead)
+	if err != nil {
+		return nil, fmt.Errorf(failSendGETRequest, err)
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+		return respBytes, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("error: %w, status code %d was returned along with the following message: %s",
+			spi.ErrDataNotFound, statusCode, respBytes)
+	default:
+		return nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+	}
+}
+
+// If value is blank, then we will do a "has" query instead which will match any documents tagged with the index name
+// regardless of value.
+func (c *Client) queryVault(vaultID, name, value string) ([]string, error) {
+	var jsonToSend []byte
+
+	var err error
+
+	if value == "" {
+		query := hasQuery{
+			Has: name,
+		}
+
+		jsonToSend, err = json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to marshal name-only "has" query: %w`, err)
+		}
+	} else {
+		query := nameAndValueQuery{
+			Name:  name,
+			Value: value,
+		}
+
+		jsonToSend, err = json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal name + value query: %w", err)
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/query", c.edvServerURL, url.PathEscape(vaultID))
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, jsonToSend, ActionRead)
+	if err != nil {
+		return nil, fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	if statusCode == http.StatusOK {
+		var docLocations []string
+
+		err = json.Unmarshal(respBytes, &docLocations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response bytes into document locations: %w", err)
+		}
+
+		return docLocations, nil
+	}
+
+	return nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+func (c *Client) queryVaultForFullDocuments(vaultID, name, value string) ([]EncryptedDocument, error) {
+	var jsonToSend []byte
+
+	var err error
+
+	if value == "" {
+		query := hasQuery{
+			ReturnFullDocuments: true,
+			Has:                 name,
+		}
+
+		jsonToSend, err = json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to marshal name-only "has" query: %w`, err)
+		}
+	} else {
+		query := nameAndValueQuery{
+			ReturnFullDocuments: true,
+			Name:                name,
+			Value:               value,
+		}
+
+		jsonToSend, err = json.Marshal(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal name + value query: %w", err)
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/query", c.edvServerURL, url.PathEscape(vaultID))
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, jsonToSend, ActionRead)
+	if err != nil {
+		return nil, fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	if statusCode == http.StatusOK {
+		var documents []EncryptedDocument
+
+		err = json.Unmarshal(respBytes, &documents)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal encrypted documents: %w", err)
+		}
+
+		return documents, nil
+	}
+
+	return nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+}
+
+func (c *Client) sendQuery(vaultID string, query *Query) ([]byte, error) {
+	jsonToSend, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/query", c.edvServerURL, url.PathEscape(vaultID))
+
+	statusCode, _, respBytes, err := c.sendHTTPRequest(http.MethodPost, endpoint, jsonToSend, ActionRead)
+	if err != nil {
+		return nil, fmt.Errorf(failSendPOSTRequest, err)
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf(failResponseFromEDVServer, statusCode, respBytes)
+	}
+
+	return respBytes, nil
+}
+
+func (c *Client) chunkEndpoint(vaultID, docID string, index int) string {
+	return fmt.Sprintf("%s/%s/documents/%s/chunks/%s", c.edvServerURL, url.PathEscape(vaultID),
+		url.PathEscape(docID), strconv.Itoa(index))
+}
+
+func (c *Client) sendHTTPRequest(method, endpoint string, body []byte, action string) (int, http.Header, []byte,
+	error) {
+	var req *http.Request
+
+	var err error
+
+	if len(body) == 0 {
+		req, err = http.NewRequest(method, endpoint, nil)
+		if err != nil {
+			return -1, nil, nil, fmt.Errorf(failCreateRequest, err)
+		}
+	} else {
+		req, err = http.NewRequest(method, endpoint, bytes.NewBuffer(body))
+		if err != nil {
+			return -1, nil, nil, fmt.Errorf(failCreateRequest, err)
+		}
+	}
+
+	if c.headersFunc != nil {
+		httpHeaders, errAddHdr := c.headersFunc(req)
+		if errAddHdr != nil {
+			return -1, nil, nil, fmt.Errorf("add optional request headers error: %w", errAddHdr)
+		}
+
+		if httpHeaders != nil {
+			req.Header = httpHeaders.Clone()
+		}
+	}
+
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", contentTypeApplicationJSON)
+	}
+
+	if c.invoker != nil {
+		err = c.invoker.InvokeCapability(req, action)
+		if err != nil {
+			return -1, nil, nil, fmt.Errorf("failed to invoke capability: %w", err)
+		}
+	}
+
+	resp, err := c.httpClient.Do(req) //nolint: bodyclose
+	if err != nil {
+		return -1, nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer closeReadCloser(resp.Body)
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return -1, nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	logger.Debugf(`Sent %s request to %s. Response status code: %d Response body: %s`, method, endpoint,
+		resp.StatusCode, respBytes)
+
+	return resp.StatusCode, resp.Header, respBytes, nil
+}
+
+func closeReadCloser(respBody io.ReadCloser) {
+	err := respBody.Close()
+	if err != nil {
+		logger.Errorf("Failed to close response body: %s", err)
+	}
+}
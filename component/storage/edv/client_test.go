@@ -0,0 +1,544 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edv_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/edv"
+	"github.com/hyperledger/aries-framework-go/component/storage/edv/mock"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/zcapld"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+	storagetest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func TestCommonWithMockServer(t *testing.T) {
+	for name, options := range map[string][]edv.Option{
+		"Standard endpoints": nil,
+		"Compound queries":   {edv.WithCompoundQueries()},
+		"Chunked values":     {edv.WithChunkSize(8)},
+		"All extensions": {
+			edv.WithBatchEndpointExtension(), edv.WithFullDocumentsReturnedFromQueries(),
+			edv.WithCompoundQueries(), edv.WithChunkSize(8),
+		},
+	} {
+		options := options
+
+		t.Run(name, func(t *testing.T) {
+			server := mock.NewServer()
+			defer server.Close()
+
+			storagetest.TestAll(t, edv.NewRESTProvider(server.EDVServerURL(), createVault(t, edv.NewClient(
+				server.EDVServerURL())), createValidEncryptedFormatter(t), options...))
+		})
+	}
+}
+
+func TestClient_Vaults(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	client := edv.NewClient(server.EDVServerURL())
+
+	config := &edv.VaultConfiguration{
+		Controller:  "did:example:123",
+		ReferenceID: "reference",
+		KEK:         edv.IDTypePair{ID: "https://example.com/kms/12345", Type: "AesKeyWrappingKey2019"},
+		HMAC:        edv.IDTypePair{ID: "https://example.com/kms/67891", Type: "Sha256HmacKey2019"},
+	}
+
+	location, err := client.CreateVault(config)
+	require.NoError(t, err)
+
+	vaultID := getVaultIDFromURL(location)
+
+	storedConfig, err := client.GetVaultConfiguration(vaultID)
+	require.NoError(t, err)
+	require.Equal(t, vaultID, storedConfig.ID)
+	require.Equal(t, config.ReferenceID, storedConfig.ReferenceID)
+	require.Equal(t, config.HMAC, storedConfig.HMAC)
+
+	_, err = client.CreateVault(config)
+	require.Contains(t, err.Error(), "status code 409")
+
+	_, err = client.GetVaultConfiguration("unknown")
+	require.True(t, errors.Is(err, spi.ErrDataNotFound))
+}
+
+func TestClient_Documents(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	client := edv.NewClient(server.EDVServerURL())
+	vaultID := createVault(t, client)
+
+	document := newDocument("doc1", edv.IndexedAttribute{Name: "email", Value: "alice", Unique: true})
+
+	location, err := client.CreateDocument(vaultID, document)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(location, "/documents/doc1"))
+
+	_, err = client.CreateDocument(vaultID, document)
+	require.Contains(t, err.Error(), "status code 409")
+
+	// Unique attributes cannot be shared by documents.
+	_, err = client.CreateDocument(vaultID, newDocument("doc2",
+		edv.IndexedAttribute{Name: "email", Value: "alice", Unique: true}))
+	require.Contains(t, err.Error(), "status code 409")
+
+	document.Sequence = 1
+	require.NoError(t, client.UpdateDocument(vaultID, "doc1", document))
+
+	readDocument, err := client.ReadDocument(vaultID, "doc1")
+	require.NoError(t, err)
+	require.Equal(t, document, readDocument)
+
+	require.NoError(t, client.DeleteDocument(vaultID, "doc1"))
+
+	_, err = client.ReadDocument(vaultID, "doc1")
+	require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+	err = client.DeleteDocument(vaultID, "doc1")
+	require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+	err = client.UpdateDocument(vaultID, "doc1", document)
+	require.Contains(t, err.Error(), "status code 404")
+}
+
+func TestClient_Query(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	client := edv.NewClient(server.EDVServerURL())
+	vaultID := createVault(t, client)
+
+	for _, document := range []*edv.EncryptedDocument{
+		newDocument("doc1", edv.IndexedAttribute{Name: "type", Value: "photo"},
+			edv.IndexedAttribute{Name: "owner", Value: "alice"}),
+		newDocument("doc2", edv.IndexedAttribute{Name: "type", Value: "photo"},
+			edv.IndexedAttribute{Name: "owner", Value: "bob"}),
+		newDocument("doc3", edv.IndexedAttribute{Name: "type", Value: "video"}),
+	} {
+		_, err := client.CreateDocument(vaultID, document)
+		require.NoError(t, err)
+	}
+
+	for name, tc := range map[string]struct {
+		query    *edv.Query
+		expected []string
+	}{
+		"All attributes of a term must match": {
+			query:    &edv.Query{Equals: []map[string]string{{"type": "photo", "owner": "bob"}}},
+			expected: []string{"doc2"},
+		},
+		"Any term may match": {
+			query: &edv.Query{Equals: []map[string]string{
+				{"type": "photo", "owner": "alice"}, {"type": "video"},
+			}},
+			expected: []string{"doc1", "doc3"},
+		},
+		"All attributes must be present": {
+			query:    &edv.Query{Has: []string{"type", "owner"}},
+			expected: []string{"doc1", "doc2"},
+		},
+		"Index of another HMAC key": {
+			query: &edv.Query{Index: "https://example.com/kms/other", Has: []string{"type"}},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			locations, err := client.Query(vaultID, tc.query)
+			require.NoError(t, err)
+			require.Len(t, locations, len(tc.expected))
+
+			documents, err := client.QueryFullDocuments(vaultID, tc.query)
+			require.NoError(t, err)
+			require.Len(t, documents, len(tc.expected))
+
+			for i, id := range tc.expected {
+				require.True(t, strings.HasSuffix(locations[i], "/documents/"+id))
+				require.Equal(t, id, documents[i].ID)
+			}
+		})
+	}
+
+	_, err := client.Query(vaultID, &edv.Query{})
+	require.Contains(t, err.Error(), "status code 400")
+}
+
+func TestClient_Streams(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	client := edv.NewClient(server.EDVServerURL())
+	vaultID := createVault(t, client)
+	encrypter, decrypter := createEncrypterAndDecrypter(t)
+
+	_, err := client.CreateDocument(vaultID, newDocument("doc1"))
+	require.NoError(t, err)
+
+	data := make([]byte, 100)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+
+	stream, err := client.WriteStream(vaultID, "doc1", 1, bytes.NewReader(data), 32, encrypter)
+	require.NoError(t, err)
+	require.Equal(t, &edv.Stream{Sequence: 1, Chunks: 4}, stream)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, client.ReadStream(vaultID, "doc1", stream, decrypter, &buf))
+	require.Equal(t, data, buf.Bytes())
+
+	// An empty stream has no chunks.
+	emptyStream, err := client.WriteStream(vaultID, "doc1", 2, bytes.NewReader(nil), 32, encrypter)
+	require.NoError(t, err)
+	require.Equal(t, 0, emptyStream.Chunks)
+
+	_, err = client.WriteStream(vaultID, "doc1", 1, bytes.NewReader(data), 0, encrypter)
+	require.EqualError(t, err, "chunk size must be positive")
+
+	// Chunks cannot be swapped.
+	chunk, err := client.ReadChunk(vaultID, "doc1", 0)
+	require.NoError(t, err)
+
+	chunk.Index = 1
+	chunk.Offset = 32
+	require.NoError(t, client.PutChunk(vaultID, "doc1", chunk))
+
+	err = client.ReadStream(vaultID, "doc1", stream, decrypter, &buf)
+	require.EqualError(t, err, "chunk 1 does not belong to the stream")
+
+	// Chunks are deleted along with their document.
+	require.NoError(t, client.DeleteDocument(vaultID, "doc1"))
+
+	_, err = client.ReadChunk(vaultID, "doc1", 0)
+	require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+	_, err = client.WriteStream(vaultID, "doc1", 1, bytes.NewReader(data), 32, encrypter)
+	require.Contains(t, err.Error(), "failed to store chunk 0")
+}
+
+func TestRESTProvider_ChunkedValues(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	client := edv.NewClient(server.EDVServerURL())
+	vaultID := createVault(t, client)
+
+	provider := edv.NewRESTProvider(server.EDVServerURL(), vaultID, createValidEncryptedFormatter(t),
+		edv.WithChunkSize(16), edv.WithCompoundQueries())
+
+	store, err := provider.OpenStore("attachments")
+	require.NoError(t, err)
+
+	attachment := bytes.Repeat([]byte("attachment"), 100)
+
+	require.NoError(t, store.Put("photo", attachment, spi.Tag{Name: "type", Value: "photo"}))
+
+	value, err := store.Get("photo")
+	require.NoError(t, err)
+	require.Equal(t, attachment, value)
+
+	iterator, err := store.Query("type:photo")
+	require.NoError(t, err)
+
+	ok, err := iterator.Next()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	value, err = iterator.Value()
+	require.NoError(t, err)
+	require.Equal(t, attachment, value)
+	require.NoError(t, iterator.Close())
+
+	// Smaller values are stored in their document again.
+	require.NoError(t, store.Put("photo", []byte("small")))
+
+	value, err = store.Get("photo")
+	require.NoError(t, err)
+	require.Equal(t, []byte("small"), value)
+}
+
+func TestRESTProvider_ChunkedValueUpdates(t *testing.T) {
+	for name, options := range map[string][]edv.Option{
+		"Standard endpoints": {edv.WithChunkSize(16)},
+		"Batch extension":    {edv.WithChunkSize(16), edv.WithBatchEndpointExtension()},
+	} {
+		options := options
+
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+
+			server := mock.NewServer(mock.WithAuthorizer(func(req *http.Request, _, _ string) error {
+				requests = append(requests, req.Method+" "+strings.TrimPrefix(req.URL.Path, mock.BasePath))
+
+				return nil
+			}))
+			defer server.Close()
+
+			client := edv.NewClient(server.EDVServerURL())
+			vaultID := createVault(t, client)
+
+			store, err := edv.NewRESTProvider(server.EDVServerURL(), vaultID, createValidEncryptedFormatter(t),
+				options...).OpenStore("attachments")
+			require.NoError(t, err)
+
+			require.NoError(t, store.Put("photo", bytes.Repeat([]byte("attachment"), 10)))
+
+			// The document is created before its chunks.
+			var documentPath string
+
+			for i, request := range requests {
+				if strings.Contains(request, "/chunks/") {
+					require.Equal(t, len(requests)-7, i)
+
+					documentPath = request[len("POST "):strings.Index(request, "/chunks/")]
+
+					break
+				}
+			}
+
+			docID := documentPath[strings.LastIndex(documentPath, "/")+1:]
+
+			chunk, err := client.ReadChunk(vaultID, docID, 0)
+			require.NoError(t, err)
+			require.Equal(t, 0, chunk.Sequence)
+
+			requests = nil
+
+			require.NoError(t, store.Put("photo", bytes.Repeat([]byte("photo"), 10)))
+
+			// The chunks of a stored document are written with the next sequence before the document, and the
+			// chunks which are no longer used are deleted.
+			var chunkWrites, chunkDeletes []string
+
+			for i, request := range requests {
+				switch {
+				case strings.HasPrefix(request, "POST "+documentPath+"/chunks/"):
+					chunkWrites = append(chunkWrites, request)
+				case strings.HasPrefix(request, "DELETE "+documentPath+"/chunks/"):
+					chunkDeletes = append(chunkDeletes, request)
+				case request == "POST "+documentPath || strings.HasSuffix(request, "/batch"):
+					require.Len(t, chunkWrites, 4, "document saved before its chunks")
+					require.Empty(t, chunkDeletes)
+					require.Equal(t, len(requests)-4, i)
+				}
+			}
+
+			require.Len(t, chunkWrites, 4)
+			require.Equal(t, []string{
+				"DELETE " + documentPath + "/chunks/4", "DELETE " + documentPath + "/chunks/5",
+				"DELETE " + documentPath + "/chunks/6",
+			}, chunkDeletes)
+
+			chunk, err = client.ReadChunk(vaultID, docID, 0)
+			require.NoError(t, err)
+			require.Equal(t, 1, chunk.Sequence)
+
+			value, err := store.Get("photo")
+			require.NoError(t, err)
+			require.Equal(t, bytes.Repeat([]byte("photo"), 10), value)
+
+			// All the chunks are deleted once the value is stored in the document again.
+			require.NoError(t, store.Put("photo", []byte("small")))
+
+			_, err = client.ReadChunk(vaultID, docID, 0)
+			require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+			require.True(t, errors.Is(client.DeleteChunk(vaultID, docID, 0), spi.ErrDataNotFound))
+		})
+	}
+}
+
+func TestZCAPInvocations(t *testing.T) {
+	alice, bob, carol := newZCAPAgent(t), newZCAPAgent(t), newZCAPAgent(t)
+
+	root := zcapld.NewRootCapability("https://vaults.example.com", alice.did)
+	verif := zcapld.NewVerifier(zcapld.SimpleCapabilityResolver{root.ID: root}, zcapKeyResolver{alice, bob, carol})
+
+	var invocations []*zcapld.Invocation
+
+	server := mock.NewServer(mock.WithAuthorizer(func(req *http.Request, _, action string) error {
+		invocation, err := verif.VerifyInvocation(req)
+		if err != nil {
+			return err
+		}
+
+		if invocation.Action != action {
+			return fmt.Errorf("action %s was invoked instead of %s", invocation.Action, action)
+		}
+
+		invocations = append(invocations, invocation)
+
+		return nil
+	}))
+	defer server.Close()
+
+	vaultID := createVault(t, edv.NewClient(server.EDVServerURL(),
+		edv.WithClientCapabilityInvoker(zcapld.NewInvoker(root, alice.keyID, alice.signer))))
+
+	toBob, err := zcapld.Delegate(root, alice.proofContext(), zcapld.WithController(bob.did))
+	require.NoError(t, err)
+
+	toCarol, err := zcapld.Delegate(toBob, bob.proofContext(), zcapld.WithInvoker(carol.did),
+		zcapld.WithAllowedActions(edv.ActionRead))
+	require.NoError(t, err)
+
+	formatter := createValidEncryptedFormatter(t)
+
+	openStore := func(invoker edv.CapabilityInvoker) spi.Store {
+		provider := edv.NewRESTProvider(server.EDVServerURL(), vaultID, formatter,
+			edv.WithCapabilityInvoker(invoker))
+
+		store, errOpen := provider.OpenStore("store")
+		require.NoError(t, errOpen)
+
+		return store
+	}
+
+	require.NoError(t, openStore(zcapld.NewInvoker(toBob, bob.keyID, bob.signer)).Put("key", []byte("value")))
+
+	carolStore := openStore(zcapld.NewInvoker(toCarol, carol.keyID, carol.signer))
+
+	value, err := carolStore.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	invocation := invocations[len(invocations)-1]
+	require.Equal(t, toCarol.ID, invocation.Capability.ID)
+	require.Equal(t, root.ID, invocation.Root.ID)
+	require.Equal(t, edv.ActionRead, invocation.Action)
+	require.Equal(t, carol.keyID, invocation.KeyID)
+
+	err = carolStore.Put("key", []byte("other value"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status code 401")
+
+	t.Run("Unauthorized requests are rejected", func(t *testing.T) {
+		for name, invoker := range map[string]edv.CapabilityInvoker{
+			"Not an invoker": zcapld.NewInvoker(toBob, carol.keyID, carol.signer),
+			"Wrong key":      zcapld.NewInvoker(toBob, bob.keyID, carol.signer),
+			"Expired": zcapld.NewInvoker(toCarol, carol.keyID, carol.signer,
+				zcapld.WithInvocationExpiry(-time.Minute)),
+			"Untrusted root": zcapld.NewInvoker(zcapld.NewRootCapability("https://vaults.example.com", bob.did),
+				bob.keyID, bob.signer),
+		} {
+			_, err = edv.NewClient(server.EDVServerURL(), edv.WithClientCapabilityInvoker(invoker)).
+				ReadDocument(vaultID, "doc1")
+			require.Contains(t, err.Error(), "status code 401", name)
+		}
+
+		_, err = edv.NewClient(server.EDVServerURL()).ReadDocument(vaultID, "doc1")
+		require.Contains(t, err.Error(), "status code 401")
+	})
+	t.Run("Tampered body", func(t *testing.T) {
+		newRequest := func(body string) *http.Request {
+			req, errRequest := http.NewRequest(http.MethodPost, server.EDVServerURL()+"/"+vaultID+"/documents",
+				strings.NewReader(body))
+			require.NoError(t, errRequest)
+
+			req.Header.Set("Content-Type", "application/json")
+
+			return req
+		}
+
+		req := newRequest(`{"id":"doc1"}`)
+		require.NoError(t, zcapld.NewInvoker(toBob, bob.keyID, bob.signer).InvokeCapability(req, edv.ActionWrite))
+
+		_, err = verif.VerifyInvocation(req)
+		require.NoError(t, err)
+
+		for _, body := range []string{`{"id":"doc2"}`, ""} {
+			tampered := newRequest(body)
+			tampered.Header = req.Header.Clone()
+
+			_, err = verif.VerifyInvocation(tampered)
+			require.True(t, errors.Is(err, zcapld.ErrUnauthorized))
+			require.Contains(t, err.Error(), "the body does not match the signed digest")
+		}
+	})
+}
+
+func createVault(t *testing.T, client *edv.Client) string {
+	t.Helper()
+
+	location, err := client.CreateVault(&edv.VaultConfiguration{Controller: "did:example:123"})
+	require.NoError(t, err)
+
+	return getVaultIDFromURL(location)
+}
+
+func newDocument(id string, attributes ...edv.IndexedAttribute) *edv.EncryptedDocument {
+	return &edv.EncryptedDocument{
+		ID: id,
+		IndexedAttributeCollections: []edv.IndexedAttributeCollection{{
+			HMAC:              edv.IDTypePair{ID: "https://example.com/kms/67891", Type: "Sha256HmacKey2019"},
+			IndexedAttributes: attributes,
+		}},
+		JWE: []byte(`{"ciphertext":"..."}`),
+	}
+}
+
+type zcapAgent struct {
+	did    string
+	keyID  string
+	pubKey ed25519.PublicKey
+	signer ed25519Signer
+}
+
+func newZCAPAgent(t *testing.T) *zcapAgent {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	did, keyID := fingerprint.CreateDIDKey(pubKey)
+
+	return &zcapAgent{did: did, keyID: keyID, pubKey: pubKey, signer: ed25519Signer(privKey)}
+}
+
+func (a *zcapAgent) proofContext() *zcapld.ProofContext {
+	return &zcapld.ProofContext{
+		SignatureType:      ed25519signature2018.SignatureType,
+		Suite:              ed25519signature2018.New(suite.WithSigner(a.signer)),
+		VerificationMethod: a.keyID,
+	}
+}
+
+type zcapKeyResolver []*zcapAgent
+
+func (r zcapKeyResolver) Resolve(keyID string) (*verifier.PublicKey, error) {
+	for _, a := range r {
+		if a.keyID == keyID {
+			return &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: a.pubKey}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key %s not found", keyID)
+}
+
+type ed25519Signer ed25519.PrivateKey
+
+func (s ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(ed25519.PrivateKey(s), data), nil
+}
@@ -53,7 +53,7 @@ func (e *EncryptedFormatter) Deformat(_ string, formattedValue []byte, _ ...spi.
 			"in order to return the deformatted key and tags")
 	}
 
-	structuredDocument, err := e.getStructuredDocFromEncryptedDoc(formattedValue)
+	_, structuredDocument, err := e.getStructuredDocFromEncryptedDoc(formattedValue)
 	if err != nil {
 		return "", nil, nil, fmt.Errorf("failed to get structured document from encrypted document bytes: %w", err)
 	}
@@ -64,6 +64,17 @@ func (e *EncryptedFormatter) Deformat(_ string, formattedValue []byte, _ ...spi.
 
 func (e *EncryptedFormatter) format(keyAndTagPrefix, key string, value []byte, tags ...spi.Tag) (string, []byte,
 	[]spi.Tag, error) {
+	return e.formatDocument(keyAndTagPrefix, key, value, nil, tags...)
+}
+
+// formatStream is like format, but the value is stored in the given data stream of the document.
+func (e *EncryptedFormatter) formatStream(keyAndTagPrefix, key string, stream *Stream, tags ...spi.Tag) (string,
+	[]byte, []spi.Tag, error) {
+	return e.formatDocument(keyAndTagPrefix, key, nil, stream, tags...)
+}
+
+func (e *EncryptedFormatter) formatDocument(keyAndTagPrefix, key string, value []byte, stream *Stream,
+	tags ...spi.Tag) (string, []byte, []spi.Tag, error) {
 	var formattedKey string
 
 	if key != "" {
@@ -80,7 +91,7 @@ func (e *EncryptedFormatter) format(keyAndTagPrefix, key string, value []byte, t
 		return "", nil, nil, fmt.Errorf("failed to format tags: %w", err)
 	}
 
-	formattedValue, err := e.formatValue(key, formattedKey, value, tags, formattedTags)
+	formattedValue, err := e.formatValue(key, formattedKey, value, stream, tags, formattedTags)
 	if err != nil {
 		return "", nil, nil, fmt.Errorf("failed to format value: %w", err)
 	}
@@ -88,34 +99,36 @@ func (e *EncryptedFormatter) format(keyAndTagPrefix, key string, value []byte, t
 	return formattedKey, formattedValue, formattedTags, nil
 }
 
+// getStructuredDocFromEncryptedDoc returns the ID of the encrypted document along with its decrypted structured
+// document.
 func (e *EncryptedFormatter) getStructuredDocFromEncryptedDoc(
-	encryptedDocBytes []byte) (structuredDocument, error) {
-	var encryptedDocument encryptedDocument
+	encryptedDocBytes []byte) (string, structuredDocument, error) {
+	var encryptedDocument EncryptedDocument
 
 	err := json.Unmarshal(encryptedDocBytes, &encryptedDocument)
 	if err != nil {
-		return structuredDocument{},
+		return "", structuredDocument{},
 			fmt.Errorf("failed to unmarshal value into an encrypted document: %w", err)
 	}
 
 	encryptedJWE, err := jose.Deserialize(string(encryptedDocument.JWE))
 	if err != nil {
-		return structuredDocument{}, fmt.Errorf("failed to deserialize JWE: %w", err)
+		return "", structuredDocument{}, fmt.Errorf("failed to deserialize JWE: %w", err)
 	}
 
 	structuredDocumentBytes, err := e.jweDecrypter.Decrypt(encryptedJWE)
 	if err != nil {
-		return structuredDocument{}, fmt.Errorf("failed to decrypt JWE: %w", err)
+		return "", structuredDocument{}, fmt.Errorf("failed to decrypt JWE: %w", err)
 	}
 
 	var structuredDoc structuredDocument
 
 	err = json.Unmarshal(structuredDocumentBytes, &structuredDoc)
 	if err != nil {
-		return structuredDocument{}, fmt.Errorf("failed to unmarshal structured document: %w", err)
+		return "", structuredDocument{}, fmt.Errorf("failed to unmarshal structured document: %w", err)
 	}
 
-	return structuredDoc, nil
+	return encryptedDocument.ID, structuredDoc, nil
 }
 
 // TODO (#2376) Revisit how we're generating EDV document IDs, since it's technically not 100% in line with the spec.
@@ -163,14 +176,15 @@ func (e *EncryptedFormatter) formatTags(tagPrefix string, tags []spi.Tag) ([]spi
 	return formattedTags, nil
 }
 
-func (e *EncryptedFormatter) formatValue(key, formattedKey string, value []byte,
+func (e *EncryptedFormatter) formatValue(key, formattedKey string, value []byte, stream *Stream,
 	tags, formattedTags []spi.Tag) ([]byte, error) {
 	var formattedValue []byte
 
-	if value != nil {
+	if value != nil || stream != nil {
 		// Since the formatted key and tags are hashes and can't be reversed, the only way we can retrieve the
 		// unformatted key and tags later is to embed them in the structured document.
 		structuredDoc := createStructuredDocument(key, value, tags)
+		structuredDoc.Stream = stream
 
 		structuredDocumentBytes, err := json.Marshal(structuredDoc)
 		if err != nil {
@@ -189,7 +203,7 @@ func (e *EncryptedFormatter) formatValue(key, formattedKey string, value []byte,
 
 		indexedAttributeCollections := e.convertToIndexedAttributeCollection(formattedTags)
 
-		encryptedDoc := encryptedDocument{
+		encryptedDoc := EncryptedDocument{
 			ID:                          formattedKey,
 			IndexedAttributeCollections: indexedAttributeCollections,
 			JWE:                         []byte(serializedJWE),
@@ -225,20 +239,20 @@ func createStructuredDocument(key string, value []byte, tags []spi.Tag) structur
 }
 
 func (e *EncryptedFormatter) convertToIndexedAttributeCollection(
-	formattedTags []spi.Tag) []indexedAttributeCollection {
-	indexedAttributes := make([]indexedAttribute, len(formattedTags))
+	formattedTags []spi.Tag) []IndexedAttributeCollection {
+	indexedAttributes := make([]IndexedAttribute, len(formattedTags))
 
 	for i, formattedTag := range formattedTags {
-		indexedAttributes[i] = indexedAttribute{
+		indexedAttributes[i] = IndexedAttribute{
 			Name:  formattedTag.Name,
 			Value: formattedTag.Value,
 		}
 	}
 
-	indexedAttrCollection := indexedAttributeCollection{
-		HMAC:              idTypePair{},
+	indexedAttrCollection := IndexedAttributeCollection{
+		HMAC:              IDTypePair{},
 		IndexedAttributes: indexedAttributes,
 	}
 
-	return []indexedAttributeCollection{indexedAttrCollection}
+	return []IndexedAttributeCollection{indexedAttrCollection}
 }
@@ -12,8 +12,15 @@ require (
 	github.com/google/tink/go v1.5.0
 	github.com/google/uuid v1.1.2
 	github.com/hyperledger/aries-framework-go v0.1.6-0.20210226235232-298aa129d822
-	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20210226235232-298aa129d822
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210226235232-298aa129d822
+	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20261019133114-510320220604
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261019084901-66f4e01095f2
 	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20210226235232-298aa129d822
 	github.com/stretchr/testify v1.7.0
 )
+
+replace (
+	github.com/hyperledger/aries-framework-go => ../../..
+	github.com/hyperledger/aries-framework-go/component/storageutil => ../../storageutil
+	github.com/hyperledger/aries-framework-go/spi => ../../../spi
+	github.com/hyperledger/aries-framework-go/test/component => ../../../test/component
+)
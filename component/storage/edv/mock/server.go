@@ -0,0 +1,575 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package mock provides an in-process EDV server, implementing the data vault HTTP API
+// (https://identity.foundation/confidential-storage/#http-api) along with the TrustBloc batch and "return full
+// documents on query" extensions, so that EDV clients can be tested without a real server.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/edv"
+)
+
+// BasePath is the path of the EDV endpoints in the server.
+const BasePath = "/encrypted-data-vaults"
+
+// Authorizer authorizes a request to the vault with the given ID (empty when creating a vault) for the given
+// action (edv.ActionRead or edv.ActionWrite). An error rejects the request with a 401 status.
+type Authorizer func(req *http.Request, vaultID, action string) error
+
+// Option configures a Server.
+type Option func(server *Server)
+
+// WithAuthorizer option makes the server authorize every request with authorize, for instance by verifying its
+// ZCAP-LD capability invocation with zcapld.Verifier.
+func WithAuthorizer(authorize Authorizer) Option {
+	return func(server *Server) {
+		server.authorize = authorize
+	}
+}
+
+// Server is an in-process EDV server keeping its vaults in memory.
+type Server struct {
+	*httptest.Server
+	authorize Authorizer
+	vaults    map[string]*vault
+	lock      sync.RWMutex
+}
+
+type vault struct {
+	config    edv.VaultConfiguration
+	documents map[string]edv.EncryptedDocument
+	chunks    map[string]map[int]json.RawMessage
+}
+
+// NewServer starts a new Server, which must be closed when no longer needed.
+func NewServer(options ...Option) *Server {
+	server := &Server{vaults: make(map[string]*vault)}
+
+	for _, option := range options {
+		option(server)
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	return server
+}
+
+// EDVServerURL returns the base URL of the EDV endpoints, to be given to edv.NewClient and edv.NewRESTProvider.
+func (s *Server) EDVServerURL() string {
+	return s.URL + BasePath
+}
+
+func (s *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !strings.HasPrefix(req.URL.Path, BasePath) {
+		http.NotFound(rw, req)
+
+		return
+	}
+
+	var segments []string
+
+	for _, segment := range strings.Split(strings.TrimPrefix(req.URL.Path, BasePath), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	var vaultID string
+
+	if len(segments) > 0 {
+		vaultID = segments[0]
+	}
+
+	action := edv.ActionWrite
+	if req.Method == http.MethodGet || len(segments) == 2 && segments[1] == "query" {
+		action = edv.ActionRead
+	}
+
+	if s.authorize != nil {
+		if err := s.authorize(req, vaultID, action); err != nil {
+			http.Error(rw, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	s.route(rw, req.Method, segments, body)
+}
+
+func (s *Server) route(rw http.ResponseWriter, method string, segments []string, body []byte) {
+	switch {
+	case len(segments) == 0 && method == http.MethodPost:
+		s.createVault(rw, body)
+	case len(segments) == 1 && method == http.MethodGet:
+		s.withVault(rw, segments[0], func(v *vault) {
+			writeJSON(rw, http.StatusOK, v.config)
+		})
+	case len(segments) == 2 && segments[1] == "documents" && method == http.MethodPost:
+		s.createDocument(rw, segments[0], body)
+	case len(segments) == 2 && segments[1] == "query" && method == http.MethodPost:
+		s.query(rw, segments[0], body)
+	case len(segments) == 2 && segments[1] == "batch" && method == http.MethodPost:
+		s.batch(rw, segments[0], body)
+	case len(segments) == 3 && segments[1] == "documents":
+		s.document(rw, method, segments[0], segments[2], body)
+	case len(segments) == 5 && segments[1] == "documents" && segments[3] == "chunks":
+		index, err := strconv.Atoi(segments[4])
+		if err != nil {
+			http.Error(rw, "invalid chunk index", http.StatusBadRequest)
+
+			return
+		}
+
+		s.chunk(rw, method, segments[0], segments[2], index, body)
+	default:
+		http.Error(rw, "unsupported endpoint", http.StatusNotFound)
+	}
+}
+
+func (s *Server) createVault(rw http.ResponseWriter, body []byte) {
+	var config edv.VaultConfiguration
+
+	if err := json.Unmarshal(body, &config); err != nil {
+		http.Error(rw, fmt.Sprintf("invalid vault configuration: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	if config.Controller == "" {
+		http.Error(rw, "a controller is required", http.StatusBadRequest)
+
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, v := range s.vaults {
+		if config.ReferenceID != "" && v.config.ReferenceID == config.ReferenceID {
+			http.Error(rw, "a vault with this reference ID already exists", http.StatusConflict)
+
+			return
+		}
+	}
+
+	config.ID = uuid.New().String()
+
+	s.vaults[config.ID] = &vault{
+		config:    config,
+		documents: make(map[string]edv.EncryptedDocument),
+		chunks:    make(map[string]map[int]json.RawMessage),
+	}
+
+	rw.Header().Set("Location", s.EDVServerURL()+"/"+config.ID)
+	rw.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) createDocument(rw http.ResponseWriter, vaultID string, body []byte) {
+	document, ok := parseDocument(rw, body)
+	if !ok {
+		return
+	}
+
+	s.withVault(rw, vaultID, func(v *vault) {
+		if _, exists := v.documents[document.ID]; exists {
+			http.Error(rw, "a document with this ID already exists", http.StatusConflict)
+
+			return
+		}
+
+		if v.upsert(rw, document) {
+			rw.Header().Set("Location", s.documentLocation(vaultID, document.ID))
+			rw.WriteHeader(http.StatusCreated)
+		}
+	})
+}
+
+func (s *Server) document(rw http.ResponseWriter, method, vaultID, docID string, body []byte) {
+	s.withVault(rw, vaultID, func(v *vault) {
+		document, exists := v.documents[docID]
+		if !exists {
+			http.Error(rw, "document not found", http.StatusNotFound)
+
+			return
+		}
+
+		switch method {
+		case http.MethodGet:
+			writeJSON(rw, http.StatusOK, document)
+		case http.MethodPost:
+			updated, ok := parseDocument(rw, body)
+			if !ok {
+				return
+			}
+
+			if updated.ID != docID {
+				http.Error(rw, "the document ID does not match the endpoint", http.StatusBadRequest)
+
+				return
+			}
+
+			if v.upsert(rw, updated) {
+				rw.WriteHeader(http.StatusOK)
+			}
+		case http.MethodDelete:
+			v.delete(docID)
+			rw.WriteHeader(http.StatusOK)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (s *Server) chunk(rw http.ResponseWriter, method, vaultID, docID string, index int, body []byte) {
+	s.withVault(rw, vaultID, func(v *vault) {
+		if _, exists := v.documents[docID]; !exists {
+			http.Error(rw, "document not found", http.StatusNotFound)
+
+			return
+		}
+
+		switch method {
+		case http.MethodGet:
+			chunk, exists := v.chunks[docID][index]
+			if !exists {
+				http.Error(rw, "chunk not found", http.StatusNotFound)
+
+				return
+			}
+
+			rw.Header().Set("Content-Type", "application/json")
+			_, _ = rw.Write(chunk) //nolint:errcheck // Nothing more can be done.
+		case http.MethodPost:
+			var chunk edv.EncryptedChunk
+
+			if err := json.Unmarshal(body, &chunk); err != nil || chunk.Index != index {
+				http.Error(rw, "invalid chunk", http.StatusBadRequest)
+
+				return
+			}
+
+			if v.chunks[docID] == nil {
+				v.chunks[docID] = make(map[int]json.RawMessage)
+			}
+
+			v.chunks[docID][index] = body
+
+			rw.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			if _, exists := v.chunks[docID][index]; !exists {
+				http.Error(rw, "chunk not found", http.StatusNotFound)
+
+				return
+			}
+
+			delete(v.chunks[docID], index)
+
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (s *Server) batch(rw http.ResponseWriter, vaultID string, body []byte) {
+	var batch edv.Batch
+
+	if err := json.Unmarshal(body, &batch); err != nil {
+		http.Error(rw, fmt.Sprintf("invalid batch: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	s.withVault(rw, vaultID, func(v *vault) {
+		responses := make([]string, len(batch))
+
+		for i, operation := range batch {
+			switch operation.Operation {
+			case edv.UpsertDocumentVaultOperation:
+				document, ok := parseDocument(rw, operation.EncryptedDocument)
+				if !ok || !v.upsert(rw, document) {
+					return
+				}
+
+				responses[i] = s.documentLocation(vaultID, document.ID)
+			case edv.DeleteDocumentVaultOperation:
+				v.delete(operation.DocumentID)
+			default:
+				http.Error(rw, "unsupported batch operation "+operation.Operation, http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		writeJSON(rw, http.StatusOK, responses)
+	})
+}
+
+// query supports both the query format of the specification and the simplified format with a single index name
+// and value (or "has" name), sent as strings.
+func (s *Server) query(rw http.ResponseWriter, vaultID string, body []byte) {
+	var q struct {
+		Index               string          `json:"index"`
+		Equals              json.RawMessage `json:"equals"`
+		Has                 json.RawMessage `json:"has"`
+		ReturnFullDocuments bool            `json:"returnFullDocuments"`
+	}
+
+	if err := json.Unmarshal(body, &q); err != nil {
+		http.Error(rw, fmt.Sprintf("invalid query: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	match, err := newMatcher(q.Index, q.Equals, q.Has)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("invalid query: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	s.withVault(rw, vaultID, func(v *vault) {
+		var ids []string
+
+		for id, document := range v.documents {
+			if match(document) {
+				ids = append(ids, id)
+			}
+		}
+
+		sort.Strings(ids)
+
+		if q.ReturnFullDocuments {
+			documents := make([]edv.EncryptedDocument, len(ids))
+
+			for i, id := range ids {
+				documents[i] = v.documents[id]
+			}
+
+			writeJSON(rw, http.StatusOK, documents)
+
+			return
+		}
+
+		locations := make([]string, len(ids))
+
+		for i, id := range ids {
+			locations[i] = s.documentLocation(vaultID, id)
+		}
+
+		writeJSON(rw, http.StatusOK, locations)
+	})
+}
+
+func (s *Server) withVault(rw http.ResponseWriter, vaultID string, f func(v *vault)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.vaults[vaultID]
+	if !ok {
+		http.Error(rw, "vault not found", http.StatusNotFound)
+
+		return
+	}
+
+	f(v)
+}
+
+func (s *Server) documentLocation(vaultID, docID string) string {
+	return fmt.Sprintf("%s/%s/documents/%s", s.EDVServerURL(), vaultID, docID)
+}
+
+// upsert stores the document unless one of its unique attributes is already used by another document, in which
+// case a 409 status is written and false is returned.
+func (v *vault) upsert(rw http.ResponseWriter, document edv.EncryptedDocument) bool {
+	for _, collection := range document.IndexedAttributeCollections {
+		for _, attribute := range collection.IndexedAttributes {
+			if attribute.Unique && v.isUsed(document.ID, attribute) {
+				http.Error(rw, "the unique attribute "+attribute.Name+" is already used", http.StatusConflict)
+
+				return false
+			}
+		}
+	}
+
+	v.documents[document.ID] = document
+
+	return true
+}
+
+func (v *vault) isUsed(docID string, attribute edv.IndexedAttribute) bool {
+	for id, document := range v.documents {
+		if id == docID {
+			continue
+		}
+
+		for _, collection := range document.IndexedAttributeCollections {
+			for _, other := range collection.IndexedAttributes {
+				if other.Name == attribute.Name && other.Value == attribute.Value {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func (v *vault) delete(docID string) {
+	delete(v.documents, docID)
+	delete(v.chunks, docID)
+}
+
+func newMatcher(index string, equalsJSON, hasJSON json.RawMessage) (func(edv.EncryptedDocument) bool, error) {
+	var name, value string
+
+	// Simplified format.
+	if json.Unmarshal(equalsJSON, &value) == nil {
+		return matchAny(func(attributes map[string]string) bool {
+			v, ok := attributes[index]
+
+			return ok && v == value
+		}), nil
+	}
+
+	if json.Unmarshal(hasJSON, &name) == nil {
+		return matchAny(func(attributes map[string]string) bool {
+			_, ok := attributes[name]
+
+			return ok
+		}), nil
+	}
+
+	var (
+		equals []map[string]string
+		has    []string
+	)
+
+	if len(equalsJSON) > 0 {
+		if err := json.Unmarshal(equalsJSON, &equals); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(hasJSON) > 0 {
+		if err := json.Unmarshal(hasJSON, &has); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(equals) == 0 && len(has) == 0 {
+		return nil, fmt.Errorf("equals or has is required")
+	}
+
+	return func(document edv.EncryptedDocument) bool {
+		for _, collection := range document.IndexedAttributeCollections {
+			if index != "" && collection.HMAC.ID != index {
+				continue
+			}
+
+			attributes := attributeMap(collection)
+
+			if len(has) > 0 && hasAll(attributes, has) {
+				return true
+			}
+
+			for _, equal := range equals {
+				if equalsAll(attributes, equal) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}, nil
+}
+
+func matchAny(match func(attributes map[string]string) bool) func(edv.EncryptedDocument) bool {
+	return func(document edv.EncryptedDocument) bool {
+		for _, collection := range document.IndexedAttributeCollections {
+			if match(attributeMap(collection)) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func attributeMap(collection edv.IndexedAttributeCollection) map[string]string {
+	attributes := make(map[string]string, len(collection.IndexedAttributes))
+
+	for _, attribute := range collection.IndexedAttributes {
+		attributes[attribute.Name] = attribute.Value
+	}
+
+	return attributes
+}
+
+func hasAll(attributes map[string]string, names []string) bool {
+	for _, name := range names {
+		if _, ok := attributes[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalsAll(attributes, expected map[string]string) bool {
+	for name, value := range expected {
+		if v, ok := attributes[name]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseDocument(rw http.ResponseWriter, body []byte) (edv.EncryptedDocument, bool) {
+	var document edv.EncryptedDocument
+
+	if err := json.Unmarshal(body, &document); err != nil || document.ID == "" {
+		http.Error(rw, "invalid encrypted document", http.StatusBadRequest)
+
+		return edv.EncryptedDocument{}, false
+	}
+
+	return document, true
+}
+
+func writeJSON(rw http.ResponseWriter, status int, v interface{}) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_, _ = rw.Write(bytes) //nolint:errcheck // Nothing more can be done.
+}
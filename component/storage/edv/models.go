@@ -12,12 +12,26 @@ import (
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+// VaultConfiguration represents a Data Vault Configuration as defined in
+// https://identity.foundation/confidential-storage/#datavaultconfiguration.
+type VaultConfiguration struct {
+	ID          string     `json:"id,omitempty"`
+	Sequence    int        `json:"sequence"`
+	Controller  string     `json:"controller"`
+	Invoker     string     `json:"invoker,omitempty"`
+	Delegator   string     `json:"delegator,omitempty"`
+	ReferenceID string     `json:"referenceId,omitempty"`
+	KEK         IDTypePair `json:"kek"`
+	HMAC        IDTypePair `json:"hmac"`
+}
+
 // structuredDocument represents a Structured Document for use with Aries. It's compatible with the model
 // defined in https://identity.foundation/confidential-storage/#structureddocument.
 type structuredDocument struct {
 	ID      string                 `json:"id"`
 	Meta    map[string]interface{} `json:"meta"`
 	Content content                `json:"content"`
+	Stream  *Stream                `json:"stream,omitempty"`
 }
 
 type content struct {
@@ -26,39 +40,49 @@ type content struct {
 	UnformattedTags  []spi.Tag `json:"unformattedTags"`
 }
 
-// encryptedDocument represents an Encrypted Document as defined in
+// EncryptedDocument represents an Encrypted Document as defined in
 // https://identity.foundation/confidential-storage/#encrypteddocument.
-type encryptedDocument struct {
+type EncryptedDocument struct {
 	ID                          string                       `json:"id"`
 	Sequence                    int                          `json:"sequence"`
-	IndexedAttributeCollections []indexedAttributeCollection `json:"indexed,omitempty"`
+	IndexedAttributeCollections []IndexedAttributeCollection `json:"indexed,omitempty"`
 	JWE                         json.RawMessage              `json:"jwe"`
 }
 
-// indexedAttributeCollection represents a collection of indexed attributes,
+// IndexedAttributeCollection represents a collection of indexed attributes,
 // all of which share a common MAC algorithm and key.
 // This format is based on https://identity.foundation/confidential-storage/#creating-encrypted-indexes.
-type indexedAttributeCollection struct {
+type IndexedAttributeCollection struct {
 	Sequence          int                `json:"sequence"`
-	HMAC              idTypePair         `json:"hmac"`
-	IndexedAttributes []indexedAttribute `json:"attributes"`
+	HMAC              IDTypePair         `json:"hmac"`
+	IndexedAttributes []IndexedAttribute `json:"attributes"`
 }
 
-// indexedAttribute represents a single indexed attribute.
-type indexedAttribute struct {
+// IndexedAttribute represents a single indexed attribute.
+type IndexedAttribute struct {
 	Name   string `json:"name"`
 	Value  string `json:"value"`
 	Unique bool   `json:"unique"`
 }
 
-// idTypePair represents an ID+Type pair.
-// TODO: #2262 This is a simplified version of the actual EDV query format, which is still not finalized
-//  in the spec as of writing. See: https://github.com/decentralized-identity/confidential-storage/issues/34.
-type idTypePair struct {
+// IDTypePair represents an ID+Type pair.
+type IDTypePair struct {
 	ID   string `json:"id"`
 	Type string `json:"type"`
 }
 
+// Query represents a query of the encrypted indexes as defined in
+// https://identity.foundation/confidential-storage/#searching-encrypted-documents. Index is the ID of the HMAC key of
+// the indexed attribute collections to search, or empty to search all of them. A document matches the query if all
+// the attributes of any of the Equals maps match, or if it has all the attributes in Has.
+// ReturnFullDocuments is currently non-standard and should only be used with an EDV server that supports it.
+type Query struct {
+	Index               string              `json:"index"`
+	Equals              []map[string]string `json:"equals,omitempty"`
+	Has                 []string            `json:"has,omitempty"`
+	ReturnFullDocuments bool                `json:"returnFullDocuments,omitempty"`
+}
+
 // nameAndValueQuery represents a name+value pair that can be used to query the encrypted indices for specific data.
 // TODO: #2262 This is a simplified version of the actual EDV query format, which is still not finalized
 //  in the spec as of writing. See: https://github.com/decentralized-identity/confidential-storage/issues/34.
@@ -77,19 +101,34 @@ type hasQuery struct {
 	Has                 string `json:"has"`
 }
 
-// batch represents a batch of operations to be performed in a vault.
-type batch []vaultOperation
+// Stream describes the encrypted chunks in which the data of a document is stored, as defined in
+// https://identity.foundation/confidential-storage/#streams.
+type Stream struct {
+	Sequence int `json:"sequence"`
+	Chunks   int `json:"chunks"`
+}
+
+// EncryptedChunk represents a chunk of the data stream of a document.
+type EncryptedChunk struct {
+	Sequence int             `json:"sequence"`
+	Index    int             `json:"index"`
+	Offset   int64           `json:"offset"`
+	JWE      json.RawMessage `json:"jwe"`
+}
+
+// Batch represents a batch of operations to be performed in a vault.
+type Batch []VaultOperation
 
 const (
-	// upsertDocumentVaultOperation represents an upsert operation to be performed in a batch.
-	upsertDocumentVaultOperation = "upsert"
-	// deleteDocumentVaultOperation represents a delete operation to be performed in a batch.
-	deleteDocumentVaultOperation = "delete"
+	// UpsertDocumentVaultOperation represents an upsert operation to be performed in a batch.
+	UpsertDocumentVaultOperation = "upsert"
+	// DeleteDocumentVaultOperation represents a delete operation to be performed in a batch.
+	DeleteDocumentVaultOperation = "delete"
 )
 
-// vaultOperation represents an upsert or delete operation to be performed in a vault.
+// VaultOperation represents an upsert or delete operation to be performed in a vault.
 // This is currently non-standard and should only be used with an EDV server that supports it.
-type vaultOperation struct {
+type VaultOperation struct {
 	Operation         string          `json:"operation"`          // Valid values: upsert,delete
 	DocumentID        string          `json:"id,omitempty"`       // Only used if Operation=delete
 	EncryptedDocument json.RawMessage `json:"document,omitempty"` // Only used if Operation=createOrUpdate
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package edv
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -15,19 +16,11 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
-const (
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-)
-
-var (
-	errEmptyKey                     = errors.New("key cannot be empty")
-	errInvalidQueryExpressionFormat = errors.New("invalid expression format. " +
-		"it must be in the following format: TagName:TagValue")
-)
+var errEmptyKey = errors.New("key cannot be empty")
 
 // Option allows for configuration of a RESTProvider.
 type Option func(opts *RESTProvider)
@@ -66,17 +59,46 @@ func WithBatchEndpointExtension() Option {
 	}
 }
 
+// WithCapabilityInvoker option authorizes every request by invoking an authorization capability with invoker,
+// for instance a zcapld.Invoker signing requests with HTTP signatures.
+func WithCapabilityInvoker(invoker CapabilityInvoker) Option {
+	return func(opts *RESTProvider) {
+		opts.restClient.invoker = invoker
+	}
+}
+
+// WithChunkSize option stores the values larger than chunkSize bytes in the data stream of their document, as
+// encrypted chunks of at most chunkSize bytes, instead of in the document itself. This allows for large values
+// exceeding the document size limit of the EDV server.
+func WithChunkSize(chunkSize int) Option {
+	return func(opts *RESTProvider) {
+		opts.chunkSize = chunkSize
+	}
+}
+
+// WithCompoundQueries option is a performance optimization that makes the EDV server match all the equality
+// conditions of each term of a query expression, using the query format defined in
+// https://identity.foundation/confidential-storage/#searching-encrypted-documents, instead of only one of them.
+// The other conditions are still checked after the documents are retrieved.
+func WithCompoundQueries() Option {
+	return func(opts *RESTProvider) {
+		opts.compoundQueries = true
+	}
+}
+
 // RESTProvider is a spi.Provider that can be used to store data in a server supporting the
 // data vault HTTP API as defined in https://identity.foundation/confidential-storage/#http-api.
 type RESTProvider struct {
 	vaultID    string
 	formatter  *EncryptedFormatter
-	restClient *restClient
+	restClient *Client
 	openStores map[string]*restStore
 	lock       sync.RWMutex
 
 	returnFullDocumentsOnQuery    bool
 	batchEndpointExtensionEnabled bool
+	compoundQueries               bool
+	chunkSize                     int
 }
 
 // NewRESTProvider returns a new RESTProvider. edvServerURL is the base URL for the EDV server.
@@ -84,15 +106,10 @@ type RESTProvider struct {
 // the EDV REST API does not provide a method to check if a vault with a given ID exists, any errors due to a
 // non-existent vault will be deferred until calls are actually made to it in the store.
 func NewRESTProvider(edvServerURL, vaultID string, formatter *EncryptedFormatter, options ...Option) *RESTProvider {
-	client := restClient{
-		edvServerURL: edvServerURL,
-		httpClient:   &http.Client{},
-	}
-
 	restProvider := RESTProvider{
 		vaultID:    vaultID,
 		formatter:  formatter,
-		restClient: &client,
+		restClient: NewClient(edvServerURL),
 		openStores: make(map[string]*restStore),
 	}
 
@@ -125,6 +142,8 @@ func (r *RESTProvider) OpenStore(name string) (spi.Store, error) {
 			restClient:                    r.restClient,
 			returnFullDocumentsOnQuery:    r.returnFullDocumentsOnQuery,
 			batchEndpointExtensionEnabled: r.batchEndpointExtensionEnabled,
+			compoundQueries:               r.compoundQueries,
+			chunkSize:                     r.chunkSize,
 			close:                         r.removeStore,
 		}
 		r.openStores[storeName] = newStore
@@ -138,6 +157,8 @@ func (r *RESTProvider) OpenStore(name string) (spi.Store, error) {
 		formatter:                  r.formatter,
 		restClient:                 r.restClient,
 		returnFullDocumentsOnQuery: r.returnFullDocumentsOnQuery,
+		compoundQueries:            r.compoundQueries,
+		chunkSize:                  r.chunkSize,
 	}, nil
 }
 
@@ -203,10 +224,12 @@ type restStore struct {
 	vaultID                       string
 	namespace                     string
 	formatter                     *EncryptedFormatter
-	restClient                    *restClient
+	restClient                    *Client
 	config                        spi.StoreConfiguration
 	returnFullDocumentsOnQuery    bool
 	batchEndpointExtensionEnabled bool
+	compoundQueries               bool
+	chunkSize                     int
 	close                         closer
 }
 
@@ -223,22 +246,25 @@ func (r *restStore) Put(key string, value []byte, tags ...spi.Tag) error {
 	// If the batch endpoint extension is enabled, we can avoid the need to read the document first since the batch
 	// endpoint does upserts instead of explicit create and updates.
 	if r.batchEndpointExtensionEnabled {
-		encryptedDocumentID, encryptedDocumentBytes, _, err :=
-			r.formatter.format(r.namespace, key, value, tags...)
+		entry, err := r.formatEntry(key, value, tags)
 		if err != nil {
 			return fmt.Errorf("failed to generate the encrypted document ID and "+
 				"encrypted document bytes: %w", err)
 		}
 
-		_, err = r.restClient.Batch(r.vaultID, batch{vaultOperation{
-			Operation:         upsertDocumentVaultOperation,
-			DocumentID:        encryptedDocumentID,
-			EncryptedDocument: encryptedDocumentBytes,
-		}})
-		if err != nil {
-			return fmt.Errorf("failed to put data in EDV server via the batch endpoint "+
-				"(is it enabled in the EDV server?): %w", err)
-		}
+		return r.saveEntry(entry, func() error {
+			_, errBatch := r.restClient.Batch(r.vaultID, Batch{VaultOperation{
+				Operation:         UpsertDocumentVaultOperation,
+				DocumentID:        entry.documentID,
+				EncryptedDocument: entry.document,
+			}})
+			if errBatch != nil {
+				return fmt.Errorf("failed to put data in EDV server via the batch endpoint "+
+					"(is it enabled in the EDV server?): %w", errBatch)
+			}
+
+			return nil
+		})
 	}
 
 	var needsUpdate bool
@@ -272,7 +298,7 @@ func (r *restStore) Get(key string) ([]byte, error) {
 		return nil, err
 	}
 
-	_, value, _, err := r.formatter.Deformat("", encryptedDocumentBytes)
+	_, value, _, err := r.deformat(encryptedDocumentBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt encrypted document: %w", err)
 	}
@@ -327,20 +353,49 @@ func (r *restStore) GetBulk(keys ...string) ([][]byte, error) {
 	return values, nil
 }
 
-// EDV doesn't support paging, so it has no use for the paging query option (which is the only one currently).
-func (r *restStore) Query(expression string, _ ...spi.QueryOption) (spi.Iterator, error) {
-	expressionTagName, expressionTagValue, err := parseQueryExpression(expression)
+// EDV servers only support single tag queries, without paging nor sorting. Each term of the expression is queried
+// by one of its equality conditions (or the first tag name), then the deformatted documents are filtered and sorted
+// once they are all retrieved.
+func (r *restStore) Query(expression string, options ...spi.QueryOption) (spi.Iterator, error) {
+	q, err := query.New(expression, options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query expression: %w", err)
 	}
 
-	_, _, tags, err := r.formatter.format(r.namespace, "", nil,
-		spi.Tag{Name: expressionTagName, Value: expressionTagValue})
-	if err != nil {
-		return nil, fmt.Errorf("failed to format tag for querying: %w", err)
+	entries := make(map[string]query.Entry)
+
+	if r.compoundQueries {
+		err = r.compoundQuery(q.Expression, entries)
+		if err != nil {
+			return nil, err
+		}
+
+		return query.NewIterator(q, mapValues(entries)), nil
+	}
+
+	for _, term := range q.Expression {
+		tag := spi.Tag{Name: term[0].TagName}
+
+		for _, condition := range term {
+			if condition.Operator == query.Equal {
+				tag = spi.Tag{Name: condition.TagName, Value: condition.Value}
+
+				break
+			}
+		}
+
+		_, _, tags, err := r.formatter.format(r.namespace, "", nil, tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format tag for querying: %w", err)
+		}
+
+		// the terms may match the same documents.
+		if err = r.query(tags[0], entries); err != nil {
+			return nil, err
+		}
 	}
 
-	return r.query(tags[0])
+	return query.NewIterator(q, mapValues(entries)), nil
 }
 
 func (r *restStore) Delete(key string) error {
@@ -398,27 +453,163 @@ func (r *restStore) Flush() error {
 }
 
 func (r *restStore) saveDataToEDVServer(key string, value []byte, tags []spi.Tag, needsUpdate bool) error {
-	encryptedDocumentID, encryptedDocumentBytes, _, err :=
-		r.formatter.format(r.namespace, key, value, tags...)
+	entry, err := r.formatEntry(key, value, tags)
 	if err != nil {
 		return fmt.Errorf("failed to generate the encrypted document: %w", err)
 	}
 
-	if needsUpdate {
-		err = r.restClient.updateDocument(r.vaultID, encryptedDocumentID, encryptedDocumentBytes)
-		if err != nil {
-			return fmt.Errorf("failed to update existing document in EDV server: %w", err)
+	return r.saveEntry(entry, func() error {
+		if needsUpdate {
+			errUpdate := r.restClient.updateDocument(r.vaultID, entry.documentID, entry.document)
+			if errUpdate != nil {
+				return fmt.Errorf("failed to update existing document in EDV server: %w", errUpdate)
+			}
+
+			return nil
 		}
-	} else {
-		_, err = r.restClient.createDocument(r.vaultID, encryptedDocumentBytes)
+
+		_, errCreate := r.restClient.createDocument(r.vaultID, entry.document)
+		if errCreate != nil {
+			return fmt.Errorf("failed to create document in EDV server: %w", errCreate)
+		}
+
+		return nil
+	})
+}
+
+// formattedEntry is the encrypted document of an entry, along with the data stream of the document if the value is
+// too large to be stored in the document itself.
+type formattedEntry struct {
+	documentID string
+	document   []byte
+	// streamedValue is written in stream, the data stream of the document.
+	streamedValue []byte
+	stream        *Stream
+	// stored tells whether the document is already stored, storedStream is its current data stream.
+	stored       bool
+	storedStream *Stream
+}
+
+// formatEntry returns the formatted entry. The data stream of a document which already has one gets the next
+// sequence, so that the chunks of its previous values can't be passed off as chunks of the new one.
+func (r *restStore) formatEntry(key string, value []byte, tags []spi.Tag) (*formattedEntry, error) {
+	entry := &formattedEntry{}
+
+	var err error
+
+	if r.chunkSize > 0 && value != nil {
+		entry.stored, entry.storedStream, err = r.getStream(key)
 		if err != nil {
-			return fmt.Errorf("failed to create document in EDV server: %w", err)
+			return nil, err
+		}
+	}
+
+	if r.chunkSize > 0 && len(value) > r.chunkSize {
+		entry.streamedValue = value
+		entry.stream = &Stream{Chunks: (len(value) + r.chunkSize - 1) / r.chunkSize}
+
+		if entry.storedStream != nil {
+			entry.stream.Sequence = entry.storedStream.Sequence + 1
+		}
+
+		entry.documentID, entry.document, _, err = r.formatter.formatStream(r.namespace, key, entry.stream, tags...)
+
+		return entry, err
+	}
+
+	entry.documentID, entry.document, _, err = r.formatter.format(r.namespace, key, value, tags...)
+
+	return entry, err
+}
+
+// getStream tells whether the document of the key is stored, and returns its data stream (nil if it has none).
+func (r *restStore) getStream(key string) (bool, *Stream, error) {
+	encryptedDocumentBytes, err := r.get(key)
+	if errors.Is(err, spi.ErrDataNotFound) {
+		return false, nil, nil
+	}
+
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get the data stream of the document: %w", err)
+	}
+
+	_, structuredDoc, err := r.formatter.getStructuredDocFromEncryptedDoc(encryptedDocumentBytes)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get structured document from encrypted document bytes: %w", err)
+	}
+
+	return true, structuredDoc.Stream, nil
+}
+
+// saveEntry saves the document of the entry with saveDocument, along with its data stream. The chunks are written
+// before the document they belong to if it is already stored, so that a failed write doesn't leave it referencing
+// stale chunks; the chunks of a new document can only be written once it is created. The chunks of the previous
+// data stream which are no longer used are deleted last.
+func (r *restStore) saveEntry(entry *formattedEntry, saveDocument func() error) error {
+	return r.saveEntries([]string{entry.documentID}, map[string]*formattedEntry{entry.documentID: entry},
+		saveDocument)
+}
+
+func (r *restStore) writeStream(entry *formattedEntry) error {
+	if entry.streamedValue == nil {
+		return nil
+	}
+
+	_, err := r.restClient.WriteStream(r.vaultID, entry.documentID, entry.stream.Sequence,
+		bytes.NewReader(entry.streamedValue), r.chunkSize, r.formatter.jweEncrypter)
+	if err != nil {
+		return fmt.Errorf("failed to write the data stream of the document: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restStore) deleteUnusedChunks(entry *formattedEntry) error {
+	if entry.storedStream == nil {
+		return nil
+	}
+
+	var used int
+
+	if entry.stream != nil {
+		used = entry.stream.Chunks
+	}
+
+	for index := used; index < entry.storedStream.Chunks; index++ {
+		err := r.restClient.DeleteChunk(r.vaultID, entry.documentID, index)
+		if err != nil && !errors.Is(err, spi.ErrDataNotFound) {
+			return fmt.Errorf("failed to delete chunk %d of the previous data stream of the document: %w", index, err)
 		}
 	}
 
 	return nil
 }
 
+// deformat is like EncryptedFormatter.Deformat, but also reads the value from the data stream of the document if it
+// is stored there.
+func (r *restStore) deformat(encryptedDocumentBytes []byte) (string, []byte, []spi.Tag, error) {
+	encryptedDocumentID, structuredDoc, err := r.formatter.getStructuredDocFromEncryptedDoc(encryptedDocumentBytes)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to get structured document from encrypted document bytes: %w", err)
+	}
+
+	value := structuredDoc.Content.UnformattedValue
+
+	if structuredDoc.Stream != nil {
+		var buf bytes.Buffer
+
+		err = r.restClient.ReadStream(r.vaultID, encryptedDocumentID, structuredDoc.Stream,
+			r.formatter.jweDecrypter, &buf)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to read the data stream of the document: %w", err)
+		}
+
+		value = buf.Bytes()
+	}
+
+	return structuredDoc.Content.UnformattedKey, value, structuredDoc.Content.UnformattedTags, nil
+}
+
 func (r *restStore) get(key string) ([]byte, error) {
 	encryptedDocumentID, _, _, err := r.formatter.format(r.namespace, key, nil)
 	if err != nil {
@@ -434,34 +625,85 @@ func (r *restStore) get(key string) ([]byte, error) {
 }
 
 func (r *restStore) fastBatchUsingBatchExtension(operations []spi.Operation) error {
-	edvBatch := make(batch, len(operations))
+	edvBatch := make(Batch, len(operations))
+	// the last entry of each document is the one whose data stream is saved, as it replaces the previous ones.
+	entries := make(map[string]*formattedEntry)
+
+	var documentIDs []string
 
 	for i, operation := range operations {
 		var edvOperation string
 
 		if operation.Value == nil {
-			edvOperation = deleteDocumentVaultOperation
+			edvOperation = DeleteDocumentVaultOperation
 		} else {
-			edvOperation = upsertDocumentVaultOperation
+			edvOperation = UpsertDocumentVaultOperation
 		}
 
-		encryptedDocumentID, encryptedDocumentBytes, _, err :=
-			r.formatter.format(r.namespace, operation.Key, operation.Value, operation.Tags...)
+		entry, err := r.formatEntry(operation.Key, operation.Value, operation.Tags)
 		if err != nil {
 			return fmt.Errorf("failed to generate the encrypted document ID and encrypted document bytes: %w",
 				err)
 		}
 
-		edvBatch[i] = vaultOperation{
+		if _, ok := entries[entry.documentID]; !ok {
+			documentIDs = append(documentIDs, entry.documentID)
+		}
+
+		if operation.Value == nil {
+			entries[entry.documentID] = nil
+		} else {
+			entries[entry.documentID] = entry
+		}
+
+		edvBatch[i] = VaultOperation{
 			Operation:         edvOperation,
-			DocumentID:        encryptedDocumentID,
-			EncryptedDocument: encryptedDocumentBytes,
+			DocumentID:        entry.documentID,
+			EncryptedDocument: entry.document,
 		}
 	}
 
-	_, err := r.restClient.Batch(r.vaultID, edvBatch)
-	if err != nil {
-		return fmt.Errorf("failure while executing batch operation in EDV server: %w", err)
+	return r.saveEntries(documentIDs, entries, func() error {
+		_, err := r.restClient.Batch(r.vaultID, edvBatch)
+		if err != nil {
+			return fmt.Errorf("failure while executing batch operation in EDV server: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// saveEntries is like saveEntry for the entries of the documents saved with saveDocuments. The entries of the
+// deleted documents are nil.
+func (r *restStore) saveEntries(documentIDs []string, entries map[string]*formattedEntry,
+	saveDocuments func() error) error {
+	for _, documentID := range documentIDs {
+		if entry := entries[documentID]; entry != nil && entry.stored {
+			if err := r.writeStream(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := saveDocuments(); err != nil {
+		return err
+	}
+
+	for _, documentID := range documentIDs {
+		entry := entries[documentID]
+		if entry == nil {
+			continue
+		}
+
+		if !entry.stored {
+			if err := r.writeStream(entry); err != nil {
+				return err
+			}
+		}
+
+		if err := r.deleteUnusedChunks(entry); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -485,131 +727,155 @@ func (r *restStore) slowBatchUsingStandardEndpoints(operations []spi.Operation)
 	return nil
 }
 
-func (r *restStore) query(tag spi.Tag) (spi.Iterator, error) {
+func (r *restStore) query(tag spi.Tag, entries map[string]query.Entry) error {
 	if r.returnFullDocumentsOnQuery {
 		documents, err := r.restClient.queryVaultForFullDocuments(r.vaultID, tag.Name, tag.Value)
 		if err != nil {
-			return nil, fmt.Errorf("failure while querying vault: %w", err)
+			return fmt.Errorf("failure while querying vault: %w", err)
 		}
 
-		allDocumentsBytes := make([][]byte, len(documents))
+		return r.addDocuments(documents, entries)
+	}
+
+	documentURLs, err := r.restClient.queryVault(r.vaultID, tag.Name, tag.Value)
+	if err != nil {
+		return fmt.Errorf("failure while querying EDV server: %w", err)
+	}
+
+	return r.addDocumentsAt(documentURLs, entries)
+}
+
+// compoundQuery queries the documents matching all the equality conditions of a term, or having all its tags if
+// it has none. The whole expression is sent in a single query when all of its terms have equality conditions.
+func (r *restStore) compoundQuery(expression query.Expression, entries map[string]query.Entry) error {
+	var (
+		equals  []map[string]string
+		queries []*Query
+	)
 
-		for i, document := range documents {
-			documentBytes, err := json.Marshal(document)
+	for _, term := range expression {
+		var equalTags, hasTags []spi.Tag
+
+		for _, condition := range term {
+			if condition.Operator == query.Equal {
+				equalTags = append(equalTags, spi.Tag{Name: condition.TagName, Value: condition.Value})
+			} else {
+				hasTags = append(hasTags, spi.Tag{Name: condition.TagName})
+			}
+		}
+
+		if len(equalTags) == 0 {
+			_, _, formattedTags, err := r.formatter.format(r.namespace, "", nil, hasTags...)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal document into bytes: %w", err)
+				return fmt.Errorf("failed to format tags for querying: %w", err)
 			}
 
-			allDocumentsBytes[i] = documentBytes
+			has := make([]string, len(formattedTags))
+
+			for i, formattedTag := range formattedTags {
+				has[i] = formattedTag.Name
+			}
+
+			queries = append(queries, &Query{Has: has})
+
+			continue
 		}
 
-		return &restIterator{
-			vaultID: r.vaultID, restClient: r.restClient, formatter: r.formatter,
-			documents: allDocumentsBytes,
-		}, nil
-	}
+		_, _, formattedTags, err := r.formatter.format(r.namespace, "", nil, equalTags...)
+		if err != nil {
+			return fmt.Errorf("failed to format tags for querying: %w", err)
+		}
 
-	documentURLs, err := r.restClient.queryVault(r.vaultID, tag.Name, tag.Value)
-	if err != nil {
-		return nil, fmt.Errorf("failure while querying EDV server: %w", err)
-	}
+		attributes := make(map[string]string, len(formattedTags))
 
-	documentIDs := make([]string, len(documentURLs))
+		for _, formattedTag := range formattedTags {
+			attributes[formattedTag.Name] = formattedTag.Value
+		}
 
-	for i, documentURL := range documentURLs {
-		documentIDs[i] = getDocIDFromURL(documentURL)
+		equals = append(equals, attributes)
+		queries = append(queries, &Query{Equals: []map[string]string{attributes}})
 	}
 
-	return &restIterator{
-		vaultID: r.vaultID, restClient: r.restClient, formatter: r.formatter,
-		documentIDs: documentIDs,
-	}, nil
-}
-
-type restIterator struct {
-	vaultID      string
-	restClient   *restClient
-	formatter    *EncryptedFormatter
-	documentIDs  []string
-	documents    [][]byte
-	currentIndex int
-	currentKey   string
-	currentValue []byte
-	currentTags  []spi.Tag
-}
+	if len(equals) == len(expression) {
+		queries = []*Query{{Equals: equals}}
+	}
 
-func (r *restIterator) Next() (bool, error) {
-	if len(r.documentIDs) == r.currentIndex || len(r.documentIDs) == 0 {
-		if len(r.documents) == r.currentIndex || len(r.documents) == 0 {
-			return false, nil
+	for _, q := range queries {
+		if err := r.sendQuery(q, entries); err != nil {
+			return err
 		}
 	}
 
-	var err error
+	return nil
+}
 
-	if r.documents != nil {
-		r.currentKey, r.currentValue, r.currentTags, err = r.formatter.Deformat("", r.documents[r.currentIndex])
+func (r *restStore) sendQuery(q *Query, entries map[string]query.Entry) error {
+	if r.returnFullDocumentsOnQuery {
+		documents, err := r.restClient.QueryFullDocuments(r.vaultID, q)
 		if err != nil {
-			return false, fmt.Errorf("failed to deformat encrypted document bytes: %w", err)
+			return fmt.Errorf("failure while querying vault: %w", err)
 		}
 
-		r.currentIndex++
-
-		return true, nil
+		return r.addDocuments(documents, entries)
 	}
 
-	encryptedDocumentBytes, err := r.restClient.readDocument(r.vaultID, r.documentIDs[r.currentIndex])
+	documentURLs, err := r.restClient.Query(r.vaultID, q)
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve document from EDV server: %w", err)
+		return fmt.Errorf("failure while querying EDV server: %w", err)
 	}
 
-	r.currentKey, r.currentValue, r.currentTags, err = r.formatter.Deformat("", encryptedDocumentBytes)
-	if err != nil {
-		return false, fmt.Errorf("failed to deformat encrypted document bytes: %w", err)
-	}
+	return r.addDocumentsAt(documentURLs, entries)
+}
 
-	r.currentIndex++
+func (r *restStore) addDocuments(documents []EncryptedDocument, entries map[string]query.Entry) error {
+	for _, document := range documents {
+		documentBytes, err := json.Marshal(document)
+		if err != nil {
+			return fmt.Errorf("failed to marshal document into bytes: %w", err)
+		}
 
-	return true, nil
-}
+		if err = r.addEntry(documentBytes, entries); err != nil {
+			return err
+		}
+	}
 
-func (r *restIterator) Key() (string, error) {
-	return r.currentKey, nil
+	return nil
 }
 
-func (r *restIterator) Value() ([]byte, error) {
-	return r.currentValue, nil
-}
+func (r *restStore) addDocumentsAt(documentURLs []string, entries map[string]query.Entry) error {
+	for _, documentURL := range documentURLs {
+		encryptedDocumentBytes, err := r.restClient.readDocument(r.vaultID, getDocIDFromURL(documentURL))
+		if err != nil {
+			return fmt.Errorf("failed to retrieve document from EDV server: %w", err)
+		}
 
-func (r *restIterator) Tags() ([]spi.Tag, error) {
-	return r.currentTags, nil
-}
+		if err = r.addEntry(encryptedDocumentBytes, entries); err != nil {
+			return err
+		}
+	}
 
-// Nothing to close for a restIterator.
-func (r *restIterator) Close() error {
 	return nil
 }
 
-func parseQueryExpression(expression string) (string, string, error) {
-	if expression == "" {
-		return "", "", errInvalidQueryExpressionFormat
+func (r *restStore) addEntry(encryptedDocumentBytes []byte, entries map[string]query.Entry) error {
+	key, value, tags, err := r.deformat(encryptedDocumentBytes)
+	if err != nil {
+		return fmt.Errorf("failed to deformat encrypted document bytes: %w", err)
 	}
 
-	expressionSplit := strings.Split(expression, ":")
+	entries[key] = query.Entry{Key: key, Value: value, Tags: tags}
+
+	return nil
+}
 
-	var expressionTagName, expressionTagValue string
+func mapValues(entries map[string]query.Entry) []query.Entry {
+	allEntries := make([]query.Entry, 0, len(entries))
 
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName = expressionSplit[0]
-	case expressionTagNameAndValueLength:
-		expressionTagName = expressionSplit[0]
-		expressionTagValue = expressionSplit[1]
-	default:
-		return "", "", errInvalidQueryExpressionFormat
+	for _, entry := range entries {
+		allEntries = append(allEntries, entry)
 	}
 
-	return expressionTagName, expressionTagValue, nil
+	return allEntries
 }
 
 func getDocIDFromURL(docURL string) string {
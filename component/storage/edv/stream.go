@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+// WriteStream reads r until EOF and stores what it reads as the data stream of the document with the given ID, in
+// chunks of at most chunkSize bytes, each encrypted with encrypter. The chunks are bound to the document, their index
+// and the sequence of the stream, so that they cannot be swapped. It returns the Stream to record in the
+// (encrypted) document so that the data can be read back with ReadStream.
+func (c *Client) WriteStream(vaultID, docID string, sequence int, r io.Reader, chunkSize int,
+	encrypter jose.Encrypter) (*Stream, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("chunk size must be positive")
+	}
+
+	buf := make([]byte, chunkSize)
+
+	var offset int64
+
+	for index := 0; ; index++ {
+		n, err := io.ReadFull(r, buf)
+		if errors.Is(err, io.EOF) {
+			return &Stream{Sequence: sequence, Chunks: index}, nil
+		}
+
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("failed to read stream: %w", err)
+		}
+
+		jwe, errEncrypt := encrypter.EncryptWithAuthData(buf[:n], chunkAAD(docID, sequence, index))
+		if errEncrypt != nil {
+			return nil, fmt.Errorf("failed to encrypt chunk %d: %w", index, errEncrypt)
+		}
+
+		serializedJWE, errEncrypt := jwe.FullSerialize(json.Marshal)
+		if errEncrypt != nil {
+			return nil, fmt.Errorf("failed to serialize JWE of chunk %d: %w", index, errEncrypt)
+		}
+
+		errPut := c.PutChunk(vaultID, docID, &EncryptedChunk{
+			Sequence: sequence,
+			Index:    index,
+			Offset:   offset,
+			JWE:      []byte(serializedJWE),
+		})
+		if errPut != nil {
+			return nil, fmt.Errorf("failed to store chunk %d: %w", index, errPut)
+		}
+
+		offset += int64(n)
+
+		if n < chunkSize {
+			return &Stream{Sequence: sequence, Chunks: index + 1}, nil
+		}
+	}
+}
+
+// ReadStream decrypts with decrypter the chunks of the given data stream of the document with the given ID, and
+// writes them to w in order.
+func (c *Client) ReadStream(vaultID, docID string, stream *Stream, decrypter jose.Decrypter, w io.Writer) error {
+	var offset int64
+
+	for index := 0; index < stream.Chunks; index++ {
+		chunk, err := c.ReadChunk(vaultID, docID, index)
+		if err != nil {
+			return fmt.Errorf("failed to read chunk %d: %w", index, err)
+		}
+
+		if chunk.Sequence != stream.Sequence || chunk.Index != index || chunk.Offset != offset {
+			return fmt.Errorf("chunk %d does not belong to the stream", index)
+		}
+
+		jwe, err := jose.Deserialize(string(chunk.JWE))
+		if err != nil {
+			return fmt.Errorf("failed to deserialize JWE of chunk %d: %w", index, err)
+		}
+
+		if jwe.AAD != string(chunkAAD(docID, stream.Sequence, index)) {
+			return fmt.Errorf("chunk %d does not belong to the stream", index)
+		}
+
+		data, err := decrypter.Decrypt(jwe)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt chunk %d: %w", index, err)
+		}
+
+		_, err = w.Write(data)
+		if err != nil {
+			return fmt.Errorf("failed to write chunk %d: %w", index, err)
+		}
+
+		offset += int64(len(data))
+	}
+
+	return nil
+}
+
+func chunkAAD(docID string, sequence, index int) []byte {
+	return []byte(docID + "." + strconv.Itoa(sequence) + "." + strconv.Itoa(index))
+}
@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edv
+
+import "net/http"
+
+const (
+	// ActionRead is the capability action of requests reading vault configurations, documents and chunks, or
+	// querying documents.
+	ActionRead = "read"
+	// ActionWrite is the capability action of all other requests.
+	ActionWrite = "write"
+)
+
+// CapabilityInvoker authorizes EDV requests by invoking an authorization capability, for instance by adding a
+// ZCAP-LD capability invocation signed with HTTP signatures as zcapld.Invoker does. action is ActionRead or
+// ActionWrite.
+type CapabilityInvoker interface {
+	InvokeCapability(req *http.Request, action string) error
+}
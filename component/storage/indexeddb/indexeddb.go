@@ -1,3 +1,4 @@
+//go:build js && wasm
 // +build js,wasm
 
 /*
@@ -17,6 +18,7 @@ import (
 	"syscall/js"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
@@ -33,16 +35,11 @@ import (
 // TODO (#2528): Proper implementation of all methods.
 
 const (
-	dbName                          = "aries-%s"
-	defDBName                       = "aries"
-	dbVersion                       = 1
-	tagMapKey                       = "TagMap"
-	storeConfigKey                  = "StoreConfig"
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-
-	invalidQueryExpressionFormat = `"%s" is not in a valid expression format. ` +
-		"it must be in the following format: TagName:TagValue"
+	dbName         = "aries-%s"
+	defDBName      = "aries"
+	dbVersion      = 1
+	tagMapKey      = "TagMap"
+	storeConfigKey = "StoreConfig"
 )
 
 // TODO (#2540): Use proper IndexedDB indexing instead of the "Tag Map" once aries-framework-go is updated to use the
@@ -286,8 +283,9 @@ func (s *store) GetBulk(keys ...string) ([][]byte, error) {
 }
 
 func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
-	if expression == "" {
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	q, err := query.New(expression, options...)
+	if err != nil {
+		return nil, err
 	}
 
 	tagMapBytes, err := s.Get(tagMapKey)
@@ -302,28 +300,12 @@ func (s *store) Query(expression string, options ...storage.QueryOption) (storag
 		return nil, fmt.Errorf("failed to unmarshal tag map bytes: %w", err)
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName := expressionSplit[0]
-
-		matchingDatabaseKeys := getDatabaseKeysMatchingTagName(tagMap, expressionTagName)
-
-		return &iterator{keys: matchingDatabaseKeys, store: s}, nil
-	case expressionTagNameAndValueLength:
-		expressionTagName := expressionSplit[0]
-		expressionTagValue := expressionSplit[1]
-
-		matchingDatabaseKeys, err :=
-			s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName, expressionTagValue)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
-		}
-
-		return &iterator{keys: matchingDatabaseKeys, store: s}, nil
-	default:
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	entries, err := s.getCandidateEntries(tagMap, q.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database entries matching the expression: %w", err)
 	}
+
+	return &iterator{entries: q.Apply(entries), query: q, store: s}, nil
 }
 
 // Delete will delete record with k key.
@@ -445,49 +427,47 @@ func (s *store) removeFromTagMap(keyToRemove string) error {
 	return nil
 }
 
-func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
-	expressionTagName, expressionTagValue string) ([]string, error) {
-	var matchingDatabaseKeys []string
-
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
-				tags, err := s.GetTags(databaseKey)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get tags: %w", err)
-				}
-
-				for _, tag := range tags {
-					if tag.Name == expressionTagName && tag.Value == expressionTagValue {
-						matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
-
-						break
-					}
-				}
-			}
+// getCandidateEntries returns the keys and tags of the entries which have the tag of the first condition of any
+// term of the expression. The values are not loaded, the entries still have to be filtered by the query.
+func (s *store) getCandidateEntries(tagMap tagMapping, expression query.Expression) ([]query.Entry, error) {
+	candidateKeys := make(map[string]struct{})
 
-			break
+	for _, term := range expression {
+		for databaseKey := range tagMap[term[0].TagName] {
+			candidateKeys[databaseKey] = struct{}{}
 		}
 	}
 
-	return matchingDatabaseKeys, nil
+	entries := make([]query.Entry, 0, len(candidateKeys))
+
+	for databaseKey := range candidateKeys {
+		tags, err := s.GetTags(databaseKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags: %w", err)
+		}
+
+		entries = append(entries, query.Entry{Key: databaseKey, Tags: tags})
+	}
+
+	return entries, nil
 }
 
 type iterator struct {
-	keys         []string
+	entries      []query.Entry
+	query        *query.Query
 	currentIndex int
 	currentKey   string
 	store        *store
 }
 
 func (i *iterator) Next() (bool, error) {
-	if len(i.keys) == i.currentIndex || len(i.keys) == 0 {
-		if len(i.keys) == i.currentIndex || len(i.keys) == 0 {
+	if len(i.entries) == i.currentIndex || len(i.entries) == 0 {
+		if len(i.entries) == i.currentIndex || len(i.entries) == 0 {
 			return false, nil
 		}
 	}
 
-	i.currentKey = i.keys[i.currentIndex]
+	i.currentKey = i.entries[i.currentIndex].Key
 
 	i.currentIndex++
 
@@ -516,6 +496,14 @@ func (i *iterator) Tags() ([]storage.Tag, error) {
 	return tags, nil
 }
 
+func (i *iterator) Bookmark() (string, error) {
+	if i.currentIndex == 0 {
+		return "", errors.New("iterator is exhausted")
+	}
+
+	return i.query.Position(i.currentKey, i.entries[i.currentIndex-1].Tags).Bookmark(), nil
+}
+
 func (i *iterator) Close() error {
 	return nil
 }
@@ -562,19 +550,3 @@ func getStoreNames() []string {
 		strings.ToLower(presentproof.Name),
 	}
 }
-
-func getDatabaseKeysMatchingTagName(tagMap tagMapping, expressionTagName string) []string {
-	var matchingDatabaseKeys []string
-
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
-				matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
-			}
-
-			break
-		}
-	}
-
-	return matchingDatabaseKeys
-}
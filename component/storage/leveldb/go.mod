@@ -7,10 +7,25 @@ module github.com/hyperledger/aries-framework-go/component/storage/leveldb
 go 1.15
 
 require (
+	github.com/golang/protobuf v1.4.2 // indirect
+	github.com/golang/snappy v0.0.1 // indirect
+	github.com/google/go-cmp v0.5.2 // indirect
 	github.com/google/uuid v1.1.2
-	github.com/hyperledger/aries-framework-go v0.1.6-0.20210304193329-f56b2cebc386
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210304193329-f56b2cebc386
+	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20261019133114-510320220604
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261019084901-66f4e01095f2
 	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20210304193329-f56b2cebc386
 	github.com/stretchr/testify v1.7.0
 	github.com/syndtr/goleveldb v1.0.0
+	golang.org/x/net v0.0.0-20200822124328-c89045814202 // indirect
+	golang.org/x/sys v0.0.0-20201211090839-8ad439b19e0f // indirect
+	golang.org/x/text v0.3.3 // indirect
+	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
+	google.golang.org/protobuf v1.25.0 // indirect
+	gopkg.in/yaml.v2 v2.2.8 // indirect
+)
+
+replace (
+	github.com/hyperledger/aries-framework-go/component/storageutil => ../../storageutil
+	github.com/hyperledger/aries-framework-go/spi => ../../../spi
+	github.com/hyperledger/aries-framework-go/test/component => ../../../test/component
 )
@@ -10,11 +10,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/syndtr/goleveldb/leveldb"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -24,12 +28,8 @@ import (
 const (
 	pathPattern = "%s-%s"
 
-	tagMapKey                       = "TagMap"
-	storeConfigKey                  = "StoreConfig"
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-	invalidQueryExpressionFormat    = `"%s" is not in a valid expression format. ` +
-		"it must be in the following format: TagName:TagValue"
+	tagMapKey      = "TagMap"
+	storeConfigKey = "StoreConfig"
 )
 
 // Provider leveldb implementation of storage.Provider interface.
@@ -122,6 +122,33 @@ func (p *Provider) GetOpenStores() []storage.Store {
 	panic("implement me")
 }
 
+// StoreNames returns the names of all stores found under the database path, in sorted order.
+func (p *Provider) StoreNames() ([]string, error) {
+	paths, err := filepath.Glob(fmt.Sprintf(pathPattern, p.dbPath, "*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list store directories: %w", err)
+	}
+
+	prefix := fmt.Sprintf(pathPattern, filepath.Base(p.dbPath), "")
+
+	var names []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat store directory: %w", err)
+		}
+
+		if info.IsDir() {
+			names = append(names, strings.TrimPrefix(filepath.Base(path), prefix))
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
 // Close closes all stores created under this store provider.
 func (p *Provider) Close() error {
 	p.lock.RLock()
@@ -253,8 +280,9 @@ func (s *store) GetBulk(keys ...string) ([][]byte, error) {
 }
 
 func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
-	if expression == "" {
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	q, err := query.New(expression, options...)
+	if err != nil {
+		return nil, err
 	}
 
 	tagMap, err := s.getTagMap()
@@ -262,28 +290,35 @@ func (s *store) Query(expression string, options ...storage.QueryOption) (storag
 		return nil, fmt.Errorf("failed to get tag map: %w", err)
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName := expressionSplit[0]
+	entries, err := s.getCandidateEntries(tagMap, q.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database entries matching the expression: %w", err)
+	}
 
-		matchingDatabaseKeys := getDatabaseKeysMatchingTagName(tagMap, expressionTagName)
+	return &iterator{entries: q.Apply(entries), query: q, store: s}, nil
+}
 
-		return &iterator{keys: matchingDatabaseKeys, store: s}, nil
-	case expressionTagNameAndValueLength:
-		expressionTagName := expressionSplit[0]
-		expressionTagValue := expressionSplit[1]
+// Keys returns every key in the store, including the keys of entries without tags, in sorted order.
+func (s *store) Keys() ([]string, error) {
+	iterator := s.db.NewIterator(nil, nil)
+	defer iterator.Release()
 
-		matchingDatabaseKeys, err :=
-			s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName, expressionTagValue)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
+	var keys []string
+
+	for iterator.Next() {
+		key := string(iterator.Key())
+
+		if key != tagMapKey && key != storeConfigKey {
+			keys = append(keys, key)
 		}
+	}
 
-		return &iterator{keys: matchingDatabaseKeys, store: s}, nil
-	default:
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	err := iterator.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate over keys: %w", err)
 	}
+
+	return keys, nil
 }
 
 // Delete will delete record with k key.
@@ -411,65 +446,47 @@ func (s *store) removeFromTagMap(keyToRemove string) error {
 	return nil
 }
 
-func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
-	expressionTagName, expressionTagValue string) ([]string, error) {
-	var matchingDatabaseKeys []string
-
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
-				tags, err := s.GetTags(databaseKey)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get tags: %w", err)
-				}
-
-				for _, tag := range tags {
-					if tag.Name == expressionTagName && tag.Value == expressionTagValue {
-						matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
-
-						break
-					}
-				}
-			}
+// getCandidateEntries returns the keys and tags of the entries which have the tag of the first condition of any
+// term of the expression. The values are not loaded, the entries still have to be filtered by the query.
+func (s *store) getCandidateEntries(tagMap tagMapping, expression query.Expression) ([]query.Entry, error) {
+	candidateKeys := make(map[string]struct{})
 
-			break
+	for _, term := range expression {
+		for databaseKey := range tagMap[term[0].TagName] {
+			candidateKeys[databaseKey] = struct{}{}
 		}
 	}
 
-	return matchingDatabaseKeys, nil
-}
-
-func getDatabaseKeysMatchingTagName(tagMap tagMapping, expressionTagName string) []string {
-	var matchingDatabaseKeys []string
-
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
-				matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
-			}
+	entries := make([]query.Entry, 0, len(candidateKeys))
 
-			break
+	for databaseKey := range candidateKeys {
+		tags, err := s.GetTags(databaseKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags: %w", err)
 		}
+
+		entries = append(entries, query.Entry{Key: databaseKey, Tags: tags})
 	}
 
-	return matchingDatabaseKeys
+	return entries, nil
 }
 
 type iterator struct {
-	keys         []string
+	entries      []query.Entry
+	query        *query.Query
 	currentIndex int
 	currentKey   string
 	store        *store
 }
 
 func (i *iterator) Next() (bool, error) {
-	if len(i.keys) == i.currentIndex || len(i.keys) == 0 {
-		if len(i.keys) == i.currentIndex || len(i.keys) == 0 {
+	if len(i.entries) == i.currentIndex || len(i.entries) == 0 {
+		if len(i.entries) == i.currentIndex || len(i.entries) == 0 {
 			return false, nil
 		}
 	}
 
-	i.currentKey = i.keys[i.currentIndex]
+	i.currentKey = i.entries[i.currentIndex].Key
 
 	i.currentIndex++
 
@@ -498,6 +515,14 @@ func (i *iterator) Tags() ([]storage.Tag, error) {
 	return tags, nil
 }
 
+func (i *iterator) Bookmark() (string, error) {
+	if i.currentIndex == 0 {
+		return "", errors.New("iterator is exhausted")
+	}
+
+	return i.query.Position(i.currentKey, i.entries[i.currentIndex-1].Tags).Bookmark(), nil
+}
+
 func (i *iterator) Close() error {
 	return nil
 }
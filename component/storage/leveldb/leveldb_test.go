@@ -45,6 +45,7 @@ func TestCommon(t *testing.T) {
 	commontest.TestPutGet(t, provider)
 	commontest.TestStoreGetTags(t, provider)
 	commontest.TestStoreQuery(t, provider)
+	commontest.TestStoreQueryWithSortingAndBookmark(t, provider)
 	commontest.TestStoreDelete(t, provider)
 	commontest.TestStoreClose(t, provider)
 	commontest.TestProviderClose(t, provider)
@@ -198,3 +199,34 @@ func TestIterator(t *testing.T) {
 func randomStoreName() string {
 	return "store-" + uuid.New().String()
 }
+
+func TestProvider_StoreNames(t *testing.T) {
+	path := setupLevelDB(t)
+
+	provider := leveldb.NewProvider(path)
+
+	_, err := provider.OpenStore("StoreB")
+	require.NoError(t, err)
+
+	store, err := provider.OpenStore("storea")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("key2", []byte("value2")))
+	require.NoError(t, store.Put("key1", []byte("value1"), storage.Tag{Name: "tag"}))
+	require.NoError(t, provider.SetStoreConfig("storea", storage.StoreConfiguration{TagNames: []string{"tag"}}))
+	require.NoError(t, provider.Close())
+
+	// Stores are found on disk even after the provider has been closed.
+	provider = leveldb.NewProvider(path)
+
+	names, err := provider.StoreNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"storea", "storeb"}, names)
+
+	store, err = provider.OpenStore("storea")
+	require.NoError(t, err)
+
+	keys, err := store.(interface{ Keys() ([]string, error) }).Keys()
+	require.NoError(t, err)
+	require.Equal(t, []string{"key1", "key2"}, keys)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqldb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DriverSQLite is the driver name of github.com/mattn/go-sqlite3.
+	DriverSQLite = "sqlite3"
+	// DriverPostgres is the driver name of github.com/lib/pq.
+	DriverPostgres = "postgres"
+	// DriverPgx is the driver name of github.com/jackc/pgx/v4/stdlib.
+	DriverPgx = "pgx"
+	// DriverMySQL is the driver name of github.com/go-sql-driver/mysql.
+	DriverMySQL = "mysql"
+)
+
+// dialect holds the differences between the supported databases.
+type dialect struct {
+	blobType string
+	// the keys and sort keys are compared byte by byte, like the other stores do.
+	keyCollation     string
+	sortKeyCollation string
+	// MySQL does not support "CREATE INDEX IF NOT EXISTS", so the indexes are declared in the table definitions.
+	inlineIndexes bool
+	// PostgreSQL uses numbered placeholders ($1, $2...) instead of question marks.
+	numberedPlaceholders bool
+	// SQLite allows a single writer at a time, concurrent transactions fail with "database is locked".
+	singleConnection bool
+}
+
+// nolint:gochecknoglobals
+var dialects = map[string]*dialect{
+	DriverSQLite: {blobType: "BLOB", singleConnection: true},
+	DriverPostgres: {
+		blobType: "BYTEA", keyCollation: ` COLLATE "C"`, sortKeyCollation: ` COLLATE "C"`, numberedPlaceholders: true,
+	},
+	DriverPgx: {
+		blobType: "BYTEA", keyCollation: ` COLLATE "C"`, sortKeyCollation: ` COLLATE "C"`, numberedPlaceholders: true,
+	},
+	DriverMySQL: {
+		blobType:     "LONGBLOB",
+		keyCollation: " CHARACTER SET utf8mb4 COLLATE utf8mb4_bin",
+		// sort keys are ASCII, which keeps the sort index under the maximum key length of InnoDB.
+		sortKeyCollation: " CHARACTER SET ascii COLLATE ascii_bin",
+		inlineIndexes:    true,
+	},
+}
+
+type index struct {
+	name    string
+	columns string
+}
+
+// createTables returns the statements creating the tables (and their indexes) used by the provider.
+// Every entry is a row of the entry table, and every tag is a row of the tag table which is indexed by
+// tag name and value, so queries do not have to scan the stores. The sort key of the tag value
+// (see query.SortKey) is stored along with the value for the range conditions and the sorting.
+// Keys and tag values are unbounded TEXT, the rows are identified and indexed by their SHA-256 hashes instead.
+func (d *dialect) createTables(prefix string) []string {
+	storeTable := prefix + "store"
+	entryTable := prefix + "entry"
+	tagTable := prefix + "tag"
+
+	tagIndexes := []index{
+		{name: tagTable + "_key_idx", columns: "store_name, key_hash"},
+		{name: tagTable + "_value_idx", columns: "store_name, tag_name, value_hash"},
+		{name: tagTable + "_sort_idx", columns: "store_name, tag_name, tag_sort"},
+	}
+
+	storeName := fmt.Sprintf("store_name VARCHAR(%d) NOT NULL", maxStoreNameLength)
+	hash := fmt.Sprintf(" CHAR(%d) NOT NULL", hashLength)
+
+	return append([]string{
+		d.createTable(storeTable, nil,
+			storeName,
+			"config TEXT NOT NULL",
+			"PRIMARY KEY (store_name)"),
+		d.createTable(entryTable, nil,
+			storeName,
+			"key_hash"+hash,
+			"entry_key TEXT"+d.keyCollation+" NOT NULL",
+			"entry_value "+d.blobType+" NOT NULL",
+			"PRIMARY KEY (store_name, key_hash)"),
+		d.createTable(tagTable, tagIndexes,
+			storeName,
+			"key_hash"+hash,
+			fmt.Sprintf("tag_name VARCHAR(%d) NOT NULL", maxTagNameLength),
+			"tag_value TEXT NOT NULL",
+			"value_hash"+hash,
+			fmt.Sprintf("tag_sort VARCHAR(%d)%s NOT NULL", sortKeyLength, d.sortKeyCollation)),
+	}, d.createIndexes(tagTable, tagIndexes)...)
+}
+
+func (d *dialect) createTable(table string, indexes []index, columns ...string) string {
+	if d.inlineIndexes {
+		for _, idx := range indexes {
+			columns = append(columns, fmt.Sprintf("INDEX %s (%s)", idx.name, idx.columns))
+		}
+	}
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", table, strings.Join(columns, ", "))
+}
+
+func (d *dialect) createIndexes(table string, indexes []index) []string {
+	if d.inlineIndexes {
+		return nil
+	}
+
+	statements := make([]string, len(indexes))
+
+	for i, idx := range indexes {
+		statements[i] = fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", idx.name, table, idx.columns)
+	}
+
+	return statements
+}
+
+// rebind replaces the question mark placeholders of the query with the ones of the database.
+func (d *dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+
+	var (
+		b strings.Builder
+		n int
+	)
+
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+
+			continue
+		}
+
+		n++
+
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,21 @@
+// Copyright SecureKey Technologies Inc. All Rights Reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/hyperledger/aries-framework-go/component/storage/sqldb
+
+go 1.15
+
+require (
+	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20261019133114-510320220604
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261019084901-66f4e01095f2
+	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20210304193329-f56b2cebc386
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/stretchr/testify v1.7.0
+)
+
+replace (
+	github.com/hyperledger/aries-framework-go/component/storageutil => ../../storageutil
+	github.com/hyperledger/aries-framework-go/spi => ../../../spi
+	github.com/hyperledger/aries-framework-go/test/component => ../../../test/component
+)
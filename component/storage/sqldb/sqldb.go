@@ -0,0 +1,779 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sqldb provides a storage.Provider on top of relational databases accessed with database/sql.
+//
+// SQLite, PostgreSQL and MySQL are supported. The driver of the database is not imported by this package,
+// it has to be registered by the caller (e.g. import _ "github.com/mattn/go-sqlite3").
+package sqldb
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	defaultTablePrefix = "aries_"
+	defaultPageSize    = 25
+	// sortKeyLength is the size of the tag_sort column. Longer sort keys (only the ones of long non-numeric
+	// and non-date values can be) are truncated, the entries are then sorted by key if the truncated keys are equal.
+	sortKeyLength = 512
+	// hashLength is the size of the key_hash and value_hash columns, holding hex encoded SHA-256 hashes.
+	hashLength = 2 * sha256.Size
+	// maxStoreNameLength and maxTagNameLength are the maximum number of characters of store and tag names.
+	maxStoreNameLength = 128
+	maxTagNameLength   = 255
+)
+
+// nolint:gochecknoglobals
+var tablePrefixPattern = regexp.MustCompile(`^[a-zA-Z0-9_]*$`)
+
+// Option configures the Provider.
+type Option func(opts *Provider)
+
+// WithTablePrefix sets the prefix of the tables used by the provider. Defaults to "aries_".
+// The prefix may only contain letters, digits and underscores.
+func WithTablePrefix(prefix string) Option {
+	return func(opts *Provider) {
+		opts.tablePrefix = prefix
+	}
+}
+
+// Provider database/sql implementation of storage.Provider interface.
+type Provider struct {
+	db          *sql.DB
+	dialect     *dialect
+	tablePrefix string
+	queries     queries
+	stores      map[string]*store
+	lock        sync.RWMutex
+}
+
+type queries struct {
+	insertStore string
+	selectStore string
+	updateStore string
+	insertEntry string
+	selectEntry string
+	deleteEntry string
+	insertTag   string
+	selectTags  string
+	deleteTags  string
+	// selectMatching is formatted with the sort key expression, the conditions of the expression,
+	// the bookmark condition and the sort order (see store.Query).
+	selectMatching string
+	sortKey        string
+	matchingTag    string
+}
+
+// NewProvider opens the database with the given driver and data source name and creates the tables used by the
+// provider if they do not exist yet. The driver must be one of DriverSQLite, DriverPostgres, DriverPgx
+// or DriverMySQL and has to be registered by the caller.
+func NewProvider(driverName, dataSourceName string, opts ...Option) (*Provider, error) {
+	d, ok := dialects[driverName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver: %s", driverName)
+	}
+
+	p := &Provider{dialect: d, tablePrefix: defaultTablePrefix, stores: make(map[string]*store)}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if !tablePrefixPattern.MatchString(p.tablePrefix) {
+		return nil, fmt.Errorf("invalid table prefix: %s", p.tablePrefix)
+	}
+
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if d.singleConnection {
+		db.SetMaxOpenConns(1)
+	}
+
+	if err = db.Ping(); err != nil {
+		return nil, closeOnError(db, fmt.Errorf("failed to connect to database: %w", err))
+	}
+
+	for _, statement := range d.createTables(p.tablePrefix) {
+		if _, err = db.Exec(statement); err != nil {
+			return nil, closeOnError(db, fmt.Errorf("failed to create tables: %w", err))
+		}
+	}
+
+	p.db = db
+	p.queries = p.newQueries()
+
+	return p, nil
+}
+
+func closeOnError(db *sql.DB, err error) error {
+	if errClose := db.Close(); errClose != nil {
+		return fmt.Errorf("%w (failed to close database: %s)", err, errClose.Error())
+	}
+
+	return err
+}
+
+func (p *Provider) newQueries() queries {
+	storeTable := p.tablePrefix + "store"
+	entryTable := p.tablePrefix + "entry"
+	tagTable := p.tablePrefix + "tag"
+
+	selectMatching := "SELECT r.entry_key, r.entry_value, r.sort_key FROM (" +
+		"SELECT e.entry_key, e.entry_value, %s AS sort_key FROM " + entryTable + " e " +
+		"WHERE e.store_name = ? AND (%s)) r " +
+		"WHERE %s ORDER BY r.sort_key %[4]s, r.entry_key %[4]s LIMIT ?"
+
+	matchingTag := "SELECT %s FROM " + tagTable + " t " +
+		"WHERE t.store_name = e.store_name AND t.key_hash = e.key_hash AND t.tag_name = ?"
+
+	// the lowest sort key is used if the tag is set several times.
+	sortKey := fmt.Sprintf("COALESCE((%s), '%s')", fmt.Sprintf(matchingTag, "MIN(t.tag_sort)"), query.MissingSortKey)
+
+	q := queries{
+		insertStore: "INSERT INTO " + storeTable + " (store_name, config) VALUES (?, ?)",
+		selectStore: "SELECT config FROM " + storeTable + " WHERE store_name = ?",
+		updateStore: "UPDATE " + storeTable + " SET config = ? WHERE store_name = ?",
+		insertEntry: "INSERT INTO " + entryTable +
+			" (store_name, key_hash, entry_key, entry_value) VALUES (?, ?, ?, ?)",
+		selectEntry: "SELECT entry_value FROM " + entryTable + " WHERE store_name = ? AND key_hash = ?",
+		deleteEntry: "DELETE FROM " + entryTable + " WHERE store_name = ? AND key_hash = ?",
+		insertTag: "INSERT INTO " + tagTable +
+			" (store_name, key_hash, tag_name, tag_value, value_hash, tag_sort) VALUES (?, ?, ?, ?, ?, ?)",
+		selectTags:     "SELECT tag_name, tag_value FROM " + tagTable + " WHERE store_name = ? AND key_hash = ?",
+		deleteTags:     "DELETE FROM " + tagTable + " WHERE store_name = ? AND key_hash = ?",
+		selectMatching: selectMatching,
+		sortKey:        sortKey,
+		matchingTag:    fmt.Sprintf("EXISTS (%s%%s)", fmt.Sprintf(matchingTag, "1")),
+	}
+
+	// the queries built from selectMatching are rebound when they are formatted.
+	for _, statement := range []*string{
+		&q.insertStore, &q.selectStore, &q.updateStore, &q.insertEntry, &q.selectEntry, &q.deleteEntry,
+		&q.insertTag, &q.selectTags, &q.deleteTags,
+	} {
+		*statement = p.dialect.rebind(*statement)
+	}
+
+	return q
+}
+
+// OpenStore opens a store with the given name and returns a handle.
+// If the store has never been opened before, then it is created. Store names are limited to 128 characters.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	if name == "" {
+		return nil, errors.New("store name cannot be blank")
+	}
+
+	if utf8.RuneCountInString(name) > maxStoreNameLength {
+		return nil, fmt.Errorf("store name %.32s... exceeds %d characters", name, maxStoreNameLength)
+	}
+
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if s, ok := p.stores[name]; ok {
+		return s, nil
+	}
+
+	_, err := p.getStoreConfig(name)
+	if errors.Is(err, storage.ErrStoreNotFound) {
+		_, err = p.db.Exec(p.queries.insertStore, name, "{}")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf(`failed to create store "%s": %w`, name, err)
+	}
+
+	s := &store{name: name, db: p.db, dialect: p.dialect, queries: &p.queries, close: p.removeStore}
+	p.stores[name] = s
+
+	return s, nil
+}
+
+// SetStoreConfig sets the configuration on a store.
+// The store must be created prior to calling this method.
+// If the store cannot be found, then an error wrapping storage.ErrStoreNotFound will be returned.
+func (p *Provider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	if name == "" {
+		return errors.New("store name cannot be blank")
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal store configuration: %w", err)
+	}
+
+	name = strings.ToLower(name)
+
+	// the number of affected rows can't be used to find out whether the store exists,
+	// since MySQL reports zero when the configuration is unchanged.
+	if _, err = p.getStoreConfig(name); err != nil {
+		return err
+	}
+
+	if _, err = p.db.Exec(p.queries.updateStore, string(configBytes), name); err != nil {
+		return fmt.Errorf("failed to update store configuration: %w", err)
+	}
+
+	return nil
+}
+
+// GetStoreConfig gets the current store configuration.
+// The store must be created prior to calling this method.
+// If the store cannot be found, then an error wrapping storage.ErrStoreNotFound will be returned.
+func (p *Provider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	if name == "" {
+		return storage.StoreConfiguration{}, errors.New("store name cannot be blank")
+	}
+
+	return p.getStoreConfig(strings.ToLower(name))
+}
+
+func (p *Provider) getStoreConfig(name string) (storage.StoreConfiguration, error) {
+	var configBytes string
+
+	err := p.db.QueryRow(p.queries.selectStore, name).Scan(&configBytes)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.StoreConfiguration{}, storage.ErrStoreNotFound
+	}
+
+	if err != nil {
+		return storage.StoreConfiguration{},
+			fmt.Errorf(`failed to get store configuration for "%s": %w`, name, err)
+	}
+
+	var config storage.StoreConfiguration
+
+	if err = json.Unmarshal([]byte(configBytes), &config); err != nil {
+		return storage.StoreConfiguration{}, fmt.Errorf("failed to unmarshal store configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// GetOpenStores returns all currently open stores.
+func (p *Provider) GetOpenStores() []storage.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	openStores := make([]storage.Store, 0, len(p.stores))
+
+	for _, s := range p.stores {
+		openStores = append(openStores, s)
+	}
+
+	return openStores
+}
+
+// Close closes all stores created under this store provider and the underlying database.
+// The data in the stores is not deleted.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.stores = make(map[string]*store)
+
+	if err := p.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Provider) removeStore(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.stores, name)
+}
+
+type store struct {
+	name    string
+	db      *sql.DB
+	dialect *dialect
+	queries *queries
+	close   func(name string)
+}
+
+// Put stores the key and the record. Keys and tag values may be of any length, tag names are limited to
+// 255 characters.
+func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
+	if key == "" {
+		return errors.New("key cannot be blank")
+	}
+
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	if err := checkTagNames(tags); err != nil {
+		return err
+	}
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		return s.put(tx, key, value, tags)
+	})
+}
+
+// Get fetches the record based on key.
+func (s *store) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, errors.New("key cannot be blank")
+	}
+
+	var value []byte
+
+	err := s.db.QueryRow(s.queries.selectEntry, s.name, hashOf(key)).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrDataNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry: %w", err)
+	}
+
+	return value, nil
+}
+
+// GetTags fetches the tags associated with the given key.
+func (s *store) GetTags(key string) ([]storage.Tag, error) {
+	// makes sure the entry exists, since an entry without tags has no rows in the tag table.
+	if _, err := s.Get(key); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(s.queries.selectTags, s.name, hashOf(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	defer closeRows(rows)
+
+	var tags []storage.Tag
+
+	for rows.Next() {
+		var tag storage.Tag
+
+		if err = rows.Scan(&tag.Name, &tag.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetBulk fetches the values associated with the given keys.
+// If no data exists under a given key, then a nil []byte is returned for that value.
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys slice must contain at least one key")
+	}
+
+	for _, key := range keys {
+		if key == "" {
+			return nil, errors.New("key cannot be blank")
+		}
+	}
+
+	values := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		value, err := s.Get(key)
+		if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("failed to get value for key %s: %w", key, err)
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// Query returns all data that satisfies the expression (see storage.Store Query).
+// The expression is evaluated by the database, which returns the results page by page.
+func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	q, err := query.New(expression, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := q.Options.PageSize
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	var (
+		sortKey = "''"
+		args    []interface{}
+	)
+
+	if tagName := q.SortTagName(); tagName != "" {
+		sortKey = s.queries.sortKey
+		args = append(args, tagName)
+	}
+
+	args = append(args, s.name)
+
+	terms := make([]string, len(q.Expression))
+
+	for i, term := range q.Expression {
+		conditions := make([]string, len(term))
+
+		for j, condition := range term {
+			var conditionArgs []interface{}
+
+			conditions[j], conditionArgs = s.matchingTag(condition)
+			args = append(args, conditionArgs...)
+		}
+
+		terms[i] = strings.Join(conditions, " AND ")
+	}
+
+	order, comparison := "ASC", ">"
+	if q.Descending() {
+		order, comparison = "DESC", "<"
+	}
+
+	return &iterator{
+		store: s,
+		// the first page is not bounded unless a bookmark is given.
+		firstQuery: s.dialect.rebind(fmt.Sprintf(s.queries.selectMatching,
+			sortKey, strings.Join(terms, " OR "), "1 = 1", order)),
+		query: s.dialect.rebind(fmt.Sprintf(s.queries.selectMatching,
+			sortKey, strings.Join(terms, " OR "),
+			fmt.Sprintf("(r.sort_key %[1]s ? OR (r.sort_key = ? AND r.entry_key %[1]s ?))", comparison), order)),
+		args:     args,
+		pageSize: pageSize,
+		position: q.After,
+	}, nil
+}
+
+// matchingTag returns the SQL condition matching the entries satisfying the condition, and its arguments.
+func (s *store) matchingTag(condition query.Condition) (string, []interface{}) {
+	switch condition.Operator {
+	case query.Has:
+		return fmt.Sprintf(s.queries.matchingTag, ""), []interface{}{condition.TagName}
+	case query.Equal:
+		return fmt.Sprintf(s.queries.matchingTag, " AND t.value_hash = ?"),
+			[]interface{}{condition.TagName, hashOf(condition.Value)}
+	}
+
+	lower, upper, lowerExclusive, upperInclusive, ok := condition.RangeBounds()
+	if !ok {
+		// no tag value can satisfy the condition.
+		return "1 = 0", nil
+	}
+
+	lowerComparison, upperComparison := ">=", "<"
+
+	if lowerExclusive {
+		lowerComparison = ">"
+	}
+
+	if upperInclusive {
+		upperComparison = "<="
+	}
+
+	return fmt.Sprintf(s.queries.matchingTag,
+			fmt.Sprintf(" AND t.tag_sort %s ? AND t.tag_sort %s ?", lowerComparison, upperComparison)),
+		[]interface{}{condition.TagName, lower, upper}
+}
+
+// Delete deletes the record (and its tags) associated with key.
+func (s *store) Delete(key string) error {
+	if key == "" {
+		return errors.New("key cannot be blank")
+	}
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		return s.delete(tx, key)
+	})
+}
+
+// Batch performs the Put and Delete operations in a single transaction.
+// Either all the operations are applied, or none of them.
+func (s *store) Batch(operations []storage.Operation) error {
+	for _, operation := range operations {
+		if operation.Key == "" {
+			return errors.New("key cannot be blank")
+		}
+
+		if err := checkTagNames(operation.Tags); err != nil {
+			return err
+		}
+	}
+
+	return s.inTransaction(func(tx *sql.Tx) error {
+		for _, operation := range operations {
+			var err error
+
+			if operation.Value == nil {
+				err = s.delete(tx, operation.Key)
+			} else {
+				err = s.put(tx, operation.Key, operation.Value, operation.Tags)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Flush is a no-op since operations are never queued.
+func (s *store) Flush() error {
+	return nil
+}
+
+// Close removes the store from the open stores of the provider. The underlying database is closed by the provider.
+func (s *store) Close() error {
+	s.close(s.name)
+
+	return nil
+}
+
+func (s *store) put(tx *sql.Tx, key string, value []byte, tags []storage.Tag) error {
+	if err := s.delete(tx, key); err != nil {
+		return err
+	}
+
+	keyHash := hashOf(key)
+
+	if _, err := tx.Exec(s.queries.insertEntry, s.name, keyHash, key, value); err != nil {
+		return fmt.Errorf("failed to insert entry: %w", err)
+	}
+
+	for _, tag := range tags {
+		sortKey := query.SortKey(tag.Value)
+		if len(sortKey) > sortKeyLength {
+			sortKey = sortKey[:sortKeyLength]
+		}
+
+		_, err := tx.Exec(s.queries.insertTag, s.name, keyHash, tag.Name, tag.Value, hashOf(tag.Value), sortKey)
+		if err != nil {
+			return fmt.Errorf("failed to insert tag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *store) delete(tx *sql.Tx, key string) error {
+	keyHash := hashOf(key)
+
+	if _, err := tx.Exec(s.queries.deleteTags, s.name, keyHash); err != nil {
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+
+	if _, err := tx.Exec(s.queries.deleteEntry, s.name, keyHash); err != nil {
+		return fmt.Errorf("failed to delete entry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) inTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err = fn(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return fmt.Errorf("%w (failed to rollback transaction: %s)", err, errRollback.Error())
+		}
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+type entry struct {
+	key     string
+	value   []byte
+	sortKey string
+}
+
+// iterator fetches the matching entries page by page, each page starting after the last entry of the previous one.
+type iterator struct {
+	store      *store
+	firstQuery string
+	query      string
+	args       []interface{}
+	pageSize   int
+	page       []entry
+	current    int
+	position   *query.Position
+	done       bool
+}
+
+func (i *iterator) Next() (bool, error) {
+	if i.current+1 < len(i.page) {
+		i.current++
+
+		return true, nil
+	}
+
+	if i.done {
+		i.page = nil
+
+		return false, nil
+	}
+
+	if err := i.fetchPage(); err != nil {
+		return false, err
+	}
+
+	i.current = 0
+
+	return len(i.page) > 0, nil
+}
+
+func (i *iterator) fetchPage() error {
+	statement, args := i.firstQuery, append([]interface{}{}, i.args...)
+
+	if i.position != nil {
+		statement = i.query
+		args = append(args, i.position.SortKey, i.position.SortKey, i.position.Key)
+	}
+
+	args = append(args, i.pageSize)
+
+	rows, err := i.store.db.Query(statement, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query entries: %w", err)
+	}
+
+	defer closeRows(rows)
+
+	i.page = i.page[:0]
+
+	for rows.Next() {
+		var e entry
+
+		if err = rows.Scan(&e.key, &e.value, &e.sortKey); err != nil {
+			return fmt.Errorf("failed to scan entry: %w", err)
+		}
+
+		i.page = append(i.page, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to query entries: %w", err)
+	}
+
+	if len(i.page) < i.pageSize {
+		i.done = true
+	}
+
+	if len(i.page) > 0 {
+		last := i.page[len(i.page)-1]
+		i.position = &query.Position{SortKey: last.sortKey, Key: last.key}
+	}
+
+	return nil
+}
+
+func (i *iterator) Key() (string, error) {
+	if i.current >= len(i.page) {
+		return "", errors.New("iterator is exhausted")
+	}
+
+	return i.page[i.current].key, nil
+}
+
+func (i *iterator) Value() ([]byte, error) {
+	if i.current >= len(i.page) {
+		return nil, errors.New("iterator is exhausted")
+	}
+
+	return i.page[i.current].value, nil
+}
+
+func (i *iterator) Tags() ([]storage.Tag, error) {
+	key, err := i.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := i.store.GetTags(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags from store: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (i *iterator) Bookmark() (string, error) {
+	if i.current >= len(i.page) {
+		return "", errors.New("iterator is exhausted")
+	}
+
+	e := i.page[i.current]
+
+	return query.Position{SortKey: e.sortKey, Key: e.key}.Bookmark(), nil
+}
+
+func (i *iterator) Close() error {
+	i.page = nil
+	i.done = true
+
+	return nil
+}
+
+// checkTagNames checks that the tag names fit in the tag_name column.
+func checkTagNames(tags []storage.Tag) error {
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag.Name) > maxTagNameLength {
+			return fmt.Errorf("tag name %.32s... exceeds %d characters", tag.Name, maxTagNameLength)
+		}
+	}
+
+	return nil
+}
+
+// hashOf returns the hex encoded SHA-256 hash of s, identifying keys and tag values in the tables.
+func hashOf(s string) string {
+	hash := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func closeRows(rows *sql.Rows) {
+	// errors are reported by rows.Err() while reading the rows.
+	_ = rows.Close() // nolint:errcheck
+}
@@ -0,0 +1,294 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqldb_test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3" // registers the sqlite3 driver
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/sqldb"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	commontest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func newSQLiteProvider(t *testing.T, opts ...sqldb.Option) (*sqldb.Provider, string) {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "aries.db")
+
+	provider, err := sqldb.NewProvider(sqldb.DriverSQLite, dsn, opts...)
+	require.NoError(t, err)
+
+	return provider, dsn
+}
+
+func TestCommon(t *testing.T) {
+	provider, _ := newSQLiteProvider(t)
+
+	commontest.TestAll(t, provider)
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("unsupported driver", func(t *testing.T) {
+		_, err := sqldb.NewProvider("oracle", "")
+		require.EqualError(t, err, "unsupported database driver: oracle")
+	})
+
+	t.Run("invalid table prefix", func(t *testing.T) {
+		_, err := sqldb.NewProvider(sqldb.DriverSQLite, "", sqldb.WithTablePrefix("aries; DROP TABLE"))
+		require.EqualError(t, err, "invalid table prefix: aries; DROP TABLE")
+	})
+
+	t.Run("driver not registered", func(t *testing.T) {
+		_, err := sqldb.NewProvider(sqldb.DriverMySQL, "")
+		require.Contains(t, err.Error(), "failed to open database")
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		_, err := sqldb.NewProvider(sqldb.DriverSQLite, filepath.Join(t.TempDir(), "missing", "aries.db"))
+		require.Contains(t, err.Error(), "failed to connect to database")
+	})
+}
+
+func TestProvider_Persistence(t *testing.T) {
+	provider, dsn := newSQLiteProvider(t, sqldb.WithTablePrefix("test_"))
+
+	store, err := provider.OpenStore("StoreName")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig("storename", storage.StoreConfiguration{TagNames: []string{"tag"}}))
+	require.NoError(t, store.Put("key", []byte("value"), storage.Tag{Name: "tag", Value: "value"}))
+	require.NoError(t, provider.Close())
+
+	// the stores and their configurations are kept by the database.
+	provider, err = sqldb.NewProvider(sqldb.DriverSQLite, dsn, sqldb.WithTablePrefix("test_"))
+	require.NoError(t, err)
+
+	config, err := provider.GetStoreConfig("storeName")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tag"}, config.TagNames)
+
+	store, err = provider.OpenStore("storename")
+	require.NoError(t, err)
+
+	value, err := store.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	// tables with another prefix are not shared.
+	other, err := sqldb.NewProvider(sqldb.DriverSQLite, dsn, sqldb.WithTablePrefix("other_"))
+	require.NoError(t, err)
+
+	_, err = other.GetStoreConfig("storename")
+	require.True(t, errors.Is(err, storage.ErrStoreNotFound))
+}
+
+func TestStore_Query(t *testing.T) {
+	provider, _ := newSQLiteProvider(t)
+
+	store, err := provider.OpenStore("store")
+	require.NoError(t, err)
+
+	t.Run("pages are ordered by key", func(t *testing.T) {
+		require.NoError(t, store.Batch([]storage.Operation{
+			{Key: "key3", Value: []byte("value3"), Tags: []storage.Tag{{Name: "tag", Value: "b"}}},
+			{Key: "key1", Value: []byte("value1"), Tags: []storage.Tag{{Name: "tag", Value: "a"}}},
+			{Key: "key2", Value: []byte("value2"), Tags: []storage.Tag{{Name: "tag", Value: "a"}}},
+			{Key: "key4", Value: []byte("value4")},
+		}))
+
+		iterator, err := store.Query("tag", storage.WithPageSize(2))
+		require.NoError(t, err)
+
+		var keys []string
+
+		more, err := iterator.Next()
+		require.NoError(t, err)
+
+		for more {
+			key, err := iterator.Key()
+			require.NoError(t, err)
+
+			keys = append(keys, key)
+
+			more, err = iterator.Next()
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, []string{"key1", "key2", "key3"}, keys)
+
+		_, err = iterator.Key()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		_, err = iterator.Value()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		_, err = iterator.Tags()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		_, err = iterator.Bookmark()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("sorted pages continue after the sort key of the last entry", func(t *testing.T) {
+		require.NoError(t, store.Batch([]storage.Operation{
+			{Key: "key5", Value: []byte("value5"), Tags: []storage.Tag{{Name: "tag", Value: "2"}}},
+			{Key: "key6", Value: []byte("value6"), Tags: []storage.Tag{{Name: "tag", Value: "10"}}},
+			{Key: "key7", Value: []byte("value7"), Tags: []storage.Tag{{Name: "tag", Value: "10"}}},
+		}))
+
+		iterator, err := store.Query("tag>1||tag:a", storage.WithPageSize(1),
+			storage.WithSortingOrder(&storage.SortOptions{Order: storage.SortDescending, TagName: "tag"}))
+		require.NoError(t, err)
+
+		var keys []string
+
+		more, err := iterator.Next()
+		require.NoError(t, err)
+
+		for more {
+			key, err := iterator.Key()
+			require.NoError(t, err)
+
+			keys = append(keys, key)
+
+			more, err = iterator.Next()
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, []string{"key2", "key1", "key7", "key6", "key5"}, keys)
+		require.NoError(t, iterator.Close())
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := store.Query("")
+		require.Contains(t, err.Error(), "is not in a valid expression format")
+
+		_, err = store.Query("name:value:other")
+		require.Contains(t, err.Error(), "is not in a valid expression format")
+	})
+}
+
+func TestStore_LongValues(t *testing.T) {
+	provider, _ := newSQLiteProvider(t)
+
+	store, err := provider.OpenStore("store")
+	require.NoError(t, err)
+
+	key := "did:example:" + strings.Repeat("k", 2048)
+	tag := storage.Tag{Name: "tag", Value: strings.Repeat("v", 2048)}
+
+	require.NoError(t, store.Put(key, []byte("value"), tag))
+
+	value, err := store.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	tags, err := store.GetTags(key)
+	require.NoError(t, err)
+	require.Equal(t, []storage.Tag{tag}, tags)
+
+	iterator, err := store.Query("tag:" + tag.Value)
+	require.NoError(t, err)
+
+	more, err := iterator.Next()
+	require.NoError(t, err)
+	require.True(t, more)
+
+	k, err := iterator.Key()
+	require.NoError(t, err)
+	require.Equal(t, key, k)
+	require.NoError(t, iterator.Close())
+
+	require.NoError(t, store.Delete(key))
+
+	_, err = store.Get(key)
+	require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+	t.Run("names exceeding the column sizes", func(t *testing.T) {
+		err = store.Put("key", []byte("value"), storage.Tag{Name: strings.Repeat("t", 256)})
+		require.Contains(t, err.Error(), "exceeds 255 characters")
+
+		err = store.Batch([]storage.Operation{
+			{Key: "key", Value: []byte("value"), Tags: []storage.Tag{{Name: strings.Repeat("t", 256)}}},
+		})
+		require.Contains(t, err.Error(), "exceeds 255 characters")
+
+		_, err = provider.OpenStore(strings.Repeat("s", 129))
+		require.Contains(t, err.Error(), "exceeds 128 characters")
+	})
+}
+
+func TestStore_Batch(t *testing.T) {
+	provider, dsn := newSQLiteProvider(t)
+
+	store, err := provider.OpenStore("store")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("key", []byte("value")))
+
+	db, err := sql.Open(sqldb.DriverSQLite, dsn)
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, db.Close()) }()
+
+	_, err = db.Exec(`CREATE TRIGGER fail_insert BEFORE INSERT ON aries_entry WHEN NEW.entry_key = 'fail'
+		BEGIN SELECT RAISE(ABORT, 'insert failed'); END`)
+	require.NoError(t, err)
+
+	// the failing operation rolls back the whole batch.
+	err = store.Batch([]storage.Operation{
+		{Key: "key", Value: nil},
+		{Key: "fail", Value: []byte("value")},
+	})
+	require.Contains(t, err.Error(), "insert failed")
+
+	value, err := store.Get("key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+}
+
+func TestProvider_Closed(t *testing.T) {
+	provider, _ := newSQLiteProvider(t)
+
+	store, err := provider.OpenStore("store")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Close())
+
+	_, err = provider.OpenStore("other")
+	require.Contains(t, err.Error(), "failed to create store")
+
+	require.Contains(t, provider.SetStoreConfig("store", storage.StoreConfiguration{}).Error(),
+		"failed to get store configuration")
+
+	_, err = store.Get("key")
+	require.Contains(t, err.Error(), "failed to get entry")
+
+	_, err = store.GetTags("key")
+	require.Contains(t, err.Error(), "failed to get entry")
+
+	_, err = store.GetBulk("key")
+	require.Contains(t, err.Error(), "failed to get value for key")
+
+	require.Contains(t, store.Put("key", []byte("value")).Error(), "failed to begin transaction")
+	require.Contains(t, store.Delete("key").Error(), "failed to begin transaction")
+
+	iterator, err := store.Query("tag")
+	require.NoError(t, err)
+
+	_, err = iterator.Next()
+	require.Contains(t, err.Error(), "failed to query entries")
+}
@@ -13,13 +13,11 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-
 	failFormat                     = `failed to format %s "%s": %w`
 	failFormatData                 = `failed to format data: %w`
 	failDeformat                   = `failed to deformat %s "%s" returned from the underlying store: %w`
@@ -29,6 +27,7 @@ const (
 
 var (
 	errEmptyKey                     = errors.New("key cannot be empty")
+	errIteratorNotStarted           = errors.New("iterator is exhausted")
 	errInvalidQueryExpressionFormat = errors.New("invalid expression format. " +
 		"it must be in the following format: TagName:TagValue")
 )
@@ -334,42 +333,71 @@ func (f *formatStore) GetBulk(keys ...string) ([][]byte, error) {
 	return deformattedValues, nil
 }
 
+// Query returns all data that satisfies the expression (see spi.Store Query).
+// Since formatted tag values and keys can't be compared (e.g. they are hashed by the EDV formatter), the range
+// conditions are replaced by tag name conditions in the underlying query, and the results are filtered and sorted
+// once deformatted. All the results are therefore loaded into memory when the iteration starts.
 func (f *formatStore) Query(expression string, options ...spi.QueryOption) (spi.Iterator, error) {
-	if expression == "" {
+	q, err := query.New(expression, options...)
+	if err != nil {
 		return &formattedIterator{}, errInvalidQueryExpressionFormat
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		_, _, formattedTags, err := f.formatter.Format("", nil, spi.Tag{Name: expressionSplit[0]})
-		if err != nil {
-			return &formattedIterator{}, fmt.Errorf(failFormat, "tag name", expressionSplit[0], err)
-		}
+	formattedExpression, err := f.formatExpression(q.Expression)
+	if err != nil {
+		return &formattedIterator{}, err
+	}
 
-		underlyingIterator, err := f.store.Query(formattedTags[0].Name, options...)
-		if err != nil {
-			return &formattedIterator{}, fmt.Errorf(failQueryUnderlyingStore, err)
-		}
+	var underlyingOptions []spi.QueryOption
 
-		return &formattedIterator{underlyingIterator: underlyingIterator, formatter: f.formatter}, nil
-	case expressionTagNameAndValueLength:
-		_, _, formattedTags, err := f.formatter.Format("", nil,
-			spi.Tag{Name: expressionSplit[0], Value: expressionSplit[1]})
-		if err != nil {
-			return &formattedIterator{}, fmt.Errorf("failed to format tag: %w", err)
-		}
+	if q.Options.PageSize > 0 {
+		underlyingOptions = append(underlyingOptions, spi.WithPageSize(q.Options.PageSize))
+	}
 
-		underlyingIterator, err := f.store.Query(
-			fmt.Sprintf("%s:%s", formattedTags[0].Name, formattedTags[0].Value), options...)
-		if err != nil {
-			return &formattedIterator{}, fmt.Errorf(failQueryUnderlyingStore, err)
+	underlyingIterator, err := f.store.Query(formattedExpression, underlyingOptions...)
+	if err != nil {
+		return &formattedIterator{}, fmt.Errorf(failQueryUnderlyingStore, err)
+	}
+
+	return &formattedIterator{underlyingIterator: underlyingIterator, formatter: f.formatter, query: q}, nil
+}
+
+func (f *formatStore) formatExpression(expression query.Expression) (string, error) {
+	terms := make([]string, len(expression))
+
+	for i, term := range expression {
+		conditions := make([]string, len(term))
+
+		for j, condition := range term {
+			if condition.Operator != query.Equal {
+				_, _, formattedTags, err := f.formatter.Format("", nil, spi.Tag{Name: condition.TagName})
+				if err != nil {
+					return "", fmt.Errorf(failFormat, "tag name", condition.TagName, err)
+				}
+
+				conditions[j] = formattedTags[0].Name
+
+				continue
+			}
+
+			_, _, formattedTags, err := f.formatter.Format("", nil,
+				spi.Tag{Name: condition.TagName, Value: condition.Value})
+			if err != nil {
+				return "", fmt.Errorf("failed to format tag: %w", err)
+			}
+
+			// the formatted value could otherwise add conditions to the underlying query.
+			if err = query.CheckTagValue(formattedTags[0].Value); err != nil {
+				return "", fmt.Errorf("failed to format tag: %w", err)
+			}
+
+			conditions[j] = fmt.Sprintf("%s:%s", formattedTags[0].Name, formattedTags[0].Value)
 		}
 
-		return &formattedIterator{underlyingIterator: underlyingIterator, formatter: f.formatter}, nil
-	default:
-		return &formattedIterator{}, errInvalidQueryExpressionFormat
+		terms[i] = strings.Join(conditions, "&&")
 	}
+
+	return strings.Join(terms, "||"), nil
 }
 
 func (f *formatStore) Delete(key string) error {
@@ -442,74 +470,106 @@ func (f *formatStore) Close() error {
 	return nil
 }
 
+// formattedIterator deformats the results of the underlying iterator, then filters and sorts them
+// when the iteration starts.
 type formattedIterator struct {
 	underlyingIterator spi.Iterator
 	formatter          Formatter
+	query              *query.Query
+	results            spi.Iterator
 }
 
 func (f *formattedIterator) Next() (bool, error) {
-	nextOK, err := f.underlyingIterator.Next()
-	if err != nil {
-		return false, fmt.Errorf("failed to move the entry pointer in the underlying iterator: %w", err)
+	if f.results == nil {
+		entries, err := f.deformatAll()
+		if err != nil {
+			return false, err
+		}
+
+		f.results = query.NewIterator(f.query, entries)
 	}
 
-	return nextOK, nil
+	return f.results.Next()
 }
 
-func (f *formattedIterator) Key() (string, error) {
+func (f *formattedIterator) deformatAll() ([]query.Entry, error) {
+	var entries []query.Entry
+
+	for {
+		nextOK, err := f.underlyingIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to move the entry pointer in the underlying iterator: %w", err)
+		}
+
+		if !nextOK {
+			return entries, nil
+		}
+
+		entry, err := f.deformatCurrent()
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+}
+
+func (f *formattedIterator) deformatCurrent() (query.Entry, error) {
 	formattedKey, err := f.underlyingIterator.Key()
 	if err != nil {
-		return "", fmt.Errorf("failed to get formatted key from the underlying iterator: %w", err)
+		return query.Entry{}, fmt.Errorf("failed to get formatted key from the underlying iterator: %w", err)
 	}
 
-	// Some Formatter implementations (like EDV Encrypted Formatter) require the value to determine the deformatted key.
 	formattedValue, err := f.underlyingIterator.Value()
 	if err != nil {
-		return "", fmt.Errorf(failGetValueUnderlyingIterator, err)
+		return query.Entry{}, fmt.Errorf(failGetValueUnderlyingIterator, err)
 	}
 
-	key, _, _, err := f.formatter.Deformat(formattedKey, formattedValue, nil...)
+	formattedTags, err := f.underlyingIterator.Tags()
 	if err != nil {
-		return "", fmt.Errorf("failed to deformat formatted key from the underlying iterator: %w", err)
+		return query.Entry{}, fmt.Errorf("failed to get formatted tags from the underlying iterator: %w", err)
 	}
 
-	return key, nil
+	// Some Formatter implementations (like EDV Encrypted Formatter) require the value to determine the deformatted key
+	// and tags, since EDV tags are not reversible (they are hashed) and are embedded in the stored value itself.
+	key, value, tags, err := f.formatter.Deformat(formattedKey, formattedValue, formattedTags...)
+	if err != nil {
+		return query.Entry{}, fmt.Errorf(failDeformat, "value", string(formattedValue), err)
+	}
+
+	return query.Entry{Key: key, Value: value, Tags: tags}, nil
 }
 
-func (f *formattedIterator) Value() ([]byte, error) {
-	formattedValue, err := f.underlyingIterator.Value()
-	if err != nil {
-		return nil, fmt.Errorf(failGetValueUnderlyingIterator, err)
+func (f *formattedIterator) Key() (string, error) {
+	if f.results == nil {
+		return "", errIteratorNotStarted
 	}
 
-	_, value, _, err := f.formatter.Deformat("", formattedValue)
-	if err != nil {
-		return nil, fmt.Errorf(failDeformat, "value", string(formattedValue), err)
+	return f.results.Key()
+}
+
+func (f *formattedIterator) Value() ([]byte, error) {
+	if f.results == nil {
+		return nil, errIteratorNotStarted
 	}
 
-	return value, nil
+	return f.results.Value()
 }
 
 func (f *formattedIterator) Tags() ([]spi.Tag, error) {
-	formattedTags, err := f.underlyingIterator.Tags()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get formatted tags from the underlying iterator: %w", err)
+	if f.results == nil {
+		return nil, errIteratorNotStarted
 	}
 
-	// FormatProvider must support EDV formatting, and EDV tags are not reversible since they are hashed.
-	// Retrieving EDV tags requires embedding them in the stored value itself.
-	// In order to support this use case, formatStore calls the underlying iterator's Value method as well.
-	formattedValue, err := f.underlyingIterator.Value()
-	if err != nil {
-		return nil, fmt.Errorf(failGetValueUnderlyingIterator, err)
-	}
+	return f.results.Tags()
+}
 
-	_, _, tags, err := f.formatter.Deformat("", formattedValue, formattedTags...)
-	if err != nil {
-		return nil, fmt.Errorf(failDeformat, "value", string(formattedValue), err)
+func (f *formattedIterator) Bookmark() (string, error) {
+	if f.results == nil {
+		return "", errIteratorNotStarted
 	}
 
-	return tags, nil
+	return f.results.Bookmark()
 }
 
 func (f *formattedIterator) Close() error {
@@ -16,15 +16,21 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/formattedstore/exampleformatters"
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 	storagetest "github.com/hyperledger/aries-framework-go/test/component/storage"
 )
 
 type mockFormatter struct {
-	errFormat error
+	errFormat         error
+	formattedTagValue string
 }
 
-func (m *mockFormatter) Format(string, []byte, ...spi.Tag) (string, []byte, []spi.Tag, error) {
+func (m *mockFormatter) Format(_ string, _ []byte, tags ...spi.Tag) (string, []byte, []spi.Tag, error) {
+	if m.formattedTagValue != "" && len(tags) > 0 {
+		return "", nil, []spi.Tag{{Name: tags[0].Name, Value: m.formattedTagValue}}, nil
+	}
+
 	return "", nil, nil, m.errFormat
 }
 
@@ -242,6 +248,19 @@ func TestFormatStore_Query(t *testing.T) {
 			require.Empty(t, iterator)
 		})
 	})
+	t.Run("Formatted tag value can't be used in the underlying query", func(t *testing.T) {
+		provider := formattedstore.NewProvider(mem.NewProvider(),
+			&mockFormatter{formattedTagValue: "TagValue1||TagName2"})
+		require.NotNil(t, provider)
+
+		store, err := provider.OpenStore("StoreName")
+		require.NoError(t, err)
+		require.NotNil(t, store)
+
+		iterator, err := store.Query("TagName1:TagValue1")
+		require.True(t, errors.Is(err, query.ErrInvalidTagValue))
+		require.Empty(t, iterator)
+	})
 	t.Run("Fail to query underlying store", func(t *testing.T) {
 		provider := formattedstore.NewProvider(&mock.Provider{
 			OpenStoreReturn: &mock.Store{
@@ -338,46 +357,76 @@ func TestFormatStore_Close(t *testing.T) {
 }
 
 func TestFormattedIterator(t *testing.T) {
-	provider := formattedstore.NewProvider(&mock.Provider{OpenStoreReturn: &mock.Store{QueryReturn: &mock.Iterator{
-		ErrNext:  errors.New("next failure"),
-		ErrKey:   errors.New("key failure"),
-		ErrValue: errors.New("value failure"),
-		ErrTags:  errors.New("tags failure"),
-		ErrClose: errors.New("close failure"),
-	}}},
-		&exampleformatters.NoOpFormatter{})
-	require.NotNil(t, provider)
+	t.Run("Next: fail to move entry pointer in the underlying iterator", func(t *testing.T) {
+		iterator := queryMockIterator(t, &mock.Iterator{ErrNext: errors.New("next failure")})
 
-	store, err := provider.OpenStore("StoreName")
-	require.NoError(t, err)
-	require.NotNil(t, store)
+		ok, err := iterator.Next()
+		require.EqualError(t, err, "failed to move the entry pointer in the underlying iterator: next failure")
+		require.False(t, ok)
+	})
+	t.Run("Next: fail to get formatted key from the underlying iterator", func(t *testing.T) {
+		iterator := queryMockIterator(t, &mock.Iterator{NextReturn: true, ErrKey: errors.New("key failure")})
 
-	iterator, err := store.Query("TagName1")
-	require.NoError(t, err)
-	require.NotNil(t, iterator)
+		ok, err := iterator.Next()
+		require.EqualError(t, err, "failed to get formatted key from the underlying iterator: key failure")
+		require.False(t, ok)
+	})
+	t.Run("Next: fail to get formatted value from the underlying iterator", func(t *testing.T) {
+		iterator := queryMockIterator(t, &mock.Iterator{NextReturn: true, ErrValue: errors.New("value failure")})
 
-	t.Run("Next: fail to move entry pointer in the underlying iterator", func(t *testing.T) {
 		ok, err := iterator.Next()
-		require.EqualError(t, err, "failed to move the entry pointer in the underlying iterator: next failure")
+		require.EqualError(t, err, "failed to get formatted value from the underlying iterator: value failure")
 		require.False(t, ok)
 	})
-	t.Run("Key: fail to get formatted key from the underlying iterator", func(t *testing.T) {
+	t.Run("Next: fail to get formatted tags from the underlying iterator", func(t *testing.T) {
+		iterator := queryMockIterator(t, &mock.Iterator{NextReturn: true, ErrTags: errors.New("tags failure")})
+
+		ok, err := iterator.Next()
+		require.EqualError(t, err, "failed to get formatted tags from the underlying iterator: tags failure")
+		require.False(t, ok)
+	})
+	t.Run("Key, Value, Tags and Bookmark: iteration not started", func(t *testing.T) {
+		iterator := queryMockIterator(t, &mock.Iterator{})
+
 		key, err := iterator.Key()
-		require.EqualError(t, err, "failed to get formatted key from the underlying iterator: key failure")
+		require.EqualError(t, err, "iterator is exhausted")
 		require.Empty(t, key)
-	})
-	t.Run("Value: fail to get formatted value from the underlying iterator", func(t *testing.T) {
+
 		value, err := iterator.Value()
-		require.EqualError(t, err, "failed to get formatted value from the underlying iterator: value failure")
+		require.EqualError(t, err, "iterator is exhausted")
 		require.Nil(t, value)
-	})
-	t.Run("Tags: fail to get formatted tags from the underlying iterator", func(t *testing.T) {
+
 		tags, err := iterator.Tags()
-		require.EqualError(t, err, "failed to get formatted tags from the underlying iterator: tags failure")
+		require.EqualError(t, err, "iterator is exhausted")
 		require.Nil(t, tags)
+
+		bookmark, err := iterator.Bookmark()
+		require.EqualError(t, err, "iterator is exhausted")
+		require.Empty(t, bookmark)
 	})
 	t.Run("Close: fail to close underlying iterator", func(t *testing.T) {
+		iterator := queryMockIterator(t, &mock.Iterator{ErrClose: errors.New("close failure")})
+
 		err := iterator.Close()
 		require.EqualError(t, err, "failed to close underlying iterator: close failure")
 	})
 }
+
+func queryMockIterator(t *testing.T, underlyingIterator *mock.Iterator) spi.Iterator {
+	t.Helper()
+
+	provider := formattedstore.NewProvider(&mock.Provider{OpenStoreReturn: &mock.Store{
+		QueryReturn: underlyingIterator,
+	}}, &exampleformatters.NoOpFormatter{})
+	require.NotNil(t, provider)
+
+	store, err := provider.OpenStore("StoreName")
+	require.NoError(t, err)
+	require.NotNil(t, store)
+
+	iterator, err := store.Query("TagName1")
+	require.NoError(t, err)
+	require.NotNil(t, iterator)
+
+	return iterator
+}
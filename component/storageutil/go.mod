@@ -8,9 +8,15 @@ go 1.15
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210224230531-58e1368e5661
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261019084901-66f4e01095f2
 	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20210226235232-298aa129d822
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/crypto v0.0.0-20201002170205-7f63de1d35b0
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 )
+
+replace (
+	github.com/hyperledger/aries-framework-go/spi => ../../spi
+	github.com/hyperledger/aries-framework-go/test/component => ../../test/component
+)
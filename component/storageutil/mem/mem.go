@@ -9,23 +9,15 @@ package mem
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
-const (
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-)
-
-var (
-	errEmptyKey                     = errors.New("key cannot be empty")
-	errInvalidQueryExpressionFormat = errors.New("invalid expression format. " +
-		"it must be in the following format: TagName:TagValue")
-	errIteratorExhausted = errors.New("iterator is exhausted")
-)
+var errEmptyKey = errors.New("key cannot be empty")
 
 // Provider represents an in-memory implementation of the spi.Provider interface.
 type Provider struct {
@@ -113,6 +105,22 @@ func (p *Provider) GetOpenStores() []spi.Store {
 	return openStores
 }
 
+// StoreNames returns the names of all stores held by this provider, in sorted order.
+func (p *Provider) StoreNames() ([]string, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	names := make([]string, 0, len(p.dbs))
+
+	for name := range p.dbs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
 // Close closes all stores created under this store provider.
 func (p *Provider) Close() error {
 	p.lock.Lock()
@@ -230,39 +238,40 @@ func (m *memStore) GetBulk(keys ...string) ([][]byte, error) {
 	return values, nil
 }
 
-// Query returns all data that satisfies the expression. Expression format: TagName:TagValue.
-// If TagValue is not provided, then all data associated with the TagName will be returned.
-// For now, expression can only be a single tag Name + Value pair.
-// memStore does not make use of spi.QueryOptions.
-func (m *memStore) Query(expression string, _ ...spi.QueryOption) (spi.Iterator, error) {
-	if expression == "" {
-		return nil, errInvalidQueryExpressionFormat
+// Query returns all data that satisfies the expression (see spi.Store Query).
+// memStore does not make use of the spi.QueryOptions page size, since the data is already in memory.
+func (m *memStore) Query(expression string, options ...spi.QueryOption) (spi.Iterator, error) {
+	q, err := query.New(expression, options...)
+	if err != nil {
+		return nil, err
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName := expressionSplit[0]
+	m.RLock()
+	defer m.RUnlock()
 
-		m.RLock()
-		defer m.RUnlock()
+	entries := make([]query.Entry, 0, len(m.db))
 
-		keys, dbEntries := m.getMatchingKeysAndDBEntries(expressionTagName, "")
+	for key, dbEntry := range m.db {
+		entries = append(entries, query.Entry{Key: key, Value: dbEntry.value, Tags: dbEntry.tags})
+	}
 
-		return &memIterator{keys: keys, dbEntries: dbEntries}, nil
-	case expressionTagNameAndValueLength:
-		expressionTagName := expressionSplit[0]
-		expressionTagValue := expressionSplit[1]
+	return query.NewIterator(q, entries), nil
+}
 
-		m.RLock()
-		defer m.RUnlock()
+// Keys returns every key in the store, including the keys of entries without tags, in sorted order.
+func (m *memStore) Keys() ([]string, error) {
+	m.RLock()
+	defer m.RUnlock()
 
-		keys, dbEntries := m.getMatchingKeysAndDBEntries(expressionTagName, expressionTagValue)
+	keys := make([]string, 0, len(m.db))
 
-		return &memIterator{keys: keys, dbEntries: dbEntries}, nil
-	default:
-		return nil, errInvalidQueryExpressionFormat
+	for key := range m.db {
+		keys = append(keys, key)
 	}
+
+	sort.Strings(keys)
+
+	return keys, nil
 }
 
 // Delete deletes the key + value pair (and all tags) associated with key.
@@ -317,82 +326,3 @@ func (m *memStore) Close() error {
 func (m *memStore) Flush() error {
 	return nil
 }
-
-func (m *memStore) getMatchingKeysAndDBEntries(tagName, tagValue string) ([]string, []dbEntry) {
-	var matchAnyValue bool
-	if tagValue == "" {
-		matchAnyValue = true
-	}
-
-	var keys []string
-
-	var dbEntries []dbEntry
-
-	for key, dbEntry := range m.db {
-		for _, tag := range dbEntry.tags {
-			if tag.Name == tagName && (matchAnyValue || tag.Value == tagValue) {
-				keys = append(keys, key)
-				dbEntries = append(dbEntries, dbEntry)
-
-				break
-			}
-		}
-	}
-
-	return keys, dbEntries
-}
-
-// memIterator represents a snapshot of some set of entries in a memStore.
-type memIterator struct {
-	currentIndex   int
-	currentKey     string
-	currentDBEntry dbEntry
-	keys           []string
-	dbEntries      []dbEntry
-}
-
-// Next moves the pointer to the next entry in the iterator. It returns false if the iterator is exhausted.
-func (m *memIterator) Next() (bool, error) {
-	if len(m.dbEntries) == m.currentIndex || len(m.dbEntries) == 0 {
-		m.dbEntries = nil
-		return false, nil
-	}
-
-	m.currentKey = m.keys[m.currentIndex]
-	m.currentDBEntry = m.dbEntries[m.currentIndex]
-	m.currentIndex++
-
-	return true, nil
-}
-
-// Key returns the key of the current entry.
-func (m *memIterator) Key() (string, error) {
-	if len(m.dbEntries) == 0 {
-		return "", errIteratorExhausted
-	}
-
-	return m.currentKey, nil
-}
-
-// Value returns the value of the current entry.
-func (m *memIterator) Value() ([]byte, error) {
-	if len(m.dbEntries) == 0 {
-		return nil, errIteratorExhausted
-	}
-
-	return m.currentDBEntry.value, nil
-}
-
-// Tags returns the tags associated with the key of the current entry.
-func (m *memIterator) Tags() ([]spi.Tag, error) {
-	if len(m.dbEntries) == 0 {
-		return nil, errIteratorExhausted
-	}
-
-	return m.currentDBEntry.tags, nil
-}
-
-// Close is a no-op, since there's nothing to close for a memIterator.
-func (m *memIterator) Close() error {
-	return nil
-}
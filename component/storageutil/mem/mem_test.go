@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 	storagetest "github.com/hyperledger/aries-framework-go/test/component/storage"
 )
 
@@ -42,3 +43,24 @@ func TestMemIterator(t *testing.T) {
 	require.EqualError(t, err, "iterator is exhausted")
 	require.Nil(t, tags)
 }
+
+func TestMemListing(t *testing.T) {
+	provider := mem.NewProvider()
+
+	store, err := provider.OpenStore("StoreB")
+	require.NoError(t, err)
+
+	_, err = provider.OpenStore("storea")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("key2", []byte("value2")))
+	require.NoError(t, store.Put("key1", []byte("value1"), spi.Tag{Name: "tag"}))
+
+	names, err := provider.StoreNames()
+	require.NoError(t, err)
+	require.Equal(t, []string{"storea", "storeb"}, names)
+
+	keys, err := store.(interface{ Keys() ([]string, error) }).Keys()
+	require.NoError(t, err)
+	require.Equal(t, []string{"key1", "key2"}, keys)
+}
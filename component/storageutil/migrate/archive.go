@@ -0,0 +1,384 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migrate
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	archiveFormat  = "aries-storage-backup"
+	archiveVersion = 1
+
+	recordStore    = "store"
+	recordEntry    = "entry"
+	recordChecksum = "checksum"
+	recordEnd      = "end"
+)
+
+// ErrInvalidArchive is returned when a backup archive is malformed or truncated.
+var ErrInvalidArchive = errors.New("invalid backup archive")
+
+// archiveHeader is the first line of an archive. The rest of the archive is a sequence of JSON records, encrypted if
+// the header has encryption parameters.
+type archiveHeader struct {
+	Format     string            `json:"format"`
+	Version    int               `json:"version"`
+	Encryption *encryptionParams `json:"encryption,omitempty"`
+}
+
+type record struct {
+	Type     string                  `json:"type"`
+	Store    string                  `json:"store,omitempty"`
+	Config   *spi.StoreConfiguration `json:"config,omitempty"`
+	Key      string                  `json:"key,omitempty"`
+	Value    []byte                  `json:"value,omitempty"`
+	Tags     []spi.Tag               `json:"tags,omitempty"`
+	Entries  int                     `json:"entries,omitempty"`
+	Checksum string                  `json:"checksum,omitempty"`
+}
+
+// Backup writes the stores of the source provider, along with their configurations, to a backup archive. The archive
+// is encrypted if a passphrase is set with WithPassphrase.
+func Backup(source spi.Provider, w io.Writer, opts ...Option) (*Report, error) {
+	options := getOptions(opts)
+
+	storeNames, err := getStoreNames(source, options.storeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	header := archiveHeader{Format: archiveFormat, Version: archiveVersion}
+
+	if len(options.passphrase) > 0 {
+		header.Encryption, err = newEncryptionParams()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal archive header: %w", err)
+	}
+
+	_, err = w.Write(append(headerBytes, '\n'))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write archive header: %w", err)
+	}
+
+	var bodyWriter io.WriteCloser = nopWriteCloser{w}
+
+	if header.Encryption != nil {
+		bodyWriter, err = newEncryptingWriter(w, options.passphrase, header.Encryption, headerBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report, err := backup(source, json.NewEncoder(bodyWriter), storeNames, options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bodyWriter.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	if options.snapshotAttempts > 0 {
+		err = verifySnapshot(source, report, options.pageSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func backup(source spi.Provider, encoder *json.Encoder, storeNames []string, options *options) (*Report, error) {
+	report := &Report{}
+
+	for _, name := range storeNames {
+		storeReport, err := backupStore(source, encoder, name, options.pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to back up store %s: %w", name, err)
+		}
+
+		report.Stores = append(report.Stores, storeReport)
+	}
+
+	err := encoder.Encode(record{Type: recordEnd})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return report, nil
+}
+
+func backupStore(source spi.Provider, encoder *json.Encoder, name string, pageSize int) (StoreReport, error) {
+	store, config, err := openStore(source, name)
+	if err != nil {
+		return StoreReport{}, err
+	}
+
+	keys, err := ListKeys(store, config, pageSize)
+	if err != nil {
+		return StoreReport{}, err
+	}
+
+	err = encoder.Encode(record{Type: recordStore, Store: name, Config: &config})
+	if err != nil {
+		return StoreReport{}, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	entryHash := sha256.New()
+	storeReport := StoreReport{Name: name}
+
+	for _, key := range keys {
+		value, tags, err := getEntry(store, key)
+		if errors.Is(err, spi.ErrDataNotFound) {
+			continue // Deleted since the keys were listed.
+		}
+
+		if err != nil {
+			return StoreReport{}, err
+		}
+
+		hashEntry(entryHash, key, value, tags)
+
+		err = encoder.Encode(record{Type: recordEntry, Key: key, Value: value, Tags: tags})
+		if err != nil {
+			return StoreReport{}, fmt.Errorf("failed to write archive: %w", err)
+		}
+
+		storeReport.Entries++
+	}
+
+	storeReport.Checksum = hex.EncodeToString(entryHash.Sum(nil))
+
+	err = encoder.Encode(record{Type: recordChecksum, Entries: storeReport.Entries, Checksum: storeReport.Checksum})
+	if err != nil {
+		return StoreReport{}, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return storeReport, nil
+}
+
+// Restore writes the stores of a backup archive, along with their configurations, to the target provider. The
+// checksum of every store is verified against the archive. WithStoreNames restricts the restored stores and
+// WithPassphrase must be set for an encrypted archive.
+func Restore(r io.Reader, target spi.Provider, opts ...Option) (*Report, error) {
+	options := getOptions(opts)
+
+	reader := bufio.NewReader(r)
+
+	headerBytes, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive header: %w", ErrInvalidArchive)
+	}
+
+	headerBytes = headerBytes[:len(headerBytes)-1]
+
+	var header archiveHeader
+
+	err = json.Unmarshal(headerBytes, &header)
+	if err != nil || header.Format != archiveFormat {
+		return nil, fmt.Errorf("not a backup archive: %w", ErrInvalidArchive)
+	}
+
+	if header.Version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d: %w", header.Version, ErrInvalidArchive)
+	}
+
+	var body io.Reader = reader
+
+	switch {
+	case header.Encryption != nil && len(options.passphrase) == 0:
+		return nil, errors.New("a passphrase is required to restore an encrypted archive")
+	case header.Encryption == nil && len(options.passphrase) > 0:
+		return nil, errors.New("a passphrase was given but the archive is not encrypted")
+	case header.Encryption != nil:
+		body, err = newDecryptingReader(reader, options.passphrase, header.Encryption, headerBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	progressStore, err := target.OpenStore(ProgressStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the progress store: %w", err)
+	}
+
+	restorer := &restorer{
+		decoder:       json.NewDecoder(body),
+		target:        target,
+		progressStore: progressStore,
+		options:       options,
+		report:        &Report{},
+	}
+
+	err = restorer.restore()
+	if err != nil {
+		return nil, err
+	}
+
+	return restorer.report, nil
+}
+
+type restorer struct {
+	decoder       *json.Decoder
+	target        spi.Provider
+	progressStore spi.Store
+	options       *options
+	report        *Report
+}
+
+func (r *restorer) restore() error {
+	for {
+		var storeRecord record
+
+		err := r.next(&storeRecord)
+		if err != nil {
+			return err
+		}
+
+		switch storeRecord.Type {
+		case recordEnd:
+			return nil
+		case recordStore:
+			err = r.restoreStore(storeRecord)
+			if err != nil {
+				return fmt.Errorf("failed to restore store %s: %w", storeRecord.Store, err)
+			}
+		default:
+			return fmt.Errorf("unexpected %s record: %w", storeRecord.Type, ErrInvalidArchive)
+		}
+	}
+}
+
+func (r *restorer) restoreStore(storeRecord record) error {
+	var (
+		writer *storeWriter
+		err    error
+	)
+
+	if r.isSelected(storeRecord.Store) {
+		var config spi.StoreConfiguration
+
+		if storeRecord.Config != nil {
+			config = *storeRecord.Config
+		}
+
+		writer, err = newStoreWriter(r.target, r.progressStore, storeRecord.Store, config, r.options)
+		if err != nil {
+			return err
+		}
+	}
+
+	entryHash := sha256.New()
+	entries := 0
+
+	for {
+		var entryRecord record
+
+		err = r.next(&entryRecord)
+		if err != nil {
+			return err
+		}
+
+		switch entryRecord.Type {
+		case recordEntry:
+			entries++
+
+			err = r.restoreEntry(writer, entryHash, entryRecord)
+			if err != nil {
+				return err
+			}
+		case recordChecksum:
+			checksum := hex.EncodeToString(entryHash.Sum(nil))
+			if checksum != entryRecord.Checksum || entries != entryRecord.Entries {
+				return fmt.Errorf("archive: %w", ErrChecksumMismatch)
+			}
+
+			if writer == nil {
+				return nil
+			}
+
+			if !writer.state.Complete {
+				err = writer.finish(checksum)
+				if err != nil {
+					return err
+				}
+			}
+
+			r.report.Stores = append(r.report.Stores, writer.report())
+
+			return nil
+		default:
+			return fmt.Errorf("unexpected %s record: %w", entryRecord.Type, ErrInvalidArchive)
+		}
+	}
+}
+
+func (r *restorer) restoreEntry(writer *storeWriter, entryHash hash.Hash, entryRecord record) error {
+	if entryRecord.Value == nil {
+		entryRecord.Value = []byte{}
+	}
+
+	hashEntry(entryHash, entryRecord.Key, entryRecord.Value, entryRecord.Tags)
+
+	if writer == nil || writer.state.Complete {
+		return nil
+	}
+
+	return writer.write(entryRecord.Key, entryRecord.Value, entryRecord.Tags)
+}
+
+func (r *restorer) next(rec *record) error {
+	err := r.decoder.Decode(rec)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("archive is truncated: %w", ErrInvalidArchive)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	return nil
+}
+
+func (r *restorer) isSelected(name string) bool {
+	if len(r.options.storeNames) == 0 {
+		return true
+	}
+
+	for _, storeName := range r.options.storeNames {
+		if storeName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migrate_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	t.Run("Unencrypted archive", func(t *testing.T) {
+		source := newSourceProvider(t)
+
+		var archive bytes.Buffer
+
+		backupReport, err := migrate.Backup(source, &archive, migrate.WithSnapshot(1))
+		require.NoError(t, err)
+		require.Contains(t, archive.String(), "conn1")
+
+		target := mem.NewProvider()
+
+		restoreReport, err := migrate.Restore(&archive, target)
+		require.NoError(t, err)
+		require.Equal(t, backupReport, restoreReport)
+
+		requireSameStores(t, source, target, "connections", "keys")
+
+		config, err := target.GetStoreConfig("connections")
+		require.NoError(t, err)
+		require.Equal(t, []string{"state", "theirDID"}, config.TagNames)
+	})
+	t.Run("Encrypted archive", func(t *testing.T) {
+		source := newSourceProvider(t)
+		passphrase := []byte("passphrase")
+
+		var archive bytes.Buffer
+
+		_, err := migrate.Backup(source, &archive, migrate.WithPassphrase(passphrase))
+		require.NoError(t, err)
+		require.NotContains(t, archive.String(), "conn1")
+
+		_, err = migrate.Restore(bytes.NewReader(archive.Bytes()), mem.NewProvider())
+		require.EqualError(t, err, "a passphrase is required to restore an encrypted archive")
+
+		_, err = migrate.Restore(bytes.NewReader(archive.Bytes()), mem.NewProvider(),
+			migrate.WithPassphrase([]byte("wrong")))
+		require.EqualError(t, err,
+			"failed to read archive: failed to decrypt archive: wrong passphrase or corrupted archive")
+
+		// the key derivation parameters are checked before the header can be authenticated.
+		for name, tampered := range map[string]string{
+			"cost":      strings.Replace(archive.String(), `"n":32768`, `"n":1073741824`, 1),
+			"block":     strings.Replace(archive.String(), `"r":8`, `"r":1024`, 1),
+			"threads":   strings.Replace(archive.String(), `"p":1`, `"p":64`, 1),
+			"salt size": strings.Replace(archive.String(), `"salt":"`, `"salt":"AAAA`, 1),
+		} {
+			_, err = migrate.Restore(strings.NewReader(tampered), mem.NewProvider(), migrate.WithPassphrase(passphrase))
+			require.True(t, errors.Is(err, migrate.ErrInvalidArchive), name)
+			require.Contains(t, err.Error(), "unsupported scrypt parameters", name)
+		}
+
+		truncated := archive.Bytes()[:archive.Len()-1]
+
+		_, err = migrate.Restore(bytes.NewReader(truncated), mem.NewProvider(), migrate.WithPassphrase(passphrase))
+		require.True(t, errors.Is(err, migrate.ErrInvalidArchive))
+
+		target := mem.NewProvider()
+
+		report, err := migrate.Restore(bytes.NewReader(archive.Bytes()), target,
+			migrate.WithPassphrase(passphrase), migrate.WithStoreNames("keys"))
+		require.NoError(t, err)
+		require.Len(t, report.Stores, 1)
+
+		requireSameStores(t, source, target, "keys")
+
+		names, err := target.StoreNames()
+		require.NoError(t, err)
+		require.Equal(t, []string{"keys", migrate.ProgressStoreName}, names)
+	})
+	t.Run("Resume restore", func(t *testing.T) {
+		source := newSourceProvider(t)
+
+		var archive bytes.Buffer
+
+		_, err := migrate.Backup(source, &archive)
+		require.NoError(t, err)
+
+		target := &failingProvider{Provider: mem.NewProvider(), failPuts: 1}
+
+		_, err = migrate.Restore(bytes.NewReader(archive.Bytes()), target, migrate.WithPageSize(1))
+		require.EqualError(t, err, "failed to restore store connections: failed to write conn2: put failed")
+
+		report, err := migrate.Restore(bytes.NewReader(archive.Bytes()), target,
+			migrate.WithPageSize(1), migrate.WithResume())
+		require.NoError(t, err)
+		require.Equal(t, 3, report.Stores[0].Entries)
+		require.Equal(t, 5, target.puts)
+
+		requireSameStores(t, source, target.Provider, "connections", "keys")
+	})
+	t.Run("Invalid archives", func(t *testing.T) {
+		var archive bytes.Buffer
+
+		_, err := migrate.Backup(newSourceProvider(t), &archive)
+		require.NoError(t, err)
+
+		for name, invalid := range map[string]string{
+			"empty":      "",
+			"not JSON":   "archive\n",
+			"version":    strings.Replace(archive.String(), `"version":1`, `"version":2`, 1),
+			"truncated":  archive.String()[:archive.Len()-20],
+			"unexpected": strings.Replace(archive.String(), `"type":"end"`, `"type":"entry"`, 1),
+		} {
+			_, err = migrate.Restore(strings.NewReader(invalid), mem.NewProvider())
+			require.True(t, errors.Is(err, migrate.ErrInvalidArchive), name)
+		}
+
+		tampered := strings.Replace(archive.String(), `"key":"key1"`, `"key":"key0"`, 1)
+
+		_, err = migrate.Restore(strings.NewReader(tampered), mem.NewProvider())
+		require.True(t, errors.Is(err, migrate.ErrChecksumMismatch))
+
+		_, err = migrate.Restore(&archive, mem.NewProvider(), migrate.WithPassphrase([]byte("passphrase")))
+		require.EqualError(t, err, "a passphrase was given but the archive is not encrypted")
+	})
+}
@@ -0,0 +1,259 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migrate
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// An encrypted archive body is a sequence of segments, each made of a final flag byte, the big-endian length of its
+// ciphertext and its AES-256-GCM ciphertext. The nonce of a segment is the nonce prefix of the archive followed by the
+// segment number and the final flag, so that segments cannot be reordered, dropped or truncated unnoticed.
+const (
+	encryptionAlgorithm = "A256GCM"
+	kdfAlgorithm        = "scrypt"
+
+	scryptN   = 1 << 15
+	scryptR   = 8
+	scryptP   = 1
+	keySize   = 32
+	saltSize  = 16
+	prefixLen = 7
+
+	segmentSize = 64 * 1024
+)
+
+var errDecrypt = errors.New("failed to decrypt archive: wrong passphrase or corrupted archive")
+
+type encryptionParams struct {
+	Algorithm   string `json:"alg"`
+	KDF         string `json:"kdf"`
+	Salt        []byte `json:"salt"`
+	N           int    `json:"n"`
+	R           int    `json:"r"`
+	P           int    `json:"p"`
+	NoncePrefix []byte `json:"noncePrefix"`
+}
+
+func newEncryptionParams() (*encryptionParams, error) {
+	params := &encryptionParams{
+		Algorithm:   encryptionAlgorithm,
+		KDF:         kdfAlgorithm,
+		Salt:        make([]byte, saltSize),
+		N:           scryptN,
+		R:           scryptR,
+		P:           scryptP,
+		NoncePrefix: make([]byte, prefixLen),
+	}
+
+	_, err := rand.Read(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	_, err = rand.Read(params.NoncePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce prefix: %w", err)
+	}
+
+	return params, nil
+}
+
+func newAEAD(passphrase []byte, params *encryptionParams) (cipher.AEAD, error) {
+	if params.Algorithm != encryptionAlgorithm || params.KDF != kdfAlgorithm || len(params.NoncePrefix) != prefixLen {
+		return nil, fmt.Errorf("unsupported archive encryption %s with %s: %w",
+			params.Algorithm, params.KDF, ErrInvalidArchive)
+	}
+
+	// the header is only authenticated once the key is derived, so the cost of the derivation must not be
+	// chosen by the archive.
+	if params.N != scryptN || params.R != scryptR || params.P != scryptP || len(params.Salt) != saltSize {
+		return nil, fmt.Errorf("unsupported scrypt parameters N=%d, r=%d, p=%d: %w",
+			params.N, params.R, params.P, ErrInvalidArchive)
+	}
+
+	key, err := scrypt.Key(passphrase, params.Salt, params.N, params.R, params.P, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive archive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive cipher: %w", err)
+	}
+
+	return aead, nil
+}
+
+func segmentNonce(prefix []byte, counter uint32, final bool) []byte {
+	nonce := make([]byte, 0, prefixLen+5) // nolint:gomnd // The counter and the final flag.
+	nonce = append(nonce, prefix...)
+	nonce = append(nonce, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(nonce[prefixLen:], counter)
+
+	if final {
+		return append(nonce, 1)
+	}
+
+	return append(nonce, 0)
+}
+
+type encryptingWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	aad     []byte
+	counter uint32
+	buffer  []byte
+}
+
+// newEncryptingWriter returns a writer encrypting the archive body. The archive header is authenticated along with
+// every segment. Close must be called to write the final segment.
+func newEncryptingWriter(w io.Writer, passphrase []byte, params *encryptionParams,
+	header []byte) (io.WriteCloser, error) {
+	aead, err := newAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptingWriter{
+		w:      w,
+		aead:   aead,
+		prefix: params.NoncePrefix,
+		aad:    header,
+		buffer: make([]byte, 0, segmentSize),
+	}, nil
+}
+
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		if len(e.buffer) == segmentSize {
+			err := e.writeSegment(false)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(e.buffer[len(e.buffer):segmentSize], p)
+		e.buffer = e.buffer[:len(e.buffer)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+func (e *encryptingWriter) Close() error {
+	return e.writeSegment(true)
+}
+
+func (e *encryptingWriter) writeSegment(final bool) error {
+	ciphertext := e.aead.Seal(nil, segmentNonce(e.prefix, e.counter, final), e.buffer, e.aad)
+
+	segmentHeader := make([]byte, 5) // nolint:gomnd // The final flag and the length.
+	if final {
+		segmentHeader[0] = 1
+	}
+
+	binary.BigEndian.PutUint32(segmentHeader[1:], uint32(len(ciphertext)))
+
+	_, err := e.w.Write(append(segmentHeader, ciphertext...))
+	if err != nil {
+		return err
+	}
+
+	e.counter++
+	e.buffer = e.buffer[:0]
+
+	return nil
+}
+
+type decryptingReader struct {
+	r         io.Reader
+	aead      cipher.AEAD
+	prefix    []byte
+	aad       []byte
+	counter   uint32
+	plaintext []byte
+	final     bool
+}
+
+func newDecryptingReader(r io.Reader, passphrase []byte, params *encryptionParams,
+	header []byte) (io.Reader, error) {
+	aead, err := newAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptingReader{r: r, aead: aead, prefix: params.NoncePrefix, aad: header}, nil
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for len(d.plaintext) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+
+		err := d.readSegment()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.plaintext)
+	d.plaintext = d.plaintext[n:]
+
+	return n, nil
+}
+
+func (d *decryptingReader) readSegment() error {
+	segmentHeader := make([]byte, 5) // nolint:gomnd // The final flag and the length.
+
+	_, err := io.ReadFull(d.r, segmentHeader)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	length := binary.BigEndian.Uint32(segmentHeader[1:])
+	if length > segmentSize+uint32(d.aead.Overhead()) {
+		return errDecrypt
+	}
+
+	ciphertext := make([]byte, length)
+
+	_, err = io.ReadFull(d.r, ciphertext)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+
+	final := segmentHeader[0] == 1
+
+	d.plaintext, err = d.aead.Open(nil, segmentNonce(d.prefix, d.counter, final), ciphertext, d.aad)
+	if err != nil {
+		return errDecrypt
+	}
+
+	d.counter++
+	d.final = final
+
+	return nil
+}
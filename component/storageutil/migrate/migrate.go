@@ -0,0 +1,568 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package migrate copies stores, along with their configurations, from one storage provider to another, either
+// directly or through a (optionally encrypted) backup archive.
+//
+// The entries of a store are found through its KeyLister implementation, if it has one. Otherwise they are found by
+// querying the store for each tag name in its configuration, in which case entries without any configured tag cannot
+// be found and are not copied.
+//
+// Every store is copied in key order and its progress is recorded in the ProgressStoreName store of the target
+// provider, so that an interrupted copy can be resumed with WithResume. Once a store has been copied, its entries are
+// read back from the target provider and their checksum is compared with the checksum of the copied entries.
+// Entries already in the target provider that are not in the source are left in place.
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"sort"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// ProgressStoreName is the name of the store in which the target provider records the progress of a migration.
+const ProgressStoreName = "storagemigrationprogress"
+
+const (
+	defaultPageSize         = 100
+	defaultSnapshotAttempts = 3
+)
+
+var (
+	// ErrChecksumMismatch is returned when the entries of a store differ from the entries that were copied.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrSourceModified is returned when a consistent snapshot was requested but the source provider kept changing
+	// while it was being copied.
+	ErrSourceModified = errors.New("source was modified while it was being copied")
+)
+
+// StoreLister is implemented by providers that can list the names of all the stores they hold.
+type StoreLister interface {
+	StoreNames() ([]string, error)
+}
+
+// KeyLister is implemented by stores that can list all of their keys, including the keys of entries without tags.
+type KeyLister interface {
+	Keys() ([]string, error)
+}
+
+// StoreReport describes a copied store.
+type StoreReport struct {
+	Name     string `json:"name"`
+	Entries  int    `json:"entries"`
+	Checksum string `json:"checksum"`
+	// Resumed is set if the store had already been copied completely by an earlier, interrupted, run.
+	Resumed bool `json:"resumed,omitempty"`
+}
+
+// Report describes the stores copied by Migrate, Backup or Restore.
+type Report struct {
+	Stores []StoreReport `json:"stores"`
+}
+
+type options struct {
+	storeNames       []string
+	pageSize         int
+	snapshotAttempts int
+	resume           bool
+	passphrase       []byte
+}
+
+// Option configures Migrate, Backup and Restore.
+type Option func(opts *options)
+
+// WithStoreNames sets the names of the stores to copy. By default, all the stores of the source provider are copied,
+// which requires the source provider to implement StoreLister.
+func WithStoreNames(names ...string) Option {
+	return func(opts *options) {
+		opts.storeNames = names
+	}
+}
+
+// WithPageSize sets the page size of the queries used to find entries and the number of entries written between two
+// saves of the progress.
+func WithPageSize(size int) Option {
+	return func(opts *options) {
+		opts.pageSize = size
+	}
+}
+
+// WithSnapshot requests a consistent snapshot of the source provider. Once all the stores have been copied, they are
+// read again from the source provider and the copy is started over, up to the given number of attempts in total, if
+// any of them was modified in the meantime. Backup cannot start over and fails on the first modification.
+func WithSnapshot(attempts int) Option {
+	if attempts < 1 {
+		attempts = defaultSnapshotAttempts
+	}
+
+	return func(opts *options) {
+		opts.snapshotAttempts = attempts
+	}
+}
+
+// WithResume resumes an earlier, interrupted, run from the progress recorded in the target provider.
+func WithResume() Option {
+	return func(opts *options) {
+		opts.resume = true
+	}
+}
+
+// WithPassphrase sets the passphrase with which Backup encrypts the archive and Restore decrypts it.
+func WithPassphrase(passphrase []byte) Option {
+	return func(opts *options) {
+		opts.passphrase = passphrase
+	}
+}
+
+func getOptions(opts []Option) *options {
+	options := &options{pageSize: defaultPageSize}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.pageSize <= 0 {
+		options.pageSize = defaultPageSize
+	}
+
+	return options
+}
+
+// Migrate copies the stores of the source provider, along with their configurations, to the target provider.
+func Migrate(source, target spi.Provider, opts ...Option) (*Report, error) {
+	options := getOptions(opts)
+
+	storeNames, err := getStoreNames(source, options.storeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		report, err := migrate(source, target, storeNames, options)
+		if err != nil || options.snapshotAttempts == 0 {
+			return report, err
+		}
+
+		err = verifySnapshot(source, report, options.pageSize)
+		if err == nil || !errors.Is(err, ErrSourceModified) || attempt >= options.snapshotAttempts {
+			return report, err
+		}
+
+		// Resumed stores may have been copied before the modification, so the next attempt starts over.
+		options.resume = false
+	}
+}
+
+func migrate(source, target spi.Provider, storeNames []string, options *options) (*Report, error) {
+	progressStore, err := target.OpenStore(ProgressStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the progress store: %w", err)
+	}
+
+	report := &Report{}
+
+	for _, name := range storeNames {
+		storeReport, err := migrateStore(source, target, progressStore, name, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate store %s: %w", name, err)
+		}
+
+		report.Stores = append(report.Stores, storeReport)
+	}
+
+	return report, nil
+}
+
+func migrateStore(source, target spi.Provider, progressStore spi.Store, name string,
+	options *options) (StoreReport, error) {
+	sourceStore, config, err := openStore(source, name)
+	if err != nil {
+		return StoreReport{}, err
+	}
+
+	writer, err := newStoreWriter(target, progressStore, name, config, options)
+	if err != nil {
+		return StoreReport{}, err
+	}
+
+	if writer.state.Complete {
+		return writer.report(), nil
+	}
+
+	keys, err := ListKeys(sourceStore, config, options.pageSize)
+	if err != nil {
+		return StoreReport{}, err
+	}
+
+	entryHash := sha256.New()
+
+	for _, key := range keys {
+		value, tags, err := getEntry(sourceStore, key)
+		if errors.Is(err, spi.ErrDataNotFound) {
+			continue // Deleted since the keys were listed.
+		}
+
+		if err != nil {
+			return StoreReport{}, err
+		}
+
+		hashEntry(entryHash, key, value, tags)
+
+		err = writer.write(key, value, tags)
+		if err != nil {
+			return StoreReport{}, err
+		}
+	}
+
+	err = writer.finish(hex.EncodeToString(entryHash.Sum(nil)))
+	if err != nil {
+		return StoreReport{}, err
+	}
+
+	return writer.report(), nil
+}
+
+func verifySnapshot(source spi.Provider, report *Report, pageSize int) error {
+	for _, storeReport := range report.Stores {
+		store, config, err := openStore(source, storeReport.Name)
+		if err != nil {
+			return err
+		}
+
+		keys, err := ListKeys(store, config, pageSize)
+		if err != nil {
+			return err
+		}
+
+		checksum, err := storeChecksum(store, keys, true)
+		if err != nil {
+			return fmt.Errorf("failed to verify store %s: %w", storeReport.Name, err)
+		}
+
+		if checksum != storeReport.Checksum {
+			return fmt.Errorf("store %s: %w", storeReport.Name, ErrSourceModified)
+		}
+	}
+
+	return nil
+}
+
+func getStoreNames(provider spi.Provider, storeNames []string) ([]string, error) {
+	if len(storeNames) > 0 {
+		return storeNames, nil
+	}
+
+	lister, ok := provider.(StoreLister)
+	if !ok {
+		return nil, errors.New("store names must be given for a provider that cannot list its stores")
+	}
+
+	allNames, err := lister.StoreNames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stores: %w", err)
+	}
+
+	names := make([]string, 0, len(allNames))
+
+	for _, name := range allNames {
+		if name != ProgressStoreName {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+func openStore(provider spi.Provider, name string) (spi.Store, spi.StoreConfiguration, error) {
+	store, err := provider.OpenStore(name)
+	if err != nil {
+		return nil, spi.StoreConfiguration{}, fmt.Errorf("failed to open store %s: %w", name, err)
+	}
+
+	config, err := provider.GetStoreConfig(name)
+	if err != nil && !errors.Is(err, spi.ErrDataNotFound) && !errors.Is(err, spi.ErrStoreNotFound) {
+		return nil, spi.StoreConfiguration{}, fmt.Errorf("failed to get configuration of store %s: %w", name, err)
+	}
+
+	return store, config, nil
+}
+
+// ListKeys returns the sorted keys of a store, found through its KeyLister implementation if it has one and by
+// querying each tag name of its configuration otherwise.
+func ListKeys(store spi.Store, config spi.StoreConfiguration, pageSize int) ([]string, error) {
+	if lister, ok := store.(KeyLister); ok {
+		keys, err := lister.Keys()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list keys: %w", err)
+		}
+
+		sort.Strings(keys)
+
+		return keys, nil
+	}
+
+	keySet := make(map[string]struct{})
+
+	for _, tagName := range config.TagNames {
+		err := addTaggedKeys(store, tagName, pageSize, keySet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(keySet))
+
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func addTaggedKeys(store spi.Store, tagName string, pageSize int, keySet map[string]struct{}) error {
+	iterator, err := store.Query(tagName, spi.WithPageSize(pageSize))
+	if err != nil {
+		return fmt.Errorf("failed to query tag %s: %w", tagName, err)
+	}
+
+	defer iterator.Close() // nolint:errcheck // Only the keys are read.
+
+	for {
+		ok, err := iterator.Next()
+		if err != nil {
+			return fmt.Errorf("failed to query tag %s: %w", tagName, err)
+		}
+
+		if !ok {
+			return nil
+		}
+
+		key, err := iterator.Key()
+		if err != nil {
+			return fmt.Errorf("failed to query tag %s: %w", tagName, err)
+		}
+
+		keySet[key] = struct{}{}
+	}
+}
+
+func getEntry(store spi.Store, key string) ([]byte, []spi.Tag, error) {
+	value, err := store.Get(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+
+	tags, err := store.GetTags(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tags of %s: %w", key, err)
+	}
+
+	if value == nil {
+		value = []byte{}
+	}
+
+	return value, tags, nil
+}
+
+// storeChecksum computes the checksum of the entries under the given keys. Missing entries are skipped if
+// skipMissing is set, and are an error otherwise.
+func storeChecksum(store spi.Store, keys []string, skipMissing bool) (string, error) {
+	entryHash := sha256.New()
+
+	for _, key := range keys {
+		value, tags, err := getEntry(store, key)
+		if skipMissing && errors.Is(err, spi.ErrDataNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		hashEntry(entryHash, key, value, tags)
+	}
+
+	return hex.EncodeToString(entryHash.Sum(nil)), nil
+}
+
+// hashEntry adds an entry to a store checksum. Tags are sorted since providers do not preserve their order.
+func hashEntry(h hash.Hash, key string, value []byte, tags []spi.Tag) {
+	sortedTags := append([]spi.Tag(nil), tags...)
+
+	sort.Slice(sortedTags, func(i, j int) bool {
+		if sortedTags[i].Name != sortedTags[j].Name {
+			return sortedTags[i].Name < sortedTags[j].Name
+		}
+
+		return sortedTags[i].Value < sortedTags[j].Value
+	})
+
+	hashField(h, []byte(key))
+	hashField(h, value)
+
+	for _, tag := range sortedTags {
+		hashField(h, []byte(tag.Name))
+		hashField(h, []byte(tag.Value))
+	}
+
+	hashField(h, nil)
+}
+
+func hashField(h hash.Hash, field []byte) {
+	var length [8]byte
+
+	binary.BigEndian.PutUint64(length[:], uint64(len(field)))
+
+	h.Write(length[:]) // nolint:errcheck // A hash never returns an error.
+	h.Write(field)     // nolint:errcheck // A hash never returns an error.
+}
+
+// progress is the progress of a store copy, as recorded in the progress store.
+type progress struct {
+	LastKey  string `json:"lastKey,omitempty"`
+	Entries  int    `json:"entries"`
+	Complete bool   `json:"complete,omitempty"`
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// storeWriter writes the entries of a store, in key order, to the target provider and records its progress.
+type storeWriter struct {
+	name          string
+	store         spi.Store
+	progressStore spi.Store
+	state         progress
+	resumeAfter   string
+	resumed       bool
+	pageSize      int
+	unsaved       int
+	keys          []string
+}
+
+func newStoreWriter(target spi.Provider, progressStore spi.Store, name string, config spi.StoreConfiguration,
+	options *options) (*storeWriter, error) {
+	writer := &storeWriter{name: name, progressStore: progressStore, pageSize: options.pageSize}
+
+	if options.resume {
+		stateBytes, err := progressStore.Get(name)
+		if err != nil && !errors.Is(err, spi.ErrDataNotFound) {
+			return nil, fmt.Errorf("failed to get progress: %w", err)
+		}
+
+		if err == nil {
+			err = json.Unmarshal(stateBytes, &writer.state)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal progress: %w", err)
+			}
+		}
+
+		writer.resumeAfter = writer.state.LastKey
+	}
+
+	if writer.state.Complete {
+		writer.resumed = true
+
+		return writer, nil
+	}
+
+	// Entries skipped on resume were already counted by the earlier run.
+	writer.state.Entries = 0
+
+	var err error
+
+	writer.store, err = target.OpenStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open target store: %w", err)
+	}
+
+	err = target.SetStoreConfig(name, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set target store configuration: %w", err)
+	}
+
+	return writer, nil
+}
+
+// write writes an entry. Entries must be written in increasing key order. Entries are written one at a time, since
+// not all providers implement Batch, and the progress is saved after every page.
+func (w *storeWriter) write(key string, value []byte, tags []spi.Tag) error {
+	w.keys = append(w.keys, key)
+	w.state.Entries++
+
+	if key <= w.resumeAfter {
+		return nil
+	}
+
+	err := w.store.Put(key, value, tags...)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	w.state.LastKey = key
+	w.unsaved++
+
+	if w.unsaved < w.pageSize {
+		return nil
+	}
+
+	return w.saveProgress()
+}
+
+// finish verifies the target store against the checksum of the written entries.
+func (w *storeWriter) finish(checksum string) error {
+	err := w.store.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush target store: %w", err)
+	}
+
+	targetChecksum, err := storeChecksum(w.store, w.keys, false)
+	if err != nil {
+		return fmt.Errorf("failed to verify target store: %w", err)
+	}
+
+	if targetChecksum != checksum {
+		return fmt.Errorf("target store: %w", ErrChecksumMismatch)
+	}
+
+	w.state.Complete = true
+	w.state.Checksum = checksum
+
+	return w.saveProgress()
+}
+
+func (w *storeWriter) saveProgress() error {
+	stateBytes, err := json.Marshal(w.state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress: %w", err)
+	}
+
+	err = w.progressStore.Put(w.name, stateBytes)
+	if err != nil {
+		return fmt.Errorf("failed to save progress: %w", err)
+	}
+
+	w.unsaved = 0
+
+	return nil
+}
+
+func (w *storeWriter) report() StoreReport {
+	return StoreReport{
+		Name:     w.name,
+		Entries:  w.state.Entries,
+		Checksum: w.state.Checksum,
+		Resumed:  w.resumed,
+	}
+}
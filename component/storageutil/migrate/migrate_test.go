@@ -0,0 +1,285 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migrate_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Run("Copies all stores and their configurations", func(t *testing.T) {
+		source := newSourceProvider(t)
+		target := mem.NewProvider()
+
+		report, err := migrate.Migrate(source, target)
+		require.NoError(t, err)
+		require.Len(t, report.Stores, 2)
+		require.Equal(t, "connections", report.Stores[0].Name)
+		require.Equal(t, 3, report.Stores[0].Entries)
+		require.Equal(t, "keys", report.Stores[1].Name)
+		require.Equal(t, 2, report.Stores[1].Entries)
+
+		requireSameStores(t, source, target, "connections", "keys")
+
+		config, err := target.GetStoreConfig("connections")
+		require.NoError(t, err)
+		require.Equal(t, []string{"state", "theirDID"}, config.TagNames)
+
+		// Migrating the target again does not copy the progress store.
+		report, err = migrate.Migrate(target, mem.NewProvider())
+		require.NoError(t, err)
+		require.Len(t, report.Stores, 2)
+	})
+	t.Run("Provider that cannot list its stores", func(t *testing.T) {
+		source := hiddenListers{newSourceProvider(t)}
+
+		_, err := migrate.Migrate(source, mem.NewProvider())
+		require.EqualError(t, err, "store names must be given for a provider that cannot list its stores")
+
+		target := mem.NewProvider()
+
+		// Without KeyLister only entries with configured tags can be found.
+		report, err := migrate.Migrate(source, target, migrate.WithStoreNames("connections"), migrate.WithPageSize(1))
+		require.NoError(t, err)
+		require.Len(t, report.Stores, 1)
+		require.Equal(t, 2, report.Stores[0].Entries)
+
+		store, err := target.OpenStore("connections")
+		require.NoError(t, err)
+
+		_, err = store.Get("untagged")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+	})
+	t.Run("Resume after a failure", func(t *testing.T) {
+		source := newSourceProvider(t)
+		target := &failingProvider{Provider: mem.NewProvider(), failPuts: 2}
+
+		_, err := migrate.Migrate(source, target, migrate.WithPageSize(1))
+		require.EqualError(t, err, "failed to migrate store connections: failed to write conn2: put failed")
+
+		target.failPuts = 0
+
+		report, err := migrate.Migrate(source, target, migrate.WithPageSize(1), migrate.WithResume())
+		require.NoError(t, err)
+		require.False(t, report.Stores[0].Resumed)
+		require.Equal(t, 3, report.Stores[0].Entries)
+		require.Equal(t, 5, target.puts)
+
+		report, err = migrate.Migrate(source, target, migrate.WithResume())
+		require.NoError(t, err)
+		require.True(t, report.Stores[0].Resumed)
+		require.True(t, report.Stores[1].Resumed)
+		require.Equal(t, 3, report.Stores[0].Entries)
+
+		requireSameStores(t, source, target.Provider, "connections", "keys")
+	})
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		target := &failingProvider{Provider: mem.NewProvider(), corrupt: true}
+
+		_, err := migrate.Migrate(newSourceProvider(t), target)
+		require.True(t, errors.Is(err, migrate.ErrChecksumMismatch))
+	})
+	t.Run("Snapshot", func(t *testing.T) {
+		source := newSourceProvider(t)
+
+		store, err := source.OpenStore("keys")
+		require.NoError(t, err)
+
+		var opened, added, limit int
+
+		// A key is added whenever a store is opened, until the limit is reached.
+		modifyingSource := &modifyingProvider{Provider: source, modify: func() {
+			opened++
+
+			if opened <= limit {
+				added++
+
+				require.NoError(t, store.Put(fmt.Sprintf("added%d", added), []byte("value")))
+			}
+		}}
+
+		limit = 100
+
+		_, err = migrate.Migrate(modifyingSource, mem.NewProvider(), migrate.WithSnapshot(2))
+		require.True(t, errors.Is(err, migrate.ErrSourceModified))
+
+		// The first attempt copies both stores, then keys is modified while connections is being verified.
+		opened, limit = 0, 3
+		target := mem.NewProvider()
+
+		report, err := migrate.Migrate(modifyingSource, target, migrate.WithSnapshot(0))
+		require.NoError(t, err)
+		require.Equal(t, 2+added, report.Stores[1].Entries)
+
+		requireSameStores(t, source, target, "connections", "keys")
+	})
+	t.Run("Fail to open store", func(t *testing.T) {
+		_, err := migrate.Migrate(&mock.Provider{ErrOpenStore: errors.New("open failed")}, mem.NewProvider(),
+			migrate.WithStoreNames("store"))
+		require.EqualError(t, err, "failed to migrate store store: failed to open store store: open failed")
+
+		_, err = migrate.Migrate(newSourceProvider(t), &mock.Provider{ErrOpenStore: errors.New("open failed")})
+		require.EqualError(t, err, "failed to open the progress store: open failed")
+	})
+	t.Run("Fail to query tags", func(t *testing.T) {
+		source := &mock.Provider{
+			OpenStoreReturn:      &mock.Store{ErrQuery: errors.New("query failed")},
+			GetStoreConfigReturn: spi.StoreConfiguration{TagNames: []string{"tag"}},
+		}
+
+		_, err := migrate.Migrate(source, mem.NewProvider(), migrate.WithStoreNames("store"))
+		require.EqualError(t, err, "failed to migrate store store: failed to query tag tag: query failed")
+	})
+}
+
+func newSourceProvider(t *testing.T) *mem.Provider {
+	t.Helper()
+
+	provider := mem.NewProvider()
+
+	connections, err := provider.OpenStore("connections")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig("connections",
+		spi.StoreConfiguration{TagNames: []string{"state", "theirDID"}}))
+
+	require.NoError(t, connections.Put("conn1", []byte(`{"state":"completed"}`),
+		spi.Tag{Name: "state", Value: "completed"}, spi.Tag{Name: "theirDID", Value: "did:example:1"}))
+	require.NoError(t, connections.Put("conn2", []byte(`{"state":"requested"}`),
+		spi.Tag{Name: "theirDID", Value: "did:example:2"}))
+	require.NoError(t, connections.Put("untagged", []byte("value")))
+
+	keys, err := provider.OpenStore("keys")
+	require.NoError(t, err)
+
+	require.NoError(t, keys.Put("key1", []byte("keyset1")))
+	require.NoError(t, keys.Put("key2", []byte("keyset2")))
+
+	return provider
+}
+
+func requireSameStores(t *testing.T, expected, actual spi.Provider, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		expectedStore, err := expected.OpenStore(name)
+		require.NoError(t, err)
+
+		actualStore, err := actual.OpenStore(name)
+		require.NoError(t, err)
+
+		keys, err := expectedStore.(migrate.KeyLister).Keys()
+		require.NoError(t, err)
+
+		for _, key := range keys {
+			expectedValue, err := expectedStore.Get(key)
+			require.NoError(t, err)
+
+			actualValue, err := actualStore.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, expectedValue, actualValue)
+
+			expectedTags, err := expectedStore.GetTags(key)
+			require.NoError(t, err)
+
+			actualTags, err := actualStore.GetTags(key)
+			require.NoError(t, err)
+			require.ElementsMatch(t, expectedTags, actualTags)
+		}
+	}
+}
+
+// hiddenListers hides the StoreLister and KeyLister implementations of a provider.
+type hiddenListers struct {
+	provider spi.Provider
+}
+
+func (h hiddenListers) OpenStore(name string) (spi.Store, error) {
+	store, err := h.provider.OpenStore(name)
+
+	return struct{ spi.Store }{store}, err
+}
+
+func (h hiddenListers) SetStoreConfig(name string, config spi.StoreConfiguration) error {
+	return h.provider.SetStoreConfig(name, config)
+}
+
+func (h hiddenListers) GetStoreConfig(name string) (spi.StoreConfiguration, error) {
+	return h.provider.GetStoreConfig(name)
+}
+
+func (h hiddenListers) GetOpenStores() []spi.Store {
+	return h.provider.GetOpenStores()
+}
+
+func (h hiddenListers) Close() error {
+	return h.provider.Close()
+}
+
+// failingProvider fails the given number of puts after the first one, or corrupts the values it returns.
+type failingProvider struct {
+	*mem.Provider
+	failPuts int
+	puts     int
+	corrupt  bool
+}
+
+func (f *failingProvider) OpenStore(name string) (spi.Store, error) {
+	store, err := f.Provider.OpenStore(name)
+	if err != nil || name == migrate.ProgressStoreName {
+		return store, err
+	}
+
+	return &failingStore{Store: store, provider: f}, nil
+}
+
+type failingStore struct {
+	spi.Store
+	provider *failingProvider
+}
+
+func (f *failingStore) Put(key string, value []byte, tags ...spi.Tag) error {
+	if f.provider.failPuts > 0 && f.provider.puts > 0 {
+		f.provider.failPuts--
+
+		return errors.New("put failed")
+	}
+
+	f.provider.puts++
+
+	return f.Store.Put(key, value, tags...)
+}
+
+func (f *failingStore) Get(key string) ([]byte, error) {
+	value, err := f.Store.Get(key)
+	if f.provider.corrupt {
+		return []byte("corrupted"), err
+	}
+
+	return value, err
+}
+
+// modifyingProvider calls modify whenever a store is opened.
+type modifyingProvider struct {
+	*mem.Provider
+	modify func()
+}
+
+func (m *modifyingProvider) OpenStore(name string) (spi.Store, error) {
+	m.modify()
+
+	return m.Provider.OpenStore(name)
+}
@@ -134,6 +134,9 @@ type Iterator struct {
 	TagsReturn []spi.Tag
 	ErrTags    error
 
+	BookmarkReturn string
+	ErrBookmark    error
+
 	ErrClose error
 }
 
@@ -157,6 +160,11 @@ func (i *Iterator) Tags() ([]spi.Tag, error) {
 	return i.TagsReturn, i.ErrTags
 }
 
+// Bookmark returns mocked results.
+func (i *Iterator) Bookmark() (string, error) {
+	return i.BookmarkReturn, i.ErrBookmark
+}
+
 // Close returns mocked results.
 func (i *Iterator) Close() error {
 	return i.ErrClose
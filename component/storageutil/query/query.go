@@ -0,0 +1,569 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package query evaluates the expressions, sort options and bookmarks of spi.Store Query calls.
+// Store implementations which can't evaluate them natively filter and sort the data with this package,
+// the others use it to parse the expressions and bookmarks so the results are consistent across stores.
+package query
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	orSeparator  = "||"
+	andSeparator = "&&"
+
+	dateOnlyLayout = "2006-01-02"
+
+	// NumberSortKeyPrefix prefixes the sort keys of numeric tag values.
+	NumberSortKeyPrefix = "0"
+	// DateSortKeyPrefix prefixes the sort keys of date tag values.
+	DateSortKeyPrefix = "1"
+	// StringSortKeyPrefix prefixes the sort keys of the other tag values.
+	StringSortKeyPrefix = "2"
+	// MissingSortKey is the sort key of the data without the sort tag.
+	MissingSortKey = "3"
+)
+
+// ErrInvalidBookmark is returned when the bookmark passed to a Query call can't be parsed.
+var ErrInvalidBookmark = errors.New("invalid bookmark")
+
+// ErrInvalidTagValue is returned by CheckTagValue when the tag value can't be used in an expression.
+var ErrInvalidTagValue = errors.New("invalid tag value")
+
+// Operator is the comparison of a Condition.
+type Operator int
+
+const (
+	// Has matches the data which has the tag, whatever its value is (TagName).
+	Has Operator = iota
+	// Equal matches the data whose tag value is equal to the condition value (TagName:Value).
+	Equal
+	// Less matches the data whose tag value is lower than the condition value (TagName<Value).
+	Less
+	// LessOrEqual matches the data whose tag value is lower or equal to the condition value (TagName<=Value).
+	LessOrEqual
+	// Greater matches the data whose tag value is greater than the condition value (TagName>Value).
+	Greater
+	// GreaterOrEqual matches the data whose tag value is greater or equal to the condition value (TagName>=Value).
+	GreaterOrEqual
+)
+
+// Condition is a single tag condition of an expression.
+type Condition struct {
+	TagName  string
+	Operator Operator
+	Value    string
+}
+
+// Expression is a disjunction (OR) of conjunctions (AND) of conditions.
+type Expression [][]Condition
+
+// ParseExpression parses the query expression (see spi.Store Query).
+func ParseExpression(expression string) (Expression, error) {
+	if expression == "" {
+		return nil, invalidExpression(expression)
+	}
+
+	terms := strings.Split(expression, orSeparator)
+	parsed := make(Expression, len(terms))
+
+	for i, term := range terms {
+		conditions := strings.Split(term, andSeparator)
+		parsed[i] = make([]Condition, len(conditions))
+
+		for j, condition := range conditions {
+			c, err := parseCondition(condition)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", invalidExpression(expression), err.Error())
+			}
+
+			parsed[i][j] = c
+		}
+	}
+
+	return parsed, nil
+}
+
+// CheckTagValue checks that the tag value can be used in the conditions of an expression: it must not contain
+// colons or the separators of the conditions (&& and ||), which would change the meaning of the expression.
+func CheckTagValue(value string) error {
+	if strings.Contains(value, ":") || strings.Contains(value, andSeparator) || strings.Contains(value, orSeparator) {
+		return fmt.Errorf(`%w "%s": it must not contain ":", "%s" or "%s"`,
+			ErrInvalidTagValue, value, andSeparator, orSeparator)
+	}
+
+	return nil
+}
+
+func invalidExpression(expression string) error {
+	return fmt.Errorf(`"%s" is not in a valid expression format. `+
+		"it must be in the following format: TagName:TagValue", expression)
+}
+
+func parseCondition(condition string) (Condition, error) {
+	i := strings.IndexAny(condition, ":<>")
+	if i < 0 {
+		if condition == "" {
+			return Condition{}, errors.New("empty condition")
+		}
+
+		return Condition{TagName: condition, Operator: Has}, nil
+	}
+
+	c := Condition{TagName: condition[:i]}
+	if c.TagName == "" {
+		return Condition{}, fmt.Errorf(`missing tag name in "%s"`, condition)
+	}
+
+	operand := condition[i+1:]
+
+	switch condition[i] {
+	case ':':
+		if strings.Contains(operand, ":") {
+			return Condition{}, fmt.Errorf(`too many colon-separated parts in "%s"`, condition)
+		}
+
+		c.Operator, c.Value = Equal, operand
+
+		return c, nil
+	case '<':
+		c.Operator = Less
+	default:
+		c.Operator = Greater
+	}
+
+	if strings.HasPrefix(operand, "=") {
+		c.Operator++
+		operand = operand[1:]
+	}
+
+	if operand == "" {
+		return Condition{}, fmt.Errorf(`missing value in "%s"`, condition)
+	}
+
+	c.Value = operand
+
+	return c, nil
+}
+
+// Match tells whether the tags satisfy the expression.
+func (e Expression) Match(tags []spi.Tag) bool {
+	for _, term := range e {
+		matches := true
+
+		for _, condition := range term {
+			if !condition.Match(tags) {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match tells whether the tags satisfy the condition.
+func (c Condition) Match(tags []spi.Tag) bool {
+	for _, tag := range tags {
+		if tag.Name != c.TagName {
+			continue
+		}
+
+		switch c.Operator {
+		case Has:
+			return true
+		case Equal:
+			if tag.Value == c.Value {
+				return true
+			}
+		default:
+			if c.matchRange(tag.Value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c Condition) matchRange(value string) bool {
+	cmp, ok := compareValues(value, c.Value)
+	if !ok {
+		return false
+	}
+
+	switch c.Operator { // nolint:exhaustive // Has and Equal are not range comparisons.
+	case Less:
+		return cmp < 0
+	case LessOrEqual:
+		return cmp <= 0
+	case Greater:
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// compareValues compares two numbers or two dates. It reports false if the values are not comparable.
+func compareValues(a, b string) (int, bool) {
+	if x, ok := parseNumber(a); ok {
+		y, ok := parseNumber(b)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	x, ok := parseDate(a)
+	if !ok {
+		return 0, false
+	}
+
+	y, ok := parseDate(b)
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case x.Before(y):
+		return -1, true
+	case x.After(y):
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+func parseNumber(value string) (float64, bool) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+
+	if f == 0 {
+		// -0 and 0 are equal, and must have the same sort key.
+		f = 0
+	}
+
+	return f, true
+}
+
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, dateOnlyLayout} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// SortKey returns the key used to sort the data by the given tag value. Comparing the sort keys byte by byte
+// orders numbers numerically first, then dates chronologically, then the other values.
+// The keys only contain ASCII digits and lowercase letters, so they can be sorted by databases as well.
+func SortKey(value string) string {
+	if f, ok := parseNumber(value); ok {
+		bits := math.Float64bits(f)
+
+		// flips the bits so the unsigned integers are in the same order as the numbers.
+		if bits>>63 == 1 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+
+		return fmt.Sprintf("%s%016x", NumberSortKeyPrefix, bits)
+	}
+
+	if t, ok := parseDate(value); ok {
+		return fmt.Sprintf("%s%016x%08x", DateSortKeyPrefix, uint64(t.Unix())^1<<63, t.Nanosecond())
+	}
+
+	return StringSortKeyPrefix + hex.EncodeToString([]byte(value))
+}
+
+// RangeBounds returns the sort keys bounding the tag values which satisfy the range condition:
+// lower <= sort key < upper (or lower < sort key when lowerExclusive, and sort key <= upper when upperInclusive).
+// It reports false if the condition value is neither a number nor a date, since no tag value can satisfy it.
+func (c Condition) RangeBounds() (lower, upper string, lowerExclusive, upperInclusive, ok bool) {
+	var prefix, next string
+
+	if _, isNumber := parseNumber(c.Value); isNumber {
+		prefix, next = NumberSortKeyPrefix, DateSortKeyPrefix
+	} else if _, isDate := parseDate(c.Value); isDate {
+		prefix, next = DateSortKeyPrefix, StringSortKeyPrefix
+	} else {
+		return "", "", false, false, false
+	}
+
+	key := SortKey(c.Value)
+
+	switch c.Operator { // nolint:exhaustive // Has and Equal are not range comparisons.
+	case Less:
+		return prefix, key, false, false, true
+	case LessOrEqual:
+		return prefix, key, false, true, true
+	case Greater:
+		return key, next, true, false, true
+	default:
+		return key, next, false, false, true
+	}
+}
+
+// Position is the position of the data in the results of a query.
+type Position struct {
+	SortKey string `json:"s,omitempty"`
+	Key     string `json:"k"`
+}
+
+// Bookmark returns the bookmark which resumes the query after the position.
+func (p Position) Bookmark() string {
+	// the marshalling of strings can't fail.
+	src, _ := json.Marshal(p) // nolint:errcheck
+
+	return base64.RawURLEncoding.EncodeToString(src)
+}
+
+// ParseBookmark returns the position of the bookmark.
+func ParseBookmark(bookmark string) (Position, error) {
+	src, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil {
+		return Position{}, fmt.Errorf("%w: %s", ErrInvalidBookmark, err.Error())
+	}
+
+	var p Position
+
+	if err = json.Unmarshal(src, &p); err != nil {
+		return Position{}, fmt.Errorf("%w: %s", ErrInvalidBookmark, err.Error())
+	}
+
+	return p, nil
+}
+
+// Query is a parsed query: its expression, options and bookmark.
+type Query struct {
+	Expression Expression
+	Options    spi.QueryOptions
+	// After is the position the query resumes after, if a bookmark was given.
+	After *Position
+}
+
+// New parses the query expression and options.
+func New(expression string, options ...spi.QueryOption) (*Query, error) {
+	e, err := ParseExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{Expression: e}
+
+	for _, option := range options {
+		option(&q.Options)
+	}
+
+	if q.Options.Bookmark != "" {
+		p, err := ParseBookmark(q.Options.Bookmark)
+		if err != nil {
+			return nil, err
+		}
+
+		q.After = &p
+	}
+
+	return q, nil
+}
+
+// SortTagName returns the name of the tag the results are sorted by, if any.
+func (q *Query) SortTagName() string {
+	if q.Options.SortOptions == nil {
+		return ""
+	}
+
+	return q.Options.SortOptions.TagName
+}
+
+// Descending tells whether the results are sorted in descending order.
+func (q *Query) Descending() bool {
+	return q.Options.SortOptions != nil && q.Options.SortOptions.Order == spi.SortDescending
+}
+
+// Position returns the position of the data in the results of the query.
+func (q *Query) Position(key string, tags []spi.Tag) Position {
+	tagName := q.SortTagName()
+	if tagName == "" {
+		return Position{Key: key}
+	}
+
+	sortKey := MissingSortKey
+
+	for _, tag := range tags {
+		if tag.Name != tagName {
+			continue
+		}
+
+		// the lowest value is used if the tag is set several times.
+		if k := SortKey(tag.Value); k < sortKey {
+			sortKey = k
+		}
+	}
+
+	return Position{SortKey: sortKey, Key: key}
+}
+
+// Less tells whether the position a comes before the position b in the results of the query.
+func (q *Query) Less(a, b Position) bool {
+	if q.Descending() {
+		a, b = b, a
+	}
+
+	if a.SortKey != b.SortKey {
+		return a.SortKey < b.SortKey
+	}
+
+	return a.Key < b.Key
+}
+
+// Entry is a key + value pair and its tags.
+type Entry struct {
+	Key   string
+	Value []byte
+	Tags  []spi.Tag
+}
+
+// Apply returns the entries which satisfy the query expression and come after its bookmark, sorted.
+func (q *Query) Apply(entries []Entry) []Entry {
+	type positioned struct {
+		entry    Entry
+		position Position
+	}
+
+	var results []positioned
+
+	for _, entry := range entries {
+		if !q.Expression.Match(entry.Tags) {
+			continue
+		}
+
+		p := q.Position(entry.Key, entry.Tags)
+
+		if q.After != nil && !q.Less(*q.After, p) {
+			continue
+		}
+
+		results = append(results, positioned{entry: entry, position: p})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return q.Less(results[i].position, results[j].position)
+	})
+
+	sorted := make([]Entry, len(results))
+
+	for i := range results {
+		sorted[i] = results[i].entry
+	}
+
+	return sorted
+}
+
+// NewIterator returns an iterator over the entries which satisfy the query, sorted.
+func NewIterator(q *Query, entries []Entry) spi.Iterator {
+	return &iterator{query: q, entries: q.Apply(entries), current: -1}
+}
+
+var errIteratorExhausted = errors.New("iterator is exhausted")
+
+type iterator struct {
+	query   *Query
+	entries []Entry
+	current int
+}
+
+func (i *iterator) Next() (bool, error) {
+	if i.current+1 >= len(i.entries) {
+		i.current = len(i.entries)
+
+		return false, nil
+	}
+
+	i.current++
+
+	return true, nil
+}
+
+func (i *iterator) entry() (*Entry, error) {
+	if i.current < 0 || i.current >= len(i.entries) {
+		return nil, errIteratorExhausted
+	}
+
+	return &i.entries[i.current], nil
+}
+
+func (i *iterator) Key() (string, error) {
+	e, err := i.entry()
+	if err != nil {
+		return "", err
+	}
+
+	return e.Key, nil
+}
+
+func (i *iterator) Value() ([]byte, error) {
+	e, err := i.entry()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Value, nil
+}
+
+func (i *iterator) Tags() ([]spi.Tag, error) {
+	e, err := i.entry()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Tags, nil
+}
+
+func (i *iterator) Bookmark() (string, error) {
+	e, err := i.entry()
+	if err != nil {
+		return "", err
+	}
+
+	return i.query.Position(e.Key, e.Tags).Bookmark(), nil
+}
+
+func (i *iterator) Close() error {
+	return nil
+}
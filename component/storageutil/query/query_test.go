@@ -0,0 +1,241 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package query_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestParseExpression(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		expression, err := query.ParseExpression("a||b:1&&c<=2&&d>2021-01-01T10:00:00Z||e>=-1&&f<3")
+		require.NoError(t, err)
+		require.Equal(t, query.Expression{
+			{{TagName: "a", Operator: query.Has}},
+			{
+				{TagName: "b", Operator: query.Equal, Value: "1"},
+				{TagName: "c", Operator: query.LessOrEqual, Value: "2"},
+				{TagName: "d", Operator: query.Greater, Value: "2021-01-01T10:00:00Z"},
+			},
+			{
+				{TagName: "e", Operator: query.GreaterOrEqual, Value: "-1"},
+				{TagName: "f", Operator: query.Less, Value: "3"},
+			},
+		}, expression)
+	})
+	t.Run("Invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{
+			"", "a||", "&&a", ":value", "<3", "name:value:other", "name<", "name>=",
+		} {
+			_, err := query.ParseExpression(expression)
+			require.Error(t, err, expression)
+			require.Contains(t, err.Error(), "is not in a valid expression format")
+		}
+	})
+}
+
+func TestCheckTagValue(t *testing.T) {
+	for _, value := range []string{"", "value", "2021-01-01", "a<b", "a&b|c"} {
+		require.NoError(t, query.CheckTagValue(value), value)
+	}
+
+	for _, value := range []string{"a:b", "a&&b", "a||b", "completed||conn_"} {
+		err := query.CheckTagValue(value)
+		require.True(t, errors.Is(err, query.ErrInvalidTagValue), value)
+	}
+}
+
+func TestExpression_Match(t *testing.T) {
+	tags := []spi.Tag{
+		{Name: "type", Value: "credential"},
+		{Name: "age", Value: "30"},
+		{Name: "issued", Value: "2021-03-01"},
+	}
+
+	for expression, expected := range map[string]bool{
+		"type":                          true,
+		"state":                         false,
+		"type:credential":               true,
+		"type:connection":               false,
+		"type:connection||age":          true,
+		"type:credential&&state":        false,
+		"age>29.5&&age<=30":             true,
+		"age<30":                        false,
+		"age>=1e1":                      true,
+		"age>2021-01-01":                false,
+		"issued>2021-02-28T23:59:59Z":   true,
+		"issued<2021-03-01":             false,
+		"issued<=2021-03-01T00:00:00Z":  true,
+		"type>a":                        false,
+		"issued>2021-01-01&&type:other": false,
+	} {
+		e, err := query.ParseExpression(expression)
+		require.NoError(t, err)
+		require.Equal(t, expected, e.Match(tags), expression)
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	ordered := []string{
+		"-1e10", "-2.5", "-0", "0", "1", "2", "10", "1e10",
+		"1969-12-31T23:59:59Z", "2020-12-31", "2021-01-01T00:00:00.5Z", "2021-01-02",
+		"", "a", "b", "ba",
+	}
+
+	keys := make([]string, len(ordered))
+	for i, value := range ordered {
+		keys[i] = query.SortKey(value)
+	}
+
+	require.True(t, sort.StringsAreSorted(keys))
+	require.Equal(t, query.SortKey("0"), query.SortKey("-0"))
+	require.Less(t, keys[len(keys)-1], query.MissingSortKey)
+}
+
+func TestCondition_RangeBounds(t *testing.T) {
+	t.Run("Bounds match the sort keys", func(t *testing.T) {
+		values := []string{"-3", "0", "4", "2020-12-31", "2021-03-01T10:00:00Z", "value"}
+
+		for _, expression := range []string{"a<4", "a<=4", "a>0", "a>=0", "a<2021-01-01", "a>=2020-12-31"} {
+			e, err := query.ParseExpression(expression)
+			require.NoError(t, err)
+
+			condition := e[0][0]
+
+			lower, upper, lowerExclusive, upperInclusive, ok := condition.RangeBounds()
+			require.True(t, ok)
+
+			for _, value := range values {
+				key := query.SortKey(value)
+
+				inRange := (key > lower || !lowerExclusive && key == lower) &&
+					(key < upper || upperInclusive && key == upper)
+
+				require.Equal(t, condition.Match([]spi.Tag{{Name: "a", Value: value}}), inRange,
+					"%s with %s", expression, value)
+			}
+		}
+	})
+	t.Run("Not a number nor a date", func(t *testing.T) {
+		_, _, _, _, ok := query.Condition{TagName: "a", Operator: query.Less, Value: "value"}.RangeBounds()
+		require.False(t, ok)
+	})
+}
+
+func TestParseBookmark(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		position := query.Position{SortKey: query.SortKey("3"), Key: "key"}
+
+		parsed, err := query.ParseBookmark(position.Bookmark())
+		require.NoError(t, err)
+		require.Equal(t, position, parsed)
+	})
+	t.Run("Not base64", func(t *testing.T) {
+		_, err := query.ParseBookmark("not a bookmark")
+		require.True(t, errors.Is(err, query.ErrInvalidBookmark))
+	})
+	t.Run("Not JSON", func(t *testing.T) {
+		_, err := query.ParseBookmark("bm90IGpzb24")
+		require.True(t, errors.Is(err, query.ErrInvalidBookmark))
+	})
+	t.Run("Invalid bookmark option", func(t *testing.T) {
+		_, err := query.New("tag", spi.WithBookmark("not a bookmark"))
+		require.True(t, errors.Is(err, query.ErrInvalidBookmark))
+	})
+}
+
+func TestNewIterator(t *testing.T) {
+	entries := []query.Entry{
+		{Key: "key3", Value: []byte("value3"), Tags: []spi.Tag{{Name: "age", Value: "4"}}},
+		{Key: "key1", Value: []byte("value1"), Tags: []spi.Tag{{Name: "age", Value: "30"}, {Name: "age", Value: "2"}}},
+		{Key: "key2", Value: []byte("value2"), Tags: []spi.Tag{{Name: "name"}}},
+		{Key: "key4", Value: []byte("value4"), Tags: []spi.Tag{{Name: "age", Value: "4"}}},
+	}
+
+	t.Run("Sorted by key", func(t *testing.T) {
+		q, err := query.New("age||name")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"key1", "key2", "key3", "key4"}, iterate(t, query.NewIterator(q, entries)))
+	})
+	t.Run("Sorted by tag value with bookmark", func(t *testing.T) {
+		sortOptions := &spi.SortOptions{Order: spi.SortDescending, TagName: "age"}
+
+		q, err := query.New("age||name", spi.WithSortingOrder(sortOptions))
+		require.NoError(t, err)
+
+		iterator := query.NewIterator(q, entries)
+
+		// key1 is sorted by its lowest age, and key2 which has no age comes first in descending order.
+		ok, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+		require.Equal(t, "key2", key)
+
+		bookmark, err := iterator.Bookmark()
+		require.NoError(t, err)
+
+		q, err = query.New("age||name", spi.WithSortingOrder(sortOptions), spi.WithBookmark(bookmark))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"key4", "key3", "key1"}, iterate(t, query.NewIterator(q, entries)))
+	})
+	t.Run("Iterator is exhausted", func(t *testing.T) {
+		q, err := query.New("other")
+		require.NoError(t, err)
+
+		iterator := query.NewIterator(q, entries)
+
+		_, err = iterator.Key()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		ok, err := iterator.Next()
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		_, err = iterator.Value()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		_, err = iterator.Tags()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		_, err = iterator.Bookmark()
+		require.EqualError(t, err, "iterator is exhausted")
+
+		require.NoError(t, iterator.Close())
+	})
+}
+
+func iterate(t *testing.T, iterator spi.Iterator) []string {
+	t.Helper()
+
+	var keys []string
+
+	for {
+		ok, err := iterator.Next()
+		require.NoError(t, err)
+
+		if !ok {
+			return keys
+		}
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+}
@@ -17,8 +17,8 @@ require (
 	github.com/google/tink/go v1.5.0
 	github.com/google/uuid v1.1.2
 	github.com/gorilla/mux v1.7.3
-	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20210224230531-58e1368e5661
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210224230531-58e1368e5661
+	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20261019133114-510320220604
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261019084901-66f4e01095f2
 	github.com/jinzhu/copier v0.0.0-20190924061706-b57f9002281a
 	github.com/kawamuray/jsonpath v0.0.0-20201211160320-7483bafabd7e
 	github.com/kilic/bls12-381 v0.0.0-20201104083100-a288617c07f1
@@ -43,3 +43,10 @@ require (
 )
 
 go 1.15
+
+// The replacements only apply to the builds of this repository, the modules depending on it get the required
+// versions above.
+replace (
+	github.com/hyperledger/aries-framework-go/component/storageutil => ./component/storageutil
+	github.com/hyperledger/aries-framework-go/spi => ./spi
+)
@@ -0,0 +1,165 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+)
+
+type (
+	// Menu presents the available actions to the requester.
+	Menu actionmenu.Menu
+	// Perform selects one of the menu options.
+	Perform actionmenu.Perform
+	// Action contains helpful information about action.
+	Action actionmenu.Action
+)
+
+var (
+	errEmptyMenu    = errors.New("menu message is empty")
+	errEmptyPerform = errors.New("perform message is empty")
+)
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the actionmenu service.
+type ProtocolService interface {
+	service.DIDComm
+	Actions() ([]actionmenu.Action, error)
+	ActionContinue(piID string, opt actionmenu.Opt) error
+	ActionStop(piID string, err error) error
+}
+
+// Client enable access to actionmenu API
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0509-action-menu
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New returns new instance of the actionmenu client.
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(actionmenu.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to actionmenu service failed")
+	}
+
+	return &Client{
+		Event:   svc,
+		service: svc,
+	}, nil
+}
+
+// Actions returns pending actions that have yet to be executed or cancelled.
+func (c *Client) Actions() ([]Action, error) {
+	actions, err := c.service.Actions()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Action, len(actions))
+	for i, action := range actions {
+		result[i] = Action(action)
+	}
+
+	return result, nil
+}
+
+// RequestMenu is used by the Requester to ask the Responder for its root menu.
+// It returns the threadID of the new instance of the protocol.
+func (c *Client) RequestMenu(myDID, theirDID string) (string, error) {
+	return c.service.HandleInbound(service.NewDIDCommMsgMap(&actionmenu.MenuRequest{
+		Type: actionmenu.MenuRequestMsgType,
+	}), myDID, theirDID)
+}
+
+// SendMenu is used by the Responder to send a menu without being asked for it.
+// It returns the threadID of the new instance of the protocol.
+func (c *Client) SendMenu(msg *Menu, myDID, theirDID string) (string, error) {
+	if msg == nil {
+		return "", errEmptyMenu
+	}
+
+	msg.Type = actionmenu.MenuMsgType
+
+	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), myDID, theirDID)
+}
+
+// AcceptMenuRequest is used by the Responder to reply to a menu request with its root menu.
+func (c *Client) AcceptMenuRequest(piID string, msg *Menu) error {
+	if msg == nil {
+		return errEmptyMenu
+	}
+
+	return c.service.ActionContinue(piID, WithMenu(msg))
+}
+
+// DeclineMenuRequest is used when the Responder does not want to provide a menu.
+func (c *Client) DeclineMenuRequest(piID, reason string) error {
+	return c.service.ActionStop(piID, errors.New(reason))
+}
+
+// PerformAction is used by the Requester to select one of the options of the menu it received.
+func (c *Client) PerformAction(piID string, msg *Perform) error {
+	if msg == nil {
+		return errEmptyPerform
+	}
+
+	return c.service.ActionContinue(piID, WithPerform(msg))
+}
+
+// DeclineMenu is used when the Requester does not want to select any of the menu options.
+func (c *Client) DeclineMenu(piID, reason string) error {
+	return c.service.ActionStop(piID, errors.New(reason))
+}
+
+// AcceptPerform is used by the Responder to acknowledge the selected option.
+// The optional menu is sent to the Requester as a follow-up.
+func (c *Client) AcceptPerform(piID string, msg *Menu) error {
+	if msg == nil {
+		return c.service.ActionContinue(piID, nil)
+	}
+
+	return c.service.ActionContinue(piID, WithMenu(msg))
+}
+
+// DeclinePerform is used when the Responder does not want to perform the selected option.
+func (c *Client) DeclinePerform(piID, reason string) error {
+	return c.service.ActionStop(piID, errors.New(reason))
+}
+
+// AcceptProblemReport accepts problem report action.
+func (c *Client) AcceptProblemReport(piID string) error {
+	return c.service.ActionContinue(piID, nil)
+}
+
+// WithMenu allows providing Menu message
+// Use this option to respond to MenuRequest or Perform.
+func WithMenu(msg *Menu) actionmenu.Opt {
+	origin := actionmenu.Menu(*msg)
+
+	return actionmenu.WithMenu(&origin)
+}
+
+// WithPerform allows providing Perform message
+// Use this option to respond to Menu.
+func WithPerform(msg *Perform) actionmenu.Opt {
+	origin := actionmenu.Perform(*msg)
+
+	return actionmenu.WithPerform(&origin)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/actionmenu"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	const errMsg = "test err"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New(errMsg))
+		_, err := New(provider)
+		require.EqualError(t, err, errMsg)
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to actionmenu service failed")
+	})
+}
+
+func TestClient_RequestMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	thid := uuid.New().String()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().HandleInbound(gomock.Any(), Alice, Bob).
+		DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+			require.Equal(t, actionmenu.MenuRequestMsgType, msg.Type())
+
+			return thid, nil
+		})
+
+	client := newClient(t, ctrl, svc)
+
+	result, err := client.RequestMenu(Alice, Bob)
+	require.NoError(t, err)
+	require.Equal(t, thid, result)
+}
+
+func TestClient_SendMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		thid := uuid.New().String()
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), Bob, Alice).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, actionmenu.MenuMsgType, msg.Type())
+
+				return thid, nil
+			})
+
+		client := newClient(t, ctrl, svc)
+
+		result, err := client.SendMenu(&Menu{Title: "menu"}, Bob, Alice)
+		require.NoError(t, err)
+		require.Equal(t, thid, result)
+	})
+
+	t.Run("Empty menu", func(t *testing.T) {
+		client := newClient(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+		_, err := client.SendMenu(nil, Bob, Alice)
+		require.EqualError(t, err, errEmptyMenu.Error())
+	})
+}
+
+func TestClient_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Actions().Return([]actionmenu.Action{{PIID: "1"}, {PIID: "2"}}, nil)
+
+		client := newClient(t, ctrl, svc)
+
+		actions, err := client.Actions()
+		require.NoError(t, err)
+		require.Equal(t, []Action{{PIID: "1"}, {PIID: "2"}}, actions)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Actions().Return(nil, errors.New("error"))
+
+		client := newClient(t, ctrl, svc)
+
+		_, err := client.Actions()
+		require.EqualError(t, err, "error")
+	})
+}
+
+func TestClient_Continue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	piID := uuid.New().String()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue(piID, gomock.Any()).Return(nil).Times(4)
+
+	client := newClient(t, ctrl, svc)
+
+	require.NoError(t, client.AcceptMenuRequest(piID, &Menu{}))
+	require.EqualError(t, client.AcceptMenuRequest(piID, nil), errEmptyMenu.Error())
+
+	require.NoError(t, client.PerformAction(piID, &Perform{Name: "option"}))
+	require.EqualError(t, client.PerformAction(piID, nil), errEmptyPerform.Error())
+
+	require.NoError(t, client.AcceptPerform(piID, &Menu{}))
+	require.NoError(t, client.AcceptPerform(piID, nil))
+}
+
+func TestClient_AcceptProblemReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	piID := uuid.New().String()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue(piID, nil).Return(nil)
+
+	require.NoError(t, newClient(t, ctrl, svc).AcceptProblemReport(piID))
+}
+
+func TestClient_Decline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	piID := uuid.New().String()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionStop(piID, errors.New("reason")).Return(nil).Times(3)
+
+	client := newClient(t, ctrl, svc)
+
+	require.NoError(t, client.DeclineMenuRequest(piID, "reason"))
+	require.NoError(t, client.DeclineMenu(piID, "reason"))
+	require.NoError(t, client.DeclinePerform(piID, "reason"))
+}
+
+func newClient(t *testing.T, ctrl *gomock.Controller, svc ProtocolService) *Client {
+	t.Helper()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	return client
+}
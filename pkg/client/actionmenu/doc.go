@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package actionmenu provides support for the Action Menu Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0509-action-menu.
+//
+// A protocol allowing an agent (the Responder) to present a menu of actions to another agent (the Requester),
+// which selects one of them.
+//
+// 1. Create your client:
+//
+// 	client, err := actionmenu.New(ctx)
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 2. Register an action event channel.
+//
+// 	actions := make(chan service.DIDCommAction)
+// 	client.RegisterActionEvent(actions)
+//
+// 3. Handle incoming actions.
+//
+//  for {
+//    select {
+//      case event := <-actions:
+//        piid := e.Properties.All()["piid"].(string)
+//
+//        if event.Message.Type() == actionmenu.MenuRequestMsgType {
+//          // If Responder is willing to provide the menu.
+//          client.AcceptMenuRequest(piid, &Menu{})
+//          // If Responder is not willing to provide the menu.
+//          client.DeclineMenuRequest(piid, reason)
+//        }
+//
+//        if event.Message.Type() == actionmenu.MenuMsgType {
+//          // If Requester selects one of the options.
+//          client.PerformAction(piid, &Perform{Name: "option"})
+//          // If Requester does not select any option.
+//          client.DeclineMenu(piid, reason)
+//        }
+//
+//        if event.Message.Type() == actionmenu.PerformMsgType {
+//          // If Responder performs the selected option (the menu is optional).
+//          client.AcceptPerform(piid, &Menu{})
+//          // If Responder is not willing to perform the selected option.
+//          client.DeclinePerform(piid, reason)
+//        }
+//
+//        if event.Message.Type() == actionmenu.ProblemReportMsgType {
+//          Problem report message is triggered to notify client about the error.
+//          In that case, there is only one option - accept it.
+//          client.AcceptProblemReport(piid)
+//        }
+//    }
+//  }
+//
+// How to initiate the protocol?
+// The protocol can be initiated by the Requester or by the Responder.
+// Requester initiates the protocol.
+//  client.RequestMenu(myDID, theirDID)
+// Responder initiates the protocol.
+//  client.SendMenu(&Menu{}, myDID, theirDID)
+//
+package actionmenu
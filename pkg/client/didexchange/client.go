@@ -287,10 +287,9 @@ func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (str
 }
 
 // QueryConnections queries connections matching given criteria(parameters).
-func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) { //nolint: gocyclo
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria and
-	//  also results needs to be paged.
-	records, err := c.connectionStore.QueryConnectionRecords()
+func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) {
+	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - results needs to be paged.
+	records, err := c.connectionStore.QueryConnectionRecordsFiltered(request.filter())
 	if err != nil {
 		return nil, fmt.Errorf("failed query connections: %w", err)
 	}
@@ -298,26 +297,6 @@ func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connectio
 	var result []*Connection
 
 	for _, record := range records {
-		if request.State != "" && request.State != record.State {
-			continue
-		}
-
-		if request.InvitationID != "" && request.InvitationID != record.InvitationID {
-			continue
-		}
-
-		if request.ParentThreadID != "" && request.ParentThreadID != record.ParentThreadID {
-			continue
-		}
-
-		if request.MyDID != "" && request.MyDID != record.MyDID {
-			continue
-		}
-
-		if request.TheirDID != "" && request.TheirDID != record.TheirDID {
-			continue
-		}
-
 		result = append(result, &Connection{Record: record})
 	}
 
@@ -961,23 +961,23 @@ func TestClient_QueryConnectionsByParams(t *testing.T) { // nolint: gocyclo
 		require.NoError(t, err)
 		require.NotNil(t, svc)
 
-		storageProvider := mem.NewProvider()
-		c, err := New(&mockprovider.Provider{
+		prov := &mockprovider.Provider{
 			ProtocolStateStorageProviderValue: mem.NewProvider(),
-			StorageProviderValue:              storageProvider,
+			StorageProviderValue:              mem.NewProvider(),
 			ServiceMap: map[string]interface{}{
 				didexchange.DIDExchange: svc,
 				mediator.Coordination:   &mockroute.MockMediatorSvc{},
 			},
-		})
+		}
+
+		c, err := New(prov)
 		require.NoError(t, err)
 
-		didExchangeStore, err := storageProvider.OpenStore("didexchange")
+		recorder, err := connection.NewRecorder(prov)
 		require.NoError(t, err)
 
 		const count = 10
 		const countWithState = 5
-		const keyPrefix = "conn_"
 		const state = "completed"
 		const myDID = "my_did"
 		const theirDID = "their_did"
@@ -987,17 +987,14 @@ func TestClient_QueryConnectionsByParams(t *testing.T) { // nolint: gocyclo
 				queryState = state
 			}
 
-			val, e := json.Marshal(&connection.Record{
+			require.NoError(t, recorder.SaveConnectionRecord(&connection.Record{
 				ConnectionID:   fmt.Sprint(i),
 				InvitationID:   fmt.Sprintf("inv-%d", i),
 				ParentThreadID: fmt.Sprintf("ptid-%d", i),
 				State:          queryState,
 				MyDID:          myDID + strconv.Itoa(i),
 				TheirDID:       theirDID + strconv.Itoa(i),
-			})
-			require.NoError(t, e)
-			require.NoError(t,
-				didExchangeStore.Put(fmt.Sprintf("%sabc%d", keyPrefix, i), val, spi.Tag{Name: keyPrefix}))
+			}))
 		}
 
 		results, err := c.QueryConnections(&QueryConnectionsParams{})
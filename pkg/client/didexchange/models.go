@@ -45,6 +45,17 @@ type QueryConnectionsParams struct {
 	TheirRole string `json:"their_role,omitempty"`
 }
 
+// filter returns the connection store filter of the query parameters.
+func (p *QueryConnectionsParams) filter() connection.QueryFilter {
+	return connection.QueryFilter{
+		State:          p.State,
+		MyDID:          p.MyDID,
+		TheirDID:       p.TheirDID,
+		InvitationID:   p.InvitationID,
+		ParentThreadID: p.ParentThreadID,
+	}
+}
+
 // Connection model
 //
 // This is used to represent query connection result
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	Query(connectionID, query string, options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Protocol, error)
+	Supported(query string) []string
+}
+
+// Client enables access to the discover features api.
+type Client struct {
+	discoverFeaturesSvc protocolService
+}
+
+// New returns new instance of the discover features client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discover features service: %w", err)
+	}
+
+	discoverFeaturesSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{discoverFeaturesSvc: discoverFeaturesSvc}, nil
+}
+
+// Query asks the other party of the given connection which protocols matching the query it supports.
+// The query may contain * wildcards (e.g. https://didcomm.org/issue-credential/*).
+func (c *Client) Query(connectionID, query string,
+	options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Protocol, error) {
+	protocols, err := c.discoverFeaturesSvc.Query(connectionID, query, options...)
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - query: %w", err)
+	}
+
+	return protocols, nil
+}
+
+// Supported returns the protocols supported by this agent matching the query.
+func (c *Client) Supported(query string) []string {
+	return c.discoverFeaturesSvc.Supported(query)
+}
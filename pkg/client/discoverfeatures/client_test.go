@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to discover features service failed")
+	})
+}
+
+func TestQuery(t *testing.T) {
+	t.Run("query - success", func(t *testing.T) {
+		expected := []*discoverfeatures.Protocol{{PID: "https://didcomm.org/trust_ping/1.0"}}
+
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryFunc: func(connectionID, query string,
+					_ ...discoverfeatures.QueryOption) ([]*discoverfeatures.Protocol, error) {
+					require.Equal(t, "connID", connectionID)
+					require.Equal(t, "*", query)
+
+					return expected, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		protocols, err := client.Query("connID", "*", discoverfeatures.WithVersion(discoverfeatures.V2))
+		require.NoError(t, err)
+		require.Equal(t, expected, protocols)
+	})
+
+	t.Run("query - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{QueryErr: errors.New("service error")},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Query("connID", "*")
+		require.EqualError(t, err, "discover features client - query: service error")
+	})
+}
+
+func TestSupported(t *testing.T) {
+	client, err := New(&mockprovider.Provider{
+		ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			SupportedFunc: func(query string) []string {
+				return []string{query}
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"*"}, client.Supported("*"))
+}
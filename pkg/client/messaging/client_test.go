@@ -30,7 +30,6 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
-	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 func TestNew(t *testing.T) {
@@ -184,15 +183,13 @@ func TestCommand_Send(t *testing.T) { // nolint: gocognit, gocyclo
 			t.Run(tc.name, func(t *testing.T) {
 				memProvider := mem.NewProvider()
 
-				memStore, err := memProvider.OpenStore("didexchange")
-				require.NoError(t, err)
-
 				if tc.testConnection != nil {
-					connBytes, errMarshal := json.Marshal(tc.testConnection)
-					require.NoError(t, errMarshal)
-					require.NoError(t,
-						memStore.Put(fmt.Sprintf("conn_%s", tc.testConnection.ConnectionID), connBytes,
-							spi.Tag{Name: "conn_"}))
+					recorder, errRecorder := connection.NewRecorder(&protocol.MockProvider{
+						StoreProvider:              memProvider,
+						ProtocolStateStoreProvider: mem.NewProvider(),
+					})
+					require.NoError(t, errRecorder)
+					require.NoError(t, recorder.SaveConnectionRecord(tc.testConnection))
 				}
 
 				cmd, err := New(&protocol.MockProvider{
@@ -269,15 +266,13 @@ func TestCommand_Send(t *testing.T) { // nolint: gocognit, gocyclo
 			t.Run(tc.name, func(t *testing.T) {
 				memProvider := mem.NewProvider()
 
-				memStore, err := memProvider.OpenStore("didexchange")
-				require.NoError(t, err)
-
 				if tc.testConnection != nil {
-					connBytes, errMarshal := json.Marshal(tc.testConnection)
-					require.NoError(t, errMarshal)
-					require.NoError(t,
-						memStore.Put(fmt.Sprintf("conn_%s", tc.testConnection.ConnectionID), connBytes,
-							spi.Tag{Name: "conn_"}))
+					recorder, errRecorder := connection.NewRecorder(&protocol.MockProvider{
+						StoreProvider:              memProvider,
+						ProtocolStateStoreProvider: mem.NewProvider(),
+					})
+					require.NoError(t, errRecorder)
+					require.NoError(t, recorder.SaveConnectionRecord(tc.testConnection))
 				}
 
 				registrar := msghandler.NewMockMsgServiceProvider()
@@ -9,6 +9,7 @@ package messaging
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -80,6 +81,16 @@ func (m *msgService) Accept(msgType string, purpose []string) bool {
 	return purposeMatched && typeMatched
 }
 
+// Features returns the protocol of the message type handled by this service, if any.
+func (m *msgService) Features() []string {
+	i := strings.LastIndex(m.msgType, "/")
+	if i < 0 {
+		return nil
+	}
+
+	return []string{m.msgType[:i]}
+}
+
 func (m *msgService) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	if m.name == "" || m.topicHandle == nil {
 		return "", fmt.Errorf(errTopicNotFound)
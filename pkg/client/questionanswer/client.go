@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+)
+
+type (
+	// Question is asked by the Questioner.
+	Question questionanswer.Question
+	// Action contains helpful information about action.
+	Action questionanswer.Action
+)
+
+var errEmptyQuestion = errors.New("question message is empty")
+
+// Provider contains dependencies for the protocol and is typically created by using aries.Context().
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// ProtocolService defines the questionanswer service.
+type ProtocolService interface {
+	service.DIDComm
+	Actions() ([]questionanswer.Action, error)
+	ActionContinue(piID string, opt questionanswer.Opt) error
+	ActionStop(piID string, err error) error
+}
+
+// Client enable access to questionanswer API
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0113-question-answer
+type Client struct {
+	service.Event
+	service ProtocolService
+}
+
+// New returns new instance of the questionanswer client.
+func New(ctx Provider) (*Client, error) {
+	raw, err := ctx.Service(questionanswer.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, ok := raw.(ProtocolService)
+	if !ok {
+		return nil, errors.New("cast service to questionanswer service failed")
+	}
+
+	return &Client{
+		Event:   svc,
+		service: svc,
+	}, nil
+}
+
+// Actions returns pending actions that have yet to be executed or cancelled.
+func (c *Client) Actions() ([]Action, error) {
+	actions, err := c.service.Actions()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Action, len(actions))
+	for i, action := range actions {
+		result[i] = Action(action)
+	}
+
+	return result, nil
+}
+
+// SendQuestion is used by the Questioner to ask a question.
+// It returns the threadID of the new instance of the protocol.
+func (c *Client) SendQuestion(msg *Question, myDID, theirDID string) (string, error) {
+	if msg == nil {
+		return "", errEmptyQuestion
+	}
+
+	msg.Type = questionanswer.QuestionMsgType
+
+	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), myDID, theirDID)
+}
+
+// AnswerQuestion is used by the Responder to answer the question.
+// The response must be one of the valid responses of the question, if any were provided.
+func (c *Client) AnswerQuestion(piID, response string) error {
+	return c.service.ActionContinue(piID, WithAnswer(response))
+}
+
+// DeclineQuestion is used when the Responder does not want to answer the question.
+func (c *Client) DeclineQuestion(piID, reason string) error {
+	return c.service.ActionStop(piID, errors.New(reason))
+}
+
+// AcceptProblemReport accepts problem report action.
+func (c *Client) AcceptProblemReport(piID string) error {
+	return c.service.ActionContinue(piID, nil)
+}
+
+// WithAnswer allows providing the response to the question.
+// Use this option to respond to Question.
+func WithAnswer(response string) questionanswer.Opt {
+	return questionanswer.WithAnswer(&questionanswer.Answer{Response: response})
+}
@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/questionanswer"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	const errMsg = "test err"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("get service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, errors.New(errMsg))
+		_, err := New(provider)
+		require.EqualError(t, err, errMsg)
+	})
+
+	t.Run("cast service error", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+		_, err := New(provider)
+		require.EqualError(t, err, "cast service to questionanswer service failed")
+	})
+}
+
+func TestClient_SendQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		thid := uuid.New().String()
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, questionanswer.QuestionMsgType, msg.Type())
+
+				return thid, nil
+			})
+
+		client := newClient(t, ctrl, svc)
+
+		result, err := client.SendQuestion(&Question{QuestionText: "question"}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, thid, result)
+	})
+
+	t.Run("Empty question", func(t *testing.T) {
+		client := newClient(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+		_, err := client.SendQuestion(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyQuestion.Error())
+	})
+}
+
+func TestClient_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Actions().Return([]questionanswer.Action{{PIID: "1"}, {PIID: "2"}}, nil)
+
+		client := newClient(t, ctrl, svc)
+
+		actions, err := client.Actions()
+		require.NoError(t, err)
+		require.Equal(t, []Action{{PIID: "1"}, {PIID: "2"}}, actions)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().Actions().Return(nil, errors.New("error"))
+
+		client := newClient(t, ctrl, svc)
+
+		_, err := client.Actions()
+		require.EqualError(t, err, "error")
+	})
+}
+
+func TestClient_AnswerQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	piID := uuid.New().String()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue(piID, gomock.Any()).Return(nil)
+
+	require.NoError(t, newClient(t, ctrl, svc).AnswerQuestion(piID, "yes"))
+}
+
+func TestClient_DeclineQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	piID := uuid.New().String()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionStop(piID, errors.New("reason")).Return(nil)
+
+	require.NoError(t, newClient(t, ctrl, svc).DeclineQuestion(piID, "reason"))
+}
+
+func TestClient_AcceptProblemReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	piID := uuid.New().String()
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue(piID, nil).Return(nil)
+
+	require.NoError(t, newClient(t, ctrl, svc).AcceptProblemReport(piID))
+}
+
+func newClient(t *testing.T, ctrl *gomock.Controller, svc ProtocolService) *Client {
+	t.Helper()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	return client
+}
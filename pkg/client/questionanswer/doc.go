@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package questionanswer provides support for the Question Answer Protocol 1.0:
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0113-question-answer.
+//
+// A protocol allowing an agent (the Questioner) to ask a question with a set of valid responses
+// to another agent (the Responder).
+//
+// 1. Create your client:
+//
+// 	client, err := questionanswer.New(ctx)
+// 	if err != nil {
+// 	 panic(err)
+// 	}
+//
+// 2. Register an action event channel.
+//
+// 	actions := make(chan service.DIDCommAction)
+// 	client.RegisterActionEvent(actions)
+//
+// 3. Handle incoming actions.
+//
+//  for {
+//    select {
+//      case event := <-actions:
+//        piid := e.Properties.All()["piid"].(string)
+//
+//        if event.Message.Type() == questionanswer.QuestionMsgType {
+//          // If Responder is willing to answer the question.
+//          client.AnswerQuestion(piid, response)
+//          // If Responder is not willing to answer the question.
+//          client.DeclineQuestion(piid, reason)
+//        }
+//
+//        if event.Message.Type() == questionanswer.ProblemReportMsgType {
+//          Problem report message is triggered to notify client about the error.
+//          In that case, there is only one option - accept it.
+//          client.AcceptProblemReport(piid)
+//        }
+//    }
+//  }
+//
+// 4. The answer is delivered to the Questioner through the state message events
+// (the "answer-received" state).
+//
+// How to initiate the protocol?
+// The protocol is initiated by the Questioner.
+//  client.SendQuestion(&Question{}, myDID, theirDID)
+//
+package questionanswer
@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	Ping(connectionID string, options ...trustping.PingOption) (time.Duration, error)
+}
+
+// Client enables access to the trust ping api.
+type Client struct {
+	trustPingSvc protocolService
+}
+
+// New returns new instance of the trust ping client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(trustping.TrustPing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trust ping service: %w", err)
+	}
+
+	trustPingSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to trust ping service failed")
+	}
+
+	return &Client{trustPingSvc: trustPingSvc}, nil
+}
+
+// Ping checks the liveness of the given connection by sending a ping and waiting for the response.
+// It returns the round-trip time.
+func (c *Client) Ping(connectionID string, options ...trustping.PingOption) (time.Duration, error) {
+	rtt, err := c.trustPingSvc.Ping(connectionID, options...)
+	if err != nil {
+		return 0, fmt.Errorf("trust ping client - ping: %w", err)
+	}
+
+	return rtt, nil
+}
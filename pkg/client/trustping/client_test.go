@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to trust ping service failed")
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("ping - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{},
+		})
+		require.NoError(t, err)
+
+		rtt, err := client.Ping("connID")
+		require.NoError(t, err)
+		require.Equal(t, time.Millisecond, rtt)
+	})
+
+	t.Run("ping - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{PingErr: errors.New("service error")},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Ping("connID")
+		require.EqualError(t, err, "trust ping client - ping: service error")
+	})
+}
@@ -0,0 +1,456 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	jsonContentType      = "application/json"
+	defaultChallengeTTL  = 5 * time.Minute
+	defaultMaxChallenges = 10000
+)
+
+// provider contains dependencies for the credential refresh client and is typically created by using aries.Context().
+type provider interface {
+	VerifiableStore() verifiablestore.Store
+	VDRegistry() vdrapi.Registry
+}
+
+// Opt is an option of the Client and the Service.
+type Opt func(opts *options)
+
+type options struct {
+	httpClient     *http.Client
+	documentLoader ld.DocumentLoader
+	domain         string
+	challengeTTL   time.Duration
+	maxChallenges  int
+}
+
+// WithHTTPClient sets the HTTP client used by the Client to call refresh services.
+func WithHTTPClient(client *http.Client) Opt {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithJSONLDDocumentLoader sets the JSON-LD document loader used to sign and verify presentations and credentials.
+func WithJSONLDDocumentLoader(loader ld.DocumentLoader) Opt {
+	return func(opts *options) {
+		opts.documentLoader = loader
+	}
+}
+
+// WithDomain sets the domain which the Service requires the presentations of the holders to be bound to.
+func WithDomain(domain string) Opt {
+	return func(opts *options) {
+		opts.domain = domain
+	}
+}
+
+// WithChallengeTTL sets how long the challenges issued by the Service can be used (5 minutes by default).
+func WithChallengeTTL(ttl time.Duration) Opt {
+	return func(opts *options) {
+		opts.challengeTTL = ttl
+	}
+}
+
+// WithMaxChallenges sets how many unexpired challenges the Service can have issued (10000 by default). Further
+// presentation requests are refused until challenges expire or are used.
+func WithMaxChallenges(maxChallenges int) Opt {
+	return func(opts *options) {
+		opts.maxChallenges = maxChallenges
+	}
+}
+
+func getOptions(opts []Opt) *options {
+	o := &options{
+		httpClient:    http.DefaultClient,
+		challengeTTL:  defaultChallengeTTL,
+		maxChallenges: defaultMaxChallenges,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.documentLoader == nil {
+		o.documentLoader = verifiable.CachingJSONLDLoader()
+	}
+
+	return o
+}
+
+// Client refreshes the credentials of the verifiable store of a holder with their refresh services.
+// To prove the control of the credentials, the holder authenticates to a refresh service with a presentation
+// of the credential signed with a key of the holder DID. The credentials of a vcwallet are not refreshed, as the
+// wallet can't store credentials yet.
+type Client struct {
+	store     verifiablestore.Store
+	keys      verifiable.PublicKeyFetcher
+	authProof verifiable.LinkedDataProofContext
+	holder    string
+	opts      *options
+
+	mu     sync.RWMutex
+	events []chan<- Event
+}
+
+// New returns a new credential refresh Client. Presentations sent to refresh services are signed using authProof,
+// whose VerificationMethod must be a DID URL of a key of the holder DID.
+func New(ctx provider, authProof *verifiable.LinkedDataProofContext, opts ...Opt) (*Client, error) {
+	holder, err := controllerDID(authProof.VerificationMethod)
+	if err != nil {
+		return nil, fmt.Errorf("verification method of authentication proof is not a DID URL: %w", err)
+	}
+
+	return &Client{
+		store:     ctx.VerifiableStore(),
+		keys:      verifiable.NewDIDKeyResolver(ctx.VDRegistry()).PublicKeyFetcher(),
+		authProof: *authProof,
+		holder:    holder,
+		opts:      getOptions(opts),
+	}, nil
+}
+
+// RegisterEvent registers a channel receiving the events of the refresh of credentials. The events are sent
+// without blocking the refresh: they are dropped if the channel is not ready to receive them, so it should be
+// buffered.
+func (c *Client) RegisterEvent(ch chan<- Event) error {
+	if ch == nil {
+		return service.ErrNilChannel
+	}
+
+	c.mu.Lock()
+	c.events = append(c.events, ch)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// UnregisterEvent unregisters a channel registered by RegisterEvent().
+func (c *Client) UnregisterEvent(ch chan<- Event) error {
+	c.mu.Lock()
+	for i := 0; i < len(c.events); i++ {
+		if c.events[i] == ch {
+			c.events = append(c.events[:i], c.events[i+1:]...)
+			i--
+		}
+	}
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *Client) emit(event Event) {
+	c.mu.RLock()
+	events := append(c.events[:0:0], c.events...)
+	c.mu.RUnlock()
+
+	for _, ch := range events {
+		select {
+		case ch <- event:
+		default:
+			logger.Warnf("dropped %s event of credential %s: channel is not ready", event.Type, event.Name)
+		}
+	}
+}
+
+// ExpiringCredentials returns the records of the credentials of the store which expire within the given period
+// and have a supported refresh service.
+func (c *Client) ExpiringCredentials(within time.Duration) ([]*verifiablestore.Record, error) {
+	records, err := c.store.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("get credentials: %w", err)
+	}
+
+	deadline := time.Now().Add(within)
+
+	var expiring []*verifiablestore.Record
+
+	for _, record := range records {
+		vc, e := c.store.GetCredential(record.ID)
+		if e != nil {
+			return nil, fmt.Errorf("get credential %s: %w", record.Name, e)
+		}
+
+		if _, ok := refreshServiceURL(vc); !ok {
+			continue
+		}
+
+		if _, validUntil := vc.ValidityPeriod(); validUntil != nil && validUntil.Before(deadline) {
+			expiring = append(expiring, record)
+		}
+	}
+
+	return expiring, nil
+}
+
+// RefreshExpiring refreshes the credentials of the store which expire within the given period (see Refresh()).
+// A failed refresh does not stop the refresh of other credentials, the returned error lists all failures.
+func (c *Client) RefreshExpiring(within time.Duration) error {
+	records, err := c.ExpiringCredentials(within)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+
+	for _, record := range records {
+		if _, e := c.Refresh(record.Name); e != nil {
+			failures = append(failures, e.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("refresh credentials: %s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// Refresh refreshes the credential stored under the name with its refresh service, and replaces it in the store
+// by the refreshed credential, which must be secured by the issuer of the previous credential and whose subject
+// must still be the holder. CredentialRefreshed or CredentialRefreshFailed event is emitted.
+func (c *Client) Refresh(name string) (*verifiable.Credential, error) {
+	previous, refreshed, err := c.refresh(name)
+	if err != nil {
+		err = fmt.Errorf("refresh credential %s: %w", name, err)
+
+		c.emit(Event{Type: CredentialRefreshFailed, Name: name, PreviousCredential: previous, Err: err})
+
+		return nil, err
+	}
+
+	c.emit(Event{Type: CredentialRefreshed, Name: name, PreviousCredential: previous, Credential: refreshed})
+
+	return refreshed, nil
+}
+
+func (c *Client) refresh(name string) (*verifiable.Credential, *verifiable.Credential, error) {
+	record, err := c.record(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := c.store.GetCredential(record.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get credential: %w", err)
+	}
+
+	serviceURL, ok := refreshServiceURL(previous)
+	if !ok {
+		return previous, nil, errors.New("credential has no supported refresh service")
+	}
+
+	var request PresentationRequest
+
+	if err = c.post(serviceURL, nil, &request); err != nil {
+		return previous, nil, fmt.Errorf("request presentation: %w", err)
+	}
+
+	vp, err := c.authPresentation(previous, &request)
+	if err != nil {
+		return previous, nil, err
+	}
+
+	refreshed, err := c.sendPresentation(serviceURL, vp, previous.Issuer.ID)
+	if err != nil {
+		return previous, nil, err
+	}
+
+	if subjectID, e := verifiable.SubjectID(refreshed.Subject); e != nil || subjectID != c.holder {
+		return previous, nil, errors.New("holder is not the subject of the refreshed credential")
+	}
+
+	if err = c.replace(record, previous, refreshed); err != nil {
+		return previous, nil, err
+	}
+
+	return previous, refreshed, nil
+}
+
+func (c *Client) record(name string) (*verifiablestore.Record, error) {
+	records, err := c.store.GetCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("get credentials: %w", err)
+	}
+
+	for _, record := range records {
+		if record.Name == name {
+			return record, nil
+		}
+	}
+
+	return nil, errors.New("credential not found")
+}
+
+func (c *Client) authPresentation(vc *verifiable.Credential,
+	request *PresentationRequest) (*verifiable.Presentation, error) {
+	if !hasDIDAuthenticationQuery(request) || request.Challenge == "" {
+		return nil, errors.New("refresh service did not request DID authentication")
+	}
+
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(vc))
+	if err != nil {
+		return nil, fmt.Errorf("create presentation: %w", err)
+	}
+
+	vp.Holder = c.holder
+
+	proofContext := c.authProof
+	proofContext.Challenge = request.Challenge
+	proofContext.Domain = request.Domain
+	proofContext.Purpose = authenticationProofPurpose
+
+	if err = vp.AddLinkedDataProof(&proofContext, jsonld.WithDocumentLoader(c.opts.documentLoader)); err != nil {
+		return nil, fmt.Errorf("sign presentation: %w", err)
+	}
+
+	return vp, nil
+}
+
+// sendPresentation sends the authentication presentation to the refresh service and returns the refreshed
+// credential, which must be secured by a proof or JWS of the issuer of the previous credential.
+func (c *Client) sendPresentation(serviceURL string, vp *verifiable.Presentation,
+	issuer string) (*verifiable.Credential, error) {
+	vpBytes, err := vp.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal presentation: %w", err)
+	}
+
+	var response json.RawMessage
+
+	if err = c.post(serviceURL, vpBytes, &response); err != nil {
+		return nil, fmt.Errorf("send presentation: %w", err)
+	}
+
+	responseVP, err := verifiable.ParsePresentation(response,
+		verifiable.WithPresDisabledProofCheck(),
+		verifiable.WithPresJSONLDDocumentLoader(c.opts.documentLoader),
+		verifiable.WithPresDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2))
+	if err != nil {
+		return nil, fmt.Errorf("parse refreshed presentation: %w", err)
+	}
+
+	credentials, err := responseVP.MarshalledCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(credentials) == 0 {
+		return nil, errors.New("refresh service returned no credential")
+	}
+
+	refreshed, err := verifiable.ParseCredential(credentials[0],
+		verifiable.WithPublicKeyFetcher(c.keys),
+		verifiable.WithJSONLDDocumentLoader(c.opts.documentLoader),
+		verifiable.WithDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2),
+		verifiable.WithVerificationPolicy(&verifiable.VerificationPolicy{
+			TrustedIssuers: map[string][]string{verifiable.VCType: {issuer}},
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("parse refreshed credential: %w", err)
+	}
+
+	return refreshed, nil
+}
+
+// replace replaces the credential of the record in the store, keeping its name and participants.
+func (c *Client) replace(record *verifiablestore.Record, previous, refreshed *verifiable.Credential) error {
+	if err := c.store.RemoveCredentialByName(record.Name); err != nil {
+		return fmt.Errorf("remove previous credential: %w", err)
+	}
+
+	err := c.store.SaveCredential(record.Name, refreshed,
+		verifiablestore.WithMyDID(record.MyDID), verifiablestore.WithTheirDID(record.TheirDID))
+	if err == nil {
+		return nil
+	}
+
+	// restore the previous credential not to lose it.
+	if e := c.store.SaveCredential(record.Name, previous,
+		verifiablestore.WithMyDID(record.MyDID), verifiablestore.WithTheirDID(record.TheirDID)); e != nil {
+		return fmt.Errorf("save refreshed credential: %w (restore previous credential: %v)", err, e)
+	}
+
+	return fmt.Errorf("save refreshed credential: %w", err)
+}
+
+func (c *Client) post(url string, body []byte, v interface{}) error {
+	resp, err := c.opts.httpClient.Post(url, jsonContentType, bytes.NewReader(body)) //nolint:noctx
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close response body: %v", e)
+		}
+	}()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("refresh service responded with status %d: %s", resp.StatusCode,
+			strings.TrimSpace(string(respBody)))
+	}
+
+	if err = json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// refreshServiceURL returns the URL of the first refresh service of the credential of a supported type.
+// Only HTTPS URLs are supported as the holder sends the presentation of the credential to the refresh service.
+func refreshServiceURL(vc *verifiable.Credential) (string, bool) {
+	for _, refreshService := range vc.RefreshService {
+		if refreshService.Type != ManualRefreshService2018 &&
+			refreshService.Type != VerifiableCredentialRefreshService2021 {
+			continue
+		}
+
+		if strings.HasPrefix(refreshService.ID, "https://") {
+			return refreshService.ID, true
+		}
+	}
+
+	return "", false
+}
+
+func hasDIDAuthenticationQuery(request *PresentationRequest) bool {
+	for _, query := range request.Query {
+		if query.Type == DIDAuthenticationQuery {
+			return true
+		}
+	}
+
+	return false
+}
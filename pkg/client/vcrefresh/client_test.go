@@ -0,0 +1,327 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const credentialName = "badge"
+
+type testProvider struct {
+	store verifiablestore.Store
+	vdr   vdrapi.Registry
+}
+
+func (p *testProvider) VerifiableStore() verifiablestore.Store {
+	return p.store
+}
+
+func (p *testProvider) VDRegistry() vdrapi.Registry {
+	return p.vdr
+}
+
+// testParty is an issuer or a holder identified by did:key.
+type testParty struct {
+	did      string
+	proofCtx *verifiable.LinkedDataProofContext
+	vdr      vdrapi.Registry
+}
+
+func newTestParty(t *testing.T) *testParty {
+	t.Helper()
+
+	signer, err := signature.NewSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	did, keyID := fingerprint.CreateDIDKey(signer.PublicKeyBytes())
+
+	return &testParty{
+		did: did,
+		proofCtx: &verifiable.LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SignatureRepresentation: verifiable.SignatureJWS,
+			VerificationMethod:      keyID,
+		},
+		vdr: vdr.New(&mockprovider.Provider{}, vdr.WithVDR(key.New())),
+	}
+}
+
+func (p *testParty) issue(t *testing.T, holder, refreshURL string, validUntil time.Time) *verifiable.Credential {
+	t.Helper()
+
+	vc, err := p.newCredential(holder, refreshURL, validUntil)
+	require.NoError(t, err)
+
+	return vc
+}
+
+func (p *testParty) newCredential(holder, refreshURL string, validUntil time.Time) (*verifiable.Credential, error) {
+	return p.signCredential(p.did, holder, refreshURL, validUntil)
+}
+
+// signCredential signs a credential of the issuer, which may be a DID not controlled by the party.
+func (p *testParty) signCredential(issuer, holder, refreshURL string,
+	validUntil time.Time) (*verifiable.Credential, error) {
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		ID:      fmt.Sprintf("http://example.com/credentials/badge/%d", validUntil.UnixNano()),
+		Types:   []string{verifiable.VCType},
+		Issuer:  verifiable.Issuer{ID: issuer},
+		Issued:  util.NewTime(validUntil.Add(-30 * 24 * time.Hour)),
+		Expired: util.NewTime(validUntil),
+		Subject: verifiable.Subject{ID: holder},
+	}
+
+	if refreshURL != "" {
+		vc.RefreshService = []verifiable.TypedID{{ID: refreshURL, Type: ManualRefreshService2018}}
+	}
+
+	err := vc.AddLinkedDataProof(p.proofCtx, jsonld.WithDocumentLoader(verifiable.CachingJSONLDLoader()))
+	if err != nil {
+		return nil, err
+	}
+
+	return vc, nil
+}
+
+func newTestStore(t *testing.T) verifiablestore.Store {
+	t.Helper()
+
+	store, err := verifiablestore.New(&mockprovider.Provider{StorageProviderValue: storage.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	return store
+}
+
+//nolint:funlen
+func TestClient_Refresh(t *testing.T) {
+	issuer, holder, attacker := newTestParty(t), newTestParty(t), newTestParty(t)
+
+	var (
+		reissueErr error
+		reissueFn  ReissueFunc
+	)
+
+	server := httptest.NewTLSServer(NewService(issuer.vdr,
+		func(holderDID string, vc *verifiable.Credential) (*verifiable.Credential, error) {
+			if reissueErr != nil {
+				return nil, reissueErr
+			}
+
+			if reissueFn != nil {
+				return reissueFn(holderDID, vc)
+			}
+
+			assert.Equal(t, holder.did, holderDID)
+			assert.Equal(t, issuer.did, vc.Issuer.ID)
+
+			return issuer.newCredential(holderDID, vc.RefreshService[0].ID, vc.Expired.Add(30*24*time.Hour))
+		}))
+	defer server.Close()
+
+	store := newTestStore(t)
+
+	client, err := New(&testProvider{store: store, vdr: holder.vdr}, holder.proofCtx, WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	events := make(chan Event, 1)
+	require.NoError(t, client.RegisterEvent(events))
+
+	vc := issuer.issue(t, holder.did, server.URL, time.Now().Add(48*time.Hour))
+	require.NoError(t, store.SaveCredential(credentialName, vc, verifiablestore.WithTheirDID(issuer.did)))
+
+	require.NoError(t, store.SaveCredential("not refreshable",
+		issuer.issue(t, holder.did, "", time.Now().Add(time.Hour))))
+
+	t.Run("detect expiring credentials", func(t *testing.T) {
+		records, err := client.ExpiringCredentials(72 * time.Hour)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, credentialName, records[0].Name)
+
+		records, err = client.ExpiringCredentials(24 * time.Hour)
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+
+	t.Run("refresh denied", func(t *testing.T) {
+		reissueErr = fmt.Errorf("employee left: %w", ErrRefreshDenied)
+		defer func() { reissueErr = nil }()
+
+		err := client.RefreshExpiring(72 * time.Hour)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status 403")
+		require.Contains(t, err.Error(), "employee left")
+
+		event := <-events
+		require.Equal(t, CredentialRefreshFailed, event.Type)
+		require.Equal(t, credentialName, event.Name)
+		require.Equal(t, vc.ID, event.PreviousCredential.ID)
+		require.Equal(t, err.Error(), "refresh credentials: "+event.Err.Error())
+
+		id, err := store.GetCredentialIDByName(credentialName)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, id)
+	})
+
+	t.Run("refreshed credential is not valid", func(t *testing.T) {
+		defer func() { reissueFn = nil }()
+
+		tests := []struct {
+			name    string
+			reissue ReissueFunc
+			err     string
+		}{
+			{
+				name: "signed by other DID",
+				reissue: func(holderDID string, vc *verifiable.Credential) (*verifiable.Credential, error) {
+					return attacker.signCredential(issuer.did, holderDID, vc.RefreshService[0].ID,
+						vc.Expired.Add(30*24*time.Hour))
+				},
+				err: "is not secured by a verified proof of issuer",
+			},
+			{
+				name: "not signed",
+				reissue: func(holderDID string, vc *verifiable.Credential) (*verifiable.Credential, error) {
+					reissued, e := issuer.newCredential(holderDID, vc.RefreshService[0].ID, vc.Expired.Add(time.Hour))
+					if e == nil {
+						reissued.Proofs = nil
+					}
+
+					return reissued, e
+				},
+				err: "is not secured by a verified proof of issuer",
+			},
+			{
+				name: "issued to other subject",
+				reissue: func(_ string, vc *verifiable.Credential) (*verifiable.Credential, error) {
+					return issuer.newCredential(attacker.did, vc.RefreshService[0].ID, vc.Expired.Add(time.Hour))
+				},
+				err: "holder is not the subject of the refreshed credential",
+			},
+		}
+
+		for _, tc := range tests {
+			reissueFn = tc.reissue
+
+			_, err := client.Refresh(credentialName)
+			require.Error(t, err, tc.name)
+			require.Contains(t, err.Error(), tc.err, tc.name)
+			require.Equal(t, CredentialRefreshFailed, (<-events).Type)
+
+			id, err := store.GetCredentialIDByName(credentialName)
+			require.NoError(t, err)
+			require.Equal(t, vc.ID, id)
+		}
+	})
+
+	t.Run("refresh expiring credentials", func(t *testing.T) {
+		require.NoError(t, client.RefreshExpiring(72*time.Hour))
+
+		event := <-events
+		require.Equal(t, CredentialRefreshed, event.Type)
+		require.Equal(t, credentialName, event.Name)
+		require.Equal(t, vc.ID, event.PreviousCredential.ID)
+		require.NoError(t, event.Err)
+		require.True(t, event.Credential.Expired.After(vc.Expired.Time))
+
+		id, err := store.GetCredentialIDByName(credentialName)
+		require.NoError(t, err)
+		require.Equal(t, event.Credential.ID, id)
+
+		records, err := store.GetCredentials()
+		require.NoError(t, err)
+
+		for _, record := range records {
+			if record.Name == credentialName {
+				require.Equal(t, issuer.did, record.TheirDID)
+			}
+		}
+
+		records, err = client.ExpiringCredentials(72 * time.Hour)
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+
+	t.Run("refresh credential without refresh service", func(t *testing.T) {
+		_, err := client.Refresh("not refreshable")
+		require.EqualError(t, err, "refresh credential not refreshable: credential has no supported refresh service")
+		require.Equal(t, CredentialRefreshFailed, (<-events).Type)
+
+		_, err = client.Refresh("unknown")
+		require.EqualError(t, err, "refresh credential unknown: credential not found")
+		require.Equal(t, CredentialRefreshFailed, (<-events).Type)
+
+		require.NoError(t, store.SaveCredential("plain HTTP",
+			issuer.issue(t, holder.did, "http://example.com/refresh", time.Now().Add(time.Hour))))
+
+		_, err = client.Refresh("plain HTTP")
+		require.EqualError(t, err, "refresh credential plain HTTP: credential has no supported refresh service")
+		require.Equal(t, CredentialRefreshFailed, (<-events).Type)
+	})
+
+	t.Run("refresh service is not available", func(t *testing.T) {
+		unavailable := httptest.NewTLSServer(nil)
+		unavailable.Close()
+
+		require.NoError(t, store.SaveCredential("unavailable",
+			issuer.issue(t, holder.did, unavailable.URL, time.Now().Add(time.Hour))))
+
+		_, err := client.Refresh("unavailable")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "refresh credential unavailable: request presentation")
+		require.Equal(t, CredentialRefreshFailed, (<-events).Type)
+	})
+
+	t.Run("events are not blocking", func(t *testing.T) {
+		notReady := make(chan Event)
+		require.NoError(t, client.RegisterEvent(notReady))
+
+		defer func() { require.NoError(t, client.UnregisterEvent(notReady)) }()
+
+		_, err := client.Refresh("unknown")
+		require.Error(t, err)
+		require.Equal(t, CredentialRefreshFailed, (<-events).Type)
+	})
+
+	require.NoError(t, client.UnregisterEvent(events))
+	require.Equal(t, service.ErrNilChannel, client.RegisterEvent(nil))
+}
+
+func TestNew(t *testing.T) {
+	client, err := New(&testProvider{},
+		&verifiable.LinkedDataProofContext{VerificationMethod: "did:example:holder?versionId=1#key1"})
+	require.NoError(t, err)
+	require.Equal(t, "did:example:holder", client.holder)
+
+	_, err = New(&testProvider{}, &verifiable.LinkedDataProofContext{VerificationMethod: "#key1"})
+	require.Contains(t, err.Error(), "verification method of authentication proof is not a DID URL")
+}
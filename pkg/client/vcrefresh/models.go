@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// ManualRefreshService2018 is the type of refresh service of VC Data Model 1.1 examples
+	// (https://www.w3.org/TR/vc-data-model/#refreshing).
+	ManualRefreshService2018 = "ManualRefreshService2018"
+
+	// VerifiableCredentialRefreshService2021 is the type of refresh service of VC Refresh 2021
+	// (https://w3c-ccg.github.io/vc-refresh-2021/).
+	VerifiableCredentialRefreshService2021 = "VerifiableCredentialRefreshService2021"
+
+	// DIDAuthenticationQuery is the type of the query of the presentation request of a refresh service.
+	DIDAuthenticationQuery = "DIDAuthentication"
+
+	// authenticationProofPurpose is the purpose of the proof of the presentation sent to a refresh service.
+	authenticationProofPurpose = "authentication"
+)
+
+// PresentationRequest is the request of a refresh service for a DID authenticated presentation of the credential
+// to refresh (https://w3c-ccg.github.io/vp-request-spec/#did-authentication).
+type PresentationRequest struct {
+	Query     []Query `json:"query"`
+	Challenge string  `json:"challenge"`
+	Domain    string  `json:"domain,omitempty"`
+}
+
+// Query of the PresentationRequest.
+type Query struct {
+	Type string `json:"type"`
+}
+
+// EventType is the type of Event.
+type EventType string
+
+const (
+	// CredentialRefreshed event is emitted when a credential is replaced by its refreshed version in the store.
+	CredentialRefreshed EventType = "credential-refreshed"
+
+	// CredentialRefreshFailed event is emitted when a credential can not be refreshed.
+	CredentialRefreshFailed EventType = "credential-refresh-failed"
+)
+
+// Event is emitted by the Client to the registered channels on the refresh of a credential.
+type Event struct {
+	Type EventType
+	// Name of the credential in the verifiable store.
+	Name string
+	// PreviousCredential is the credential replaced in the store (if known).
+	PreviousCredential *verifiable.Credential
+	// Credential is the refreshed credential (CredentialRefreshed event only).
+	Credential *verifiable.Credential
+	// Err is the reason of CredentialRefreshFailed event.
+	Err error
+}
@@ -0,0 +1,270 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	maxRequestSize = 1 << 20
+
+	proofPurposeField       = "proofPurpose"
+	proofChallengeField     = "challenge"
+	proofDomainField        = "domain"
+	proofVerificationMethod = "verificationMethod"
+)
+
+var logger = log.New("aries-framework/client/vcrefresh")
+
+// ErrRefreshDenied is returned by ReissueFunc when the issuer refuses to refresh the credential.
+var ErrRefreshDenied = errors.New("credential refresh denied")
+
+// ReissueFunc is the hook of the issuer reissuing a credential presented to the Service by its holder. The
+// presentation and the proofs of the credential are verified, and the holder is the subject of the credential,
+// but ReissueFunc must check that the credential was issued by the issuer (e.g. its issuer ID).
+// It returns the reissued credential, or an error wrapping ErrRefreshDenied if it refuses to refresh it.
+type ReissueFunc func(holder string, credential *verifiable.Credential) (*verifiable.Credential, error)
+
+// Service is the refresh service of an issuer, an http.Handler to be served at the URL of the refreshService of
+// the issued credentials. The holder POSTs an empty request to receive a PresentationRequest for DID authentication,
+// and then POSTs the presentation of the credential to receive a presentation of the reissued credential.
+type Service struct {
+	keys    verifiable.PublicKeyFetcher
+	reissue ReissueFunc
+	opts    *options
+
+	mu         sync.Mutex
+	challenges map[string]time.Time
+}
+
+// NewService returns a new refresh Service reissuing credentials with the reissue hook.
+func NewService(vdr vdrapi.Registry, reissue ReissueFunc, opts ...Opt) *Service {
+	return &Service{
+		keys:       verifiable.NewDIDKeyResolver(vdr).PublicKeyFetcher(),
+		reissue:    reissue,
+		opts:       getOptions(opts),
+		challenges: make(map[string]time.Time),
+	}
+}
+
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// ServeHTTP serves the PresentationRequest on empty POST requests, and the presentation of the reissued
+// credentials on POST requests with the presentation of the credentials to refresh.
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("read request: %v", err), http.StatusBadRequest)
+
+		return
+	}
+
+	var response interface{}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		response, err = s.presentationRequest()
+	} else {
+		response, err = s.refresh(body)
+	}
+
+	var httpErr *httpError
+
+	if errors.As(err, &httpErr) {
+		http.Error(w, httpErr.Error(), httpErr.status)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", jsonContentType)
+
+	if err = json.NewEncoder(w).Encode(response); err != nil {
+		logger.Errorf("failed to write refresh service response: %v", err)
+	}
+}
+
+// presentationRequest issues a challenge, unless the Service has already issued the maximum number of unexpired
+// challenges: the presentation requests are not authenticated, so the number of challenges must be bounded.
+func (s *Service) presentationRequest() (*PresentationRequest, error) {
+	challenge := uuid.New().String()
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.challenges) >= s.opts.maxChallenges {
+		for c, expiry := range s.challenges {
+			if now.After(expiry) {
+				delete(s.challenges, c)
+			}
+		}
+	}
+
+	if len(s.challenges) >= s.opts.maxChallenges {
+		return nil, &httpError{http.StatusServiceUnavailable, errors.New("too many pending presentation requests")}
+	}
+
+	s.challenges[challenge] = now.Add(s.opts.challengeTTL)
+
+	return &PresentationRequest{
+		Query:     []Query{{Type: DIDAuthenticationQuery}},
+		Challenge: challenge,
+		Domain:    s.opts.domain,
+	}, nil
+}
+
+// consumeChallenge returns whether the challenge was issued by the Service and has not expired,
+// and prevents its further usage.
+func (s *Service) consumeChallenge(challenge string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.challenges[challenge]
+	delete(s.challenges, challenge)
+
+	return ok && time.Now().Before(expiry)
+}
+
+func (s *Service) refresh(vpBytes []byte) (*verifiable.Presentation, error) {
+	vp, err := verifiable.ParsePresentation(vpBytes,
+		verifiable.WithPresPublicKeyFetcher(s.keys),
+		verifiable.WithPresJSONLDDocumentLoader(s.opts.documentLoader),
+		verifiable.WithPresDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2))
+	if err != nil {
+		return nil, &httpError{http.StatusUnauthorized, fmt.Errorf("verify presentation: %w", err)}
+	}
+
+	if err = s.checkAuthentication(vp); err != nil {
+		return nil, &httpError{http.StatusUnauthorized, err}
+	}
+
+	credentials, err := vp.MarshalledCredentials()
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, err}
+	}
+
+	if len(credentials) == 0 {
+		return nil, &httpError{http.StatusBadRequest, errors.New("presentation has no credential")}
+	}
+
+	reissued := make([]*verifiable.Credential, len(credentials))
+
+	for i, vcBytes := range credentials {
+		var vc *verifiable.Credential
+
+		vc, err = s.presentedCredential(vcBytes, vp.Holder)
+		if err != nil {
+			return nil, &httpError{http.StatusUnauthorized, fmt.Errorf("credential %d: %w", i, err)}
+		}
+
+		reissued[i], err = s.reissue(vp.Holder, vc)
+		if errors.Is(err, ErrRefreshDenied) {
+			return nil, &httpError{http.StatusForbidden, fmt.Errorf("credential %d: %w", i, err)}
+		}
+
+		if err != nil {
+			return nil, &httpError{http.StatusInternalServerError, fmt.Errorf("reissue credential %d: %w", i, err)}
+		}
+	}
+
+	refreshed, err := verifiable.NewPresentation(verifiable.WithCredentials(reissued...))
+	if err != nil {
+		return nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	return refreshed, nil
+}
+
+// checkAuthentication checks that the presentation has an authentication proof of its holder
+// bound to a challenge issued by the Service (and to its domain).
+func (s *Service) checkAuthentication(vp *verifiable.Presentation) error {
+	if vp.Holder == "" || len(vp.Proofs) == 0 {
+		return errors.New("presentation has no holder or proof")
+	}
+
+	for _, proof := range vp.Proofs {
+		purpose, _ := proof[proofPurposeField].(string)                  //nolint:errcheck
+		challenge, _ := proof[proofChallengeField].(string)              //nolint:errcheck
+		domain, _ := proof[proofDomainField].(string)                    //nolint:errcheck
+		verificationMethod, _ := proof[proofVerificationMethod].(string) //nolint:errcheck
+
+		if purpose != authenticationProofPurpose || domain != s.opts.domain {
+			continue
+		}
+
+		if holder, err := controllerDID(verificationMethod); err != nil || holder != vp.Holder {
+			continue
+		}
+
+		if s.consumeChallenge(challenge) {
+			return nil
+		}
+	}
+
+	return errors.New("presentation has no authentication proof of its holder for an issued challenge")
+}
+
+// presentedCredential parses and verifies the credential presented by the holder, which must be secured
+// and whose subject must be the holder.
+func (s *Service) presentedCredential(vcBytes []byte, holder string) (*verifiable.Credential, error) {
+	vc, err := verifiable.ParseCredential(vcBytes,
+		verifiable.WithPublicKeyFetcher(s.keys),
+		verifiable.WithJSONLDDocumentLoader(s.opts.documentLoader),
+		verifiable.WithDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vc.Proofs) == 0 && !jwt.IsJWS(string(vcBytes)) {
+		return nil, errors.New("credential is not secured")
+	}
+
+	if subjectID, e := verifiable.SubjectID(vc.Subject); e != nil || subjectID != holder {
+		return nil, errors.New("holder is not the subject of the credential")
+	}
+
+	return vc, nil
+}
+
+// controllerDID returns the DID of a verification method given as a DID URL.
+func controllerDID(verificationMethod string) (string, error) {
+	didURL, err := did.ParseDIDURL(verificationMethod)
+	if err != nil {
+		return "", err
+	}
+
+	return didURL.DID.String(), nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vcrefresh
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const testDomain = "issuer.example.com"
+
+//nolint:funlen
+func TestService(t *testing.T) {
+	issuer, holder := newTestParty(t), newTestParty(t)
+
+	vc := issuer.issue(t, holder.did, "https://issuer.example.com/refresh", time.Now().Add(time.Hour))
+
+	reissue := func(holderDID string, vc *verifiable.Credential) (*verifiable.Credential, error) {
+		return vc, nil
+	}
+
+	holderClient, err := New(&testProvider{}, holder.proofCtx)
+	require.NoError(t, err)
+
+	// presentation returns the presentation of vc by holder authenticated for the challenge of the service.
+	presentation := func(t *testing.T, svc *Service, vc *verifiable.Credential) []byte {
+		t.Helper()
+
+		request := post(t, svc, nil)
+		require.Equal(t, http.StatusOK, request.Code)
+
+		var vpRequest PresentationRequest
+
+		require.NoError(t, json.Unmarshal(request.Body.Bytes(), &vpRequest))
+
+		vp, err := holderClient.authPresentation(vc, &vpRequest)
+		require.NoError(t, err)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	t.Run("presentation request", func(t *testing.T) {
+		resp := post(t, NewService(issuer.vdr, reissue, WithDomain(testDomain)), nil)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, jsonContentType, resp.Header().Get("Content-Type"))
+
+		var request PresentationRequest
+
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &request))
+		require.Equal(t, []Query{{Type: DIDAuthenticationQuery}}, request.Query)
+		require.NotEmpty(t, request.Challenge)
+		require.Equal(t, testDomain, request.Domain)
+	})
+
+	t.Run("reissue credential", func(t *testing.T) {
+		svc := NewService(issuer.vdr, reissue, WithDomain(testDomain))
+		vpBytes := presentation(t, svc, vc)
+
+		resp := post(t, svc, vpBytes)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+		vp, err := verifiable.ParsePresentation(resp.Body.Bytes(), verifiable.WithPresDisabledProofCheck())
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		// the challenge can be used only once.
+		resp = post(t, svc, vpBytes)
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		require.Contains(t, resp.Body.String(), "no authentication proof of its holder for an issued challenge")
+	})
+
+	t.Run("challenge expired", func(t *testing.T) {
+		svc := NewService(issuer.vdr, reissue, WithChallengeTTL(-time.Second))
+
+		resp := post(t, svc, presentation(t, svc, vc))
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		require.Contains(t, resp.Body.String(), "no authentication proof of its holder for an issued challenge")
+	})
+
+	t.Run("too many pending challenges", func(t *testing.T) {
+		svc := NewService(issuer.vdr, reissue, WithMaxChallenges(2))
+
+		for i := 0; i < 2; i++ {
+			require.Equal(t, http.StatusOK, post(t, svc, nil).Code)
+		}
+
+		resp := post(t, svc, nil)
+		require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+		require.Contains(t, resp.Body.String(), "too many pending presentation requests")
+
+		// a used challenge is not pending anymore.
+		svc = NewService(issuer.vdr, reissue, WithMaxChallenges(1))
+		vpBytes := presentation(t, svc, vc)
+		require.Equal(t, http.StatusServiceUnavailable, post(t, svc, nil).Code)
+		require.Equal(t, http.StatusOK, post(t, svc, vpBytes).Code)
+		require.Equal(t, http.StatusOK, post(t, svc, nil).Code)
+
+		// expired challenges are purged.
+		svc = NewService(issuer.vdr, reissue, WithMaxChallenges(1), WithChallengeTTL(-time.Second))
+		require.Equal(t, http.StatusOK, post(t, svc, nil).Code)
+		require.Equal(t, http.StatusOK, post(t, svc, nil).Code)
+		require.Len(t, svc.challenges, 1)
+	})
+
+	t.Run("presentation bound to other domain", func(t *testing.T) {
+		svc := NewService(issuer.vdr, reissue, WithDomain(testDomain))
+		vpBytes := presentation(t, svc, vc)
+
+		svc.opts.domain = "other.example.com"
+
+		resp := post(t, svc, vpBytes)
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("holder is not the subject of the credential", func(t *testing.T) {
+		svc := NewService(issuer.vdr, reissue)
+
+		resp := post(t, svc, presentation(t, svc, issuer.issue(t, issuer.did, "", time.Now())))
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		require.Contains(t, resp.Body.String(), "credential 0: holder is not the subject of the credential")
+	})
+
+	t.Run("reissue error", func(t *testing.T) {
+		svc := NewService(issuer.vdr, func(string, *verifiable.Credential) (*verifiable.Credential, error) {
+			return nil, errors.New("issuer is down")
+		})
+
+		resp := post(t, svc, presentation(t, svc, vc))
+		require.Equal(t, http.StatusInternalServerError, resp.Code)
+		require.Contains(t, resp.Body.String(), "reissue credential 0: issuer is down")
+	})
+
+	t.Run("invalid presentation", func(t *testing.T) {
+		resp := post(t, NewService(issuer.vdr, reissue), []byte(`{"type": "VerifiablePresentation"}`))
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+		require.Contains(t, resp.Body.String(), "verify presentation")
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+
+		NewService(issuer.vdr, reissue).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+		require.Equal(t, http.MethodPost, resp.Header().Get("Allow"))
+	})
+}
+
+func post(t *testing.T, svc *Service, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	resp := httptest.NewRecorder()
+
+	svc.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+	return resp
+}
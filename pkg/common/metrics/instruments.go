@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+const namespace = "aries_"
+
+// Label names used by the framework instruments.
+const (
+	LabelMsgType   = "type"
+	LabelService   = "service"
+	LabelPacker    = "packer"
+	LabelTransport = "transport"
+	LabelDirection = "direction"
+	LabelProtocol  = "protocol"
+	LabelState     = "state"
+	LabelOperation = "operation"
+)
+
+// Values of LabelDirection.
+const (
+	DirectionInbound  = "inbound"
+	DirectionOutbound = "outbound"
+)
+
+// DIDComm instruments.
+// nolint:gochecknoglobals
+var (
+	// InboundMessages counts inbound DIDComm messages dispatched to a service, by the name of the service. Inbound
+	// message types are chosen by the peers, so they are not used as label values.
+	InboundMessages = NewCounterVec(namespace+"didcomm_inbound_messages_total",
+		"Number of inbound DIDComm messages by service.", LabelService)
+
+	// OutboundMessages counts outbound DIDComm messages, by message type.
+	OutboundMessages = NewCounterVec(namespace+"didcomm_outbound_messages_total",
+		"Number of outbound DIDComm messages by message type.", LabelMsgType)
+
+	// PackDuration measures how long packing a DIDComm message takes, by packer.
+	PackDuration = NewHistogramVec(namespace+"didcomm_pack_duration_seconds",
+		"Latency of packing DIDComm messages by packer.", nil, LabelPacker)
+
+	// UnpackDuration measures how long unpacking a DIDComm envelope takes, by packer.
+	UnpackDuration = NewHistogramVec(namespace+"didcomm_unpack_duration_seconds",
+		"Latency of unpacking DIDComm envelopes by packer.", nil, LabelPacker)
+
+	// TransportErrors counts transport failures, by transport and direction.
+	TransportErrors = NewCounterVec(namespace+"didcomm_transport_errors_total",
+		"Number of transport errors by transport and direction.", LabelTransport, LabelDirection)
+
+	// ProtocolStateTransitions counts protocol state transitions, by protocol and entered state.
+	ProtocolStateTransitions = NewCounterVec(namespace+"didcomm_protocol_state_transitions_total",
+		"Number of protocol state transitions by protocol and entered state.", LabelProtocol, LabelState)
+)
+
+// KMS and crypto instruments.
+// nolint:gochecknoglobals
+var (
+	// KMSOperationDuration measures KMS operations, by operation.
+	KMSOperationDuration = NewHistogramVec(namespace+"kms_operation_duration_seconds",
+		"Latency of KMS operations by operation.", nil, LabelOperation)
+
+	// CryptoOperationDuration measures crypto operations, by operation.
+	CryptoOperationDuration = NewHistogramVec(namespace+"crypto_operation_duration_seconds",
+		"Latency of crypto operations by operation.", nil, LabelOperation)
+)
@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics provides the instruments used to measure the framework. By default measurements are discarded,
+// a Provider backed by a metrics system (e.g. Prometheus) can be set using Initialize.
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Provider creates metric instruments. Implementations must return the same instrument for repeated calls
+// using the same name.
+type Provider interface {
+	// Counter returns the counter with given name, help text and label names.
+	Counter(name, help string, labels ...string) Counter
+	// Histogram returns the histogram with given name, help text, buckets and label names.
+	// Buckets are nil when the provider's defaults should be used.
+	Histogram(name, help string, buckets []float64, labels ...string) Histogram
+}
+
+// Counter is a cumulative metric whose value only goes up.
+type Counter interface {
+	// Inc increments the counter for the given label values.
+	Inc(labelValues ...string)
+}
+
+// Histogram samples observations and counts them in buckets.
+type Histogram interface {
+	// Observe adds a single observation for the given label values.
+	Observe(value float64, labelValues ...string)
+}
+
+// nolint:gochecknoglobals
+var (
+	rwmutex           = &sync.RWMutex{}
+	provider Provider = &noopProvider{}
+)
+
+// Initialize sets the metrics provider used by the framework instruments.
+// Passing nil restores the default provider which discards all measurements.
+func Initialize(p Provider) {
+	rwmutex.Lock()
+	defer rwmutex.Unlock()
+
+	if p == nil {
+		p = &noopProvider{}
+	}
+
+	provider = p
+}
+
+func currentProvider() Provider {
+	rwmutex.RLock()
+	defer rwmutex.RUnlock()
+
+	return provider
+}
+
+// CounterVec describes a counter; the instrument is taken from the current provider on each use.
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+}
+
+// NewCounterVec returns a new counter description.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{name: name, help: help, labels: labels}
+}
+
+// Inc increments the counter for the given label values.
+func (c *CounterVec) Inc(labelValues ...string) {
+	currentProvider().Counter(c.name, c.help, c.labels...).Inc(labelValues...)
+}
+
+// HistogramVec describes a histogram; the instrument is taken from the current provider on each use.
+type HistogramVec struct {
+	name    string
+	help    string
+	buckets []float64
+	labels  []string
+}
+
+// NewHistogramVec returns a new histogram description.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{name: name, help: help, buckets: buckets, labels: labels}
+}
+
+// Observe adds a single observation for the given label values.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	currentProvider().Histogram(h.name, h.help, h.buckets, h.labels...).Observe(value, labelValues...)
+}
+
+// ObserveSince observes the time elapsed since start, in seconds. It is meant to be deferred:
+//
+//	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "create")
+func (h *HistogramVec) ObserveSince(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+type noopProvider struct{}
+
+func (p *noopProvider) Counter(string, string, ...string) Counter {
+	return noopInstrument{}
+}
+
+func (p *noopProvider) Histogram(string, string, []float64, ...string) Histogram {
+	return noopInstrument{}
+}
+
+type noopInstrument struct{}
+
+func (noopInstrument) Inc(...string) {}
+
+func (noopInstrument) Observe(float64, ...string) {}
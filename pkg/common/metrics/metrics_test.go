@@ -0,0 +1,76 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultProvider(t *testing.T) {
+	require.NotPanics(t, func() {
+		InboundMessages.Inc("https://didcomm.org/trust_ping/1.0/ping")
+		PackDuration.ObserveSince(time.Now(), "authcrypt")
+	})
+}
+
+func TestInitialize(t *testing.T) {
+	p := newMockProvider()
+
+	Initialize(p)
+	defer Initialize(nil)
+
+	InboundMessages.Inc("type-1")
+	InboundMessages.Inc("type-1")
+	OutboundMessages.Inc("type-2")
+	KMSOperationDuration.Observe(0.5, "create")
+	KMSOperationDuration.ObserveSince(time.Now().Add(-time.Second), "create")
+
+	require.Equal(t, []string{"type-1", "type-1"}, p.counters[namespace+"didcomm_inbound_messages_total"])
+	require.Equal(t, []string{"type-2"}, p.counters[namespace+"didcomm_outbound_messages_total"])
+
+	observed := p.histograms[namespace+"kms_operation_duration_seconds"]
+	require.Len(t, observed, 2)
+	require.Equal(t, 0.5, observed[0])
+	require.GreaterOrEqual(t, observed[1], 1.0)
+
+	Initialize(nil)
+	InboundMessages.Inc("type-3")
+	require.Len(t, p.counters[namespace+"didcomm_inbound_messages_total"], 2)
+}
+
+type mockProvider struct {
+	counters   map[string][]string
+	histograms map[string][]float64
+}
+
+func newMockProvider() *mockProvider {
+	return &mockProvider{counters: map[string][]string{}, histograms: map[string][]float64{}}
+}
+
+func (p *mockProvider) Counter(name, _ string, _ ...string) Counter {
+	return &mockInstrument{name: name, p: p}
+}
+
+func (p *mockProvider) Histogram(name, _ string, _ []float64, _ ...string) Histogram {
+	return &mockInstrument{name: name, p: p}
+}
+
+type mockInstrument struct {
+	name string
+	p    *mockProvider
+}
+
+func (m *mockInstrument) Inc(labelValues ...string) {
+	m.p.counters[m.name] = append(m.p.counters[m.name], labelValues...)
+}
+
+func (m *mockInstrument) Observe(value float64, _ ...string) {
+	m.p.histograms[m.name] = append(m.p.histograms[m.name], value)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tracing provides the spans recorded by the framework. By default spans are discarded,
+// a Tracer backed by a tracing system (e.g. OpenTelemetry) can be set using Initialize.
+//
+// Trace context crosses process boundaries through carriers: inbound transports extract it from
+// transport headers, and the inbound dispatcher stores it into the metadata of DIDComm messages so that
+// protocol services may continue the trace (see ContextFromMetadata).
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// MetadataKey is the DIDComm message metadata key holding the trace context carrier.
+const MetadataKey = "_trace"
+
+// Tracer starts spans and propagates their context.
+type Tracer interface {
+	// Start starts a span, child of the span found in ctx if any.
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+	// Inject writes the trace context of ctx into carrier.
+	Inject(ctx context.Context, carrier map[string]string)
+	// Extract returns a copy of ctx carrying the trace context read from carrier.
+	Extract(ctx context.Context, carrier map[string]string) context.Context
+}
+
+// Span is a single operation within a trace.
+type Span interface {
+	// SetAttribute sets an attribute on the span.
+	SetAttribute(key, value string)
+	// RecordError marks the span as failed. It is a no-op for nil errors.
+	RecordError(err error)
+	// End completes the span.
+	End()
+}
+
+// nolint:gochecknoglobals
+var (
+	rwmutex       = &sync.RWMutex{}
+	tracer Tracer = &noopTracer{}
+)
+
+// Initialize sets the tracer used by the framework.
+// Passing nil restores the default tracer which discards all spans.
+func Initialize(t Tracer) {
+	rwmutex.Lock()
+	defer rwmutex.Unlock()
+
+	if t == nil {
+		t = &noopTracer{}
+	}
+
+	tracer = t
+}
+
+func currentTracer() Tracer {
+	rwmutex.RLock()
+	defer rwmutex.RUnlock()
+
+	return tracer
+}
+
+// Start starts a span using the current tracer.
+func Start(ctx context.Context, spanName string) (context.Context, Span) {
+	return currentTracer().Start(ctx, spanName)
+}
+
+// Inject writes the trace context of ctx into carrier using the current tracer.
+func Inject(ctx context.Context, carrier map[string]string) {
+	currentTracer().Inject(ctx, carrier)
+}
+
+// Extract returns a copy of ctx carrying the trace context read from carrier using the current tracer.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return currentTracer().Extract(ctx, carrier)
+}
+
+// InjectMetadata stores the trace context of ctx into DIDComm message metadata.
+func InjectMetadata(ctx context.Context, metadata map[string]interface{}) {
+	if metadata == nil {
+		return
+	}
+
+	carrier := map[string]string{}
+	Inject(ctx, carrier)
+
+	if len(carrier) > 0 {
+		metadata[MetadataKey] = carrier
+	}
+}
+
+// ContextFromMetadata returns a context carrying the trace context stored in DIDComm message metadata.
+func ContextFromMetadata(metadata map[string]interface{}) context.Context {
+	carrier := map[string]string{}
+
+	switch c := metadata[MetadataKey].(type) {
+	case map[string]string:
+		carrier = c
+	case map[string]interface{}: // metadata restored from JSON
+		for k, v := range c {
+			if s, ok := v.(string); ok {
+				carrier[k] = s
+			}
+		}
+	default:
+		return context.Background()
+	}
+
+	return Extract(context.Background(), carrier)
+}
+
+type noopTracer struct{}
+
+func (t *noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+func (t *noopTracer) Inject(context.Context, map[string]string) {}
+
+func (t *noopTracer) Extract(ctx context.Context, _ map[string]string) context.Context {
+	return ctx
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, string) {}
+
+func (noopSpan) RecordError(error) {}
+
+func (noopSpan) End() {}
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+func TestDefaultTracer(t *testing.T) {
+	ctx, span := Start(context.Background(), "test")
+	require.NotNil(t, ctx)
+
+	span.SetAttribute("k", "v")
+	span.RecordError(errors.New("error"))
+	span.End()
+
+	metadata := map[string]interface{}{}
+	InjectMetadata(ctx, metadata)
+	require.Empty(t, metadata)
+	require.Equal(t, context.Background(), ContextFromMetadata(metadata))
+}
+
+func TestInitialize(t *testing.T) {
+	Initialize(&mockTracer{})
+	defer Initialize(nil)
+
+	ctx, span := Start(context.Background(), "parent")
+	defer span.End()
+
+	metadata := map[string]interface{}{}
+	InjectMetadata(ctx, metadata)
+	require.Equal(t, map[string]string{"trace-id": "parent"}, metadata[MetadataKey])
+
+	require.Equal(t, "parent", ContextFromMetadata(metadata).Value(ctxKey{}))
+
+	InjectMetadata(ctx, nil)
+}
+
+type mockTracer struct{}
+
+func (m *mockTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	return context.WithValue(ctx, ctxKey{}, spanName), noopSpan{}
+}
+
+func (m *mockTracer) Inject(ctx context.Context, carrier map[string]string) {
+	if v, ok := ctx.Value(ctxKey{}).(string); ok {
+		carrier["trace-id"] = v
+	}
+}
+
+func (m *mockTracer) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, carrier["trace-id"])
+}
@@ -0,0 +1,421 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid action menu controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.ActionMenu)
+	// ActionsErrorCode is for failures in actions command.
+	ActionsErrorCode
+	// RequestMenuErrorCode is for failures in request menu command.
+	RequestMenuErrorCode
+	// SendMenuErrorCode is for failures in send menu command.
+	SendMenuErrorCode
+	// AcceptMenuRequestErrorCode is for failures in accept menu request command.
+	AcceptMenuRequestErrorCode
+	// DeclineMenuRequestErrorCode is for failures in decline menu request command.
+	DeclineMenuRequestErrorCode
+	// PerformActionErrorCode is for failures in perform action command.
+	PerformActionErrorCode
+	// DeclineMenuErrorCode is for failures in decline menu command.
+	DeclineMenuErrorCode
+	// AcceptPerformErrorCode is for failures in accept perform command.
+	AcceptPerformErrorCode
+	// DeclinePerformErrorCode is for failures in decline perform command.
+	DeclinePerformErrorCode
+	// AcceptProblemReportErrorCode is for failures in accept problem report command.
+	AcceptProblemReportErrorCode
+)
+
+// constants for the ActionMenu operations.
+const (
+	// command name.
+	CommandName = "actionmenu"
+
+	Actions             = "Actions"
+	RequestMenu         = "RequestMenu"
+	SendMenu            = "SendMenu"
+	AcceptMenuRequest   = "AcceptMenuRequest"
+	DeclineMenuRequest  = "DeclineMenuRequest"
+	PerformAction       = "PerformAction"
+	DeclineMenu         = "DeclineMenu"
+	AcceptPerform       = "AcceptPerform"
+	DeclinePerform      = "DeclinePerform"
+	AcceptProblemReport = "AcceptProblemReport"
+)
+
+const (
+	// error messages.
+	errEmptyPIID     = "empty PIID"
+	errEmptyMyDID    = "empty MyDID"
+	errEmptyTheirDID = "empty TheirDID"
+	errEmptyMenu     = "empty Menu"
+	errEmptyPerform  = "empty Perform"
+
+	// log constants.
+	successString = "success"
+
+	_actions = "_actions"
+	_states  = "_states"
+)
+
+var logger = log.New("aries-framework/controller/actionmenu")
+
+// Command is controller command for action menu.
+type Command struct {
+	client *actionmenu.Client
+}
+
+// New returns new action menu controller command instance.
+func New(ctx actionmenu.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := actionmenu.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates action channel
+	actions := make(chan service.DIDCommAction)
+	// registers action channel to listen for events
+	if err := client.RegisterActionEvent(actions); err != nil {
+		return nil, fmt.Errorf("register action event: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterAction(protocol.Name+_actions, actions)
+	obs.RegisterStateMsg(protocol.Name+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, Actions, c.Actions),
+		cmdutil.NewCommandHandler(CommandName, RequestMenu, c.RequestMenu),
+		cmdutil.NewCommandHandler(CommandName, SendMenu, c.SendMenu),
+		cmdutil.NewCommandHandler(CommandName, AcceptMenuRequest, c.AcceptMenuRequest),
+		cmdutil.NewCommandHandler(CommandName, DeclineMenuRequest, c.DeclineMenuRequest),
+		cmdutil.NewCommandHandler(CommandName, PerformAction, c.PerformAction),
+		cmdutil.NewCommandHandler(CommandName, DeclineMenu, c.DeclineMenu),
+		cmdutil.NewCommandHandler(CommandName, AcceptPerform, c.AcceptPerform),
+		cmdutil.NewCommandHandler(CommandName, DeclinePerform, c.DeclinePerform),
+		cmdutil.NewCommandHandler(CommandName, AcceptProblemReport, c.AcceptProblemReport),
+	}
+}
+
+// Actions returns pending actions that have not yet to be executed or canceled.
+func (c *Command) Actions(rw io.Writer, _ io.Reader) command.Error {
+	result, err := c.client.Actions()
+	if err != nil {
+		logutil.LogError(logger, CommandName, Actions, err.Error())
+		return command.NewExecuteError(ActionsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ActionsResponse{
+		Actions: result,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, Actions, successString)
+
+	return nil
+}
+
+// RequestMenu is used by the Requester to ask the Responder for its root menu.
+func (c *Command) RequestMenu(rw io.Writer, req io.Reader) command.Error {
+	var args RequestMenuArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, RequestMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.MyDID == "" {
+		logutil.LogDebug(logger, CommandName, RequestMenu, errEmptyMyDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
+	}
+
+	if args.TheirDID == "" {
+		logutil.LogDebug(logger, CommandName, RequestMenu, errEmptyTheirDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyTheirDID))
+	}
+
+	piid, err := c.client.RequestMenu(args.MyDID, args.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RequestMenu, err.Error())
+		return command.NewExecuteError(RequestMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &RequestMenuResponse{
+		PIID: piid,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, RequestMenu, successString)
+
+	return nil
+}
+
+// SendMenu is used by the Responder to send a menu without being asked for it.
+func (c *Command) SendMenu(rw io.Writer, req io.Reader) command.Error {
+	var args SendMenuArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.MyDID == "" {
+		logutil.LogDebug(logger, CommandName, SendMenu, errEmptyMyDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
+	}
+
+	if args.TheirDID == "" {
+		logutil.LogDebug(logger, CommandName, SendMenu, errEmptyTheirDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyTheirDID))
+	}
+
+	if args.Menu == nil {
+		logutil.LogDebug(logger, CommandName, SendMenu, errEmptyMenu)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMenu))
+	}
+
+	piid, err := c.client.SendMenu(args.Menu, args.MyDID, args.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendMenu, err.Error())
+		return command.NewExecuteError(SendMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &SendMenuResponse{
+		PIID: piid,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendMenu, successString)
+
+	return nil
+}
+
+// AcceptMenuRequest is used by the Responder to reply to a menu request with its root menu.
+// nolint: dupl
+func (c *Command) AcceptMenuRequest(rw io.Writer, req io.Reader) command.Error {
+	var args AcceptMenuRequestArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptMenuRequest, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, AcceptMenuRequest, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if args.Menu == nil {
+		logutil.LogDebug(logger, CommandName, AcceptMenuRequest, errEmptyMenu)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMenu))
+	}
+
+	if err := c.client.AcceptMenuRequest(args.PIID, args.Menu); err != nil {
+		logutil.LogError(logger, CommandName, AcceptMenuRequest, err.Error())
+		return command.NewExecuteError(AcceptMenuRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AcceptMenuRequestResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptMenuRequest, successString)
+
+	return nil
+}
+
+// DeclineMenuRequest is used when the Responder does not want to provide a menu.
+// nolint: dupl
+func (c *Command) DeclineMenuRequest(rw io.Writer, req io.Reader) command.Error {
+	var args DeclineMenuRequestArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, DeclineMenuRequest, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, DeclineMenuRequest, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.DeclineMenuRequest(args.PIID, args.Reason); err != nil {
+		logutil.LogError(logger, CommandName, DeclineMenuRequest, err.Error())
+		return command.NewExecuteError(DeclineMenuRequestErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &DeclineMenuRequestResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeclineMenuRequest, successString)
+
+	return nil
+}
+
+// PerformAction is used by the Requester to select one of the options of the menu it received.
+// nolint: dupl
+func (c *Command) PerformAction(rw io.Writer, req io.Reader) command.Error {
+	var args PerformActionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, PerformAction, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, PerformAction, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if args.Perform == nil {
+		logutil.LogDebug(logger, CommandName, PerformAction, errEmptyPerform)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPerform))
+	}
+
+	if err := c.client.PerformAction(args.PIID, args.Perform); err != nil {
+		logutil.LogError(logger, CommandName, PerformAction, err.Error())
+		return command.NewExecuteError(PerformActionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &PerformActionResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, PerformAction, successString)
+
+	return nil
+}
+
+// DeclineMenu is used when the Requester does not want to select any of the menu options.
+// nolint: dupl
+func (c *Command) DeclineMenu(rw io.Writer, req io.Reader) command.Error {
+	var args DeclineMenuArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, DeclineMenu, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, DeclineMenu, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.DeclineMenu(args.PIID, args.Reason); err != nil {
+		logutil.LogError(logger, CommandName, DeclineMenu, err.Error())
+		return command.NewExecuteError(DeclineMenuErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &DeclineMenuResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeclineMenu, successString)
+
+	return nil
+}
+
+// AcceptPerform is used by the Responder to perform the selected option.
+// The menu is optional and, if provided, is sent to the Requester as a follow-up.
+func (c *Command) AcceptPerform(rw io.Writer, req io.Reader) command.Error {
+	var args AcceptPerformArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptPerform, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, AcceptPerform, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.AcceptPerform(args.PIID, args.Menu); err != nil {
+		logutil.LogError(logger, CommandName, AcceptPerform, err.Error())
+		return command.NewExecuteError(AcceptPerformErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AcceptPerformResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptPerform, successString)
+
+	return nil
+}
+
+// DeclinePerform is used when the Responder does not want to perform the selected option.
+// nolint: dupl
+func (c *Command) DeclinePerform(rw io.Writer, req io.Reader) command.Error {
+	var args DeclinePerformArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, DeclinePerform, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, DeclinePerform, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.DeclinePerform(args.PIID, args.Reason); err != nil {
+		logutil.LogError(logger, CommandName, DeclinePerform, err.Error())
+		return command.NewExecuteError(DeclinePerformErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &DeclinePerformResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeclinePerform, successString)
+
+	return nil
+}
+
+// AcceptProblemReport is used for accepting problem report.
+// nolint: dupl
+func (c *Command) AcceptProblemReport(rw io.Writer, req io.Reader) command.Error {
+	var args AcceptProblemReportArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptProblemReport, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, AcceptProblemReport, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.AcceptProblemReport(args.PIID); err != nil {
+		logutil.LogError(logger, CommandName, AcceptProblemReport, err.Error())
+		return command.NewExecuteError(AcceptProblemReportErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AcceptProblemReportResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptProblemReport, successString)
+
+	return nil
+}
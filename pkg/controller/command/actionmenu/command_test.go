@@ -0,0 +1,321 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/actionmenu"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+const jsonPayload = `{"piid":"id"}`
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+		require.Len(t, cmd.GetHandlers(), 10)
+	})
+
+	t.Run("Create client (error)", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "cannot create a client: cast service to actionmenu service failed")
+		require.Nil(t, cmd)
+	})
+
+	t.Run("Register action event (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(errors.New("error"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "register action event: error")
+		require.Nil(t, cmd)
+	})
+
+	t.Run("Register msg event (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(errors.New("error"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "register msg event: error")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().Actions().Return([]protocol.Action{{PIID: "id"}}, nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Actions(&b, nil))
+
+		var res ActionsResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.Actions, 1)
+		require.Equal(t, "id", res.Actions[0].PIID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().Actions().Return(nil, errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		cmdErr := cmd.Actions(&bytes.Buffer{}, nil)
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "some error message")
+		require.Equal(t, ActionsErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+	})
+}
+
+func TestCommand_RequestMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+	requireValidationError(t, cmd.RequestMenu, "}", "")
+	requireValidationError(t, cmd.RequestMenu, "{}", errEmptyMyDID)
+	requireValidationError(t, cmd.RequestMenu, `{"my_did":"id"}`, errEmptyTheirDID)
+
+	const payload = `{"my_did":"id","their_did":"id"}`
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().HandleInbound(gomock.Any(), "id", "id").Return("piid", nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.RequestMenu(&b, bytes.NewBufferString(payload)))
+
+		var res RequestMenuResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, "piid", res.PIID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return("", errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		requireExecuteError(t, cmd.RequestMenu(&bytes.Buffer{}, bytes.NewBufferString(payload)), RequestMenuErrorCode)
+	})
+}
+
+func TestCommand_SendMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+	requireValidationError(t, cmd.SendMenu, "}", "")
+	requireValidationError(t, cmd.SendMenu, "{}", errEmptyMyDID)
+	requireValidationError(t, cmd.SendMenu, `{"my_did":"id"}`, errEmptyTheirDID)
+	requireValidationError(t, cmd.SendMenu, `{"my_did":"id","their_did":"id"}`, errEmptyMenu)
+
+	const payload = `{"my_did":"id","their_did":"id","menu":{"title":"menu"}}`
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().HandleInbound(gomock.Any(), "id", "id").Return("piid", nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.SendMenu(&b, bytes.NewBufferString(payload)))
+
+		var res SendMenuResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, "piid", res.PIID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return("", errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		requireExecuteError(t, cmd.SendMenu(&bytes.Buffer{}, bytes.NewBufferString(payload)), SendMenuErrorCode)
+	})
+}
+
+func TestCommand_Continue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name    string
+		handler func(*Command) command.Exec
+		payload string
+		empty   string
+		code    command.Code
+	}{{
+		name:    AcceptMenuRequest,
+		handler: func(c *Command) command.Exec { return c.AcceptMenuRequest },
+		payload: `{"piid":"id","menu":{}}`,
+		empty:   errEmptyMenu,
+		code:    AcceptMenuRequestErrorCode,
+	}, {
+		name:    PerformAction,
+		handler: func(c *Command) command.Exec { return c.PerformAction },
+		payload: `{"piid":"id","perform":{"name":"option"}}`,
+		empty:   errEmptyPerform,
+		code:    PerformActionErrorCode,
+	}, {
+		name:    AcceptPerform,
+		handler: func(c *Command) command.Exec { return c.AcceptPerform },
+		payload: jsonPayload,
+		code:    AcceptPerformErrorCode,
+	}, {
+		name:    AcceptProblemReport,
+		handler: func(c *Command) command.Exec { return c.AcceptProblemReport },
+		payload: jsonPayload,
+		code:    AcceptProblemReportErrorCode,
+	}}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			handler := tc.handler(newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl)))
+
+			requireValidationError(t, handler, "}", "")
+			requireValidationError(t, handler, "{}", errEmptyPIID)
+
+			if tc.empty != "" {
+				requireValidationError(t, handler, jsonPayload, tc.empty)
+			}
+
+			service := mocks.NewMockProtocolService(ctrl)
+			service.EXPECT().ActionContinue("id", gomock.Any()).Return(nil)
+
+			require.Nil(t, tc.handler(newCommand(t, ctrl, service))(&bytes.Buffer{}, bytes.NewBufferString(tc.payload)))
+
+			service = mocks.NewMockProtocolService(ctrl)
+			service.EXPECT().ActionContinue("id", gomock.Any()).Return(errors.New("some error message"))
+
+			requireExecuteError(t,
+				tc.handler(newCommand(t, ctrl, service))(&bytes.Buffer{}, bytes.NewBufferString(tc.payload)), tc.code)
+		})
+	}
+}
+
+func TestCommand_Decline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name    string
+		handler func(*Command) command.Exec
+		code    command.Code
+	}{{
+		name:    DeclineMenuRequest,
+		handler: func(c *Command) command.Exec { return c.DeclineMenuRequest },
+		code:    DeclineMenuRequestErrorCode,
+	}, {
+		name:    DeclineMenu,
+		handler: func(c *Command) command.Exec { return c.DeclineMenu },
+		code:    DeclineMenuErrorCode,
+	}, {
+		name:    DeclinePerform,
+		handler: func(c *Command) command.Exec { return c.DeclinePerform },
+		code:    DeclinePerformErrorCode,
+	}}
+
+	const payload = `{"piid":"id","reason":"reason"}`
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			handler := tc.handler(newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl)))
+
+			requireValidationError(t, handler, "}", "")
+			requireValidationError(t, handler, "{}", errEmptyPIID)
+
+			service := mocks.NewMockProtocolService(ctrl)
+			service.EXPECT().ActionStop("id", errors.New("reason")).Return(nil)
+
+			require.Nil(t, tc.handler(newCommand(t, ctrl, service))(&bytes.Buffer{}, bytes.NewBufferString(payload)))
+
+			service = mocks.NewMockProtocolService(ctrl)
+			service.EXPECT().ActionStop("id", gomock.Any()).Return(errors.New("some error message"))
+
+			requireExecuteError(t,
+				tc.handler(newCommand(t, ctrl, service))(&bytes.Buffer{}, bytes.NewBufferString(payload)), tc.code)
+		})
+	}
+}
+
+func newCommand(t *testing.T, ctrl *gomock.Controller, service *mocks.MockProtocolService) *Command {
+	t.Helper()
+
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+	cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	return cmd
+}
+
+func requireValidationError(t *testing.T, exec command.Exec, payload, msg string) {
+	t.Helper()
+
+	cmdErr := exec(&bytes.Buffer{}, bytes.NewBufferString(payload))
+
+	require.Error(t, cmdErr)
+	require.Contains(t, cmdErr.Error(), msg)
+	require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	require.Equal(t, command.ValidationError, cmdErr.Type())
+}
+
+func requireExecuteError(t *testing.T, cmdErr command.Error, code command.Code) {
+	t.Helper()
+
+	require.Error(t, cmdErr)
+	require.Contains(t, cmdErr.Error(), "some error message")
+	require.Equal(t, code, cmdErr.Code())
+	require.Equal(t, command.ExecuteError, cmdErr.Type())
+}
@@ -0,0 +1,176 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import "github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+
+// ActionsResponse model
+//
+// Represents Actions response message
+//
+type ActionsResponse struct {
+	Actions []actionmenu.Action `json:"actions"`
+}
+
+// RequestMenuArgs model
+//
+// This is used for requesting the root menu
+//
+type RequestMenuArgs struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+}
+
+// RequestMenuResponse model
+//
+// Represents a RequestMenu response message
+//
+type RequestMenuResponse struct {
+	// PIID Protocol instance ID. It can be used as a correlation ID
+	PIID string `json:"piid"`
+}
+
+// SendMenuArgs model
+//
+// This is used for sending a menu without being asked for it
+//
+type SendMenuArgs struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+	// Menu presents the available actions to the requester.
+	Menu *actionmenu.Menu `json:"menu"`
+}
+
+// SendMenuResponse model
+//
+// Represents a SendMenu response message
+//
+type SendMenuResponse struct {
+	// PIID Protocol instance ID. It can be used as a correlation ID
+	PIID string `json:"piid"`
+}
+
+// AcceptMenuRequestArgs model
+//
+// This is used for accepting a menu request
+//
+type AcceptMenuRequestArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Menu presents the available actions to the requester.
+	Menu *actionmenu.Menu `json:"menu"`
+}
+
+// AcceptMenuRequestResponse model
+//
+// Represents a AcceptMenuRequest response message
+//
+type AcceptMenuRequestResponse struct{}
+
+// DeclineMenuRequestArgs model
+//
+// This is used when the menu request needs to be rejected
+//
+type DeclineMenuRequestArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Reason why menu request is declined
+	Reason string `json:"reason"`
+}
+
+// DeclineMenuRequestResponse model
+//
+// Represents a DeclineMenuRequest response message
+//
+type DeclineMenuRequestResponse struct{}
+
+// PerformActionArgs model
+//
+// This is used for selecting one of the menu options
+//
+type PerformActionArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Perform selects one of the menu options.
+	Perform *actionmenu.Perform `json:"perform"`
+}
+
+// PerformActionResponse model
+//
+// Represents a PerformAction response message
+//
+type PerformActionResponse struct{}
+
+// DeclineMenuArgs model
+//
+// This is used when the menu needs to be rejected
+//
+type DeclineMenuArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Reason why menu is declined
+	Reason string `json:"reason"`
+}
+
+// DeclineMenuResponse model
+//
+// Represents a DeclineMenu response message
+//
+type DeclineMenuResponse struct{}
+
+// AcceptPerformArgs model
+//
+// This is used for performing the selected option
+//
+type AcceptPerformArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Menu is an optional follow-up menu sent to the requester.
+	Menu *actionmenu.Menu `json:"menu,omitempty"`
+}
+
+// AcceptPerformResponse model
+//
+// Represents a AcceptPerform response message
+//
+type AcceptPerformResponse struct{}
+
+// DeclinePerformArgs model
+//
+// This is used when the selected option needs to be rejected
+//
+type DeclinePerformArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Reason why perform is declined
+	Reason string `json:"reason"`
+}
+
+// DeclinePerformResponse model
+//
+// Represents a DeclinePerform response message
+//
+type DeclinePerformResponse struct{}
+
+// AcceptProblemReportArgs model
+//
+// This is used for accepting a problem report
+//
+type AcceptProblemReportArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+}
+
+// AcceptProblemReportResponse model
+//
+// Represents a AcceptProblemReport response message
+//
+type AcceptProblemReportResponse struct{}
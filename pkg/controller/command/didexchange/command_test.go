@@ -360,18 +360,12 @@ func TestCommand_QueryConnections(t *testing.T) {
 
 		prov := mockProvider()
 
-		memStorageProvider := mem.NewProvider()
+		prov.StorageProviderValue = mem.NewProvider()
 
-		store, err := memStorageProvider.OpenStore("didexchange")
+		recorder, err := connection.NewRecorder(prov)
 		require.NoError(t, err)
-
-		connRec := &connection.Record{State: state, ConnectionID: connID, ThreadID: "th1234"}
-
-		connBytes, err := json.Marshal(connRec)
-		require.NoError(t, err)
-		require.NoError(t, store.Put("conn_"+connID, connBytes, spi.Tag{Name: "conn_"}))
-
-		prov.StorageProviderValue = memStorageProvider
+		require.NoError(t, recorder.SaveConnectionRecord(
+			&connection.Record{State: state, ConnectionID: connID, ThreadID: "th1234"}))
 
 		cmd, err := New(prov, mockwebhook.NewMockWebhookNotifier(), "", false)
 		require.NoError(t, err)
@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/discoverfeatures")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.DiscoverFeatures)
+
+	// QueryMissingConnIDCode for connection ID validation error.
+	QueryMissingConnIDCode
+
+	// QueryErrorCode for query error.
+	QueryErrorCode
+)
+
+// constant for the discover features controller.
+const (
+	// command name.
+	CommandName = "discoverfeatures"
+
+	// command methods.
+	QueryCommandMethod     = "Query"
+	SupportedCommandMethod = "Supported"
+
+	// log constants.
+	connectionID  = "connectionID"
+	successString = "success"
+
+	defaultQuery = "*"
+)
+
+// provider contains dependencies for the discover features protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command contains command operations provided by discover features controller.
+type Command struct {
+	client *discoverfeatures.Client
+}
+
+// New returns new discover features controller command instance.
+func New(ctx provider) (*Command, error) {
+	client, err := discoverfeatures.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features client : %w", err)
+	}
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, QueryCommandMethod, c.Query),
+		cmdutil.NewCommandHandler(CommandName, SupportedCommandMethod, c.Supported),
+	}
+}
+
+// Query asks the other party of the given connection which protocols it supports.
+func (c *Command) Query(rw io.Writer, req io.Reader) command.Error {
+	var request QueryArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, QueryCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, QueryCommandMethod, "missing connectionID")
+		return command.NewValidationError(QueryMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	if request.Query == "" {
+		request.Query = defaultQuery
+	}
+
+	var opts []protocol.QueryOption
+
+	if request.Version != "" {
+		opts = append(opts, protocol.WithVersion(protocol.Version(request.Version)))
+	}
+
+	if request.Timeout > 0 {
+		opts = append(opts, protocol.WithTimeout(request.Timeout))
+	}
+
+	protocols, err := c.client.Query(request.ConnectionID, request.Query, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, QueryCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(QueryErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &QueryResponse{Protocols: protocols}, logger)
+
+	logutil.LogDebug(logger, CommandName, QueryCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
+
+// Supported lists the protocols supported by this agent.
+func (c *Command) Supported(rw io.Writer, req io.Reader) command.Error {
+	var request SupportedArgs
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SupportedCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.Query == "" {
+		request.Query = defaultQuery
+	}
+
+	command.WriteNillableResponse(rw, &SupportedResponse{Protocols: c.client.Supported(request.Query)}, logger)
+
+	logutil.LogDebug(logger, CommandName, SupportedCommandMethod, successString)
+
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const pid = "https://didcomm.org/trust_ping/1.0"
+
+func TestNew(t *testing.T) {
+	t.Run("test new command", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 2)
+	})
+
+	t.Run("test new command - client creation fail", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create discover features client")
+	})
+}
+
+func TestCommand_Query(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			QueryFunc: func(connectionID, query string,
+				_ ...discoverfeatures.QueryOption) ([]*discoverfeatures.Protocol, error) {
+				require.Equal(t, "123-abc", connectionID)
+				require.Equal(t, "*", query)
+
+				return []*discoverfeatures.Protocol{{PID: pid}}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString(`{"connectionID":"123-abc","version":"2.0","timeout":1000}`))
+		require.NoError(t, cmdErr)
+
+		var response QueryResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, []*discoverfeatures.Protocol{{PID: pid}}, response.Protocols)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.Query(&b, bytes.NewBufferString(`{"connectionID":""}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, QueryMissingConnIDCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			QueryErr: errors.New("query error"),
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Query(&b, bytes.NewBufferString(`{"connectionID":"123-abc"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, QueryErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "query error")
+	})
+}
+
+func TestCommand_Supported(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			SupportedFunc: func(query string) []string {
+				require.Equal(t, "*", query)
+
+				return []string{pid}
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Supported(&b, bytes.NewBufferString(`{}`))
+		require.NoError(t, cmdErr)
+
+		var response SupportedResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, []string{pid}, response.Protocols)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Supported(&b, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+}
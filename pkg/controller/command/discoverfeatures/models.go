@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// QueryArgs model
+//
+// This is used for querying the protocols supported by the other party of a connection.
+type QueryArgs struct {
+	// ConnectionID of the connection to query.
+	ConnectionID string `json:"connectionID"`
+
+	// Query matching protocol identifier URIs, * matches any sequence of characters.
+	// Defaults to * if not set.
+	Query string `json:"query,omitempty"`
+
+	// Version of the discover-features protocol to use: 1.0 or 2.0. Defaults to 1.0 if not set.
+	Version string `json:"version,omitempty"`
+
+	// Timeout (in nanoseconds) waiting for the disclosure (optional).
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// QueryResponse model
+//
+// Protocols disclosed by the other party.
+type QueryResponse struct {
+	Protocols []*discoverfeatures.Protocol `json:"protocols"`
+}
+
+// SupportedArgs model
+//
+// This is used for listing the protocols supported by this agent.
+type SupportedArgs struct {
+	// Query matching protocol identifier URIs, * matches any sequence of characters.
+	// Defaults to * if not set.
+	Query string `json:"query,omitempty"`
+}
+
+// SupportedResponse model
+//
+// Protocols supported by this agent.
+type SupportedResponse struct {
+	Protocols []string `json:"protocols"`
+}
@@ -64,6 +64,18 @@ const (
 
 	// Outofband error group for outofband command errors.
 	Outofband = 11000
+
+	// TrustPing error group for trust ping command errors.
+	TrustPing = 12000
+
+	// DiscoverFeatures error group for discover features command errors.
+	DiscoverFeatures = 13000
+
+	// ActionMenu error group for action menu command errors.
+	ActionMenu = 14000
+
+	// QuestionAnswer error group for question answer command errors.
+	QuestionAnswer = 15000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
@@ -31,6 +32,8 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// ExportJWKSError is for failures while exporting keys as a JWK Set.
+	ExportJWKSError
 )
 
 // constants for KMS commands.
@@ -41,10 +44,13 @@ const (
 	// command methods.
 	CreateKeySetCommandMethod = "CreateKeySet"
 	ImportKeyCommandMethod    = "ImportKey"
+	ExportJWKSCommandMethod   = "ExportJWKS"
+	GetJWKSCommandMethod      = "GetJWKS"
 
 	// error messages.
 	errEmptyKeyType = "key type is mandatory"
 	errEmptyKeyID   = "key id is mandatory"
+	errEmptyKeys    = "keys are mandatory"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -57,12 +63,20 @@ type Command struct {
 	ctx       provider
 	importKey func(privKey interface{}, kt kms.KeyType,
 		opts ...kms.PrivateKeyOpts) (string, interface{}, error) // needed for unit test
+	publishedKeys map[string]kms.KeyType
 }
 
-// New returns new kms command instance.
-func New(p provider) *Command {
+// New returns new kms command instance. The public keys of the published keys are returned by GetJWKS.
+func New(p provider, publishedKeys ...KeyReference) *Command {
+	keys := make(map[string]kms.KeyType, len(publishedKeys))
+
+	for _, key := range publishedKeys {
+		keys[key.KeyID] = kms.KeyType(key.KeyType)
+	}
+
 	return &Command{
-		ctx: p,
+		ctx:           p,
+		publishedKeys: keys,
 		importKey: func(privKey interface{}, kt kms.KeyType,
 			opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
 			return p.KMS().ImportPrivateKey(privKey, kt, opts...)
@@ -75,6 +89,8 @@ func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateKeySetCommandMethod, o.CreateKeySet),
 		cmdutil.NewCommandHandler(CommandName, ImportKeyCommandMethod, o.ImportKey),
+		cmdutil.NewCommandHandler(CommandName, ExportJWKSCommandMethod, o.ExportJWKS),
+		cmdutil.NewCommandHandler(CommandName, GetJWKSCommandMethod, o.GetJWKS),
 	}
 }
 
@@ -154,3 +170,64 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// ExportJWKS exports the public keys of the requested KMS keys as a JWK Set. Each JWK 'kid' is the key's RFC 7638
+// thumbprint.
+func (o *Command) ExportJWKS(rw io.Writer, req io.Reader) command.Error {
+	var request ExportJWKSRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportJWKSCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if len(request.Keys) == 0 {
+		logutil.LogDebug(logger, CommandName, ExportJWKSCommandMethod, errEmptyKeys)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeys))
+	}
+
+	keys := make(map[string]kms.KeyType, len(request.Keys))
+
+	for _, key := range request.Keys {
+		if key.KeyID == "" {
+			logutil.LogDebug(logger, CommandName, ExportJWKSCommandMethod, errEmptyKeyID)
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+		}
+
+		if key.KeyType == "" {
+			logutil.LogDebug(logger, CommandName, ExportJWKSCommandMethod, errEmptyKeyType)
+			return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyType))
+		}
+
+		keys[key.KeyID] = kms.KeyType(key.KeyType)
+	}
+
+	jwks, err := jwkkid.ExportJWKS(o.ctx.KMS(), keys)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ExportJWKSCommandMethod, err.Error())
+		return command.NewExecuteError(ExportJWKSError, err)
+	}
+
+	command.WriteNillableResponse(rw, jwks, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportJWKSCommandMethod, "success")
+
+	return nil
+}
+
+// GetJWKS returns the public keys of the published keys (see New) as a JWK Set, which relying parties can fetch to
+// verify the signatures of the agent. Each JWK 'kid' is the key's RFC 7638 thumbprint.
+func (o *Command) GetJWKS(rw io.Writer, _ io.Reader) command.Error {
+	jwks, err := jwkkid.ExportJWKS(o.ctx.KMS(), o.publishedKeys)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetJWKSCommandMethod, err.Error())
+		return command.NewExecuteError(ExportJWKSError, err)
+	}
+
+	command.WriteNillableResponse(rw, jwks, logger)
+
+	logutil.LogDebug(logger, CommandName, GetJWKSCommandMethod, "success")
+
+	return nil
+}
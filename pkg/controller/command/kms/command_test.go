@@ -34,7 +34,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 2, len(handlers))
+		require.Equal(t, 4, len(handlers))
 	})
 
 	t.Run("test new command - error from import key", func(t *testing.T) {
@@ -257,3 +257,105 @@ func TestImportKey(t *testing.T) {
 		require.Contains(t, err.Error(), "failed request decode")
 	})
 }
+
+func TestExportJWKS(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("test export jwks - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesValue: pubKey},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(ExportJWKSRequest{
+			Keys: []KeyReference{{KeyID: "k1", KeyType: string(kms.ED25519Type)}},
+		})
+		require.NoError(t, err)
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.ExportJWKS(&getRW, bytes.NewBuffer(reqBytes))
+		require.NoError(t, cmdErr)
+
+		jwks, err := ariesjose.ParseJWKSet(getRW.Bytes())
+		require.NoError(t, err)
+		require.Len(t, jwks.Keys, 1)
+		require.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+		require.Equal(t, "sig", jwks.Keys[0].Use)
+		require.EqualValues(t, pubKey, jwks.Keys[0].Key)
+	})
+
+	t.Run("test export jwks - validation errors", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ExportJWKS(&b, bytes.NewBuffer(nil))
+		require.Error(t, cmdErr)
+		require.Contains(t, cmdErr.Error(), "failed request decode")
+
+		for req, errMsg := range map[*ExportJWKSRequest]string{
+			{}: errEmptyKeys,
+			{Keys: []KeyReference{{KeyType: string(kms.ED25519Type)}}}: errEmptyKeyID,
+			{Keys: []KeyReference{{KeyID: "k1"}}}:                      errEmptyKeyType,
+		} {
+			reqBytes, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			cmdErr = cmd.ExportJWKS(&b, bytes.NewBuffer(reqBytes))
+			require.Error(t, cmdErr)
+			require.Contains(t, cmdErr.Error(), errMsg)
+		}
+	})
+
+	t.Run("test get jwks of published keys", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesValue: pubKey},
+		}, KeyReference{KeyID: "k1", KeyType: string(kms.ED25519Type)})
+		require.NotNil(t, cmd)
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetJWKS(&getRW, nil)
+		require.NoError(t, cmdErr)
+
+		jwks, err := ariesjose.ParseJWKSet(getRW.Bytes())
+		require.NoError(t, err)
+		require.Len(t, jwks.Keys, 1)
+		require.EqualValues(t, pubKey, jwks.Keys[0].Key)
+
+		// no key is published by default.
+		getRW.Reset()
+		require.NoError(t, New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}}).GetJWKS(&getRW, nil))
+		require.JSONEq(t, `{"keys":[]}`, getRW.String())
+	})
+
+	t.Run("test get jwks - error from export public key", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesErr: fmt.Errorf("key not found")},
+		}, KeyReference{KeyID: "k1", KeyType: string(kms.ED25519Type)})
+
+		var b bytes.Buffer
+		cmdErr := cmd.GetJWKS(&b, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, ExportJWKSError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "key not found")
+	})
+
+	t.Run("test export jwks - error from export public key", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesErr: fmt.Errorf("key not found")},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(ExportJWKSRequest{
+			Keys: []KeyReference{{KeyID: "k1", KeyType: string(kms.ED25519Type)}},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.ExportJWKS(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, ExportJWKSError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "key not found")
+	})
+}
@@ -19,6 +19,18 @@ type CreateKeySetResponse struct {
 	PublicKey string `json:"publicKey,omitempty"`
 }
 
+// KeyReference references a KMS key by its ID and type.
+type KeyReference struct {
+	KeyID   string `json:"keyID,omitempty"`
+	KeyType string `json:"keyType,omitempty"`
+}
+
+// ExportJWKSRequest is model for exportJWKS request.
+type ExportJWKSRequest struct {
+	// keys to export in the JWK Set
+	Keys []KeyReference `json:"keys,omitempty"`
+}
+
 // JSONWebKey contains subset of json web key json properties.
 type JSONWebKey struct {
 	Use string `json:"use,omitempty"`
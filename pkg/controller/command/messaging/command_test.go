@@ -30,7 +30,6 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
-	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 func TestNew(t *testing.T) {
@@ -454,14 +453,13 @@ func TestCommand_Send(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				memProvider := mem.NewProvider()
 
-				store, err := memProvider.OpenStore("didexchange")
-				require.NoError(t, err)
-
 				if tc.testConnection != nil {
-					connBytes, errMarshal := json.Marshal(tc.testConnection)
-					require.NoError(t, errMarshal)
-					require.NoError(t, store.Put(fmt.Sprintf("conn_%s", tc.testConnection.ConnectionID), connBytes,
-						spi.Tag{Name: "conn_"}))
+					recorder, errRecorder := connection.NewRecorder(&protocol.MockProvider{
+						StoreProvider:              memProvider,
+						ProtocolStateStoreProvider: mem.NewProvider(),
+					})
+					require.NoError(t, errRecorder)
+					require.NoError(t, recorder.SaveConnectionRecord(tc.testConnection))
 				}
 
 				cmd, err := New(&protocol.MockProvider{
@@ -573,19 +571,15 @@ func TestCommand_Send(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				memProvider := mem.NewProvider()
 
-				store, err := memProvider.OpenStore("didexchange")
-				require.NoError(t, err)
-
 				provider := &protocol.MockProvider{
 					StoreProvider:              memProvider,
 					ProtocolStateStoreProvider: mem.NewProvider(),
 				}
 
 				if tc.testConnection != nil {
-					connBytes, errMarshal := json.Marshal(tc.testConnection)
-					require.NoError(t, errMarshal)
-					require.NoError(t, store.Put(fmt.Sprintf("conn_%s", tc.testConnection.ConnectionID), connBytes,
-						spi.Tag{Name: "conn_"}))
+					recorder, err := connection.NewRecorder(provider)
+					require.NoError(t, err)
+					require.NoError(t, recorder.SaveConnectionRecord(tc.testConnection))
 				}
 
 				if tc.messenger != nil {
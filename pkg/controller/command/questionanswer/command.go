@@ -0,0 +1,253 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+const (
+	// InvalidRequestErrorCode is typically a code for validation errors
+	// for invalid question answer controller requests.
+	InvalidRequestErrorCode = command.Code(iota + command.QuestionAnswer)
+	// ActionsErrorCode is for failures in actions command.
+	ActionsErrorCode
+	// SendQuestionErrorCode is for failures in send question command.
+	SendQuestionErrorCode
+	// AnswerQuestionErrorCode is for failures in answer question command.
+	AnswerQuestionErrorCode
+	// DeclineQuestionErrorCode is for failures in decline question command.
+	DeclineQuestionErrorCode
+	// AcceptProblemReportErrorCode is for failures in accept problem report command.
+	AcceptProblemReportErrorCode
+)
+
+// constants for the QuestionAnswer operations.
+const (
+	// command name.
+	CommandName = "questionanswer"
+
+	Actions             = "Actions"
+	SendQuestion        = "SendQuestion"
+	AnswerQuestion      = "AnswerQuestion"
+	DeclineQuestion     = "DeclineQuestion"
+	AcceptProblemReport = "AcceptProblemReport"
+)
+
+const (
+	// error messages.
+	errEmptyPIID     = "empty PIID"
+	errEmptyMyDID    = "empty MyDID"
+	errEmptyTheirDID = "empty TheirDID"
+	errEmptyQuestion = "empty Question"
+	errEmptyResponse = "empty Response"
+
+	// log constants.
+	successString = "success"
+
+	_actions = "_actions"
+	_states  = "_states"
+)
+
+var logger = log.New("aries-framework/controller/questionanswer")
+
+// Command is controller command for question answer.
+type Command struct {
+	client *questionanswer.Client
+}
+
+// New returns new question answer controller command instance.
+func New(ctx questionanswer.Provider, notifier command.Notifier) (*Command, error) {
+	client, err := questionanswer.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create a client: %w", err)
+	}
+
+	// creates action channel
+	actions := make(chan service.DIDCommAction)
+	// registers action channel to listen for events
+	if err := client.RegisterActionEvent(actions); err != nil {
+		return nil, fmt.Errorf("register action event: %w", err)
+	}
+
+	// creates state channel
+	states := make(chan service.StateMsg)
+	// registers state channel to listen for events
+	if err := client.RegisterMsgEvent(states); err != nil {
+		return nil, fmt.Errorf("register msg event: %w", err)
+	}
+
+	obs := webnotifier.NewObserver(notifier)
+	obs.RegisterAction(protocol.Name+_actions, actions)
+	obs.RegisterStateMsg(protocol.Name+_states, states)
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, Actions, c.Actions),
+		cmdutil.NewCommandHandler(CommandName, SendQuestion, c.SendQuestion),
+		cmdutil.NewCommandHandler(CommandName, AnswerQuestion, c.AnswerQuestion),
+		cmdutil.NewCommandHandler(CommandName, DeclineQuestion, c.DeclineQuestion),
+		cmdutil.NewCommandHandler(CommandName, AcceptProblemReport, c.AcceptProblemReport),
+	}
+}
+
+// Actions returns pending actions that have not yet to be executed or canceled.
+func (c *Command) Actions(rw io.Writer, _ io.Reader) command.Error {
+	result, err := c.client.Actions()
+	if err != nil {
+		logutil.LogError(logger, CommandName, Actions, err.Error())
+		return command.NewExecuteError(ActionsErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ActionsResponse{
+		Actions: result,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, Actions, successString)
+
+	return nil
+}
+
+// SendQuestion is used by the Questioner to ask a question.
+func (c *Command) SendQuestion(rw io.Writer, req io.Reader) command.Error {
+	var args SendQuestionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, SendQuestion, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.MyDID == "" {
+		logutil.LogDebug(logger, CommandName, SendQuestion, errEmptyMyDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyMyDID))
+	}
+
+	if args.TheirDID == "" {
+		logutil.LogDebug(logger, CommandName, SendQuestion, errEmptyTheirDID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyTheirDID))
+	}
+
+	if args.Question == nil {
+		logutil.LogDebug(logger, CommandName, SendQuestion, errEmptyQuestion)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyQuestion))
+	}
+
+	piid, err := c.client.SendQuestion(args.Question, args.MyDID, args.TheirDID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SendQuestion, err.Error())
+		return command.NewExecuteError(SendQuestionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &SendQuestionResponse{
+		PIID: piid,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, SendQuestion, successString)
+
+	return nil
+}
+
+// AnswerQuestion is used by the Responder to answer the question.
+func (c *Command) AnswerQuestion(rw io.Writer, req io.Reader) command.Error {
+	var args AnswerQuestionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AnswerQuestion, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, AnswerQuestion, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if args.Response == "" {
+		logutil.LogDebug(logger, CommandName, AnswerQuestion, errEmptyResponse)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyResponse))
+	}
+
+	if err := c.client.AnswerQuestion(args.PIID, args.Response); err != nil {
+		logutil.LogError(logger, CommandName, AnswerQuestion, err.Error())
+		return command.NewExecuteError(AnswerQuestionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AnswerQuestionResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, AnswerQuestion, successString)
+
+	return nil
+}
+
+// DeclineQuestion is used when the Responder does not want to answer the question.
+// nolint: dupl
+func (c *Command) DeclineQuestion(rw io.Writer, req io.Reader) command.Error {
+	var args DeclineQuestionArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, DeclineQuestion, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, DeclineQuestion, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.DeclineQuestion(args.PIID, args.Reason); err != nil {
+		logutil.LogError(logger, CommandName, DeclineQuestion, err.Error())
+		return command.NewExecuteError(DeclineQuestionErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &DeclineQuestionResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, DeclineQuestion, successString)
+
+	return nil
+}
+
+// AcceptProblemReport is used for accepting problem report.
+// nolint: dupl
+func (c *Command) AcceptProblemReport(rw io.Writer, req io.Reader) command.Error {
+	var args AcceptProblemReportArgs
+
+	if err := json.NewDecoder(req).Decode(&args); err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptProblemReport, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	if args.PIID == "" {
+		logutil.LogDebug(logger, CommandName, AcceptProblemReport, errEmptyPIID)
+		return command.NewValidationError(InvalidRequestErrorCode, errors.New(errEmptyPIID))
+	}
+
+	if err := c.client.AcceptProblemReport(args.PIID); err != nil {
+		logutil.LogError(logger, CommandName, AcceptProblemReport, err.Error())
+		return command.NewExecuteError(AcceptProblemReportErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AcceptProblemReportResponse{}, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptProblemReport, successString)
+
+	return nil
+}
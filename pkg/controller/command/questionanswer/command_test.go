@@ -0,0 +1,264 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/questionanswer"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+const jsonPayload = `{"piid":"id"}`
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+		require.Len(t, cmd.GetHandlers(), 5)
+	})
+
+	t.Run("Create client (error)", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(nil, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "cannot create a client: cast service to questionanswer service failed")
+		require.Nil(t, cmd)
+	})
+
+	t.Run("Register action event (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(errors.New("error"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "register action event: error")
+		require.Nil(t, cmd)
+	})
+
+	t.Run("Register msg event (error)", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+		service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(errors.New("error"))
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+		cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+		require.EqualError(t, err, "register msg event: error")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().Actions().Return([]protocol.Action{{PIID: "id"}}, nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.Actions(&b, nil))
+
+		var res ActionsResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Len(t, res.Actions, 1)
+		require.Equal(t, "id", res.Actions[0].PIID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().Actions().Return(nil, errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		requireExecuteError(t, cmd.Actions(&bytes.Buffer{}, nil), ActionsErrorCode)
+	})
+}
+
+func TestCommand_SendQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+	requireValidationError(t, cmd.SendQuestion, "}", "")
+	requireValidationError(t, cmd.SendQuestion, "{}", errEmptyMyDID)
+	requireValidationError(t, cmd.SendQuestion, `{"my_did":"id"}`, errEmptyTheirDID)
+	requireValidationError(t, cmd.SendQuestion, `{"my_did":"id","their_did":"id"}`, errEmptyQuestion)
+
+	const payload = `{"my_did":"id","their_did":"id","question":{"question_text":"Alice?"}}`
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().HandleInbound(gomock.Any(), "id", "id").Return("piid", nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		var b bytes.Buffer
+		require.Nil(t, cmd.SendQuestion(&b, bytes.NewBufferString(payload)))
+
+		var res SendQuestionResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &res))
+		require.Equal(t, "piid", res.PIID)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return("", errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		requireExecuteError(t, cmd.SendQuestion(&bytes.Buffer{}, bytes.NewBufferString(payload)), SendQuestionErrorCode)
+	})
+}
+
+func TestCommand_AnswerQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+	requireValidationError(t, cmd.AnswerQuestion, "}", "")
+	requireValidationError(t, cmd.AnswerQuestion, "{}", errEmptyPIID)
+	requireValidationError(t, cmd.AnswerQuestion, jsonPayload, errEmptyResponse)
+
+	const payload = `{"piid":"id","response":"yes"}`
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().ActionContinue("id", gomock.Any()).Return(nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		require.Nil(t, cmd.AnswerQuestion(&bytes.Buffer{}, bytes.NewBufferString(payload)))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().ActionContinue("id", gomock.Any()).Return(errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		requireExecuteError(t, cmd.AnswerQuestion(&bytes.Buffer{}, bytes.NewBufferString(payload)),
+			AnswerQuestionErrorCode)
+	})
+}
+
+func TestCommand_DeclineQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+	requireValidationError(t, cmd.DeclineQuestion, "}", "")
+	requireValidationError(t, cmd.DeclineQuestion, "{}", errEmptyPIID)
+
+	const payload = `{"piid":"id","reason":"reason"}`
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().ActionStop("id", errors.New("reason")).Return(nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		require.Nil(t, cmd.DeclineQuestion(&bytes.Buffer{}, bytes.NewBufferString(payload)))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().ActionStop("id", gomock.Any()).Return(errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		requireExecuteError(t, cmd.DeclineQuestion(&bytes.Buffer{}, bytes.NewBufferString(payload)),
+			DeclineQuestionErrorCode)
+	})
+}
+
+func TestCommand_AcceptProblemReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cmd := newCommand(t, ctrl, mocks.NewMockProtocolService(ctrl))
+
+	requireValidationError(t, cmd.AcceptProblemReport, "}", "")
+	requireValidationError(t, cmd.AcceptProblemReport, "{}", errEmptyPIID)
+
+	t.Run("Success", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().ActionContinue("id", nil).Return(nil)
+
+		cmd := newCommand(t, ctrl, service)
+
+		require.Nil(t, cmd.AcceptProblemReport(&bytes.Buffer{}, bytes.NewBufferString(jsonPayload)))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		service := mocks.NewMockProtocolService(ctrl)
+		service.EXPECT().ActionContinue("id", nil).Return(errors.New("some error message"))
+
+		cmd := newCommand(t, ctrl, service)
+
+		requireExecuteError(t, cmd.AcceptProblemReport(&bytes.Buffer{}, bytes.NewBufferString(jsonPayload)),
+			AcceptProblemReportErrorCode)
+	})
+}
+
+func newCommand(t *testing.T, ctrl *gomock.Controller, service *mocks.MockProtocolService) *Command {
+	t.Helper()
+
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+	cmd, err := New(provider, mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	return cmd
+}
+
+func requireValidationError(t *testing.T, exec command.Exec, payload, msg string) {
+	t.Helper()
+
+	cmdErr := exec(&bytes.Buffer{}, bytes.NewBufferString(payload))
+
+	require.Error(t, cmdErr)
+	require.Contains(t, cmdErr.Error(), msg)
+	require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	require.Equal(t, command.ValidationError, cmdErr.Type())
+}
+
+func requireExecuteError(t *testing.T, cmdErr command.Error, code command.Code) {
+	t.Helper()
+
+	require.Error(t, cmdErr)
+	require.Contains(t, cmdErr.Error(), "some error message")
+	require.Equal(t, code, cmdErr.Code())
+	require.Equal(t, command.ExecuteError, cmdErr.Type())
+}
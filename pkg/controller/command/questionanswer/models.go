@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import "github.com/hyperledger/aries-framework-go/pkg/client/questionanswer"
+
+// ActionsResponse model
+//
+// Represents Actions response message
+//
+type ActionsResponse struct {
+	Actions []questionanswer.Action `json:"actions"`
+}
+
+// SendQuestionArgs model
+//
+// This is used for asking a question
+//
+type SendQuestionArgs struct {
+	// MyDID sender's did
+	MyDID string `json:"my_did"`
+	// TheirDID receiver's did
+	TheirDID string `json:"their_did"`
+	// Question is asked by the Questioner along with the valid responses.
+	Question *questionanswer.Question `json:"question"`
+}
+
+// SendQuestionResponse model
+//
+// Represents a SendQuestion response message
+//
+type SendQuestionResponse struct {
+	// PIID Protocol instance ID. It can be used as a correlation ID
+	PIID string `json:"piid"`
+}
+
+// AnswerQuestionArgs model
+//
+// This is used for answering a question
+//
+type AnswerQuestionArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Response is one of the valid responses of the question
+	Response string `json:"response"`
+}
+
+// AnswerQuestionResponse model
+//
+// Represents a AnswerQuestion response message
+//
+type AnswerQuestionResponse struct{}
+
+// DeclineQuestionArgs model
+//
+// This is used when the question needs to be rejected
+//
+type DeclineQuestionArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+	// Reason why question is declined
+	Reason string `json:"reason"`
+}
+
+// DeclineQuestionResponse model
+//
+// Represents a DeclineQuestion response message
+//
+type DeclineQuestionResponse struct{}
+
+// AcceptProblemReportArgs model
+//
+// This is used for accepting a problem report
+//
+type AcceptProblemReportArgs struct {
+	// PIID Protocol instance ID
+	PIID string `json:"piid"`
+}
+
+// AcceptProblemReportResponse model
+//
+// Represents a AcceptProblemReport response message
+//
+type AcceptProblemReportResponse struct{}
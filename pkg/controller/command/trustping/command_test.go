@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.Len(t, cmd.GetHandlers(), 1)
+	})
+
+	t.Run("test new command - client creation fail", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping client")
+	})
+}
+
+func TestCommand_Ping(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingFunc: func(connectionID string, _ ...trustping.PingOption) (time.Duration, error) {
+				require.Equal(t, "123-abc", connectionID)
+
+				return time.Second, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":"123-abc","timeout":1000000}`))
+		require.NoError(t, cmdErr)
+
+		var response PingResponse
+		require.NoError(t, json.Unmarshal(b.Bytes(), &response))
+		require.Equal(t, time.Second, response.RoundTrip)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`--`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":""}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, PingMissingConnIDCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("ping error", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingErr: errors.New("ping error"),
+		}})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(`{"connectionID":"123-abc"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, PingErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "ping error")
+	})
+}
@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "time"
+
+// PingArgs model
+//
+// This is used for checking the liveness of a connection.
+type PingArgs struct {
+	// ConnectionID of the connection to ping.
+	ConnectionID string `json:"connectionID"`
+
+	// Comment of the ping message (optional).
+	Comment string `json:"comment,omitempty"`
+
+	// Timeout (in nanoseconds) waiting for the ping response (optional).
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// PingResponse model
+//
+// Response of the ping.
+type PingResponse struct {
+	// RoundTrip (in nanoseconds) between sending the ping and receiving its response.
+	RoundTrip time.Duration `json:"round_trip"`
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/piprate/json-gold/ld"
@@ -261,8 +262,10 @@ func (o *Command) GetHandlers() []command.Handler {
 }
 
 // ValidateCredential validates the verifiable credential.
+// When several verifiable credentials are passed, their proofs are verified in parallel and the validation result
+// of each credential is returned.
 func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error {
-	request := &Credential{}
+	request := &ValidateCredentialRequest{}
 
 	err := json.NewDecoder(req).Decode(&request)
 	if err != nil {
@@ -271,10 +274,16 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
 	}
 
+	if len(request.VerifiableCredentials) > 0 {
+		o.validateCredentials(rw, request)
+
+		return nil
+	}
+
 	// we are only validating the VerifiableCredential here, hence ignoring other return values
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/1316 VC Validate Command - Add keys for proof
 	//  verification as options to the function.
-	_, err = verifiable.ParseCredential([]byte(request.VerifiableCredential))
+	_, err = verifiable.ParseCredential([]byte(request.VerifiableCredential), withDataModels())
 	if err != nil {
 		logutil.LogInfo(logger, CommandName, ValidateCredentialCommandMethod, "validate vc : "+err.Error())
 
@@ -288,6 +297,50 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 	return nil
 }
 
+func (o *Command) validateCredentials(rw io.Writer, request *ValidateCredentialRequest) {
+	vcs := make([][]byte, len(request.VerifiableCredentials))
+
+	for i, vcRaw := range request.VerifiableCredentials {
+		vcs[i] = vcRaw
+
+		// a credential in JWT format is passed as a JSON string.
+		var vcStr string
+		if json.Unmarshal(vcRaw, &vcStr) == nil {
+			vcs[i] = []byte(vcStr)
+		}
+	}
+
+	opts := []verifiable.BatchVerificationOpt{
+		verifiable.WithBatchCredentialOpts(o.getCredentialOpts(false)...),
+	}
+
+	if request.MaxConcurrency > 0 {
+		opts = append(opts, verifiable.WithBatchMaxConcurrency(request.MaxConcurrency))
+	}
+
+	results := verifiable.VerifyCredentials(vcs, opts...)
+
+	response := &ValidateCredentialResponse{
+		Results: make([]CredentialValidationResult, len(results)),
+	}
+
+	for i, result := range results {
+		if result.Err != nil {
+			response.Results[i].Error = result.Err.Error()
+
+			continue
+		}
+
+		response.Results[i].ID = result.Credential.ID
+		response.Results[i].Valid = true
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, ValidateCredentialCommandMethod, "success",
+		logutil.CreateKeyValueString("credentials", strconv.Itoa(len(results))))
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 	request := &CredentialExt{}
@@ -304,7 +357,8 @@ func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 		return command.NewValidationError(SaveCredentialErrorCode, fmt.Errorf(errEmptyCredentialName))
 	}
 
-	vc, err := verifiable.ParseCredential([]byte(request.VerifiableCredential), verifiable.WithDisabledProofCheck())
+	vc, err := verifiable.ParseCredential([]byte(request.VerifiableCredential), verifiable.WithDisabledProofCheck(),
+		withDataModels())
 	if err != nil {
 		logutil.LogError(logger, CommandName, SaveCredentialCommandMethod, "parse vc : "+err.Error())
 
@@ -342,7 +396,7 @@ func (o *Command) SavePresentation(rw io.Writer, req io.Reader) command.Error {
 	}
 
 	vp, err := verifiable.ParsePresentation([]byte(request.VerifiablePresentation),
-		verifiable.WithPresDisabledProofCheck())
+		verifiable.WithPresDisabledProofCheck(), withPresDataModels())
 	if err != nil {
 		logutil.LogError(logger, CommandName, SavePresentationCommandMethod, "parse vp : "+err.Error())
 
@@ -432,7 +486,7 @@ func (o *Command) SignCredential(rw io.Writer, req io.Reader) command.Error {
 		didDoc = doc.DIDDocument
 	}
 
-	vc, err := verifiable.ParseCredential(request.Credential, verifiable.WithDisabledProofCheck())
+	vc, err := verifiable.ParseCredential(request.Credential, verifiable.WithDisabledProofCheck(), withDataModels())
 	if err != nil {
 		logutil.LogError(logger, CommandName, SignCredentialCommandMethod, "parse credential : "+err.Error())
 
@@ -930,7 +984,8 @@ func (o *Command) parseVerifiableCredentials(request *PresentationRequest,
 
 func (o *Command) parsePresentation(request *PresentationRequest,
 	didDoc *did.Doc) ([]*verifiable.Credential, *verifiable.Presentation, *ProofOptions, error) {
-	presentation, err := verifiable.ParsePresentation(request.Presentation, verifiable.WithPresDisabledProofCheck())
+	presentation, err := verifiable.ParsePresentation(request.Presentation, verifiable.WithPresDisabledProofCheck(),
+		withPresDataModels())
 	if err != nil {
 		logutil.LogError(logger, CommandName, GeneratePresentationCommandMethod,
 			"failed to parse presentation from request: "+err.Error())
@@ -966,12 +1021,21 @@ func (o *Command) parsePresentationRequest(request *PresentationRequest,
 
 func (o *Command) getCredentialOpts(disableProofCheck bool) []verifiable.CredentialOpt {
 	if disableProofCheck {
-		return []verifiable.CredentialOpt{verifiable.WithDisabledProofCheck()}
+		return []verifiable.CredentialOpt{verifiable.WithDisabledProofCheck(), withDataModels()}
 	}
 
 	return []verifiable.CredentialOpt{verifiable.WithPublicKeyFetcher(
 		verifiable.NewDIDKeyResolver(o.ctx.VDRegistry()).PublicKeyFetcher(),
-	)}
+	), withDataModels()}
+}
+
+// withDataModels makes the command accept credentials of both VC Data Model 1.1 and 2.0.
+func withDataModels() verifiable.CredentialOpt {
+	return verifiable.WithDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2)
+}
+
+func withPresDataModels() verifiable.PresentationOpt {
+	return verifiable.WithPresDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2)
 }
 
 func prepareOpts(opts *ProofOptions, didDoc *did.Doc, method did.VerificationRelationship) (*ProofOptions, error) {
@@ -484,6 +484,78 @@ func TestValidateVC(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "new credential")
 	})
+
+	t.Run("test validate several credentials", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveErr: errors.New("did not found"),
+			},
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		validVC := `{
+			"@context": "https://www.w3.org/2018/credentials/v1",
+			"id": "http://example.edu/credentials/1989",
+			"type": "VerifiableCredential",
+			"credentialSubject": {"id": "did:example:iuajk1f712ebc6f1c276e12ec21"},
+			"issuer": "did:example:09s12ec712ebc6f1c671ebfeb1f",
+			"issuanceDate": "2020-01-01T10:54:01Z"
+		}`
+
+		vcReq := ValidateCredentialRequest{
+			VerifiableCredentials: []json.RawMessage{
+				[]byte(validVC), []byte(vcWithDIDNotAvailble), []byte(`"--"`),
+			},
+			MaxConcurrency: 2,
+		}
+		vcReqBytes, err := json.Marshal(vcReq)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		err = cmd.ValidateCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.NoError(t, err)
+
+		var response ValidateCredentialResponse
+
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Results, 3)
+
+		require.True(t, response.Results[0].Valid)
+		require.Equal(t, "http://example.edu/credentials/1989", response.Results[0].ID)
+		require.Empty(t, response.Results[0].Error)
+
+		require.False(t, response.Results[1].Valid)
+		require.Contains(t, response.Results[1].Error, "did not found")
+
+		require.False(t, response.Results[2].Valid)
+		require.Contains(t, response.Results[2].Error, "new credential")
+	})
+
+	t.Run("test validate credential of VC Data Model 2.0", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NotNil(t, cmd)
+		require.NoError(t, err)
+
+		vcReq := Credential{VerifiableCredential: `{
+			"@context": "https://www.w3.org/ns/credentials/v2",
+			"id": "http://example.edu/credentials/1989",
+			"type": "VerifiableCredential",
+			"credentialSubject": {"id": "did:example:iuajk1f712ebc6f1c276e12ec21"},
+			"issuer": "did:example:09s12ec712ebc6f1c671ebfeb1f",
+			"validFrom": "2020-01-01T10:54:01Z"
+		}`}
+		vcReqBytes, err := json.Marshal(vcReq)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.ValidateCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.NoError(t, err)
+	})
 }
 
 func TestSaveVC(t *testing.T) {
@@ -19,6 +19,28 @@ type Credential struct {
 	VerifiableCredential string `json:"verifiableCredential,omitempty"`
 }
 
+// ValidateCredentialRequest is model for validate credential request.
+type ValidateCredentialRequest struct {
+	Credential
+	// VerifiableCredentials are validated in parallel when defined, the validation result of each credential is
+	// returned in ValidateCredentialResponse. VerifiableCredential is ignored in that case.
+	VerifiableCredentials []json.RawMessage `json:"verifiableCredentials,omitempty"`
+	// MaxConcurrency is the maximum number of VerifiableCredentials validated at once, defaults to the number of CPUs.
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// ValidateCredentialResponse is model for validate credential response of several credentials.
+type ValidateCredentialResponse struct {
+	Results []CredentialValidationResult `json:"results,omitempty"`
+}
+
+// CredentialValidationResult is model for the validation result of a credential.
+type CredentialValidationResult struct {
+	ID    string `json:"id,omitempty"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
 // PresentationRequest is model for verifiable presentation request.
 type PresentationRequest struct {
 	VerifiableCredentials []json.RawMessage `json:"verifiableCredential,omitempty"`
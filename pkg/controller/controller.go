@@ -10,7 +10,9 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	actionmenucmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/actionmenu"
 	didexchangecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didexchange"
+	discoverfeaturescmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
 	introducecmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/introduce"
 	issuecredentialcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
@@ -18,10 +20,14 @@ import (
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	questionanswercmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+	trustpingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	actionmenurest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/actionmenu"
 	didexchangerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/didexchange"
+	discoverfeaturesrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/discoverfeatures"
 	introducerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/introduce"
 	issuecredentialrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/issuecredential"
 	kmsrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/kms"
@@ -29,6 +35,8 @@ import (
 	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
 	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
+	questionanswerrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/questionanswer"
+	trustpingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/trustping"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
@@ -36,11 +44,12 @@ import (
 )
 
 type allOpts struct {
-	webhookURLs  []string
-	defaultLabel string
-	autoAccept   bool
-	msgHandler   command.MessageHandler
-	notifier     command.Notifier
+	webhookURLs   []string
+	defaultLabel  string
+	autoAccept    bool
+	msgHandler    command.MessageHandler
+	notifier      command.Notifier
+	publishedKeys []kms.KeyReference
 }
 
 const wsPath = "/ws"
@@ -83,6 +92,13 @@ func WithMessageHandler(handler command.MessageHandler) Opt {
 	}
 }
 
+// WithPublishedKeys is an option setting the KMS keys whose public keys are published as a JWK Set.
+func WithPublishedKeys(keys ...kms.KeyReference) Opt {
+	return func(opts *allOpts) {
+		opts.publishedKeys = keys
+	}
+}
+
 // GetRESTHandlers returns all REST handlers provided by controller.
 func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error) { // nolint: funlen,gocyclo
 	restAPIOpts := &allOpts{}
@@ -151,8 +167,32 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create outofband rest command : %w", err)
 	}
 
+	// trust ping REST operation
+	trustpingOp, err := trustpingrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping rest command : %w", err)
+	}
+
+	// discover features REST operation
+	discoverfeaturesOp, err := discoverfeaturesrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features rest command : %w", err)
+	}
+
+	// action menu REST operation
+	actionmenuOp, err := actionmenurest.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create action menu rest command : %w", err)
+	}
+
+	// question answer REST operation
+	questionanswerOp, err := questionanswerrest.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create question answer rest command : %w", err)
+	}
+
 	// kms command operation
-	kmscmd := kmsrest.New(ctx)
+	kmscmd := kmsrest.New(ctx, restAPIOpts.publishedKeys...)
 
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
@@ -165,6 +205,10 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, presentproofOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, introduceOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, outofbandOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, trustpingOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, discoverfeaturesOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, actionmenuOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, questionanswerOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, kmscmd.GetRESTHandlers()...)
 
 	nhp, ok := notifier.(handlerProvider)
@@ -247,8 +291,32 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create outofband command : %w", err)
 	}
 
+	// trust ping command operation
+	trustping, err := trustpingcmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping command : %w", err)
+	}
+
+	// discover features command operation
+	discoverfeatures, err := discoverfeaturescmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features command : %w", err)
+	}
+
+	// action menu command operation
+	actionmenu, err := actionmenucmd.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create action menu command : %w", err)
+	}
+
+	// question answer command operation
+	questionanswer, err := questionanswercmd.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("create question answer command : %w", err)
+	}
+
 	// kms command operation
-	kmscmd := kms.New(ctx)
+	kmscmd := kms.New(ctx, cmdOpts.publishedKeys...)
 
 	var allHandlers []command.Handler
 	allHandlers = append(allHandlers, didexcmd.GetHandlers()...)
@@ -261,6 +329,10 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, presentproof.GetHandlers()...)
 	allHandlers = append(allHandlers, introduce.GetHandlers()...)
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
+	allHandlers = append(allHandlers, trustping.GetHandlers()...)
+	allHandlers = append(allHandlers, discoverfeatures.GetHandlers()...)
+	allHandlers = append(allHandlers, actionmenu.GetHandlers()...)
+	allHandlers = append(allHandlers, questionanswer.GetHandlers()...)
 
 	return allHandlers, nil
 }
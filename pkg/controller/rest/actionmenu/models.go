@@ -0,0 +1,283 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
+
+// actionMenuActionsRequest model
+//
+// Returns pending actions that have not yet to be executed or cancelled.
+//
+// swagger:parameters actionMenuActions
+type actionMenuActionsRequest struct{} // nolint: unused,deadcode
+
+// actionMenuActionsResponse model
+//
+// Represents a Actions response message.
+//
+// swagger:response actionMenuActionsResponse
+type actionMenuActionsResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Actions []struct{ *protocol.Action } `json:"actions"`
+	}
+}
+
+// actionMenuRequestMenuRequest model
+//
+// This is used for operation to request the root menu.
+//
+// swagger:parameters actionMenuRequestMenu
+type actionMenuRequestMenuRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+	}
+}
+
+// actionMenuRequestMenuResponse model
+//
+// Represents a RequestMenu response message.
+//
+// swagger:response actionMenuRequestMenuResponse
+type actionMenuRequestMenuResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// PIID Protocol instance ID. It can be used as a correlation ID
+		PIID string `json:"piid"`
+	}
+}
+
+// actionMenuSendMenuRequest model
+//
+// This is used for operation to send a menu.
+//
+// swagger:parameters actionMenuSendMenu
+type actionMenuSendMenuRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+		// Menu presents the available actions to the requester.
+		// required: true
+		Menu struct{ *protocol.Menu } `json:"menu"`
+	}
+}
+
+// actionMenuSendMenuResponse model
+//
+// Represents a SendMenu response message.
+//
+// swagger:response actionMenuSendMenuResponse
+type actionMenuSendMenuResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// PIID Protocol instance ID. It can be used as a correlation ID
+		PIID string `json:"piid"`
+	}
+}
+
+// actionMenuAcceptMenuRequestRequest model
+//
+// This is used for operation to accept a menu request.
+//
+// swagger:parameters actionMenuAcceptMenuRequest
+type actionMenuAcceptMenuRequestRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// Menu presents the available actions to the requester.
+		//
+		// required: true
+		Menu struct{ *protocol.Menu } `json:"menu"`
+	}
+}
+
+// actionMenuAcceptMenuRequestResponse model
+//
+// Represents a AcceptMenuRequest response message.
+//
+// swagger:response actionMenuAcceptMenuRequestResponse
+type actionMenuAcceptMenuRequestResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// actionMenuDeclineMenuRequestRequest model
+//
+// This is used for operation to decline a menu request.
+//
+// swagger:parameters actionMenuDeclineMenuRequest
+type actionMenuDeclineMenuRequestRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// Reason is an explanation of why it was declined
+	Reason string `json:"reason"`
+}
+
+// actionMenuDeclineMenuRequestResponse model
+//
+// Represents a DeclineMenuRequest response message.
+//
+// swagger:response actionMenuDeclineMenuRequestResponse
+type actionMenuDeclineMenuRequestResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// actionMenuPerformActionRequest model
+//
+// This is used for operation to select one of the menu options.
+//
+// swagger:parameters actionMenuPerformAction
+type actionMenuPerformActionRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// Perform selects one of the menu options.
+		//
+		// required: true
+		Perform struct{ *protocol.Perform } `json:"perform"`
+	}
+}
+
+// actionMenuPerformActionResponse model
+//
+// Represents a PerformAction response message.
+//
+// swagger:response actionMenuPerformActionResponse
+type actionMenuPerformActionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// actionMenuDeclineMenuRequest model
+//
+// This is used for operation to decline a menu.
+//
+// swagger:parameters actionMenuDeclineMenu
+type actionMenuDeclineMenuRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// Reason is an explanation of why it was declined
+	Reason string `json:"reason"`
+}
+
+// actionMenuDeclineMenuResponse model
+//
+// Represents a DeclineMenu response message.
+//
+// swagger:response actionMenuDeclineMenuResponse
+type actionMenuDeclineMenuResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// actionMenuAcceptPerformRequest model
+//
+// This is used for operation to perform the selected option.
+//
+// swagger:parameters actionMenuAcceptPerform
+type actionMenuAcceptPerformRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// Menu is an optional follow-up menu sent to the requester.
+		Menu struct{ *protocol.Menu } `json:"menu"`
+	}
+}
+
+// actionMenuAcceptPerformResponse model
+//
+// Represents a AcceptPerform response message.
+//
+// swagger:response actionMenuAcceptPerformResponse
+type actionMenuAcceptPerformResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// actionMenuDeclinePerformRequest model
+//
+// This is used for operation to decline the selected option.
+//
+// swagger:parameters actionMenuDeclinePerform
+type actionMenuDeclinePerformRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// Reason is an explanation of why it was declined
+	Reason string `json:"reason"`
+}
+
+// actionMenuDeclinePerformResponse model
+//
+// Represents a DeclinePerform response message.
+//
+// swagger:response actionMenuDeclinePerformResponse
+type actionMenuDeclinePerformResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// actionMenuAcceptProblemReportRequest model
+//
+// This is used for operation to accept a problem report.
+//
+// swagger:parameters actionMenuAcceptProblemReport
+type actionMenuAcceptProblemReportRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+}
+
+// actionMenuAcceptProblemReportResponse model
+//
+// Represents a AcceptProblemReport response message.
+//
+// swagger:response actionMenuAcceptProblemReportResponse
+type actionMenuAcceptProblemReportResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/actionmenu"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/actionmenu"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+func provider(ctrl *gomock.Controller) client.Provider {
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+	service.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().Actions().AnyTimes()
+	service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().ActionStop(gomock.Any(), gomock.Any()).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+	return provider
+}
+
+func TestOperation_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	buf, code, err := sendRequestToHandler(handlerLookup(t, operation, Actions), nil, Actions)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, buf.String(), "actions")
+}
+
+func TestOperation_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		path    string
+		payload string
+	}{
+		{path: RequestMenu, payload: `{"my_did":"id","their_did":"id"}`},
+		{path: SendMenu, payload: `{"my_did":"id","their_did":"id","menu":{}}`},
+	}
+
+	for _, tc := range tests {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		_, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, tc.path),
+			bytes.NewBufferString(tc.payload),
+			tc.path,
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code, tc.path)
+	}
+}
+
+func TestOperation_Accept(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		path    string
+		payload string
+	}{
+		{path: AcceptMenuRequest, payload: `{"menu":{}}`},
+		{path: PerformAction, payload: `{"perform":{"name":"option"}}`},
+		{path: AcceptPerform, payload: `{}`},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.path, func(t *testing.T) {
+			operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+			require.NoError(t, err)
+
+			buf, code, err := sendRequestToHandler(
+				handlerLookup(t, operation, tc.path), nil,
+				strings.Replace(tc.path, `{piid}`, "1234", 1),
+			)
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, code)
+			require.Contains(t, buf.String(), "payload was not provided")
+
+			operation, err = New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+			require.NoError(t, err)
+
+			_, code, err = sendRequestToHandler(
+				handlerLookup(t, operation, tc.path),
+				bytes.NewBufferString(tc.payload),
+				strings.Replace(tc.path, `{piid}`, "1234", 1),
+			)
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, code)
+		})
+	}
+}
+
+func TestOperation_Decline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	for _, path := range []string{DeclineMenuRequest, DeclineMenu, DeclinePerform, AcceptProblemReport} {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		_, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, path),
+			nil,
+			strings.Replace(path, `{piid}`, "1234", 1)+"?reason=reason",
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code, path)
+	}
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
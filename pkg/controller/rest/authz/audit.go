@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authz
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+)
+
+var logger = log.New("aries-framework/rest/authz")
+
+// AuditEvent records a single authorization decision.
+type AuditEvent struct {
+	Time    time.Time `json:"time"`
+	Subject string    `json:"subject,omitempty"`
+	Method  string    `json:"method"`
+	Path    string    `json:"path"`
+	Scope   string    `json:"scope"`
+	Allowed bool      `json:"allowed"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// AuditLogger receives every authorization decision taken by Authorizer.
+type AuditLogger interface {
+	Log(event *AuditEvent)
+}
+
+// logAuditLogger is the default AuditLogger writing decisions to the framework logger.
+type logAuditLogger struct{}
+
+func (l *logAuditLogger) Log(e *AuditEvent) {
+	if e.Allowed {
+		logger.Infof("authz allowed: subject=%s method=%s path=%s scope=%s", e.Subject, e.Method, e.Path, e.Scope)
+
+		return
+	}
+
+	logger.Warnf("authz denied: subject=%s method=%s path=%s scope=%s reason=%s",
+		e.Subject, e.Method, e.Path, e.Scope, e.Reason)
+}
+
+// JSONAuditLogger writes authorization decisions to w as JSON lines.
+type JSONAuditLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditLogger returns a new JSONAuditLogger writing to w.
+func NewJSONAuditLogger(w io.Writer) *JSONAuditLogger {
+	return &JSONAuditLogger{enc: json.NewEncoder(w)}
+}
+
+// Log writes the event as a single JSON line.
+func (l *JSONAuditLogger) Log(e *AuditEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.enc.Encode(e); err != nil {
+		logger.Errorf("failed to write audit event: %s", err)
+	}
+}
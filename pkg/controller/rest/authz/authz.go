@@ -0,0 +1,245 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package authz provides scope based authorization of controller REST API calls using
+// JWT access tokens verified against a JSON Web Key Set.
+//
+// Every REST handler belongs to a group named after the first segment of its path
+// (e.g. "/kms/keyset" belongs to "kms", "/verifiable/credentials" to "verifiable").
+// Safe methods (GET, HEAD and OPTIONS) require the "<group>:read" scope, all other
+// methods require "<group>:write". Granted scopes may use "*" in place of the group
+// or the action, e.g. "*:read" grants read access to every group.
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const (
+	// ActionRead is the scope action required by safe HTTP methods.
+	ActionRead = "read"
+	// ActionWrite is the scope action required by HTTP methods changing state.
+	ActionWrite = "write"
+
+	wildcard       = "*"
+	bearerPrefix   = "Bearer "
+	scopeSeparator = ":"
+
+	// default clock skew tolerated when validating exp and nbf claims.
+	defaultLeeway = time.Minute
+)
+
+// Authorizer validates JWT access tokens and checks their scopes against the requested REST endpoint.
+type Authorizer struct {
+	jwks     *JWKS
+	issuer   string
+	audience string
+	leeway   time.Duration
+	audit    AuditLogger
+	now      func() time.Time
+}
+
+// Opt configures Authorizer.
+type Opt func(a *Authorizer)
+
+// WithIssuer option requires the "iss" claim of access tokens to be equal to issuer.
+func WithIssuer(issuer string) Opt {
+	return func(a *Authorizer) {
+		a.issuer = issuer
+	}
+}
+
+// WithAudience option requires the "aud" claim of access tokens to contain audience.
+func WithAudience(audience string) Opt {
+	return func(a *Authorizer) {
+		a.audience = audience
+	}
+}
+
+// WithLeeway option sets the clock skew tolerated when validating "exp" and "nbf" claims.
+func WithLeeway(leeway time.Duration) Opt {
+	return func(a *Authorizer) {
+		a.leeway = leeway
+	}
+}
+
+// WithAuditLogger option sets the logger receiving every authorization decision.
+func WithAuditLogger(l AuditLogger) Opt {
+	return func(a *Authorizer) {
+		a.audit = l
+	}
+}
+
+// New returns a new Authorizer verifying access tokens against the given JSON Web Key Set.
+func New(jwksBytes []byte, opts ...Opt) (*Authorizer, error) {
+	jwks, err := ParseJWKS(jwksBytes)
+	if err != nil {
+		return nil, fmt.Errorf("new authorizer: %w", err)
+	}
+
+	a := &Authorizer{
+		jwks:   jwks,
+		leeway: defaultLeeway,
+		audit:  &logAuditLogger{},
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a, nil
+}
+
+// RequiredScope returns the scope needed to call the REST endpoint addressed by r.
+func RequiredScope(r *http.Request) string {
+	group := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)[0] // nolint:gomnd
+
+	action := ActionWrite
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		action = ActionRead
+	}
+
+	return group + scopeSeparator + action
+}
+
+// Middleware returns http middleware rejecting requests without an access token granting the required scope.
+func (a *Authorizer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		required := RequiredScope(r)
+
+		subject, err := a.authorize(r, required)
+
+		a.audit.Log(&AuditEvent{
+			Time:    a.now(),
+			Subject: subject,
+			Method:  r.Method,
+			Path:    r.URL.Path,
+			Scope:   required,
+			Allowed: err == nil,
+			Reason:  reason(err),
+		})
+
+		if err != nil {
+			status := http.StatusUnauthorized
+			if errors.Is(err, errForbidden) {
+				status = http.StatusForbidden
+			}
+
+			w.WriteHeader(status)
+			w.Write([]byte(http.StatusText(status) + ".\n")) // nolint:gosec,errcheck
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+var errForbidden = errors.New("scope not granted")
+
+type claims struct {
+	jwt.Claims
+
+	Scope string   `json:"scope,omitempty"`
+	Scp   []string `json:"scp,omitempty"`
+}
+
+func (c *claims) scopes() []string {
+	return append(strings.Fields(c.Scope), c.Scp...)
+}
+
+// authorize validates the access token of r and checks it grants the required scope.
+// It returns the token subject, when known, along with the authorization error.
+func (a *Authorizer) authorize(r *http.Request, required string) (string, error) {
+	hdr := r.Header.Get("Authorization")
+	if !strings.HasPrefix(hdr, bearerPrefix) {
+		return "", errors.New("missing bearer token")
+	}
+
+	token, err := jwt.Parse(strings.TrimPrefix(hdr, bearerPrefix),
+		jwt.WithSignatureVerifier(a.jwks.signatureVerifier()))
+	if err != nil {
+		return "", fmt.Errorf("invalid access token: %w", err)
+	}
+
+	c := &claims{}
+
+	if err = token.DecodeClaims(c); err != nil {
+		return "", fmt.Errorf("decode access token claims: %w", err)
+	}
+
+	if err = a.validateClaims(c); err != nil {
+		return c.Subject, err
+	}
+
+	if !granted(c.scopes(), required) {
+		return c.Subject, fmt.Errorf("%w: %s", errForbidden, required)
+	}
+
+	return c.Subject, nil
+}
+
+func (a *Authorizer) validateClaims(c *claims) error {
+	now := a.now()
+
+	if c.Expiry == nil {
+		return errors.New("access token has no exp claim")
+	}
+
+	if now.Add(-a.leeway).After(c.Expiry.Time()) {
+		return errors.New("access token is expired")
+	}
+
+	if c.NotBefore != nil && now.Add(a.leeway).Before(c.NotBefore.Time()) {
+		return errors.New("access token is not valid yet")
+	}
+
+	if a.issuer != "" && c.Issuer != a.issuer {
+		return fmt.Errorf("unexpected issuer '%s'", c.Issuer)
+	}
+
+	if a.audience != "" && !c.Audience.Contains(a.audience) {
+		return errors.New("access token audience mismatch")
+	}
+
+	return nil
+}
+
+// granted checks whether any of the scopes grants the required one.
+func granted(scopes []string, required string) bool {
+	reqParts := strings.SplitN(required, scopeSeparator, 2) // nolint:gomnd
+
+	for _, s := range scopes {
+		parts := strings.SplitN(s, scopeSeparator, 2) // nolint:gomnd
+		if len(parts) != len(reqParts) {
+			continue
+		}
+
+		if (parts[0] == wildcard || parts[0] == reqParts[0]) &&
+			(parts[1] == wildcard || parts[1] == reqParts[1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func reason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
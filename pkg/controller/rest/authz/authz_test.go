@@ -0,0 +1,314 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authz
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gojwt "github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const (
+	testKID    = "key-1"
+	testIssuer = "https://auth.example.com"
+)
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, path, scope string
+	}{
+		{http.MethodGet, "/verifiable/credentials", "verifiable:read"},
+		{http.MethodPost, "/kms/keyset", "kms:write"},
+		{http.MethodDelete, "/connections/123", "connections:write"},
+		{http.MethodHead, "/vdr/did", "vdr:read"},
+	}
+
+	for _, tc := range tests {
+		r := httptest.NewRequest(tc.method, tc.path, nil)
+		require.Equal(t, tc.scope, RequiredScope(r))
+	}
+}
+
+func TestGranted(t *testing.T) {
+	require.True(t, granted([]string{"kms:write"}, "kms:write"))
+	require.True(t, granted([]string{"verifiable:read", "kms:*"}, "kms:write"))
+	require.True(t, granted([]string{"*:read"}, "kms:read"))
+	require.True(t, granted([]string{"*:*"}, "kms:write"))
+	require.False(t, granted([]string{"kms:read"}, "kms:write"))
+	require.False(t, granted([]string{"*:read"}, "kms:write"))
+	require.False(t, granted([]string{"kms"}, "kms:write"))
+	require.False(t, granted(nil, "kms:read"))
+}
+
+func TestParseJWKS(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		jwksBytes, _ := newEd25519JWKS(t)
+
+		jwks, err := ParseJWKS(jwksBytes)
+		require.NoError(t, err)
+		require.Len(t, jwks.Keys, 1)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseJWKS([]byte("{"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal JWKS")
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		_, err := ParseJWKS([]byte(`{"keys":[]}`))
+		require.EqualError(t, err, "JWKS contains no keys")
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := ParseJWKS([]byte(`{"keys":[{"kty":"unknown"}]}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal JWK at index 0")
+	})
+}
+
+func TestAuthorizer_Middleware(t *testing.T) {
+	jwksBytes, privKey := newEd25519JWKS(t)
+
+	auditLog := &bytes.Buffer{}
+
+	a, err := New(jwksBytes, WithIssuer(testIssuer), WithAudience("agent"),
+		WithAuditLogger(NewJSONAuditLogger(auditLog)))
+	require.NoError(t, err)
+
+	handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	validClaims := func(scope string) *claims {
+		return &claims{
+			Claims: jwt.Claims{
+				Issuer:   testIssuer,
+				Subject:  "ui",
+				Audience: gojwt.Audience{"agent"},
+				Expiry:   gojwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+			Scope: scope,
+		}
+	}
+
+	call := func(method, path, token string) int {
+		r := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, r)
+
+		return rr.Code
+	}
+
+	t.Run("read only token", func(t *testing.T) {
+		token := signToken(t, privKey, testKID, validClaims("verifiable:read vdr:read"))
+
+		require.Equal(t, http.StatusOK, call(http.MethodGet, "/verifiable/credentials", token))
+		require.Equal(t, http.StatusForbidden, call(http.MethodPost, "/verifiable/credential", token))
+		require.Equal(t, http.StatusForbidden, call(http.MethodPost, "/kms/keyset", token))
+	})
+
+	t.Run("scp claim", func(t *testing.T) {
+		c := validClaims("")
+		c.Scp = []string{"kms:write"}
+
+		require.Equal(t, http.StatusOK, call(http.MethodPost, "/kms/keyset", signToken(t, privKey, testKID, c)))
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", ""))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		token := signToken(t, otherKey, testKID, validClaims("*:*"))
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", token))
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		token := signToken(t, privKey, "other", validClaims("*:*"))
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", token))
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		c := validClaims("*:*")
+		c.Expiry = gojwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+		token := signToken(t, privKey, testKID, c)
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", token))
+	})
+
+	t.Run("no expiry", func(t *testing.T) {
+		c := validClaims("*:*")
+		c.Expiry = nil
+
+		token := signToken(t, privKey, testKID, c)
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", token))
+	})
+
+	t.Run("not valid yet", func(t *testing.T) {
+		c := validClaims("*:*")
+		c.NotBefore = gojwt.NewNumericDate(time.Now().Add(time.Hour))
+
+		token := signToken(t, privKey, testKID, c)
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", token))
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		c := validClaims("*:*")
+		c.Issuer = "https://other.example.com"
+
+		token := signToken(t, privKey, testKID, c)
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", token))
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		c := validClaims("*:*")
+		c.Audience = gojwt.Audience{"other"}
+
+		token := signToken(t, privKey, testKID, c)
+		require.Equal(t, http.StatusUnauthorized, call(http.MethodGet, "/verifiable/credentials", token))
+	})
+
+	t.Run("audit log", func(t *testing.T) {
+		auditLog.Reset()
+
+		token := signToken(t, privKey, testKID, validClaims("verifiable:read"))
+		require.Equal(t, http.StatusForbidden, call(http.MethodPost, "/kms/keyset", token))
+
+		event := &AuditEvent{}
+		require.NoError(t, json.Unmarshal(auditLog.Bytes(), event))
+		require.False(t, event.Allowed)
+		require.Equal(t, "ui", event.Subject)
+		require.Equal(t, "kms:write", event.Scope)
+		require.Equal(t, "/kms/keyset", event.Path)
+		require.Contains(t, event.Reason, "scope not granted")
+	})
+}
+
+func TestAuthorizer_RSA(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk, err := jose.JWKFromPublicKey(&privKey.PublicKey)
+	require.NoError(t, err)
+
+	jwk.KeyID = testKID
+
+	jwksBytes := marshalJWKS(t, jwk)
+
+	a, err := New(jwksBytes, WithLeeway(0))
+	require.NoError(t, err)
+
+	c := &claims{
+		Claims: jwt.Claims{Expiry: gojwt.NewNumericDate(time.Now().Add(time.Hour))},
+		Scope:  "kms:read",
+	}
+
+	token, err := jwt.NewSigned(c, jose.Headers{jose.HeaderKeyID: testKID}, &rs256Signer{privKey: privKey})
+	require.NoError(t, err)
+
+	tokenStr, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/kms/keys", nil)
+	r.Header.Set("Authorization", "Bearer "+tokenStr)
+
+	_, err = a.authorize(r, RequiredScope(r))
+	require.NoError(t, err)
+}
+
+func TestNew_Error(t *testing.T) {
+	_, err := New([]byte("not a JWKS"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "new authorizer")
+}
+
+func newEd25519JWKS(t *testing.T) ([]byte, ed25519.PrivateKey) {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := jose.JWKFromPublicKey(pubKey)
+	require.NoError(t, err)
+
+	jwk.KeyID = testKID
+
+	return marshalJWKS(t, jwk), privKey
+}
+
+func marshalJWKS(t *testing.T, jwk *jose.JWK) []byte {
+	t.Helper()
+
+	jwkBytes, err := jwk.MarshalJSON()
+	require.NoError(t, err)
+
+	return []byte(`{"keys":[` + string(jwkBytes) + `]}`)
+}
+
+func signToken(t *testing.T, privKey ed25519.PrivateKey, kid string, c *claims) string {
+	t.Helper()
+
+	token, err := jwt.NewSigned(c, jose.Headers{jose.HeaderKeyID: kid}, &ed25519Signer{privKey: privKey})
+	require.NoError(t, err)
+
+	tokenStr, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return tokenStr
+}
+
+type ed25519Signer struct {
+	privKey ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "EdDSA"}
+}
+
+type rs256Signer struct {
+	privKey *rsa.PrivateKey
+}
+
+func (s *rs256Signer) Sign(data []byte) ([]byte, error) {
+	hashed := crypto.SHA256.New()
+
+	_, err := hashed.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsa.SignPKCS1v15(rand.Reader, s.privKey, crypto.SHA256, hashed.Sum(nil))
+}
+
+func (s *rs256Signer) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "RS256"}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authz
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// JWKS is a JSON Web Key Set (https://tools.ietf.org/html/rfc7517#section-5).
+type JWKS struct {
+	Keys []*jose.JWK `json:"keys"`
+}
+
+// ParseJWKS parses JSON Web Key Set document.
+func ParseJWKS(jwksBytes []byte) (*JWKS, error) {
+	var raw struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+
+	if err := json.Unmarshal(jwksBytes, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal JWKS: %w", err)
+	}
+
+	if len(raw.Keys) == 0 {
+		return nil, errors.New("JWKS contains no keys")
+	}
+
+	jwks := &JWKS{}
+
+	for i, k := range raw.Keys {
+		jwk := &jose.JWK{}
+
+		if err := jwk.UnmarshalJSON(k); err != nil {
+			return nil, fmt.Errorf("unmarshal JWK at index %d: %w", i, err)
+		}
+
+		if !jwk.IsPublic() {
+			return nil, fmt.Errorf("JWK at index %d is not a public key", i)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks, nil
+}
+
+// lookup returns the key matching kid. When the token carries no kid and the set holds a single key,
+// this key is returned.
+func (s *JWKS) lookup(kid string) (*jose.JWK, error) {
+	if kid == "" {
+		if len(s.Keys) == 1 {
+			return s.Keys[0], nil
+		}
+
+		return nil, errors.New("kid header is required when JWKS contains several keys")
+	}
+
+	for _, k := range s.Keys {
+		if k.KeyID == kid {
+			return k, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key with kid '%s' not found in JWKS", kid)
+}
+
+// signatureVerifier returns jose.SignatureVerifier which checks JWS signatures against the key set.
+func (s *JWKS) signatureVerifier() jose.SignatureVerifier {
+	return jose.SignatureVerifierFunc(func(joseHeaders jose.Headers, _, signingInput, signature []byte) error {
+		alg, ok := joseHeaders.Algorithm()
+		if !ok {
+			return errors.New("alg is not defined")
+		}
+
+		kid, _ := joseHeaders.KeyID()
+
+		jwk, err := s.lookup(kid)
+		if err != nil {
+			return err
+		}
+
+		if jwk.Algorithm != "" && jwk.Algorithm != alg {
+			return fmt.Errorf("alg '%s' does not match key alg '%s'", alg, jwk.Algorithm)
+		}
+
+		return verifySignature(alg, jwk, signingInput, signature)
+	})
+}
+
+func verifySignature(alg string, jwk *jose.JWK, msg, signature []byte) error {
+	pubKey := &verifier.PublicKey{Type: jwk.Kty, JWK: jwk}
+
+	switch alg {
+	case "EdDSA":
+		return verifier.NewEd25519SignatureVerifier().Verify(pubKey, msg, signature)
+	case "ES256":
+		return verifier.NewECDSAES256SignatureVerifier().Verify(pubKey, msg, signature)
+	case "ES384":
+		return verifier.NewECDSAES384SignatureVerifier().Verify(pubKey, msg, signature)
+	case "ES512":
+		return verifier.NewECDSAES521SignatureVerifier().Verify(pubKey, msg, signature)
+	case "ES256K":
+		return verifier.NewECDSASecp256k1SignatureVerifier().Verify(pubKey, msg, signature)
+	case "RS256", "PS256":
+		rsaKey, ok := jwk.Key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key is not an RSA public key")
+		}
+
+		pubKey.Value = x509.MarshalPKCS1PublicKey(rsaKey)
+
+		if alg == "PS256" {
+			return verifier.NewRSAPS256SignatureVerifier().Verify(pubKey, msg, signature)
+		}
+
+		return jwt.VerifyRS256(pubKey, msg, signature)
+	default:
+		return fmt.Errorf("unsupported alg '%s'", alg)
+	}
+}
@@ -39,7 +39,6 @@ import (
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
-	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 func TestOperation_GetAPIHandlers(t *testing.T) {
@@ -483,9 +482,12 @@ func getHandlerWithError(t *testing.T, lookup string, f *fails) rest.Handler {
 	store := mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)}
 	connRec := &connection.Record{State: "complete", ConnectionID: "1234", ThreadID: "th1234"}
 
-	connBytes, err := json.Marshal(connRec)
+	recorder, err := connection.NewRecorder(&mockprovider.Provider{
+		ProtocolStateStorageProviderValue: &mockstore.MockStoreProvider{Store: &protocolStateStore},
+		StorageProviderValue:              &mockstore.MockStoreProvider{Store: &store},
+	})
 	require.NoError(t, err)
-	require.NoError(t, store.Put("conn_1234", connBytes, spi.Tag{Name: "conn_"}))
+	require.NoError(t, recorder.SaveConnectionRecord(connRec))
 
 	h := crypto.SHA256.New()
 	hash := h.Sum([]byte(connRec.ConnectionID))
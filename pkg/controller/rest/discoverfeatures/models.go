@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+
+// queryRequest model
+//
+// This is used for querying the protocols supported by the other party of a connection.
+//
+// swagger:parameters queryRequest
+type queryRequest struct { // nolint: unused,deadcode
+	// Params for the query
+	//
+	// in: body
+	Params discoverfeatures.QueryArgs
+}
+
+// queryResponse model
+//
+// Protocols disclosed by the other party.
+//
+// swagger:response queryResponse
+type queryResponse struct {
+	// in: body
+	discoverfeatures.QueryResponse
+}
+
+// supportedRequest model
+//
+// This is used for listing the protocols supported by this agent.
+//
+// swagger:parameters supportedRequest
+type supportedRequest struct { // nolint: unused,deadcode
+	// Query matching protocol identifier URIs, * matches any sequence of characters.
+	//
+	// in: query
+	Query string `json:"query"`
+}
+
+// supportedResponse model
+//
+// Protocols supported by this agent.
+//
+// swagger:response supportedResponse
+type supportedResponse struct {
+	// in: body
+	discoverfeatures.SupportedResponse
+}
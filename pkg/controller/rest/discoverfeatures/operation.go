@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for the discover features operations.
+const (
+	OperationID   = "/discover-features"
+	QueryPath     = OperationID + "/query"
+	SupportedPath = OperationID + "/supported"
+)
+
+// provider contains dependencies for the discover features protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Operation contains basic common operations provided by controller REST API.
+type Operation struct {
+	handlers []rest.Handler
+	command  *discoverfeatures.Command
+}
+
+// New returns new discover features rest client instance.
+func New(ctx provider) (*Operation, error) {
+	cmd, err := discoverfeatures.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create discover features command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(QueryPath, http.MethodPost, o.Query),
+		cmdutil.NewHTTPHandler(SupportedPath, http.MethodGet, o.Supported),
+	}
+}
+
+// Query swagger:route POST /discover-features/query discover-features queryRequest
+//
+// Queries the protocols supported by the other party of a connection.
+//
+// Responses:
+//
+//	default: genericError
+//	200: queryResponse
+func (o *Operation) Query(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Query, rw, req.Body)
+}
+
+// Supported swagger:route GET /discover-features/supported discover-features supportedRequest
+//
+// Lists the protocols supported by this agent.
+//
+// Responses:
+//
+//	default: genericError
+//	200: supportedResponse
+func (o *Operation) Supported(rw http.ResponseWriter, req *http.Request) {
+	reqBytes, err := json.Marshal(&discoverfeatures.SupportedArgs{Query: req.URL.Query().Get("query")})
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, discoverfeatures.InvalidRequestErrorCode, err)
+		return
+	}
+
+	rest.Execute(o.command.Supported, rw, bytes.NewReader(reqBytes))
+}
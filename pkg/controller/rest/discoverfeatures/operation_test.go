@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const pid = "https://didcomm.org/trust_ping/1.0"
+
+func TestNew(t *testing.T) {
+	t.Run("test new operation", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{}})
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 2)
+	})
+
+	t.Run("test new operation - command creation fail", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create discover features command")
+	})
+}
+
+func TestOperation_Query(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			QueryFunc: func(_, _ string, _ ...protocol.QueryOption) ([]*protocol.Protocol, error) {
+				return []*protocol.Protocol{{PID: pid}}, nil
+			},
+		}})
+		require.NoError(t, err)
+
+		buf, code := sendRequestToHandler(t, lookupHandler(t, op, QueryPath), QueryPath,
+			bytes.NewBufferString(`{"connectionID":"abc-123"}`))
+		require.Equal(t, http.StatusOK, code)
+
+		response := queryResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, []*protocol.Protocol{{PID: pid}}, response.Protocols)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			QueryErr: errors.New("query error"),
+		}})
+		require.NoError(t, err)
+
+		buf, code := sendRequestToHandler(t, lookupHandler(t, op, QueryPath), QueryPath,
+			bytes.NewBufferString(`{"connectionID":"abc-123"}`))
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, buf.String(), fmt.Sprintf(`"code":%d`, discoverfeatures.QueryErrorCode))
+	})
+}
+
+func TestOperation_Supported(t *testing.T) {
+	op, err := New(&mockprovider.Provider{ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+		SupportedFunc: func(query string) []string {
+			require.Equal(t, "https://didcomm.org/*", query)
+
+			return []string{pid}
+		},
+	}})
+	require.NoError(t, err)
+
+	buf, code := sendRequestToHandler(t, lookupHandler(t, op, SupportedPath),
+		SupportedPath+"?query=https://didcomm.org/*", nil)
+	require.Equal(t, http.StatusOK, code)
+
+	response := supportedResponse{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+	require.Equal(t, []string{pid}, response.Protocols)
+}
+
+func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
+	t.Helper()
+
+	for _, h := range op.GetRESTHandlers() {
+		if h.Path() == path {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(t *testing.T, handler rest.Handler, path string, requestBody io.Reader) (*bytes.Buffer, int) {
+	t.Helper()
+
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
@@ -8,6 +8,7 @@ package kms
 
 import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 )
 
 // createKeySetReq model
@@ -43,3 +44,25 @@ type importKeyReq struct {
 	// in: body
 	kms.JSONWebKey
 }
+
+// exportJWKSReq model
+//
+// This is used for exportJWKS request.
+//
+// swagger:parameters exportJWKSReq
+type exportJWKSReq struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ExportJWKSRequest
+}
+
+// exportJWKSRes model
+//
+// This is used for returning the exported JWK Set
+//
+// swagger:response exportJWKSRes
+type exportJWKSRes struct { // nolint: unused,deadcode
+
+	// in: body
+	jose.JWKSet
+}
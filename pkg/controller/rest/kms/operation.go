@@ -22,6 +22,8 @@ const (
 	KmsOperationID   = "/kms"
 	CreateKeySetPath = KmsOperationID + "/keyset"
 	ImportKeyPath    = KmsOperationID + "/import"
+	ExportJWKSPath   = KmsOperationID + "/jwks"
+	JWKSPath         = KmsOperationID + "/jwks"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -32,6 +34,8 @@ type provider interface {
 type kmsCommand interface {
 	CreateKeySet(rw io.Writer, req io.Reader) command.Error
 	ImportKey(rw io.Writer, req io.Reader) command.Error
+	ExportJWKS(rw io.Writer, req io.Reader) command.Error
+	GetJWKS(rw io.Writer, req io.Reader) command.Error
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -40,9 +44,10 @@ type Operation struct {
 	command  kmsCommand
 }
 
-// New returns new kms operations rest client instance.
-func New(p provider) *Operation {
-	cmd := cmdkms.New(p)
+// New returns new kms operations rest client instance. The public keys of the published keys are served
+// as a JWK Set on GET JWKSPath.
+func New(p provider, publishedKeys ...cmdkms.KeyReference) *Operation {
+	cmd := cmdkms.New(p, publishedKeys...)
 
 	o := &Operation{command: cmd}
 	o.registerHandler()
@@ -60,6 +65,8 @@ func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
+		cmdutil.NewHTTPHandler(ExportJWKSPath, http.MethodPost, o.ExportJWKS),
+		cmdutil.NewHTTPHandler(JWKSPath, http.MethodGet, o.GetJWKS),
 	}
 }
 
@@ -83,3 +90,25 @@ func (o *Operation) CreateKeySet(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ImportKey(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ImportKey, rw, req.Body)
 }
+
+// ExportJWKS swagger:route POST /kms/jwks kms exportJWKS
+//
+// Export public keys as a JWK Set.
+//
+// Responses:
+//    default: genericError
+//        200: exportJWKSRes
+func (o *Operation) ExportJWKS(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ExportJWKS, rw, req.Body)
+}
+
+// GetJWKS swagger:route GET /kms/jwks kms getJWKS
+//
+// Get the public keys published by the agent as a JWK Set.
+//
+// Responses:
+//    default: genericError
+//        200: exportJWKSRes
+func (o *Operation) GetJWKS(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetJWKS, rw, req.Body)
+}
@@ -31,7 +31,7 @@ func TestNew(t *testing.T) {
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
-		require.Equal(t, 2, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 4, len(cmd.GetRESTHandlers()))
 	})
 }
 
@@ -102,12 +102,75 @@ func TestImportKey(t *testing.T) {
 	})
 }
 
+func TestExportJWKS(t *testing.T) {
+	t.Run("test export jwks - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{}
+
+		handler := lookupHandler(t, cmd, ExportJWKSPath)
+		err := getSuccessResponseFromHandler(handler, ExportJWKSPath)
+		require.NoError(t, err)
+	})
+
+	t.Run("test export jwks - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesErr: fmt.Errorf("key not found")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, ExportJWKSPath)
+
+		req := exportJWKSReq{ExportJWKSRequest: kms.ExportJWKSRequest{
+			Keys: []kms.KeyReference{{KeyID: "k1", KeyType: "ED25519"}},
+		}}
+		reqBytes, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer(reqBytes), ExportJWKSPath)
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.ExportJWKSError, "key not found", buf.Bytes())
+	})
+}
+
+func TestGetJWKS(t *testing.T) {
+	t.Run("test get jwks - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		handler := lookupHandlerWithMethod(t, cmd, JWKSPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, JWKSPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+		require.JSONEq(t, `{"keys":[]}`, buf.String())
+	})
+
+	t.Run("test get jwks - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ExportPubKeyBytesErr: fmt.Errorf("key not found")},
+		}, kms.KeyReference{KeyID: "k1", KeyType: "ED25519"})
+
+		handler := lookupHandlerWithMethod(t, cmd, JWKSPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, JWKSPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.ExportJWKSError, "key not found", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
+	return lookupHandlerWithMethod(t, op, path, http.MethodPost)
+}
+
+func lookupHandlerWithMethod(t *testing.T, op *Operation, path, method string) rest.Handler {
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
 
 	for _, h := range handlers {
-		if h.Path() == path && h.Method() == http.MethodPost {
+		if h.Path() == path && h.Method() == method {
 			return h
 		}
 	}
@@ -181,3 +244,11 @@ func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error
 func (m *mockKMSCommand) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	return m.importKeyError
 }
+
+func (m *mockKMSCommand) ExportJWKS(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) GetJWKS(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
@@ -35,7 +35,6 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
-	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -474,16 +473,16 @@ func TestOperation_Send(t *testing.T) {
 		for _, test := range tests {
 			tc := test
 			t.Run(tc.name, func(t *testing.T) {
-				mockStore := &storage.MockStore{Store: make(map[string]storage.DBEntry)}
+				provider := &protocol.MockProvider{StoreProvider: storage.NewCustomMockStoreProvider(
+					&storage.MockStore{Store: make(map[string]storage.DBEntry)})}
+
 				if tc.testConnection != nil {
-					connBytes, err := json.Marshal(tc.testConnection)
+					recorder, err := connection.NewRecorder(provider)
 					require.NoError(t, err)
-					require.NoError(t, mockStore.Put(fmt.Sprintf("conn_%s", tc.testConnection.ConnectionID),
-						connBytes, spi.Tag{Name: "conn_"}))
+					require.NoError(t, recorder.SaveConnectionRecord(tc.testConnection))
 				}
 
-				svc, err := New(&protocol.MockProvider{StoreProvider: storage.NewCustomMockStoreProvider(mockStore)},
-					msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
+				svc, err := New(provider, msghandler.NewMockMsgServiceProvider(), webhook.NewMockWebhookNotifier())
 				require.NoError(t, err)
 				require.NotNil(t, svc)
 
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+
+// questionAnswerActionsRequest model
+//
+// Returns pending actions that have not yet to be executed or cancelled.
+//
+// swagger:parameters questionAnswerActions
+type questionAnswerActionsRequest struct{} // nolint: unused,deadcode
+
+// questionAnswerActionsResponse model
+//
+// Represents a Actions response message.
+//
+// swagger:response questionAnswerActionsResponse
+type questionAnswerActionsResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		Actions []struct{ *protocol.Action } `json:"actions"`
+	}
+}
+
+// questionAnswerSendQuestionRequest model
+//
+// This is used for operation to send a question.
+//
+// swagger:parameters questionAnswerSendQuestion
+type questionAnswerSendQuestionRequest struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// MyDID sender's did
+		// required: true
+		MyDID string `json:"my_did"`
+		// TheirDID receiver's did
+		// required: true
+		TheirDID string `json:"their_did"`
+		// Question is asked by the Questioner along with the valid responses.
+		// required: true
+		Question struct{ *protocol.Question } `json:"question"`
+	}
+}
+
+// questionAnswerSendQuestionResponse model
+//
+// Represents a SendQuestion response message.
+//
+// swagger:response questionAnswerSendQuestionResponse
+type questionAnswerSendQuestionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct {
+		// PIID Protocol instance ID. It can be used as a correlation ID
+		PIID string `json:"piid"`
+	}
+}
+
+// questionAnswerAnswerQuestionRequest model
+//
+// This is used for operation to answer a question.
+//
+// swagger:parameters questionAnswerAnswerQuestion
+type questionAnswerAnswerQuestionRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// in: body
+	Body struct {
+		// Response is one of the valid responses of the question.
+		//
+		// required: true
+		Response string `json:"response"`
+	}
+}
+
+// questionAnswerAnswerQuestionResponse model
+//
+// Represents a AnswerQuestion response message.
+//
+// swagger:response questionAnswerAnswerQuestionResponse
+type questionAnswerAnswerQuestionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// questionAnswerDeclineQuestionRequest model
+//
+// This is used for operation to decline a question.
+//
+// swagger:parameters questionAnswerDeclineQuestion
+type questionAnswerDeclineQuestionRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+
+	// Reason is an explanation of why it was declined
+	Reason string `json:"reason"`
+}
+
+// questionAnswerDeclineQuestionResponse model
+//
+// Represents a DeclineQuestion response message.
+//
+// swagger:response questionAnswerDeclineQuestionResponse
+type questionAnswerDeclineQuestionResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
+
+// questionAnswerAcceptProblemReportRequest model
+//
+// This is used for operation to accept a problem report.
+//
+// swagger:parameters questionAnswerAcceptProblemReport
+type questionAnswerAcceptProblemReportRequest struct { // nolint: unused,deadcode
+	// Protocol instance ID
+	//
+	// in: path
+	// required: true
+	PIID string `json:"piid"`
+}
+
+// questionAnswerAcceptProblemReportResponse model
+//
+// Represents a AcceptProblemReport response message.
+//
+// swagger:response questionAnswerAcceptProblemReportResponse
+type questionAnswerAcceptProblemReportResponse struct { // nolint: unused,deadcode
+	// in: body
+	Body struct{}
+}
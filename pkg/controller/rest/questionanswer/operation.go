@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+)
+
+// constants for QuestionAnswer operations.
+const (
+	OperationID         = "/questionanswer"
+	Actions             = OperationID + "/actions"
+	SendQuestion        = OperationID + "/send-question"
+	AnswerQuestion      = OperationID + "/{piid}/answer-question"
+	DeclineQuestion     = OperationID + "/{piid}/decline-question"
+	AcceptProblemReport = OperationID + "/{piid}/accept-problem-report"
+)
+
+// Operation is controller REST service controller for question answer.
+type Operation struct {
+	command  *questionanswer.Command
+	handlers []rest.Handler
+}
+
+// New returns new question answer rest client protocol instance.
+func New(ctx client.Provider, notifier command.Notifier) (*Operation, error) {
+	cmd, err := questionanswer.New(ctx, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("question answer command : %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this protocol service.
+func (c *Operation) GetRESTHandlers() []rest.Handler {
+	return c.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (c *Operation) registerHandler() {
+	// Add more protocol endpoints here to expose them as controller API endpoints
+	c.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(Actions, http.MethodGet, c.Actions),
+		cmdutil.NewHTTPHandler(SendQuestion, http.MethodPost, c.SendQuestion),
+		cmdutil.NewHTTPHandler(AnswerQuestion, http.MethodPost, c.AnswerQuestion),
+		cmdutil.NewHTTPHandler(DeclineQuestion, http.MethodPost, c.DeclineQuestion),
+		cmdutil.NewHTTPHandler(AcceptProblemReport, http.MethodPost, c.AcceptProblemReport),
+	}
+}
+
+// Actions swagger:route GET /questionanswer/actions question-answer questionAnswerActions
+//
+// Returns pending actions that have not yet to be executed or cancelled.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerActionsResponse
+func (c *Operation) Actions(rw http.ResponseWriter, _ *http.Request) {
+	rest.Execute(c.command.Actions, rw, nil)
+}
+
+// SendQuestion swagger:route POST /questionanswer/send-question question-answer questionAnswerSendQuestion
+//
+// Sends a question.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerSendQuestionResponse
+func (c *Operation) SendQuestion(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.SendQuestion, rw, req.Body)
+}
+
+// AnswerQuestion swagger:route POST /questionanswer/{piid}/answer-question question-answer questionAnswerAnswerQuestion
+//
+// Answers a question.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerAnswerQuestionResponse
+func (c *Operation) AnswerQuestion(rw http.ResponseWriter, req *http.Request) {
+	if ok, r := toCommandRequest(rw, req); ok {
+		rest.Execute(c.command.AnswerQuestion, rw, r)
+	}
+}
+
+// DeclineQuestion swagger:route POST /questionanswer/{piid}/decline-question question-answer questionAnswerDeclineQuestion
+//
+// Declines a question.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerDeclineQuestionResponse
+func (c *Operation) DeclineQuestion(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.DeclineQuestion, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"piid":%q,
+		"reason":%q
+	}`, mux.Vars(req)["piid"], req.URL.Query().Get("reason"))))
+}
+
+// AcceptProblemReport swagger:route POST /questionanswer/{piid}/accept-problem-report question-answer questionAnswerAcceptProblemReport
+//
+// Accepts a problem report.
+//
+// Responses:
+//    default: genericError
+//        200: questionAnswerAcceptProblemReportResponse
+func (c *Operation) AcceptProblemReport(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(c.command.AcceptProblemReport, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"piid":%q
+	}`, mux.Vars(req)["piid"])))
+}
+
+func toCommandRequest(rw http.ResponseWriter, req *http.Request) (bool, io.Reader) {
+	var buf bytes.Buffer
+
+	if req.Body != nil {
+		// nolint: errcheck
+		_, _ = io.Copy(&buf, req.Body)
+	}
+
+	if !isJSONMap(buf.Bytes()) {
+		rest.SendHTTPStatusError(rw,
+			http.StatusBadRequest,
+			questionanswer.InvalidRequestErrorCode,
+			errors.New("payload was not provided"),
+		)
+
+		return false, nil
+	}
+
+	ending := fmt.Sprintf(`"piid":%q}`, mux.Vars(req)["piid"])
+
+	payload := strings.TrimSpace(buf.String())
+	if payload == "{}" {
+		payload = "{" + ending
+	} else {
+		payload = buf.String()[:buf.Len()-1] + "," + ending
+	}
+
+	return true, bytes.NewBufferString(payload)
+}
+
+func isJSONMap(data []byte) bool {
+	var v struct{}
+	return isJSON(data, &v)
+}
+
+func isJSON(data []byte, v interface{}) bool {
+	return json.Unmarshal(data, &v) == nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	client "github.com/hyperledger/aries-framework-go/pkg/client/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/client/questionanswer"
+	mocknotifier "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+func provider(ctrl *gomock.Controller) client.Provider {
+	service := mocks.NewMockProtocolService(ctrl)
+	service.EXPECT().RegisterActionEvent(gomock.Any()).Return(nil)
+	service.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+	service.EXPECT().HandleInbound(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().Actions().AnyTimes()
+	service.EXPECT().ActionContinue(gomock.Any(), gomock.Any()).AnyTimes()
+	service.EXPECT().ActionStop(gomock.Any(), gomock.Any()).AnyTimes()
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().Service(gomock.Any()).Return(service, nil)
+
+	return provider
+}
+
+func TestOperation_Actions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	buf, code, err := sendRequestToHandler(handlerLookup(t, operation, Actions), nil, Actions)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, buf.String(), "actions")
+}
+
+func TestOperation_SendQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	_, code, err := sendRequestToHandler(
+		handlerLookup(t, operation, SendQuestion),
+		bytes.NewBufferString(`{"my_did":"id","their_did":"id","question":{"question_text":"Alice?"}}`),
+		SendQuestion,
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestOperation_AnswerQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("No payload", func(t *testing.T) {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		buf, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, AnswerQuestion), nil,
+			strings.Replace(AnswerQuestion, `{piid}`, "1234", 1),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Contains(t, buf.String(), "payload was not provided")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+		require.NoError(t, err)
+
+		_, code, err := sendRequestToHandler(
+			handlerLookup(t, operation, AnswerQuestion),
+			bytes.NewBufferString(`{"response":"yes"}`),
+			strings.Replace(AnswerQuestion, `{piid}`, "1234", 1),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	})
+}
+
+func TestOperation_DeclineQuestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	_, code, err := sendRequestToHandler(
+		handlerLookup(t, operation, DeclineQuestion),
+		nil,
+		strings.Replace(DeclineQuestion, `{piid}`, "1234", 1)+"?reason=reason",
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestOperation_AcceptProblemReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operation, err := New(provider(ctrl), mocknotifier.NewMockNotifier(nil))
+	require.NoError(t, err)
+
+	_, code, err := sendRequestToHandler(
+		handlerLookup(t, operation, AcceptProblemReport),
+		nil,
+		strings.Replace(AcceptProblemReport, `{piid}`, "1234", 1),
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+}
+
+func handlerLookup(t *testing.T, op *Operation, lookup string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == lookup {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
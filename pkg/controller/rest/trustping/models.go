@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+
+// pingRequest model
+//
+// This is used for checking the liveness of a connection.
+//
+// swagger:parameters pingRequest
+type pingRequest struct { // nolint: unused,deadcode
+	// Params for the ping
+	//
+	// in: body
+	Params trustping.PingArgs
+}
+
+// pingResponse model
+//
+// Round-trip time of the ping.
+//
+// swagger:response pingResponse
+type pingResponse struct {
+	// in: body
+	trustping.PingResponse
+}
@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new operation", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 1)
+	})
+
+	t.Run("test new operation - command creation fail", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping command")
+	})
+}
+
+func TestOperation_Ping(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		buf, code := sendRequestToHandler(t, op.GetRESTHandlers()[0], bytes.NewBufferString(`{"connectionID":"abc-123"}`))
+		require.Equal(t, http.StatusOK, code)
+
+		response := pingResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, time.Millisecond, response.RoundTrip)
+	})
+
+	t.Run("missing connectionID", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{}})
+		require.NoError(t, err)
+
+		buf, code := sendRequestToHandler(t, op.GetRESTHandlers()[0], bytes.NewBufferString(`{}`))
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Contains(t, buf.String(), "connectionID is mandatory")
+		require.Contains(t, buf.String(), fmt.Sprintf(`"code":%d`, trustping.PingMissingConnIDCode))
+	})
+
+	t.Run("ping error", func(t *testing.T) {
+		op, err := New(&mockprovider.Provider{ServiceValue: &mocktrustping.MockTrustPingSvc{
+			PingErr: errors.New("ping error"),
+		}})
+		require.NoError(t, err)
+
+		buf, code := sendRequestToHandler(t, op.GetRESTHandlers()[0], bytes.NewBufferString(`{"connectionID":"abc-123"}`))
+		require.Equal(t, http.StatusInternalServerError, code)
+		require.Contains(t, buf.String(), "ping error")
+		require.Contains(t, buf.String(), fmt.Sprintf(`"code":%d`, trustping.PingErrorCode))
+	})
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(t *testing.T, handler rest.Handler, requestBody io.Reader) (*bytes.Buffer, int) {
+	t.Helper()
+
+	req, err := http.NewRequest(handler.Method(), handler.Path(), requestBody)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code
+}
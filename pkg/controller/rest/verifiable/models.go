@@ -20,9 +20,20 @@ import (
 // swagger:parameters validateCredentialReq
 type validateCredentialReq struct { // nolint: unused,deadcode
 	// Params for validating the verifiable credential (pass the vc document as a string)
+	// or several verifiable credentials validated in parallel
 	//
 	// in: body
-	Params verifiable.Credential
+	Params verifiable.ValidateCredentialRequest
+}
+
+// validateCredentialRes model
+//
+// This is used for returning the validation results of several verifiable credentials.
+//
+// swagger:response validateCredentialRes
+type validateCredentialRes struct { // nolint: unused,deadcode
+	// in: body
+	verifiable.ValidateCredentialResponse
 }
 
 // emptyRes model
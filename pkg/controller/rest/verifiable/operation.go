@@ -103,11 +103,11 @@ func (o *Operation) registerHandler() {
 
 // ValidateCredential swagger:route POST /verifiable/credential/validate verifiable validateCredentialReq
 //
-// Validates the verifiable credential.
+// Validates the verifiable credential, or several verifiable credentials in parallel.
 //
 // Responses:
 //    default: genericError
-//        200: emptyRes
+//        200: validateCredentialRes
 func (o *Operation) ValidateCredential(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ValidateCredential, rw, req.Body)
 }
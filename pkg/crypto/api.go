@@ -82,6 +82,29 @@ type Crypto interface {
 	// 		signature proof in []byte
 	//		error in case of errors
 	DeriveProof(messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int, kh interface{}) ([]byte, error)
+	// BlindMessages will commit to messages (eg a holder's link secret) found at indexes among messagesCount messages
+	// to be BBS+ signed by the owner of kh key handle of a public key, without disclosing them to the signer. The
+	// commitment includes a proof of knowledge of the messages bound to nonce.
+	// returns:
+	// 		blind signature context in []byte to be sent to the signer (see BlindSign())
+	// 		blinding factor in []byte to be kept by the holder to unblind the signature (see UnblindSignature())
+	//		error in case of errors
+	BlindMessages(messages [][]byte, indexes []int, messagesCount int, nonce []byte,
+		kh interface{}) ([]byte, []byte, error)
+	// BlindSign will create a blind BBS+ signature of messagesCount messages using a matching signing primitive found
+	// in kh key handle of a private key. messages are known to the signer at indexes while the remaining ones are
+	// committed to in blindContext (see BlindMessages()) whose proof of knowledge is verified against nonce.
+	// returns:
+	// 		blind signature in []byte
+	//		error in case of errors
+	BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int, nonce []byte,
+		kh interface{}) ([]byte, error)
+	// UnblindSignature will unblind a BBS+ signature created by BlindSign() using the holder's blindingFactor returned
+	// by BlindMessages(). The unblinded signature is a BBS+ signature of all messages (both known and blinded ones).
+	// returns:
+	// 		signature in []byte
+	//		error in case of errors
+	UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error)
 }
 
 // DefKeySize is the default key size for crypto primitives.
@@ -97,6 +120,12 @@ type RecipientWrappedKey struct {
 	APV          []byte    `json:"apv,omitempty"`
 }
 
+// PrivateKey mainly to set an ephemeral key in WrapKey() (see WithEPK()).
+type PrivateKey struct {
+	PublicKey PublicKey `json:"pubKey,omitempty"`
+	D         []byte    `json:"d,omitempty"`
+}
+
 // PublicKey mainly to exchange EPK in RecipientWrappedKey.
 type PublicKey struct {
 	KID   string `json:"kid,omitempty"`
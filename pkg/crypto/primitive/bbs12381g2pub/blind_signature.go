@@ -0,0 +1,319 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	bls12381 "github.com/kilic/bls12-381"
+)
+
+// BlindSignatureContext is a commitment to the messages the holder wants signed without disclosing them to the signer
+// (eg a link secret) together with a proof of knowledge of the committed messages bound to a nonce.
+type BlindSignatureContext struct {
+	commitment *bls12381.PointG1
+	proof      *ProofG1
+}
+
+// SignatureBlinding is the blinding factor of a BlindSignatureContext commitment. It is kept by the holder to unblind
+// the signature created by the signer from the commitment.
+type SignatureBlinding struct {
+	fr *bls12381.Fr
+}
+
+// NewBlindSignatureContext commits to messages, keyed by their index in the messages to be signed, with a random
+// blinding factor and creates the proof of knowledge of the committed messages.
+func NewBlindSignatureContext(messages map[int]*SignatureMessage, pubKey *PublicKeyWithGenerators,
+	nonce *ProofNonce) (*BlindSignatureContext, *SignatureBlinding, error) {
+	if len(messages) == 0 {
+		return nil, nil, errors.New("no message to blind")
+	}
+
+	indexes := make([]int, 0, len(messages))
+	for i := range messages {
+		indexes = append(indexes, i)
+	}
+
+	indexes, err := checkIndexes(indexes, pubKey.messagesCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blinding := createRandSignatureFr()
+
+	cb := newCommitmentBuilder(len(messages) + 1)
+	cb.add(pubKey.h0, blinding)
+
+	committing := NewProverCommittingG1()
+	committing.Commit(pubKey.h0)
+
+	secrets := make([]*bls12381.Fr, 0, len(messages)+1)
+	secrets = append(secrets, blinding)
+
+	for _, i := range indexes {
+		cb.add(pubKey.h[i], messages[i].FR)
+		committing.Commit(pubKey.h[i])
+
+		secrets = append(secrets, messages[i].FR)
+	}
+
+	commitment := cb.build()
+	committed := committing.Finish()
+
+	challenge := blindChallenge(commitment, committed.bases, committed.commitment, nonce)
+
+	return &BlindSignatureContext{
+		commitment: commitment,
+		proof:      committed.GenerateProof(challenge, secrets),
+	}, &SignatureBlinding{fr: blinding}, nil
+}
+
+// Verify verifies the proof of knowledge of the messages committed at blindedIndexes.
+func (bsc *BlindSignatureContext) Verify(blindedIndexes []int, pubKey *PublicKeyWithGenerators,
+	nonce *ProofNonce) error {
+	blindedIndexes, err := checkIndexes(blindedIndexes, pubKey.messagesCount)
+	if err != nil {
+		return err
+	}
+
+	bases := make([]*bls12381.PointG1, 0, len(blindedIndexes)+1)
+	bases = append(bases, pubKey.h0)
+
+	for _, i := range blindedIndexes {
+		bases = append(bases, pubKey.h[i])
+	}
+
+	if len(bsc.proof.responses) != len(bases) {
+		return fmt.Errorf("invalid blind signature context: %d blinded messages expected", len(blindedIndexes))
+	}
+
+	challenge := blindChallenge(bsc.commitment, bases, bsc.proof.commitment, nonce)
+
+	err = bsc.proof.Verify(bases, bsc.commitment, challenge)
+	if err != nil {
+		return fmt.Errorf("invalid blind signature context: %w", err)
+	}
+
+	return nil
+}
+
+// ToBytes converts BlindSignatureContext to bytes.
+func (bsc *BlindSignatureContext) ToBytes() []byte {
+	bytes := g1.ToCompressed(bsc.commitment)
+
+	return append(bytes, bsc.proof.ToBytes()...)
+}
+
+// ParseBlindSignatureContext parses a BlindSignatureContext from bytes.
+func ParseBlindSignatureContext(bytes []byte) (*BlindSignatureContext, error) {
+	if len(bytes) < g1CompressedSize {
+		return nil, errors.New("invalid size of blind signature context")
+	}
+
+	commitment, err := g1.FromCompressed(bytes[:g1CompressedSize])
+	if err != nil {
+		return nil, fmt.Errorf("parse G1 point: %w", err)
+	}
+
+	proof, err := ParseProofG1(bytes[g1CompressedSize:])
+	if err != nil {
+		return nil, fmt.Errorf("parse G1 proof: %w", err)
+	}
+
+	return &BlindSignatureContext{
+		commitment: commitment,
+		proof:      proof,
+	}, nil
+}
+
+// ToBytes converts SignatureBlinding to bytes.
+func (sb *SignatureBlinding) ToBytes() []byte {
+	return sb.fr.RedToBytes()
+}
+
+// ParseSignatureBlinding parses a SignatureBlinding from bytes.
+func ParseSignatureBlinding(bytes []byte) (*SignatureBlinding, error) {
+	if len(bytes) != frCompressedSize {
+		return nil, errors.New("invalid size of signature blinding")
+	}
+
+	return &SignatureBlinding{fr: parseFr(bytes)}, nil
+}
+
+// BlindMessages commits to the messages found at indexes among messagesCount messages to be signed with the signer's
+// public key, so that they can be signed by BlindSign() without being disclosed to the signer.
+// It returns the blind signature context to be sent to the signer and the blinding factor to be kept to unblind the
+// signature (see UnblindSignature()).
+func (bbs *BBSG2Pub) BlindMessages(messages [][]byte, indexes []int, messagesCount int,
+	nonce, pubKeyBytes []byte) ([]byte, []byte, error) {
+	if len(messages) != len(indexes) {
+		return nil, nil, fmt.Errorf("%d messages do not match %d indexes", len(messages), len(indexes))
+	}
+
+	pubKey, err := UnmarshalPublicKey(pubKeyBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	publicKeyWithGenerators, err := pubKey.ToPublicKeyWithGenerators(messagesCount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	messagesFr := make(map[int]*SignatureMessage, len(messages))
+
+	for i, idx := range indexes {
+		if _, ok := messagesFr[idx]; ok {
+			return nil, nil, fmt.Errorf("duplicate index %d", idx)
+		}
+
+		messagesFr[idx] = ParseSignatureMessage(messages[i])
+	}
+
+	blindContext, blinding, err := NewBlindSignatureContext(messagesFr, publicKeyWithGenerators,
+		ParseProofNonce(nonce))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create blind signature context: %w", err)
+	}
+
+	return blindContext.ToBytes(), blinding.ToBytes(), nil
+}
+
+// BlindSign signs messagesCount messages using private key in compressed form: messages found at indexes are known to
+// the signer while the other ones are committed to in blindContext (see BlindMessages()). The proof of knowledge of
+// the committed messages is verified against nonce before signing.
+// The returned blind signature must be unblinded by the holder (see UnblindSignature()).
+func (bbs *BBSG2Pub) BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int,
+	nonce, privKeyBytes []byte) ([]byte, error) {
+	if len(messages) != len(indexes) {
+		return nil, fmt.Errorf("%d messages do not match %d indexes", len(messages), len(indexes))
+	}
+
+	privKey, err := UnmarshalPrivateKey(privKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal private key: %w", err)
+	}
+
+	pubKeyWithGenerators, err := privKey.PublicKey().ToPublicKeyWithGenerators(messagesCount)
+	if err != nil {
+		return nil, fmt.Errorf("build generators from public key: %w", err)
+	}
+
+	knownIndexes, err := checkIndexes(indexes, messagesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	blindSignatureContext, err := ParseBlindSignatureContext(blindContext)
+	if err != nil {
+		return nil, fmt.Errorf("parse blind signature context: %w", err)
+	}
+
+	knownMessages := make(map[int]*SignatureMessage, len(messages))
+	for i, idx := range indexes {
+		knownMessages[idx] = ParseSignatureMessage(messages[i])
+	}
+
+	blindedIndexes := make([]int, 0, messagesCount-len(knownMessages))
+
+	for i := 0; i < messagesCount; i++ {
+		if _, ok := knownMessages[i]; !ok {
+			blindedIndexes = append(blindedIndexes, i)
+		}
+	}
+
+	if len(blindedIndexes) == 0 {
+		return nil, errors.New("no blinded messages")
+	}
+
+	err = blindSignatureContext.Verify(blindedIndexes, pubKeyWithGenerators, ParseProofNonce(nonce))
+	if err != nil {
+		return nil, err
+	}
+
+	e, s := createRandSignatureFr(), createRandSignatureFr()
+	exp := bls12381.NewFr().Set(privKey.FR)
+	exp.Add(exp, e)
+	exp.Inverse(exp)
+
+	cb := newCommitmentBuilder(len(knownMessages) + 2) //nolint:gomnd
+	cb.add(g1.One(), bls12381.NewFr().RedOne())
+	cb.add(pubKeyWithGenerators.h0, s)
+
+	for _, idx := range knownIndexes {
+		cb.add(pubKeyWithGenerators.h[idx], knownMessages[idx].FR)
+	}
+
+	b := cb.build()
+	g1.Add(b, b, blindSignatureContext.commitment)
+
+	sig := g1.New()
+	g1.MulScalar(sig, b, frToRepr(exp))
+
+	signature := &Signature{
+		A: sig,
+		E: e,
+		S: s,
+	}
+
+	return signature.ToBytes()
+}
+
+// UnblindSignature unblinds a signature created by BlindSign() using the blinding factor returned by BlindMessages().
+// The unblinded signature is a regular BBS+ signature of all messages (both known and blinded ones).
+func (bbs *BBSG2Pub) UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error) {
+	signature, err := ParseSignature(blindSignature)
+	if err != nil {
+		return nil, fmt.Errorf("parse signature: %w", err)
+	}
+
+	blinding, err := ParseSignatureBlinding(blindingFactor)
+	if err != nil {
+		return nil, fmt.Errorf("parse blinding factor: %w", err)
+	}
+
+	signature.S.Add(signature.S, blinding.fr)
+
+	return signature.ToBytes()
+}
+
+// checkIndexes checks indexes are unique indexes of messagesCount messages and returns them sorted.
+func checkIndexes(indexes []int, messagesCount int) ([]int, error) {
+	sorted := make([]int, len(indexes))
+	copy(sorted, indexes)
+	sort.Ints(sorted)
+
+	for i, idx := range sorted {
+		if idx < 0 || idx >= messagesCount {
+			return nil, fmt.Errorf("index %d is out of range of %d messages", idx, messagesCount)
+		}
+
+		if i > 0 && sorted[i-1] == idx {
+			return nil, fmt.Errorf("duplicate index %d", idx)
+		}
+	}
+
+	return sorted, nil
+}
+
+func blindChallenge(commitment *bls12381.PointG1, bases []*bls12381.PointG1, proofCommitment *bls12381.PointG1,
+	nonce *ProofNonce) *bls12381.Fr {
+	bytes := make([]byte, 0, (len(bases)+2)*g1UncompressedSize+frCompressedSize) //nolint:gomnd
+
+	bytes = append(bytes, g1.ToUncompressed(commitment)...)
+
+	for _, base := range bases {
+		bytes = append(bytes, g1.ToUncompressed(base)...)
+	}
+
+	bytes = append(bytes, g1.ToUncompressed(proofCommitment)...)
+	bytes = append(bytes, nonce.ToBytes()...)
+
+	return frFromOKM(bytes)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbs12381g2pub_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+)
+
+func TestBBSG2Pub_BlindSign(t *testing.T) {
+	pubKey, privKey, err := generateKeyPairRandom()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	linkSecret := []byte("link secret")
+	messagesBytes := [][]byte{
+		[]byte("message1"),
+		[]byte("message2"),
+		[]byte("message3"),
+		linkSecret,
+	}
+	knownIndexes := []int{0, 1, 2}
+	messagesCount := len(messagesBytes)
+	nonce := []byte("nonce")
+
+	bls := bbs12381g2pub.New()
+
+	blindContext, blindingFactor, err := bls.BlindMessages([][]byte{linkSecret}, []int{3}, messagesCount, nonce,
+		pubKeyBytes)
+	require.NoError(t, err)
+
+	blindSignature, err := bls.BlindSign(blindContext, messagesBytes[:3], knownIndexes, messagesCount, nonce,
+		privKeyBytes)
+	require.NoError(t, err)
+
+	t.Run("unblinded signature is a signature of all messages", func(t *testing.T) {
+		require.Error(t, bls.Verify(messagesBytes, blindSignature, pubKeyBytes))
+
+		signature, err := bls.UnblindSignature(blindSignature, blindingFactor)
+		require.NoError(t, err)
+		require.NoError(t, bls.Verify(messagesBytes, signature, pubKeyBytes))
+
+		otherMessagesBytes := [][]byte{messagesBytes[0], messagesBytes[1], messagesBytes[2], []byte("other secret")}
+		require.Error(t, bls.Verify(otherMessagesBytes, signature, pubKeyBytes))
+	})
+
+	t.Run("derive proof requires the blinded messages", func(t *testing.T) {
+		signature, err := bls.UnblindSignature(blindSignature, blindingFactor)
+		require.NoError(t, err)
+
+		proofNonce := []byte("proof nonce")
+
+		proof, err := bls.DeriveProof(messagesBytes, signature, proofNonce, pubKeyBytes, []int{0, 2})
+		require.NoError(t, err)
+		require.NoError(t, bls.VerifyProof([][]byte{messagesBytes[0], messagesBytes[2]}, proof, proofNonce,
+			pubKeyBytes))
+
+		otherMessagesBytes := [][]byte{messagesBytes[0], messagesBytes[1], messagesBytes[2], []byte("other secret")}
+		_, err = bls.DeriveProof(otherMessagesBytes, signature, proofNonce, pubKeyBytes, []int{0, 2})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "init proof of knowledge signature")
+	})
+
+	t.Run("blind sign with unordered known indexes", func(t *testing.T) {
+		unordered, err := bls.BlindSign(blindContext, [][]byte{messagesBytes[2], messagesBytes[0], messagesBytes[1]},
+			[]int{2, 0, 1}, messagesCount, nonce, privKeyBytes)
+		require.NoError(t, err)
+
+		signature, err := bls.UnblindSignature(unordered, blindingFactor)
+		require.NoError(t, err)
+		require.NoError(t, bls.Verify(messagesBytes, signature, pubKeyBytes))
+	})
+
+	t.Run("blind signature context bound to nonce", func(t *testing.T) {
+		_, err := bls.BlindSign(blindContext, messagesBytes[:3], knownIndexes, messagesCount, []byte("other nonce"),
+			privKeyBytes)
+		require.EqualError(t, err, "invalid blind signature context: contribution is not zero")
+	})
+
+	t.Run("blind signature context bound to blinded indexes", func(t *testing.T) {
+		_, err := bls.BlindSign(blindContext, messagesBytes[1:], []int{1, 2, 3}, messagesCount, nonce, privKeyBytes)
+		require.EqualError(t, err, "invalid blind signature context: contribution is not zero")
+
+		_, err = bls.BlindSign(blindContext, messagesBytes[:2], []int{0, 1}, messagesCount, nonce, privKeyBytes)
+		require.EqualError(t, err, "invalid blind signature context: 2 blinded messages expected")
+	})
+
+	t.Run("blind signature context bound to messages count", func(t *testing.T) {
+		_, err := bls.BlindSign(blindContext, [][]byte{messagesBytes[0], messagesBytes[1], messagesBytes[2],
+			[]byte("message5")}, []int{0, 1, 2, 4}, messagesCount+1, nonce, privKeyBytes)
+		require.EqualError(t, err, "invalid blind signature context: contribution is not zero")
+	})
+
+	t.Run("invalid blind sign input", func(t *testing.T) {
+		_, err := bls.BlindSign(blindContext, messagesBytes, knownIndexes, messagesCount, nonce, privKeyBytes)
+		require.EqualError(t, err, "4 messages do not match 3 indexes")
+
+		_, err = bls.BlindSign(blindContext, messagesBytes, []int{0, 1, 2, 3}, messagesCount, nonce, privKeyBytes)
+		require.EqualError(t, err, "no blinded messages")
+
+		_, err = bls.BlindSign(blindContext, messagesBytes[:3], []int{0, 1, 4}, messagesCount, nonce, privKeyBytes)
+		require.EqualError(t, err, "index 4 is out of range of 4 messages")
+
+		_, err = bls.BlindSign(blindContext, messagesBytes[:3], []int{0, 1, 1}, messagesCount, nonce, privKeyBytes)
+		require.EqualError(t, err, "duplicate index 1")
+
+		_, err = bls.BlindSign([]byte("invalid"), messagesBytes[:3], knownIndexes, messagesCount, nonce,
+			privKeyBytes)
+		require.EqualError(t, err, "parse blind signature context: invalid size of blind signature context")
+
+		_, err = bls.BlindSign(blindContext, messagesBytes[:3], knownIndexes, messagesCount, nonce,
+			[]byte("invalid"))
+		require.EqualError(t, err, "unmarshal private key: invalid size of private key")
+	})
+
+	t.Run("invalid blind messages input", func(t *testing.T) {
+		_, _, err := bls.BlindMessages([][]byte{linkSecret}, []int{3, 4}, messagesCount, nonce, pubKeyBytes)
+		require.EqualError(t, err, "1 messages do not match 2 indexes")
+
+		_, _, err = bls.BlindMessages(nil, nil, messagesCount, nonce, pubKeyBytes)
+		require.EqualError(t, err, "create blind signature context: no message to blind")
+
+		_, _, err = bls.BlindMessages([][]byte{linkSecret}, []int{4}, messagesCount, nonce, pubKeyBytes)
+		require.EqualError(t, err, "create blind signature context: index 4 is out of range of 4 messages")
+
+		_, _, err = bls.BlindMessages([][]byte{linkSecret, linkSecret}, []int{3, 3}, messagesCount, nonce,
+			pubKeyBytes)
+		require.EqualError(t, err, "duplicate index 3")
+
+		_, _, err = bls.BlindMessages([][]byte{linkSecret}, []int{3}, messagesCount, nonce, []byte("invalid"))
+		require.EqualError(t, err, "parse public key: invalid size of public key")
+	})
+
+	t.Run("invalid unblind signature input", func(t *testing.T) {
+		_, err := bls.UnblindSignature([]byte("invalid"), blindingFactor)
+		require.EqualError(t, err, "parse signature: invalid size of signature")
+
+		_, err = bls.UnblindSignature(blindSignature, []byte("invalid"))
+		require.EqualError(t, err, "parse blinding factor: invalid size of signature blinding")
+	})
+}
@@ -13,6 +13,7 @@ package tinkcrypto
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/tink/go/aead"
 	aeadsubtle "github.com/google/tink/go/aead/subtle"
@@ -22,7 +23,9 @@ import (
 	"github.com/google/tink/go/signature"
 	"golang.org/x/crypto/chacha20poly1305"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 )
 
@@ -38,6 +41,9 @@ const (
 
 	nistPECDHKWPrivateKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey"
 	x25519ECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPrivateKey"
+
+	// bbsSignatureLen is the length of a BBS+ signature without Tink's output prefix.
+	bbsSignatureLen = 112
 )
 
 var errBadKeyHandleFormat = errors.New("bad key handle format")
@@ -60,6 +66,8 @@ func New() (*Crypto, error) {
 
 // Encrypt will encrypt msg using the implementation's corresponding encryption key and primitive in kh of a public key.
 func (t *Crypto) Encrypt(msg, aad []byte, kh interface{}) ([]byte, []byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "encrypt")
+
 	keyHandle, ok := kh.(*keyset.Handle)
 	if !ok {
 		return nil, nil, errBadKeyHandleFormat
@@ -107,6 +115,8 @@ func nonceSize(ps *primitiveset.PrimitiveSet) int {
 // Decrypt will decrypt cipher using the implementation's corresponding encryption key referenced by kh of
 // a private key.
 func (t *Crypto) Decrypt(cipher, aad, nonce []byte, kh interface{}) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "decrypt")
+
 	keyHandle, ok := kh.(*keyset.Handle)
 	if !ok {
 		return nil, errBadKeyHandleFormat
@@ -138,6 +148,8 @@ func (t *Crypto) Decrypt(cipher, aad, nonce []byte, kh interface{}) ([]byte, err
 
 // Sign will sign msg using the implementation's corresponding signing key referenced by kh of a private key.
 func (t *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "sign")
+
 	keyHandle, ok := kh.(*keyset.Handle)
 	if !ok {
 		return nil, errBadKeyHandleFormat
@@ -159,6 +171,8 @@ func (t *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
 // Verify will verify sig signature of msg using the implementation's corresponding signing key referenced by kh of
 // a public key.
 func (t *Crypto) Verify(sig, msg []byte, kh interface{}) error {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "verify")
+
 	keyHandle, ok := kh.(*keyset.Handle)
 	if !ok {
 		return errBadKeyHandleFormat
@@ -180,6 +194,8 @@ func (t *Crypto) Verify(sig, msg []byte, kh interface{}) error {
 // ComputeMAC computes message authentication code (MAC) for code data
 // using a matching MAC primitive in kh key handle.
 func (t *Crypto) ComputeMAC(data []byte, kh interface{}) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "compute_mac")
+
 	keyHandle, ok := kh.(*keyset.Handle)
 	if !ok {
 		return nil, errBadKeyHandleFormat
@@ -196,6 +212,8 @@ func (t *Crypto) ComputeMAC(data []byte, kh interface{}) ([]byte, error) {
 // VerifyMAC determines if mac is a correct authentication code (MAC) for data
 // using a matching MAC primitive in kh key handle and returns nil if so, otherwise it returns an error.
 func (t *Crypto) VerifyMAC(macBytes, data []byte, kh interface{}) error {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "verify_mac")
+
 	keyHandle, ok := kh.(*keyset.Handle)
 	if !ok {
 		return errBadKeyHandleFormat
@@ -217,9 +235,14 @@ func (t *Crypto) VerifyMAC(macBytes, data []byte, kh interface{}) error {
 //  - KDF (based on recPubKey.Curve): `Concat KDF` as per https://tools.ietf.org/html/rfc7518#section-4.6 (for recPubKey
 //    with NIST P curves) or `Curve25519`+`Concat KDF` as per https://tools.ietf.org/html/rfc7748#section-6.1 (for
 //    recPubKey with X25519 curve).
+//  - Tag binding: `ECDH-1PU` derivation includes the content encryption tag set with crypto.WithTag() in the KDF's
+//    SuppPubInfo as required when the content is encrypted with AES-CBC-HMAC (A128CBC-HS256 or A256CBC-HS512).
+//    crypto.WithEPK() can be used to set the ephemeral key shared by all recipients of the same message.
 // returns the resulting key wrapping info as *composite.RecipientWrappedKey or error in case of wrapping failure.
 func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
 	wrapKeyOpts ...cryptoapi.WrapKeyOpts) (*cryptoapi.RecipientWrappedKey, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "wrap_key")
+
 	if recPubKey == nil {
 		return nil, errors.New("wrapKey: recipient public key is required")
 	}
@@ -230,7 +253,8 @@ func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
 		opt(pOpts)
 	}
 
-	wk, err := t.deriveKEKAndWrap(cek, apu, apv, pOpts.SenderKey(), recPubKey, pOpts.UseXC20PKW())
+	wk, err := t.deriveKEKAndWrap(cek, apu, apv, pOpts.Tag(), pOpts.SenderKey(), recPubKey, pOpts.EPK(),
+		pOpts.UseXC20PKW())
 	if err != nil {
 		return nil, fmt.Errorf("wrapKey: %w", err)
 	}
@@ -257,8 +281,11 @@ func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
 // 3- the ephemeral key in recWK.EPK must have the same KeyType as the recipientKH and the same Curve for NIST P
 //    curved keys. Unwrapping a key with non matching types/curves will result in unwrapping failure.
 // 4- recipientKH must contain the private key since unwrapping is usually done on the recipient side.
+// 5- if the crypto.WithTag() option was used in WrapKey(), then the same tag must be set here as well.
 func (t *Crypto) UnwrapKey(recWK *cryptoapi.RecipientWrappedKey, recipientKH interface{},
 	wrapKeyOpts ...cryptoapi.WrapKeyOpts) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "unwrap_key")
+
 	if recWK == nil {
 		return nil, fmt.Errorf("unwrapKey: RecipientWrappedKey is empty")
 	}
@@ -269,8 +296,8 @@ func (t *Crypto) UnwrapKey(recWK *cryptoapi.RecipientWrappedKey, recipientKH int
 		opt(pOpts)
 	}
 
-	key, err := t.deriveKEKAndUnwrap(recWK.Alg, recWK.EncryptedCEK, recWK.APU, recWK.APV, &recWK.EPK, pOpts.SenderKey(),
-		recipientKH)
+	key, err := t.deriveKEKAndUnwrap(recWK.Alg, recWK.EncryptedCEK, recWK.APU, recWK.APV, pOpts.Tag(), &recWK.EPK,
+		pOpts.SenderKey(), recipientKH)
 	if err != nil {
 		return nil, fmt.Errorf("unwrapKey: %w", err)
 	}
@@ -283,6 +310,8 @@ func (t *Crypto) UnwrapKey(recWK *cryptoapi.RecipientWrappedKey, recipientKH int
 // 		signature in []byte
 //		error in case of errors
 func (t *Crypto) SignMulti(messages [][]byte, signerKH interface{}) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "sign_multi")
+
 	keyHandle, ok := signerKH.(*keyset.Handle)
 	if !ok {
 		return nil, errBadKeyHandleFormat
@@ -305,6 +334,8 @@ func (t *Crypto) SignMulti(messages [][]byte, signerKH interface{}) ([]byte, err
 // returns:
 // 		error in case of errors or nil if signature verification was successful
 func (t *Crypto) VerifyMulti(messages [][]byte, bbsSignature []byte, signerPubKH interface{}) error {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "verify_multi")
+
 	keyHandle, ok := signerPubKH.(*keyset.Handle)
 	if !ok {
 		return errBadKeyHandleFormat
@@ -328,6 +359,8 @@ func (t *Crypto) VerifyMulti(messages [][]byte, bbsSignature []byte, signerPubKH
 // returns:
 // 		error in case of errors or nil if signature proof verification was successful
 func (t *Crypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, signerPubKH interface{}) error {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "verify_proof")
+
 	keyHandle, ok := signerPubKH.(*keyset.Handle)
 	if !ok {
 		return errBadKeyHandleFormat
@@ -353,6 +386,8 @@ func (t *Crypto) VerifyProof(revealedMessages [][]byte, proof, nonce []byte, sig
 //		error in case of errors
 func (t *Crypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, revealedIndexes []int,
 	signerPubKH interface{}) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "derive_proof")
+
 	keyHandle, ok := signerPubKH.(*keyset.Handle)
 	if !ok {
 		return nil, errBadKeyHandleFormat
@@ -370,3 +405,84 @@ func (t *Crypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, reve
 
 	return proof, nil
 }
+
+// BlindMessages will commit to messages (eg a holder's link secret) found at indexes among messagesCount messages to
+// be BBS+ signed with the private key matching the signer's public key in signerPubKH handle.
+// returns:
+// 		blind signature context in []byte
+// 		blinding factor in []byte
+//		error in case of errors
+func (t *Crypto) BlindMessages(messages [][]byte, indexes []int, messagesCount int, nonce []byte,
+	signerPubKH interface{}) ([]byte, []byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "blind_messages")
+
+	keyHandle, ok := signerPubKH.(*keyset.Handle)
+	if !ok {
+		return nil, nil, errBadKeyHandleFormat
+	}
+
+	verifier, err := bbs.NewVerifier(keyHandle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create new BBS+ verifier: %w", err)
+	}
+
+	blindContext, blindingFactor, err := verifier.BlindMessages(messages, indexes, messagesCount, nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("BBS+ blind messages: %w", err)
+	}
+
+	return blindContext, blindingFactor, nil
+}
+
+// BlindSign will create a blind BBS+ signature of messagesCount messages using the signer's private key in signerKH
+// handle. messages are known to the signer at indexes while the remaining ones are committed to in blindContext.
+// returns:
+// 		blind signature in []byte
+//		error in case of errors
+func (t *Crypto) BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int, nonce []byte,
+	signerKH interface{}) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "blind_sign")
+
+	keyHandle, ok := signerKH.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	signer, err := bbs.NewSigner(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new BBS+ signer: %w", err)
+	}
+
+	s, err := signer.BlindSign(blindContext, messages, indexes, messagesCount, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("BBS+ blind sign msg: %w", err)
+	}
+
+	return s, nil
+}
+
+// UnblindSignature will unblind a BBS+ signature created by BlindSign() using the holder's blindingFactor. The output
+// prefix of the blind signature, if any, is kept in the unblinded signature.
+// returns:
+// 		signature in []byte
+//		error in case of errors
+func (t *Crypto) UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error) {
+	defer metrics.CryptoOperationDuration.ObserveSince(time.Now(), "unblind_signature")
+
+	if len(blindSignature) < bbsSignatureLen {
+		return nil, errors.New("BBS+ unblind signature: invalid size of signature")
+	}
+
+	prefixLen := len(blindSignature) - bbsSignatureLen
+
+	s, err := bbs12381g2pub.New().UnblindSignature(blindSignature[prefixLen:], blindingFactor)
+	if err != nil {
+		return nil, fmt.Errorf("BBS+ unblind signature: %w", err)
+	}
+
+	ret := make([]byte, 0, len(blindSignature))
+	ret = append(ret, blindSignature[:prefixLen]...)
+	ret = append(ret, s...)
+
+	return ret, nil
+}
@@ -23,7 +23,9 @@ import (
 	"github.com/google/tink/go/subtle/random"
 	"github.com/stretchr/testify/require"
 	chacha "golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
@@ -352,6 +354,13 @@ func TestCrypto_ECDHES_Wrap_Unwrap_ForAllKeyTypes(t *testing.T) {
 			keyType:  ecdhpb.KeyType_EC.String(),
 			keyCurve: elliptic.P521().Params().Name,
 		},
+		{
+			tcName:   "key wrap using ECDH-ES with secp256k1 key and A256GCM kw",
+			keyTempl: ecdh.Secp256k1ECDHKWKeyTemplate(),
+			kwAlg:    ECDHESA256KWAlg,
+			keyType:  ecdhpb.KeyType_EC.String(),
+			keyCurve: "SECP256K1",
+		},
 		{
 			tcName:   "key wrap using ECDH-ES with X25519 key and A256GCM kw",
 			keyTempl: ecdh.X25519ECDHKWKeyTemplate(),
@@ -383,6 +392,14 @@ func TestCrypto_ECDHES_Wrap_Unwrap_ForAllKeyTypes(t *testing.T) {
 			keyCurve: elliptic.P521().Params().Name,
 			useXC20P: true,
 		},
+		{
+			tcName:   "key wrap using ECDH-ES with secp256k1 key and XC20P kw",
+			keyTempl: ecdh.Secp256k1ECDHKWKeyTemplate(),
+			kwAlg:    ECDHESXC20PKWAlg,
+			keyType:  ecdhpb.KeyType_EC.String(),
+			keyCurve: "SECP256K1",
+			useXC20P: true,
+		},
 		{
 			tcName:   "key wrap using ECDH-ES with X25519 key and XC20P kw",
 			keyTempl: ecdh.X25519ECDHKWKeyTemplate(),
@@ -415,6 +432,14 @@ func TestCrypto_ECDHES_Wrap_Unwrap_ForAllKeyTypes(t *testing.T) {
 			keyCurve: elliptic.P521().Params().Name,
 			senderKT: ecdh.NISTP521ECDHKWKeyTemplate(),
 		},
+		{
+			tcName:   "key wrap using ECDH-1PU with secp256k1 key and A256GCM kw",
+			keyTempl: ecdh.Secp256k1ECDHKWKeyTemplate(),
+			kwAlg:    ECDH1PUA256KWAlg,
+			keyType:  ecdhpb.KeyType_EC.String(),
+			keyCurve: "SECP256K1",
+			senderKT: ecdh.Secp256k1ECDHKWKeyTemplate(),
+		},
 		{
 			tcName:   "key wrap using ECDH-1PU with X25519 key and A256GCM kw",
 			keyTempl: ecdh.X25519ECDHKWKeyTemplate(),
@@ -450,6 +475,15 @@ func TestCrypto_ECDHES_Wrap_Unwrap_ForAllKeyTypes(t *testing.T) {
 			senderKT: ecdh.NISTP521ECDHKWKeyTemplate(),
 			useXC20P: true,
 		},
+		{
+			tcName:   "key wrap using ECDH-1PU with secp256k1 key and XC20P kw",
+			keyTempl: ecdh.Secp256k1ECDHKWKeyTemplate(),
+			kwAlg:    ECDH1PUXC20PKWAlg,
+			keyType:  ecdhpb.KeyType_EC.String(),
+			keyCurve: "SECP256K1",
+			senderKT: ecdh.Secp256k1ECDHKWKeyTemplate(),
+			useXC20P: true,
+		},
 		{
 			tcName:   "key wrap using ECDH-1PU with X25519 key and XC20P kw",
 			keyTempl: ecdh.X25519ECDHKWKeyTemplate(),
@@ -628,6 +662,113 @@ func TestCrypto_ECDH1PU_Wrap_Unwrap_Key_Using_CryptoPubKey_as_SenderKey(t *testi
 	require.EqualValues(t, cek, uCEK)
 }
 
+func TestCrypto_ECDH1PU_Wrap_Unwrap_Key_With_Tag(t *testing.T) {
+	ecEPK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	okpEPKPriv := random.GetRandomBytes(uint32(curve25519.ScalarSize))
+	okpEPKPub, err := curve25519.X25519(okpEPKPriv, curve25519.Basepoint)
+	require.NoError(t, err)
+
+	tests := []struct {
+		tcName   string
+		keyTempl *tinkpb.KeyTemplate
+		epk      *crypto.PrivateKey
+		useXC20P bool
+	}{
+		{
+			tcName:   "tag bound key wrap using ECDH-1PU with NIST P-256 key and A256GCM kw",
+			keyTempl: ecdh.NISTP256ECDHKWKeyTemplate(),
+			epk: &crypto.PrivateKey{
+				PublicKey: crypto.PublicKey{
+					X:     ecEPK.X.Bytes(),
+					Y:     ecEPK.Y.Bytes(),
+					Curve: elliptic.P256().Params().Name,
+					Type:  ecdhpb.KeyType_EC.String(),
+				},
+				D: ecEPK.D.Bytes(),
+			},
+		},
+		{
+			tcName:   "tag bound key wrap using ECDH-1PU with X25519 key and XC20P kw",
+			keyTempl: ecdh.X25519ECDHKWKeyTemplate(),
+			epk: &crypto.PrivateKey{
+				PublicKey: crypto.PublicKey{
+					X:     okpEPKPub,
+					Curve: "X25519",
+					Type:  ecdhpb.KeyType_OKP.String(),
+				},
+				D: okpEPKPriv,
+			},
+			useXC20P: true,
+		},
+	}
+
+	c, err := New()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.tcName, func(t *testing.T) {
+			recipientKeyHandle, err := keyset.NewHandle(tc.keyTempl)
+			require.NoError(t, err)
+
+			recipientKey, err := keyio.ExtractPrimaryPublicKey(recipientKeyHandle)
+			require.NoError(t, err)
+
+			senderKH, err := keyset.NewHandle(tc.keyTempl)
+			require.NoError(t, err)
+
+			senderPubKH, err := senderKH.Public()
+			require.NoError(t, err)
+
+			cek := random.GetRandomBytes(uint32(crypto.DefKeySize))
+			apu := random.GetRandomBytes(uint32(10))
+			apv := random.GetRandomBytes(uint32(10))
+			tag := random.GetRandomBytes(uint32(32))
+
+			wrapOpts := []crypto.WrapKeyOpts{crypto.WithSender(senderKH), crypto.WithTag(tag), crypto.WithEPK(tc.epk)}
+			if tc.useXC20P {
+				wrapOpts = append(wrapOpts, crypto.WithXC20PKW())
+			}
+
+			wrappedKey, err := c.WrapKey(cek, apu, apv, recipientKey, wrapOpts...)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.epk.PublicKey.X, wrappedKey.EPK.X)
+			require.EqualValues(t, tc.epk.PublicKey.Y, wrappedKey.EPK.Y)
+
+			uCEK, err := c.UnwrapKey(wrappedKey, recipientKeyHandle, crypto.WithSender(senderPubKH),
+				crypto.WithTag(tag))
+			require.NoError(t, err)
+			require.EqualValues(t, cek, uCEK)
+
+			// unwrapping without the tag or with a different tag must fail.
+			_, err = c.UnwrapKey(wrappedKey, recipientKeyHandle, crypto.WithSender(senderPubKH))
+			require.Error(t, err)
+
+			_, err = c.UnwrapKey(wrappedKey, recipientKeyHandle, crypto.WithSender(senderPubKH),
+				crypto.WithTag(random.GetRandomBytes(uint32(32))))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("ECDH-1PU key wrap with EPK on a different curve fails", func(t *testing.T) {
+		recipientKeyHandle, err := keyset.NewHandle(ecdh.NISTP384ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		recipientKey, err := keyio.ExtractPrimaryPublicKey(recipientKeyHandle)
+		require.NoError(t, err)
+
+		senderKH, err := keyset.NewHandle(ecdh.NISTP384ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.WrapKey(random.GetRandomBytes(uint32(crypto.DefKeySize)), nil, nil, recipientKey,
+			crypto.WithSender(senderKH), crypto.WithEPK(tests[0].epk))
+		require.EqualError(t, err, "wrapKey: deriveKEKAndWrap: error ECDH-1PU kek derivation: derive1PUKEK: EC key"+
+			" derivation error derive1PUWithECKey: convertEPKEC: recipient and ephemeral keys are not on the same curve")
+	})
+}
+
 func TestBBSCrypto_SignVerify_DeriveProofVerifyProof(t *testing.T) {
 	c := Crypto{}
 	msg := [][]byte{
@@ -727,3 +868,144 @@ func TestBBSCrypto_SignVerify_DeriveProofVerifyProof(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestBBSCrypto_BlindSign(t *testing.T) {
+	c := Crypto{}
+	linkSecret := []byte("link secret")
+	msg := [][]byte{[]byte(testMessage + "0"), []byte(testMessage + "1"), []byte(testMessage + "2"), linkSecret}
+	knownIndexes := []int{0, 1, 2}
+	linkSecretIndex := []int{3}
+	nonce := []byte("nonce")
+
+	kh, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+	require.NoError(t, err)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	badKH, err := keyset.NewHandle(aead.KMSEnvelopeAEADKeyTemplate("babdUrl", nil))
+	require.NoError(t, err)
+
+	blindContext, blindingFactor, err := c.BlindMessages([][]byte{linkSecret}, linkSecretIndex, len(msg), nonce, pubKH)
+	require.NoError(t, err)
+
+	blindSignature, err := c.BlindSign(blindContext, msg[:3], knownIndexes, len(msg), nonce, kh)
+	require.NoError(t, err)
+
+	s, err := c.UnblindSignature(blindSignature, blindingFactor)
+	require.NoError(t, err)
+
+	require.NoError(t, c.VerifyMulti(msg, s, pubKH))
+
+	t.Run("derive proof hiding the link secret", func(t *testing.T) {
+		proofNonce := []byte("proof nonce")
+
+		proof, err := c.DeriveProof(msg, s, proofNonce, []int{0, 2}, pubKH)
+		require.NoError(t, err)
+
+		require.NoError(t, c.VerifyProof([][]byte{msg[0], msg[2]}, proof, proofNonce, pubKH))
+
+		// a proof can't be derived without the link secret.
+		_, err = c.DeriveProof([][]byte{msg[0], msg[1], msg[2], []byte("other secret")}, s, proofNonce,
+			[]int{0, 2}, pubKH)
+		require.EqualError(t, err, "verify proof msg: bbs_verifier_factory: invalid signature proof")
+	})
+
+	t.Run("blind messages failures", func(t *testing.T) {
+		_, _, err = c.BlindMessages([][]byte{linkSecret}, linkSecretIndex, len(msg), nonce, nil)
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+
+		_, _, err = c.BlindMessages([][]byte{linkSecret}, linkSecretIndex, len(msg), nonce, badKH)
+		require.Error(t, err)
+
+		_, _, err = c.BlindMessages([][]byte{linkSecret}, []int{4}, len(msg), nonce, pubKH)
+		require.EqualError(t, err, "BBS+ blind messages: create blind signature context: index 4 is out of "+
+			"range of 4 messages")
+	})
+
+	t.Run("blind sign failures", func(t *testing.T) {
+		_, err = c.BlindSign(blindContext, msg[:3], knownIndexes, len(msg), nonce, "bad key type")
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+
+		_, err = c.BlindSign(blindContext, msg[:3], knownIndexes, len(msg), nonce, badKH)
+		require.Error(t, err)
+
+		_, err = c.BlindSign(blindContext, msg[:3], knownIndexes, len(msg), []byte("other nonce"), kh)
+		require.EqualError(t, err, "BBS+ blind sign msg: invalid blind signature context: contribution is not zero")
+	})
+
+	t.Run("unblind signature failures", func(t *testing.T) {
+		_, err = c.UnblindSignature([]byte("invalid"), blindingFactor)
+		require.EqualError(t, err, "BBS+ unblind signature: invalid size of signature")
+
+		_, err = c.UnblindSignature(blindSignature, []byte("invalid"))
+		require.EqualError(t, err, "BBS+ unblind signature: parse blinding factor: invalid size of signature "+
+			"blinding")
+	})
+}
+
+// operationRecorder is a metrics.Provider recording the operation label of the observed histograms.
+type operationRecorder struct {
+	operations []string
+}
+
+func (r *operationRecorder) Counter(string, string, ...string) metrics.Counter {
+	return r
+}
+
+func (r *operationRecorder) Histogram(name, _ string, _ []float64, _ ...string) metrics.Histogram {
+	if name != "aries_crypto_operation_duration_seconds" {
+		return r
+	}
+
+	return operationHistogram{r}
+}
+
+func (r *operationRecorder) Inc(...string) {}
+
+func (r *operationRecorder) Observe(float64, ...string) {}
+
+type operationHistogram struct {
+	*operationRecorder
+}
+
+func (h operationHistogram) Observe(_ float64, labelValues ...string) {
+	h.operations = append(h.operations, labelValues...)
+}
+
+func TestCrypto_OperationMetrics(t *testing.T) {
+	recorder := &operationRecorder{}
+
+	metrics.Initialize(recorder)
+	defer metrics.Initialize(nil)
+
+	c := Crypto{}
+
+	tests := []struct {
+		operation string
+		call      func()
+	}{
+		{"encrypt", func() { c.Encrypt(nil, nil, "bad key") }},                      //nolint:errcheck
+		{"decrypt", func() { c.Decrypt(nil, nil, nil, "bad key") }},                 //nolint:errcheck
+		{"sign", func() { c.Sign(nil, "bad key") }},                                 //nolint:errcheck
+		{"verify", func() { c.Verify(nil, nil, "bad key") }},                        //nolint:errcheck
+		{"compute_mac", func() { c.ComputeMAC(nil, "bad key") }},                    //nolint:errcheck
+		{"verify_mac", func() { c.VerifyMAC(nil, nil, "bad key") }},                 //nolint:errcheck
+		{"wrap_key", func() { c.WrapKey(nil, nil, nil, nil) }},                      //nolint:errcheck
+		{"unwrap_key", func() { c.UnwrapKey(nil, "bad key") }},                      //nolint:errcheck
+		{"sign_multi", func() { c.SignMulti(nil, "bad key") }},                      //nolint:errcheck
+		{"verify_multi", func() { c.VerifyMulti(nil, nil, "bad key") }},             //nolint:errcheck
+		{"verify_proof", func() { c.VerifyProof(nil, nil, nil, "bad key") }},        //nolint:errcheck
+		{"derive_proof", func() { c.DeriveProof(nil, nil, nil, nil, "bad key") }},   //nolint:errcheck
+		{"blind_messages", func() { c.BlindMessages(nil, nil, 0, nil, "bad key") }}, //nolint:errcheck
+		{"blind_sign", func() { c.BlindSign(nil, nil, nil, 0, nil, "bad key") }},    //nolint:errcheck
+		{"unblind_signature", func() { c.UnblindSignature(nil, nil) }},              //nolint:errcheck
+	}
+
+	for _, tc := range tests {
+		recorder.operations = nil
+
+		tc.call()
+		require.Equal(t, []string{tc.operation}, recorder.operations)
+	}
+}
@@ -29,8 +29,8 @@ import (
 const defKeySize = 32
 
 // deriveKEKAndWrap is the entry point for Crypto.WrapKey().
-func (t *Crypto) deriveKEKAndWrap(cek, apu, apv []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
-	useXC20PKW bool) (*cryptoapi.RecipientWrappedKey, error) {
+func (t *Crypto) deriveKEKAndWrap(cek, apu, apv, tag []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
+	ephemeralKey *cryptoapi.PrivateKey, useXC20PKW bool) (*cryptoapi.RecipientWrappedKey, error) {
 	var (
 		kek         []byte
 		epk         *cryptoapi.PublicKey
@@ -39,7 +39,8 @@ func (t *Crypto) deriveKEKAndWrap(cek, apu, apv []byte, senderKH interface{}, re
 	)
 
 	if senderKH != nil { // ecdh1pu
-		wrappingAlg, kek, epk, apu, err = t.derive1PUKEK(apu, apv, senderKH, recPubKey, useXC20PKW)
+		wrappingAlg, kek, epk, apu, err = t.derive1PUKEK(apu, apv, tag, senderKH, recPubKey, ephemeralKey,
+			useXC20PKW)
 		if err != nil {
 			return nil, fmt.Errorf("deriveKEKAndWrap: error ECDH-1PU kek derivation: %w", err)
 		}
@@ -90,7 +91,7 @@ func (t *Crypto) wrapRaw(kek, cek, apu, apv []byte, alg, kid string, epk *crypto
 }
 
 // deriveKEKAndUnwrap is the entry point for Crypto.UnwrapKey().
-func (t *Crypto) deriveKEKAndUnwrap(alg string, encCEK, apu, apv []byte, epk *cryptoapi.PublicKey, senderKH,
+func (t *Crypto) deriveKEKAndUnwrap(alg string, encCEK, apu, apv, tag []byte, epk *cryptoapi.PublicKey, senderKH,
 	recKH interface{}) ([]byte, error) {
 	var (
 		kek []byte
@@ -109,7 +110,7 @@ func (t *Crypto) deriveKEKAndUnwrap(alg string, encCEK, apu, apv []byte, epk *cr
 
 	switch alg {
 	case ECDH1PUA256KWAlg, ECDH1PUXC20PKWAlg:
-		kek, err = t.derive1PUKEKForUnwrap(alg, apu, apv, epk, senderKH, recipientPrivateKey)
+		kek, err = t.derive1PUKEKForUnwrap(alg, apu, apv, tag, epk, senderKH, recipientPrivateKey)
 		if err != nil {
 			return nil, fmt.Errorf("deriveKEKAndUnwrap: error ECDH-1PU kek derivation: %w", err)
 		}
@@ -157,8 +158,8 @@ func (t *Crypto) unwrapRaw(alg string, kek, encCEK []byte) ([]byte, error) {
 	return wk, nil
 }
 
-func (t *Crypto) derive1PUKEK(apu, apv []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
-	useXC20PKW bool) (string, []byte, *cryptoapi.PublicKey, []byte, error) {
+func (t *Crypto) derive1PUKEK(apu, apv, tag []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
+	ephemeralKey *cryptoapi.PrivateKey, useXC20PKW bool) (string, []byte, *cryptoapi.PublicKey, []byte, error) {
 	var (
 		kek         []byte
 		epk         *cryptoapi.PublicKey
@@ -168,12 +169,14 @@ func (t *Crypto) derive1PUKEK(apu, apv []byte, senderKH interface{}, recPubKey *
 
 	switch recPubKey.Type {
 	case ecdhpb.KeyType_EC.String():
-		wrappingAlg, kek, epk, apu, err = t.derive1PUWithECKey(apu, apv, senderKH, recPubKey, useXC20PKW)
+		wrappingAlg, kek, epk, apu, err = t.derive1PUWithECKey(apu, apv, tag, senderKH, recPubKey,
+			ephemeralKey, useXC20PKW)
 		if err != nil {
 			return "", nil, nil, nil, fmt.Errorf("derive1PUKEK: EC key derivation error %w", err)
 		}
 	case ecdhpb.KeyType_OKP.String():
-		wrappingAlg, kek, epk, apu, err = t.derive1PUWithOKPKey(apu, apv, senderKH, recPubKey, useXC20PKW)
+		wrappingAlg, kek, epk, apu, err = t.derive1PUWithOKPKey(apu, apv, tag, senderKH, recPubKey,
+			ephemeralKey, useXC20PKW)
 		if err != nil {
 			return "", nil, nil, nil, fmt.Errorf("derive1PUKEK: OKP key derivation error %w", err)
 		}
@@ -184,7 +187,7 @@ func (t *Crypto) derive1PUKEK(apu, apv []byte, senderKH interface{}, recPubKey *
 	return wrappingAlg, kek, epk, apu, nil
 }
 
-func (t *Crypto) derive1PUKEKForUnwrap(alg string, apu, apv []byte, epk *cryptoapi.PublicKey, senderKH interface{},
+func (t *Crypto) derive1PUKEKForUnwrap(alg string, apu, apv, tag []byte, epk *cryptoapi.PublicKey, senderKH interface{},
 	recipientPrivateKey interface{}) ([]byte, error) {
 	var (
 		kek []byte
@@ -198,12 +201,12 @@ func (t *Crypto) derive1PUKEKForUnwrap(alg string, apu, apv []byte, epk *cryptoa
 
 	switch epk.Type {
 	case ecdhpb.KeyType_EC.String():
-		kek, err = t.derive1PUWithECKeyForUnwrap(alg, apu, apv, epk, senderKH, recipientPrivateKey)
+		kek, err = t.derive1PUWithECKeyForUnwrap(alg, apu, apv, tag, epk, senderKH, recipientPrivateKey)
 		if err != nil {
 			return nil, fmt.Errorf("derive1PUKEKForUnwrap: EC key derivation error %w", err)
 		}
 	case ecdhpb.KeyType_OKP.String():
-		kek, err = t.derive1PUWithOKPKeyForUnwrap(alg, apu, apv, epk, senderKH, recipientPrivateKey)
+		kek, err = t.derive1PUWithOKPKeyForUnwrap(alg, apu, apv, tag, epk, senderKH, recipientPrivateKey)
 		if err != nil {
 			return nil, fmt.Errorf("derive1PUKEKForUnwrap: OKP key derivation error %w", err)
 		}
@@ -266,8 +269,8 @@ func (t *Crypto) deriveESKEKForUnwrap(alg string, apu, apv []byte, epk *cryptoap
 	return kek, nil
 }
 
-func (t *Crypto) derive1PUWithECKey(apu, apv []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
-	useXC20PKW bool) (string, []byte, *cryptoapi.PublicKey, []byte, error) {
+func (t *Crypto) derive1PUWithECKey(apu, apv, tag []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
+	ephemeralKey *cryptoapi.PrivateKey, useXC20PKW bool) (string, []byte, *cryptoapi.PublicKey, []byte, error) {
 	wrappingAlg := ECDH1PUA256KWAlg
 
 	if useXC20PKW {
@@ -284,6 +287,13 @@ func (t *Crypto) derive1PUWithECKey(apu, apv []byte, senderKH interface{}, recPu
 		return "", nil, nil, nil, err
 	}
 
+	if ephemeralKey != nil {
+		ephemeralPrivKey, err = t.convertEPKEC(ephemeralKey, pubKey.Curve)
+		if err != nil {
+			return "", nil, nil, nil, fmt.Errorf("derive1PUWithECKey: %w", err)
+		}
+	}
+
 	ephemeralXBytes := ephemeralPrivKey.PublicKey.X.Bytes()
 
 	if len(apu) == 0 {
@@ -291,7 +301,8 @@ func (t *Crypto) derive1PUWithECKey(apu, apv []byte, senderKH interface{}, recPu
 		base64.RawURLEncoding.Encode(apu, ephemeralXBytes)
 	}
 
-	kek, err := t.ecKW.deriveSender1Pu(wrappingAlg, apu, apv, ephemeralPrivKey, senderPrivKey, pubKey, defKeySize)
+	kek, err := t.ecKW.deriveSender1Pu(wrappingAlg, apu, apv, tag, ephemeralPrivKey, senderPrivKey, pubKey,
+		defKeySize)
 	if err != nil {
 		return "", nil, nil, nil, fmt.Errorf("derive1PUWithECKey: failed to derive key: %w", err)
 	}
@@ -299,14 +310,14 @@ func (t *Crypto) derive1PUWithECKey(apu, apv []byte, senderKH interface{}, recPu
 	epk := &cryptoapi.PublicKey{
 		X:     ephemeralXBytes,
 		Y:     ephemeralPrivKey.PublicKey.Y.Bytes(),
-		Curve: ephemeralPrivKey.PublicKey.Curve.Params().Name,
+		Curve: cryptoutil.ECCurveName(ephemeralPrivKey.PublicKey.Curve),
 		Type:  recPubKey.Type,
 	}
 
 	return wrappingAlg, kek, epk, apu, nil
 }
 
-func (t *Crypto) derive1PUWithECKeyForUnwrap(alg string, apu, apv []byte, epk *cryptoapi.PublicKey,
+func (t *Crypto) derive1PUWithECKeyForUnwrap(alg string, apu, apv, tag []byte, epk *cryptoapi.PublicKey,
 	senderKH interface{}, recipientPrivateKey interface{}) ([]byte, error) {
 	var (
 		senderPubKey *ecdsa.PublicKey
@@ -337,7 +348,7 @@ func (t *Crypto) derive1PUWithECKeyForUnwrap(alg string, apu, apv []byte, epk *c
 
 	recPrivKey := hybridECPrivToECDSAKey(recPrivECKey)
 
-	kek, err := t.ecKW.deriveRecipient1Pu(alg, apu, apv, epkPubKey, senderPubKey, recPrivKey, defKeySize)
+	kek, err := t.ecKW.deriveRecipient1Pu(alg, apu, apv, tag, epkPubKey, senderPubKey, recPrivKey, defKeySize)
 	if err != nil {
 		return nil, fmt.Errorf("derive1PUWithECKeyForUnwrap: failed to derive kek: %w", err)
 	}
@@ -401,15 +412,15 @@ func (t *Crypto) deriveESWithECKey(apu, apv []byte, recPubKey *cryptoapi.PublicK
 	epk := &cryptoapi.PublicKey{
 		X:     ephemeralXBytes,
 		Y:     ephemeralPrivKey.PublicKey.Y.Bytes(),
-		Curve: ephemeralPrivKey.PublicKey.Curve.Params().Name,
+		Curve: cryptoutil.ECCurveName(ephemeralPrivKey.PublicKey.Curve),
 		Type:  recPubKey.Type,
 	}
 
 	return wrappingAlg, kek, epk, apu, nil
 }
 
-func (t *Crypto) derive1PUWithOKPKey(apu, apv []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
-	useXC20PKW bool) (string, []byte, *cryptoapi.PublicKey, []byte, error) {
+func (t *Crypto) derive1PUWithOKPKey(apu, apv, tag []byte, senderKH interface{}, recPubKey *cryptoapi.PublicKey,
+	ephemeralKey *cryptoapi.PrivateKey, useXC20PKW bool) (string, []byte, *cryptoapi.PublicKey, []byte, error) {
 	wrappingAlg := ECDH1PUXC20PKWAlg
 
 	if !useXC20PKW {
@@ -421,9 +432,15 @@ func (t *Crypto) derive1PUWithOKPKey(apu, apv []byte, senderKH interface{}, recP
 		return "", nil, nil, nil, fmt.Errorf("derive1PUWithOKPKey: failed to retrieve sender key: %w", err)
 	}
 
-	ephemeralPubKey, ephemeralPrivKey, err := t.generateEphemeralOKPKey()
-	if err != nil {
-		return "", nil, nil, nil, fmt.Errorf("derive1PUWithOKPKey: failed to generate ephemeral key: %w", err)
+	var ephemeralPubKey, ephemeralPrivKey []byte
+
+	if ephemeralKey != nil {
+		ephemeralPubKey, ephemeralPrivKey = ephemeralKey.PublicKey.X, ephemeralKey.D
+	} else {
+		ephemeralPubKey, ephemeralPrivKey, err = t.generateEphemeralOKPKey()
+		if err != nil {
+			return "", nil, nil, nil, fmt.Errorf("derive1PUWithOKPKey: failed to generate ephemeral key: %w", err)
+		}
 	}
 
 	if len(apu) == 0 {
@@ -431,7 +448,8 @@ func (t *Crypto) derive1PUWithOKPKey(apu, apv []byte, senderKH interface{}, recP
 		base64.RawURLEncoding.Encode(apu, ephemeralPubKey)
 	}
 
-	kek, err := t.okpKW.deriveSender1Pu(wrappingAlg, apu, apv, ephemeralPrivKey, senderPrivKey, recPubKey.X, defKeySize)
+	kek, err := t.okpKW.deriveSender1Pu(wrappingAlg, apu, apv, tag, ephemeralPrivKey, senderPrivKey, recPubKey.X,
+		defKeySize)
 	if err != nil {
 		return "", nil, nil, nil, fmt.Errorf("derive1PUWithOKPKey: failed to derive key: %w", err)
 	}
@@ -445,7 +463,7 @@ func (t *Crypto) derive1PUWithOKPKey(apu, apv []byte, senderKH interface{}, recP
 	return wrappingAlg, kek, epk, apu, nil
 }
 
-func (t *Crypto) derive1PUWithOKPKeyForUnwrap(alg string, apu, apv []byte, epk *cryptoapi.PublicKey,
+func (t *Crypto) derive1PUWithOKPKeyForUnwrap(alg string, apu, apv, tag []byte, epk *cryptoapi.PublicKey,
 	senderKH interface{}, recipientPrivateKey interface{}) ([]byte, error) {
 	senderPubKey, err := ksToPublicX25519Key(senderKH)
 	if err != nil {
@@ -457,7 +475,7 @@ func (t *Crypto) derive1PUWithOKPKeyForUnwrap(alg string, apu, apv []byte, epk *
 		return nil, errors.New("derive1PUWithOKPKeyForUnwrap: recipient key is not an OKP key")
 	}
 
-	kek, err := t.okpKW.deriveRecipient1Pu(alg, apu, apv, epk.X, senderPubKey, recPrivOKPKey, defKeySize)
+	kek, err := t.okpKW.deriveRecipient1Pu(alg, apu, apv, tag, epk.X, senderPubKey, recPrivOKPKey, defKeySize)
 	if err != nil {
 		return nil, fmt.Errorf("derive1PUWithOKPKeyForUnwrap: failed to derive kek: %w", err)
 	}
@@ -548,6 +566,27 @@ func (t *Crypto) convertRecKeyAndGenEPKEC(recPubKey *cryptoapi.PublicKey) (*ecds
 	return recECPubKey, ephemeralPrivKey.(*ecdsa.PrivateKey), nil
 }
 
+// convertEPKEC converts a caller provided ephemeral key into an ECDSA private key on the recipient's curve.
+func (t *Crypto) convertEPKEC(epk *cryptoapi.PrivateKey, recCurve elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	c, err := t.ecKW.getCurve(epk.PublicKey.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("convertEPKEC: failed to get curve of ephemeral key: %w", err)
+	}
+
+	if c != recCurve {
+		return nil, errors.New("convertEPKEC: recipient and ephemeral keys are not on the same curve")
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: c,
+			X:     new(big.Int).SetBytes(epk.PublicKey.X),
+			Y:     new(big.Int).SetBytes(epk.PublicKey.Y),
+		},
+		D: new(big.Int).SetBytes(epk.D),
+	}, nil
+}
+
 func (t *Crypto) generateEphemeralOKPKey() ([]byte, []byte, error) {
 	ephemeralPrivKey, err := t.okpKW.generateKey(nil)
 	if err != nil {
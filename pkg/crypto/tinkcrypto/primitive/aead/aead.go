@@ -0,0 +1,37 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package aead provides the AES-CBC-HMAC-SHA2 AEAD primitive of the A128CBC-HS256, A192CBC-HS384 and A256CBC-HS512
+// JWE content encryption algorithms (https://tools.ietf.org/html/rfc7518#section-5.2), which Tink does not provide.
+//
+// Its key manager is registered in the Tink registry, keys are created with the key templates of this package:
+//
+//	kh, err := keyset.NewHandle(aead.AES256CBCHMACSHA512KeyTemplate())
+//	if err != nil {
+//	    // handle error
+//	}
+//
+//	a, err := tinkaead.New(kh)
+//	if err != nil {
+//	    // handle error
+//	}
+//
+//	ct, err := a.Encrypt([]byte("secret message"), []byte("associated data"))
+package aead
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+func init() {
+	// TODO - avoid the tink registry singleton.
+	err := registry.RegisterKeyManager(newAESCBCHMACAEADKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("aead.init() failed: %v", err))
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aead
+
+import (
+	"github.com/golang/protobuf/proto"
+	gcmpb "github.com/google/tink/go/proto/aes_gcm_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
+)
+
+// AES128CBCHMACSHA256KeyTemplate is a KeyTemplate that generates an A128CBC-HS256 AEAD key.
+func AES128CBCHMACSHA256KeyTemplate() *tinkpb.KeyTemplate {
+	return createAESCBCHMACAEADKeyTemplate(subtle.AES128CBCHMACSHA256KeySize)
+}
+
+// AES192CBCHMACSHA384KeyTemplate is a KeyTemplate that generates an A192CBC-HS384 AEAD key.
+func AES192CBCHMACSHA384KeyTemplate() *tinkpb.KeyTemplate {
+	return createAESCBCHMACAEADKeyTemplate(subtle.AES192CBCHMACSHA384KeySize)
+}
+
+// AES256CBCHMACSHA512KeyTemplate is a KeyTemplate that generates an A256CBC-HS512 AEAD key.
+func AES256CBCHMACSHA512KeyTemplate() *tinkpb.KeyTemplate {
+	return createAESCBCHMACAEADKeyTemplate(subtle.AES256CBCHMACSHA512KeySize)
+}
+
+func createAESCBCHMACAEADKeyTemplate(keySize uint32) *tinkpb.KeyTemplate {
+	serializedFormat, err := proto.Marshal(&gcmpb.AesGcmKeyFormat{KeySize: keySize})
+	if err != nil {
+		panic("failed to marshal AES-CBC-HMAC key format proto")
+	}
+
+	return &tinkpb.KeyTemplate{
+		TypeUrl:          AESCBCHMACAEADTypeURL,
+		Value:            serializedFormat,
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}
+}
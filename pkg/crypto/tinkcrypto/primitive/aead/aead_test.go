@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aead_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	tinkaead "github.com/google/tink/go/aead"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	gcmpb "github.com/google/tink/go/proto/aes_gcm_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
+)
+
+func TestAESCBCHMACAEAD(t *testing.T) {
+	for _, kt := range []*tinkpb.KeyTemplate{
+		aead.AES128CBCHMACSHA256KeyTemplate(),
+		aead.AES192CBCHMACSHA384KeyTemplate(),
+		aead.AES256CBCHMACSHA512KeyTemplate(),
+	} {
+		kh, err := keyset.NewHandle(kt)
+		require.NoError(t, err)
+
+		a, err := tinkaead.New(kh)
+		require.NoError(t, err)
+
+		ct, err := a.Encrypt([]byte("secret message"), []byte("aad"))
+		require.NoError(t, err)
+
+		pt, err := a.Decrypt(ct, []byte("aad"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("secret message"), pt)
+	}
+}
+
+func TestAESCBCHMACAEADKeyManager(t *testing.T) {
+	km, err := registry.GetKeyManager(aead.AESCBCHMACAEADTypeURL)
+	require.NoError(t, err)
+	require.True(t, km.DoesSupport(aead.AESCBCHMACAEADTypeURL))
+	require.Equal(t, aead.AESCBCHMACAEADTypeURL, km.TypeURL())
+
+	key, err := km.NewKey(aead.AES256CBCHMACSHA512KeyTemplate().Value)
+	require.NoError(t, err)
+	require.Len(t, key.(*gcmpb.AesGcmKey).KeyValue, subtle.AES256CBCHMACSHA512KeySize)
+
+	keyData, err := km.NewKeyData(aead.AES128CBCHMACSHA256KeyTemplate().Value)
+	require.NoError(t, err)
+	require.Equal(t, aead.AESCBCHMACAEADTypeURL, keyData.TypeUrl)
+
+	p, err := km.Primitive(keyData.Value)
+	require.NoError(t, err)
+	require.IsType(t, &subtle.AESCBCHMAC{}, p)
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err = km.Primitive(nil)
+		require.EqualError(t, err, "aes_cbc_hmac_aead_key_manager: invalid key")
+
+		_, err = km.Primitive([]byte("invalid"))
+		require.EqualError(t, err, "aes_cbc_hmac_aead_key_manager: invalid key")
+
+		invalidKey, err := proto.Marshal(&gcmpb.AesGcmKey{KeyValue: []byte("short")})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(invalidKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid AES-CBC-HMAC key size")
+
+		invalidKey, err = proto.Marshal(&gcmpb.AesGcmKey{Version: 1, KeyValue: make([]byte, 32)})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(invalidKey)
+		require.Error(t, err)
+
+		_, err = km.NewKey(nil)
+		require.EqualError(t, err, "aes_cbc_hmac_aead_key_manager: invalid key format")
+
+		_, err = km.NewKey([]byte("invalid"))
+		require.EqualError(t, err, "aes_cbc_hmac_aead_key_manager: invalid key format")
+
+		invalidFormat, err := proto.Marshal(&gcmpb.AesGcmKeyFormat{KeySize: 16})
+		require.NoError(t, err)
+
+		_, err = km.NewKeyData(invalidFormat)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid key format")
+	})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aead
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	gcmpb "github.com/google/tink/go/proto/aes_gcm_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
+)
+
+const (
+	aesCBCHMACAEADKeyVersion = 0
+	// AESCBCHMACAEADTypeURL is the type URL of AES-CBC-HMAC-SHA2 AEAD keys.
+	AESCBCHMACAEADTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.AesCbcHmacAeadKey"
+)
+
+// common errors.
+var (
+	errInvalidAESCBCHMACAEADKey       = errors.New("aes_cbc_hmac_aead_key_manager: invalid key")
+	errInvalidAESCBCHMACAEADKeyFormat = errors.New("aes_cbc_hmac_aead_key_manager: invalid key format")
+)
+
+// aesCBCHMACAEADKeyManager is an implementation of KeyManager interface.
+// It generates new AES-CBC-HMAC-SHA2 keys and produces new instances of AESCBCHMAC subtle. The keys and key formats
+// are serialized as AesGcmKey and AesGcmKeyFormat protos, which hold the key value and key size.
+type aesCBCHMACAEADKeyManager struct{}
+
+// Assert that aesCBCHMACAEADKeyManager implements the KeyManager interface.
+var _ registry.KeyManager = (*aesCBCHMACAEADKeyManager)(nil)
+
+// newAESCBCHMACAEADKeyManager creates a new aesCBCHMACAEADKeyManager.
+func newAESCBCHMACAEADKeyManager() *aesCBCHMACAEADKeyManager {
+	return new(aesCBCHMACAEADKeyManager)
+}
+
+// Primitive creates an AESCBCHMAC subtle for the given serialized key proto.
+func (km *aesCBCHMACAEADKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidAESCBCHMACAEADKey
+	}
+
+	key := new(gcmpb.AesGcmKey)
+
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidAESCBCHMACAEADKey
+	}
+
+	if err := km.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	ret, err := subtle.NewAESCBCHMAC(key.KeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("aes_cbc_hmac_aead_key_manager: cannot create new primitive: %w", err)
+	}
+
+	return ret, nil
+}
+
+// NewKey creates a new key according to the given serialized key format.
+func (km *aesCBCHMACAEADKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidAESCBCHMACAEADKeyFormat
+	}
+
+	keyFormat := new(gcmpb.AesGcmKeyFormat)
+
+	if err := proto.Unmarshal(serializedKeyFormat, keyFormat); err != nil {
+		return nil, errInvalidAESCBCHMACAEADKeyFormat
+	}
+
+	if err := subtle.ValidateAESCBCHMACKeySize(int(keyFormat.KeySize)); err != nil {
+		return nil, fmt.Errorf("aes_cbc_hmac_aead_key_manager: invalid key format: %w", err)
+	}
+
+	return &gcmpb.AesGcmKey{
+		Version:  aesCBCHMACAEADKeyVersion,
+		KeyValue: random.GetRandomBytes(keyFormat.KeySize),
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to the given serialized key format.
+// It should be used solely by the key management API.
+func (km *aesCBCHMACAEADKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         AESCBCHMACAEADTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_SYMMETRIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *aesCBCHMACAEADKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == AESCBCHMACAEADTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *aesCBCHMACAEADKeyManager) TypeURL() string {
+	return AESCBCHMACAEADTypeURL
+}
+
+// validateKey validates the given key.
+func (km *aesCBCHMACAEADKeyManager) validateKey(key *gcmpb.AesGcmKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, aesCBCHMACAEADKeyVersion)
+	if err != nil {
+		return fmt.Errorf("aes_cbc_hmac_aead_key_manager: %w", err)
+	}
+
+	if err := subtle.ValidateAESCBCHMACKeySize(len(key.KeyValue)); err != nil {
+		return fmt.Errorf("aes_cbc_hmac_aead_key_manager: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package subtle provides the AES-CBC-HMAC-SHA2 AEAD primitive.
+package subtle
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/subtle/random"
+	"github.com/google/tink/go/tink"
+	josecipher "github.com/square/go-jose/v3/cipher"
+)
+
+const (
+	// AESCBCIVSize is the IV size of AES-CBC-HMAC-SHA2 encryption.
+	AESCBCIVSize = 16
+
+	// AES128CBCHMACSHA256KeySize is the key size of A128CBC-HS256 (16 bytes MAC key and 16 bytes encryption key).
+	AES128CBCHMACSHA256KeySize = 32
+	// AES192CBCHMACSHA384KeySize is the key size of A192CBC-HS384 (24 bytes MAC key and 24 bytes encryption key).
+	AES192CBCHMACSHA384KeySize = 48
+	// AES256CBCHMACSHA512KeySize is the key size of A256CBC-HS512 (32 bytes MAC key and 32 bytes encryption key).
+	AES256CBCHMACSHA512KeySize = 64
+)
+
+// AESCBCHMAC is an AEAD with AES-CBC encryption and HMAC-SHA2 authentication, as defined for the A128CBC-HS256,
+// A192CBC-HS384 and A256CBC-HS512 JWE content encryption algorithms (https://tools.ietf.org/html/rfc7518#section-5.2).
+type AESCBCHMAC struct {
+	aead cipher.AEAD
+}
+
+var _ tink.AEAD = (*AESCBCHMAC)(nil)
+
+// NewAESCBCHMAC returns an AESCBCHMAC instance for key, the concatenation of the MAC key and the encryption key, of
+// AES128CBCHMACSHA256KeySize, AES192CBCHMACSHA384KeySize or AES256CBCHMACSHA512KeySize.
+func NewAESCBCHMAC(key []byte) (*AESCBCHMAC, error) {
+	if err := ValidateAESCBCHMACKeySize(len(key)); err != nil {
+		return nil, fmt.Errorf("aes_cbc_hmac: %w", err)
+	}
+
+	aead, err := josecipher.NewCBCHMAC(key, aes.NewCipher)
+	if err != nil {
+		return nil, fmt.Errorf("aes_cbc_hmac: %w", err)
+	}
+
+	return &AESCBCHMAC{aead: aead}, nil
+}
+
+// ValidateAESCBCHMACKeySize checks that sizeInBytes is a valid AES-CBC-HMAC-SHA2 key size.
+func ValidateAESCBCHMACKeySize(sizeInBytes int) error {
+	switch sizeInBytes {
+	case AES128CBCHMACSHA256KeySize, AES192CBCHMACSHA384KeySize, AES256CBCHMACSHA512KeySize:
+		return nil
+	default:
+		return fmt.Errorf("invalid AES-CBC-HMAC key size; want 32, 48 or 64, got %d", sizeInBytes)
+	}
+}
+
+// TagSize returns the authentication tag size for a key size, which is half of the key size.
+func TagSize(keySize int) int {
+	return keySize / 2 // nolint:gomnd
+}
+
+// Encrypt encrypts plaintext with additionalData as additional authenticated data. The resulting ciphertext is the
+// concatenation of a random IV, the encrypted plaintext and the authentication tag.
+func (a *AESCBCHMAC) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	iv := random.GetRandomBytes(AESCBCIVSize)
+
+	return a.aead.Seal(append([]byte(nil), iv...), iv, plaintext, additionalData), nil
+}
+
+// Decrypt decrypts ciphertext, the concatenation of the IV, the encrypted plaintext and the authentication tag, with
+// additionalData as additional authenticated data.
+func (a *AESCBCHMAC) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < AESCBCIVSize+a.aead.Overhead()-aes.BlockSize {
+		return nil, errors.New("aes_cbc_hmac: ciphertext too short")
+	}
+
+	plaintext, err := a.aead.Open(nil, ciphertext[:AESCBCIVSize], ciphertext[AESCBCIVSize:], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("aes_cbc_hmac: %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"crypto/aes"
+	"testing"
+
+	"github.com/google/tink/go/subtle/random"
+	josecipher "github.com/square/go-jose/v3/cipher"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESCBCHMAC(t *testing.T) {
+	plaintext := []byte("secret message")
+	aad := []byte("additional data")
+
+	for _, keySize := range []int{AES128CBCHMACSHA256KeySize, AES192CBCHMACSHA384KeySize,
+		AES256CBCHMACSHA512KeySize} {
+		key := random.GetRandomBytes(uint32(keySize))
+
+		a, err := NewAESCBCHMAC(key)
+		require.NoError(t, err)
+
+		ct, err := a.Encrypt(plaintext, aad)
+		require.NoError(t, err)
+		require.Len(t, ct, AESCBCIVSize+aes.BlockSize+TagSize(keySize))
+
+		pt, err := a.Decrypt(ct, aad)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, pt)
+
+		// the ciphertext is the JWE IV, ciphertext and tag of https://tools.ietf.org/html/rfc7518#section-5.2
+		jose, err := josecipher.NewCBCHMAC(key, aes.NewCipher)
+		require.NoError(t, err)
+
+		pt, err = jose.Open(nil, ct[:AESCBCIVSize], ct[AESCBCIVSize:], aad)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, pt)
+
+		_, err = a.Decrypt(ct, []byte("other data"))
+		require.Error(t, err)
+
+		ct[len(ct)-1] ^= 1
+
+		_, err = a.Decrypt(ct, aad)
+		require.Error(t, err)
+
+		_, err = a.Decrypt(ct[:AESCBCIVSize], aad)
+		require.EqualError(t, err, "aes_cbc_hmac: ciphertext too short")
+	}
+
+	_, err := NewAESCBCHMAC(random.GetRandomBytes(16))
+	require.EqualError(t, err, "aes_cbc_hmac: invalid AES-CBC-HMAC key size; want 32, 48 or 64, got 16")
+}
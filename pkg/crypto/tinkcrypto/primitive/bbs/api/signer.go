@@ -14,4 +14,12 @@ type Signer interface {
 	// 		signature in []byte
 	//		error in case of errors
 	Sign(messages [][]byte) ([]byte, error)
+
+	// BlindSign will create a blind signature of messagesCount messages using the signer's private key: messages are
+	// known to the signer at indexes while the remaining ones are committed to in blindContext (built with a
+	// Verifier's BlindMessages() call). The proof of knowledge of the committed messages is verified against nonce.
+	// returns:
+	// 		blind signature in []byte to be unblinded by the holder
+	//		error in case of errors
+	BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int, nonce []byte) ([]byte, error)
 }
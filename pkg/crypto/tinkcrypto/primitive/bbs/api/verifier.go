@@ -25,4 +25,12 @@ type Verifier interface {
 	// 		signature proof in []byte
 	//		error in case of errors
 	DeriveProof(messages [][]byte, signature, nonce []byte, revealedIndexes []int) ([]byte, error)
+
+	// BlindMessages will commit to messages found at indexes among messagesCount messages to be signed with the
+	// signer's private key (see Signer's BlindSign() call) without disclosing them to the signer.
+	// returns:
+	// 		blind signature context in []byte to be sent to the signer
+	//		blinding factor in []byte to be kept to unblind the signature
+	//		error in case of errors
+	BlindMessages(messages [][]byte, indexes []int, messagesCount int, nonce []byte) ([]byte, []byte, error)
 }
@@ -522,10 +522,51 @@ func TestSignerVerifierFactory(t *testing.T) {
 
 			err = bbsVerifier.VerifyProof([][]byte{{}, {}}, proof, nonce)
 			require.EqualError(t, err, "bbs_verifier_factory: invalid signature proof")
+
+			linkSecret := []byte("link secret")
+			messagesCount := len(messagesBytes) + 1
+
+			blindContext, blindingFactor, err := bbsVerifier.BlindMessages([][]byte{linkSecret},
+				[]int{len(messagesBytes)}, messagesCount, nonce)
+			if tc.prefix == tinkpb.OutputPrefixType_LEGACY {
+				require.EqualError(t, err, "bbs_verifier_factory: blinding messages is not supported with LEGACY "+
+					"output prefix")
+
+				_, err = bbsSigner.BlindSign(nil, messagesBytes, []int{0, 1, 2, 3, 4}, messagesCount, nonce)
+				require.EqualError(t, err, "bbs_signer_factory: blind signing is not supported with LEGACY "+
+					"output prefix")
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			blindSig, err := bbsSigner.BlindSign(blindContext, messagesBytes, []int{0, 1, 2, 3, 4}, messagesCount,
+				nonce)
+			require.NoError(t, err)
+
+			// the blind signature has the same output prefix as the signature created with Sign().
+			prefixLen := len(blindSig) - bbsSignatureLen
+			require.Equal(t, sig[:prefixLen], blindSig[:prefixLen])
+
+			unblindedSig, err := bbs12381g2pub.New().UnblindSignature(blindSig[prefixLen:], blindingFactor)
+			require.NoError(t, err)
+
+			signature := make([]byte, 0, len(blindSig))
+			signature = append(signature, blindSig[:prefixLen]...)
+			signature = append(signature, unblindedSig...)
+
+			allMessages := make([][]byte, 0, messagesCount)
+			allMessages = append(allMessages, messagesBytes...)
+			allMessages = append(allMessages, linkSecret)
+
+			require.NoError(t, bbsVerifier.Verify(allMessages, signature))
 		})
 	}
 }
 
+const bbsSignatureLen = 112
+
 func generatePrivateKeyProto(t *testing.T) *bbspb.BBSPrivateKey {
 	seed := make([]byte, 32)
 	hashType := commonpb.HashType_SHA256
@@ -86,3 +86,30 @@ func (ws *wrappedSigner) Sign(messages [][]byte) ([]byte, error) {
 
 	return ret, nil
 }
+
+// BlindSign blind signs the given messages with the primary primitive and returns the blind signature concatenated
+// with the identifier of the primary primitive. LEGACY primitives are not supported as they sign an extra message.
+func (ws *wrappedSigner) BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int,
+	nonce []byte) ([]byte, error) {
+	primary := ws.ps.Primary
+
+	signer, ok := (primary.Primitive).(bbsapi.Signer)
+	if !ok {
+		return nil, fmt.Errorf("bbs_signer_factory: not a BBS Signer primitive")
+	}
+
+	if primary.PrefixType == tinkpb.OutputPrefixType_LEGACY {
+		return nil, fmt.Errorf("bbs_signer_factory: blind signing is not supported with LEGACY output prefix")
+	}
+
+	signature, err := signer.BlindSign(blindContext, messages, indexes, messagesCount, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]byte, 0, len(primary.Prefix)+len(signature))
+	ret = append(ret, primary.Prefix...)
+	ret = append(ret, signature...)
+
+	return ret, nil
+}
@@ -244,3 +244,22 @@ func (wv *wrappedVerifier) DeriveProof(messages [][]byte, signature, nonce []byt
 
 	return nil, errInvalidSignatureProof
 }
+
+// BlindMessages will commit to the messages found at indexes to be blind signed with the primary primitive's signer
+// key (see Signer's BlindSign()). LEGACY primitives are not supported as they sign an extra message.
+func (wv *wrappedVerifier) BlindMessages(messages [][]byte, indexes []int, messagesCount int,
+	nonce []byte) ([]byte, []byte, error) {
+	primary := wv.ps.Primary
+
+	verifier, err := toBBSVerifier(primary.Primitive)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if primary.PrefixType == tinkpb.OutputPrefixType_LEGACY {
+		return nil, nil, errors.New("bbs_verifier_factory: blinding messages is not supported with LEGACY output " +
+			"prefix")
+	}
+
+	return verifier.BlindMessages(messages, indexes, messagesCount, nonce)
+}
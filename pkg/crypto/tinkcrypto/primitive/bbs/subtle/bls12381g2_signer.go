@@ -34,3 +34,13 @@ func NewBLS12381G2Signer(privateKey []byte) *BLS12381G2Signer {
 func (s *BLS12381G2Signer) Sign(messages [][]byte) ([]byte, error) {
 	return s.bbsPrimitive.Sign(messages, s.privateKeyBytes)
 }
+
+// BlindSign will create a blind signature of messagesCount messages using the signer's private key: messages are
+// known to the signer at indexes while the remaining ones are committed to in blindContext.
+// returns:
+// 		blind signature in []byte
+//		error in case of errors
+func (s *BLS12381G2Signer) BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int,
+	nonce []byte) ([]byte, error) {
+	return s.bbsPrimitive.BlindSign(blindContext, messages, indexes, messagesCount, nonce, s.privateKeyBytes)
+}
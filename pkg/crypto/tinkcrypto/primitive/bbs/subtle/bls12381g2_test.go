@@ -84,6 +84,35 @@ func TestBBSG2_DeriveProof(t *testing.T) {
 	require.NoError(t, blsVerifier.VerifyProof(revealedMessages, proofBytes, nonce))
 }
 
+func TestBBSG2_BlindSign(t *testing.T) {
+	pubKey, privKey, err := generateKeyPairRandom()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	blsSigner := NewBLS12381G2Signer(privKeyBytes)
+	blsVerifier := NewBLS12381G2Verifier(pubKeyBytes)
+
+	messagesBytes := [][]byte{[]byte("message1"), []byte("message2"), []byte("link secret")}
+	nonce := []byte("nonce")
+
+	blindContext, blindingFactor, err := blsVerifier.BlindMessages(messagesBytes[2:], []int{2}, 3, nonce)
+	require.NoError(t, err)
+
+	blindSignatureBytes, err := blsSigner.BlindSign(blindContext, messagesBytes[:2], []int{0, 1}, 3, nonce)
+	require.NoError(t, err)
+	require.Len(t, blindSignatureBytes, 112)
+
+	signatureBytes, err := bbs.New().UnblindSignature(blindSignatureBytes, blindingFactor)
+	require.NoError(t, err)
+
+	require.NoError(t, blsVerifier.Verify(messagesBytes, signatureBytes))
+}
+
 func generateKeyPairRandom() (*bbs.PublicKey, *bbs.PrivateKey, error) {
 	seed := make([]byte, 32)
 
@@ -49,3 +49,14 @@ func (v *BLS12381G2Verifier) DeriveProof(messages [][]byte, signature, nonce []b
 	revealedIndexes []int) ([]byte, error) {
 	return v.bbsPrimitive.DeriveProof(messages, signature, nonce, v.signerPubKeyBytes, revealedIndexes)
 }
+
+// BlindMessages will commit to messages found at indexes among messagesCount messages to be signed with the signer's
+// private key.
+// returns:
+// 		blind signature context in []byte
+//		blinding factor in []byte
+//		error in case of errors
+func (v *BLS12381G2Verifier) BlindMessages(messages [][]byte, indexes []int, messagesCount int,
+	nonce []byte) ([]byte, []byte, error) {
+	return v.bbsPrimitive.BlindMessages(messages, indexes, messagesCount, nonce, v.signerPubKeyBytes)
+}
@@ -12,7 +12,9 @@ import (
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 
+	cbcaead "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 )
 
 // NISTP256ECDHKWKeyTemplate is a KeyTemplate that generates a key that accepts a CEK for JWE content
@@ -42,6 +44,15 @@ func NISTP521ECDHKWKeyTemplate() *tinkpb.KeyTemplate {
 	return createKeyTemplate(true, commonpb.EllipticCurveType_NIST_P521, nil)
 }
 
+// Secp256k1ECDHKWKeyTemplate is a KeyTemplate that generates a key that accepts a CEK for JWE content
+// encryption. CEK wrapping is done outside of this Tink key (in the tinkcrypto service).
+// Keys from this template represent a valid recipient public/private key pairs and can be stored in the KMS. The
+// recipient key represented in this key template uses the following key wrapping curve:
+//  - secp256k1 (EC key wrapping, like the NIST P curves).
+func Secp256k1ECDHKWKeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(true, cryptoutil.Secp256k1CurveType, nil)
+}
+
 // X25519ECDHKWKeyTemplate is a KeyTemplate that generates a key that accepts a CEK for JWE content
 // encryption. CEK wrapping is done outside of this Tink key (in the tinkcrypto service).
 // Keys from this template represent a valid recipient public/private key pairs and can be stored in the KMS.The
@@ -64,6 +75,20 @@ func NISTPECDHAES256GCMKeyTemplateWithCEK(cek []byte) *tinkpb.KeyTemplate {
 	return createKeyTemplate(true, 0, cek)
 }
 
+// NISTPECDHAES128CBCHMACSHA256KeyTemplateWithCEK is similar to NISTPECDHAES256GCMKeyTemplateWithCEK but with the
+// content encryption algorithm:
+//  - AES128-CBC-HMAC-SHA256 (A128CBC-HS256), with a 32 bytes cek
+func NISTPECDHAES128CBCHMACSHA256KeyTemplateWithCEK(cek []byte) *tinkpb.KeyTemplate {
+	return createKeyTemplateWithEnc(true, 0, cbcaead.AES128CBCHMACSHA256KeyTemplate(), cek)
+}
+
+// NISTPECDHAES256CBCHMACSHA512KeyTemplateWithCEK is similar to NISTPECDHAES256GCMKeyTemplateWithCEK but with the
+// content encryption algorithm:
+//  - AES256-CBC-HMAC-SHA512 (A256CBC-HS512), with a 64 bytes cek
+func NISTPECDHAES256CBCHMACSHA512KeyTemplateWithCEK(cek []byte) *tinkpb.KeyTemplate {
+	return createKeyTemplateWithEnc(true, 0, cbcaead.AES256CBCHMACSHA512KeyTemplate(), cek)
+}
+
 // X25519ECDHXChachaKeyTemplateWithCEK is similar to X25519ECDHKWKeyTemplate but adding the cek to execute the
 // CompositeEncrypt primitive for encrypting a message targeted to one ore more recipients.
 // Keys from this template offer valid CompositeEncrypt primitive execution only and should not be stored in the KMS.
@@ -77,7 +102,18 @@ func X25519ECDHXChachaKeyTemplateWithCEK(cek []byte) *tinkpb.KeyTemplate {
 // createKeyTemplate creates a new ECDH-AEAD key template with the set cek for primitive execution. Boolean flag used:
 //  - nistpKW flag to state if kw is either NIST P curves (true) or Curve25519 (false)
 func createKeyTemplate(nistpKW bool, c commonpb.EllipticCurveType, cek []byte) *tinkpb.KeyTemplate {
-	typeURL, keyType, encTemplate := getTypeParams(nistpKW)
+	return createKeyTemplateWithEnc(nistpKW, c, nil, cek)
+}
+
+// createKeyTemplateWithEnc is createKeyTemplate with the encTemplate content encryption key template instead of the
+// default one of the kw curve if set.
+func createKeyTemplateWithEnc(nistpKW bool, c commonpb.EllipticCurveType, encTemplate *tinkpb.KeyTemplate,
+	cek []byte) *tinkpb.KeyTemplate {
+	typeURL, keyType, defaultEncTemplate := getTypeParams(nistpKW)
+
+	if encTemplate == nil {
+		encTemplate = defaultEncTemplate
+	}
 
 	format := &ecdhpb.EcdhAeadKeyFormat{
 		Params: &ecdhpb.EcdhAeadParams{
@@ -34,6 +34,10 @@ func TestECDHESKeyTemplateSuccess(t *testing.T) {
 			tcName:   "create ECDH NIST P-521 KW AES256-GCM key templates test",
 			tmplFunc: NISTP521ECDHKWKeyTemplate,
 		},
+		{
+			tcName:   "create ECDH secp256k1 KW AES256-GCM key templates test",
+			tmplFunc: Secp256k1ECDHKWKeyTemplate,
+		},
 		{
 			tcName:   "creat ECDH X25519 KW with XChacha20Poly1305 key templates test",
 			tmplFunc: X25519ECDHKWKeyTemplate,
@@ -100,3 +104,32 @@ func TestECDHESKeyTemplateSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestECDHAESCBCHMACKeyTemplateWithCEK(t *testing.T) {
+	for cekSize, tmplFunc := range map[uint32]func([]byte) *tinkpb.KeyTemplate{
+		32: NISTPECDHAES128CBCHMACSHA256KeyTemplateWithCEK,
+		64: NISTPECDHAES256CBCHMACSHA512KeyTemplateWithCEK,
+	} {
+		kh, err := keyset.NewHandle(tmplFunc(random.GetRandomBytes(cekSize)))
+		require.NoError(t, err)
+
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		e, err := NewECDHEncrypt(pubKH)
+		require.NoError(t, err)
+
+		pt := []byte("secret message")
+		aad := []byte("aad message")
+
+		ct, err := e.Encrypt(pt, aad)
+		require.NoError(t, err)
+
+		d, err := NewECDHDecrypt(kh)
+		require.NoError(t, err)
+
+		dpt, err := d.Decrypt(ct, aad)
+		require.NoError(t, err)
+		require.Equal(t, pt, dpt)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh/subtle"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 )
 
 const (
@@ -33,7 +34,8 @@ var (
 	errInvalidNISTPECDHKWPrivateKeyFormat = errors.New("nistpkw_ecdh_private_key_manager: invalid key format")
 )
 
-// nistPECDHKWPrivateKeyManager is an implementation of PrivateKeyManager interface for NIST P curved key wrapping.
+// nistPECDHKWPrivateKeyManager is an implementation of PrivateKeyManager interface for NIST P (and secp256k1) curved
+// key wrapping.
 // It generates new ECDHPrivateKey (NIST P KW) keys and produces new instances of ECDHAEADCompositeDecrypt subtle.
 type nistPECDHKWPrivateKeyManager struct{}
 
@@ -193,7 +195,7 @@ func validateKeyFormat(params *ecdhpb.EcdhAeadParams) (elliptic.Curve, error) {
 	// if CEK is set, then curve is unknown, ie this is not a recipient key, it's a primitive execution key for
 	// Encryption/Decryption. Set P-384 curve for key generation
 	if params.EncParams.CEK == nil {
-		c, err = hybrid.GetCurve(params.KwParams.CurveType.String())
+		c, err = cryptoutil.GetCurve(cryptoutil.CurveName(params.KwParams.CurveType))
 		if err != nil {
 			return nil, fmt.Errorf("nistpkw_ecdh_private_key_manager: invalid key: %w", err)
 		}
@@ -29,7 +29,8 @@ const (
 // common errors.
 var errInvalidNISTPECDHKWPublicKey = errors.New("nistpkw_ecdh_public_key_manager: invalid key")
 
-// nistPECDHKWPublicKeyManager is an implementation of KeyManager interface for NIST P curved key wrapping.
+// nistPECDHKWPublicKeyManager is an implementation of KeyManager interface for NIST P (and secp256k1) curved key
+// wrapping.
 // It generates new ECDHPublicKey (AES) keys and produces new instances of ECDHAEADCompositeEncrypt subtle.
 type nistPECDHKWPublicKeyManager struct{}
 
@@ -16,7 +16,6 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/aead"
-	hybrid "github.com/google/tink/go/hybrid/subtle"
 	"github.com/google/tink/go/insecurecleartextkeyset"
 	"github.com/google/tink/go/keyset"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
@@ -24,6 +23,7 @@ import (
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 )
 
 // Package keyio supports exporting of Composite keys (aka Write) and converting the public key part of the a composite
@@ -137,8 +137,8 @@ func buildCompositeKey(kid, keyType, curve string, x, y []byte) (*cryptoapi.Publ
 	// validate keyType and curve
 	switch keyType {
 	case ecdhpb.KeyType_EC.String():
-		// validate NIST P curves
-		_, err := hybrid.GetCurve(curve)
+		// validate NIST P and secp256k1 curves
+		_, err := cryptoutil.GetCurve(curve)
 		if err != nil {
 			return nil, fmt.Errorf("undefined EC curve: %w", err)
 		}
@@ -187,7 +187,7 @@ func (e *ecdhKey) kid() string {
 }
 
 func (e *ecdhKey) curveName() string {
-	return e.protoKey.Params.KwParams.CurveType.String()
+	return cryptoutil.CurveName(e.protoKey.Params.KwParams.CurveType)
 }
 
 func (e *ecdhKey) keyType() string {
@@ -318,6 +318,8 @@ func getCurveProto(c string) (commonpb.EllipticCurveType, error) {
 		return commonpb.EllipticCurveType_NIST_P384, nil
 	case "secp521r1", "NIST_P521", "P-521", "EllipticCurveType_NIST_P521":
 		return commonpb.EllipticCurveType_NIST_P521, nil
+	case cryptoutil.Secp256k1CurveName, "secp256k1":
+		return cryptoutil.Secp256k1CurveType, nil
 	case commonpb.EllipticCurveType_CURVE25519.String():
 		return commonpb.EllipticCurveType_CURVE25519, nil
 	default:
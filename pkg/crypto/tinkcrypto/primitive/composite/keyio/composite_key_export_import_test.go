@@ -43,6 +43,10 @@ func TestPubKeyExport(t *testing.T) {
 			tcName:      "export then read ECDH KW NIST P-521 public recipient key",
 			keyTemplate: ecdh.NISTP521ECDHKWKeyTemplate(),
 		},
+		{
+			tcName:      "export then read ECDH KW secp256k1 public recipient key",
+			keyTemplate: ecdh.Secp256k1ECDHKWKeyTemplate(),
+		},
 		{
 			tcName:      "export then read ECDH KW X25519 public recipient key",
 			keyTemplate: ecdh.X25519ECDHKWKeyTemplate(),
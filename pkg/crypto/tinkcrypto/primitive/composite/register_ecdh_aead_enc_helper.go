@@ -20,6 +20,9 @@ import (
 	"github.com/google/tink/go/tink"
 	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/poly1305"
+
+	cbcaead "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead"
+	cbcsubtle "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
 )
 
 const (
@@ -29,6 +32,8 @@ const (
 	ChaCha20Poly1305TypeURL = "type.googleapis.com/google.crypto.tink.ChaCha20Poly1305Key"
 	// XChaCha20Poly1305TypeURL for XChachaPoly1305 content encryption URL identifier.
 	XChaCha20Poly1305TypeURL = "type.googleapis.com/google.crypto.tink.XChaCha20Poly1305Key"
+	// AESCBCHMACAEADTypeURL for AES-CBC-HMAC-SHA2 content encryption URL identifier.
+	AESCBCHMACAEADTypeURL = cbcaead.AESCBCHMACAEADTypeURL
 )
 
 type marshalFunc func(interface{}) ([]byte, error)
@@ -68,6 +73,21 @@ func NewRegisterCompositeAEADEncHelper(k *tinkpb.KeyTemplate) (*RegisterComposit
 		if err != nil {
 			return nil, fmt.Errorf("compositeAEADEncHelper: failed to serialize gcm key format, error: %w", err)
 		}
+	case AESCBCHMACAEADTypeURL:
+		cbcKeyFormat := new(gcmpb.AesGcmKeyFormat)
+
+		err = proto.Unmarshal(k.Value, cbcKeyFormat)
+		if err != nil {
+			return nil, fmt.Errorf("compositeAEADEncHelper: failed to unmarshal cbcKeyFormat: %w", err)
+		}
+
+		tagSize = cbcsubtle.TagSize(int(cbcKeyFormat.KeySize))
+		ivSize = cbcsubtle.AESCBCIVSize
+
+		skf, err = proto.Marshal(cbcKeyFormat)
+		if err != nil {
+			return nil, fmt.Errorf("compositeAEADEncHelper: failed to serialize cbc key format, error: %w", err)
+		}
 	case ChaCha20Poly1305TypeURL:
 		tagSize = poly1305.TagSize
 		ivSize = chacha20poly1305.NonceSize
@@ -187,7 +207,7 @@ func (r *RegisterCompositeAEADEncHelper) getSerializedKey(symmetricKeyValue []by
 	)
 
 	switch r.encKeyURL {
-	case AESGCMTypeURL:
+	case AESGCMTypeURL, AESCBCHMACAEADTypeURL: // both keys are serialized as AesGcmKey protos
 		sk, err = r.getSerializedAESGCMKey(symmetricKeyValue)
 		if err != nil {
 			return nil, fmt.Errorf("registerCompositeAEADEncHelper: failed to serialize key, error: %w", err)
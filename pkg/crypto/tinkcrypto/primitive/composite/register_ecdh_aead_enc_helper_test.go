@@ -20,6 +20,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/poly1305"
+
+	cbcaead "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead"
+	cbcsubtle "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
 )
 
 func newKeyTemplates() []*tinkpb.KeyTemplate {
@@ -28,6 +31,7 @@ func newKeyTemplates() []*tinkpb.KeyTemplate {
 		aead.XChaCha20Poly1305KeyTemplate(),
 		aead.AES256GCMKeyTemplate(),
 		aead.AES128GCMKeyTemplate(),
+		cbcaead.AES128CBCHMACSHA256KeyTemplate(),
 	}
 }
 
@@ -48,8 +52,15 @@ func TestCipherGetters(t *testing.T) {
 		case XChaCha20Poly1305TypeURL:
 			require.EqualValues(t, chacha20poly1305.NonceSizeX, rDem.GetIVSize())
 			require.EqualValues(t, poly1305.TagSize, rDem.GetTagSize())
+		case AESCBCHMACAEADTypeURL:
+			require.EqualValues(t, cbcsubtle.AESCBCIVSize, rDem.GetIVSize())
+			require.EqualValues(t, 16, rDem.GetTagSize())
 		}
 	}
+
+	rDem, err := NewRegisterCompositeAEADEncHelper(cbcaead.AES256CBCHMACSHA512KeyTemplate())
+	require.NoError(t, err)
+	require.EqualValues(t, 32, rDem.GetTagSize())
 }
 
 func TestUnsupportedKeyTemplates(t *testing.T) {
@@ -59,6 +70,7 @@ func TestUnsupportedKeyTemplates(t *testing.T) {
 		{TypeUrl: "some url", Value: []byte{0}},
 		{TypeUrl: AESGCMTypeURL},
 		{TypeUrl: AESGCMTypeURL, Value: []byte("123")},
+		{TypeUrl: AESCBCHMACAEADTypeURL, Value: []byte("123")},
 	}
 
 	for _, l := range uTemplates {
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 
 	"github.com/golang/protobuf/proto"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
@@ -21,6 +22,7 @@ import (
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 )
 
 func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
@@ -57,12 +59,12 @@ func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
 
 		var c elliptic.Curve
 
-		c, err = hybrid.GetCurve(pbKey.PublicKey.Params.KwParams.CurveType.String())
+		c, err = cryptoutil.GetCurve(cryptoutil.CurveName(pbKey.PublicKey.Params.KwParams.CurveType))
 		if err != nil {
 			return nil, fmt.Errorf("extractPrivKey: invalid key: %w", err)
 		}
 
-		return hybrid.GetECPrivateKey(c, pbKey.KeyValue), nil
+		return getECPrivateKey(c, pbKey.KeyValue), nil
 	case x25519ECDHKWPrivateKeyTypeURL:
 		pbKey := new(ecdhpb.EcdhAeadPrivateKey)
 
@@ -81,6 +83,23 @@ func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
 	return nil, fmt.Errorf("extractPrivKey: can't extract unsupported private key '%s'", primaryKey.KeyData.TypeUrl)
 }
 
+// getECPrivateKey is similar to hybrid.GetECPrivateKey() but computes the public point with c.ScalarBaseMult() instead
+// of the generic c.Params() implementation, which only supports curves with a = -3 (ie it fails with secp256k1).
+func getECPrivateKey(c elliptic.Curve, d []byte) *hybrid.ECPrivateKey {
+	x, y := c.ScalarBaseMult(d)
+
+	return &hybrid.ECPrivateKey{
+		PublicKey: hybrid.ECPublicKey{
+			Curve: c,
+			Point: hybrid.ECPoint{
+				X: x,
+				Y: y,
+			},
+		},
+		D: new(big.Int).SetBytes(d),
+	}
+}
+
 func hybridECPrivToECDSAKey(hybridEcPriv *hybrid.ECPrivateKey) *ecdsa.PrivateKey {
 	return &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{
@@ -17,7 +17,6 @@ import (
 	"errors"
 	"fmt"
 
-	hybrid "github.com/google/tink/go/hybrid/subtle"
 	josecipher "github.com/square/go-jose/v3/cipher"
 	"golang.org/x/crypto/chacha20poly1305"
 
@@ -30,16 +29,16 @@ type keyWrapper interface {
 	createPrimitive(key []byte) (interface{}, error)
 	wrap(blockPrimitive interface{}, cek []byte) ([]byte, error)
 	unwrap(blockPrimitive interface{}, encryptedKey []byte) ([]byte, error)
-	deriveSender1Pu(kwAlg string, apu, apv []byte, ephemeralPriv, senderPrivKey, recPubKey interface{},
+	deriveSender1Pu(kwAlg string, apu, apv, tag []byte, ephemeralPriv, senderPrivKey, recPubKey interface{},
 		keySize int) ([]byte, error)
-	deriveRecipient1Pu(kwAlg string, apu, apv []byte, ephemeralPub, senderPubKey, recPrivKey interface{},
+	deriveRecipient1Pu(kwAlg string, apu, apv, tag []byte, ephemeralPub, senderPubKey, recPrivKey interface{},
 		keySize int) ([]byte, error)
 }
 
 type ecKWSupport struct{}
 
 func (w *ecKWSupport) getCurve(curve string) (elliptic.Curve, error) {
-	return hybrid.GetCurve(curve)
+	return cryptoutil.GetCurve(curve)
 }
 
 func (w *ecKWSupport) generateKey(curve elliptic.Curve) (interface{}, error) {
@@ -68,7 +67,7 @@ func (w *ecKWSupport) unwrap(block interface{}, encryptedKey []byte) ([]byte, er
 	return josecipher.KeyUnwrap(blockCipher, encryptedKey)
 }
 
-func (w *ecKWSupport) deriveSender1Pu(alg string, apu, apv []byte, ephemeralPriv, senderPrivKey interface{},
+func (w *ecKWSupport) deriveSender1Pu(alg string, apu, apv, tag []byte, ephemeralPriv, senderPrivKey interface{},
 	recPubKey interface{}, keySize int) ([]byte, error) {
 	ephemeralPrivEC, ok := ephemeralPriv.(*ecdsa.PrivateKey)
 	if !ok {
@@ -92,10 +91,10 @@ func (w *ecKWSupport) deriveSender1Pu(alg string, apu, apv []byte, ephemeralPriv
 	ze := josecipher.DeriveECDHES(alg, apu, apv, ephemeralPrivEC, recPubKeyEC, keySize)
 	zs := josecipher.DeriveECDHES(alg, apu, apv, senderPrivKeyEC, recPubKeyEC, keySize)
 
-	return derive1Pu(alg, ze, zs, apu, apv, keySize), nil
+	return derive1Pu(alg, ze, zs, apu, apv, tag, keySize), nil
 }
 
-func (w *ecKWSupport) deriveRecipient1Pu(alg string, apu, apv []byte, ephemeralPub, senderPubKey interface{},
+func (w *ecKWSupport) deriveRecipient1Pu(alg string, apu, apv, tag []byte, ephemeralPub, senderPubKey interface{},
 	recPrivKey interface{}, keySize int) ([]byte, error) {
 	ephemeralPubEC, ok := ephemeralPub.(*ecdsa.PublicKey)
 	if !ok {
@@ -120,7 +119,7 @@ func (w *ecKWSupport) deriveRecipient1Pu(alg string, apu, apv []byte, ephemeralP
 	ze := josecipher.DeriveECDHES(alg, apu, apv, recPrivKeyEC, ephemeralPubEC, keySize)
 	zs := josecipher.DeriveECDHES(alg, apu, apv, recPrivKeyEC, senderPubKeyEC, keySize)
 
-	return derive1Pu(alg, ze, zs, apu, apv, keySize), nil
+	return derive1Pu(alg, ze, zs, apu, apv, tag, keySize), nil
 }
 
 type okpKWSupport struct{}
@@ -188,7 +187,7 @@ func (o *okpKWSupport) unwrap(aead interface{}, encryptedKey []byte) ([]byte, er
 	return cek, nil
 }
 
-func (o *okpKWSupport) deriveSender1Pu(kwAlg string, apu, apv []byte, ephemeralPriv, senderPrivKey interface{},
+func (o *okpKWSupport) deriveSender1Pu(kwAlg string, apu, apv, tag []byte, ephemeralPriv, senderPrivKey interface{},
 	recPubKey interface{}, _ int) ([]byte, error) {
 	ephemeralPrivOKP, ok := ephemeralPriv.([]byte)
 	if !ok {
@@ -224,10 +223,10 @@ func (o *okpKWSupport) deriveSender1Pu(kwAlg string, apu, apv []byte, ephemeralP
 		return nil, fmt.Errorf("deriveSender1Pu: derive25519KEK with sender key failed: %w", err)
 	}
 
-	return derive1Pu(kwAlg, ze, zs, apu, apv, chacha20poly1305.KeySize), nil
+	return derive1Pu(kwAlg, ze, zs, apu, apv, tag, chacha20poly1305.KeySize), nil
 }
 
-func (o *okpKWSupport) deriveRecipient1Pu(kwAlg string, apu, apv []byte, ephemeralPub, senderPubKey interface{},
+func (o *okpKWSupport) deriveRecipient1Pu(kwAlg string, apu, apv, tag []byte, ephemeralPub, senderPubKey interface{},
 	recPrivKey interface{}, _ int) ([]byte, error) {
 	ephemeralPubOKP, ok := ephemeralPub.([]byte)
 	if !ok {
@@ -263,10 +262,12 @@ func (o *okpKWSupport) deriveRecipient1Pu(kwAlg string, apu, apv []byte, ephemer
 		return nil, fmt.Errorf("deriveRecipient1Pu: derive25519KEK with sender key failed: %w", err)
 	}
 
-	return derive1Pu(kwAlg, ze, zs, apu, apv, chacha20poly1305.KeySize), nil
+	return derive1Pu(kwAlg, ze, zs, apu, apv, tag, chacha20poly1305.KeySize), nil
 }
 
-func derive1Pu(kwAlg string, ze, zs, apu, apv []byte, keySize int) []byte {
+// derive1Pu derives a KEK using ECDH-1PU's Concat KDF. When tag is set, it is bound to the KEK by appending its
+// length and value to SuppPubInfo as per ECDH-1PU draft 04 (used with AES-CBC-HMAC content encryption).
+func derive1Pu(kwAlg string, ze, zs, apu, apv, tag []byte, keySize int) []byte {
 	round1 := make([]byte, 4)
 	binary.BigEndian.PutUint32(round1, uint32(1))
 
@@ -284,6 +285,10 @@ func derive1Pu(kwAlg string, ze, zs, apu, apv []byte, keySize int) []byte {
 	byteLen := 8
 	binary.BigEndian.PutUint32(supPubInfo, uint32(keySize)*uint32(byteLen))
 
+	if len(tag) > 0 {
+		supPubInfo = append(supPubInfo, cryptoutil.LengthPrefix(tag)...)
+	}
+
 	reader := josecipher.NewConcatKDF(crypto.SHA256, z, algID, ptyUInfo, ptyVInfo, supPubInfo, []byte{})
 
 	kek := make([]byte, keySize)
@@ -24,43 +24,43 @@ func Test_ecKWSupportFailures(t *testing.T) {
 	_, err = ecKW.unwrap("badCipherBlockType", []byte(""))
 	require.EqualError(t, err, "unwrap support: EC wrap with invalid cipher block type")
 
-	_, err = ecKW.deriveSender1Pu("", nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
+	_, err = ecKW.deriveSender1Pu("", nil, nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
 	require.EqualError(t, err, "deriveSender1Pu: ephemeral key not ECDSA type")
 
-	_, err = ecKW.deriveSender1Pu("", nil, nil, &ecdsa.PrivateKey{}, "badSenderPrivKeyType", nil, 0)
+	_, err = ecKW.deriveSender1Pu("", nil, nil, nil, &ecdsa.PrivateKey{}, "badSenderPrivKeyType", nil, 0)
 	require.EqualError(t, err, "deriveSender1Pu: sender key not ECDSA type")
 
-	_, err = ecKW.deriveSender1Pu("", nil, nil, &ecdsa.PrivateKey{}, &ecdsa.PrivateKey{}, "badSenderPrivKeyType", 0)
+	_, err = ecKW.deriveSender1Pu("", nil, nil, nil, &ecdsa.PrivateKey{}, &ecdsa.PrivateKey{}, "badSenderPrivKeyType", 0)
 	require.EqualError(t, err, "deriveSender1Pu: recipient key not ECDSA type")
 
-	_, err = ecKW.deriveSender1Pu("", nil, nil, &ecdsa.PrivateKey{
+	_, err = ecKW.deriveSender1Pu("", nil, nil, nil, &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()},
 	}, &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{Curve: elliptic.P521()},
 	}, &ecdsa.PublicKey{Curve: elliptic.P521()}, 0)
 	require.EqualError(t, err, "deriveSender1Pu: recipient, sender and ephemeral key are not on the same curve")
 
-	_, err = ecKW.deriveSender1Pu("", nil, nil, &ecdsa.PrivateKey{
+	_, err = ecKW.deriveSender1Pu("", nil, nil, nil, &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{Curve: elliptic.P521()},
 	}, &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()},
 	}, &ecdsa.PublicKey{Curve: elliptic.P521()}, 0)
 	require.EqualError(t, err, "deriveSender1Pu: recipient, sender and ephemeral key are not on the same curve")
 
-	_, err = ecKW.deriveRecipient1Pu("", nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
+	_, err = ecKW.deriveRecipient1Pu("", nil, nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: ephemeral key not ECDSA type")
 
-	_, err = ecKW.deriveRecipient1Pu("", nil, nil, &ecdsa.PublicKey{}, "badSenderPrivKeyType", nil, 0)
+	_, err = ecKW.deriveRecipient1Pu("", nil, nil, nil, &ecdsa.PublicKey{}, "badSenderPrivKeyType", nil, 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: sender key not ECDSA type")
 
-	_, err = ecKW.deriveRecipient1Pu("", nil, nil, &ecdsa.PublicKey{}, &ecdsa.PublicKey{}, "badSenderPrivKeyType", 0)
+	_, err = ecKW.deriveRecipient1Pu("", nil, nil, nil, &ecdsa.PublicKey{}, &ecdsa.PublicKey{}, "badSenderPrivKeyType", 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: recipient key not ECDSA type")
 
-	_, err = ecKW.deriveRecipient1Pu("", nil, nil, &ecdsa.PublicKey{Curve: elliptic.P521()},
+	_, err = ecKW.deriveRecipient1Pu("", nil, nil, nil, &ecdsa.PublicKey{Curve: elliptic.P521()},
 		&ecdsa.PublicKey{Curve: elliptic.P521()}, &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()}}, 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: recipient, sender and ephemeral key are not on the same curve")
 
-	_, err = ecKW.deriveRecipient1Pu("", nil, nil, &ecdsa.PublicKey{Curve: elliptic.P521()},
+	_, err = ecKW.deriveRecipient1Pu("", nil, nil, nil, &ecdsa.PublicKey{Curve: elliptic.P521()},
 		&ecdsa.PublicKey{Curve: elliptic.P256()}, &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: elliptic.P521()}}, 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: recipient, sender and ephemeral key are not on the same curve")
 }
@@ -95,16 +95,16 @@ func Test_okpKWSupportFailures(t *testing.T) {
 	_, err = okpKW.unwrap(XC20PPrimitive, []byte("badEncryptedKeyLargerThankNonceSize"))
 	require.EqualError(t, err, "unwrap support: OKP failed to unwrap key: chacha20poly1305: message authentication failed")
 
-	_, err = okpKW.deriveSender1Pu("", nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
+	_, err = okpKW.deriveSender1Pu("", nil, nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
 	require.EqualError(t, err, "deriveSender1Pu: ephemeral key not OKP type")
 
-	_, err = okpKW.deriveSender1Pu("", nil, nil, []byte{}, "badSenderPrivKeyType", nil, 0)
+	_, err = okpKW.deriveSender1Pu("", nil, nil, nil, []byte{}, "badSenderPrivKeyType", nil, 0)
 	require.EqualError(t, err, "deriveSender1Pu: sender key not OKP type")
 
-	_, err = okpKW.deriveSender1Pu("", nil, nil, []byte{}, []byte{}, "badSenderPrivKeyType", 0)
+	_, err = okpKW.deriveSender1Pu("", nil, nil, nil, []byte{}, []byte{}, "badSenderPrivKeyType", 0)
 	require.EqualError(t, err, "deriveSender1Pu: recipient key not OKP type")
 
-	_, err = okpKW.deriveSender1Pu("", nil, nil, []byte{}, []byte{}, []byte{}, 0)
+	_, err = okpKW.deriveSender1Pu("", nil, nil, nil, []byte{}, []byte{}, []byte{}, 0)
 	require.EqualError(t, err, "deriveSender1Pu: derive25519KEK with ephemeral key failed: bad input point: "+
 		"low order point")
 
@@ -118,22 +118,22 @@ func Test_okpKWSupportFailures(t *testing.T) {
 		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
 	}
 
-	_, err = okpKW.deriveSender1Pu("", nil, nil, derivedKEK, kekBytes, lowOrderPoint, 0)
+	_, err = okpKW.deriveSender1Pu("", nil, nil, nil, derivedKEK, kekBytes, lowOrderPoint, 0)
 	require.EqualError(t, err, "deriveSender1Pu: derive25519KEK with ephemeral key failed: bad input point: "+
 		"low order point")
 	// can't reproduce key derivation error with sender key because recipient public key as lowOrderPoint fails for
 	// ephemeral key derivation. ie sender key derivation failure only fails if ephemeral key derivation fails.
 
-	_, err = okpKW.deriveRecipient1Pu("", nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
+	_, err = okpKW.deriveRecipient1Pu("", nil, nil, nil, "badEphemeralPrivKeyType", nil, nil, 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: ephemeral key not OKP type")
 
-	_, err = okpKW.deriveRecipient1Pu("", nil, nil, []byte{}, "badSenderPrivKeyType", nil, 0)
+	_, err = okpKW.deriveRecipient1Pu("", nil, nil, nil, []byte{}, "badSenderPrivKeyType", nil, 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: sender key not OKP type")
 
-	_, err = okpKW.deriveRecipient1Pu("", nil, nil, []byte{}, []byte{}, "badSenderPrivKeyType", 0)
+	_, err = okpKW.deriveRecipient1Pu("", nil, nil, nil, []byte{}, []byte{}, "badSenderPrivKeyType", 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: recipient key not OKP type")
 
-	_, err = okpKW.deriveRecipient1Pu("", nil, nil, []byte{}, []byte{}, []byte{}, 0)
+	_, err = okpKW.deriveRecipient1Pu("", nil, nil, nil, []byte{}, []byte{}, []byte{}, 0)
 	require.EqualError(t, err, "deriveRecipient1Pu: derive25519KEK with ephemeral key failed: bad input point:"+
 		" low order point")
 }
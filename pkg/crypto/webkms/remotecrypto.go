@@ -23,6 +23,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	webkmsimpl "github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
 	spi "github.com/hyperledger/aries-framework-go/spi/log"
 )
@@ -69,6 +70,26 @@ type deriveProofReq struct {
 	RevealedIndexes []int    `json:"revealedIndexes,omitempty"`
 }
 
+type blindMessagesReq struct {
+	Messages      []string `json:"messages,omitempty"`
+	Indexes       []int    `json:"indexes,omitempty"`
+	MessagesCount int      `json:"messagesCount,omitempty"`
+	Nonce         string   `json:"nonce,omitempty"`
+}
+
+type blindMessagesResp struct {
+	BlindContext   string `json:"blindContext,omitempty"`
+	BlindingFactor string `json:"blindingFactor,omitempty"`
+}
+
+type blindSignReq struct {
+	BlindContext  string   `json:"blindContext,omitempty"`
+	Messages      []string `json:"messages,omitempty"`
+	Indexes       []int    `json:"indexes,omitempty"`
+	MessagesCount int      `json:"messagesCount,omitempty"`
+	Nonce         string   `json:"nonce,omitempty"`
+}
+
 type signResp struct {
 	Signature string `json:"signature,omitempty"`
 }
@@ -135,6 +156,8 @@ const (
 	verifyMultiURI = "/verifymulti"
 	deriveProofURI = "/deriveproof"
 	verifyProofURI = "/verifyproof"
+	blindMsgsURI   = "/blindmessages"
+	blindSignURI   = "/blindsign"
 )
 
 // New creates a new remoteCrypto instance using http client connecting to keystoreURL.
@@ -181,6 +204,13 @@ func (r *RemoteCrypto) doHTTPRequest(method, destination string, mReq []byte) (*
 		}
 	}
 
+	if r.opts.CapabilityInvoker != nil {
+		e := r.opts.CapabilityInvoker.InvokeCapability(httpReq, webkmsimpl.CapabilityAction(httpReq))
+		if e != nil {
+			return nil, fmt.Errorf("invoke capability error: %w", e)
+		}
+	}
+
 	resp, err := r.httpClient.Do(httpReq)
 
 	// TODO switch to Debug once perf testing with remote server is done.
@@ -820,6 +850,144 @@ func (r *RemoteCrypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte
 	return keyBytes, nil
 }
 
+// BlindMessages will commit to messages found at indexes among messagesCount messages to be BBS+ signed with the
+// private key matching the signer's public key handle found at signerKeyURL.
+// returns:
+// 		blind signature context in []byte
+// 		blinding factor in []byte
+//		error in case of errors
+func (r *RemoteCrypto) BlindMessages(messages [][]byte, indexes []int, messagesCount int, nonce []byte,
+	signerKeyURL interface{}) ([]byte, []byte, error) {
+	startBlindMessages := time.Now()
+	destination := fmt.Sprintf("%s", signerKeyURL) + blindMsgsURI
+
+	var encMessages []string
+	for _, msg := range messages {
+		encMessages = append(encMessages, base64.URLEncoding.EncodeToString(msg))
+	}
+
+	bReq := blindMessagesReq{
+		Messages:      encMessages,
+		Indexes:       indexes,
+		MessagesCount: messagesCount,
+		Nonce:         base64.URLEncoding.EncodeToString(nonce),
+	}
+
+	httpReqBytes, err := r.marshalFunc(bReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal request for BBS+ Blind messages failed [%s, %w]", destination, err)
+	}
+
+	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("posting BBS+ Blind messages failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "BBS+ Blind Messages")
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response for BBS+ Blind messages failed [%s, %w]", destination, err)
+	}
+
+	httpResp := &blindMessagesResp{}
+
+	err = r.unmarshalFunc(respBody, httpResp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unmarshal response for BBS+ Blind messages failed [%s, %w]", destination, err)
+	}
+
+	blindContext, err := base64.URLEncoding.DecodeString(httpResp.BlindContext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blindingFactor, err := base64.URLEncoding.DecodeString(httpResp.BlindingFactor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// TODO switch to Debug once perf testing with remote server is done.
+	logger.Infof("overall BBS+ Blind messages duration: %s", time.Since(startBlindMessages))
+
+	return blindContext, blindingFactor, nil
+}
+
+// BlindSign will create a blind BBS+ signature of messagesCount messages using the signer's private key handle found
+// at signerKeyURL. messages are known to the signer at indexes while the remaining ones are committed to in
+// blindContext.
+// returns:
+// 		blind signature in []byte
+//		error in case of errors
+func (r *RemoteCrypto) BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int,
+	nonce []byte, signerKeyURL interface{}) ([]byte, error) {
+	startBlindSign := time.Now()
+	destination := fmt.Sprintf("%s", signerKeyURL) + blindSignURI
+
+	var encMessages []string
+	for _, msg := range messages {
+		encMessages = append(encMessages, base64.URLEncoding.EncodeToString(msg))
+	}
+
+	bReq := blindSignReq{
+		BlindContext:  base64.URLEncoding.EncodeToString(blindContext),
+		Messages:      encMessages,
+		Indexes:       indexes,
+		MessagesCount: messagesCount,
+		Nonce:         base64.URLEncoding.EncodeToString(nonce),
+	}
+
+	httpReqBytes, err := r.marshalFunc(bReq)
+	if err != nil {
+		return nil, fmt.Errorf("marshal signature request for BBS+ Blind sign failed [%s, %w]", destination, err)
+	}
+
+	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	if err != nil {
+		return nil, fmt.Errorf("posting BBS+ Blind sign message failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "BBS+ Blind Sign")
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read signature response for BBS+ Blind sign failed [%s, %w]", destination, err)
+	}
+
+	httpResp := &signResp{}
+
+	err = r.unmarshalFunc(respBody, httpResp)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal signature for BBS+ Blind sign failed [%s, %w]", destination, err)
+	}
+
+	sigBytes, err := base64.URLEncoding.DecodeString(httpResp.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO switch to Debug once perf testing with remote server is done.
+	logger.Infof("overall BBS+ Blind sign duration: %s", time.Since(startBlindSign))
+
+	return sigBytes, nil
+}
+
+// UnblindSignature will unblind a BBS+ signature created by BlindSign() using the holder's blindingFactor. It does
+// not use any key, the signature is unblinded locally.
+// returns:
+// 		signature in []byte
+//		error in case of errors
+func (r *RemoteCrypto) UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error) {
+	sigBytes, err := bbs12381g2pub.New().UnblindSignature(blindSignature, blindingFactor)
+	if err != nil {
+		return nil, fmt.Errorf("BBS+ unblind signature: %w", err)
+	}
+
+	return sigBytes, nil
+}
+
 // closeResponseBody closes the response body.
 //nolint: interfacer // don't want to add test stretcher logger here
 func closeResponseBody(respBody io.Closer, logger spi.Logger, action string) {
@@ -1166,6 +1166,99 @@ func TestBBSSignVerify_DeriveProofVerifyProof(t *testing.T) {
 	})
 }
 
+func TestBBSBlindSign(t *testing.T) {
+	kh, err := keyset.NewHandle(bbs.BLS12381G2KeyTemplate())
+	require.NoError(t, err)
+
+	hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err = processBBSPOSTRequest(w, r, kh)
+		require.NoError(t, err)
+	})
+
+	server, url, client := CreateMockHTTPServerAndClient(t, hf)
+
+	defer func() {
+		e := server.Close()
+		require.NoError(t, e)
+	}()
+
+	defaultKeystoreURL := fmt.Sprintf("%s/%s", strings.ReplaceAll(webkmsimpl.KeystoreEndpoint,
+		"{serverEndpoint}", url), defaultKeyStoreID)
+	defaultKeyURL := defaultKeystoreURL + "/keys/" + defaultKID
+	rCrypto := New(defaultKeystoreURL, client)
+	linkSecret := []byte("link secret")
+	msg := [][]byte{[]byte("lorem ipsum"), []byte("dolor sit amet,"), linkSecret}
+	nonce := []byte("nonce")
+
+	// test successful BBS+ BlindMessages/BlindSign/UnblindSignature
+	blindContext, blindingFactor, err := rCrypto.BlindMessages([][]byte{linkSecret}, []int{2}, len(msg), nonce,
+		defaultKeyURL)
+	require.NoError(t, err)
+
+	blindSig, err := rCrypto.BlindSign(blindContext, msg[:2], []int{0, 1}, len(msg), nonce, defaultKeyURL)
+	require.NoError(t, err)
+
+	sig, err := rCrypto.UnblindSignature(blindSig, blindingFactor)
+	require.NoError(t, err)
+
+	err = rCrypto.VerifyMulti(msg, sig, defaultKeyURL)
+	require.NoError(t, err)
+
+	t.Run("BBS+ Blind messages Post request failure", func(t *testing.T) {
+		tmpCrypto := New(defaultKeystoreURL, &http.Client{})
+
+		_, _, err = tmpCrypto.BlindMessages(nil, nil, 0, nil, defaultKeyURL)
+		require.EqualError(t, err, fmt.Errorf("posting BBS+ Blind messages failed [%s, Post \"%s\": x509: "+
+			"certificate signed by unknown authority]", defaultKeyURL+blindMsgsURI, defaultKeyURL+blindMsgsURI).Error())
+	})
+
+	t.Run("BBS+ Blind sign Post request failure", func(t *testing.T) {
+		tmpCrypto := New(defaultKeystoreURL, &http.Client{})
+
+		_, err = tmpCrypto.BlindSign(nil, nil, nil, 0, nil, defaultKeyURL)
+		require.EqualError(t, err, fmt.Errorf("posting BBS+ Blind sign message failed [%s, Post \"%s\": x509: "+
+			"certificate signed by unknown authority]", defaultKeyURL+blindSignURI, defaultKeyURL+blindSignURI).Error())
+	})
+
+	t.Run("BBS+ Blind messages json marshal and unmarshal failures", func(t *testing.T) {
+		remoteCrypto2 := New(defaultKeystoreURL, client)
+
+		remoteCrypto2.marshalFunc = failingMarshal
+		_, _, err = remoteCrypto2.BlindMessages([][]byte{linkSecret}, []int{2}, len(msg), nonce, defaultKeyURL)
+		require.EqualError(t, err, fmt.Errorf("marshal request for BBS+ Blind messages failed [%s, %w]",
+			defaultKeyURL+blindMsgsURI, errFailingMarshal).Error())
+
+		remoteCrypto2 = New(defaultKeystoreURL, client)
+
+		remoteCrypto2.unmarshalFunc = failingUnmarshal
+		_, _, err = remoteCrypto2.BlindMessages([][]byte{linkSecret}, []int{2}, len(msg), nonce, defaultKeyURL)
+		require.EqualError(t, err, fmt.Errorf("unmarshal response for BBS+ Blind messages failed [%s, %w]",
+			defaultKeyURL+blindMsgsURI, errFailingUnmarshal).Error())
+	})
+
+	t.Run("BBS+ Blind sign json marshal and unmarshal failures", func(t *testing.T) {
+		remoteCrypto2 := New(defaultKeystoreURL, client)
+
+		remoteCrypto2.marshalFunc = failingMarshal
+		_, err = remoteCrypto2.BlindSign(blindContext, msg[:2], []int{0, 1}, len(msg), nonce, defaultKeyURL)
+		require.EqualError(t, err, fmt.Errorf("marshal signature request for BBS+ Blind sign failed [%s, %w]",
+			defaultKeyURL+blindSignURI, errFailingMarshal).Error())
+
+		remoteCrypto2 = New(defaultKeystoreURL, client)
+
+		remoteCrypto2.unmarshalFunc = failingUnmarshal
+		_, err = remoteCrypto2.BlindSign(blindContext, msg[:2], []int{0, 1}, len(msg), nonce, defaultKeyURL)
+		require.EqualError(t, err, fmt.Errorf("unmarshal signature for BBS+ Blind sign failed [%s, %w]",
+			defaultKeyURL+blindSignURI, errFailingUnmarshal).Error())
+	})
+
+	t.Run("BBS+ unblind signature failure", func(t *testing.T) {
+		_, err = rCrypto.UnblindSignature(blindSig, []byte("invalid"))
+		require.EqualError(t, err, "BBS+ unblind signature: parse blinding factor: invalid size of signature "+
+			"blinding")
+	})
+}
+
 // nolint:gocyclo
 func processBBSPOSTRequest(w http.ResponseWriter, r *http.Request, sigKH *keyset.Handle) error {
 	if valid := validateHTTPMethod(w, r); !valid {
@@ -1209,9 +1302,130 @@ func processBBSPOSTRequest(w http.ResponseWriter, r *http.Request, sigKH *keyset
 		}
 	}
 
+	if strings.LastIndex(r.URL.Path, blindMsgsURI) == len(r.URL.Path)-len(blindMsgsURI) {
+		err = bbsBlindMessagesPOSTHandle(w, reqBody, sigKH)
+		if err != nil {
+			return err
+		}
+	}
+
+	if strings.LastIndex(r.URL.Path, blindSignURI) == len(r.URL.Path)-len(blindSignURI) {
+		err = bbsBlindSignPOSTHandle(w, reqBody, sigKH)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// nolint: interfacer // unnecessary for tests to set w io.Writer, this is a helper for tests only
+func bbsBlindMessagesPOSTHandle(w http.ResponseWriter, reqBody []byte, sigKH *keyset.Handle) error {
+	blindReq := &blindMessagesReq{}
+
+	err := json.Unmarshal(reqBody, blindReq)
+	if err != nil {
+		return err
+	}
+
+	messages, err := decodeBBSMessages(blindReq.Messages)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := base64.URLEncoding.DecodeString(blindReq.Nonce)
+	if err != nil {
+		return err
+	}
+
+	pubKH, err := sigKH.Public()
+	if err != nil {
+		return err
+	}
+
+	verifier, err := bbs.NewVerifier(pubKH)
+	if err != nil {
+		return fmt.Errorf("create new BBS+ verifier: %w", err)
+	}
+
+	blindContext, blindingFactor, err := verifier.BlindMessages(messages, blindReq.Indexes, blindReq.MessagesCount,
+		nonce)
+	if err != nil {
+		return fmt.Errorf("BBS+ blind messages: %w", err)
+	}
+
+	mResp, err := json.Marshal(&blindMessagesResp{
+		BlindContext:   base64.URLEncoding.EncodeToString(blindContext),
+		BlindingFactor: base64.URLEncoding.EncodeToString(blindingFactor),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(mResp)
+
+	return err
+}
+
+// nolint: interfacer // unnecessary for tests to set w io.Writer, this is a helper for tests only
+func bbsBlindSignPOSTHandle(w http.ResponseWriter, reqBody []byte, sigKH *keyset.Handle) error {
+	blindReq := &blindSignReq{}
+
+	err := json.Unmarshal(reqBody, blindReq)
+	if err != nil {
+		return err
+	}
+
+	messages, err := decodeBBSMessages(blindReq.Messages)
+	if err != nil {
+		return err
+	}
+
+	blindContext, err := base64.URLEncoding.DecodeString(blindReq.BlindContext)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := base64.URLEncoding.DecodeString(blindReq.Nonce)
+	if err != nil {
+		return err
+	}
+
+	signer, err := bbs.NewSigner(sigKH)
+	if err != nil {
+		return fmt.Errorf("create new signer: %w", err)
+	}
+
+	s, err := signer.BlindSign(blindContext, messages, blindReq.Indexes, blindReq.MessagesCount, nonce)
+	if err != nil {
+		return fmt.Errorf("blind sign msg: %w", err)
+	}
+
+	mResp, err := json.Marshal(&signResp{Signature: base64.URLEncoding.EncodeToString(s)})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(mResp)
+
+	return err
+}
+
+func decodeBBSMessages(encMessages []string) ([][]byte, error) {
+	messages := make([][]byte, len(encMessages))
+
+	for i, msg := range encMessages {
+		msgRecord, err := base64.URLEncoding.DecodeString(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		messages[i] = msgRecord
+	}
+
+	return messages, nil
+}
+
 // nolint: interfacer // unnecessary for tests to set w io.Writer, this is a helper for tests only
 func bbsSignPOSTHandle(w http.ResponseWriter, reqBody []byte, sigKH *keyset.Handle) error {
 	sigReq := &signMultiReq{}
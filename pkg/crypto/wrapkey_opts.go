@@ -9,6 +9,8 @@ package crypto
 type wrapKeyOpts struct {
 	senderKey  interface{}
 	useXC20PKW bool
+	tag        []byte
+	epk        *PrivateKey
 }
 
 // NewOpt creates a new empty wrap key option.
@@ -30,6 +32,20 @@ func (pk *wrapKeyOpts) UseXC20PKW() bool {
 	return pk.useXC20PKW
 }
 
+// Tag gets the content encryption tag bound into ECDH-1PU key wrapping.
+// Not to be used directly. It's intended for implementations of Crypto interface.
+// Use WithTag() option function below instead.
+func (pk *wrapKeyOpts) Tag() []byte {
+	return pk.tag
+}
+
+// EPK gets the ephemeral key to use for ECDH-1PU key wrapping.
+// Not to be used directly. It's intended for implementations of Crypto interface.
+// Use WithEPK() option function below instead.
+func (pk *wrapKeyOpts) EPK() *PrivateKey {
+	return pk.epk
+}
+
 // WrapKeyOpts are the crypto.Wrap key options.
 type WrapKeyOpts func(opts *wrapKeyOpts)
 
@@ -55,3 +71,22 @@ func WithXC20PKW() WrapKeyOpts {
 		opts.useXC20PKW = true
 	}
 }
+
+// WithTag option is for binding the content encryption tag of a JWE into ECDH-1PU key wrapping (and unwrapping) as per
+// https://tools.ietf.org/html/draft-madden-jose-ecdh-1pu-04#section-2.3, where the tag is appended to the KDF's
+// SuppPubInfo. It is used with WithSender() and the AES-CBC-HMAC-SHA2 content encryption algorithms, in which case the
+// content is encrypted before the cek is wrapped.
+func WithTag(tag []byte) WrapKeyOpts {
+	return func(opts *wrapKeyOpts) {
+		opts.tag = tag
+	}
+}
+
+// WithEPK option is for setting the ephemeral key of ECDH-1PU key wrapping instead of generating a new one, eg: to
+// build the JWE protected headers (which contain the epk) before encrypting the content and wrapping the cek with
+// WithTag(). The ephemeral key must have the same type and curve as the recipient key.
+func WithEPK(epk *PrivateKey) WrapKeyOpts {
+	return func(opts *wrapKeyOpts) {
+		opts.epk = epk
+	}
+}
@@ -0,0 +1,413 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package lifecycle enforces message and protocol instance lifetimes which are shared by the protocol services:
+//   - messages received after their ~timing.expires_time are rejected
+//     (https://github.com/hyperledger/aries-rfcs/tree/master/features/0032-message-timing)
+//   - messages whose ~thread.sender_order does not increase within the thread are rejected
+//     (https://github.com/hyperledger/aries-rfcs/tree/master/concepts/0008-message-id-and-threading)
+//   - protocol instances without any activity within the configured timeout are reported as stale.
+package lifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// CodeExpired is the problem-report code for a message received after its expiration time.
+	CodeExpired = "expired"
+	// CodeOutOfOrder is the problem-report code for a message received out of order.
+	CodeOutOfOrder = "out-of-order"
+	// CodeTimeout is the problem-report code for a protocol instance abandoned for inactivity.
+	CodeTimeout = "timeout"
+
+	storeNamePrefix = "lifecycle_"
+	recordTag       = "lifecycle"
+	defaultInterval = time.Minute
+
+	jsonThread   = "~thread"
+	jsonThreadID = "thid"
+)
+
+// nolint:gochecknoglobals
+var (
+	logger = log.New("aries-framework/didcomm/lifecycle")
+
+	// ErrExpired is returned when the message was received after its ~timing.expires_time.
+	ErrExpired = errors.New("message expired")
+	// ErrOutOfOrder is returned when the message ~thread.sender_order was already seen within the thread.
+	ErrOutOfOrder = errors.New("message out of order")
+	// ErrTimeout is set to the state event of a protocol instance abandoned for inactivity.
+	ErrTimeout = errors.New("protocol instance timed out")
+)
+
+// record keeps the activity of the protocol instance.
+type record struct {
+	LastActivity time.Time      `json:"last_activity"`
+	SenderOrders map[string]int `json:"sender_orders,omitempty"`
+}
+
+// Tracker keeps track of the protocol instances of a single protocol service.
+type Tracker struct {
+	store    storage.Store
+	timeout  time.Duration
+	interval time.Duration
+	now      func() time.Time
+	stop     chan struct{}
+	done     chan struct{}
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+}
+
+// Opt configures the Tracker.
+type Opt func(t *Tracker)
+
+// WithTimeout sets the inactivity period after which a protocol instance is considered stale.
+// Zero (the default) disables the stale protocol instance checks.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(t *Tracker) {
+		t.timeout = timeout
+	}
+}
+
+// WithInterval sets how often the stale protocol instances are checked. Defaults to one minute.
+func WithInterval(interval time.Duration) Opt {
+	return func(t *Tracker) {
+		t.interval = interval
+	}
+}
+
+// New returns a new Tracker for the given protocol.
+func New(p storage.Provider, protocolName string, opts ...Opt) (*Tracker, error) {
+	storeName := storeNamePrefix + protocolName
+
+	store, err := p.OpenStore(storeName)
+	if err != nil {
+		return nil, fmt.Errorf("open store: %w", err)
+	}
+
+	err = p.SetStoreConfig(storeName, storage.StoreConfiguration{TagNames: []string{recordTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store config: %w", err)
+	}
+
+	t := &Tracker{
+		store:    store,
+		interval: defaultInterval,
+		now:      time.Now,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
+}
+
+// Check verifies that the inbound message has not expired and was received in order.
+// On success, the message sender order and the protocol instance activity are recorded.
+func (t *Tracker) Check(msg service.DIDCommMsg, piID, sender string) error {
+	decorators := struct {
+		Thread *decorator.Thread `json:"~thread,omitempty"`
+		Timing *decorator.Timing `json:"~timing,omitempty"`
+	}{}
+
+	if err := msg.Decode(&decorators); err != nil {
+		return fmt.Errorf("decode decorators: %w", err)
+	}
+
+	now := t.now()
+
+	if decorators.Timing != nil && decorators.Timing.Expired(now) {
+		return fmt.Errorf("%w: expired at %s", ErrExpired, decorators.Timing.ExpiresTime.Format(time.RFC3339))
+	}
+
+	rec, err := t.getRecord(piID)
+	if err != nil {
+		return err
+	}
+
+	// sender_order is zero for the first message of the sender (and for senders which do not support ordering)
+	// so only the following messages can be checked.
+	if decorators.Thread != nil && decorators.Thread.SenderOrder > 0 {
+		order := decorators.Thread.SenderOrder
+
+		if last, ok := rec.SenderOrders[sender]; ok && order <= last {
+			return fmt.Errorf("%w: sender_order %d received after %d", ErrOutOfOrder, order, last)
+		}
+
+		rec.SenderOrders[sender] = order
+	}
+
+	rec.LastActivity = now
+
+	return t.saveRecord(piID, rec)
+}
+
+// Touch records the activity of the protocol instance.
+func (t *Tracker) Touch(piID string) error {
+	rec, err := t.getRecord(piID)
+	if err != nil {
+		return err
+	}
+
+	rec.LastActivity = t.now()
+
+	return t.saveRecord(piID, rec)
+}
+
+// Complete stops tracking the protocol instance.
+func (t *Tracker) Complete(piID string) error {
+	return t.store.Delete(piID)
+}
+
+// Stale returns the protocol instances without any activity within the timeout.
+func (t *Tracker) Stale() ([]string, error) {
+	if t.timeout <= 0 {
+		return nil, nil
+	}
+
+	records, err := t.store.Query(recordTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	deadline := t.now().Add(-t.timeout)
+
+	var stale []string
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		key, errKey := records.Key()
+		if errKey != nil {
+			return nil, fmt.Errorf("failed to get key: %w", errKey)
+		}
+
+		value, errValue := records.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("failed to get value: %w", errValue)
+		}
+
+		var rec record
+		if errUnmarshal := json.Unmarshal(value, &rec); errUnmarshal != nil {
+			return nil, fmt.Errorf("unmarshal: %w", errUnmarshal)
+		}
+
+		if rec.LastActivity.Before(deadline) {
+			stale = append(stale, key)
+		}
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	return stale, nil
+}
+
+// Start periodically passes the stale protocol instances to the given function and stops tracking them.
+// It does nothing if the timeout is not set, or if the Tracker is already started or stopped.
+func (t *Tracker) Start(abandon func(piID string) error) {
+	if t.timeout <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.started || t.stopped {
+		return
+	}
+
+	t.started = true
+
+	go func() {
+		defer close(t.done)
+
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				t.sweep(abandon)
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the stale protocol instance checks and waits for a running check to finish.
+func (t *Tracker) Stop() {
+	t.mu.Lock()
+
+	if !t.stopped {
+		t.stopped = true
+		close(t.stop)
+
+		if !t.started {
+			close(t.done)
+		}
+	}
+
+	t.mu.Unlock()
+
+	<-t.done
+}
+
+// Done returns a channel closed once the Tracker is stopped and its stale protocol instance checks have exited.
+func (t *Tracker) Done() <-chan struct{} {
+	return t.done
+}
+
+func (t *Tracker) sweep(abandon func(piID string) error) {
+	stale, err := t.Stale()
+	if err != nil {
+		logger.Errorf("stale protocol instances: %s", err)
+
+		return
+	}
+
+	for _, piID := range stale {
+		if err := abandon(piID); err != nil {
+			logger.Errorf("abandon stale protocol instance %s: %s", piID, err)
+		}
+
+		if err := t.Complete(piID); err != nil {
+			logger.Errorf("complete stale protocol instance %s: %s", piID, err)
+		}
+	}
+}
+
+func (t *Tracker) getRecord(piID string) (*record, error) {
+	rec := &record{SenderOrders: map[string]int{}}
+
+	src, err := t.store.Get(piID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return rec, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("store get: %w", err)
+	}
+
+	if err = json.Unmarshal(src, rec); err != nil {
+		return nil, fmt.Errorf("unmarshal record: %w", err)
+	}
+
+	if rec.SenderOrders == nil {
+		rec.SenderOrders = map[string]int{}
+	}
+
+	return rec, nil
+}
+
+func (t *Tracker) saveRecord(piID string, rec *record) error {
+	src, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal record: %w", err)
+	}
+
+	return t.store.Put(piID, src, storage.Tag{Name: recordTag})
+}
+
+// Reject replies to the message rejected by Check with a problem-report of the given type.
+// Problem-reports and messages rejected for other reasons are not answered.
+func Reject(messenger service.Messenger, msg service.DIDCommMsg, problemReportType, myDID, theirDID string,
+	cause error) error {
+	code, ok := rejectionCode(msg, problemReportType, cause)
+	if !ok {
+		return nil
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("threadID: %w", err)
+	}
+
+	return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
+		Type:        problemReportType,
+		Description: model.Code{Code: code},
+	}), &service.NestedReplyOpts{ThreadID: thID, MyDID: myDID, TheirDID: theirDID})
+}
+
+// RejectMsg returns the problem-report of the given type which answers the message rejected by Check, for the
+// protocol services which send their replies without a service.Messenger. It returns nil for problem-reports and
+// messages rejected for other reasons, which are not answered.
+func RejectMsg(msg service.DIDCommMsg, problemReportType string, cause error) (service.DIDCommMsgMap, error) {
+	code, ok := rejectionCode(msg, problemReportType, cause)
+	if !ok {
+		return nil, nil
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return nil, fmt.Errorf("threadID: %w", err)
+	}
+
+	report := service.NewDIDCommMsgMap(&model.ProblemReport{
+		Type:        problemReportType,
+		ID:          uuid.New().String(),
+		Description: model.Code{Code: code},
+	})
+
+	report[jsonThread] = map[string]interface{}{jsonThreadID: thID}
+
+	return report, nil
+}
+
+func rejectionCode(msg service.DIDCommMsg, problemReportType string, cause error) (string, bool) {
+	if msg.Type() == problemReportType {
+		return "", false
+	}
+
+	switch {
+	case errors.Is(cause, ErrExpired):
+		return CodeExpired, true
+	case errors.Is(cause, ErrOutOfOrder):
+		return CodeOutOfOrder, true
+	default:
+		return "", false
+	}
+}
+
+// TimeoutMsg returns the problem-report of the given type which describes the stale protocol instance.
+// The message is not sent, protocol services use it to abandon the protocol instance locally.
+func TimeoutMsg(problemReportType, piID string) service.DIDCommMsgMap {
+	msg := service.NewDIDCommMsgMap(&model.ProblemReport{
+		Type:        problemReportType,
+		ID:          uuid.New().String(),
+		Description: model.Code{Code: CodeTimeout},
+	})
+
+	msg[jsonThread] = map[string]interface{}{jsonThreadID: piID}
+
+	return msg
+}
@@ -0,0 +1,298 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+)
+
+const (
+	protocolName      = "protocol"
+	problemReportType = "https://didcomm.org/protocol/1.0/problem-report"
+	piID              = "piID"
+	sender            = "theirDID"
+	errMsg            = "test error"
+)
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		tracker, err := New(mem.NewProvider(), protocolName, WithTimeout(time.Hour), WithInterval(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, time.Hour, tracker.timeout)
+		require.Equal(t, time.Second, tracker.interval)
+	})
+
+	t.Run("open store error", func(t *testing.T) {
+		provider := storageMocks.NewMockProvider(ctrl)
+		provider.EXPECT().OpenStore(storeNamePrefix+protocolName).Return(nil, errors.New(errMsg))
+
+		_, err := New(provider, protocolName)
+		require.EqualError(t, err, "open store: "+errMsg)
+	})
+
+	t.Run("set store config error", func(t *testing.T) {
+		provider := storageMocks.NewMockProvider(ctrl)
+		provider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+		provider.EXPECT().SetStoreConfig(storeNamePrefix+protocolName, gomock.Any()).Return(errors.New(errMsg))
+
+		_, err := New(provider, protocolName)
+		require.EqualError(t, err, "failed to set store config: "+errMsg)
+	})
+}
+
+func TestTracker_Check(t *testing.T) {
+	t.Run("expired", func(t *testing.T) {
+		tracker, err := New(mem.NewProvider(), protocolName)
+		require.NoError(t, err)
+
+		err = tracker.Check(service.DIDCommMsgMap{
+			"@id":     "id",
+			"~timing": map[string]interface{}{"expires_time": "2020-01-01T00:00:00Z"},
+		}, piID, sender)
+		require.True(t, errors.Is(err, ErrExpired))
+	})
+
+	t.Run("not expired", func(t *testing.T) {
+		tracker, err := New(mem.NewProvider(), protocolName)
+		require.NoError(t, err)
+
+		require.NoError(t, tracker.Check(service.DIDCommMsgMap{
+			"@id":     "id",
+			"~timing": map[string]interface{}{"expires_time": time.Now().Add(time.Hour).Format(time.RFC3339)},
+		}, piID, sender))
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		tracker, err := New(mem.NewProvider(), protocolName)
+		require.NoError(t, err)
+
+		for _, order := range []int{0, 1, 2} {
+			require.NoError(t, tracker.Check(threadMsg(order), piID, sender))
+		}
+
+		err = tracker.Check(threadMsg(1), piID, sender)
+		require.True(t, errors.Is(err, ErrOutOfOrder))
+		require.Contains(t, err.Error(), "sender_order 1 received after 2")
+
+		// the order is tracked per sender
+		require.NoError(t, tracker.Check(threadMsg(1), piID, "another"))
+		require.NoError(t, tracker.Check(threadMsg(3), piID, sender))
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		tracker, err := New(mem.NewProvider(), protocolName)
+		require.NoError(t, err)
+
+		err = tracker.Check(service.DIDCommMsgMap{"~timing": "timing"}, piID, sender)
+		require.Contains(t, err.Error(), "decode decorators")
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Get(piID).Return(nil, errors.New(errMsg)).Times(2)
+
+		tracker := &Tracker{store: store, now: time.Now}
+
+		require.EqualError(t, tracker.Check(threadMsg(0), piID, sender), "store get: "+errMsg)
+		require.EqualError(t, tracker.Touch(piID), "store get: "+errMsg)
+	})
+}
+
+func TestTracker_Stale(t *testing.T) {
+	tracker, err := New(mem.NewProvider(), protocolName, WithTimeout(time.Hour))
+	require.NoError(t, err)
+
+	require.NoError(t, tracker.Touch("old"))
+	require.NoError(t, tracker.Touch("recent"))
+	require.NoError(t, tracker.Touch("completed"))
+	require.NoError(t, tracker.Complete("completed"))
+
+	tracker.now = func() time.Time { return time.Now().Add(30 * time.Minute) }
+	require.NoError(t, tracker.Touch("recent"))
+
+	tracker.now = func() time.Time { return time.Now().Add(70 * time.Minute) }
+
+	stale, err := tracker.Stale()
+	require.NoError(t, err)
+	require.Equal(t, []string{"old"}, stale)
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled, err := New(mem.NewProvider(), protocolName)
+		require.NoError(t, err)
+
+		require.NoError(t, disabled.Touch(piID))
+
+		disabled.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+		stale, err := disabled.Stale()
+		require.NoError(t, err)
+		require.Empty(t, stale)
+	})
+}
+
+func TestTracker_Start(t *testing.T) {
+	tracker, err := New(mem.NewProvider(), protocolName,
+		WithTimeout(time.Nanosecond), WithInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	require.NoError(t, tracker.Touch(piID))
+
+	abandoned := make(chan string)
+
+	tracker.Start(func(piID string) error {
+		abandoned <- piID
+
+		return errors.New(errMsg)
+	})
+	defer tracker.Stop()
+
+	select {
+	case id := <-abandoned:
+		require.Equal(t, piID, id)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	tracker.Stop()
+
+	select {
+	case <-tracker.Done():
+	default:
+		t.Fatal("stale protocol instance checks are still running")
+	}
+
+	// stopping is idempotent, and a stopped tracker can not be restarted
+	tracker.Stop()
+	tracker.Start(func(string) error { return nil })
+
+	t.Run("stop a tracker which is not started", func(t *testing.T) {
+		tracker, err := New(mem.NewProvider(), protocolName)
+		require.NoError(t, err)
+
+		tracker.Start(func(string) error { return nil })
+		tracker.Stop()
+
+		<-tracker.Done()
+	})
+}
+
+func TestReject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msg := service.DIDCommMsgMap{"@id": "id", "@type": "type"}
+
+	t.Run("expired", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, problemReportType, r.Type)
+				require.Equal(t, CodeExpired, r.Description.Code)
+				require.Equal(t, &service.NestedReplyOpts{ThreadID: "id", MyDID: "myDID", TheirDID: sender}, opts)
+
+				return nil
+			})
+
+		require.NoError(t, Reject(messenger, msg, problemReportType, "myDID", sender, ErrExpired))
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, _ *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, CodeOutOfOrder, r.Description.Code)
+
+				return nil
+			})
+
+		require.NoError(t, Reject(messenger, msg, problemReportType, "myDID", sender, ErrOutOfOrder))
+	})
+
+	t.Run("not answered", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+
+		require.NoError(t, Reject(messenger, msg, problemReportType, "", "", errors.New(errMsg)))
+		require.NoError(t, Reject(messenger, service.DIDCommMsgMap{"@id": "id", "@type": problemReportType},
+			problemReportType, "", "", ErrExpired))
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		err := Reject(nil, service.DIDCommMsgMap{}, problemReportType, "", "", ErrExpired)
+		require.Contains(t, err.Error(), "threadID")
+	})
+}
+
+func TestRejectMsg(t *testing.T) {
+	msg := service.DIDCommMsgMap{"@id": "id", "@type": "type"}
+
+	report, err := RejectMsg(msg, problemReportType, ErrOutOfOrder)
+	require.NoError(t, err)
+	require.Equal(t, problemReportType, report.Type())
+	require.NotEmpty(t, report.ID())
+
+	thID, err := report.ThreadID()
+	require.NoError(t, err)
+	require.Equal(t, "id", thID)
+
+	r := &model.ProblemReport{}
+	require.NoError(t, report.Decode(r))
+	require.Equal(t, CodeOutOfOrder, r.Description.Code)
+
+	report, err = RejectMsg(msg, problemReportType, errors.New(errMsg))
+	require.NoError(t, err)
+	require.Nil(t, report)
+
+	report, err = RejectMsg(service.DIDCommMsgMap{"@id": "id", "@type": problemReportType}, problemReportType,
+		ErrExpired)
+	require.NoError(t, err)
+	require.Nil(t, report)
+
+	_, err = RejectMsg(service.DIDCommMsgMap{}, problemReportType, ErrExpired)
+	require.Contains(t, err.Error(), "threadID")
+}
+
+func TestTimeoutMsg(t *testing.T) {
+	msg := TimeoutMsg(problemReportType, piID)
+	require.Equal(t, problemReportType, msg.Type())
+	require.NotEmpty(t, msg.ID())
+
+	thID, err := msg.ThreadID()
+	require.NoError(t, err)
+	require.Equal(t, piID, thID)
+
+	r := &model.ProblemReport{}
+	require.NoError(t, msg.Decode(r))
+	require.Equal(t, CodeTimeout, r.Description.Code)
+}
+
+func threadMsg(order int) service.DIDCommMsgMap {
+	return service.DIDCommMsgMap{
+		"@id":     "id",
+		"~thread": map[string]interface{}{"thid": piID, "sender_order": order},
+	}
+}
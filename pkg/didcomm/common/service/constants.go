@@ -6,5 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 
 package service
 
-// ForwardMsgType defines the route forward message type.
-const ForwardMsgType = "https://didcomm.org/routing/1.0/forward"
+const (
+	// RoutingSpec defines the routing protocol spec.
+	RoutingSpec = "https://didcomm.org/routing/1.0/"
+	// ForwardMsgType defines the route forward message type.
+	ForwardMsgType = RoutingSpec + "forward"
+)
@@ -26,14 +26,22 @@ const (
 )
 
 // GetDestination constructs a Destination struct based on the given DID and parameters
-// It resolves the DID using the given VDR, and uses CreateDestination under the hood.
+// It dereferences the DID using the given VDR, and uses CreateDestination under the hood. The DID can also be the
+// DID URL of a specific service of the DID document (e.g. did#service or did?service=service).
 func GetDestination(did string, vdr vdrapi.Registry) (*Destination, error) {
-	docResolution, err := vdr.Resolve(did)
+	result, err := vdr.Dereference(did)
 	if err != nil {
 		return nil, fmt.Errorf("getDestination: failed to resolve did [%s] : %w", did, err)
 	}
 
-	return CreateDestination(docResolution.DIDDocument)
+	if result.Service != nil {
+		svc := *result.Service
+		svc.ServiceEndpoint = result.ServiceEndpoint
+
+		return createDestination(&svc, result.DIDDocument)
+	}
+
+	return CreateDestination(result.DIDDocument)
 }
 
 // CreateDestination makes a DIDComm Destination object from a DID Doc as per the DIDComm service conventions:
@@ -44,6 +52,10 @@ func CreateDestination(didDoc *diddoc.Doc) (*Destination, error) {
 		return nil, fmt.Errorf("create destination: missing DID doc service")
 	}
 
+	return createDestination(didCommService, didDoc)
+}
+
+func createDestination(didCommService *diddoc.Service, didDoc *diddoc.Doc) (*Destination, error) {
 	if didCommService.ServiceEndpoint == "" {
 		return nil, fmt.Errorf("create destination: no service endpoint on didcomm service block in diddoc: %+v", didDoc)
 	}
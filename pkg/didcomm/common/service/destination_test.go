@@ -31,6 +31,26 @@ func TestGetDestinationFromDID(t *testing.T) {
 		require.NotNil(t, destination)
 	})
 
+	t.Run("successfully getting destination from service DID URL", func(t *testing.T) {
+		doc2 := createDIDDoc()
+		doc2.Service = append(doc2.Service, doc2.Service[0])
+		doc2.Service[1].ID = doc2.ID + "#other"
+		doc2.Service[1].ServiceEndpoint = "https://other.example.com"
+
+		vdr := mockvdr.MockVDRegistry{ResolveValue: doc2}
+		destination, err := GetDestination(doc2.ID+"#other", &vdr)
+		require.NoError(t, err)
+		require.Equal(t, "https://other.example.com", destination.ServiceEndpoint)
+
+		destination, err = GetDestination(doc2.ID+"?service=other&relativeRef=/inbox", &vdr)
+		require.NoError(t, err)
+		require.Equal(t, "https://other.example.com/inbox", destination.ServiceEndpoint)
+
+		_, err = GetDestination(doc2.ID+"#missing", &vdr)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DID URL resource not found")
+	})
+
 	t.Run("test service not found", func(t *testing.T) {
 		doc2 := createDIDDoc()
 		doc2.Service = nil
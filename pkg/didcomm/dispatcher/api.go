@@ -26,6 +26,13 @@ type MessageService interface {
 	Name() string
 }
 
+// FeatureProvider is optionally implemented by protocol and message services to advertise
+// the protocols they support (see the discover-features protocol).
+type FeatureProvider interface {
+	// Features returns the identifier URIs of the supported protocols (e.g. https://didcomm.org/trust_ping/1.0).
+	Features() []string
+}
+
 // Outbound interface.
 type Outbound interface {
 	// Send the message after packing with the sender key and recipient keys.
@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
@@ -74,8 +77,18 @@ func (o *OutboundDispatcher) SendToDID(msg interface{}, myDID, theirDID string)
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
-// nolint:gocyclo
 func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *service.Destination) error {
+	_, span := tracing.Start(msgContext(msg), "didcomm.dispatch.outbound")
+	defer span.End()
+
+	err := o.send(msg, senderVerKey, des)
+	span.RecordError(err)
+
+	return err
+}
+
+// nolint:gocyclo
+func (o *OutboundDispatcher) send(msg interface{}, senderVerKey string, des *service.Destination) error {
 	for _, v := range o.outboundTransports {
 		// check if outbound accepts routing keys, else use recipient keys
 		keys := des.RecipientKeys
@@ -124,6 +137,8 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 			return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
 		}
 
+		metrics.OutboundMessages.Inc(msgType(req))
+
 		return nil
 	}
 
@@ -155,6 +170,26 @@ func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination)
 	return fmt.Errorf("outboundDispatcher.Forward: no transport found for serviceEndpoint: %s", des.ServiceEndpoint)
 }
 
+// msgContext returns the context carrying the trace context found in the message metadata, if any.
+func msgContext(msg interface{}) context.Context {
+	if m, ok := msg.(service.DIDCommMsgMap); ok {
+		return tracing.ContextFromMetadata(m.Metadata())
+	}
+
+	return context.Background()
+}
+
+func msgType(req []byte) string {
+	header := &struct {
+		Type string `json:"@type"`
+	}{}
+
+	// ignore error - the message type is only used as metrics label
+	_ = json.Unmarshal(req, header) // nolint:errcheck
+
+	return header.Type
+}
+
 func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Destination) ([]byte, error) {
 	if len(des.RoutingKeys) == 0 {
 		return msg, nil
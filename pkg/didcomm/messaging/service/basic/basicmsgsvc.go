@@ -95,6 +95,11 @@ func (m *MessageService) Accept(msgType string, purpose []string) bool {
 	return msgType == MessageRequestType
 }
 
+// Features returns the protocols supported by this basic message service.
+func (m *MessageService) Features() []string {
+	return []string{"https://didcomm.org/basicmessage/1.0"}
+}
+
 // HandleInbound for basic message service.
 func (m *MessageService) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	basicMsg := Message{}
@@ -24,6 +24,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -134,6 +135,11 @@ func (m *OverDIDComm) Accept(msgType string, purpose []string) bool {
 	return false
 }
 
+// Features returns the protocols supported by this HTTP over DIDComm message service.
+func (m *OverDIDComm) Features() []string {
+	return []string{strings.TrimSuffix(OverDIDCommSpec, "/")}
+}
+
 // HandleInbound for HTTP over DIDComm message service.
 func (m *OverDIDComm) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	svcMsg := httpOverDIDCommMsg{}
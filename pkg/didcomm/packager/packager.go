@@ -12,9 +12,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
@@ -77,18 +79,24 @@ func New(ctx Provider) (*Packager, error) {
 	return &basePackager, nil
 }
 
-func (bp *Packager) addPacker(pack packer.Packer) {
-	packerID := pack.EncodingType()
+func packerID(pack packer.Packer) string {
+	id := pack.EncodingType()
 
 	_, ok := pack.(*authcrypt.Packer)
 	if ok {
 		// anoncrypt and authcrypt have the same encoding type
 		// so authcrypt will have an appended suffix
-		packerID += authSuffix
+		id += authSuffix
 	}
 
-	if bp.packers[packerID] == nil {
-		bp.packers[packerID] = pack
+	return id
+}
+
+func (bp *Packager) addPacker(pack packer.Packer) {
+	id := packerID(pack)
+
+	if bp.packers[id] == nil {
+		bp.packers[id] = pack
 	}
 }
 
@@ -117,11 +125,15 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 
 	// TODO find a way to dynamically select a packer based on FromKey, recipients and their types.
 	//      https://github.com/hyperledger/aries-framework-go/issues/1112 Configurable packing
+	start := time.Now()
+
 	bytes, err := bp.primaryPacker.Pack(messageEnvelope.Message, messageEnvelope.FromKey, recipients)
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: failed to pack: %w", err)
 	}
 
+	metrics.PackDuration.ObserveSince(start, packerID(bp.primaryPacker))
+
 	return bytes, nil
 }
 
@@ -190,11 +202,15 @@ func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error
 		return nil, fmt.Errorf("message Type not recognized")
 	}
 
+	start := time.Now()
+
 	envelope, err := p.Unpack(encMessage)
 	if err != nil {
 		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
+	metrics.UnpackDuration.ObserveSince(start, encType)
+
 	//	ignore error - agents can communicate without using DIDs - for example, in DIDExchange
 	theirDID, err := bp.connectionStore.GetDID(base58.Encode(envelope.FromKey))
 	if errors.Is(err, did.ErrNotFound) {
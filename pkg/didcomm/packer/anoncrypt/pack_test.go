@@ -16,7 +16,6 @@ import (
 	"strings"
 	"testing"
 
-	hybrid "github.com/google/tink/go/hybrid/subtle"
 	"github.com/google/tink/go/keyset"
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
@@ -26,6 +25,7 @@ import (
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	afgjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
@@ -47,6 +47,16 @@ func TestAnoncryptPackerSuccess(t *testing.T) {
 			kms.NISTP256ECDHKWType,
 			afgjose.A256GCM,
 		},
+		{
+			"anoncrypt using Secp256k1ECDHKW and AES256-GCM",
+			kms.Secp256k1ECDHKWType,
+			afgjose.A256GCM,
+		},
+		{
+			"anoncrypt using Secp256k1ECDHKW and XChacha20Poly1305",
+			kms.Secp256k1ECDHKWType,
+			afgjose.XC20P,
+		},
 		{
 			"anoncrypt using X25519ECDHKW and XChacha20Poly1305",
 			kms.X25519ECDHKWType,
@@ -333,14 +343,16 @@ func prettyPrint(msg []byte) (string, error) {
 }
 
 func getPrintedECPubKey(t *testing.T, pubKey *cryptoapi.PublicKey) string {
-	crv, err := hybrid.GetCurve(pubKey.Curve)
+	crv, err := cryptoutil.GetCurve(pubKey.Curve)
 	require.NoError(t, err)
 
-	jwk := jose.JSONWebKey{
-		Key: &ecdsa.PublicKey{
-			Curve: crv,
-			X:     new(big.Int).SetBytes(pubKey.X),
-			Y:     new(big.Int).SetBytes(pubKey.Y),
+	jwk := afgjose.JWK{
+		JSONWebKey: jose.JSONWebKey{
+			Key: &ecdsa.PublicKey{
+				Curve: crv,
+				X:     new(big.Int).SetBytes(pubKey.X),
+				Y:     new(big.Int).SetBytes(pubKey.Y),
+			},
 		},
 	}
 
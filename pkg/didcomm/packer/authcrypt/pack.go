@@ -55,6 +55,8 @@ type Packer struct {
 // pre-populated with the sender key required by a recipient to Unpack a JWE envelope. It is not needed by the sender
 // (as the sender packs the envelope with its own key).
 // The returned Packer contains all the information required to pack and unpack payloads.
+// encAlg is the JWE content encryption algorithm, with jose.A128CBCHS256 and jose.A256CBCHS512 the content
+// encryption tag is bound to the ECDH-1PU key wrapping of each recipient.
 func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 	k := ctx.KMS()
 	if k == nil {
@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+// Menu is sent by the responder to present the available actions to the requester.
+type Menu struct {
+	Type string `json:"@type,omitempty"`
+	// Title is a short label for the menu.
+	Title string `json:"title,omitempty"`
+	// Description is a longer explanation of the menu.
+	Description string `json:"description,omitempty"`
+	// ErrorMsg is an optional error message to display in the menu header.
+	ErrorMsg string `json:"errormsg,omitempty"`
+	// Options is the list of actions the requester can choose from.
+	Options []Option `json:"options"`
+}
+
+// Option is a single action of the menu.
+type Option struct {
+	// Name is the unique identifier of the option, it is sent back in the Perform message.
+	Name string `json:"name"`
+	// Title is the label of the option.
+	Title string `json:"title,omitempty"`
+	// Description is a longer explanation of the option.
+	Description string `json:"description,omitempty"`
+	// Disabled indicates that the option is shown but cannot be selected.
+	Disabled bool `json:"disabled,omitempty"`
+	// Form describes the parameters to be collected before performing the action.
+	Form *Form `json:"form,omitempty"`
+}
+
+// Form describes the parameters of an option.
+type Form struct {
+	Description string      `json:"description,omitempty"`
+	Params      []FormParam `json:"params,omitempty"`
+	SubmitLabel string      `json:"submit-label,omitempty"`
+}
+
+// FormParam is a single parameter of a form.
+type FormParam struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// MenuRequest is sent by the requester to ask for the root menu.
+type MenuRequest struct {
+	Type string `json:"@type,omitempty"`
+}
+
+// Perform is sent by the requester to select one of the menu options.
+type Perform struct {
+	Type string `json:"@type,omitempty"`
+	// Name is the name of the selected option.
+	Name string `json:"name"`
+	// Params contains the values of the form parameters.
+	Params map[string]string `json:"params,omitempty"`
+}
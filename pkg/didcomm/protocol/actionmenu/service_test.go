@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+		require.Equal(t, Name, svc.Name())
+		require.Equal(t, []string{"https://didcomm.org/action-menu/1.0"}, svc.Features())
+
+		for _, msgType := range []string{MenuMsgType, MenuRequestMsgType, PerformMsgType, ProblemReportMsgType} {
+			require.True(t, svc.Accept(msgType))
+		}
+
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("Error open store", func(t *testing.T) {
+		_, err := New(&provider{storage: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("store error"),
+		}})
+		require.EqualError(t, err, "store error")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("No clients", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(randomInboundMessage(MenuMsgType), Alice, Bob)
+		require.EqualError(t, err, "no clients are registered to handle the message")
+	})
+
+	t.Run("Unrecognized message", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Menu{Type: "unknown"}), Alice, Bob)
+		require.EqualError(t, err, "doHandle: nextState: unrecognized msgType: unknown")
+	})
+
+	t.Run("Perform without menu", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Perform{Type: PerformMsgType}), Alice, Bob)
+		require.EqualError(t, err, "doHandle: nextState: perform can be sent only as a reply to a menu")
+	})
+
+	t.Run("Invalid state transition", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err = svc.HandleInbound(randomInboundMessage(PerformMsgType), Alice, Bob)
+		require.EqualError(t, err, "doHandle: invalid state transition: start -> perform-received")
+	})
+}
+
+func TestService_Menu(t *testing.T) {
+	alice, bob := newPeers(t)
+
+	aliceActions := make(chan service.DIDCommAction)
+	require.NoError(t, alice.RegisterActionEvent(aliceActions))
+
+	bobActions := make(chan service.DIDCommAction)
+	require.NoError(t, bob.RegisterActionEvent(bobActions))
+
+	// Alice asks for the root menu
+	piID, err := alice.HandleInbound(service.NewDIDCommMsgMap(&MenuRequest{Type: MenuRequestMsgType}), Alice, Bob)
+	require.NoError(t, err)
+	require.NotEmpty(t, piID)
+
+	// Bob provides the root menu
+	action := waitForAction(t, bobActions, MenuRequestMsgType)
+	require.Equal(t, piID, action.Properties.All()["piid"])
+
+	actions, err := bob.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	action.Continue(WithMenu(&Menu{
+		Title:   "Welcome",
+		Options: []Option{{Name: "issue-credential", Title: "Get a credential"}},
+	}))
+
+	// Alice selects an option
+	action = waitForAction(t, aliceActions, MenuMsgType)
+	require.Equal(t, piID, action.Properties.All()["piid"])
+
+	menu := &Menu{}
+	require.NoError(t, action.Message.Decode(menu))
+	require.Equal(t, "Welcome", menu.Title)
+
+	require.NoError(t, alice.ActionContinue(piID, WithPerform(&Perform{Name: "issue-credential"})))
+
+	// Bob handles the selection and replies with a new menu
+	action = waitForAction(t, bobActions, PerformMsgType)
+
+	perform := &Perform{}
+	require.NoError(t, action.Message.Decode(perform))
+	require.Equal(t, "issue-credential", perform.Name)
+
+	action.Continue(WithMenu(&Menu{Title: "Credentials"}))
+
+	// the new menu starts a new protocol instance
+	action = waitForAction(t, aliceActions, MenuMsgType)
+	require.NotEqual(t, piID, action.Properties.All()["piid"])
+
+	require.NoError(t, action.Message.Decode(menu))
+	require.Equal(t, "Credentials", menu.Title)
+}
+
+func TestService_Stop(t *testing.T) {
+	alice, bob := newPeers(t)
+
+	aliceActions := make(chan service.DIDCommAction)
+	require.NoError(t, alice.RegisterActionEvent(aliceActions))
+
+	bobActions := make(chan service.DIDCommAction)
+	require.NoError(t, bob.RegisterActionEvent(bobActions))
+
+	aliceStates := make(chan service.StateMsg, 10)
+	require.NoError(t, alice.RegisterMsgEvent(aliceStates))
+
+	// Bob sends an unsolicited menu
+	piID, err := bob.HandleInbound(service.NewDIDCommMsgMap(&Menu{Type: MenuMsgType, Title: "Menu"}), Bob, Alice)
+	require.NoError(t, err)
+
+	waitForAction(t, aliceActions, MenuMsgType)
+
+	_, err = alice.Actions()
+	require.NoError(t, err)
+
+	require.NoError(t, alice.ActionStop(piID, errors.New("not interested")))
+
+	// Bob receives the problem report
+	action := waitForAction(t, bobActions, ProblemReportMsgType)
+	action.Continue(nil)
+
+	for {
+		select {
+		case msg := <-aliceStates:
+			if msg.StateID == stateNameAbandoned && msg.Type == service.PostState {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
+
+func TestService_ActionErrors(t *testing.T) {
+	svc, err := New(&provider{storage: mem.NewProvider()})
+	require.NoError(t, err)
+
+	require.Contains(t, svc.ActionContinue(uuid.New().String(), nil).Error(), "get transitional payload")
+	require.Contains(t, svc.ActionStop(uuid.New().String(), nil).Error(), "get transitional payload")
+}
+
+func waitForAction(t *testing.T, actions chan service.DIDCommAction, msgType string) service.DIDCommAction {
+	t.Helper()
+
+	select {
+	case action := <-actions:
+		require.Equal(t, msgType, action.Message.Type())
+
+		return action
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for %s", msgType)
+	}
+
+	return service.DIDCommAction{}
+}
+
+func newPeers(t *testing.T) (*Service, *Service) {
+	t.Helper()
+
+	aliceMessenger, bobMessenger := &messenger{}, &messenger{}
+
+	alice, err := New(&provider{storage: mem.NewProvider(), messenger: aliceMessenger})
+	require.NoError(t, err)
+
+	bob, err := New(&provider{storage: mem.NewProvider(), messenger: bobMessenger})
+	require.NoError(t, err)
+
+	aliceMessenger.peer = bob
+	bobMessenger.peer = alice
+
+	return alice, bob
+}
+
+type provider struct {
+	storage   storage.Provider
+	messenger service.Messenger
+}
+
+func (p *provider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *provider) StorageProvider() storage.Provider {
+	return p.storage
+}
+
+// messenger delivers the messages directly to the peer's service.
+type messenger struct {
+	service.Messenger
+	peer *Service
+}
+
+func (m *messenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if msg.ID() == "" {
+		msg["@id"] = uuid.New().String()
+	}
+
+	msg[jsonThread] = map[string]interface{}{"thid": msg.ID()}
+
+	return m.deliver(msg, myDID, theirDID)
+}
+
+func (m *messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID string) error {
+	thID, err := in.ThreadID()
+	if err != nil {
+		return err
+	}
+
+	out["@id"] = uuid.New().String()
+	out[jsonThread] = map[string]interface{}{"thid": thID}
+
+	return m.deliver(out, myDID, theirDID)
+}
+
+func (m *messenger) ReplyToNested(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+	msg["@id"] = uuid.New().String()
+	msg[jsonThread] = map[string]interface{}{"pthid": opts.ThreadID}
+
+	return m.deliver(msg, opts.MyDID, opts.TheirDID)
+}
+
+func (m *messenger) deliver(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	go func() {
+		// nolint: errcheck
+		_, _ = m.peer.HandleInbound(msg, theirDID, myDID)
+	}()
+
+	return nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	// common states.
+	stateNameStart     = "start"
+	stateNameAbandoned = "abandoned"
+	stateNameDone      = "done"
+	stateNameNoop      = "noop"
+
+	// states for Requester.
+	stateNameAwaitingRootMenu   = "awaiting-root-menu"
+	stateNamePreparingSelection = "preparing-selection"
+	stateNamePerformSent        = "perform-sent"
+
+	// states for Responder.
+	stateNamePreparingRootMenu = "preparing-root-menu"
+	stateNameAwaitingSelection = "awaiting-selection"
+	stateNamePerformReceived   = "perform-received"
+)
+
+const (
+	// error codes.
+	codeInternalError = "internal"
+	codeRejectedError = "rejected"
+
+	jsonThread = "~thread"
+)
+
+// state action for network call.
+type stateAction func(messenger service.Messenger) error
+
+// the protocol's state.
+type state interface {
+	// Name of this state.
+	Name() string
+	// Whether this state allows transitioning into the next state.
+	CanTransitionTo(next state) bool
+	// Executes this state, returning a followup state to be immediately executed as well.
+	// The 'noOp' state should be returned if the state has no followup.
+	Execute(msg *metaData) (state, stateAction, error)
+}
+
+// represents zero state's action.
+func zeroAction(service.Messenger) error { return nil }
+
+// start state.
+type start struct{}
+
+func (s *start) Name() string {
+	return stateNameStart
+}
+
+func (s *start) CanTransitionTo(st state) bool {
+	switch st.Name() {
+	// Requester.
+	case stateNameAwaitingRootMenu, stateNamePreparingSelection:
+		return true
+	// Responder.
+	case stateNamePreparingRootMenu, stateNameAwaitingSelection:
+		return true
+	}
+
+	return false
+}
+
+func (s *start) Execute(_ *metaData) (state, stateAction, error) {
+	return nil, nil, fmt.Errorf("%s: is not implemented yet", s.Name())
+}
+
+// abandoned state.
+type abandoned struct {
+	Code string
+}
+
+func (s *abandoned) Name() string {
+	return stateNameAbandoned
+}
+
+func (s *abandoned) CanTransitionTo(st state) bool {
+	return false
+}
+
+func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
+	// if code is not provided it means we do not need to notify the another agent.
+	// if we received ProblemReport message no need to answer.
+	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType {
+		return &noOp{}, zeroAction, nil
+	}
+
+	code := model.Code{Code: s.Code}
+
+	// if the protocol was stopped by the user we will set the rejected error code
+	if errors.As(md.err, &customError{}) {
+		code = model.Code{Code: codeRejectedError}
+	}
+
+	thID, err := md.Msg.ThreadID()
+	if err != nil {
+		return nil, nil, fmt.Errorf("threadID: %w", err)
+	}
+
+	return &noOp{}, func(messenger service.Messenger) error {
+		return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
+			Type:        ProblemReportMsgType,
+			Description: code,
+		}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
+	}, nil
+}
+
+// done state.
+type done struct{}
+
+func (s *done) Name() string {
+	return stateNameDone
+}
+
+func (s *done) CanTransitionTo(_ state) bool {
+	return false
+}
+
+func (s *done) Execute(_ *metaData) (state, stateAction, error) {
+	return &noOp{}, zeroAction, nil
+}
+
+// noOp state.
+type noOp struct{}
+
+func (s *noOp) Name() string {
+	return stateNameNoop
+}
+
+func (s *noOp) CanTransitionTo(_ state) bool {
+	return false
+}
+
+func (s *noOp) Execute(_ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("cannot execute no-op")
+}
+
+func canReplyTo(msg service.DIDCommMsgMap) bool {
+	_, ok := msg[jsonThread]
+	return ok
+}
+
+func forwardInitial(md *metaData) stateAction {
+	return func(messenger service.Messenger) error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+}
+
+// awaitingRootMenu the Requester's state.
+type awaitingRootMenu struct{}
+
+func (s *awaitingRootMenu) Name() string {
+	return stateNameAwaitingRootMenu
+}
+
+func (s *awaitingRootMenu) CanTransitionTo(st state) bool {
+	return st.Name() == stateNamePreparingSelection ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *awaitingRootMenu) Execute(md *metaData) (state, stateAction, error) {
+	return &noOp{}, forwardInitial(md), nil
+}
+
+// preparingSelection the Requester's state.
+type preparingSelection struct{}
+
+func (s *preparingSelection) Name() string {
+	return stateNamePreparingSelection
+}
+
+func (s *preparingSelection) CanTransitionTo(st state) bool {
+	return st.Name() == stateNamePerformSent ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *preparingSelection) Execute(md *metaData) (state, stateAction, error) {
+	if md.perform == nil {
+		return nil, nil, errors.New("perform was not provided")
+	}
+
+	return &performSent{}, zeroAction, nil
+}
+
+// performSent the Requester's state.
+type performSent struct{}
+
+func (s *performSent) Name() string {
+	return stateNamePerformSent
+}
+
+func (s *performSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *performSent) Execute(md *metaData) (state, stateAction, error) {
+	if md.perform == nil {
+		return nil, nil, errors.New("perform was not provided")
+	}
+
+	return &done{}, func(messenger service.Messenger) error {
+		md.perform.Type = PerformMsgType
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(md.perform), md.MyDID, md.TheirDID)
+	}, nil
+}
+
+// preparingRootMenu the Responder's state.
+type preparingRootMenu struct{}
+
+func (s *preparingRootMenu) Name() string {
+	return stateNamePreparingRootMenu
+}
+
+func (s *preparingRootMenu) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameAwaitingSelection ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *preparingRootMenu) Execute(md *metaData) (state, stateAction, error) {
+	if md.menu == nil {
+		return nil, nil, errors.New("menu was not provided")
+	}
+
+	return &awaitingSelection{}, zeroAction, nil
+}
+
+// awaitingSelection the Responder's state.
+type awaitingSelection struct{}
+
+func (s *awaitingSelection) Name() string {
+	return stateNameAwaitingSelection
+}
+
+func (s *awaitingSelection) CanTransitionTo(st state) bool {
+	return st.Name() == stateNamePerformReceived ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *awaitingSelection) Execute(md *metaData) (state, stateAction, error) {
+	// the menu is sent without being requested
+	if !canReplyTo(md.Msg) {
+		return &noOp{}, forwardInitial(md), nil
+	}
+
+	if md.menu == nil {
+		return nil, nil, errors.New("menu was not provided")
+	}
+
+	return &noOp{}, func(messenger service.Messenger) error {
+		md.menu.Type = MenuMsgType
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(md.menu), md.MyDID, md.TheirDID)
+	}, nil
+}
+
+// performReceived the Responder's state.
+type performReceived struct{}
+
+func (s *performReceived) Name() string {
+	return stateNamePerformReceived
+}
+
+func (s *performReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *performReceived) Execute(md *metaData) (state, stateAction, error) {
+	if md.menu == nil {
+		return &done{}, zeroAction, nil
+	}
+
+	// the follow-up menu starts a new instance of the protocol
+	return &done{}, func(messenger service.Messenger) error {
+		md.menu.Type = MenuMsgType
+		return messenger.Send(service.NewDIDCommMsgMap(md.menu), md.MyDID, md.TheirDID)
+	}, nil
+}
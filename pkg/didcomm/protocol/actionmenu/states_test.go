@@ -0,0 +1,282 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package actionmenu
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+func TestCanTransitionTo(t *testing.T) {
+	allStates := []state{
+		// common states
+		&start{}, &abandoned{}, &done{}, &noOp{},
+		// states for Requester
+		&awaitingRootMenu{}, &preparingSelection{}, &performSent{},
+		// states for Responder
+		&preparingRootMenu{}, &awaitingSelection{}, &performReceived{},
+	}
+
+	transitions := map[string][]string{
+		stateNameStart: {
+			stateNameAwaitingRootMenu, stateNamePreparingSelection,
+			stateNamePreparingRootMenu, stateNameAwaitingSelection,
+		},
+		stateNameAwaitingRootMenu:   {stateNamePreparingSelection, stateNameAbandoned},
+		stateNamePreparingSelection: {stateNamePerformSent, stateNameAbandoned},
+		stateNamePerformSent:        {stateNameDone, stateNameAbandoned},
+		stateNamePreparingRootMenu:  {stateNameAwaitingSelection, stateNameAbandoned},
+		stateNameAwaitingSelection:  {stateNamePerformReceived, stateNameAbandoned},
+		stateNamePerformReceived:    {stateNameDone, stateNameAbandoned},
+	}
+
+	for _, current := range allStates {
+		require.Equal(t, current.Name(), stateFromName(current.Name()).Name())
+
+		for _, next := range allStates {
+			expected := false
+
+			for _, name := range transitions[current.Name()] {
+				if name == next.Name() {
+					expected = true
+				}
+			}
+
+			require.Equal(t, expected, current.CanTransitionTo(next), "%s -> %s", current.Name(), next.Name())
+		}
+	}
+}
+
+func TestStart_Execute(t *testing.T) {
+	followup, action, err := (&start{}).Execute(&metaData{})
+	require.Contains(t, fmt.Sprintf("%v", err), "is not implemented yet")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestNoOp_Execute(t *testing.T) {
+	followup, action, err := (&noOp{}).Execute(&metaData{})
+	require.Contains(t, fmt.Sprintf("%v", err), "cannot execute no-op")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestDone_Execute(t *testing.T) {
+	followup, action, err := (&done{}).Execute(&metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &noOp{}, followup)
+	require.NoError(t, action(nil))
+}
+
+func TestAbandoned_Execute(t *testing.T) {
+	t.Run("Internal Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		md := &metaData{}
+		md.Msg = randomInboundMessage(MenuMsgType)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeInternalError, r.Description.Code)
+				require.Equal(t, ProblemReportMsgType, r.Type)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Custom Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		md := &metaData{err: customError{error: errors.New("error")}}
+		md.Msg = randomInboundMessage(MenuMsgType)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeRejectedError, r.Description.Code)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Invalid message", func(t *testing.T) {
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(&metaData{})
+		require.EqualError(t, errors.Unwrap(err), service.ErrInvalidMessage.Error())
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Problem report received", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = randomInboundMessage(ProblemReportMsgType)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action(nil))
+	})
+}
+
+func TestPreparingSelection_Execute(t *testing.T) {
+	followup, action, err := (&preparingSelection{}).Execute(&metaData{})
+	require.EqualError(t, err, "perform was not provided")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+
+	followup, action, err = (&preparingSelection{}).Execute(&metaData{perform: &Perform{}})
+	require.NoError(t, err)
+	require.Equal(t, &performSent{}, followup)
+	require.NoError(t, action(nil))
+}
+
+func TestPerformSent_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, _, err := (&performSent{}).Execute(&metaData{})
+	require.EqualError(t, err, "perform was not provided")
+
+	md := &metaData{perform: &Perform{Name: "option"}}
+	md.Msg = randomInboundMessage(MenuMsgType)
+
+	followup, action, err := (&performSent{}).Execute(md)
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyToMsg(md.Msg, gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+			require.Equal(t, PerformMsgType, msg.Type())
+
+			return nil
+		})
+
+	require.NoError(t, action(messenger))
+}
+
+func TestPreparingRootMenu_Execute(t *testing.T) {
+	_, _, err := (&preparingRootMenu{}).Execute(&metaData{})
+	require.EqualError(t, err, "menu was not provided")
+
+	followup, action, err := (&preparingRootMenu{}).Execute(&metaData{menu: &Menu{}})
+	require.NoError(t, err)
+	require.Equal(t, &awaitingSelection{}, followup)
+	require.NoError(t, action(nil))
+}
+
+func TestAwaitingSelection_Execute(t *testing.T) {
+	t.Run("Unsolicited menu", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		md := &metaData{}
+		md.Msg = service.NewDIDCommMsgMap(&Menu{Type: MenuMsgType})
+
+		followup, action, err := (&awaitingSelection{}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(md.Msg, gomock.Any(), gomock.Any()).Return(nil)
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Reply to menu request", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		md := &metaData{}
+		md.Msg = randomInboundMessage(MenuRequestMsgType)
+
+		_, _, err := (&awaitingSelection{}).Execute(md)
+		require.EqualError(t, err, "menu was not provided")
+
+		md.menu = &Menu{Title: "menu"}
+
+		followup, action, err := (&awaitingSelection{}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(md.Msg, gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, MenuMsgType, msg.Type())
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+}
+
+func TestPerformReceived_Execute(t *testing.T) {
+	followup, action, err := (&performReceived{}).Execute(&metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+	require.NoError(t, action(nil))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	followup, action, err = (&performReceived{}).Execute(&metaData{menu: &Menu{}})
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(msg service.DIDCommMsgMap, _, _ string) error {
+			require.Equal(t, MenuMsgType, msg.Type())
+
+			return nil
+		})
+
+	require.NoError(t, action(messenger))
+}
+
+func randomInboundMessage(t string) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(struct {
+		ID     string           `json:"@id"`
+		Thread decorator.Thread `json:"~thread"`
+		Type   string           `json:"@type"`
+	}{
+		ID:     uuid.New().String(),
+		Thread: decorator.Thread{ID: uuid.New().String()},
+		Type:   t,
+	})
+}
@@ -38,6 +38,11 @@ type Timing struct {
 	ExpiresTime time.Time `json:"expires_time,omitempty"`
 }
 
+// Expired reports whether the expiration time is set and has passed at the given time.
+func (t Timing) Expired(now time.Time) bool {
+	return !t.ExpiresTime.IsZero() && now.After(t.ExpiresTime)
+}
+
 // Transport transport decorator
 // https://github.com/hyperledger/aries-rfcs/tree/master/features/0092-transport-return-route
 type Transport struct {
@@ -10,10 +10,19 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
+func TestTiming_Expired(t *testing.T) {
+	now := time.Now()
+
+	require.False(t, Timing{}.Expired(now))
+	require.False(t, Timing{ExpiresTime: now.Add(time.Minute)}.Expired(now))
+	require.True(t, Timing{ExpiresTime: now.Add(-time.Minute)}.Expired(now))
+}
+
 func TestAttachmentData_Fetch(t *testing.T) {
 	t.Run("json", func(t *testing.T) {
 		expected := map[string]interface{}{
@@ -14,7 +14,9 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -45,6 +47,7 @@ const (
 	AckMsgType = PIURI + "/ack"
 	// oobMsgType is the internal message type for the oob invitation that the didexchange service receives.
 	oobMsgType             = "oob-invitation"
+	problemReportMsgType   = PIURI + "/problem_report"
 	routerConnsMetadataKey = "routerConnections"
 )
 
@@ -86,6 +89,7 @@ type Service struct {
 	ctx             *context
 	callbackChannel chan *message
 	connectionStore *connectionStore
+	lifecycle       *lifecycle.Tracker
 }
 
 type context struct {
@@ -148,6 +152,12 @@ func New(prov provider) (*Service, error) {
 	return svc, nil
 }
 
+// UseLifecycle enables rejecting expired and out-of-order messages and abandoning stale exchanges.
+func (s *Service) UseLifecycle(tracker *lifecycle.Tracker) {
+	s.lifecycle = tracker
+	s.lifecycle.Start(s.abandonStale)
+}
+
 func retrievingRouterConnections(msg service.DIDCommMsg) []string {
 	raw, found := msg.Metadata()[routerConnsMetadataKey]
 	if !found {
@@ -163,7 +173,7 @@ func retrievingRouterConnections(msg service.DIDCommMsg) []string {
 }
 
 // HandleInbound handles inbound didexchange messages.
-func (s *Service) HandleInbound(msg service.DIDCommMsg, _, _ string) (string, error) {
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	logger.Debugf("receive inbound message : %s", msg)
 
 	// fetch the thread id
@@ -184,6 +194,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, _, _ string) (string, er
 		return "", fmt.Errorf("failed to fetch connection record : %w", err)
 	}
 
+	if err = s.checkLifecycle(msg, connRecord, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("handle inbound - lifecycle : %w", err)
+	}
+
 	internalMsg := &message{
 		Options:       &options{routerConnections: retrievingRouterConnections(msg)},
 		Msg:           msg.Clone(),
@@ -219,6 +233,11 @@ func (s *Service) Name() string {
 	return DIDExchange
 }
 
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{PIURI}
+}
+
 func findNamespace(msgType string) string {
 	namespace := theirNSPrefix
 	if msgType == InvitationMsgType || msgType == ResponseMsgType || msgType == oobMsgType {
@@ -316,6 +335,10 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 
 		logger.Debugf("updated connection record %+v", connectionRecord)
 
+		if err = s.trackState(connectionRecord); err != nil {
+			return fmt.Errorf("failed to track state %s %w", next.Name(), err)
+		}
+
 		if err = action(); err != nil {
 			return fmt.Errorf("failed to execute state action '%s': %w", next.Name(), err)
 		}
@@ -417,6 +440,10 @@ func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DI
 
 // sendEvent triggers the message events.
 func (s *Service) sendMsgEvents(msg *service.StateMsg) {
+	if msg.Type == service.PostState {
+		metrics.ProtocolStateTransitions.Inc(msg.ProtocolName, msg.StateID)
+	}
+
 	// trigger the message events
 	for _, handler := range s.MsgEvents() {
 		handler <- *msg
@@ -543,6 +570,10 @@ func (s *Service) abandon(thID string, msg service.DIDCommMsg, processErr error)
 		return fmt.Errorf("unable to update the state to abandoned: %w", err)
 	}
 
+	if err = s.trackState(connRec); err != nil {
+		return fmt.Errorf("unable to track the abandoned state: %w", err)
+	}
+
 	// send the message event
 	s.sendMsgEvents(&service.StateMsg{
 		ProtocolName: DIDExchange,
@@ -555,6 +586,91 @@ func (s *Service) abandon(thID string, msg service.DIDCommMsg, processErr error)
 	return nil
 }
 
+// checkLifecycle rejects the inbound message with a problem-report if it has expired or was received out of order.
+// The exchanges are tracked by the connection ID since the thread ID changes once the request is sent.
+func (s *Service) checkLifecycle(msg service.DIDCommMsg, connRecord *connection.Record, myDID, theirDID string) error {
+	if s.lifecycle == nil {
+		return nil
+	}
+
+	err := s.lifecycle.Check(msg, connRecord.ConnectionID, theirDID)
+	if err != nil {
+		if rErr := s.reject(msg, connRecord, myDID, theirDID, err); rErr != nil {
+			logger.Errorf("reject message: %s", rErr)
+		}
+	}
+
+	return err
+}
+
+// reject replies to the message rejected by the lifecycle with a problem-report. The DIDs of the exchange are not
+// known yet when an invitation is rejected, the problem-report can't be sent then.
+func (s *Service) reject(msg service.DIDCommMsg, connRecord *connection.Record, myDID, theirDID string,
+	cause error) error {
+	report, err := lifecycle.RejectMsg(msg, problemReportMsgType, cause)
+	if err != nil || report == nil {
+		return err
+	}
+
+	if myDID == "" {
+		myDID = connRecord.MyDID
+	}
+
+	if theirDID == "" {
+		theirDID = connRecord.TheirDID
+	}
+
+	if myDID == "" || theirDID == "" {
+		logger.Debugf("problem-report not sent: DIDs of connection %s unknown", connRecord.ConnectionID)
+
+		return nil
+	}
+
+	return s.ctx.outboundDispatcher.SendToDID(report, myDID, theirDID)
+}
+
+// trackState records the activity of the exchange until it is completed or abandoned.
+func (s *Service) trackState(connRec *connection.Record) error {
+	if s.lifecycle == nil {
+		return nil
+	}
+
+	if connRec.State == StateIDCompleted || connRec.State == StateIDAbandoned {
+		return s.lifecycle.Complete(connRec.ConnectionID)
+	}
+
+	return s.lifecycle.Touch(connRec.ConnectionID)
+}
+
+// abandonStale abandons the exchange which had no activity within the lifecycle timeout.
+func (s *Service) abandonStale(connectionID string) error {
+	connRec, err := s.connectionStore.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("unable to update the state to abandoned: %w", err)
+	}
+
+	if connRec.State == StateIDCompleted || connRec.State == StateIDAbandoned {
+		return nil
+	}
+
+	connRec.State = StateIDAbandoned
+
+	if err = s.connectionStore.saveConnectionRecord(connRec); err != nil {
+		return fmt.Errorf("unable to update the state to abandoned: %w", err)
+	}
+
+	// send the message event
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: DIDExchange,
+		Type:         service.PostState,
+		Msg:          lifecycle.TimeoutMsg(problemReportMsgType, connRec.ThreadID),
+		StateID:      StateIDAbandoned,
+		Properties:   createErrorEventProperties(connRec.ConnectionID, connRec.InvitationID, lifecycle.ErrTimeout),
+	})
+
+	return nil
+}
+
 func (s *Service) processCallback(msg *message) {
 	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
 	// channel internally.
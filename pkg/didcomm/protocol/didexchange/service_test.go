@@ -20,7 +20,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -29,6 +31,7 @@ import (
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
@@ -827,6 +830,126 @@ func TestEventsSuccess(t *testing.T) {
 	}
 }
 
+func TestService_UseLifecycle(t *testing.T) {
+	t.Run("expired message", func(t *testing.T) {
+		svc, err := New(testProvider())
+		require.NoError(t, err)
+
+		tracker, err := lifecycle.New(mem.NewProvider(), DIDExchange)
+		require.NoError(t, err)
+
+		svc.UseLifecycle(tracker)
+
+		invite, err := json.Marshal(&Invitation{
+			Type:          InvitationMsgType,
+			ID:            randomString(),
+			Label:         "test",
+			RecipientKeys: []string{"key"},
+		})
+		require.NoError(t, err)
+
+		didMsg, err := service.ParseDIDCommMsgMap(invite)
+		require.NoError(t, err)
+
+		didMsg["~timing"] = map[string]interface{}{"expires_time": "2020-01-01T00:00:00Z"}
+
+		_, err = svc.HandleInbound(didMsg, "", "")
+		require.True(t, errors.Is(err, lifecycle.ErrExpired))
+	})
+
+	t.Run("expired message answered with a problem-report", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+
+		prov := testProvider()
+		prov.CustomOutbound = &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				require.Equal(t, "did:example:me", myDID)
+				require.Equal(t, "did:example:them", theirDID)
+
+				sent <- msg
+
+				return nil
+			},
+		}
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		tracker, err := lifecycle.New(mem.NewProvider(), DIDExchange)
+		require.NoError(t, err)
+
+		svc.UseLifecycle(tracker)
+
+		request, err := json.Marshal(&Request{
+			Type:   RequestMsgType,
+			ID:     randomString(),
+			Label:  "test",
+			Thread: &decorator.Thread{PID: randomString()},
+			Connection: &Connection{
+				DID: "did:example:them",
+			},
+		})
+		require.NoError(t, err)
+
+		didMsg, err := service.ParseDIDCommMsgMap(request)
+		require.NoError(t, err)
+
+		didMsg["~timing"] = map[string]interface{}{"expires_time": "2020-01-01T00:00:00Z"}
+
+		_, err = svc.HandleInbound(didMsg, "did:example:me", "did:example:them")
+		require.True(t, errors.Is(err, lifecycle.ErrExpired))
+
+		select {
+		case msg := <-sent:
+			report, ok := msg.(service.DIDCommMsgMap)
+			require.True(t, ok)
+			require.Equal(t, problemReportMsgType, report.Type())
+
+			thID, errThID := report.ThreadID()
+			require.NoError(t, errThID)
+			require.Equal(t, didMsg.ID(), thID)
+		default:
+			require.Fail(t, "problem-report not sent")
+		}
+	})
+
+	t.Run("stale exchange", func(t *testing.T) {
+		svc, err := New(testProvider())
+		require.NoError(t, err)
+
+		statusCh := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(statusCh))
+
+		connRec := &connection.Record{
+			ConnectionID: randomString(),
+			ThreadID:     randomString(),
+			State:        StateIDRequested,
+		}
+		require.NoError(t, svc.connectionStore.saveConnectionRecord(connRec))
+
+		tracker, err := lifecycle.New(mem.NewProvider(), DIDExchange,
+			lifecycle.WithTimeout(time.Nanosecond), lifecycle.WithInterval(time.Millisecond))
+		require.NoError(t, err)
+		require.NoError(t, tracker.Touch(connRec.ConnectionID))
+
+		svc.UseLifecycle(tracker)
+		defer tracker.Stop()
+
+		select {
+		case e := <-statusCh:
+			require.Equal(t, service.PostState, e.Type)
+			require.Equal(t, StateIDAbandoned, e.StateID)
+			require.Equal(t, lifecycle.ErrTimeout.Error(), e.Properties.All()["error"])
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "tests are not validated")
+		}
+
+		record, err := svc.connectionStore.GetConnectionRecord(connRec.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, StateIDAbandoned, record.State)
+	})
+}
+
 func TestContinueWithPublicDID(t *testing.T) {
 	didDoc := mockdiddoc.GetMockDIDDoc(t)
 	svc, err := New(&protocol.MockProvider{
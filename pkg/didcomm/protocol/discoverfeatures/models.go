@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+// Query asks which protocols matching the query are supported by the other party (discover-features 1.0).
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0031-discover-features#query-message-type
+type Query struct {
+	Type    string `json:"@type,omitempty"`
+	ID      string `json:"@id,omitempty"`
+	Query   string `json:"query"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Disclose lists the supported protocols matching a query (discover-features 1.0).
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0031-discover-features#disclose-message-type
+type Disclose struct {
+	Type      string            `json:"@type,omitempty"`
+	ID        string            `json:"@id,omitempty"`
+	Protocols []*Protocol       `json:"protocols"`
+	Thread    *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Protocol is a supported protocol.
+type Protocol struct {
+	PID   string   `json:"pid"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Queries asks which features matching the queries are supported by the other party (discover-features 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0557-discover-features-v2#queries-message-type
+type Queries struct {
+	Type    string          `json:"@type,omitempty"`
+	ID      string          `json:"@id,omitempty"`
+	Queries []*FeatureQuery `json:"queries"`
+}
+
+// FeatureQuery matches features of a given type.
+type FeatureQuery struct {
+	FeatureType string `json:"feature-type"`
+	Match       string `json:"match"`
+}
+
+// DiscloseV2 lists the supported features matching queries (discover-features 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0557-discover-features-v2#disclose-message-type
+type DiscloseV2 struct {
+	Type        string            `json:"@type,omitempty"`
+	ID          string            `json:"@id,omitempty"`
+	Disclosures []*Disclosure     `json:"disclosures"`
+	Thread      *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Disclosure is a supported feature.
+type Disclosure struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
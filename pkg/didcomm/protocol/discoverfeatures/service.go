@@ -0,0 +1,439 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// DiscoverFeatures defines the protocol name.
+	DiscoverFeatures = "discover-features"
+	// Spec defines the discover-features 1.0 protocol spec.
+	Spec = "https://didcomm.org/discover-features/1.0/"
+	// QueryMsgType defines the discover-features 1.0 query message type.
+	QueryMsgType = Spec + "query"
+	// DiscloseMsgType defines the discover-features 1.0 disclose message type.
+	DiscloseMsgType = Spec + "disclose"
+	// SpecV2 defines the discover-features 2.0 protocol spec.
+	SpecV2 = "https://didcomm.org/discover-features/2.0/"
+	// QueriesMsgType defines the discover-features 2.0 queries message type.
+	QueriesMsgType = SpecV2 + "queries"
+	// DiscloseV2MsgType defines the discover-features 2.0 disclose message type.
+	DiscloseV2MsgType = SpecV2 + "disclose"
+	// FeatureTypeProtocol is the discover-features 2.0 feature type of protocols.
+	FeatureTypeProtocol = "protocol"
+)
+
+// Version of the discover-features protocol.
+type Version string
+
+// supported versions of the discover-features protocol.
+const (
+	V1 Version = "1.0"
+	V2 Version = "2.0"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ErrConnectionNotFound connection not found error.
+var (
+	ErrConnectionNotFound = errors.New("connection not found")
+	logger                = log.New("aries-framework/discoverfeatures")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Registry provides the protocol and message services registered in the framework.
+type Registry interface {
+	AllServices() []dispatcher.ProtocolService
+	MessageServiceProvider() api.MessageServiceProvider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// QueryOption configures a query.
+type QueryOption func(opts *queryOpts)
+
+type queryOpts struct {
+	version Version
+	timeout time.Duration
+}
+
+// WithVersion sets the version of the discover-features protocol used for the query. Defaults to V1.
+func WithVersion(version Version) QueryOption {
+	return func(opts *queryOpts) {
+		opts.version = version
+	}
+}
+
+// WithTimeout sets how long to wait for the disclosure. Defaults to 10 seconds.
+func WithTimeout(timeout time.Duration) QueryOption {
+	return func(opts *queryOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// Service for the Discover Features protocol.
+// Disclosures are computed from the services registered at the time of the query,
+// using the protocols advertised by services implementing dispatcher.FeatureProvider.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0031-discover-features
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0557-discover-features-v2
+type Service struct {
+	registry          Registry
+	connectionLookup  connections
+	outbound          dispatcher.Outbound
+	disclosureMap     map[string]chan []*Protocol
+	disclosureMapLock sync.RWMutex
+}
+
+// New returns the discover features service.
+func New(prov provider, registry Registry) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		registry:         registry,
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		disclosureMap:    make(map[string]chan []*Protocol),
+	}, nil
+}
+
+// HandleInbound handles inbound discover features messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	// perform action asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case QueryMsgType:
+			err = s.handleQuery(msg, myDID, theirDID)
+		case QueriesMsgType:
+			err = s.handleQueries(msg, myDID, theirDID)
+		case DiscloseMsgType:
+			err = s.handleDisclose(msg)
+		case DiscloseV2MsgType:
+			err = s.handleDiscloseV2(msg)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%s)", err)
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the discover features message to the other party.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("send %s message: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case QueryMsgType, DiscloseMsgType, QueriesMsgType, DiscloseV2MsgType:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return DiscoverFeatures
+}
+
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{strings.TrimSuffix(Spec, "/"), strings.TrimSuffix(SpecV2, "/")}
+}
+
+// Supported returns the protocols supported by this agent matching the query.
+// The query may contain * wildcards matching any sequence of characters.
+func (s *Service) Supported(query string) []string {
+	set := map[string]struct{}{}
+
+	add := func(svc interface{}) {
+		fp, ok := svc.(dispatcher.FeatureProvider)
+		if !ok {
+			return
+		}
+
+		for _, pid := range fp.Features() {
+			if match(query, pid) {
+				set[pid] = struct{}{}
+			}
+		}
+	}
+
+	for _, svc := range s.registry.AllServices() {
+		add(svc)
+	}
+
+	if msp := s.registry.MessageServiceProvider(); msp != nil {
+		for _, svc := range msp.Services() {
+			add(svc)
+		}
+	}
+
+	protocols := make([]string, 0, len(set))
+	for pid := range set {
+		protocols = append(protocols, pid)
+	}
+
+	sort.Strings(protocols)
+
+	return protocols
+}
+
+// Query asks the other party of the given connection which protocols matching the query it supports,
+// and waits for its disclosure.
+func (s *Service) Query(connectionID, query string, options ...QueryOption) ([]*Protocol, error) {
+	opts := &queryOpts{version: V1, timeout: defaultTimeout}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		id  = uuid.New().String()
+		msg interface{}
+	)
+
+	switch opts.version {
+	case V1:
+		msg = &Query{Type: QueryMsgType, ID: id, Query: query}
+	case V2:
+		msg = &Queries{
+			Type:    QueriesMsgType,
+			ID:      id,
+			Queries: []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: query}},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported discover-features version %s", opts.version)
+	}
+
+	// register chan for callback processing
+	disclosureCh := make(chan []*Protocol, 1)
+	s.setDisclosureCh(id, disclosureCh)
+
+	defer s.setDisclosureCh(id, nil)
+
+	if err := s.outbound.SendToDID(msg, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send query: %w", err)
+	}
+
+	select {
+	case protocols := <-disclosureCh:
+		return protocols, nil
+	case <-time.After(opts.timeout):
+		return nil, errors.New("timeout waiting for disclosure")
+	}
+}
+
+func (s *Service) handleQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	query := &Query{}
+
+	err := msg.Decode(query)
+	if err != nil {
+		return fmt.Errorf("query message unmarshal: %w", err)
+	}
+
+	disclose := &Disclose{
+		Type:      DiscloseMsgType,
+		ID:        uuid.New().String(),
+		Protocols: []*Protocol{},
+		Thread:    &decorator.Thread{ID: query.ID},
+	}
+
+	for _, pid := range s.Supported(query.Query) {
+		disclose.Protocols = append(disclose.Protocols, &Protocol{PID: pid})
+	}
+
+	return s.outbound.SendToDID(disclose, myDID, theirDID)
+}
+
+func (s *Service) handleQueries(msg service.DIDCommMsg, myDID, theirDID string) error {
+	queries := &Queries{}
+
+	err := msg.Decode(queries)
+	if err != nil {
+		return fmt.Errorf("queries message unmarshal: %w", err)
+	}
+
+	disclose := &DiscloseV2{
+		Type:        DiscloseV2MsgType,
+		ID:          uuid.New().String(),
+		Disclosures: []*Disclosure{},
+		Thread:      &decorator.Thread{ID: queries.ID},
+	}
+
+	disclosed := map[string]struct{}{}
+
+	for _, q := range queries.Queries {
+		// only protocols are disclosed
+		if q.FeatureType != FeatureTypeProtocol {
+			continue
+		}
+
+		for _, pid := range s.Supported(q.Match) {
+			if _, ok := disclosed[pid]; ok {
+				continue
+			}
+
+			disclosed[pid] = struct{}{}
+			disclose.Disclosures = append(disclose.Disclosures, &Disclosure{FeatureType: FeatureTypeProtocol, ID: pid})
+		}
+	}
+
+	return s.outbound.SendToDID(disclose, myDID, theirDID)
+}
+
+func (s *Service) handleDisclose(msg service.DIDCommMsg) error {
+	disclose := &Disclose{}
+
+	err := msg.Decode(disclose)
+	if err != nil {
+		return fmt.Errorf("disclose message unmarshal: %w", err)
+	}
+
+	if disclose.Thread == nil {
+		return errors.New("disclose without thread")
+	}
+
+	s.notifyDisclosure(disclose.Thread.ID, disclose.Protocols)
+
+	return nil
+}
+
+func (s *Service) handleDiscloseV2(msg service.DIDCommMsg) error {
+	disclose := &DiscloseV2{}
+
+	err := msg.Decode(disclose)
+	if err != nil {
+		return fmt.Errorf("disclose message unmarshal: %w", err)
+	}
+
+	if disclose.Thread == nil {
+		return errors.New("disclose without thread")
+	}
+
+	var protocols []*Protocol
+
+	for _, d := range disclose.Disclosures {
+		if d.FeatureType == FeatureTypeProtocol {
+			protocols = append(protocols, &Protocol{PID: d.ID, Roles: d.Roles})
+		}
+	}
+
+	s.notifyDisclosure(disclose.Thread.ID, protocols)
+
+	return nil
+}
+
+func (s *Service) notifyDisclosure(queryID string, protocols []*Protocol) {
+	// check if there are any channels registered for the query ID
+	disclosureCh := s.getDisclosureCh(queryID)
+	if disclosureCh == nil {
+		return
+	}
+
+	select {
+	case disclosureCh <- protocols:
+	default: // duplicate disclosure
+	}
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) getDisclosureCh(queryID string) chan []*Protocol {
+	s.disclosureMapLock.RLock()
+	defer s.disclosureMapLock.RUnlock()
+
+	return s.disclosureMap[queryID]
+}
+
+func (s *Service) setDisclosureCh(queryID string, disclosureCh chan []*Protocol) {
+	s.disclosureMapLock.Lock()
+	defer s.disclosureMapLock.Unlock()
+
+	if disclosureCh == nil {
+		delete(s.disclosureMap, queryID)
+	} else {
+		s.disclosureMap[queryID] = disclosureCh
+	}
+}
+
+// match reports whether pid matches query, where * in the query matches any sequence of characters.
+func match(query, pid string) bool {
+	parts := strings.Split(query, "*")
+	if len(parts) == 1 {
+		return query == pid
+	}
+
+	if !strings.HasPrefix(pid, parts[0]) {
+		return false
+	}
+
+	pid = pid[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(pid, part)
+		if i < 0 {
+			return false
+		}
+
+		pid = pid[i+len(part):]
+	}
+
+	return strings.HasSuffix(pid, parts[len(parts)-1])
+}
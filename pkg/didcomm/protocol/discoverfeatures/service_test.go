@@ -0,0 +1,271 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
+	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+)
+
+func TestServiceNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := New(newProvider(nil), &mockRegistry{})
+		require.NoError(t, err)
+		require.Equal(t, DiscoverFeatures, svc.Name())
+		require.Equal(t, []string{
+			"https://didcomm.org/discover-features/1.0", "https://didcomm.org/discover-features/2.0",
+		}, svc.Features())
+
+		for _, msgType := range []string{QueryMsgType, DiscloseMsgType, QueriesMsgType, DiscloseV2MsgType} {
+			require.True(t, svc.Accept(msgType))
+		}
+
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("store error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		}, &mockRegistry{})
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestSupported(t *testing.T) {
+	registry := &mockRegistry{msgServices: msghandler.NewMockMsgServiceProvider()}
+
+	svc := newService(t, nil, registry)
+	registry.services = []dispatcher.ProtocolService{svc, &mockdidexchange.MockDIDExchangeSvc{}}
+
+	require.Equal(t, []string{
+		"https://didcomm.org/discover-features/1.0", "https://didcomm.org/discover-features/2.0",
+	}, svc.Supported("*"))
+
+	// message services registered later are disclosed
+	basicMsgSvc, err := basic.NewMessageService("basic", func(basic.Message, string, string) error { return nil })
+	require.NoError(t, err)
+	require.NoError(t, registry.msgServices.Register(basicMsgSvc))
+
+	require.Equal(t, []string{"https://didcomm.org/basicmessage/1.0"}, svc.Supported("https://didcomm.org/basic*"))
+	require.Len(t, svc.Supported("*"), 3)
+	require.Equal(t, []string{"https://didcomm.org/discover-features/2.0"}, svc.Supported("*/discover-*/2.*"))
+	require.Empty(t, svc.Supported("https://didcomm.org/trust_ping/1.0"))
+}
+
+func TestMatch(t *testing.T) {
+	const pid = "https://didcomm.org/trust_ping/1.0"
+
+	require.True(t, match(pid, pid))
+	require.True(t, match("*", pid))
+	require.True(t, match("https://didcomm.org/*", pid))
+	require.True(t, match("*/trust_ping/*", pid))
+	require.True(t, match("https://*/trust_ping/1.*", pid))
+	require.False(t, match("https://didcomm.org/trust_ping/2.*", pid))
+	require.False(t, match("*/trust_ping/2.0", pid))
+	require.False(t, match("http://*", pid))
+	require.False(t, match("", pid))
+}
+
+func TestQuery(t *testing.T) {
+	for _, version := range []Version{V1, V2} {
+		version := version
+
+		t.Run("success "+string(version), func(t *testing.T) {
+			var alice, bob *Service
+
+			alice = newService(t, func(msg interface{}, myDID, theirDID string) error {
+				_, err := bob.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+				return err
+			}, &mockRegistry{})
+
+			bobRegistry := &mockRegistry{}
+			bob = newService(t, func(msg interface{}, myDID, theirDID string) error {
+				_, err := alice.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+				return err
+			}, bobRegistry)
+			bobRegistry.services = []dispatcher.ProtocolService{bob}
+
+			protocols, err := alice.Query("conn", "https://didcomm.org/discover-features/*", WithVersion(version))
+			require.NoError(t, err)
+			require.Equal(t, []*Protocol{
+				{PID: "https://didcomm.org/discover-features/1.0"},
+				{PID: "https://didcomm.org/discover-features/2.0"},
+			}, protocols)
+		})
+	}
+
+	t.Run("unsupported version", func(t *testing.T) {
+		svc := newService(t, nil, &mockRegistry{})
+
+		_, err := svc.Query("conn", "*", WithVersion("3.0"))
+		require.EqualError(t, err, "unsupported discover-features version 3.0")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		svc := newService(t, nil, &mockRegistry{})
+
+		_, err := svc.Query("conn", "*", WithTimeout(time.Millisecond))
+		require.EqualError(t, err, "timeout waiting for disclosure")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc := newService(t, nil, &mockRegistry{})
+
+		_, err := svc.Query("unknown", "*")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc := newService(t, func(interface{}, string, string) error {
+			return errors.New("send error")
+		}, &mockRegistry{})
+
+		_, err := svc.Query("conn", "*")
+		require.EqualError(t, err, "send query: send error")
+	})
+}
+
+func TestHandleInbound(t *testing.T) {
+	t.Run("queries with other feature types", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+
+		registry := &mockRegistry{}
+		svc := newService(t, func(msg interface{}, _, _ string) error {
+			sent <- msg
+
+			return nil
+		}, registry)
+		registry.services = []dispatcher.ProtocolService{svc}
+
+		err := svc.handleQueries(service.NewDIDCommMsgMap(&Queries{
+			ID:   "123",
+			Type: QueriesMsgType,
+			Queries: []*FeatureQuery{
+				{FeatureType: "goal-code", Match: "*"},
+				{FeatureType: FeatureTypeProtocol, Match: "*/1.0"},
+				{FeatureType: FeatureTypeProtocol, Match: "*"},
+			},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		disclose, ok := (<-sent).(*DiscloseV2)
+		require.True(t, ok)
+		require.Equal(t, "123", disclose.Thread.ID)
+		require.Len(t, disclose.Disclosures, 2)
+	})
+
+	t.Run("decode errors", func(t *testing.T) {
+		svc := newService(t, nil, &mockRegistry{})
+
+		msg := service.DIDCommMsgMap{"@id": map[int]int{}}
+
+		err := svc.handleQuery(msg, MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "query message unmarshal")
+
+		err = svc.handleQueries(msg, MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "queries message unmarshal")
+
+		err = svc.handleDisclose(msg)
+		require.Contains(t, err.Error(), "disclose message unmarshal")
+
+		err = svc.handleDiscloseV2(msg)
+		require.Contains(t, err.Error(), "disclose message unmarshal")
+	})
+
+	t.Run("disclose without thread", func(t *testing.T) {
+		svc := newService(t, nil, &mockRegistry{})
+
+		err := svc.handleDisclose(service.NewDIDCommMsgMap(&Disclose{Type: DiscloseMsgType}))
+		require.EqualError(t, err, "disclose without thread")
+
+		err = svc.handleDiscloseV2(service.NewDIDCommMsgMap(&DiscloseV2{Type: DiscloseV2MsgType}))
+		require.EqualError(t, err, "disclose without thread")
+	})
+
+	t.Run("handle outbound", func(t *testing.T) {
+		svc := newService(t, nil, &mockRegistry{})
+
+		id, err := svc.HandleOutbound(service.NewDIDCommMsgMap(&Query{ID: "123", Type: QueryMsgType}), MYDID, THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, "123", id)
+
+		svc = newService(t, func(interface{}, string, string) error {
+			return errors.New("send error")
+		}, &mockRegistry{})
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Query{ID: "123", Type: QueryMsgType}), MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "send error")
+	})
+}
+
+type mockRegistry struct {
+	services    []dispatcher.ProtocolService
+	msgServices *msghandler.MockMsgSvcProvider
+}
+
+func (m *mockRegistry) AllServices() []dispatcher.ProtocolService {
+	return m.services
+}
+
+func (m *mockRegistry) MessageServiceProvider() api.MessageServiceProvider {
+	if m.msgServices == nil {
+		return nil
+	}
+
+	return m.msgServices
+}
+
+func newProvider(sendToDID func(msg interface{}, myDID, theirDID string) error) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           &mockdispatcher.MockOutbound{ValidateSendToDID: sendToDID},
+	}
+}
+
+func newService(t *testing.T, sendToDID func(msg interface{}, myDID, theirDID string) error,
+	registry Registry) *Service {
+	t.Helper()
+
+	provider := newProvider(sendToDID)
+
+	r, err := connection.NewRecorder(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+	}))
+
+	svc, err := New(provider, registry)
+	require.NoError(t, err)
+
+	return svc
+}
@@ -11,11 +11,14 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -109,6 +112,7 @@ type Service struct {
 	callbacks chan *metaData
 	oobEvent  chan service.StateMsg
 	messenger service.Messenger
+	lifecycle *lifecycle.Tracker
 }
 
 // Provider contains dependencies for the DID exchange protocol and is typically created by using aries.Context().
@@ -158,6 +162,12 @@ func New(p Provider) (*Service, error) {
 	return svc, nil
 }
 
+// UseLifecycle enables rejecting expired and out-of-order messages and abandoning stale protocol instances.
+func (s *Service) UseLifecycle(tracker *lifecycle.Tracker) {
+	s.lifecycle = tracker
+	s.lifecycle.Start(s.abandonStale)
+}
+
 // startInternalListener listens to messages in gochannel for callback messages from clients.
 func (s *Service) startInternalListener() {
 	for {
@@ -296,6 +306,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 	md.MyDID = myDID
 	md.TheirDID = theirDID
 
+	if err = s.checkLifecycle(md); err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+
 	// trigger action event based on message type for inbound messages
 	if canTriggerActionEvents(msg) {
 		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
@@ -328,6 +342,10 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *metaData, stateID string, stateType service.StateMsgType) {
+	if stateType == service.PostState {
+		metrics.ProtocolStateTransitions.Inc(Introduce, stateID)
+	}
+
 	// trigger the message events
 	for _, handler := range s.MsgEvents() {
 		handler <- service.StateMsg{
@@ -650,6 +668,10 @@ func (s *Service) handle(md *metaData) error {
 		return fmt.Errorf("failed to persist state %s: %w", stateName, err)
 	}
 
+	if err := s.trackState(md.PIID, stateName); err != nil {
+		return fmt.Errorf("track state %s: %w", stateName, err)
+	}
+
 	for _, action := range actions {
 		if err := action(); err != nil {
 			return err
@@ -659,6 +681,56 @@ func (s *Service) handle(md *metaData) error {
 	return nil
 }
 
+// checkLifecycle rejects the inbound message with a problem-report if it has expired or was received out of order.
+func (s *Service) checkLifecycle(md *metaData) error {
+	if s.lifecycle == nil {
+		return nil
+	}
+
+	err := s.lifecycle.Check(md.Msg, md.PIID, md.TheirDID)
+	if err != nil {
+		if rErr := lifecycle.Reject(s.messenger, md.Msg, ProblemReportMsgType, md.MyDID, md.TheirDID, err); rErr != nil {
+			logger.Errorf("reject message: %s", rErr)
+		}
+	}
+
+	return err
+}
+
+// trackState records the activity of the protocol instance until it is done.
+func (s *Service) trackState(piID, stateName string) error {
+	if s.lifecycle == nil {
+		return nil
+	}
+
+	if stateName == stateNameDone {
+		return s.lifecycle.Complete(piID)
+	}
+
+	return s.lifecycle.Touch(piID)
+}
+
+// abandonStale abandons the protocol instance which had no activity within the lifecycle timeout.
+func (s *Service) abandonStale(piID string) error {
+	if err := s.deleteTransitionalPayload(piID); err != nil {
+		return fmt.Errorf("delete transitional payload: %w", err)
+	}
+
+	msg := lifecycle.TimeoutMsg(ProblemReportMsgType, piID)
+
+	return s.handle(&metaData{
+		transitionalPayload: transitionalPayload{
+			StateName: stateNameAbandoning,
+			Action:    Action{Msg: msg, PIID: piID},
+		},
+		saveMetadata: s.saveMetadata,
+		state:        &abandoning{},
+		msgClone:     msg.Clone(),
+		inbound:      true,
+		err:          lifecycle.ErrTimeout,
+	})
+}
+
 func contextOOBMessage(msg service.DIDCommMsg) map[string]interface{} {
 	var oobMsg map[string]interface{}
 
@@ -851,6 +923,11 @@ func (s *Service) Name() string {
 	return Introduce
 }
 
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{strings.TrimSuffix(IntroduceSpec, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
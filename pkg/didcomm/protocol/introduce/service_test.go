@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
@@ -1858,3 +1859,95 @@ func TestOOBMessageReceived(t *testing.T) {
 		require.True(t, ignored)
 	})
 }
+
+func TestService_UseLifecycle(t *testing.T) {
+	newService := func(t *testing.T, messenger service.Messenger, opts ...lifecycle.Opt) *introduce.Service {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+
+		storageProvider := mem.NewProvider()
+
+		provider := introduceMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider).Times(2)
+		provider.EXPECT().Messenger().Return(messenger)
+
+		oobService := serviceMocks.NewMockDIDComm(ctrl)
+		oobService.EXPECT().RegisterMsgEvent(gomock.Any()).Return(nil)
+		provider.EXPECT().Service(outofband.Name).Return(oobService, nil)
+
+		svc, err := introduce.New(provider)
+		require.NoError(t, err)
+
+		tracker, err := lifecycle.New(storageProvider, introduce.Introduce, opts...)
+		require.NoError(t, err)
+
+		svc.UseLifecycle(tracker)
+		t.Cleanup(tracker.Stop)
+
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction, 10)))
+
+		return svc
+	}
+
+	proposal := func(expires time.Time) service.DIDCommMsgMap {
+		return service.DIDCommMsgMap{
+			"@id":     "ID",
+			"@type":   introduce.ProposalMsgType,
+			"~thread": map[string]interface{}{"thid": "thID"},
+			"~timing": map[string]interface{}{"expires_time": expires.Format(time.RFC3339)},
+		}
+	}
+
+	t.Run("Expired message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, introduce.ProblemReportMsgType, r.Type)
+				require.Equal(t, lifecycle.CodeExpired, r.Description.Code)
+				require.Equal(t, "thID", opts.ThreadID)
+				require.Equal(t, Alice, opts.TheirDID)
+
+				return nil
+			})
+
+		svc := newService(t, messenger)
+
+		_, err := svc.HandleInbound(proposal(time.Now().Add(-time.Minute)), Bob, Alice)
+		require.True(t, errors.Is(err, lifecycle.ErrExpired))
+	})
+
+	t.Run("Stale protocol instance", func(t *testing.T) {
+		svc := newService(t, nil, lifecycle.WithTimeout(time.Nanosecond), lifecycle.WithInterval(time.Millisecond))
+
+		events := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		_, err := svc.HandleInbound(proposal(time.Now().Add(time.Hour)), Bob, Alice)
+		require.NoError(t, err)
+
+		for {
+			select {
+			case event := <-events:
+				if event.StateID != "done" || event.Type != service.PostState {
+					continue
+				}
+
+				require.True(t, errors.Is(event.Properties.All()["error"].(error), lifecycle.ErrTimeout))
+
+				actions, err := svc.Actions()
+				require.NoError(t, err)
+				require.Empty(t, actions)
+
+				return
+			case <-time.After(time.Second):
+				t.Fatal("timeout")
+			}
+		}
+	})
+}
@@ -10,10 +10,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -180,6 +183,7 @@ type Service struct {
 	callbacks  chan *metaData
 	messenger  service.Messenger
 	middleware Handler
+	lifecycle  *lifecycle.Tracker
 }
 
 // New returns the issuecredential service.
@@ -217,6 +221,12 @@ func (s *Service) Use(items ...Middleware) {
 	s.middleware = handler
 }
 
+// UseLifecycle enables rejecting expired and out-of-order messages and abandoning stale protocol instances.
+func (s *Service) UseLifecycle(tracker *lifecycle.Tracker) {
+	s.lifecycle = tracker
+	s.lifecycle.Start(s.abandonStale)
+}
+
 // HandleInbound handles inbound message (issuecredential protocol).
 func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
 	aEvent := s.ActionEvent()
@@ -236,6 +246,10 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string)
 	md.MyDID = myDID
 	md.TheirDID = theirDID
 
+	if err = s.checkLifecycle(md); err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+
 	// trigger action event based on message type for inbound messages
 	if canTriggerActionEvents(msg) {
 		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
@@ -380,6 +394,10 @@ func (s *Service) handle(md *metaData) error {
 		return fmt.Errorf("failed to persist state %s: %w", stateName, err)
 	}
 
+	if err := s.trackState(md.PIID, stateName); err != nil {
+		return fmt.Errorf("track state %s: %w", stateName, err)
+	}
+
 	for _, action := range actions {
 		if err := action(s.messenger); err != nil {
 			return fmt.Errorf("action %s: %w", stateName, err)
@@ -389,6 +407,56 @@ func (s *Service) handle(md *metaData) error {
 	return nil
 }
 
+// checkLifecycle rejects the inbound message with a problem-report if it has expired or was received out of order.
+func (s *Service) checkLifecycle(md *metaData) error {
+	if s.lifecycle == nil {
+		return nil
+	}
+
+	err := s.lifecycle.Check(md.Msg, md.PIID, md.TheirDID)
+	if err != nil {
+		if rErr := lifecycle.Reject(s.messenger, md.Msg, ProblemReportMsgType, md.MyDID, md.TheirDID, err); rErr != nil {
+			logger.Errorf("reject message: %s", rErr)
+		}
+	}
+
+	return err
+}
+
+// trackState records the activity of the protocol instance until it is done.
+func (s *Service) trackState(piID, stateName string) error {
+	if s.lifecycle == nil {
+		return nil
+	}
+
+	if stateName == stateNameDone {
+		return s.lifecycle.Complete(piID)
+	}
+
+	return s.lifecycle.Touch(piID)
+}
+
+// abandonStale abandons the protocol instance which had no activity within the lifecycle timeout.
+func (s *Service) abandonStale(piID string) error {
+	if err := s.deleteTransitionalPayload(piID); err != nil {
+		return fmt.Errorf("delete transitional payload: %w", err)
+	}
+
+	msg := lifecycle.TimeoutMsg(ProblemReportMsgType, piID)
+
+	return s.handle(&metaData{
+		transitionalPayload: transitionalPayload{
+			StateName: stateNameAbandoning,
+			Action:    Action{Msg: msg, PIID: piID},
+		},
+		state:      &abandoning{},
+		msgClone:   msg.Clone(),
+		inbound:    true,
+		properties: map[string]interface{}{},
+		err:        lifecycle.ErrTimeout,
+	})
+}
+
 func getPIID(msg service.DIDCommMsg) (string, error) {
 	if pthID := msg.ParentThreadID(); pthID != "" {
 		return pthID, nil
@@ -668,6 +736,10 @@ func (s *Service) execute(next state, md *metaData) (state, stateAction, error)
 
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *metaData, stateID string, stateType service.StateMsgType) {
+	if stateType == service.PostState {
+		metrics.ProtocolStateTransitions.Inc(Name, stateID)
+	}
+
 	// trigger the message events
 	for _, handler := range s.MsgEvents() {
 		handler <- service.StateMsg{
@@ -685,6 +757,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
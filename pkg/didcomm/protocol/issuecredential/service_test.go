@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -1386,6 +1387,110 @@ func TestService_ActionStop(t *testing.T) {
 	})
 }
 
+func TestService_UseLifecycle(t *testing.T) {
+	newService := func(t *testing.T, messenger service.Messenger, opts ...lifecycle.Opt) *Service {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+
+		storeProvider := mem.NewProvider()
+
+		provider := issuecredentialMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger)
+		provider.EXPECT().StorageProvider().Return(storeProvider).AnyTimes()
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		tracker, err := lifecycle.New(storeProvider, Name, opts...)
+		require.NoError(t, err)
+
+		svc.UseLifecycle(tracker)
+		t.Cleanup(tracker.Stop)
+
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction, 10)))
+
+		return svc
+	}
+
+	proposal := func(order int, expires time.Time) service.DIDCommMsgMap {
+		return service.DIDCommMsgMap{
+			"@id":     uuid.New().String(),
+			"@type":   ProposeCredentialMsgType,
+			"~thread": map[string]interface{}{"thid": "thID", "sender_order": order},
+			"~timing": map[string]interface{}{"expires_time": expires.Format(time.RFC3339)},
+		}
+	}
+
+	t.Run("Expired message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, ProblemReportMsgType, r.Type)
+				require.Equal(t, lifecycle.CodeExpired, r.Description.Code)
+				require.Equal(t, "thID", opts.ThreadID)
+				require.Equal(t, Bob, opts.TheirDID)
+
+				return nil
+			})
+
+		svc := newService(t, messenger)
+
+		_, err := svc.HandleInbound(proposal(0, time.Now().Add(-time.Minute)), Alice, Bob)
+		require.True(t, errors.Is(err, lifecycle.ErrExpired))
+	})
+
+	t.Run("Out of order message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).Return(nil)
+
+		svc := newService(t, messenger)
+
+		_, err := svc.HandleInbound(proposal(2, time.Now().Add(time.Hour)), Alice, Bob)
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(proposal(1, time.Now().Add(time.Hour)), Alice, Bob)
+		require.True(t, errors.Is(err, lifecycle.ErrOutOfOrder))
+	})
+
+	t.Run("Stale protocol instance", func(t *testing.T) {
+		svc := newService(t, nil, lifecycle.WithTimeout(time.Nanosecond), lifecycle.WithInterval(time.Millisecond))
+
+		events := make(chan service.StateMsg, 10)
+		require.NoError(t, svc.RegisterMsgEvent(events))
+
+		_, err := svc.HandleInbound(proposal(0, time.Now().Add(time.Hour)), Alice, Bob)
+		require.NoError(t, err)
+
+		for {
+			select {
+			case event := <-events:
+				if event.StateID != stateNameDone || event.Type != service.PostState {
+					continue
+				}
+
+				require.True(t, errors.Is(event.Properties.All()["error"].(error), lifecycle.ErrTimeout))
+
+				actions, err := svc.Actions()
+				require.NoError(t, err)
+				require.Empty(t, actions)
+
+				return
+			case <-time.After(time.Second):
+				t.Fatal("timeout")
+			}
+		}
+	})
+}
+
 func Test_stateFromName(t *testing.T) {
 	require.Equal(t, stateFromName(stateNameStart), &start{})
 	require.Equal(t, stateFromName(stateNameAbandoning), &abandoning{})
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -342,6 +343,14 @@ func (s *Service) Name() string {
 	return Coordination
 }
 
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{
+		strings.TrimSuffix(CoordinationSpec, "/"),
+		strings.TrimSuffix(service.RoutingSpec, "/"),
+	}
+}
+
 func (s *Service) handleInboundRequest(c *callback) error {
 	// unmarshal the payload
 	request := &Request{}
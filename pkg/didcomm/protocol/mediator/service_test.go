@@ -54,6 +54,9 @@ func TestServiceNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.Equal(t, Coordination, svc.Name())
+		require.Equal(t, []string{
+			"https://didcomm.org/coordinatemediation/1.0", "https://didcomm.org/routing/1.0",
+		}, svc.Features())
 	})
 
 	t.Run("test new service name - failure", func(t *testing.T) {
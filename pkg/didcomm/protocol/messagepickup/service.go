@@ -9,6 +9,7 @@ package messagepickup
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -155,6 +156,11 @@ func (s *Service) Name() string {
 	return MessagePickup
 }
 
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
 func (s *Service) handleStatus(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	statusMsg := &Status{}
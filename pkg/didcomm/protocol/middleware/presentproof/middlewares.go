@@ -53,11 +53,32 @@ type Provider interface {
 	Crypto() crypto.Crypto
 }
 
+// OptSP represents option function for the SavePresentation middleware.
+type OptSP func(o *spOptions)
+
+// WithVerificationPolicy allows providing the policy which received presentations and credentials embedded
+// into them are checked against. Presentations violating the policy are not saved.
+func WithVerificationPolicy(policy *verifiable.VerificationPolicy) OptSP {
+	return func(o *spOptions) {
+		o.policy = policy
+	}
+}
+
+type spOptions struct {
+	policy *verifiable.VerificationPolicy
+}
+
 // SavePresentation the helper function for the present proof protocol which saves the presentations.
-func SavePresentation(p Provider) presentproof.Middleware {
+func SavePresentation(p Provider, opts ...OptSP) presentproof.Middleware {
 	vdr := p.VDRegistry()
 	store := p.VerifiableStore()
 
+	options := &spOptions{}
+
+	for i := range opts {
+		opts[i](options)
+	}
+
 	return func(next presentproof.Handler) presentproof.Handler {
 		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
 			if metadata.StateName() != stateNamePresentationReceived {
@@ -69,7 +90,7 @@ func SavePresentation(p Provider) presentproof.Middleware {
 				return fmt.Errorf("decode: %w", err)
 			}
 
-			presentations, err := toVerifiablePresentation(vdr, presentation.PresentationsAttach)
+			presentations, err := toVerifiablePresentation(vdr, presentation.PresentationsAttach, options.policy)
 			if err != nil {
 				return fmt.Errorf("to verifiable presentation: %w", err)
 			}
@@ -339,7 +360,8 @@ func getName(idx int, id string, metadata presentproof.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment) ([]*verifiable.Presentation, error) {
+func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment,
+	policy *verifiable.VerificationPolicy) ([]*verifiable.Presentation, error) {
 	var presentations []*verifiable.Presentation
 
 	for i := range data {
@@ -353,6 +375,7 @@ func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment)
 				verifiable.NewDIDKeyResolver(vdr).PublicKeyFetcher(),
 			),
 			verifiable.WithPresJSONLDDocumentLoader(presexch.CachingJSONLDLoader()),
+			verifiable.WithPresVerificationPolicy(policy),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("parse presentation: %w", err)
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/presentproof"
 	mocksvdr "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/framework/aries/api/vdr"
 	mocksstore "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
@@ -160,8 +161,8 @@ func TestSavePresentation(t *testing.T) {
 			Return(errors.New(errMsg))
 
 		registry := mocksvdr.NewMockRegistry(ctrl)
-		registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").Return(
-			&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil)
+		registry.EXPECT().Dereference("did:example:ebfeb1f712ebc6f1c276e12ec21#key-1").Return(
+			&vdrapi.DereferenceResult{VerificationMethod: &pubKey}, nil)
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
@@ -182,8 +183,8 @@ func TestSavePresentation(t *testing.T) {
 		}))
 
 		registry := mocksvdr.NewMockRegistry(ctrl)
-		registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").
-			Return(&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil)
+		registry.EXPECT().Dereference("did:example:ebfeb1f712ebc6f1c276e12ec21#key-1").
+			Return(&vdrapi.DereferenceResult{VerificationMethod: &pubKey}, nil)
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
@@ -216,6 +217,8 @@ func TestSavePresentation(t *testing.T) {
 			Return(nil)
 
 		registry := mocksvdr.NewMockRegistry(ctrl)
+		registry.EXPECT().Dereference("did:example:ebfeb1f712ebc6f1c276e12ec21#").Return(
+			nil, vdrapi.ErrResourceNotFound)
 		registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").Return(
 			&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil)
 
@@ -228,6 +231,39 @@ func TestSavePresentation(t *testing.T) {
 		require.NotEmpty(t, props["names"].([]string)[0])
 	})
 
+	t.Run("Verification policy violated", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.Presentation{
+			Type: presentproof.PresentationMsgType,
+			PresentationsAttach: []decorator.Attachment{
+				{Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(vpJWS))}},
+			},
+		}))
+
+		registry := mocksvdr.NewMockRegistry(ctrl)
+		registry.EXPECT().Dereference("did:example:ebfeb1f712ebc6f1c276e12ec21#key-1").Return(
+			&vdrapi.DereferenceResult{VerificationMethod: &pubKey}, nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(mocksstore.NewMockStore(ctrl))
+
+		policy := &verifiable.VerificationPolicy{
+			TrustedIssuers: map[string][]string{verifiable.VCType: {"did:example:trusted"}},
+		}
+
+		err := SavePresentation(provider, WithVerificationPolicy(policy))(next).Handle(metadata)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not trusted for credential type VerifiableCredential")
+
+		var policyErr *verifiable.PolicyError
+
+		require.True(t, errors.As(err, &policyErr))
+		require.Len(t, policyErr.Violations, 1)
+		require.Equal(t, verifiable.PolicyRuleTrustedIssuer, policyErr.Violations[0].Rule)
+	})
+
 	t.Run("Success", func(t *testing.T) {
 		const vcName = "vc-name"
 
@@ -252,8 +288,8 @@ func TestSavePresentation(t *testing.T) {
 			Return(nil)
 
 		registry := mocksvdr.NewMockRegistry(ctrl)
-		registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").Return(
-			&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil)
+		registry.EXPECT().Dereference("did:example:ebfeb1f712ebc6f1c276e12ec21#key-1").Return(
+			&vdrapi.DereferenceResult{VerificationMethod: &pubKey}, nil)
 
 		provider := mocks.NewMockProvider(ctrl)
 		provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
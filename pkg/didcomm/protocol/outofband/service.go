@@ -173,6 +173,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{"https://didcomm.org/out-of-band/1.0", "https://didcomm.org/oob-request/1.0"}
+}
+
 // Accept determines whether this service can handle the given type of message.
 func (s *Service) Accept(msgType string) bool {
 	return msgType == RequestMsgType || msgType == InvitationMsgType
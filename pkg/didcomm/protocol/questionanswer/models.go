@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Question is sent by the questioner to ask the responder a question.
+type Question struct {
+	Type string `json:"@type,omitempty"`
+	// QuestionText is the question to be answered.
+	QuestionText string `json:"question_text"`
+	// QuestionDetail is an optional text that gives more context to the question.
+	QuestionDetail string `json:"question_detail,omitempty"`
+	// Nonce is a unique value which is expected to be included in a signed response.
+	Nonce string `json:"nonce,omitempty"`
+	// SignatureRequired indicates whether the response is expected to be signed.
+	SignatureRequired bool `json:"signature_required,omitempty"`
+	// ValidResponses lists the responses the questioner accepts.
+	ValidResponses []ValidResponse `json:"valid_responses,omitempty"`
+	// Timing contains the time after which the question may no longer be answered.
+	Timing *decorator.Timing `json:"~timing,omitempty"`
+}
+
+// ValidResponse is one of the responses accepted by the questioner.
+type ValidResponse struct {
+	Text string `json:"text"`
+}
+
+// Answer is sent by the responder to answer the question.
+type Answer struct {
+	Type string `json:"@type,omitempty"`
+	// Response is the text of the selected response.
+	Response string `json:"response"`
+}
@@ -0,0 +1,272 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+		require.Equal(t, Name, svc.Name())
+		require.Equal(t, []string{"https://didcomm.org/questionanswer/1.0"}, svc.Features())
+
+		for _, msgType := range []string{QuestionMsgType, AnswerMsgType, ProblemReportMsgType} {
+			require.True(t, svc.Accept(msgType))
+		}
+
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("Error open store", func(t *testing.T) {
+		_, err := New(&provider{storage: &mockstore.MockStoreProvider{
+			ErrOpenStoreHandle: errors.New("store error"),
+		}})
+		require.EqualError(t, err, "store error")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("No clients", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(randomInboundMessage(QuestionMsgType), Alice, Bob)
+		require.EqualError(t, err, "no clients are registered to handle the message")
+	})
+
+	t.Run("Unrecognized message", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Question{Type: "unknown"}), Alice, Bob)
+		require.EqualError(t, err, "doHandle: nextState: unrecognized msgType: unknown")
+	})
+
+	t.Run("Answer without question", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(service.NewDIDCommMsgMap(&Answer{Type: AnswerMsgType}), Alice, Bob)
+		require.EqualError(t, err, "doHandle: nextState: answer can be sent only as a reply to a question")
+	})
+
+	t.Run("Invalid state transition", func(t *testing.T) {
+		svc, err := New(&provider{storage: mem.NewProvider()})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+		_, err = svc.HandleInbound(randomInboundMessage(AnswerMsgType), Alice, Bob)
+		require.EqualError(t, err, "doHandle: invalid state transition: start -> answer-received")
+	})
+}
+
+func TestService_Question(t *testing.T) {
+	alice, bob := newPeers(t)
+
+	require.NoError(t, alice.RegisterActionEvent(make(chan service.DIDCommAction)))
+
+	aliceStates := make(chan service.StateMsg, 10)
+	require.NoError(t, alice.RegisterMsgEvent(aliceStates))
+
+	bobActions := make(chan service.DIDCommAction)
+	require.NoError(t, bob.RegisterActionEvent(bobActions))
+
+	// Alice asks a question
+	piID, err := alice.HandleInbound(service.NewDIDCommMsgMap(&Question{
+		Type:           QuestionMsgType,
+		QuestionText:   "Are you on the phone with the bank?",
+		ValidResponses: []ValidResponse{{Text: "yes"}, {Text: "no"}},
+	}), Alice, Bob)
+	require.NoError(t, err)
+	require.NotEmpty(t, piID)
+
+	// Bob answers the question
+	action := waitForAction(t, bobActions, QuestionMsgType)
+	require.Equal(t, piID, action.Properties.All()["piid"])
+
+	question := &Question{}
+	require.NoError(t, action.Message.Decode(question))
+	require.Equal(t, "Are you on the phone with the bank?", question.QuestionText)
+
+	actions, err := bob.Actions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	require.NoError(t, bob.ActionContinue(piID, WithAnswer(&Answer{Response: "yes"})))
+
+	// Alice receives the answer
+	for {
+		select {
+		case msg := <-aliceStates:
+			if msg.StateID != stateNameAnswerReceived || msg.Type != service.PostState {
+				continue
+			}
+
+			answer := &Answer{}
+			require.NoError(t, msg.Msg.Decode(answer))
+			require.Equal(t, "yes", answer.Response)
+
+			return
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
+
+func TestService_Stop(t *testing.T) {
+	alice, bob := newPeers(t)
+
+	aliceActions := make(chan service.DIDCommAction)
+	require.NoError(t, alice.RegisterActionEvent(aliceActions))
+
+	bobActions := make(chan service.DIDCommAction)
+	require.NoError(t, bob.RegisterActionEvent(bobActions))
+
+	bobStates := make(chan service.StateMsg, 10)
+	require.NoError(t, bob.RegisterMsgEvent(bobStates))
+
+	piID, err := alice.HandleInbound(service.NewDIDCommMsgMap(&Question{
+		Type:         QuestionMsgType,
+		QuestionText: "Are you on the phone with the bank?",
+	}), Alice, Bob)
+	require.NoError(t, err)
+
+	waitForAction(t, bobActions, QuestionMsgType)
+
+	require.NoError(t, bob.ActionStop(piID, errors.New("no answer")))
+
+	// Alice receives the problem report
+	action := waitForAction(t, aliceActions, ProblemReportMsgType)
+	action.Continue(nil)
+
+	for {
+		select {
+		case msg := <-bobStates:
+			if msg.StateID == stateNameAbandoned && msg.Type == service.PostState {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
+
+func TestService_ActionErrors(t *testing.T) {
+	svc, err := New(&provider{storage: mem.NewProvider()})
+	require.NoError(t, err)
+
+	require.Contains(t, svc.ActionContinue(uuid.New().String(), nil).Error(), "get transitional payload")
+	require.Contains(t, svc.ActionStop(uuid.New().String(), nil).Error(), "get transitional payload")
+}
+
+func waitForAction(t *testing.T, actions chan service.DIDCommAction, msgType string) service.DIDCommAction {
+	t.Helper()
+
+	select {
+	case action := <-actions:
+		require.Equal(t, msgType, action.Message.Type())
+
+		return action
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for %s", msgType)
+	}
+
+	return service.DIDCommAction{}
+}
+
+func newPeers(t *testing.T) (*Service, *Service) {
+	t.Helper()
+
+	aliceMessenger, bobMessenger := &messenger{}, &messenger{}
+
+	alice, err := New(&provider{storage: mem.NewProvider(), messenger: aliceMessenger})
+	require.NoError(t, err)
+
+	bob, err := New(&provider{storage: mem.NewProvider(), messenger: bobMessenger})
+	require.NoError(t, err)
+
+	aliceMessenger.peer = bob
+	bobMessenger.peer = alice
+
+	return alice, bob
+}
+
+type provider struct {
+	storage   storage.Provider
+	messenger service.Messenger
+}
+
+func (p *provider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *provider) StorageProvider() storage.Provider {
+	return p.storage
+}
+
+// messenger delivers the messages directly to the peer's service.
+type messenger struct {
+	service.Messenger
+	peer *Service
+}
+
+func (m *messenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	if msg.ID() == "" {
+		msg["@id"] = uuid.New().String()
+	}
+
+	msg[jsonThread] = map[string]interface{}{"thid": msg.ID()}
+
+	return m.deliver(msg, myDID, theirDID)
+}
+
+func (m *messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID string) error {
+	thID, err := in.ThreadID()
+	if err != nil {
+		return err
+	}
+
+	out["@id"] = uuid.New().String()
+	out[jsonThread] = map[string]interface{}{"thid": thID}
+
+	return m.deliver(out, myDID, theirDID)
+}
+
+func (m *messenger) ReplyToNested(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+	msg["@id"] = uuid.New().String()
+	msg[jsonThread] = map[string]interface{}{"pthid": opts.ThreadID}
+
+	return m.deliver(msg, opts.MyDID, opts.TheirDID)
+}
+
+func (m *messenger) deliver(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	go func() {
+		// nolint: errcheck
+		_, _ = m.peer.HandleInbound(msg, theirDID, myDID)
+	}()
+
+	return nil
+}
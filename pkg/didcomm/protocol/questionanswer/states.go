@@ -0,0 +1,270 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	// common states.
+	stateNameStart     = "start"
+	stateNameAbandoned = "abandoned"
+	stateNameDone      = "done"
+	stateNameNoop      = "noop"
+
+	// states for Questioner.
+	stateNameQuestionSent   = "question-sent"
+	stateNameAnswerReceived = "answer-received"
+
+	// states for Responder.
+	stateNameQuestionReceived = "question-received"
+	stateNameAnswerSent       = "answer-sent"
+)
+
+const (
+	// error codes.
+	codeInternalError = "internal"
+	codeRejectedError = "rejected"
+
+	jsonThread = "~thread"
+)
+
+// state action for network call.
+type stateAction func(messenger service.Messenger) error
+
+// the protocol's state.
+type state interface {
+	// Name of this state.
+	Name() string
+	// Whether this state allows transitioning into the next state.
+	CanTransitionTo(next state) bool
+	// Executes this state, returning a followup state to be immediately executed as well.
+	// The 'noOp' state should be returned if the state has no followup.
+	Execute(msg *metaData) (state, stateAction, error)
+}
+
+// represents zero state's action.
+func zeroAction(service.Messenger) error { return nil }
+
+// start state.
+type start struct{}
+
+func (s *start) Name() string {
+	return stateNameStart
+}
+
+func (s *start) CanTransitionTo(st state) bool {
+	switch st.Name() {
+	// Questioner.
+	case stateNameQuestionSent:
+		return true
+	// Responder.
+	case stateNameQuestionReceived:
+		return true
+	}
+
+	return false
+}
+
+func (s *start) Execute(_ *metaData) (state, stateAction, error) {
+	return nil, nil, fmt.Errorf("%s: is not implemented yet", s.Name())
+}
+
+// abandoned state.
+type abandoned struct {
+	Code string
+}
+
+func (s *abandoned) Name() string {
+	return stateNameAbandoned
+}
+
+func (s *abandoned) CanTransitionTo(st state) bool {
+	return false
+}
+
+func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
+	// if code is not provided it means we do not need to notify the another agent.
+	// if we received ProblemReport message no need to answer.
+	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType {
+		return &noOp{}, zeroAction, nil
+	}
+
+	code := model.Code{Code: s.Code}
+
+	// if the protocol was stopped by the user we will set the rejected error code
+	if errors.As(md.err, &customError{}) {
+		code = model.Code{Code: codeRejectedError}
+	}
+
+	thID, err := md.Msg.ThreadID()
+	if err != nil {
+		return nil, nil, fmt.Errorf("threadID: %w", err)
+	}
+
+	return &noOp{}, func(messenger service.Messenger) error {
+		return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
+			Type:        ProblemReportMsgType,
+			Description: code,
+		}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
+	}, nil
+}
+
+// done state.
+type done struct{}
+
+func (s *done) Name() string {
+	return stateNameDone
+}
+
+func (s *done) CanTransitionTo(_ state) bool {
+	return false
+}
+
+func (s *done) Execute(_ *metaData) (state, stateAction, error) {
+	return &noOp{}, zeroAction, nil
+}
+
+// noOp state.
+type noOp struct{}
+
+func (s *noOp) Name() string {
+	return stateNameNoop
+}
+
+func (s *noOp) CanTransitionTo(_ state) bool {
+	return false
+}
+
+func (s *noOp) Execute(_ *metaData) (state, stateAction, error) {
+	return nil, nil, errors.New("cannot execute no-op")
+}
+
+func canReplyTo(msg service.DIDCommMsgMap) bool {
+	_, ok := msg[jsonThread]
+	return ok
+}
+
+func forwardInitial(md *metaData) stateAction {
+	return func(messenger service.Messenger) error {
+		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
+	}
+}
+
+// questionSent the Questioner's state.
+type questionSent struct{}
+
+func (s *questionSent) Name() string {
+	return stateNameQuestionSent
+}
+
+func (s *questionSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameAnswerReceived ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *questionSent) Execute(md *metaData) (state, stateAction, error) {
+	return &noOp{}, forwardInitial(md), nil
+}
+
+// answerReceived the Questioner's state.
+type answerReceived struct{}
+
+func (s *answerReceived) Name() string {
+	return stateNameAnswerReceived
+}
+
+func (s *answerReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *answerReceived) Execute(_ *metaData) (state, stateAction, error) {
+	return &done{}, zeroAction, nil
+}
+
+// questionReceived the Responder's state.
+type questionReceived struct{}
+
+func (s *questionReceived) Name() string {
+	return stateNameQuestionReceived
+}
+
+func (s *questionReceived) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameAnswerSent ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *questionReceived) Execute(md *metaData) (state, stateAction, error) {
+	if md.answer == nil {
+		return nil, nil, errors.New("answer was not provided")
+	}
+
+	var question *Question
+
+	if err := md.Msg.Decode(&question); err != nil {
+		return nil, nil, err
+	}
+
+	if question.Timing != nil && question.Timing.Expired(time.Now()) {
+		return nil, nil, errors.New("question has expired")
+	}
+
+	if question.SignatureRequired {
+		return nil, nil, errors.New("signed answers are not supported")
+	}
+
+	if !isValidResponse(question.ValidResponses, md.answer.Response) {
+		return nil, nil, fmt.Errorf("invalid response: %s", md.answer.Response)
+	}
+
+	return &answerSent{}, zeroAction, nil
+}
+
+func isValidResponse(validResponses []ValidResponse, response string) bool {
+	// any response is valid if none were listed
+	if len(validResponses) == 0 {
+		return true
+	}
+
+	for _, valid := range validResponses {
+		if valid.Text == response {
+			return true
+		}
+	}
+
+	return false
+}
+
+// answerSent the Responder's state.
+type answerSent struct{}
+
+func (s *answerSent) Name() string {
+	return stateNameAnswerSent
+}
+
+func (s *answerSent) CanTransitionTo(st state) bool {
+	return st.Name() == stateNameDone ||
+		st.Name() == stateNameAbandoned
+}
+
+func (s *answerSent) Execute(md *metaData) (state, stateAction, error) {
+	if md.answer == nil {
+		return nil, nil, errors.New("answer was not provided")
+	}
+
+	return &done{}, func(messenger service.Messenger) error {
+		md.answer.Type = AnswerMsgType
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(md.answer), md.MyDID, md.TheirDID)
+	}, nil
+}
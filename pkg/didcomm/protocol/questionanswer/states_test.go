@@ -0,0 +1,269 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package questionanswer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+func TestCanTransitionTo(t *testing.T) {
+	allStates := []state{
+		// common states
+		&start{}, &abandoned{}, &done{}, &noOp{},
+		// states for Questioner
+		&questionSent{}, &answerReceived{},
+		// states for Responder
+		&questionReceived{}, &answerSent{},
+	}
+
+	transitions := map[string][]string{
+		stateNameStart:            {stateNameQuestionSent, stateNameQuestionReceived},
+		stateNameQuestionSent:     {stateNameAnswerReceived, stateNameAbandoned},
+		stateNameAnswerReceived:   {stateNameDone, stateNameAbandoned},
+		stateNameQuestionReceived: {stateNameAnswerSent, stateNameAbandoned},
+		stateNameAnswerSent:       {stateNameDone, stateNameAbandoned},
+	}
+
+	for _, current := range allStates {
+		require.Equal(t, current.Name(), stateFromName(current.Name()).Name())
+
+		for _, next := range allStates {
+			expected := false
+
+			for _, name := range transitions[current.Name()] {
+				if name == next.Name() {
+					expected = true
+				}
+			}
+
+			require.Equal(t, expected, current.CanTransitionTo(next), "%s -> %s", current.Name(), next.Name())
+		}
+	}
+}
+
+func TestStart_Execute(t *testing.T) {
+	followup, action, err := (&start{}).Execute(&metaData{})
+	require.Contains(t, fmt.Sprintf("%v", err), "is not implemented yet")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestNoOp_Execute(t *testing.T) {
+	followup, action, err := (&noOp{}).Execute(&metaData{})
+	require.Contains(t, fmt.Sprintf("%v", err), "cannot execute no-op")
+	require.Nil(t, followup)
+	require.Nil(t, action)
+}
+
+func TestDone_Execute(t *testing.T) {
+	followup, action, err := (&done{}).Execute(&metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &noOp{}, followup)
+	require.NoError(t, action(nil))
+}
+
+func TestAbandoned_Execute(t *testing.T) {
+	t.Run("Internal Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		md := &metaData{}
+		md.Msg = randomInboundMessage(QuestionMsgType)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeInternalError, r.Description.Code)
+				require.Equal(t, ProblemReportMsgType, r.Type)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Custom Error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		md := &metaData{err: customError{error: errors.New("error")}}
+		md.Msg = randomInboundMessage(QuestionMsgType)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeRejectedError, r.Description.Code)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Invalid message", func(t *testing.T) {
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(&metaData{})
+		require.EqualError(t, errors.Unwrap(err), service.ErrInvalidMessage.Error())
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Problem report received", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = randomInboundMessage(ProblemReportMsgType)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action(nil))
+	})
+}
+
+func TestQuestionSent_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	md := &metaData{}
+	md.Msg = service.NewDIDCommMsgMap(&Question{Type: QuestionMsgType})
+
+	followup, action, err := (&questionSent{}).Execute(md)
+	require.NoError(t, err)
+	require.Equal(t, &noOp{}, followup)
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().Send(md.Msg, gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, action(messenger))
+}
+
+func TestAnswerReceived_Execute(t *testing.T) {
+	followup, action, err := (&answerReceived{}).Execute(&metaData{})
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+	require.NoError(t, action(nil))
+}
+
+func TestQuestionReceived_Execute(t *testing.T) {
+	newMetaData := func(question *Question, answer *Answer) *metaData {
+		question.Type = QuestionMsgType
+
+		md := &metaData{answer: answer}
+		md.Msg = service.NewDIDCommMsgMap(question)
+
+		return md
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		followup, action, err := (&questionReceived{}).Execute(newMetaData(&Question{
+			ValidResponses: []ValidResponse{{Text: "yes"}, {Text: "no"}},
+			Timing:         &decorator.Timing{ExpiresTime: time.Now().Add(time.Hour)},
+		}, &Answer{Response: "no"}))
+		require.NoError(t, err)
+		require.Equal(t, &answerSent{}, followup)
+		require.NoError(t, action(nil))
+
+		followup, _, err = (&questionReceived{}).Execute(newMetaData(&Question{}, &Answer{Response: "anything"}))
+		require.NoError(t, err)
+		require.Equal(t, &answerSent{}, followup)
+	})
+
+	t.Run("No answer", func(t *testing.T) {
+		_, _, err := (&questionReceived{}).Execute(newMetaData(&Question{}, nil))
+		require.EqualError(t, err, "answer was not provided")
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		md := newMetaData(&Question{}, &Answer{})
+		md.Msg["question_text"] = []int{}
+
+		_, _, err := (&questionReceived{}).Execute(md)
+		require.Error(t, err)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		_, _, err := (&questionReceived{}).Execute(newMetaData(&Question{
+			Timing: &decorator.Timing{ExpiresTime: time.Now().Add(-time.Hour)},
+		}, &Answer{Response: "yes"}))
+		require.EqualError(t, err, "question has expired")
+	})
+
+	t.Run("Signature required", func(t *testing.T) {
+		_, _, err := (&questionReceived{}).Execute(newMetaData(&Question{
+			SignatureRequired: true,
+		}, &Answer{Response: "yes"}))
+		require.EqualError(t, err, "signed answers are not supported")
+	})
+
+	t.Run("Invalid response", func(t *testing.T) {
+		_, _, err := (&questionReceived{}).Execute(newMetaData(&Question{
+			ValidResponses: []ValidResponse{{Text: "yes"}, {Text: "no"}},
+		}, &Answer{Response: "maybe"}))
+		require.EqualError(t, err, "invalid response: maybe")
+	})
+}
+
+func TestAnswerSent_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, _, err := (&answerSent{}).Execute(&metaData{})
+	require.EqualError(t, err, "answer was not provided")
+
+	md := &metaData{answer: &Answer{Response: "yes"}}
+	md.Msg = randomInboundMessage(QuestionMsgType)
+
+	followup, action, err := (&answerSent{}).Execute(md)
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyToMsg(md.Msg, gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+			require.Equal(t, AnswerMsgType, msg.Type())
+
+			return nil
+		})
+
+	require.NoError(t, action(messenger))
+}
+
+func randomInboundMessage(t string) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(struct {
+		ID     string           `json:"@id"`
+		Thread decorator.Thread `json:"~thread"`
+		Type   string           `json:"@type"`
+	}{
+		ID:     uuid.New().String(),
+		Thread: decorator.Thread{ID: uuid.New().String()},
+		Type:   t,
+	})
+}
@@ -0,0 +1,273 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// TrustPing defines the protocol name.
+	TrustPing = "trustping"
+	// Spec defines the protocol spec.
+	Spec = "https://didcomm.org/trust_ping/1.0/"
+	// PingMsgType defines the protocol ping message type.
+	PingMsgType = Spec + "ping"
+	// PingResponseMsgType defines the protocol ping_response message type.
+	PingResponseMsgType = Spec + "ping_response"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ErrConnectionNotFound connection not found error.
+var (
+	ErrConnectionNotFound = errors.New("connection not found")
+	logger                = log.New("aries-framework/trustping")
+)
+
+type provider interface {
+	OutboundDispatcher() dispatcher.Outbound
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// PingOption configures a ping.
+type PingOption func(opts *pingOpts)
+
+type pingOpts struct {
+	comment string
+	timeout time.Duration
+}
+
+// WithComment sets the comment of the ping message.
+func WithComment(comment string) PingOption {
+	return func(opts *pingOpts) {
+		opts.comment = comment
+	}
+}
+
+// WithTimeout sets how long to wait for the ping response. Defaults to 10 seconds.
+func WithTimeout(timeout time.Duration) PingOption {
+	return func(opts *pingOpts) {
+		opts.timeout = timeout
+	}
+}
+
+// Service for the Trust Ping protocol.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0048-trust-ping
+type Service struct {
+	connectionLookup connections
+	outbound         dispatcher.Outbound
+	responseMap      map[string]*pendingPing
+	responseMapLock  sync.RWMutex
+}
+
+// pendingPing is a ping waiting for its response, which must come from the pinged connection.
+type pendingPing struct {
+	myDID      string
+	theirDID   string
+	responseCh chan *PingResponse
+}
+
+// New returns the trust ping service.
+func New(prov provider) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		connectionLookup: connectionLookup,
+		outbound:         prov.OutboundDispatcher(),
+		responseMap:      make(map[string]*pendingPing),
+	}, nil
+}
+
+// HandleInbound handles inbound trust ping messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	// perform action asynchronously
+	go func() {
+		var err error
+
+		switch msg.Type() {
+		case PingMsgType:
+			err = s.handlePing(msg, myDID, theirDID)
+		case PingResponseMsgType:
+			err = s.handlePingResponse(msg, myDID, theirDID)
+		}
+
+		if err != nil {
+			logger.Errorf("Error handling message: (%s)", err)
+		}
+	}()
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound sends the trust ping message to the other party.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("send %s message: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == PingMsgType || msgType == PingResponseMsgType
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return TrustPing
+}
+
+// Features returns the protocols supported by this service.
+func (s *Service) Features() []string {
+	return []string{strings.TrimSuffix(Spec, "/")}
+}
+
+// Ping sends a ping to the other party of the given connection and waits for its response.
+// It returns the round-trip time.
+func (s *Service) Ping(connectionID string, options ...PingOption) (time.Duration, error) {
+	opts := &pingOpts{timeout: defaultTimeout}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return 0, err
+	}
+
+	ping := &Ping{
+		Type:    PingMsgType,
+		ID:      uuid.New().String(),
+		Comment: opts.comment,
+	}
+
+	// register chan for callback processing
+	responseCh := make(chan *PingResponse, 1)
+	s.setPendingPing(ping.ID, &pendingPing{myDID: conn.MyDID, theirDID: conn.TheirDID, responseCh: responseCh})
+
+	defer s.setPendingPing(ping.ID, nil)
+
+	start := time.Now()
+
+	if err := s.outbound.SendToDID(ping, conn.MyDID, conn.TheirDID); err != nil {
+		return 0, fmt.Errorf("send ping: %w", err)
+	}
+
+	select {
+	case <-responseCh:
+		return time.Since(start), nil
+	case <-time.After(opts.timeout):
+		return 0, errors.New("timeout waiting for ping response")
+	}
+}
+
+func (s *Service) handlePing(msg service.DIDCommMsg, myDID, theirDID string) error {
+	ping := &Ping{}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return fmt.Errorf("ping message unmarshal: %w", err)
+	}
+
+	// response is requested unless explicitly declined
+	if ping.ResponseRequested != nil && !*ping.ResponseRequested {
+		return nil
+	}
+
+	response := &PingResponse{
+		Type:   PingResponseMsgType,
+		ID:     uuid.New().String(),
+		Thread: &decorator.Thread{ID: ping.ID},
+	}
+
+	return s.outbound.SendToDID(response, myDID, theirDID)
+}
+
+func (s *Service) handlePingResponse(msg service.DIDCommMsg, myDID, theirDID string) error {
+	response := &PingResponse{}
+
+	err := msg.Decode(response)
+	if err != nil {
+		return fmt.Errorf("ping response message unmarshal: %w", err)
+	}
+
+	if response.Thread == nil {
+		return errors.New("ping response without thread")
+	}
+
+	// check if a ping with this ID is waiting for its response
+	ping := s.getPendingPing(response.Thread.ID)
+	if ping == nil {
+		return nil
+	}
+
+	if ping.myDID != myDID || ping.theirDID != theirDID {
+		return fmt.Errorf("ping response to %s received from another connection", response.Thread.ID)
+	}
+
+	select {
+	case ping.responseCh <- response:
+	default: // duplicate response
+	}
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) getPendingPing(pingID string) *pendingPing {
+	s.responseMapLock.RLock()
+	defer s.responseMapLock.RUnlock()
+
+	return s.responseMap[pingID]
+}
+
+func (s *Service) setPendingPing(pingID string, ping *pendingPing) {
+	s.responseMapLock.Lock()
+	defer s.responseMapLock.Unlock()
+
+	if ping == nil {
+		delete(s.responseMap, pingID)
+	} else {
+		s.responseMap[pingID] = ping
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+)
+
+func TestServiceNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+		require.Equal(t, TrustPing, svc.Name())
+		require.Equal(t, []string{"https://didcomm.org/trust_ping/1.0"}, svc.Features())
+		require.True(t, svc.Accept(PingMsgType))
+		require.True(t, svc.Accept(PingResponseMsgType))
+		require.False(t, svc.Accept("unknown"))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("store error"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Contains(t, err.Error(), "store error")
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var alice, bob *Service
+
+		alice = newService(t, func(msg interface{}, myDID, theirDID string) error {
+			_, err := bob.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+			return err
+		})
+
+		bob = newService(t, func(msg interface{}, myDID, theirDID string) error {
+			_, err := alice.HandleInbound(service.NewDIDCommMsgMap(msg), theirDID, myDID)
+
+			return err
+		})
+
+		rtt, err := alice.Ping("conn", WithComment("hi"))
+		require.NoError(t, err)
+		require.True(t, rtt > 0)
+	})
+
+	t.Run("response not requested", func(t *testing.T) {
+		sent := make(chan interface{}, 1)
+
+		svc := newService(t, func(msg interface{}, _, _ string) error {
+			sent <- msg
+
+			return nil
+		})
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(`{
+			"@id": "123",
+			"@type": "https://didcomm.org/trust_ping/1.0/ping",
+			"response_requested": false
+		}`))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.handlePing(msg, MYDID, THEIRDID))
+		require.Empty(t, sent)
+
+		msg, err = service.ParseDIDCommMsgMap([]byte(`{
+			"@id": "123",
+			"@type": "https://didcomm.org/trust_ping/1.0/ping"
+		}`))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.handlePing(msg, MYDID, THEIRDID))
+
+		response, ok := (<-sent).(*PingResponse)
+		require.True(t, ok)
+		require.Equal(t, PingResponseMsgType, response.Type)
+		require.Equal(t, "123", response.Thread.ID)
+	})
+
+	t.Run("response from another connection or thread", func(t *testing.T) {
+		pinged := make(chan string, 1)
+
+		svc := newService(t, func(msg interface{}, _, _ string) error {
+			pinged <- msg.(*Ping).ID
+
+			return nil
+		})
+
+		result := make(chan error, 1)
+
+		go func() {
+			_, err := svc.Ping("conn", WithTimeout(time.Second))
+			result <- err
+		}()
+
+		pingID := <-pinged
+
+		// responses to another ping, or to this ping from another connection, don't complete the wait
+		require.NoError(t, svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{
+			Type: PingResponseMsgType, Thread: &decorator.Thread{ID: "other"},
+		}), MYDID, THEIRDID))
+
+		err := svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{
+			Type: PingResponseMsgType, Thread: &decorator.Thread{ID: pingID},
+		}), MYDID, "other-their-did")
+		require.EqualError(t, err, "ping response to "+pingID+" received from another connection")
+		require.Empty(t, result)
+
+		require.NoError(t, svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{
+			Type: PingResponseMsgType, Thread: &decorator.Thread{ID: pingID},
+		}), MYDID, THEIRDID))
+		require.NoError(t, <-result)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		_, err := svc.Ping("conn", WithTimeout(time.Millisecond))
+		require.EqualError(t, err, "timeout waiting for ping response")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		_, err := svc.Ping("unknown")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		svc := newService(t, func(interface{}, string, string) error {
+			return errors.New("send error")
+		})
+
+		_, err := svc.Ping("conn")
+		require.EqualError(t, err, "send ping: send error")
+	})
+}
+
+func TestHandleInbound(t *testing.T) {
+	t.Run("decode errors", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		msg := service.DIDCommMsgMap{"@id": map[int]int{}}
+
+		err := svc.handlePing(msg, MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "ping message unmarshal")
+
+		err = svc.handlePingResponse(msg, MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "ping response message unmarshal")
+	})
+
+	t.Run("response without thread", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		err := svc.handlePingResponse(service.NewDIDCommMsgMap(&PingResponse{Type: PingResponseMsgType}), MYDID, THEIRDID)
+		require.EqualError(t, err, "ping response without thread")
+	})
+
+	t.Run("handle outbound", func(t *testing.T) {
+		svc := newService(t, nil)
+
+		id, err := svc.HandleOutbound(service.NewDIDCommMsgMap(&Ping{ID: "123", Type: PingMsgType}), MYDID, THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, "123", id)
+
+		svc = newService(t, func(interface{}, string, string) error {
+			return errors.New("send error")
+		})
+
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Ping{ID: "123", Type: PingMsgType}), MYDID, THEIRDID)
+		require.Contains(t, err.Error(), "send error")
+	})
+}
+
+func newProvider(sendToDID func(msg interface{}, myDID, theirDID string) error) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           &mockdispatcher.MockOutbound{ValidateSendToDID: sendToDID},
+	}
+}
+
+func newService(t *testing.T, sendToDID func(msg interface{}, myDID, theirDID string) error) *Service {
+	t.Helper()
+
+	provider := newProvider(sendToDID)
+
+	r, err := connection.NewRecorder(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+	}))
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	return svc
+}
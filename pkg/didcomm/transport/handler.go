@@ -0,0 +1,25 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transport
+
+import (
+	"context"
+)
+
+// ContextInboundHandler returns the ContextInboundMessageHandler of prov when it implements ContextProvider,
+// otherwise its InboundMessageHandler ignoring the context.
+func ContextInboundHandler(prov Provider) ContextInboundMessageHandler {
+	if cp, ok := prov.(ContextProvider); ok && cp.ContextInboundMessageHandler() != nil {
+		return cp.ContextInboundMessageHandler()
+	}
+
+	handler := prov.InboundMessageHandler()
+
+	return func(_ context.Context, message []byte, myDID, theirDID string) error {
+		return handler(message, myDID, theirDID)
+	}
+}
@@ -12,10 +12,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/rs/cors"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
@@ -51,6 +54,9 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
+	ctx, span := tracing.Start(tracing.Extract(r.Context(), headerCarrier(r.Header)), "didcomm.transport.http.inbound")
+	defer span.End()
+
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		logger.Errorf("Error reading request body: %s - returning Code: %d", err, http.StatusInternalServerError)
@@ -61,16 +67,19 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 
 	unpackMsg, err := prov.Packager().UnpackMessage(body)
 	if err != nil {
+		span.RecordError(err)
+		metrics.TransportErrors.Inc(httpScheme, metrics.DirectionInbound)
 		logger.Errorf("failed to unpack msg: %s - returning Code: %d", err, http.StatusInternalServerError)
 		http.Error(w, "failed to unpack msg", http.StatusInternalServerError)
 
 		return
 	}
 
-	messageHandler := prov.InboundMessageHandler()
+	messageHandler := transport.ContextInboundHandler(prov)
 
-	err = messageHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
+	err = messageHandler(ctx, unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
 	if err != nil {
+		span.RecordError(err)
 		// TODO https://github.com/hyperledger/aries-framework-go/issues/271 HTTP Response Codes based on errors
 		//  from service
 		logger.Errorf("incoming msg processing failed: %s", err)
@@ -80,6 +89,17 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 	}
 }
 
+// headerCarrier returns the trace context carrier built from HTTP headers.
+func headerCarrier(h http.Header) map[string]string {
+	carrier := make(map[string]string, len(h))
+
+	for k := range h {
+		carrier[strings.ToLower(k)] = h.Get(k)
+	}
+
+	return carrier
+}
+
 // validatePayload validate and get the payload from the request.
 func validatePayload(r *http.Request, w http.ResponseWriter) bool {
 	if r.ContentLength == 0 { // empty payload should not be accepted
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
@@ -94,7 +95,9 @@ func (cs *OutboundHTTPClient) Start(prov transport.Provider) error {
 func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination) (string, error) {
 	resp, err := cs.client.Post(destination.ServiceEndpoint, commContentType, bytes.NewBuffer(data))
 	if err != nil {
+		metrics.TransportErrors.Inc(httpScheme, metrics.DirectionOutbound)
 		logger.Errorf("posting DID envelope to agent failed [%s, %v]", destination.ServiceEndpoint, err)
+
 		return "", err
 	}
 
@@ -113,6 +116,8 @@ func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination
 
 		_, e := buf.ReadFrom(resp.Body)
 		if e != nil {
+			metrics.TransportErrors.Inc(httpScheme, metrics.DirectionOutbound)
+
 			return "", e
 		}
 
@@ -120,6 +125,7 @@ func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination
 
 		isStatusSuccess := resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusOK
 		if !isStatusSuccess {
+			metrics.TransportErrors.Inc(httpScheme, metrics.DirectionOutbound)
 			logger.Errorf("didcomm failed : transport=http serviceEndpoint=%s status=%v errMsg=%s",
 				destination.ServiceEndpoint, resp.Status, respData)
 
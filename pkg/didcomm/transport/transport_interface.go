@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package transport
 
 import (
+	"context"
+
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 )
@@ -32,6 +34,16 @@ type OutboundTransport interface {
 // message handle invocation.
 type InboundMessageHandler func(message []byte, myDID, theirDID string) error
 
+// ContextInboundMessageHandler handles the inbound requests like InboundMessageHandler, along with the context
+// of the request carrying e.g. the trace context extracted from the transport.
+type ContextInboundMessageHandler func(ctx context.Context, message []byte, myDID, theirDID string) error
+
+// ContextProvider is implemented by providers supplying a ContextInboundMessageHandler. Inbound transports
+// prefer it over InboundMessageHandler when available.
+type ContextProvider interface {
+	ContextInboundMessageHandler() ContextInboundMessageHandler
+}
+
 // Provider contains dependencies for starting the inbound/outbound transports.
 // It is typically created by using aries.Context().
 type Provider interface {
@@ -13,6 +13,7 @@ import (
 
 	"nhooyr.io/websocket"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -45,11 +46,14 @@ func (cs *OutboundClient) Send(data []byte, destination *service.Destination) (s
 	defer cleanup()
 
 	if err != nil {
+		metrics.TransportErrors.Inc(webSocketScheme, metrics.DirectionOutbound)
+
 		return "", fmt.Errorf("get websocket connection : %w", err)
 	}
 
 	err = conn.Write(context.Background(), websocket.MessageText, data)
 	if err != nil {
+		metrics.TransportErrors.Inc(webSocketScheme, metrics.DirectionOutbound)
 		logger.Errorf("didcomm failed : transport=ws serviceEndpoint=%s errMsg=%s",
 			destination.ServiceEndpoint, err.Error())
 
@@ -13,6 +13,8 @@ import (
 
 	"nhooyr.io/websocket"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	commtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -28,7 +30,7 @@ type connPool struct {
 	connMap map[string]*websocket.Conn
 	sync.RWMutex
 	packager   commtransport.Packager
-	msgHandler transport.InboundMessageHandler
+	msgHandler transport.ContextInboundMessageHandler
 }
 
 // nolint: gochecknoglobals
@@ -41,7 +43,7 @@ func getConnPool(prov transport.Provider) *connPool {
 		pool[id] = &connPool{
 			connMap:    make(map[string]*websocket.Conn),
 			packager:   prov.Packager(),
-			msgHandler: prov.InboundMessageHandler(),
+			msgHandler: transport.ContextInboundHandler(prov),
 		}
 	}
 
@@ -86,32 +88,42 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 			break
 		}
 
-		unpackMsg, err := d.packager.UnpackMessage(message)
-		if err != nil {
-			logger.Errorf("failed to unpack msg: %v", err)
+		d.handleMessage(conn, message)
+	}
+}
 
-			continue
-		}
+func (d *connPool) handleMessage(conn *websocket.Conn, message []byte) {
+	ctx, span := tracing.Start(context.Background(), "didcomm.transport.ws.inbound")
+	defer span.End()
 
-		trans := &decorator.Transport{}
+	unpackMsg, err := d.packager.UnpackMessage(message)
+	if err != nil {
+		span.RecordError(err)
+		metrics.TransportErrors.Inc(webSocketScheme, metrics.DirectionInbound)
+		logger.Errorf("failed to unpack msg: %v", err)
 
-		err = json.Unmarshal(unpackMsg.Message, trans)
-		if err != nil {
-			logger.Errorf("unmarshal transport decorator : %v", err)
-		}
+		return
+	}
 
-		didKey, _ := fingerprint.CreateDIDKey(unpackMsg.FromKey)
+	trans := &decorator.Transport{}
 
-		if trans != nil && trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
-			d.add(didKey, conn)
-		}
+	err = json.Unmarshal(unpackMsg.Message, trans)
+	if err != nil {
+		logger.Errorf("unmarshal transport decorator : %v", err)
+	}
 
-		messageHandler := d.msgHandler
+	didKey, _ := fingerprint.CreateDIDKey(unpackMsg.FromKey)
 
-		err = messageHandler(unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
-		if err != nil {
-			logger.Errorf("incoming msg processing failed: %v", err)
-		}
+	if trans != nil && trans.ReturnRoute != nil && trans.ReturnRoute.Value == decorator.TransportReturnRouteAll {
+		d.add(didKey, conn)
+	}
+
+	messageHandler := d.msgHandler
+
+	err = messageHandler(ctx, unpackMsg.Message, unpackMsg.ToDID, unpackMsg.FromDID)
+	if err != nil {
+		span.RecordError(err)
+		logger.Errorf("incoming msg processing failed: %v", err)
 	}
 }
 
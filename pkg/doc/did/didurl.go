@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DID URL query parameters defined by DID Core (https://www.w3.org/TR/did-core/#did-parameters).
+const (
+	ServiceParam     = "service"
+	RelativeRefParam = "relativeRef"
+	VersionIDParam   = "versionId"
+	VersionTimeParam = "versionTime"
+)
+
+// DIDURL is a DID URL parsed according to https://www.w3.org/TR/did-core/#did-url-syntax.
+type DIDURL struct {
+	DID
+	Path     string
+	Queries  url.Values
+	Fragment string
+}
+
+// ParseDIDURL parses a DID URL: a DID followed by an optional path, query and fragment.
+func ParseDIDURL(didURL string) (*DIDURL, error) {
+	rest, fragment := cut(didURL, "#")
+	rest, query := cut(rest, "?")
+
+	did, path := rest, ""
+
+	if i := strings.Index(rest, "/"); i >= 0 {
+		did, path = rest[:i], rest[i:]
+	}
+
+	parsedDID, err := Parse(did)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DID URL %s: %w", didURL, err)
+	}
+
+	queries, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DID URL %s query: %w", didURL, err)
+	}
+
+	fragment, err = url.PathUnescape(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DID URL %s fragment: %w", didURL, err)
+	}
+
+	return &DIDURL{
+		DID:      *parsedDID,
+		Path:     path,
+		Queries:  queries,
+		Fragment: fragment,
+	}, nil
+}
+
+// IsFragmentOf returns true if id, the ID of a verification method or service of the document of did, has the given
+// fragment. id can be relative ("#fragment") or absolute ("did#fragment").
+func IsFragmentOf(id, did, fragment string) bool {
+	if id == "#"+fragment {
+		return true
+	}
+
+	didURL, err := ParseDIDURL(id)
+	if err != nil {
+		return false
+	}
+
+	return didURL.Fragment == fragment && didURL.DID.String() == did && didURL.Path == "" &&
+		len(didURL.Queries) == 0
+}
+
+// cut returns the parts of s before and after the first occurrence of sep, or s and "" if sep is not found.
+func cut(s, sep string) (string, string) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):]
+	}
+
+	return s, ""
+}
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+func TestParseDIDURL(t *testing.T) {
+	t.Run("DID", func(t *testing.T) {
+		didURL, err := ParseDIDURL("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", didURL.DID.String())
+		require.Empty(t, didURL.Path)
+		require.Empty(t, didURL.Queries)
+		require.Empty(t, didURL.Fragment)
+	})
+
+	t.Run("path, query and fragment", func(t *testing.T) {
+		didURL, err := ParseDIDURL("did:example:123:456/some/path?service=agent&relativeRef=%2Finbox#key%201")
+		require.NoError(t, err)
+		require.Equal(t, "example", didURL.Method)
+		require.Equal(t, "123:456", didURL.MethodSpecificID)
+		require.Equal(t, "/some/path", didURL.Path)
+		require.Equal(t, "agent", didURL.Queries.Get(ServiceParam))
+		require.Equal(t, "/inbox", didURL.Queries.Get(RelativeRefParam))
+		require.Equal(t, "key 1", didURL.Fragment)
+	})
+
+	t.Run("fragment with query characters", func(t *testing.T) {
+		didURL, err := ParseDIDURL("did:example:123#key?1")
+		require.NoError(t, err)
+		require.Empty(t, didURL.Queries)
+		require.Equal(t, "key?1", didURL.Fragment)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, didURL := range []string{"", "#key-1", "example:123#key-1", "did:example:123?a=%", "did:example:123#%"} {
+			_, err := ParseDIDURL(didURL)
+			require.Error(t, err, didURL)
+		}
+	})
+}
+
+func TestIsFragmentOf(t *testing.T) {
+	require.True(t, IsFragmentOf("#key-1", "did:example:123", "key-1"))
+	require.True(t, IsFragmentOf("did:example:123#key-1", "did:example:123", "key-1"))
+	require.False(t, IsFragmentOf("did:example:456#key-1", "did:example:123", "key-1"))
+	require.False(t, IsFragmentOf("did:example:123#key-2", "did:example:123", "key-1"))
+	require.False(t, IsFragmentOf("did:example:123?service=agent#key-1", "did:example:123", "key-1"))
+	require.False(t, IsFragmentOf("key-1", "did:example:123", "key-1"))
+}
@@ -0,0 +1,281 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package didconfig implements DID Configurations (https://identity.foundation/.well-known/resources/did-configuration/),
+// which link DIDs to the domains hosting them with domain linkage credentials.
+package didconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// ContextV1 is the JSON-LD context of DID configurations and domain linkage credentials.
+	ContextV1 = "https://identity.foundation/.well-known/did-configuration/v1"
+	// DomainLinkageCredentialType is the type of domain linkage credentials.
+	DomainLinkageCredentialType = "DomainLinkageCredential"
+	// WellKnownPath is the path of the DID configuration of a domain.
+	WellKnownPath = "/.well-known/did-configuration.json"
+
+	originField = "origin"
+)
+
+// contextV1 from https://identity.foundation/.well-known/did-configuration/v1
+const contextV1 = `
+{
+  "@context": [
+    {
+      "@version": 1.1,
+      "@protected": true,
+      "LinkedDomains": "https://identity.foundation/.well-known/resources/did-configuration/#LinkedDomains",
+      "DomainLinkageCredential": "https://identity.foundation/.well-known/resources/did-configuration/#DomainLinkageCredential",
+      "origin": "https://identity.foundation/.well-known/resources/did-configuration/#origin",
+      "linked_dids": "https://identity.foundation/.well-known/resources/did-configuration/#linked_dids"
+    }
+  ]
+}`
+
+// ErrDomainNotLinked is returned when a DID configuration has no valid domain linkage credential of a DID.
+var ErrDomainNotLinked = errors.New("DID is not linked to the domain")
+
+// Configuration is a DID configuration, to be published at the WellKnownPath of a domain.
+type Configuration struct {
+	Context string `json:"@context"`
+	// LinkedDIDs are domain linkage credentials, either in JSON-LD with a linked data proof or as JWTs.
+	LinkedDIDs []json.RawMessage `json:"linked_dids"`
+}
+
+// NewConfiguration returns an empty DID configuration.
+func NewConfiguration() *Configuration {
+	return &Configuration{Context: ContextV1, LinkedDIDs: []json.RawMessage{}}
+}
+
+// AddCredential adds a domain linkage credential signed with a linked data proof.
+func (c *Configuration) AddCredential(vc *verifiable.Credential) error {
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshal domain linkage credential: %w", err)
+	}
+
+	c.LinkedDIDs = append(c.LinkedDIDs, vcBytes)
+
+	return nil
+}
+
+// AddJWT adds a domain linkage credential in JWT format (see verifiable.Credential.JWTClaims).
+func (c *Configuration) AddJWT(jwt string) error {
+	jwtBytes, err := json.Marshal(jwt)
+	if err != nil {
+		return fmt.Errorf("marshal domain linkage credential: %w", err)
+	}
+
+	c.LinkedDIDs = append(c.LinkedDIDs, jwtBytes)
+
+	return nil
+}
+
+// NewDomainLinkageCredential returns a domain linkage credential of a DID and an origin (e.g. https://example.com),
+// valid until expires, to be signed by the DID.
+func NewDomainLinkageCredential(did, origin string, expires time.Time) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{verifiable.ContextURI, ContextV1},
+		Types:   []string{verifiable.VCType, DomainLinkageCredentialType},
+		Issuer:  verifiable.Issuer{ID: did},
+		Issued:  util.NewTime(time.Now().UTC().Truncate(time.Second)),
+		Expired: util.NewTime(expires.UTC().Truncate(time.Second)),
+		Subject: verifiable.Subject{
+			ID:           did,
+			CustomFields: verifiable.CustomFields{originField: origin},
+		},
+	}
+}
+
+// VerifyDIDAndDomain verifies that a DID configuration, published at the WellKnownPath of an origin, links a DID to
+// the origin: it must have a valid domain linkage credential of the DID for the origin, whose proof is verified with
+// the credential options (e.g. verifiable.WithPublicKeyFetcher). ErrDomainNotLinked is returned otherwise.
+func VerifyDIDAndDomain(configuration []byte, did, origin string, opts ...verifiable.CredentialOpt) error {
+	config := &Configuration{}
+
+	if err := json.Unmarshal(configuration, config); err != nil {
+		return fmt.Errorf("unmarshal DID configuration: %w", err)
+	}
+
+	if config.Context != ContextV1 {
+		return fmt.Errorf("unsupported DID configuration context %s", config.Context)
+	}
+
+	opts = append([]verifiable.CredentialOpt{verifiable.WithJSONLDDocumentLoader(CachingJSONLDLoader())}, opts...)
+
+	var errs []string
+
+	for _, linkedDID := range config.LinkedDIDs {
+		err := verifyLinkedDID(linkedDID, did, origin, opts)
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, errOtherDID) {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %s", ErrDomainNotLinked, strings.Join(errs, "; "))
+	}
+
+	return ErrDomainNotLinked
+}
+
+// CachingJSONLDLoader creates a JSON-LD CachingDocumentLoader with the preloaded ContextV1 context.
+func CachingJSONLDLoader() *ld.CachingDocumentLoader {
+	loader := verifiable.CachingJSONLDLoader()
+
+	reader, err := ld.DocumentFromReader(strings.NewReader(contextV1))
+	if err != nil {
+		panic(err)
+	}
+
+	loader.AddDocument(ContextV1, reader)
+
+	return loader
+}
+
+var errOtherDID = errors.New("domain linkage credential of another DID")
+
+func verifyLinkedDID(linkedDID json.RawMessage, did, origin string, opts []verifiable.CredentialOpt) error {
+	vcBytes := []byte(linkedDID)
+
+	var jwt string
+	if err := json.Unmarshal(linkedDID, &jwt); err == nil {
+		vcBytes = []byte(jwt)
+	}
+
+	vc, err := verifiable.ParseCredential(vcBytes, opts...)
+	if err != nil {
+		return fmt.Errorf("invalid domain linkage credential: %w", err)
+	}
+
+	if vc.Issuer.ID != did {
+		return errOtherDID
+	}
+
+	if err = verifySigner(vc, vcBytes, did); err != nil {
+		return err
+	}
+
+	return verifyDomainLinkageCredential(vc, did, origin)
+}
+
+// verifySigner checks that the credential is signed, and only with keys of the DID: the issuer of a credential is
+// self-asserted, the proofs are only verified with the keys they reference.
+func verifySigner(vc *verifiable.Credential, vcBytes []byte, did string) error {
+	if jwt.IsJWS(string(vcBytes)) {
+		token, err := jwt.Parse(string(vcBytes), jwt.WithSignatureVerifier(&noVerifier{}))
+		if err != nil {
+			return fmt.Errorf("invalid domain linkage credential: %w", err)
+		}
+
+		if kid := token.LookupStringHeader(jose.HeaderKeyID); !isKeyOf(kid, did) {
+			return fmt.Errorf("domain linkage credential of %s is signed with key %s of another DID", did, kid)
+		}
+
+		return nil
+	}
+
+	if len(vc.Proofs) == 0 {
+		return fmt.Errorf("domain linkage credential of %s is not signed", did)
+	}
+
+	for _, proof := range vc.Proofs {
+		verificationMethod, _ := proof["verificationMethod"].(string) //nolint:errcheck
+		if !isKeyOf(verificationMethod, did) {
+			return fmt.Errorf("domain linkage credential of %s is signed with key %s of another DID", did,
+				verificationMethod)
+		}
+	}
+
+	return nil
+}
+
+// isKeyOf tells whether keyID is a DID URL of the DID.
+func isKeyOf(keyID, did string) bool {
+	didURL, err := diddoc.ParseDIDURL(keyID)
+
+	return err == nil && didURL.DID.String() == did
+}
+
+// noVerifier skips the verification of JWS signatures already verified by verifiable.ParseCredential.
+type noVerifier struct{}
+
+func (v *noVerifier) Verify(jose.Headers, []byte, []byte, []byte) error {
+	return nil
+}
+
+func verifyDomainLinkageCredential(vc *verifiable.Credential, did, origin string) error {
+	if !contains(vc.Types, DomainLinkageCredentialType) {
+		return fmt.Errorf("credential of %s is not a %s", did, DomainLinkageCredentialType)
+	}
+
+	subjects, ok := vc.Subject.([]verifiable.Subject)
+	if !ok || len(subjects) != 1 || subjects[0].ID != did {
+		return fmt.Errorf("domain linkage credential subject of %s is not the DID", did)
+	}
+
+	subjectOrigin, ok := subjects[0].CustomFields[originField].(string)
+	if !ok || !sameOrigin(subjectOrigin, origin) {
+		return fmt.Errorf("domain linkage credential of %s is for origin %v", did, subjects[0].CustomFields[originField])
+	}
+
+	now := time.Now()
+
+	if vc.Issued == nil || vc.Issued.After(now) {
+		return fmt.Errorf("domain linkage credential of %s is not issued yet", did)
+	}
+
+	if vc.Expired == nil || !vc.Expired.After(now) {
+		return fmt.Errorf("domain linkage credential of %s has expired", did)
+	}
+
+	return nil
+}
+
+func sameOrigin(origin1, origin2 string) bool {
+	u1, err := url.Parse(origin1)
+	if err != nil {
+		return false
+	}
+
+	u2, err := url.Parse(origin2)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u1.Scheme, u2.Scheme) && strings.EqualFold(u1.Host, u2.Host) &&
+		strings.Trim(u1.Path, "/") == "" && strings.Trim(u2.Path, "/") == ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,204 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didconfig_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/didconfig"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	did    = "did:web:example.com"
+	keyID  = did + "#key-1"
+	origin = "https://example.com"
+)
+
+func TestVerifyDIDAndDomain(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := signature.GetEd25519Signer(privKey, pubKey)
+	fetcher := verifiable.WithPublicKeyFetcher(verifiable.SingleKey(pubKey, kms.ED25519))
+
+	t.Run("linked data proof", func(t *testing.T) {
+		config := didconfig.NewConfiguration()
+		require.NoError(t, config.AddCredential(signLDP(t, signer,
+			didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(time.Hour)))))
+
+		configBytes, err := json.Marshal(config)
+		require.NoError(t, err)
+
+		require.NoError(t, didconfig.VerifyDIDAndDomain(configBytes, did, origin, fetcher))
+		require.NoError(t, didconfig.VerifyDIDAndDomain(configBytes, did, "https://EXAMPLE.com/", fetcher))
+
+		err = didconfig.VerifyDIDAndDomain(configBytes, did, "https://other.example.com", fetcher)
+		require.ErrorIs(t, err, didconfig.ErrDomainNotLinked)
+		require.Contains(t, err.Error(), "is for origin https://example.com")
+
+		err = didconfig.VerifyDIDAndDomain(configBytes, "did:web:other.example.com", origin, fetcher)
+		require.ErrorIs(t, err, didconfig.ErrDomainNotLinked)
+	})
+
+	t.Run("JWT", func(t *testing.T) {
+		claims, err := didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(time.Hour)).JWTClaims(false)
+		require.NoError(t, err)
+
+		jwt, err := claims.MarshalJWS(verifiable.EdDSA, signer, keyID)
+		require.NoError(t, err)
+
+		config := didconfig.NewConfiguration()
+		require.NoError(t, config.AddJWT(jwt))
+
+		configBytes, err := json.Marshal(config)
+		require.NoError(t, err)
+
+		require.NoError(t, didconfig.VerifyDIDAndDomain(configBytes, did, origin, fetcher))
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		expired := didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(-time.Hour))
+
+		wrongType := didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(time.Hour))
+		wrongType.Types = []string{verifiable.VCType}
+
+		otherSubject := didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(time.Hour))
+		otherSubject.Subject = verifiable.Subject{
+			ID:           "did:web:other.example.com",
+			CustomFields: verifiable.CustomFields{"origin": origin},
+		}
+
+		for expected, vc := range map[string]*verifiable.Credential{
+			"has expired":                                   expired,
+			"is not a DomainLinkageCredential":              wrongType,
+			"subject of did:web:example.com is not the DID": otherSubject,
+		} {
+			config := didconfig.NewConfiguration()
+			require.NoError(t, config.AddCredential(signLDP(t, signer, vc)))
+
+			configBytes, err := json.Marshal(config)
+			require.NoError(t, err)
+
+			err = didconfig.VerifyDIDAndDomain(configBytes, did, origin, fetcher)
+			require.ErrorIs(t, err, didconfig.ErrDomainNotLinked)
+			require.Contains(t, err.Error(), expected)
+		}
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		otherPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		config := didconfig.NewConfiguration()
+		require.NoError(t, config.AddCredential(signLDP(t, signer,
+			didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(time.Hour)))))
+
+		configBytes, err := json.Marshal(config)
+		require.NoError(t, err)
+
+		err = didconfig.VerifyDIDAndDomain(configBytes, did, origin,
+			verifiable.WithPublicKeyFetcher(verifiable.SingleKey(otherPubKey, kms.ED25519)))
+		require.ErrorIs(t, err, didconfig.ErrDomainNotLinked)
+		require.Contains(t, err.Error(), "invalid domain linkage credential")
+	})
+
+	t.Run("credentials not signed by the DID", func(t *testing.T) {
+		otherKeyID := "did:web:attacker.example.com#key-1"
+
+		claims, err := didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(time.Hour)).JWTClaims(false)
+		require.NoError(t, err)
+
+		otherJWT, err := claims.MarshalJWS(verifiable.EdDSA, signer, otherKeyID)
+		require.NoError(t, err)
+
+		unsignedJWT, err := claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		for name, test := range map[string]struct {
+			add      func(config *didconfig.Configuration) error
+			expected string
+		}{
+			"linked data proof": {
+				add: func(config *didconfig.Configuration) error {
+					return config.AddCredential(signLDPWithKey(t, signer, otherKeyID,
+						didconfig.NewDomainLinkageCredential(did, origin, time.Now().Add(time.Hour))))
+				},
+				expected: "is signed with key " + otherKeyID + " of another DID",
+			},
+			"JWT": {
+				add:      func(config *didconfig.Configuration) error { return config.AddJWT(otherJWT) },
+				expected: "is signed with key " + otherKeyID + " of another DID",
+			},
+			"no proof": {
+				add: func(config *didconfig.Configuration) error {
+					return config.AddCredential(didconfig.NewDomainLinkageCredential(did, origin,
+						time.Now().Add(time.Hour)))
+				},
+				expected: "is not signed",
+			},
+			"unsecured JWT": {
+				add:      func(config *didconfig.Configuration) error { return config.AddJWT(unsignedJWT) },
+				expected: "is not signed",
+			},
+		} {
+			config := didconfig.NewConfiguration()
+			require.NoError(t, test.add(config), name)
+
+			configBytes, err := json.Marshal(config)
+			require.NoError(t, err)
+
+			err = didconfig.VerifyDIDAndDomain(configBytes, did, origin, fetcher)
+			require.ErrorIs(t, err, didconfig.ErrDomainNotLinked, name)
+			require.Contains(t, err.Error(), test.expected, name)
+		}
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		require.Error(t, didconfig.VerifyDIDAndDomain([]byte("{"), did, origin))
+
+		err := didconfig.VerifyDIDAndDomain([]byte(`{"@context":"https://example.com","linked_dids":[]}`), did,
+			origin)
+		require.EqualError(t, err, "unsupported DID configuration context https://example.com")
+
+		err = didconfig.VerifyDIDAndDomain([]byte(`{"@context":"`+didconfig.ContextV1+`","linked_dids":[]}`), did,
+			origin)
+		require.Equal(t, didconfig.ErrDomainNotLinked, err)
+	})
+}
+
+func signLDP(t *testing.T, signer signature.Signer, vc *verifiable.Credential) *verifiable.Credential {
+	t.Helper()
+
+	return signLDPWithKey(t, signer, keyID, vc)
+}
+
+func signLDPWithKey(t *testing.T, signer signature.Signer, verificationMethod string,
+	vc *verifiable.Credential) *verifiable.Credential {
+	t.Helper()
+
+	err := vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: verifiable.SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      verificationMethod,
+	}, jsonld.WithDocumentLoader(didconfig.CachingJSONLDLoader()))
+	require.NoError(t, err)
+
+	return vc
+}
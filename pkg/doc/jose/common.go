@@ -87,6 +87,12 @@ const (
 	A256GCMALG = "A256GCM"
 	// XC20PALG represented XChacha20Poly1305 content encryption algorithm value.
 	XC20PALG = "XC20P"
+	// A128CBCHS256ALG represents AES_128_CBC_HMAC_SHA_256 content encryption algorithm value as per
+	// the JWA specification: https://tools.ietf.org/html/rfc7518#section-5.2.3
+	A128CBCHS256ALG = "A128CBC-HS256"
+	// A256CBCHS512ALG represents AES_256_CBC_HMAC_SHA_512 content encryption algorithm value as per
+	// the JWA specification: https://tools.ietf.org/html/rfc7518#section-5.2.5
+	A256CBCHS512ALG = "A256CBC-HS512"
 	// DIDCommEncType representing the JWE 'Typ' protected type header.
 	DIDCommEncType = "didcomm-envelope-enc"
 )
@@ -13,6 +13,7 @@ import (
 	"fmt"
 
 	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
@@ -49,13 +50,7 @@ func NewJWEDecrypt(store storage.Store, c cryptoapi.Crypto, k kms.KeyManager) *J
 }
 
 func getECDHDecPrimitive(cek []byte, encAlg string) (api.CompositeDecrypt, error) {
-	kt := ecdh.NISTPECDHAES256GCMKeyTemplateWithCEK(cek)
-
-	if encAlg == XC20PALG {
-		kt = ecdh.X25519ECDHXChachaKeyTemplateWithCEK(cek)
-	}
-
-	kh, err := keyset.NewHandle(kt)
+	kh, err := keyset.NewHandle(encKeyTemplate(encAlg, cek))
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +58,20 @@ func getECDHDecPrimitive(cek []byte, encAlg string) (api.CompositeDecrypt, error
 	return ecdh.NewECDHDecrypt(kh)
 }
 
+// encKeyTemplate returns the content encryption key template of encAlg built with cek.
+func encKeyTemplate(encAlg string, cek []byte) *tinkpb.KeyTemplate {
+	switch encAlg {
+	case XC20PALG:
+		return ecdh.X25519ECDHXChachaKeyTemplateWithCEK(cek)
+	case A128CBCHS256ALG:
+		return ecdh.NISTPECDHAES128CBCHMACSHA256KeyTemplateWithCEK(cek)
+	case A256CBCHS512ALG:
+		return ecdh.NISTPECDHAES256CBCHMACSHA512KeyTemplateWithCEK(cek)
+	default:
+		return ecdh.NISTPECDHAES256GCMKeyTemplateWithCEK(cek)
+	}
+}
+
 // Decrypt a deserialized JWE, decrypts its protected content and returns plaintext.
 func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 	err := jd.validateAndExtractProtectedHeaders(jwe)
@@ -70,7 +79,9 @@ func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 		return nil, fmt.Errorf("jwedecrypt: %w", err)
 	}
 
-	var senderOpt cryptoapi.WrapKeyOpts
+	var senderOpt, tagOpt cryptoapi.WrapKeyOpts
+
+	encAlg, _ := jwe.ProtectedHeaders.Encryption()
 
 	skid, ok := jwe.ProtectedHeaders.SenderKeyID()
 	if ok && skid != "" {
@@ -87,7 +98,12 @@ func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 		return nil, fmt.Errorf("jwedecrypt: failed to build recipients WK: %w", err)
 	}
 
-	cek, err := jd.unwrapCEK(recWK, senderOpt)
+	if _, ok = cbcHMACKeySizes[EncAlg(encAlg)]; ok && senderOpt != nil {
+		// ECDH-1PU key wrapping with AES-CBC-HMAC content encryption is bound to the JWE tag.
+		tagOpt = cryptoapi.WithTag([]byte(jwe.Tag))
+	}
+
+	cek, err := jd.unwrapCEK(recWK, senderOpt, tagOpt)
 	if err != nil {
 		return nil, fmt.Errorf("jwedecrypt: %w", err)
 	}
@@ -106,7 +122,7 @@ func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 }
 
 func (jd *JWEDecrypt) unwrapCEK(recWK []*cryptoapi.RecipientWrappedKey,
-	senderOpt cryptoapi.WrapKeyOpts) ([]byte, error) {
+	senderOpt, tagOpt cryptoapi.WrapKeyOpts) ([]byte, error) {
 	var cek []byte
 
 	for _, rec := range recWK {
@@ -125,6 +141,10 @@ func (jd *JWEDecrypt) unwrapCEK(recWK []*cryptoapi.RecipientWrappedKey,
 			unwrapOpts = append(unwrapOpts, senderOpt)
 		}
 
+		if tagOpt != nil {
+			unwrapOpts = append(unwrapOpts, tagOpt)
+		}
+
 		if len(unwrapOpts) > 0 {
 			cek, err = jd.crypto.UnwrapKey(rec, recKH, unwrapOpts...)
 		} else {
@@ -202,7 +222,7 @@ func (jd *JWEDecrypt) validateAndExtractProtectedHeaders(jwe *JSONWebEncryption)
 	}
 
 	switch encAlg {
-	case string(A256GCM), string(XC20P):
+	case string(A256GCM), string(XC20P), string(A128CBCHS256), string(A256CBCHS512):
 	default:
 		return fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
 	}
@@ -10,22 +10,26 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 
-	hybrid "github.com/google/tink/go/hybrid/subtle"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/subtle/random"
 	"github.com/square/go-jose/v3"
+	"golang.org/x/crypto/curve25519"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	cbcsubtle "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/api"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 )
 
 // EncAlg represents the JWE content encryption algorithm.
@@ -36,8 +40,19 @@ const (
 	A256GCM = EncAlg(A256GCMALG)
 	// XC20P for XChacha20Poly1305 content encryption.
 	XC20P = EncAlg(XC20PALG)
+	// A128CBCHS256 for AES128-CBC-HMAC-SHA256 content encryption.
+	A128CBCHS256 = EncAlg(A128CBCHS256ALG)
+	// A256CBCHS512 for AES256-CBC-HMAC-SHA512 content encryption.
+	A256CBCHS512 = EncAlg(A256CBCHS512ALG)
 )
 
+// cbcHMACKeySizes are the cek sizes of the AES-CBC-HMAC-SHA2 content encryption algorithms (the key is split into
+// MAC and encryption keys of equal size).
+var cbcHMACKeySizes = map[EncAlg]int{ // nolint:gochecknoglobals
+	A128CBCHS256: cbcsubtle.AES128CBCHMACSHA256KeySize,
+	A256CBCHS512: cbcsubtle.AES256CBCHMACSHA512KeySize,
+}
+
 // Encrypter interface to Encrypt/Decrypt JWE messages.
 type Encrypter interface {
 	// EncryptWithAuthData encrypt plaintext and aad sent to more than 1 recipients and returns a valid
@@ -60,6 +75,8 @@ type JWEEncrypt struct {
 
 // NewJWEEncrypt creates a new JWEEncrypt instance to build JWE with recipientsPubKeys
 // senderKID and senderKH are used for Authcrypt (to authenticate the sender), if not set JWEEncrypt assumes Anoncrypt.
+// Authcrypt with A128CBCHS256 or A256CBCHS512 binds the content encryption tag into ECDH-1PU key wrapping and shares
+// one ephemeral key among all recipients, which must then have the same key type and curve.
 func NewJWEEncrypt(encAlg EncAlg, encType, senderKID string, senderKH *keyset.Handle,
 	recipientsPubKeys []*cryptoapi.PublicKey, crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
 	if len(recipientsPubKeys) == 0 {
@@ -67,7 +84,7 @@ func NewJWEEncrypt(encAlg EncAlg, encType, senderKID string, senderKH *keyset.Ha
 	}
 
 	switch encAlg {
-	case A256GCM, XC20P:
+	case A256GCM, XC20P, A128CBCHS256, A256CBCHS512:
 	default:
 		return nil, fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
 	}
@@ -94,13 +111,7 @@ func NewJWEEncrypt(encAlg EncAlg, encType, senderKID string, senderKH *keyset.Ha
 }
 
 func (je *JWEEncrypt) getECDHEncPrimitive(cek []byte) (api.CompositeEncrypt, error) {
-	kt := ecdh.NISTPECDHAES256GCMKeyTemplateWithCEK(cek)
-
-	if je.encAlg == XC20P {
-		kt = ecdh.X25519ECDHXChachaKeyTemplateWithCEK(cek)
-	}
-
-	kh, err := keyset.NewHandle(kt)
+	kh, err := keyset.NewHandle(encKeyTemplate(string(je.encAlg), cek))
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +140,7 @@ func (je *JWEEncrypt) EncryptWithAuthData(plaintext, aad []byte) (*JSONWebEncryp
 		protectedHeaders[HeaderSenderKeyID] = je.skid
 	}
 
-	cek := random.GetRandomBytes(uint32(cryptoapi.DefKeySize))
+	cek := random.GetRandomBytes(uint32(je.cekSize()))
 
 	// creating the crypto primitive requires a pre-built cek
 	encPrimitive, err := je.getECDHEncPrimitive(cek)
@@ -142,6 +153,10 @@ func (je *JWEEncrypt) EncryptWithAuthData(plaintext, aad []byte) (*JSONWebEncryp
 		return nil, fmt.Errorf("jweencrypt: computeAuthData: marshal error %w", err)
 	}
 
+	if je.useTagBinding() {
+		return je.encryptWithTagBinding(encPrimitive, cek, plaintext, aad, authData, protectedHeaders)
+	}
+
 	recipients, singleRecipientHeaderADDs, err := je.wrapCEKForRecipients(cek, []byte{}, []byte{}, authData, json.Marshal)
 	if err != nil {
 		return nil, fmt.Errorf("jweencrypt: failed to wrap cek: %w", err)
@@ -175,6 +190,131 @@ func (je *JWEEncrypt) EncryptWithAuthData(plaintext, aad []byte) (*JSONWebEncryp
 	return getJSONWebEncryption(encData, recipientsHeaders, protectedHeaders, aad), nil
 }
 
+// encryptWithTagBinding encrypts plaintext before wrapping cek for the recipients since ECDH-1PU key wrapping with
+// AES-CBC-HMAC content encryption must include the resulting tag. The ephemeral key is generated first to build the
+// recipient headers that are merged into the protected headers (and therefore authData) for a single recipient.
+func (je *JWEEncrypt) encryptWithTagBinding(encPrimitive api.CompositeEncrypt, cek, plaintext, aad, authData []byte,
+	protectedHeaders map[string]interface{}) (*JSONWebEncryption, error) {
+	epk, err := generateEPK(je.recipientsKeys[0])
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to generate ephemeral key: %w", err)
+	}
+
+	// apu and apv are set the same way wrapCEKForRecipients() sets them.
+	expectedWK := &cryptoapi.RecipientWrappedKey{
+		KID: je.recipientsKeys[0].KID,
+		EPK: epk.PublicKey,
+		Alg: tinkcrypto.ECDH1PUA256KWAlg,
+		APU: []byte(je.skid),
+		APV: []byte(je.recipientsKeys[0].KID),
+	}
+
+	je.encodeAPUAPV(expectedWK)
+
+	if len(je.recipientsKeys) == 1 {
+		authData, err = mergeSingleRecipientHeaders(expectedWK, authData, json.Marshal)
+		if err != nil {
+			return nil, fmt.Errorf("jweencrypt: failed to merge recipient headers: %w", err)
+		}
+	}
+
+	serializedEncData, err := encPrimitive.Encrypt(plaintext, authData)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to Encrypt: %w", err)
+	}
+
+	encData := new(composite.EncryptedData)
+
+	err = json.Unmarshal(serializedEncData, encData)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: unmarshal encrypted data failed: %w", err)
+	}
+
+	recipients, _, err := je.wrapCEKForRecipients(cek, []byte{}, []byte{}, authData, json.Marshal,
+		cryptoapi.WithEPK(epk), cryptoapi.WithTag(encData.Tag))
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to wrap cek: %w", err)
+	}
+
+	for i, rec := range recipients {
+		// a Crypto implementation ignoring WithEPK() would produce headers that don't match the encrypted content.
+		if rec.Alg != expectedWK.Alg || !bytes.Equal(rec.EPK.X, epk.PublicKey.X) {
+			return nil, fmt.Errorf("jweencrypt: wrapped key for recipient %d does not use the expected alg and "+
+				"ephemeral key", i+1)
+		}
+	}
+
+	recipientsHeaders, singleRecipientHeaders, err := je.buildRecs(recipients)
+	if err != nil {
+		return nil, fmt.Errorf("jweencrypt: failed to build recipients: %w", err)
+	}
+
+	if singleRecipientHeaders != nil {
+		mergeRecipientHeaders(protectedHeaders, singleRecipientHeaders)
+	}
+
+	return getJSONWebEncryption(encData, recipientsHeaders, protectedHeaders, aad), nil
+}
+
+func (je *JWEEncrypt) cekSize() int {
+	if size, ok := cbcHMACKeySizes[je.encAlg]; ok {
+		return size
+	}
+
+	return cryptoapi.DefKeySize
+}
+
+// useTagBinding returns true for Authcrypt (ECDH-1PU) with AES-CBC-HMAC content encryption.
+func (je *JWEEncrypt) useTagBinding() bool {
+	_, ok := cbcHMACKeySizes[je.encAlg]
+
+	return ok && je.skid != "" && je.senderKH != nil
+}
+
+// generateEPK creates an ephemeral key with the same key type and curve as recPubKey.
+func generateEPK(recPubKey *cryptoapi.PublicKey) (*cryptoapi.PrivateKey, error) {
+	switch recPubKey.Type {
+	case ecdhpb.KeyType_EC.String():
+		c, err := cryptoutil.GetCurve(recPubKey.Curve)
+		if err != nil {
+			return nil, err
+		}
+
+		privKey, err := ecdsa.GenerateKey(c, rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		return &cryptoapi.PrivateKey{
+			PublicKey: cryptoapi.PublicKey{
+				X:     privKey.X.Bytes(),
+				Y:     privKey.Y.Bytes(),
+				Curve: cryptoutil.ECCurveName(c),
+				Type:  recPubKey.Type,
+			},
+			D: privKey.D.Bytes(),
+		}, nil
+	case ecdhpb.KeyType_OKP.String():
+		privKey := random.GetRandomBytes(uint32(curve25519.ScalarSize))
+
+		pubKey, err := curve25519.X25519(privKey, curve25519.Basepoint)
+		if err != nil {
+			return nil, err
+		}
+
+		return &cryptoapi.PrivateKey{
+			PublicKey: cryptoapi.PublicKey{
+				X:     pubKey,
+				Curve: "X25519",
+				Type:  recPubKey.Type,
+			},
+			D: privKey,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid recipient key type '%s'", recPubKey.Type)
+	}
+}
+
 func getJSONWebEncryption(encData *composite.EncryptedData, recipientsHeaders []*Recipient,
 	protectedHeaders map[string]interface{}, aad []byte) *JSONWebEncryption {
 	return &JSONWebEncryption{
@@ -187,8 +327,8 @@ func getJSONWebEncryption(encData *composite.EncryptedData, recipientsHeaders []
 	}
 }
 
-func (je *JWEEncrypt) wrapCEKForRecipients(cek, apu, apv, aad []byte,
-	marshaller marshalFunc) ([]*cryptoapi.RecipientWrappedKey, []byte, error) {
+func (je *JWEEncrypt) wrapCEKForRecipients(cek, apu, apv, aad []byte, marshaller marshalFunc,
+	extraOpts ...cryptoapi.WrapKeyOpts) ([]*cryptoapi.RecipientWrappedKey, []byte, error) {
 	if len(je.recipientsKeys) == 0 {
 		return nil, nil, fmt.Errorf("JWEEncrypt - wrapCEKForRecipients: missing recipients public keys for " +
 			"key wrapping")
@@ -205,7 +345,7 @@ func (je *JWEEncrypt) wrapCEKForRecipients(cek, apu, apv, aad []byte,
 			err error
 		)
 
-		wrapOpts := je.getWrapKeyOpts()
+		wrapOpts := append(je.getWrapKeyOpts(), extraOpts...)
 
 		if len(apv) == 0 {
 			apv = append(apv, recPubKey.KID...)
@@ -473,7 +613,7 @@ func convertRecEPKToMarshalledJWK(rec *cryptoapi.RecipientWrappedKey) ([]byte, e
 
 	switch rec.EPK.Type {
 	case ecdhpb.KeyType_EC.String():
-		c, err = hybrid.GetCurve(rec.EPK.Curve)
+		c, err = cryptoutil.GetCurve(rec.EPK.Curve)
 		if err != nil {
 			return nil, err
 		}
@@ -18,7 +18,6 @@ import (
 	"testing"
 	"time"
 
-	hybrid "github.com/google/tink/go/hybrid/subtle"
 	"github.com/google/tink/go/keyset"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/subtle"
@@ -32,6 +31,7 @@ import (
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
 	ariesjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
@@ -116,6 +116,35 @@ func TestJWEEncryptRoundTrip(t *testing.T) {
 			nbRec:      1,
 			useCompact: true,
 		},
+		{
+			name:    "secp256k1 ECDH KW and AES256GCM encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256GCM,
+			keyType: kms.Secp256k1ECDHKWType,
+			nbRec:   2,
+		},
+		{
+			name:    "secp256k1 ECDH KW and AES256GCM encryption with 1 recipient (Flattened serialization)",
+			kt:      ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256GCM,
+			keyType: kms.Secp256k1ECDHKWType,
+			nbRec:   1,
+		},
+		{
+			name:       "secp256k1 ECDH KW and AES256GCM encryption with 1 recipient (Compact serialization)",
+			kt:         ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:        ariesjose.A256GCM,
+			keyType:    kms.Secp256k1ECDHKWType,
+			nbRec:      1,
+			useCompact: true,
+		},
+		{
+			name:    "secp256k1 ECDH KW and XChacha20Poly1305 encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:     ariesjose.XC20P,
+			keyType: kms.Secp256k1ECDHKWType,
+			nbRec:   2,
+		},
 		{
 			name:    "X25519 ECDH KW and AES256GCM encryption with 2 recipients (Full serialization)",
 			kt:      ecdh.X25519ECDHKWKeyTemplate(),
@@ -226,6 +255,43 @@ func TestJWEEncryptRoundTrip(t *testing.T) {
 			nbRec:      1,
 			useCompact: true,
 		},
+		{
+			name:    "P-256 ECDH KW and AES256CBC-HMAC-SHA512 encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.NISTP256ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256CBCHS512,
+			keyType: kms.NISTP256ECDHKWType,
+			nbRec:   2,
+		},
+		{
+			name:       "P-256 ECDH KW and AES256CBC-HMAC-SHA512 encryption with 1 recipient (Compact serialization)",
+			kt:         ecdh.NISTP256ECDHKWKeyTemplate(),
+			enc:        ariesjose.A256CBCHS512,
+			keyType:    kms.NISTP256ECDHKWType,
+			nbRec:      1,
+			useCompact: true,
+		},
+		{
+			name:    "P-521 ECDH KW and AES128CBC-HMAC-SHA256 encryption with 1 recipient (Flattened serialization)",
+			kt:      ecdh.NISTP521ECDHKWKeyTemplate(),
+			enc:     ariesjose.A128CBCHS256,
+			keyType: kms.NISTP521ECDHKWType,
+			nbRec:   1,
+		},
+		{
+			name:    "X25519 ECDH KW and AES256CBC-HMAC-SHA512 encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.X25519ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256CBCHS512,
+			keyType: kms.X25519ECDHKWType,
+			nbRec:   2,
+		},
+		{
+			name:       "X25519 ECDH KW and AES128CBC-HMAC-SHA256 encryption with 1 recipient (Compact serialization)",
+			kt:         ecdh.X25519ECDHKWKeyTemplate(),
+			enc:        ariesjose.A128CBCHS256,
+			keyType:    kms.X25519ECDHKWType,
+			nbRec:      1,
+			useCompact: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -672,14 +738,16 @@ func prettyPrint(msg []byte) (string, error) {
 }
 
 func getPrintedECPubKey(t *testing.T, pubKey *cryptoapi.PublicKey) string {
-	crv, err := hybrid.GetCurve(pubKey.Curve)
+	crv, err := cryptoutil.GetCurve(pubKey.Curve)
 	require.NoError(t, err)
 
-	jwk := jose.JSONWebKey{
-		Key: &ecdsa.PublicKey{
-			Curve: crv,
-			X:     new(big.Int).SetBytes(pubKey.X),
-			Y:     new(big.Int).SetBytes(pubKey.Y),
+	jwk := ariesjose.JWK{
+		JSONWebKey: jose.JSONWebKey{
+			Key: &ecdsa.PublicKey{
+				Curve: crv,
+				X:     new(big.Int).SetBytes(pubKey.X),
+				Y:     new(big.Int).SetBytes(pubKey.Y),
+			},
 		},
 	}
 
@@ -791,6 +859,35 @@ func TestECDH1PU(t *testing.T) {
 			nbRec:      1,
 			useCompact: true,
 		},
+		{
+			name:    "secp256k1 ECDH KW and AES256GCM encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256GCM,
+			keyType: kms.Secp256k1ECDHKWType,
+			nbRec:   2,
+		},
+		{
+			name:    "secp256k1 ECDH KW and AES256GCM encryption with 1 recipient (Flattened serialization)",
+			kt:      ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256GCM,
+			keyType: kms.Secp256k1ECDHKWType,
+			nbRec:   1,
+		},
+		{
+			name:       "secp256k1 ECDH KW and AES256GCM encryption with 1 recipient (Compact serialization)",
+			kt:         ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:        ariesjose.A256GCM,
+			keyType:    kms.Secp256k1ECDHKWType,
+			nbRec:      1,
+			useCompact: true,
+		},
+		{
+			name:    "secp256k1 ECDH KW and XChacha20Poly1305 encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.Secp256k1ECDHKWKeyTemplate(),
+			enc:     ariesjose.XC20P,
+			keyType: kms.Secp256k1ECDHKWType,
+			nbRec:   2,
+		},
 		{
 			name:    "X25519 ECDH KW and AES256GCM encryption with 2 recipients (Full serialization)",
 			kt:      ecdh.X25519ECDHKWKeyTemplate(),
@@ -901,6 +998,43 @@ func TestECDH1PU(t *testing.T) {
 			nbRec:      1,
 			useCompact: true,
 		},
+		{
+			name:    "P-256 ECDH KW and AES256CBC-HMAC-SHA512 encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.NISTP256ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256CBCHS512,
+			keyType: kms.NISTP256ECDHKWType,
+			nbRec:   2,
+		},
+		{
+			name:       "P-256 ECDH KW and AES256CBC-HMAC-SHA512 encryption with 1 recipient (Compact serialization)",
+			kt:         ecdh.NISTP256ECDHKWKeyTemplate(),
+			enc:        ariesjose.A256CBCHS512,
+			keyType:    kms.NISTP256ECDHKWType,
+			nbRec:      1,
+			useCompact: true,
+		},
+		{
+			name:    "P-521 ECDH KW and AES128CBC-HMAC-SHA256 encryption with 1 recipient (Flattened serialization)",
+			kt:      ecdh.NISTP521ECDHKWKeyTemplate(),
+			enc:     ariesjose.A128CBCHS256,
+			keyType: kms.NISTP521ECDHKWType,
+			nbRec:   1,
+		},
+		{
+			name:    "X25519 ECDH KW and AES256CBC-HMAC-SHA512 encryption with 2 recipients (Full serialization)",
+			kt:      ecdh.X25519ECDHKWKeyTemplate(),
+			enc:     ariesjose.A256CBCHS512,
+			keyType: kms.X25519ECDHKWType,
+			nbRec:   2,
+		},
+		{
+			name:       "X25519 ECDH KW and AES128CBC-HMAC-SHA256 encryption with 1 recipient (Compact serialization)",
+			kt:         ecdh.X25519ECDHKWKeyTemplate(),
+			enc:        ariesjose.A128CBCHS256,
+			keyType:    kms.X25519ECDHKWType,
+			nbRec:      1,
+			useCompact: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1005,6 +1139,22 @@ func TestECDH1PU(t *testing.T) {
 				require.NoError(t, err)
 				require.EqualValues(t, pt, msg)
 			})
+
+			if tc.enc == ariesjose.A128CBCHS256 || tc.enc == ariesjose.A256CBCHS512 {
+				t.Run("Decrypting JWE message with a modified tag fails to unwrap the tag bound cek", func(t *testing.T) {
+					jd := ariesjose.NewJWEDecrypt(mockStore, c, k)
+
+					tamperedJWE, e := ariesjose.Deserialize(serializedJWE)
+					require.NoError(t, e)
+
+					badTag := []byte(tamperedJWE.Tag)
+					badTag[0] ^= 0xff
+					tamperedJWE.Tag = string(badTag)
+
+					_, err = jd.Decrypt(tamperedJWE)
+					require.EqualError(t, err, "jwedecrypt: failed to unwrap cek")
+				})
+			}
 		})
 	}
 }
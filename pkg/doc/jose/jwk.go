@@ -33,6 +33,10 @@ const (
 	bitsPerByte   = 8
 	x25519Crv     = "X25519"
 	x25519Kty     = "OKP"
+	bls12381G2Crv = "Bls12381g2"
+	bls12381G2Kty = "OKP"
+	// bls12381G2Size is the size of a compressed BLS12-381 public key in G2.
+	bls12381G2Size = 96
 )
 
 // JWK (JSON Web Key) is a JSON data structure that represents a cryptographic key.
@@ -92,6 +96,31 @@ func JWEFromX25519Key(pubKey []byte) (*JWK, error) {
 	return key, nil
 }
 
+// JWKFromBLS12381G2Key is similar to JWEFromX25519Key but is specific to BLS12-381 G2 (BBS+) public keys as raw
+// []byte. The JWK representation follows the one of https://github.com/mattrglobal/bls12381-key-pair.
+func JWKFromBLS12381G2Key(pubKey []byte) (*JWK, error) {
+	key := &JWK{
+		JSONWebKey: jose.JSONWebKey{
+			Key: pubKey,
+		},
+		Crv: bls12381G2Crv,
+		Kty: bls12381G2Kty,
+	}
+
+	// marshal/unmarshal to get all JWK's fields other than Key filled.
+	keyBytes, err := key.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("create JWK: %w", err)
+	}
+
+	err = key.UnmarshalJSON(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("create JWK: %w", err)
+	}
+
+	return key, nil
+}
+
 // PublicKeyBytes converts a public key to bytes.
 func (j *JWK) PublicKeyBytes() ([]byte, error) {
 	if j.isSecp256k1() {
@@ -120,6 +149,15 @@ func (j *JWK) PublicKeyBytes() ([]byte, error) {
 		return x25519Key, nil
 	}
 
+	if j.isBLS12381G2() {
+		bbsKey, ok := j.Key.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("invalid public key in kid '%s'", j.KeyID)
+		}
+
+		return bbsKey, nil
+	}
+
 	switch pubKey := j.Public().Key.(type) {
 	case ed25519.PublicKey:
 		return pubKey, nil
@@ -155,6 +193,13 @@ func (j *JWK) UnmarshalJSON(jwkBytes []byte) error {
 			return fmt.Errorf("unable to read X25519 JWE: %w", err)
 		}
 
+		*j = *jwk
+	} else if isBLS12381G2(key.Kty, key.Crv) {
+		jwk, err := unmarshalBLS12381G2(&key)
+		if err != nil {
+			return fmt.Errorf("unable to read BLS12381G2 JWK: %w", err)
+		}
+
 		*j = *jwk
 	} else {
 		var joseJWK jose.JSONWebKey
@@ -183,9 +228,22 @@ func (j *JWK) MarshalJSON() ([]byte, error) {
 		return marshalX25519(j)
 	}
 
+	if j.isBLS12381G2() {
+		return marshalBLS12381G2(j)
+	}
+
 	return (&j.JSONWebKey).MarshalJSON()
 }
 
+func (j *JWK) isBLS12381G2() bool {
+	switch j.Key.(type) {
+	case []byte:
+		return isBLS12381G2(j.Kty, j.Crv)
+	default:
+		return false
+	}
+}
+
 func (j *JWK) isX25519() bool {
 	switch j.Key.(type) {
 	case []byte:
@@ -214,6 +272,10 @@ func isX25519(kty, crv string) bool {
 	return strings.EqualFold(kty, x25519Kty) && strings.EqualFold(crv, x25519Crv)
 }
 
+func isBLS12381G2(kty, crv string) bool {
+	return strings.EqualFold(kty, bls12381G2Kty) && strings.EqualFold(crv, bls12381G2Crv)
+}
+
 func isSecp256k1(alg, kty, crv string) bool {
 	return strings.EqualFold(alg, secp256k1Alg) ||
 		(strings.EqualFold(kty, secp256k1Kty) && strings.EqualFold(crv, secp256k1Crv))
@@ -293,6 +355,42 @@ func unmarshalX25519(jwk *jsonWebKey) (*JWK, error) {
 	}, nil
 }
 
+func unmarshalBLS12381G2(jwk *jsonWebKey) (*JWK, error) {
+	if jwk.X == nil {
+		return nil, ErrInvalidKey
+	}
+
+	if len(jwk.X.data) != bls12381G2Size {
+		return nil, ErrInvalidKey
+	}
+
+	return &JWK{
+		JSONWebKey: jose.JSONWebKey{
+			Key: jwk.X.data, KeyID: jwk.Kid, Algorithm: jwk.Alg, Use: jwk.Use,
+		},
+		Crv: jwk.Crv,
+		Kty: jwk.Kty,
+	}, nil
+}
+
+func marshalBLS12381G2(jwk *JWK) ([]byte, error) {
+	key, ok := jwk.Key.([]byte)
+	if !ok || len(key) != bls12381G2Size {
+		return nil, errors.New("marshalBLS12381G2: invalid key")
+	}
+
+	raw := jsonWebKey{
+		Kty: bls12381G2Kty,
+		Crv: bls12381G2Crv,
+		X:   newFixedSizeBuffer(key, bls12381G2Size),
+		Kid: jwk.KeyID,
+		Alg: jwk.Algorithm,
+		Use: jwk.Use,
+	}
+
+	return json.Marshal(raw)
+}
+
 func marshalX25519(jwk *JWK) ([]byte, error) {
 	var raw jsonWebKey
 
@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JWKSet (JSON Web Key Set) is a JSON data structure that represents a set of JWKs as per
+// https://tools.ietf.org/html/rfc7517#section-5.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// ParseJWKSet parses a marshalled JWK Set. It fails if any of the keys in the set is invalid.
+func ParseJWKSet(jwksBytes []byte) (*JWKSet, error) {
+	jwks := &JWKSet{}
+
+	err := json.Unmarshal(jwksBytes, jwks)
+	if err != nil {
+		return nil, fmt.Errorf("parse JWK Set: %w", err)
+	}
+
+	return jwks, nil
+}
+
+// Key gets the JWK with the given key ID from the set.
+func (s *JWKSet) Key(kid string) (*JWK, bool) {
+	for i := range s.Keys {
+		if s.Keys[i].KeyID == kid {
+			return &s.Keys[i], true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/square/go-jose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSet(t *testing.T) {
+	edPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	blsPubKey := make([]byte, bls12381G2Size)
+	_, err = rand.Read(blsPubKey)
+	require.NoError(t, err)
+
+	blsJWK, err := JWKFromBLS12381G2Key(blsPubKey)
+	require.NoError(t, err)
+
+	blsJWK.KeyID = "bls-key"
+	blsJWK.Use = "sig"
+
+	jwks := &JWKSet{
+		Keys: []JWK{
+			{
+				JSONWebKey: jose.JSONWebKey{Key: edPubKey, KeyID: "ed-key", Algorithm: "EdDSA", Use: "sig"},
+				Crv:        "Ed25519",
+				Kty:        "OKP",
+			},
+			*blsJWK,
+		},
+	}
+
+	t.Run("marshal and parse JWK Set", func(t *testing.T) {
+		jwksBytes, err := json.Marshal(jwks)
+		require.NoError(t, err)
+
+		parsed, err := ParseJWKSet(jwksBytes)
+		require.NoError(t, err)
+		require.Len(t, parsed.Keys, 2)
+
+		edJWK, ok := parsed.Key("ed-key")
+		require.True(t, ok)
+		require.Equal(t, edPubKey, edJWK.Key)
+		require.Equal(t, "EdDSA", edJWK.Algorithm)
+
+		parsedBLSJWK, ok := parsed.Key("bls-key")
+		require.True(t, ok)
+		require.Equal(t, blsPubKey, parsedBLSJWK.Key)
+		require.Equal(t, "Bls12381g2", parsedBLSJWK.Crv)
+		require.Equal(t, "sig", parsedBLSJWK.Use)
+
+		blsKeyBytes, err := parsedBLSJWK.PublicKeyBytes()
+		require.NoError(t, err)
+		require.Equal(t, blsPubKey, blsKeyBytes)
+
+		_, ok = parsed.Key("unknown-key")
+		require.False(t, ok)
+	})
+
+	t.Run("parse invalid JWK Set", func(t *testing.T) {
+		_, err := ParseJWKSet([]byte("not json"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse JWK Set")
+
+		_, err = ParseJWKSet([]byte(`{"keys":[{"kty":"OKP","crv":"Bls12381g2","x":"aW52YWxpZA"}]}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse JWK Set")
+	})
+
+	t.Run("invalid BLS12381G2 key", func(t *testing.T) {
+		_, err := JWKFromBLS12381G2Key([]byte("invalid"))
+		require.EqualError(t, err, "create JWK: marshalBLS12381G2: invalid key")
+	})
+}
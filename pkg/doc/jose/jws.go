@@ -22,7 +22,8 @@ const (
 	jwsSignaturePart = 2
 )
 
-// JSONWebSignature defines JSON Web Signature (https://tools.ietf.org/html/rfc7515)
+// JSONWebSignature defines JSON Web Signature (https://tools.ietf.org/html/rfc7515).
+// ProtectedHeaders and UnprotectedHeaders are the headers of its first signature (see Signatures).
 type JSONWebSignature struct {
 	ProtectedHeaders   Headers
 	UnprotectedHeaders Headers
@@ -30,6 +31,34 @@ type JSONWebSignature struct {
 
 	signature   []byte
 	joseHeaders Headers
+	signatures  []JWSSignature
+}
+
+// JWSSignature is one of the signatures of a JWS, with its own protected and unprotected headers
+// (https://tools.ietf.org/html/rfc7515#section-7.2.1).
+type JWSSignature struct {
+	ProtectedHeaders   Headers
+	UnprotectedHeaders Headers
+	Signature          []byte
+
+	// protected is the base64url encoded protected headers, as signed.
+	protected string
+}
+
+// jwsJSON is the JWS JSON Serialization, in the general syntax if it has signatures and in the flattened syntax
+// otherwise (https://tools.ietf.org/html/rfc7515#section-7.2).
+type jwsJSON struct {
+	Payload    string             `json:"payload,omitempty"`
+	Signatures []jwsJSONSignature `json:"signatures,omitempty"`
+	Protected  string             `json:"protected,omitempty"`
+	Header     Headers            `json:"header,omitempty"`
+	Signature  string             `json:"signature,omitempty"`
+}
+
+type jwsJSONSignature struct {
+	Protected string  `json:"protected,omitempty"`
+	Header    Headers `json:"header,omitempty"`
+	Signature string  `json:"signature"`
 }
 
 // SignatureVerifier makes verification of JSON Web Signature.
@@ -98,32 +127,62 @@ type Signer interface {
 
 // NewJWS creates JSON Web Signature.
 func NewJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte, signer Signer) (*JSONWebSignature, error) {
-	headers := mergeHeaders(protectedHeaders, signer.Headers())
-	jws := &JSONWebSignature{
-		ProtectedHeaders:   headers,
+	signature, err := newSignature(protectedHeaders, unprotectedHeaders, payload, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONWebSignature{
+		ProtectedHeaders:   signature.ProtectedHeaders,
 		UnprotectedHeaders: unprotectedHeaders,
 		Payload:            payload,
-		joseHeaders:        headers,
-	}
+		signature:          signature.Signature,
+		joseHeaders:        signature.ProtectedHeaders,
+		signatures:         []JWSSignature{*signature},
+	}, nil
+}
 
-	signature, err := sign(jws.joseHeaders, payload, signer)
+// AddSignature adds a signature of the payload with its own protected and unprotected headers, e.g. to co-sign the
+// JWS. The JWS can then only be serialized with SerializeJSON.
+func (s *JSONWebSignature) AddSignature(protectedHeaders, unprotectedHeaders Headers, signer Signer) error {
+	signature, err := newSignature(protectedHeaders, unprotectedHeaders, s.Payload, signer)
 	if err != nil {
-		return nil, fmt.Errorf("sign JWS: %w", err)
+		return err
 	}
 
-	jws.signature = signature
+	if len(s.signatures) > 0 {
+		if err := checkSameB64(s.signatures[0].ProtectedHeaders, signature.ProtectedHeaders); err != nil {
+			return err
+		}
+	}
 
-	return jws, nil
+	s.signatures = append(s.signatures, *signature)
+
+	return nil
+}
+
+// Signatures returns a copy of the signatures of the JWS.
+func (s JSONWebSignature) Signatures() []JWSSignature {
+	signatures := make([]JWSSignature, len(s.signatures))
+
+	for i, signature := range s.signatures {
+		signatures[i] = signature
+		signatures[i].Signature = append([]byte(nil), signature.Signature...)
+	}
+
+	return signatures
 }
 
 // SerializeCompact makes JWS Compact Serialization (https://tools.ietf.org/html/rfc7515#section-7.1)
 func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
-	byteHeaders, err := json.Marshal(s.joseHeaders)
-	if err != nil {
-		return "", fmt.Errorf("marshal JWS JOSE Headers: %w", err)
+	if len(s.signatures) > 1 {
+		return "", errors.New("JWS compact serialization supports a single signature")
 	}
 
-	b64Headers := base64.RawURLEncoding.EncodeToString(byteHeaders)
+	b64Headers, err := s.protectedHeaders()
+	if err != nil {
+		return "", err
+	}
 
 	b64Payload := ""
 	if !detached {
@@ -138,6 +197,46 @@ func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 		b64Signature), nil
 }
 
+// SerializeJSON makes JWS General JSON Serialization (https://tools.ietf.org/html/rfc7515#section-7.2.1),
+// with all the signatures of the JWS.
+func (s JSONWebSignature) SerializeJSON(detached bool) (string, error) {
+	jws, err := s.jsonPayload(detached)
+	if err != nil {
+		return "", err
+	}
+
+	jws.Signatures = make([]jwsJSONSignature, len(s.signatures))
+
+	for i, signature := range s.signatures {
+		jws.Signatures[i] = jwsJSONSignature{
+			Protected: signature.protected,
+			Header:    signature.UnprotectedHeaders,
+			Signature: base64.RawURLEncoding.EncodeToString(signature.Signature),
+		}
+	}
+
+	return marshalJWSJSON(jws)
+}
+
+// SerializeFlattenedJSON makes JWS Flattened JSON Serialization (https://tools.ietf.org/html/rfc7515#section-7.2.2),
+// which supports a single signature.
+func (s JSONWebSignature) SerializeFlattenedJSON(detached bool) (string, error) {
+	if len(s.signatures) != 1 {
+		return "", errors.New("JWS flattened JSON serialization supports a single signature")
+	}
+
+	jws, err := s.jsonPayload(detached)
+	if err != nil {
+		return "", err
+	}
+
+	jws.Protected = s.signatures[0].protected
+	jws.Header = s.signatures[0].UnprotectedHeaders
+	jws.Signature = base64.RawURLEncoding.EncodeToString(s.signatures[0].Signature)
+
+	return marshalJWSJSON(jws)
+}
+
 // Signature returns a copy of JWS signature.
 func (s JSONWebSignature) Signature() []byte {
 	if s.signature == nil {
@@ -150,6 +249,68 @@ func (s JSONWebSignature) Signature() []byte {
 	return sCopy
 }
 
+func (s JSONWebSignature) protectedHeaders() (string, error) {
+	if len(s.signatures) > 0 {
+		return s.signatures[0].protected, nil
+	}
+
+	byteHeaders, err := json.Marshal(s.joseHeaders)
+	if err != nil {
+		return "", fmt.Errorf("marshal JWS JOSE Headers: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(byteHeaders), nil
+}
+
+func (s JSONWebSignature) jsonPayload(detached bool) (*jwsJSON, error) {
+	if len(s.signatures) == 0 {
+		return nil, errors.New("JWS has no signature")
+	}
+
+	jws := &jwsJSON{}
+
+	if !detached {
+		payload, err := encodePayload(s.signatures[0].ProtectedHeaders, s.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		jws.Payload = payload
+	}
+
+	return jws, nil
+}
+
+func marshalJWSJSON(jws *jwsJSON) (string, error) {
+	jwsBytes, err := json.Marshal(jws)
+	if err != nil {
+		return "", fmt.Errorf("marshal JWS JSON: %w", err)
+	}
+
+	return string(jwsBytes), nil
+}
+
+func newSignature(protectedHeaders, unprotectedHeaders Headers, payload []byte,
+	signer Signer) (*JWSSignature, error) {
+	headers := mergeHeaders(protectedHeaders, signer.Headers())
+
+	if err := checkDisjointHeaders(headers, unprotectedHeaders); err != nil {
+		return nil, fmt.Errorf("sign JWS: %w", err)
+	}
+
+	protected, signature, err := sign(headers, payload, signer)
+	if err != nil {
+		return nil, fmt.Errorf("sign JWS: %w", err)
+	}
+
+	return &JWSSignature{
+		ProtectedHeaders:   headers,
+		UnprotectedHeaders: unprotectedHeaders,
+		Signature:          signature,
+		protected:          protected,
+	}, nil
+}
+
 func mergeHeaders(h1, h2 Headers) Headers {
 	h := make(Headers, len(h1)+len(h2))
 
@@ -164,28 +325,37 @@ func mergeHeaders(h1, h2 Headers) Headers {
 	return h
 }
 
-func sign(joseHeaders Headers, payload []byte, signer Signer) ([]byte, error) { //nolint:interfacer
+// sign signs the payload with the protected headers, and returns the base64url encoded headers and the signature.
+func sign(joseHeaders Headers, payload []byte, signer Signer) (string, []byte, error) { //nolint:interfacer
 	err := checkJWSHeaders(joseHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("check JOSE headers: %w", err)
+		return "", nil, fmt.Errorf("check JOSE headers: %w", err)
 	}
 
-	sigInput, err := signingInput(joseHeaders, payload)
+	headersBytes, err := json.Marshal(joseHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("prepare JWS verification data: %w", err)
+		return "", nil, fmt.Errorf("prepare JWS verification data: serialize JWS headers: %w", err)
+	}
+
+	protected := base64.RawURLEncoding.EncodeToString(headersBytes)
+
+	sigInput, err := protectedSigningInput(protected, joseHeaders, payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("prepare JWS verification data: %w", err)
 	}
 
 	signature, err := signer.Sign(sigInput)
 	if err != nil {
-		return nil, fmt.Errorf("sign JWS verification data: %w", err)
+		return "", nil, fmt.Errorf("sign JWS verification data: %w", err)
 	}
 
-	return signature, nil
+	return protected, signature, nil
 }
 
 // jwsParseOpts holds options for the JWS Parsing.
 type jwsParseOpts struct {
 	detachedPayload []byte
+	anySignature    bool
 }
 
 // JWSParseOpt is the JWS Parser option.
@@ -198,7 +368,17 @@ func WithJWSDetachedPayload(payload []byte) JWSParseOpt {
 	}
 }
 
-// ParseJWS parses serialized JWS. Currently only JWS Compact Serialization parsing is supported.
+// WithJWSAnySignature option is for accepting a JWS in JSON serialization if any of its signatures is verified,
+// instead of all of them. The parsed JWS then only holds the verified signatures.
+func WithJWSAnySignature() JWSParseOpt {
+	return func(opts *jwsParseOpts) {
+		opts.anySignature = true
+	}
+}
+
+// ParseJWS parses serialized JWS, in JWS Compact Serialization or in JWS JSON Serialization (general or flattened).
+// The signatures are verified with the verifier (e.g. a CompositeAlgSigVerifier) given the JOSE headers of each
+// signature, which are the union of its protected and unprotected headers.
 func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSONWebSignature, error) {
 	pOpts := &jwsParseOpts{}
 
@@ -206,10 +386,8 @@ func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSO
 		opt(pOpts)
 	}
 
-	if strings.HasPrefix(jws, "{") {
-		// TODO support JWS JSON serialization format
-		//  https://github.com/hyperledger/aries-framework-go/issues/1331
-		return nil, errors.New("JWS JSON serialization is not supported")
+	if strings.HasPrefix(strings.TrimSpace(jws), "{") {
+		return parseJSON(jws, verifier, pOpts)
 	}
 
 	return parseCompacted(jws, verifier, pOpts)
@@ -258,9 +436,171 @@ func parseCompacted(jwsCompact string, verifier SignatureVerifier, opts *jwsPars
 		Payload:          payload,
 		signature:        signature,
 		joseHeaders:      joseHeaders,
+		signatures: []JWSSignature{{
+			ProtectedHeaders: joseHeaders,
+			Signature:        signature,
+			protected:        parts[jwsHeaderPart],
+		}},
+	}, nil
+}
+
+func parseJSON(jwsJSONSerialized string, verifier SignatureVerifier, opts *jwsParseOpts) (*JSONWebSignature, error) {
+	var jws jwsJSON
+
+	if err := json.Unmarshal([]byte(jwsJSONSerialized), &jws); err != nil {
+		return nil, fmt.Errorf("unmarshal JWS JSON: %w", err)
+	}
+
+	rawSignatures, err := jwsJSONSignatures(&jws)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]JWSSignature, len(rawSignatures))
+
+	for i, rawSignature := range rawSignatures {
+		signature, err := parseJSONSignature(rawSignature)
+		if err != nil {
+			return nil, fmt.Errorf("JWS signature %d: %w", i, err)
+		}
+
+		if i > 0 {
+			if err := checkSameB64(signatures[0].ProtectedHeaders, signature.ProtectedHeaders); err != nil {
+				return nil, err
+			}
+		}
+
+		signatures[i] = *signature
+	}
+
+	payload, err := parseJSONPayload(jws.Payload, signatures[0].ProtectedHeaders, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := verifyJSONSignatures(signatures, payload, verifier, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONWebSignature{
+		ProtectedHeaders:   verified[0].ProtectedHeaders,
+		UnprotectedHeaders: verified[0].UnprotectedHeaders,
+		Payload:            payload,
+		signature:          verified[0].Signature,
+		joseHeaders:        verified[0].ProtectedHeaders,
+		signatures:         verified,
+	}, nil
+}
+
+func jwsJSONSignatures(jws *jwsJSON) ([]jwsJSONSignature, error) {
+	flattened := jws.Protected != "" || jws.Header != nil || jws.Signature != ""
+
+	switch {
+	case len(jws.Signatures) > 0 && flattened:
+		return nil, errors.New("invalid JWS JSON format: both general and flattened syntax")
+	case len(jws.Signatures) > 0:
+		return jws.Signatures, nil
+	case flattened:
+		return []jwsJSONSignature{{Protected: jws.Protected, Header: jws.Header, Signature: jws.Signature}}, nil
+	default:
+		return nil, errors.New("invalid JWS JSON format: no signature")
+	}
+}
+
+func parseJSONSignature(rawSignature jwsJSONSignature) (*JWSSignature, error) {
+	var protectedHeaders Headers
+
+	if rawSignature.Protected != "" {
+		headersBytes, err := base64.RawURLEncoding.DecodeString(rawSignature.Protected)
+		if err != nil {
+			return nil, fmt.Errorf("decode base64 header: %w", err)
+		}
+
+		if err := json.Unmarshal(headersBytes, &protectedHeaders); err != nil {
+			return nil, fmt.Errorf("unmarshal JSON headers: %w", err)
+		}
+	}
+
+	if err := checkDisjointHeaders(protectedHeaders, rawSignature.Header); err != nil {
+		return nil, err
+	}
+
+	if err := checkJWSHeaders(mergeHeaders(protectedHeaders, rawSignature.Header)); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(rawSignature.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 signature: %w", err)
+	}
+
+	return &JWSSignature{
+		ProtectedHeaders:   protectedHeaders,
+		UnprotectedHeaders: rawSignature.Header,
+		Signature:          signature,
+		protected:          rawSignature.Protected,
 	}, nil
 }
 
+func parseJSONPayload(jwsPayload string, protectedHeaders Headers, opts *jwsParseOpts) ([]byte, error) {
+	if len(opts.detachedPayload) > 0 {
+		return opts.detachedPayload, nil
+	}
+
+	b64, err := payloadB64(protectedHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	if !b64 {
+		return []byte(jwsPayload), nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(jwsPayload)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+func verifyJSONSignatures(signatures []JWSSignature, payload []byte, verifier SignatureVerifier,
+	opts *jwsParseOpts) ([]JWSSignature, error) {
+	var (
+		verified []JWSSignature
+		errs     []string
+	)
+
+	for i, signature := range signatures {
+		sInput, err := protectedSigningInput(signature.protected, signature.ProtectedHeaders, payload)
+		if err != nil {
+			return nil, fmt.Errorf("build signing input: %w", err)
+		}
+
+		joseHeaders := mergeHeaders(signature.ProtectedHeaders, signature.UnprotectedHeaders)
+
+		err = verifier.Verify(joseHeaders, payload, sInput, signature.Signature)
+		if err != nil && !opts.anySignature {
+			return nil, fmt.Errorf("verify JWS signature %d: %w", i, err)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("signature %d: %s", i, err))
+
+			continue
+		}
+
+		verified = append(verified, signature)
+	}
+
+	if len(verified) == 0 {
+		return nil, fmt.Errorf("no JWS signature verified: %s", strings.Join(errs, "; "))
+	}
+
+	return verified, nil
+}
+
 func parseCompactedPayload(jwsPayload string, opts *jwsParseOpts) ([]byte, error) {
 	if len(opts.detachedPayload) > 0 {
 		return opts.detachedPayload, nil
@@ -301,25 +641,64 @@ func signingInput(headers Headers, payload []byte) ([]byte, error) {
 		return nil, fmt.Errorf("serialize JWS headers: %w", err)
 	}
 
-	hBase64 := true
+	return protectedSigningInput(base64.RawURLEncoding.EncodeToString(headersBytes), headers, payload)
+}
 
-	if b64, ok := headers[HeaderB64Payload]; ok {
-		if hBase64, ok = b64.(bool); !ok {
-			return nil, errors.New("invalid b64 header")
-		}
+// protectedSigningInput builds the signing input from the base64url encoded protected headers.
+func protectedSigningInput(protected string, headers Headers, payload []byte) ([]byte, error) {
+	payloadStr, err := encodePayload(headers, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(fmt.Sprintf("%s.%s", protected, payloadStr)), nil
+}
+
+func encodePayload(headers Headers, payload []byte) (string, error) {
+	b64, err := payloadB64(headers)
+	if err != nil {
+		return "", err
 	}
 
-	headersStr := base64.RawURLEncoding.EncodeToString(headersBytes)
+	if b64 {
+		return base64.RawURLEncoding.EncodeToString(payload), nil
+	}
 
-	var payloadStr string
+	return string(payload), nil
+}
+
+// payloadB64 returns whether the payload is base64url encoded (https://tools.ietf.org/html/rfc7797#section-3).
+func payloadB64(headers Headers) (bool, error) {
+	b64, ok := headers[HeaderB64Payload]
+	if !ok {
+		return true, nil
+	}
 
-	if hBase64 {
-		payloadStr = base64.RawURLEncoding.EncodeToString(payload)
-	} else {
-		payloadStr = string(payload)
+	hBase64, ok := b64.(bool)
+	if !ok {
+		return false, errors.New("invalid b64 header")
 	}
 
-	return []byte(fmt.Sprintf("%s.%s", headersStr, payloadStr)), nil
+	return hBase64, nil
+}
+
+// checkSameB64 checks that signatures have the same b64 header value (https://tools.ietf.org/html/rfc7797#section-7).
+func checkSameB64(headers1, headers2 Headers) error {
+	b64, err := payloadB64(headers1)
+	if err != nil {
+		return err
+	}
+
+	otherB64, err := payloadB64(headers2)
+	if err != nil {
+		return err
+	}
+
+	if b64 != otherB64 {
+		return errors.New("JWS signatures must have the same b64 header")
+	}
+
+	return nil
 }
 
 func checkJWSHeaders(headers Headers) error {
@@ -330,6 +709,18 @@ func checkJWSHeaders(headers Headers) error {
 	return nil
 }
 
+// checkDisjointHeaders checks that protected and unprotected headers have no header in common
+// (https://tools.ietf.org/html/rfc7515#section-7.2.1).
+func checkDisjointHeaders(protectedHeaders, unprotectedHeaders Headers) error {
+	for k := range unprotectedHeaders {
+		if _, ok := protectedHeaders[k]; ok {
+			return fmt.Errorf("%s JWS header is both protected and unprotected", k)
+		}
+	}
+
+	return nil
+}
+
 func convertMapToValue(vOriginToBeMap, vDest interface{}) error {
 	if _, ok := vOriginToBeMap.(map[string]interface{}); !ok {
 		return errors.New("expected value to be a map")
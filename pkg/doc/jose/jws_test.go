@@ -179,7 +179,7 @@ func TestParseJWS(t *testing.T) {
 	// Parse not compact JWS format
 	parsedJWS, err = ParseJWS(`{"some": "JSON"}`, &testVerifier{})
 	require.Error(t, err)
-	require.EqualError(t, err, "JWS JSON serialization is not supported")
+	require.EqualError(t, err, "invalid JWS JSON format: no signature")
 	require.Nil(t, parsedJWS)
 
 	// Parse invalid compact JWS format
@@ -230,6 +230,162 @@ func TestParseJWS(t *testing.T) {
 	require.Nil(t, parsedJWS)
 }
 
+func TestJSONWebSignature_SerializeJSON(t *testing.T) {
+	payload := []byte("payload")
+
+	jws, err := NewJWS(Headers{"typ": "JWT"}, Headers{"kid": "key-1"}, payload, &inputSigner{alg: "alg-1"})
+	require.NoError(t, err)
+
+	flattened, err := jws.SerializeFlattenedJSON(false)
+	require.NoError(t, err)
+	require.Contains(t, flattened, `"header":{"kid":"key-1"}`)
+	require.NotContains(t, flattened, "signatures")
+
+	require.NoError(t, jws.AddSignature(nil, Headers{"kid": "key-2"}, &inputSigner{alg: "alg-2"}))
+	require.Len(t, jws.Signatures(), 2)
+	require.Equal(t, Headers{"alg": "alg-2"}, jws.Signatures()[1].ProtectedHeaders)
+	require.Equal(t, Headers{"kid": "key-2"}, jws.Signatures()[1].UnprotectedHeaders)
+
+	general, err := jws.SerializeJSON(false)
+	require.NoError(t, err)
+	require.Contains(t, general, `"payload":"cGF5bG9hZA"`)
+	require.Contains(t, general, `"signatures":[`)
+
+	detached, err := jws.SerializeJSON(true)
+	require.NoError(t, err)
+	require.NotContains(t, detached, "payload")
+
+	_, err = jws.SerializeFlattenedJSON(false)
+	require.EqualError(t, err, "JWS flattened JSON serialization supports a single signature")
+
+	_, err = jws.SerializeCompact(false)
+	require.EqualError(t, err, "JWS compact serialization supports a single signature")
+
+	// b64=false
+	jws, err = NewJWS(Headers{"b64": false}, nil, payload, &inputSigner{alg: "alg-1"})
+	require.NoError(t, err)
+
+	flattened, err = jws.SerializeFlattenedJSON(false)
+	require.NoError(t, err)
+	require.Contains(t, flattened, `"payload":"payload"`)
+
+	err = jws.AddSignature(nil, nil, &inputSigner{alg: "alg-2"})
+	require.EqualError(t, err, "JWS signatures must have the same b64 header")
+
+	// header both protected and unprotected
+	_, err = NewJWS(nil, Headers{"alg": "alg-1"}, payload, &inputSigner{alg: "alg-1"})
+	require.EqualError(t, err, "sign JWS: alg JWS header is both protected and unprotected")
+
+	err = jws.AddSignature(nil, nil, &testSigner{headers: Headers{"alg": "dummy"}, err: errors.New("signer error")})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "signer error")
+
+	// no signature
+	_, err = JSONWebSignature{}.SerializeJSON(false)
+	require.EqualError(t, err, "JWS has no signature")
+}
+
+func TestParseJWS_JSON(t *testing.T) {
+	payload := []byte("payload")
+	verifier := NewCompositeAlgSigVerifier(
+		AlgSignatureVerifier{Alg: "alg-1", Verifier: &inputVerifier{alg: "alg-1"}},
+		AlgSignatureVerifier{Alg: "alg-2", Verifier: &inputVerifier{alg: "alg-2"}})
+
+	jws, err := NewJWS(Headers{"typ": "JWT"}, Headers{"kid": "key-1"}, payload, &inputSigner{alg: "alg-1"})
+	require.NoError(t, err)
+
+	t.Run("flattened", func(t *testing.T) {
+		flattened, err := jws.SerializeFlattenedJSON(false)
+		require.NoError(t, err)
+
+		parsedJWS, err := ParseJWS(flattened, verifier)
+		require.NoError(t, err)
+		require.Equal(t, jws, parsedJWS)
+
+		compact, err := parsedJWS.SerializeCompact(false)
+		require.NoError(t, err)
+
+		_, err = ParseJWS(compact, verifier)
+		require.NoError(t, err)
+
+		detached, err := jws.SerializeFlattenedJSON(true)
+		require.NoError(t, err)
+
+		parsedJWS, err = ParseJWS(detached, verifier, WithJWSDetachedPayload(payload))
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+	})
+
+	t.Run("general with multiple signatures", func(t *testing.T) {
+		coSigned, err := NewJWS(Headers{"typ": "JWT"}, Headers{"kid": "key-1"}, payload, &inputSigner{alg: "alg-1"})
+		require.NoError(t, err)
+		require.NoError(t, coSigned.AddSignature(nil, Headers{"kid": "key-2"}, &inputSigner{alg: "alg-2"}))
+
+		general, err := coSigned.SerializeJSON(false)
+		require.NoError(t, err)
+
+		parsedJWS, err := ParseJWS(general, verifier)
+		require.NoError(t, err)
+		require.Equal(t, coSigned, parsedJWS)
+
+		// the second signature is not verified
+		onlyAlg1 := NewCompositeAlgSigVerifier(AlgSignatureVerifier{Alg: "alg-1", Verifier: &inputVerifier{alg: "alg-1"}})
+
+		_, err = ParseJWS(general, onlyAlg1)
+		require.EqualError(t, err, "verify JWS signature 1: no verifier found for alg-2 algorithm")
+
+		parsedJWS, err = ParseJWS(general, onlyAlg1, WithJWSAnySignature())
+		require.NoError(t, err)
+		require.Len(t, parsedJWS.Signatures(), 1)
+		require.Equal(t, Headers{"kid": "key-1"}, parsedJWS.UnprotectedHeaders)
+
+		_, err = ParseJWS(general, &testVerifier{err: errors.New("bad signature")}, WithJWSAnySignature())
+		require.EqualError(t, err,
+			"no JWS signature verified: signature 0: bad signature; signature 1: bad signature")
+	})
+
+	t.Run("alg in unprotected header", func(t *testing.T) {
+		signingInput := "." + base64.RawURLEncoding.EncodeToString(payload)
+		jwsJSON := fmt.Sprintf(`{"payload":%q,"header":{"alg":"alg-1"},"signature":%q}`,
+			base64.RawURLEncoding.EncodeToString(payload),
+			base64.RawURLEncoding.EncodeToString([]byte("alg-1:"+signingInput)))
+
+		parsedJWS, err := ParseJWS(jwsJSON, verifier)
+		require.NoError(t, err)
+		require.Nil(t, parsedJWS.ProtectedHeaders)
+		require.Equal(t, payload, parsedJWS.Payload)
+	})
+
+	t.Run("invalid JWS JSON", func(t *testing.T) {
+		flattened, err := jws.SerializeFlattenedJSON(false)
+		require.NoError(t, err)
+
+		protected := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"alg-1"}`))
+
+		for jwsJSON, expected := range map[string]string{
+			`{`: "unmarshal JWS JSON",
+			`{"signatures":[{"signature":"c2ln"}],"signature":"c2ln"}`:                      "both general and flattened syntax",
+			`{"protected":"!","signature":"c2ln"}`:                                          "JWS signature 0: decode base64 header",
+			`{"protected":"e30","signature":"c2ln"}`:                                        "JWS signature 0: alg JWS header is not defined",
+			`{"protected":"eyJ9","signature":"c2ln"}`:                                       "JWS signature 0: unmarshal JSON headers",
+			`{"protected":"` + protected + `","header":{"alg":"alg-1"},"signature":"c2ln"}`: "both protected and unprotected",
+			`{"protected":"` + protected + `","signature":"!"}`:                             "decode base64 signature",
+			`{"protected":"` + protected + `","payload":"!","signature":"c2ln"}`:            "decode base64 payload",
+			strings.Replace(flattened, `"signature":"`, `"signature":"AAAA`, 1):             "verify JWS signature 0",
+		} {
+			_, err := ParseJWS(jwsJSON, verifier)
+			require.Error(t, err, jwsJSON)
+			require.Contains(t, err.Error(), expected, jwsJSON)
+		}
+
+		b64False := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"alg-1","b64":false}`))
+
+		_, err = ParseJWS(`{"signatures":[{"protected":"`+protected+`","signature":"c2ln"},`+
+			`{"protected":"`+b64False+`","signature":"c2ln"}]}`, verifier)
+		require.EqualError(t, err, "JWS signatures must have the same b64 header")
+	})
+}
+
 func TestIsCompactJWS(t *testing.T) {
 	require.True(t, IsCompactJWS("a.b.c"))
 	require.False(t, IsCompactJWS("a.b"))
@@ -262,3 +418,28 @@ func (v testVerifier) Verify(_ Headers, _, _, _ []byte) error {
 func getUnmarshallableMap() map[string]interface{} {
 	return map[string]interface{}{"alg": "JWS", "error": map[chan int]interface{}{make(chan int): 6}}
 }
+
+// inputSigner signs with its algorithm and the signing input, for inputVerifier to verify the signing input.
+type inputSigner struct {
+	alg string
+}
+
+func (s inputSigner) Sign(data []byte) ([]byte, error) {
+	return []byte(s.alg + ":" + string(data)), nil
+}
+
+func (s inputSigner) Headers() Headers {
+	return Headers{"alg": s.alg}
+}
+
+type inputVerifier struct {
+	alg string
+}
+
+func (v inputVerifier) Verify(_ Headers, _, signingInput, signature []byte) error {
+	if string(signature) != v.alg+":"+string(signingInput) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
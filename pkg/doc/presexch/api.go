@@ -50,6 +50,7 @@ type InputDescriptorMapping struct {
 // MatchOptions is a holder of options that can set when matching a submission against definitions.
 type MatchOptions struct {
 	JSONLDDocumentLoader ld.DocumentLoader
+	CredentialOptions    []verifiable.CredentialOpt
 }
 
 // MatchOption is an option that sets an option for when matching.
@@ -62,6 +63,14 @@ func WithJSONLDDocumentLoader(l ld.DocumentLoader) MatchOption {
 	}
 }
 
+// WithCredentialOptions sets the options used to parse the embedded verifiable credentials
+// (e.g. verifiable.WithDataModelVersions() to accept credentials of VC Data Model 2.0).
+func WithCredentialOptions(opts ...verifiable.CredentialOpt) MatchOption {
+	return func(m *MatchOptions) {
+		m.CredentialOptions = append(m.CredentialOptions, opts...)
+	}
+}
+
 // Match returns the credentials matched against the InputDescriptors ids.
 func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:gocyclo,funlen
 	options ...MatchOption) (map[string]*verifiable.Credential, error) {
@@ -108,7 +117,7 @@ func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:g
 				descriptorMapProperty, mapping.ID)
 		}
 
-		vc, selectErr := selectByPath(builder, typelessVP, mapping.Path, opts.JSONLDDocumentLoader)
+		vc, selectErr := selectByPath(builder, typelessVP, mapping.Path, opts)
 		if selectErr != nil {
 			return nil, fmt.Errorf("failed to select vc from submission: %w", selectErr)
 		}
@@ -219,7 +228,7 @@ func descriptorIDs(input []*InputDescriptor) []string {
 // string expression that selects the credential to be submit in relation to the identified Input Descriptor
 // identified, when executed against the top-level of the object the Presentation Submission is embedded within.
 func selectByPath(builder gval.Language, vp interface{}, jsonPath string,
-	loader ld.DocumentLoader) (*verifiable.Credential, error) {
+	opts *MatchOptions) (*verifiable.Credential, error) {
 	path, err := builder.NewEvaluable(jsonPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build new json path evaluator: %w", err)
@@ -235,12 +244,14 @@ func selectByPath(builder gval.Language, vp interface{}, jsonPath string,
 		return nil, fmt.Errorf("failed to marshal credential: %w", err)
 	}
 
-	vcOpts := make([]verifiable.CredentialOpt, 0)
+	vcOpts := make([]verifiable.CredentialOpt, 0, len(opts.CredentialOptions)+1)
 
-	if loader != nil {
-		vcOpts = append(vcOpts, verifiable.WithJSONLDDocumentLoader(loader))
+	if opts.JSONLDDocumentLoader != nil {
+		vcOpts = append(vcOpts, verifiable.WithJSONLDDocumentLoader(opts.JSONLDDocumentLoader))
 	}
 
+	vcOpts = append(vcOpts, opts.CredentialOptions...)
+
 	vc, err := verifiable.ParseCredential(credBits, vcOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse credential: %w", err)
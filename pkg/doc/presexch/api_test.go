@@ -49,6 +49,52 @@ func TestPresentationDefinition_Match(t *testing.T) {
 		require.Equal(t, expected.ID, result.ID)
 	})
 
+	t.Run("match credential of VC Data Model 2.0", func(t *testing.T) {
+		uri := randomURI()
+		expected := newVC([]string{uri})
+		expected.Context[0] = verifiable.ContextV2URI
+		expected.ValidFrom = expected.Issued
+		expected.Issued = nil
+
+		defs := &PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Schema: []*Schema{{
+					URI: uri,
+				}},
+			}},
+		}
+
+		vp := newVP(t,
+			&PresentationSubmission{DescriptorMap: []*InputDescriptorMapping{{
+				ID:   defs.InputDescriptors[0].ID,
+				Path: "$.verifiableCredential[0]",
+			}}},
+			expected,
+		)
+		vp.Context[0] = verifiable.ContextV2URI
+
+		// the terms of VC Data Model 2.0 context are protected, hence they can't be redefined by the test context.
+		reader, err := ld.DocumentFromReader(strings.NewReader(`{"@context": {"ex": "https://example.org/examples#"}}`))
+		require.NoError(t, err)
+
+		loader := CachingJSONLDLoader()
+		loader.AddDocument(uri, reader)
+
+		_, err = defs.Match(vp, WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verifiable credential data model 2.0 is not accepted")
+
+		matched, err := defs.Match(vp, WithJSONLDDocumentLoader(loader),
+			WithCredentialOptions(verifiable.WithDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2)))
+		require.NoError(t, err)
+		require.Len(t, matched, 1)
+		result, ok := matched[defs.InputDescriptors[0].ID]
+		require.True(t, ok)
+		require.Equal(t, expected.ID, result.ID)
+		require.Equal(t, expected.ValidFrom.Unix(), result.ValidFrom.Unix())
+	})
+
 	t.Run("error if vp does not have the right context", func(t *testing.T) {
 		uri := randomURI()
 		defs := &PresentationDefinition{
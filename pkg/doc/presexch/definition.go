@@ -569,15 +569,7 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 			template := credentialSrc
 
 			if constraints.LimitDisclosure {
-				template, err = json.Marshal(map[string]interface{}{
-					"id":                credential.ID,
-					"credentialSchema":  credential.Schemas,
-					"type":              credential.Types,
-					"@context":          credential.Context,
-					"issuer":            credential.Issuer,
-					"credentialSubject": toSubject(credential.Subject),
-					"issuanceDate":      credential.Issued,
-				})
+				template, err = json.Marshal(limitedCredentialTemplate(credential))
 				if err != nil {
 					return nil, err
 				}
@@ -599,6 +591,29 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 	return result, nil
 }
 
+// limitedCredentialTemplate returns the mandatory fields of the credential data model, which are kept
+// when disclosure is limited.
+func limitedCredentialTemplate(credential *verifiable.Credential) map[string]interface{} {
+	template := map[string]interface{}{
+		"id":                credential.ID,
+		"credentialSchema":  credential.Schemas,
+		"type":              credential.Types,
+		"@context":          credential.Context,
+		"issuer":            credential.Issuer,
+		"credentialSubject": toSubject(credential.Subject),
+	}
+
+	if credential.DataModel() == verifiable.DataModelV2 {
+		if credential.ValidFrom != nil {
+			template["validFrom"] = credential.ValidFrom
+		}
+	} else {
+		template["issuanceDate"] = credential.Issued
+	}
+
+	return template
+}
+
 func toSubject(subject interface{}) interface{} {
 	sub, ok := subject.([]verifiable.Subject)
 	if ok && len(sub) == 1 {
@@ -630,6 +645,9 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 		explicitPaths       = make(map[string]bool)
 	)
 
+	// the limited credential keeps the data model of the credential, unless otherwise specified by opts.
+	opts = append([]verifiable.CredentialOpt{verifiable.WithDataModelVersions(credential.DataModel())}, opts...)
+
 	for _, f := range constraints.Fields {
 		paths, err := jsonpathkeys.ParsePaths(f.Path...)
 		if err != nil {
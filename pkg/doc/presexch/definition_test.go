@@ -42,19 +42,29 @@ var (
 	intFilterType = "integer"
 
 	subIsIssuerRequired = Required
-	// schemaURI is being set in init() function.
-	schemaURI string
+	// schemaURI and schemaV2URI are being set in init() function.
+	schemaURI   string
+	schemaV2URI string
 )
 
 // nolint: gochecknoinits
 func init() {
 	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		res.WriteHeader(http.StatusOK)
+
+		if req.URL.Path == "/v2" {
+			//nolint: gosec,errcheck
+			res.Write([]byte(verifiable.DefaultSchemaV2))
+
+			return
+		}
+
 		//nolint: gosec,errcheck
 		res.Write([]byte(verifiable.DefaultSchema))
 	}))
 
 	schemaURI = server.URL
+	schemaV2URI = server.URL + "/v2"
 }
 
 func TestPresentationDefinition_IsValid(t *testing.T) {
@@ -426,6 +436,69 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 		checkVP(t, vp)
 	})
 
+	t.Run("Limit disclosure (VC Data Model 2.0)", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Schema: []*Schema{{
+					URI: schemaV2URI,
+				}},
+				Constraints: &Constraints{
+					LimitDisclosure: true,
+					Fields: []*Field{{
+						Path:   []string{"$.first_name"},
+						Filter: &Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		validFrom := time.Now().UTC().Truncate(time.Second)
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{
+			{
+				Context: []string{verifiable.ContextV2URI},
+				Types:   []string{verifiable.VCType},
+				ID:      "http://example.edu/credentials/1872",
+				Schemas: []verifiable.TypedID{{
+					ID:   schemaV2URI,
+					Type: "JsonSchema",
+				}},
+				Subject: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+				ValidFrom: &util.TimeWithTrailingZeroMsec{
+					Time: validFrom,
+				},
+				Issuer: verifiable.Issuer{
+					ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+				},
+				CustomFields: map[string]interface{}{
+					"first_name": "First name",
+					"info":       "Info",
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+		require.Equal(t, 1, len(vp.Credentials()))
+
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+
+		require.Equal(t, verifiable.DataModelV2, vp.DataModel())
+		require.Equal(t, verifiable.DataModelV2, vc.DataModel())
+		require.Equal(t, validFrom, vc.ValidFrom.Time)
+		require.Nil(t, vc.Issued)
+		require.EqualValues(t, "First name", vc.CustomFields["first_name"])
+
+		_, ok = vc.CustomFields["info"]
+		require.False(t, ok)
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+	})
+
 	t.Run("Limit disclosure BBS+", func(t *testing.T) {
 		pd := &PresentationDefinition{
 			ID: uuid.New().String(),
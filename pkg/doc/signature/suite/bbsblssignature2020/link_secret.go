@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignature2020
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+)
+
+// A BbsBlsSignature2020 proof can be bound to a holder's link secret using BBS+ blind signatures: the link secret is
+// signed as an extra message following the statements of the proof and document, the issuer only sees a commitment
+// to it. Such a proof is verified by the holder with NewBoundG2PublicKeyVerifier(). As selective disclosure proofs
+// can't be derived without the link secret, bound credentials can't be presented by someone who copies them.
+//
+// The flow is:
+//  1. the holder commits to the link secret with CommitLinkSecret() and sends the blind context to the issuer,
+//  2. the issuer signs the document using a BlindSigner built with the blind context,
+//  3. the holder unblinds the signed document proofs with UnblindProofs().
+
+// CommitLinkSecret commits to the holder's linkSecret to be signed after statementsCount statements (canonical proof
+// options and document statements) with the issuer's public key in issuerPubKH handle. The nonce is provided by the
+// issuer to bind the commitment proof of knowledge to the issuance.
+// It returns the blind signature context to be sent to the issuer and the blinding factor to be kept by the holder to
+// unblind the proofs (see UnblindProofs()).
+func CommitLinkSecret(cr crypto.Crypto, linkSecret []byte, statementsCount int, nonce []byte,
+	issuerPubKH interface{}) ([]byte, []byte, error) {
+	blindContext, blindingFactor, err := cr.BlindMessages([][]byte{linkSecret}, []int{statementsCount},
+		statementsCount+1, nonce, issuerPubKH)
+	if err != nil {
+		return nil, nil, fmt.Errorf("commit link secret: %w", err)
+	}
+
+	return blindContext, blindingFactor, nil
+}
+
+// BlindSigner signs BbsBlsSignature2020 proofs bound to the holder's link secret committed to in a blind signature
+// context (see CommitLinkSecret()).
+type BlindSigner struct {
+	cr           crypto.Crypto
+	kh           interface{}
+	blindContext []byte
+	nonce        []byte
+}
+
+// NewBlindSigner creates a new BlindSigner signing with the issuer's private key in kh handle. The nonce is the one
+// sent to the holder to build blindContext.
+func NewBlindSigner(cr crypto.Crypto, kh interface{}, blindContext, nonce []byte) *BlindSigner {
+	return &BlindSigner{
+		cr:           cr,
+		kh:           kh,
+		blindContext: blindContext,
+		nonce:        nonce,
+	}
+}
+
+// Sign blind signs the statements of data followed by the holder's committed link secret.
+func (s *BlindSigner) Sign(data []byte) ([]byte, error) {
+	statements := splitMessageIntoLines(string(data))
+
+	indexes := make([]int, len(statements))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	return s.cr.BlindSign(s.blindContext, statements, indexes, len(statements)+1, s.nonce, s.kh)
+}
+
+// UnblindProofs unblinds the proof values of the BbsBlsSignature2020 proofs of doc signed by a BlindSigner, using the
+// holder's blindingFactor returned by CommitLinkSecret(). The proofs of doc are updated in place.
+func UnblindProofs(cr crypto.Crypto, doc map[string]interface{}, blindingFactor []byte) error {
+	var rawProofs []interface{}
+
+	switch p := doc["proof"].(type) {
+	case map[string]interface{}:
+		rawProofs = []interface{}{p}
+	case []interface{}:
+		rawProofs = p
+	default:
+		return errors.New("unblind proofs: document does not have a proof")
+	}
+
+	for _, rawProof := range rawProofs {
+		proofMap, ok := rawProof.(map[string]interface{})
+		if !ok {
+			return errors.New("unblind proofs: proof is not a JSON map")
+		}
+
+		if proofMap["type"] != signatureType {
+			continue
+		}
+
+		p, err := proof.NewProof(proofMap)
+		if err != nil {
+			return fmt.Errorf("unblind proofs: %w", err)
+		}
+
+		signature, err := cr.UnblindSignature(p.ProofValue, blindingFactor)
+		if err != nil {
+			return fmt.Errorf("unblind proofs: %w", err)
+		}
+
+		proofMap["proofValue"] = base64.RawURLEncoding.EncodeToString(signature)
+	}
+
+	return nil
+}
+
+func splitMessageIntoLines(msg string) [][]byte {
+	rows := strings.Split(msg, "\n")
+
+	msgs := make([][]byte, 0, len(rows))
+
+	for i := range rows {
+		if strings.TrimSpace(rows[i]) != "" {
+			msgs = append(msgs, []byte(rows[i]))
+		}
+	}
+
+	return msgs
+}
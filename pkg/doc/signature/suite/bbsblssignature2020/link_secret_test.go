@@ -0,0 +1,186 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bbsblssignature2020
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+var errTest = errors.New("test error")
+
+//nolint:funlen
+func TestLinkSecret(t *testing.T) {
+	localKMS, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kid, kh, err := localKMS.Create(kmsapi.BLS12381G2Type)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := localKMS.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+
+	pubKH, err := localKMS.PubKeyBytesToHandle(pubKeyBytes, kmsapi.BLS12381G2Type)
+	require.NoError(t, err)
+
+	loader := jsonld.WithDocumentLoader(createLDPBBS2020DocumentLoader())
+	linkSecret := []byte("link secret")
+	nonce := []byte("issuance nonce")
+	created := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	var docMap map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(vcDoc), &docMap))
+	delete(docMap, "proof")
+
+	unsignedDoc, err := json.Marshal(docMap)
+	require.NoError(t, err)
+
+	signContext := &signer.Context{
+		SignatureType:           signatureType,
+		SignatureRepresentation: proof.SignatureProofValue,
+		Creator:                 "did:example:489398593#test",
+		VerificationMethod:      "did:example:489398593#test",
+		Created:                 &created,
+		Purpose:                 "assertionMethod",
+	}
+
+	// the issuer tells the holder the number of statements to be signed.
+	counter := &statementsCounter{}
+	_, err = signer.New(New(suite.WithSigner(counter))).Sign(signContext, unsignedDoc, loader)
+	require.NoError(t, err)
+
+	blindContext, blindingFactor, err := CommitLinkSecret(tinkCrypto, linkSecret, counter.count, nonce, pubKH)
+	require.NoError(t, err)
+
+	blindSigner := NewBlindSigner(tinkCrypto, kh, blindContext, nonce)
+
+	signedDoc, err := signer.New(New(suite.WithSigner(blindSigner))).Sign(signContext, unsignedDoc, loader)
+	require.NoError(t, err)
+
+	var signedDocMap map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(signedDoc, &signedDocMap))
+	require.NoError(t, UnblindProofs(tinkCrypto, signedDocMap, blindingFactor))
+
+	signedDoc, err = json.Marshal(signedDocMap)
+	require.NoError(t, err)
+
+	pubKeyResolver := &testKeyResolver{
+		publicKey: &sigverifier.PublicKey{
+			Type:  g2PubKeyType,
+			Value: pubKeyBytes,
+		},
+	}
+
+	t.Run("verify with link secret", func(t *testing.T) {
+		v, err := sigverifier.New(pubKeyResolver,
+			New(suite.WithVerifier(NewBoundG2PublicKeyVerifier(linkSecret)), suite.WithCompactProof()))
+		require.NoError(t, err)
+		require.NoError(t, v.Verify(signedDoc, loader))
+
+		v, err = sigverifier.New(pubKeyResolver,
+			New(suite.WithVerifier(NewBoundG2PublicKeyVerifier([]byte("other secret"))), suite.WithCompactProof()))
+		require.NoError(t, err)
+		require.Error(t, v.Verify(signedDoc, loader))
+
+		v, err = sigverifier.New(pubKeyResolver,
+			New(suite.WithVerifier(NewG2PublicKeyVerifier()), suite.WithCompactProof()))
+		require.NoError(t, err)
+		require.Error(t, v.Verify(signedDoc, loader))
+	})
+
+	t.Run("selective disclosure with link secret", func(t *testing.T) {
+		revealDoc := map[string]interface{}{
+			"@context": docMap["@context"],
+			"type":     []interface{}{"VerifiableCredential", "PermanentResidentCard"},
+			"credentialSubject": map[string]interface{}{
+				"@explicit":  true,
+				"type":       []interface{}{"PermanentResident", "Person"},
+				"givenName":  map[string]interface{}{},
+				"familyName": map[string]interface{}{},
+			},
+		}
+
+		proofSuite := bbsblssignatureproof2020.New(suite.WithCompactProof(),
+			suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier([]byte("proof nonce"))))
+
+		derivedDoc, err := proofSuite.SelectiveDisclosureWithLinkSecret(signedDocMap, revealDoc,
+			[]byte("proof nonce"), linkSecret, pubKeyResolver, loader)
+		require.NoError(t, err)
+
+		derivedDocBytes, err := json.Marshal(derivedDoc)
+		require.NoError(t, err)
+		require.NotContains(t, string(derivedDocBytes), string(linkSecret))
+
+		v, err := sigverifier.New(pubKeyResolver, proofSuite)
+		require.NoError(t, err)
+		require.NoError(t, v.Verify(derivedDocBytes, loader))
+
+		_, err = proofSuite.SelectiveDisclosureWithLinkSecret(signedDocMap, revealDoc,
+			[]byte("proof nonce"), []byte("other secret"), pubKeyResolver, loader)
+		require.Error(t, err)
+
+		_, err = proofSuite.SelectiveDisclosure(signedDocMap, revealDoc, []byte("proof nonce"), pubKeyResolver,
+			loader)
+		require.Error(t, err)
+	})
+
+	t.Run("unblind proofs errors", func(t *testing.T) {
+		err := UnblindProofs(tinkCrypto, map[string]interface{}{}, blindingFactor)
+		require.EqualError(t, err, "unblind proofs: document does not have a proof")
+
+		err = UnblindProofs(tinkCrypto, map[string]interface{}{"proof": []interface{}{"invalid"}}, blindingFactor)
+		require.EqualError(t, err, "unblind proofs: proof is not a JSON map")
+
+		err = UnblindProofs(&mockcrypto.Crypto{UnblindErr: errTest}, map[string]interface{}{
+			"proof": map[string]interface{}{
+				"type":       signatureType,
+				"created":    "2021-06-01T10:00:00Z",
+				"proofValue": "c2lnbmF0dXJl",
+			},
+		}, blindingFactor)
+		require.EqualError(t, err, "unblind proofs: test error")
+	})
+
+	t.Run("commit link secret error", func(t *testing.T) {
+		_, _, err := CommitLinkSecret(&mockcrypto.Crypto{BlindMessagesErr: errTest}, linkSecret, 1, nonce, pubKH)
+		require.EqualError(t, err, "commit link secret: test error")
+	})
+}
+
+type statementsCounter struct {
+	count int
+}
+
+func (c *statementsCounter) Sign(data []byte) ([]byte, error) {
+	c.count = len(splitMessageIntoLines(string(data)))
+
+	return []byte("signature"), nil
+}
@@ -16,3 +16,10 @@ func NewG2PublicKeyVerifier() *verifier.PublicKeyVerifier {
 	return verifier.NewPublicKeyVerifier(verifier.NewBBSG2SignatureVerifier(),
 		verifier.WithExactPublicKeyType(g2PubKeyType))
 }
+
+// NewBoundG2PublicKeyVerifier creates a signature verifier that verifies a BbsBlsSignature2020 signature bound to the
+// holder's linkSecret (see BlindSigner) taking Bls12381G2Key2020 public key bytes as input.
+func NewBoundG2PublicKeyVerifier(linkSecret []byte) *verifier.PublicKeyVerifier {
+	return verifier.NewPublicKeyVerifier(verifier.NewBoundBBSG2SignatureVerifier(linkSecret),
+		verifier.WithExactPublicKeyType(g2PubKeyType))
+}
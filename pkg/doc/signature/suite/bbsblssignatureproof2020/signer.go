@@ -34,6 +34,14 @@ type keyResolver interface {
 // (with BbsBlsSignature2020 type).
 func (s *Suite) SelectiveDisclosure(doc map[string]interface{}, revealDoc map[string]interface{},
 	nonce []byte, resolver keyResolver, opts ...jsonld.ProcessorOpts) (map[string]interface{}, error) {
+	return s.SelectiveDisclosureWithLinkSecret(doc, revealDoc, nonce, nil, resolver, opts...)
+}
+
+// SelectiveDisclosureWithLinkSecret creates selective disclosure from the input doc which must have a BBS+ proof
+// (with BbsBlsSignature2020 type) bound to the holder's linkSecret (see bbsblssignature2020.BlindSigner).
+// The link secret is never revealed in the derived proof.
+func (s *Suite) SelectiveDisclosureWithLinkSecret(doc map[string]interface{}, revealDoc map[string]interface{},
+	nonce, linkSecret []byte, resolver keyResolver, opts ...jsonld.ProcessorOpts) (map[string]interface{}, error) {
 	docWithoutProof, rawProofs, err := prepareDocAndProof(doc, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("preparing doc failed: %w", err)
@@ -61,6 +69,10 @@ func (s *Suite) SelectiveDisclosure(doc map[string]interface{}, revealDoc map[st
 			return nil, fmt.Errorf("build verification data: %w", dErr)
 		}
 
+		if linkSecret != nil {
+			verData.blsMessages = append(verData.blsMessages, linkSecret)
+		}
+
 		derivedProof, dErr := generateSignatureProof(blsSignature, resolver, nonce, verData)
 		if dErr != nil {
 			return nil, fmt.Errorf("generate signature proof: %w", dErr)
@@ -383,13 +383,28 @@ func NewBBSG2SignatureVerifier() *BBSG2SignatureVerifier {
 // JWK is not supported.
 type BBSG2SignatureVerifier struct {
 	baseSignatureVerifier
+
+	linkSecret []byte
+}
+
+// NewBoundBBSG2SignatureVerifier creates a new BBSG2SignatureVerifier of BBS+ signatures bound to the holder's
+// linkSecret, which is signed as the last message following the document statements (see BBS+ blind signatures).
+func NewBoundBBSG2SignatureVerifier(linkSecret []byte) *BBSG2SignatureVerifier {
+	return &BBSG2SignatureVerifier{
+		linkSecret: linkSecret,
+	}
 }
 
 // Verify verifies the signature.
 func (v *BBSG2SignatureVerifier) Verify(pubKeyValue *PublicKey, doc, signature []byte) error {
 	bbs := bbs12381g2pub.New()
 
-	return bbs.Verify(splitMessageIntoLines(string(doc), false), signature, pubKeyValue.Value)
+	messages := splitMessageIntoLines(string(doc), false)
+	if v.linkSecret != nil {
+		messages = append(messages, v.linkSecret)
+	}
+
+	return bbs.Verify(messages, signature, pubKeyValue.Value)
 }
 
 // NewBBSG2SignatureProofVerifier creates a new BBSG2SignatureProofVerifier.
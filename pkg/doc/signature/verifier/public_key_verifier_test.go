@@ -10,6 +10,7 @@ import (
 	"crypto"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"testing"
@@ -19,6 +20,7 @@ import (
 	gojose "github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
@@ -457,6 +459,38 @@ _:c14n0 <https://w3id.org/security#verificationMethod> <did:key:zUC724vuGvHpnCGF
 	require.NoError(t, err)
 }
 
+func TestNewBoundBBSG2SignatureVerifier(t *testing.T) {
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	privKeyBytes, err := privKey.Marshal()
+	require.NoError(t, err)
+
+	doc := "statement 1\nstatement 2\n"
+	linkSecret := []byte("link secret")
+	nonce := []byte("nonce")
+	bbs := bbs12381g2pub.New()
+
+	blindContext, blindingFactor, err := bbs.BlindMessages([][]byte{linkSecret}, []int{2}, 3, nonce, pubKeyBytes)
+	require.NoError(t, err)
+
+	blindSig, err := bbs.BlindSign(blindContext, [][]byte{[]byte("statement 1"), []byte("statement 2")},
+		[]int{0, 1}, 3, nonce, privKeyBytes)
+	require.NoError(t, err)
+
+	sig, err := bbs.UnblindSignature(blindSig, blindingFactor)
+	require.NoError(t, err)
+
+	pubKeyValue := &PublicKey{Type: "Bls12381G2Key2020", Value: pubKeyBytes}
+
+	require.NoError(t, NewBoundBBSG2SignatureVerifier(linkSecret).Verify(pubKeyValue, []byte(doc), sig))
+	require.Error(t, NewBoundBBSG2SignatureVerifier([]byte("other secret")).Verify(pubKeyValue, []byte(doc), sig))
+	require.Error(t, NewBBSG2SignatureVerifier().Verify(pubKeyValue, []byte(doc), sig))
+}
+
 //nolint:lll,goconst
 func TestNewBBSG2SignatureProofVerifier(t *testing.T) {
 	// pkBase58 from did:key:zUC724vuGvHpnCGFG1qqpXb81SiBLu3KLSqVzenwEZNPoY35i2Bscb8DLaVwHvRFs6F2NkNNXRcPWvqnPDUd9ukdjLkjZd3u9zzL4wDZDUpkPAatLDGLEYVo8kkAzuAKJQMr7N2
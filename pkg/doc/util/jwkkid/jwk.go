@@ -0,0 +1,255 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwkkid
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
+	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	// jwkUseSig is the JWK 'use' value of signing keys.
+	jwkUseSig = "sig"
+	// jwkUseEnc is the JWK 'use' value of ECDH key wrapping keys.
+	jwkUseEnc = "enc"
+)
+
+// ExportJWK exports the public key of the KMS key referenced by keyID of type kt as a JWK. The JWK's 'kid' is set to
+// the key's RFC 7638 thumbprint (see CreateKID()) and its 'use' is set to 'enc' for ECDH-KW key types or 'sig' for
+// the others.
+func ExportJWK(km kms.KeyManager, keyID string, kt kms.KeyType) (*jose.JWK, error) {
+	keyBytes, err := km.ExportPubKeyBytes(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("exportJWK: failed to export public key bytes: %w", err)
+	}
+
+	jwk, err := BuildJWK(keyBytes, kt)
+	if err != nil {
+		return nil, fmt.Errorf("exportJWK: %w", err)
+	}
+
+	jwk.KeyID, err = CreateKID(keyBytes, kt)
+	if err != nil {
+		return nil, fmt.Errorf("exportJWK: %w", err)
+	}
+
+	jwk.Use = jwkUseSig
+
+	if isECDHKWType(kt) {
+		jwk.Use = jwkUseEnc
+	}
+
+	return jwk, nil
+}
+
+// ExportJWKS exports the public keys of the KMS keys referenced by the keys map (key ID to key type) as a JWK Set.
+// Keys are added to the set in key ID order.
+func ExportJWKS(km kms.KeyManager, keys map[string]kms.KeyType) (*jose.JWKSet, error) {
+	keyIDs := make([]string, 0, len(keys))
+
+	for keyID := range keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+
+	sort.Strings(keyIDs)
+
+	jwks := &jose.JWKSet{Keys: []jose.JWK{}}
+
+	for _, keyID := range keyIDs {
+		jwk, err := ExportJWK(km, keyID, keys[keyID])
+		if err != nil {
+			return nil, fmt.Errorf("exportJWKS: key '%s': %w", keyID, err)
+		}
+
+		jwks.Keys = append(jwks.Keys, *jwk)
+	}
+
+	return jwks, nil
+}
+
+// PubKeyBytesFromJWK converts jwk into public key bytes in the format of KMS.ExportPubKeyBytes() along with the
+// matching KMS key type. NIST P and secp256k1 curve EC keys with 'use' set to 'enc' and X25519 keys are returned as
+// ECDH-KW keys, other EC keys are returned as IEEE-P1363 ECDSA keys.
+func PubKeyBytesFromJWK(jwk *jose.JWK) ([]byte, kms.KeyType, error) {
+	if jwk == nil || jwk.Key == nil {
+		return nil, "", errors.New("pubKeyBytesFromJWK: empty jwk")
+	}
+
+	switch key := jwk.Public().Key.(type) {
+	case ed25519.PublicKey:
+		return key, kms.ED25519Type, nil
+	case *ecdsa.PublicKey:
+		return ecPubKeyBytes(key, jwk.KeyID, jwk.Use)
+	case *rsa.PublicKey:
+		kt := kms.RSARS256Type
+		if jwk.Algorithm == rsaAlgorithms[kms.RSAPS256Type] {
+			kt = kms.RSAPS256Type
+		}
+
+		return x509.MarshalPKCS1PublicKey(key), kt, nil
+	}
+
+	// X25519 and BLS12-381 G2 keys are raw []byte keys that go-jose can't extract in Public().
+	switch {
+	case strings.EqualFold(jwk.Crv, "X25519"):
+		x, ok := jwk.Key.([]byte)
+		if !ok {
+			return nil, "", errors.New("pubKeyBytesFromJWK: invalid X25519 key")
+		}
+
+		keyBytes, err := json.Marshal(&cryptoapi.PublicKey{
+			KID:   jwk.KeyID,
+			X:     x,
+			Curve: commonpb.EllipticCurveType_CURVE25519.String(),
+			Type:  ecdhpb.KeyType_OKP.String(),
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("pubKeyBytesFromJWK: %w", err)
+		}
+
+		return keyBytes, kms.X25519ECDHKWType, nil
+	case strings.EqualFold(jwk.Crv, "Bls12381g2"):
+		bbsKey, ok := jwk.Key.([]byte)
+		if !ok {
+			return nil, "", errors.New("pubKeyBytesFromJWK: invalid BLS12381G2 key")
+		}
+
+		return bbsKey, kms.BLS12381G2Type, nil
+	default:
+		return nil, "", fmt.Errorf("pubKeyBytesFromJWK: %w: kty '%s', crv '%s'", errInvalidKeyType, jwk.Kty, jwk.Crv)
+	}
+}
+
+// ImportJWK converts jwk into a public key handle using km.PubKeyBytesToHandle(), or keyio.PublicKeyToKeysetHandle()
+// (keyio.PublicKeyToKeysetHandleXChacha() for X25519) for ECDH-KW keys which can be used as sender keys in Authcrypt
+// (ECDH-1PU). The key is not stored in km.
+// Returns the key's thumbprint KID, the public key handle and the key's KMS key type.
+func ImportJWK(km kms.KeyManager, jwk *jose.JWK) (string, interface{}, kms.KeyType, error) {
+	keyBytes, kt, err := PubKeyBytesFromJWK(jwk)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("importJWK: %w", err)
+	}
+
+	kid, err := CreateKID(keyBytes, kt)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("importJWK: %w", err)
+	}
+
+	var kh interface{}
+
+	if isECDHKWType(kt) {
+		pubKey, e := unmarshalECDHKey(keyBytes)
+		if e != nil {
+			return "", nil, "", fmt.Errorf("importJWK: %w", e)
+		}
+
+		if kt == kms.X25519ECDHKWType {
+			kh, err = keyio.PublicKeyToKeysetHandleXChacha(pubKey)
+		} else {
+			kh, err = keyio.PublicKeyToKeysetHandle(pubKey)
+		}
+	} else {
+		kh, err = km.PubKeyBytesToHandle(keyBytes, kt)
+	}
+
+	if err != nil {
+		return "", nil, "", fmt.Errorf("importJWK: failed to create public key handle: %w", err)
+	}
+
+	return kid, kh, kt, nil
+}
+
+// ImportJWKS parses the marshalled JWK Set jwksBytes and converts each of its keys into a public key handle (see
+// ImportJWK()). Returns the handles mapped by the JWK 'kid' or by the key's thumbprint KID if the JWK has no 'kid'.
+func ImportJWKS(km kms.KeyManager, jwksBytes []byte) (map[string]interface{}, error) {
+	jwks, err := jose.ParseJWKSet(jwksBytes)
+	if err != nil {
+		return nil, fmt.Errorf("importJWKS: %w", err)
+	}
+
+	handles := make(map[string]interface{}, len(jwks.Keys))
+
+	for i := range jwks.Keys {
+		kid, kh, _, err := ImportJWK(km, &jwks.Keys[i])
+		if err != nil {
+			return nil, fmt.Errorf("importJWKS: key #%d: %w", i, err)
+		}
+
+		if jwks.Keys[i].KeyID != "" {
+			kid = jwks.Keys[i].KeyID
+		}
+
+		handles[kid] = kh
+	}
+
+	return handles, nil
+}
+
+func ecPubKeyBytes(key *ecdsa.PublicKey, kid, use string) ([]byte, kms.KeyType, error) {
+	var (
+		ecdsaType, ecdhType kms.KeyType
+		curve               commonpb.EllipticCurveType
+	)
+
+	switch key.Curve {
+	case btcec.S256():
+		ecdsaType, ecdhType, curve = kms.ECDSASecp256k1TypeIEEEP1363, kms.Secp256k1ECDHKWType, cryptoutil.Secp256k1CurveType
+	case elliptic.P256():
+		ecdsaType, ecdhType, curve = kms.ECDSAP256TypeIEEEP1363, kms.NISTP256ECDHKWType, commonpb.EllipticCurveType_NIST_P256
+	case elliptic.P384():
+		ecdsaType, ecdhType, curve = kms.ECDSAP384TypeIEEEP1363, kms.NISTP384ECDHKWType, commonpb.EllipticCurveType_NIST_P384
+	case elliptic.P521():
+		ecdsaType, ecdhType, curve = kms.ECDSAP521TypeIEEEP1363, kms.NISTP521ECDHKWType, commonpb.EllipticCurveType_NIST_P521
+	default:
+		return nil, "", fmt.Errorf("pubKeyBytesFromJWK: %w: curve '%s'", errInvalidKeyType, key.Curve.Params().Name)
+	}
+
+	if use != jwkUseEnc {
+		return elliptic.Marshal(key.Curve, key.X, key.Y), ecdsaType, nil
+	}
+
+	keyBytes, err := json.Marshal(&cryptoapi.PublicKey{
+		KID:   kid,
+		X:     key.X.Bytes(),
+		Y:     key.Y.Bytes(),
+		Curve: cryptoutil.CurveName(curve),
+		Type:  ecdhpb.KeyType_EC.String(),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("pubKeyBytesFromJWK: %w", err)
+	}
+
+	return keyBytes, ecdhType, nil
+}
+
+func isECDHKWType(kt kms.KeyType) bool {
+	switch kt {
+	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType, kms.Secp256k1ECDHKWType,
+		kms.X25519ECDHKWType:
+		return true
+	default:
+		return false
+	}
+}
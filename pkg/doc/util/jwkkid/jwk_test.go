@@ -0,0 +1,272 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwkkid
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+)
+
+func TestExportImportJWKS(t *testing.T) {
+	keys := createTestKeys(t)
+
+	km := &keysKMS{pubKeys: map[string][]byte{}}
+	keyTypes := map[string]kms.KeyType{}
+
+	for kt, keyBytes := range keys {
+		km.pubKeys[string(kt)] = keyBytes
+		keyTypes[string(kt)] = kt
+	}
+
+	jwks, err := ExportJWKS(km, keyTypes)
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, len(keys))
+
+	jwksBytes, err := json.Marshal(jwks)
+	require.NoError(t, err)
+
+	parsed, err := jose.ParseJWKSet(jwksBytes)
+	require.NoError(t, err)
+
+	for kt, keyBytes := range keys {
+		kt, keyBytes := kt, keyBytes
+
+		t.Run(string(kt), func(t *testing.T) {
+			expectedKID, err := CreateKID(keyBytes, kt)
+			require.NoError(t, err)
+
+			jwk, ok := parsed.Key(expectedKID)
+			require.True(t, ok)
+
+			if isECDHKWType(kt) {
+				require.Equal(t, "enc", jwk.Use)
+			} else {
+				require.Equal(t, "sig", jwk.Use)
+			}
+
+			importedBytes, importedKT, err := PubKeyBytesFromJWK(jwk)
+			require.NoError(t, err)
+			require.Equal(t, kt, importedKT)
+
+			if isECDHKWType(kt) {
+				requireSameECDHKey(t, keyBytes, importedBytes)
+			} else {
+				require.Equal(t, keyBytes, importedBytes)
+			}
+
+			kid, kh, importedKT, err := ImportJWK(km, jwk)
+			require.NoError(t, err)
+			require.Equal(t, expectedKID, kid)
+			require.Equal(t, kt, importedKT)
+
+			if isECDHKWType(kt) {
+				ecdhKH, ok := kh.(*keyset.Handle)
+				require.True(t, ok)
+
+				typeURL := ecdhKH.KeysetInfo().KeyInfo[0].TypeUrl
+				if kt == kms.X25519ECDHKWType {
+					require.Contains(t, typeURL, "X25519EcdhKwPublicKey")
+				} else {
+					require.Contains(t, typeURL, "NistPEcdhKwPublicKey")
+				}
+			}
+		})
+	}
+
+	t.Run("import JWKS", func(t *testing.T) {
+		handles, err := ImportJWKS(km, jwksBytes)
+		require.NoError(t, err)
+		require.Len(t, handles, len(keys))
+
+		for _, jwk := range jwks.Keys {
+			require.Contains(t, handles, jwk.KeyID)
+		}
+	})
+}
+
+func TestExportJWKFailure(t *testing.T) {
+	t.Run("export public key failure", func(t *testing.T) {
+		_, err := ExportJWK(&mockkms.KeyManager{ExportPubKeyBytesErr: errors.New("export error")}, "kid",
+			kms.ED25519Type)
+		require.EqualError(t, err, "exportJWK: failed to export public key bytes: export error")
+
+		_, err = ExportJWKS(&mockkms.KeyManager{ExportPubKeyBytesErr: errors.New("export error")},
+			map[string]kms.KeyType{"kid": kms.ED25519Type})
+		require.EqualError(t, err, "exportJWKS: key 'kid': exportJWK: failed to export public key bytes: "+
+			"export error")
+	})
+
+	t.Run("invalid key type", func(t *testing.T) {
+		_, err := ExportJWK(&mockkms.KeyManager{ExportPubKeyBytesValue: []byte("key")}, "kid",
+			kms.AES128GCMType)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exportJWK")
+	})
+}
+
+func TestImportJWKFailure(t *testing.T) {
+	t.Run("empty jwk", func(t *testing.T) {
+		_, _, _, err := ImportJWK(&mockkms.KeyManager{}, nil)
+		require.EqualError(t, err, "importJWK: pubKeyBytesFromJWK: empty jwk")
+	})
+
+	t.Run("unsupported jwk", func(t *testing.T) {
+		jwk := &jose.JWK{Crv: "unknown", Kty: "OKP"}
+		jwk.Key = []byte("key")
+
+		_, _, _, err := ImportJWK(&mockkms.KeyManager{}, jwk)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kty 'OKP', crv 'unknown'")
+	})
+
+	t.Run("public key handle failure", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := jose.JWKFromPublicKey(pubKey)
+		require.NoError(t, err)
+
+		_, _, _, err = ImportJWK(&mockkms.KeyManager{PubKeyBytesToHandleErr: errors.New("handle error")}, jwk)
+		require.EqualError(t, err, "importJWK: failed to create public key handle: handle error")
+	})
+
+	t.Run("invalid JWKS", func(t *testing.T) {
+		_, err := ImportJWKS(&mockkms.KeyManager{}, []byte("not json"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "importJWKS")
+
+		_, err = ImportJWKS(&mockkms.KeyManager{PubKeyBytesToHandleErr: errors.New("handle error")},
+			[]byte(`{"keys":[{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`))
+		require.EqualError(t, err, "importJWKS: key #0: importJWK: failed to create public key handle: "+
+			"handle error")
+	})
+}
+
+func createTestKeys(t *testing.T) map[kms.KeyType][]byte {
+	t.Helper()
+
+	keys := map[kms.KeyType][]byte{}
+
+	edPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys[kms.ED25519Type] = edPubKey
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys[kms.ECDSAP256TypeIEEEP1363] = elliptic.Marshal(p256Key.Curve, p256Key.X, p256Key.Y)
+
+	secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys[kms.ECDSASecp256k1TypeIEEEP1363] = elliptic.Marshal(secp256k1Key.Curve, secp256k1Key.X, secp256k1Key.Y)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys[kms.RSAPS256Type] = x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)
+
+	ecdhKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	keys[kms.NISTP384ECDHKWType] = marshalECDHKey(t, &cryptoapi.PublicKey{
+		KID:   "ecdh-kid",
+		X:     ecdhKey.X.Bytes(),
+		Y:     ecdhKey.Y.Bytes(),
+		Curve: commonpb.EllipticCurveType_NIST_P384.String(),
+		Type:  ecdhpb.KeyType_EC.String(),
+	})
+
+	secp256k1ECDHKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys[kms.Secp256k1ECDHKWType] = marshalECDHKey(t, &cryptoapi.PublicKey{
+		KID:   "secp256k1-kid",
+		X:     secp256k1ECDHKey.X.Bytes(),
+		Y:     secp256k1ECDHKey.Y.Bytes(),
+		Curve: "SECP256K1",
+		Type:  ecdhpb.KeyType_EC.String(),
+	})
+
+	x25519Key := make([]byte, 32)
+	_, err = rand.Read(x25519Key)
+	require.NoError(t, err)
+
+	keys[kms.X25519ECDHKWType] = marshalECDHKey(t, &cryptoapi.PublicKey{
+		KID:   "x25519-kid",
+		X:     x25519Key,
+		Curve: commonpb.EllipticCurveType_CURVE25519.String(),
+		Type:  ecdhpb.KeyType_OKP.String(),
+	})
+
+	blsKey := make([]byte, 96)
+	_, err = rand.Read(blsKey)
+	require.NoError(t, err)
+
+	keys[kms.BLS12381G2Type] = blsKey
+
+	return keys
+}
+
+func marshalECDHKey(t *testing.T, pubKey *cryptoapi.PublicKey) []byte {
+	t.Helper()
+
+	keyBytes, err := json.Marshal(pubKey)
+	require.NoError(t, err)
+
+	return keyBytes
+}
+
+func requireSameECDHKey(t *testing.T, expected, actual []byte) {
+	t.Helper()
+
+	expectedKey, err := unmarshalECDHKey(expected)
+	require.NoError(t, err)
+
+	actualKey, err := unmarshalECDHKey(actual)
+	require.NoError(t, err)
+
+	require.Equal(t, expectedKey.X, actualKey.X)
+	require.Equal(t, expectedKey.Y, actualKey.Y)
+	require.Equal(t, expectedKey.Curve, actualKey.Curve)
+	require.Equal(t, expectedKey.Type, actualKey.Type)
+}
+
+// keysKMS is a mock KMS exporting public keys by key ID.
+type keysKMS struct {
+	mockkms.KeyManager
+	pubKeys map[string][]byte
+}
+
+func (k *keysKMS) ExportPubKeyBytes(keyID string) ([]byte, error) {
+	keyBytes, ok := k.pubKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key '%s' not found", keyID)
+	}
+
+	return keyBytes, nil
+}
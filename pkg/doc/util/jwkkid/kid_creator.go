@@ -18,7 +18,7 @@ import (
 	"fmt"
 	"math/big"
 
-	hybrid "github.com/google/tink/go/hybrid/subtle"
+	"github.com/btcsuite/btcd/btcec"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -26,8 +26,16 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
+const secp256k1KeySize = 32
+
 var errInvalidKeyType = errors.New("key type is not supported")
 
+// rsaAlgorithms maps RSA KMS key types to their JWA signature algorithm.
+var rsaAlgorithms = map[kms.KeyType]string{ // nolint:gochecknoglobals
+	kms.RSARS256Type: "RS256",
+	kms.RSAPS256Type: "PS256",
+}
+
 // CreateKID creates a KID value based on the marshalled keyBytes of type kt. This function should be called for
 // asymmetric public keys only (ECDSA DER or IEEE-P1363, ED25519, X25519, BLS12381G2).
 // returns:
@@ -53,6 +61,20 @@ func CreateKID(keyBytes []byte, kt kms.KeyType) (string, error) {
 		}
 
 		return ed25519KID, nil
+	case kms.ECDSASecp256k1TypeIEEEP1363: // secp256k1 curve is not supported by go-jose JWK thumbprint.
+		secp256k1KID, err := createSecp256K1KID(keyBytes)
+		if err != nil {
+			return "", fmt.Errorf("createKID: %w", err)
+		}
+
+		return secp256k1KID, nil
+	case kms.Secp256k1ECDHKWType: // secp256k1 curve is not supported by go-jose JWK thumbprint.
+		secp256k1KID, err := createSecp256K1ECDHKID(keyBytes)
+		if err != nil {
+			return "", fmt.Errorf("createKID: %w", err)
+		}
+
+		return secp256k1KID, nil
 	case kms.BLS12381G2Type: // BBS+ as JWK thumbprint.
 		bbsKID, err := createBLS12381G2KID(keyBytes)
 		if err != nil {
@@ -88,13 +110,13 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jose.JWK, error) {
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdsa DER key: %w", err)
 		}
-		// TODO remove `case kms.ED25519Type` in CreateKID() and uncomment below case when go-jose fixes Ed25519
-		//      JWK thumbprint. Also remove `createED25519KID(keyBytes []byte)` function further below.
-	// case kms.ED25519Type:
-	//	jwk, err = jose.JWKFromPublicKey(ed25519.PublicKey(keyBytes))
-	//	if err != nil {
-	//		return nil, fmt.Errorf("buildJWK: failed to build JWK from ed25519 key: %w", err)
-	//	}
+		// TODO remove `case kms.ED25519Type` in CreateKID() when go-jose fixes Ed25519 JWK thumbprint. Also remove
+		//      `createED25519KID(keyBytes []byte)` function further below.
+	case kms.ED25519Type:
+		jwk, err = jose.JWKFromPublicKey(ed25519.PublicKey(keyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from ed25519 key: %w", err)
+		}
 	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
 		c := getCurveByKMSKeyType(kt)
 		x, y := elliptic.Unmarshal(c, keyBytes)
@@ -109,11 +131,36 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jose.JWK, error) {
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdsa key in IEEE1363 format: %w", err)
 		}
-	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		x, y := elliptic.Unmarshal(btcec.S256(), keyBytes)
+		if x == nil {
+			return nil, errors.New("buildJWK: failed to unmarshal secp256k1 key in IEEE1363 format")
+		}
+
+		jwk, err = jose.JWKFromPublicKey(&ecdsa.PublicKey{Curve: btcec.S256(), X: x, Y: y})
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from secp256k1 key: %w", err)
+		}
+	case kms.RSARS256Type, kms.RSAPS256Type:
+		jwk, err = generateJWKFromRSA(keyBytes, kt)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from rsa key: %w", err)
+		}
+	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType, kms.Secp256k1ECDHKWType:
 		jwk, err = generateJWKFromECDH(keyBytes)
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdh key: %w", err)
 		}
+	case kms.X25519ECDHKWType:
+		jwk, err = generateJWKFromX25519ECDH(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdh key: %w", err)
+		}
+	case kms.BLS12381G2Type:
+		jwk, err = jose.JWKFromBLS12381G2Key(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from bls12381g2 key: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("buildJWK: %w: '%s'", errInvalidKeyType, kt)
 	}
@@ -136,7 +183,7 @@ func generateJWKFromECDH(keyBytes []byte) (*jose.JWK, error) {
 		return nil, fmt.Errorf("generateJWKFromECDH: %w", err)
 	}
 
-	c, err := hybrid.GetCurve(compositeKey.Curve)
+	c, err := cryptoutil.GetCurve(compositeKey.Curve)
 	if err != nil {
 		return nil, fmt.Errorf("generateJWKFromECDH: failed to get Curve for ECDH key: %w", err)
 	}
@@ -150,6 +197,31 @@ func generateJWKFromECDH(keyBytes []byte) (*jose.JWK, error) {
 	return jose.JWKFromPublicKey(pubKey)
 }
 
+func generateJWKFromX25519ECDH(keyBytes []byte) (*jose.JWK, error) {
+	compositeKey, err := unmarshalECDHKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("generateJWKFromX25519ECDH: %w", err)
+	}
+
+	return jose.JWEFromX25519Key(compositeKey.X)
+}
+
+func generateJWKFromRSA(keyBytes []byte, kt kms.KeyType) (*jose.JWK, error) {
+	pubKey, err := x509.ParsePKCS1PublicKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("generateJWKFromRSA: failed to parse rsa key in PKCS1 format: %w", err)
+	}
+
+	jwk, err := jose.JWKFromPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("generateJWKFromRSA: %w", err)
+	}
+
+	jwk.Algorithm = rsaAlgorithms[kt]
+
+	return jwk, nil
+}
+
 func getCurveByKMSKeyType(kt kms.KeyType) elliptic.Curve {
 	switch kt {
 	case kms.ECDSAP256TypeIEEEP1363:
@@ -226,6 +298,44 @@ func buildX25519JWK(keyBytes []byte) (string, error) {
 	return jwk, nil
 }
 
+func createSecp256K1KID(keyBytes []byte) (string, error) {
+	const secp256k1ThumbprintTemplate = `{"crv":"secp256k1","kty":"EC","x":"%s","y":"%s"}`
+
+	x, y := elliptic.Unmarshal(btcec.S256(), keyBytes)
+	if x == nil {
+		return "", errors.New("createSecp256K1KID: invalid secp256k1 key")
+	}
+
+	jwk := fmt.Sprintf(secp256k1ThumbprintTemplate,
+		base64.RawURLEncoding.EncodeToString(padBytes(x.Bytes(), secp256k1KeySize)),
+		base64.RawURLEncoding.EncodeToString(padBytes(y.Bytes(), secp256k1KeySize)))
+
+	thumbprint := sha256Sum(jwk)
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+func createSecp256K1ECDHKID(marshalledKey []byte) (string, error) {
+	compositeKey, err := unmarshalECDHKey(marshalledKey)
+	if err != nil {
+		return "", fmt.Errorf("createSecp256K1ECDHKID: %w", err)
+	}
+
+	keyBytes := elliptic.Marshal(btcec.S256(), new(big.Int).SetBytes(compositeKey.X),
+		new(big.Int).SetBytes(compositeKey.Y))
+
+	kid, err := createSecp256K1KID(keyBytes)
+	if err != nil {
+		return "", fmt.Errorf("createSecp256K1ECDHKID: %w", err)
+	}
+
+	return kid, nil
+}
+
+func padBytes(b []byte, size int) []byte {
+	return append(make([]byte, size-len(b)), b...)
+}
+
 func createBLS12381G2KID(keyBytes []byte) (string, error) {
 	const (
 		bls12381g2ThumbprintTemplate = `{"crv":"Bls12381g2","kty":"OKP","x":"%s"}`
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	"github.com/stretchr/testify/require"
 
@@ -226,3 +227,32 @@ func TestCreateBLS12381G2KID(t *testing.T) {
 	_, err = CreateKID(append(pubKeyBytes, []byte("larger key")...), kms.BLS12381G2Type)
 	require.EqualError(t, err, "createKID: invalid BBS+ key")
 }
+
+func TestCreateSecp256K1ECDHKID(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	ecdhKey, err := json.Marshal(&cryptoapi.PublicKey{
+		X:     privKey.X.Bytes(),
+		Y:     privKey.Y.Bytes(),
+		Curve: "SECP256K1",
+		Type:  ecdhpb.KeyType_EC.String(),
+	})
+	require.NoError(t, err)
+
+	kid, err := CreateKID(ecdhKey, kms.Secp256k1ECDHKWType)
+	require.NoError(t, err)
+
+	// the KID of a secp256k1 ECDH-KW key is the thumbprint of the same key as a signing key.
+	signingKID, err := CreateKID(elliptic.Marshal(btcec.S256(), privKey.X, privKey.Y), kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+	require.Equal(t, signingKID, kid)
+
+	jwk, err := BuildJWK(ecdhKey, kms.Secp256k1ECDHKWType)
+	require.NoError(t, err)
+	require.Equal(t, "secp256k1", jwk.Crv)
+
+	_, err = CreateKID([]byte("bad key"), kms.Secp256k1ECDHKWType)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "createKID: createSecp256K1ECDHKID: unmarshalECDHKey")
+}
@@ -0,0 +1,203 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// CredentialVerificationResult holds the result of the verification of a credential by VerifyCredentials().
+type CredentialVerificationResult struct {
+	// Credential is the parsed credential, it is nil if the verification failed.
+	Credential *Credential
+	// Err is the error of the verification, it is nil if the credential is valid.
+	Err error
+}
+
+// CredentialsError is returned by ParsePresentation with batch verification (see WithPresBatchVerification())
+// when credentials embedded into the presentation are not valid.
+type CredentialsError struct {
+	// Errs holds the error of each credential, at the same index as the credential in the presentation.
+	// It is nil for valid credentials.
+	Errs []error
+}
+
+func (e *CredentialsError) Error() string {
+	var msgs []string
+
+	for i, err := range e.Errs {
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("decode credential %d of presentation: %v", i, err))
+		}
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the error of the first invalid credential.
+func (e *CredentialsError) Unwrap() error {
+	for _, err := range e.Errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// batchVerificationOpts holds options for the batch verification of Verifiable Credentials.
+type batchVerificationOpts struct {
+	maxConcurrency int
+	credentialOpts []CredentialOpt
+}
+
+// BatchVerificationOpt is the batch verification option.
+type BatchVerificationOpt func(opts *batchVerificationOpts)
+
+// WithBatchMaxConcurrency sets the maximum number of credentials verified in parallel.
+// By default, it is the number of CPUs.
+func WithBatchMaxConcurrency(maxConcurrency int) BatchVerificationOpt {
+	return func(opts *batchVerificationOpts) {
+		opts.maxConcurrency = maxConcurrency
+	}
+}
+
+// WithBatchCredentialOpts defines the options used to parse each credential (see ParseCredential()).
+func WithBatchCredentialOpts(opts ...CredentialOpt) BatchVerificationOpt {
+	return func(batchOpts *batchVerificationOpts) {
+		batchOpts.credentialOpts = append(batchOpts.credentialOpts, opts...)
+	}
+}
+
+// VerifyCredentials parses and verifies the proofs of credentials in parallel, with a bounded concurrency.
+// Public keys shared by several credentials are fetched only once and JSON-LD documents are loaded using
+// a single document loader.
+// It returns the result of each credential, at the same index as the credential in vcs.
+func VerifyCredentials(vcs [][]byte, opts ...BatchVerificationOpt) []CredentialVerificationResult {
+	batchOpts := &batchVerificationOpts{
+		maxConcurrency: runtime.NumCPU(),
+	}
+
+	for _, opt := range opts {
+		opt(batchOpts)
+	}
+
+	vcOpts := getCredentialOpts(batchOpts.credentialOpts)
+
+	credentialOpts := make([]CredentialOpt, 0, len(batchOpts.credentialOpts)+2) //nolint:gomnd
+	credentialOpts = append(credentialOpts, batchOpts.credentialOpts...)
+	credentialOpts = append(credentialOpts, withBatchSharedOpts(vcOpts.jsonldDocumentLoader,
+		vcOpts.publicKeyFetcher)...)
+
+	results := make([]CredentialVerificationResult, len(vcs))
+
+	runBatch(len(vcs), batchOpts.maxConcurrency, func(i int) {
+		results[i].Credential, results[i].Err = ParseCredential(vcs[i], credentialOpts...)
+	})
+
+	return results
+}
+
+// withBatchSharedOpts returns the credential options which make the document loader and the public key fetcher
+// safe to be shared by the credentials verified in parallel.
+func withBatchSharedOpts(loader ld.DocumentLoader, fetcher PublicKeyFetcher) []CredentialOpt {
+	opts := []CredentialOpt{WithJSONLDDocumentLoader(newSyncDocumentLoader(loader))}
+
+	if fetcher != nil {
+		opts = append(opts, WithPublicKeyFetcher(singleFetchPublicKeyFetcher(fetcher)))
+	}
+
+	return opts
+}
+
+// runBatch calls fn with indexes from 0 to n-1, running at most maxConcurrency calls in parallel.
+func runBatch(n, maxConcurrency int, fn func(i int)) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, maxConcurrency)
+
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+type publicKeyFetchResult struct {
+	once   sync.Once
+	pubKey *verifier.PublicKey
+	err    error
+}
+
+// singleFetchPublicKeyFetcher wraps fetcher so that each public key is fetched once, even by concurrent callers.
+func singleFetchPublicKeyFetcher(fetcher PublicKeyFetcher) PublicKeyFetcher {
+	var mutex sync.Mutex
+
+	results := make(map[[2]string]*publicKeyFetchResult)
+
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		mutex.Lock()
+
+		result, ok := results[[2]string{issuerID, keyID}]
+		if !ok {
+			result = &publicKeyFetchResult{}
+			results[[2]string{issuerID, keyID}] = result
+		}
+
+		mutex.Unlock()
+
+		result.once.Do(func() {
+			result.pubKey, result.err = fetcher(issuerID, keyID)
+		})
+
+		return result.pubKey, result.err
+	}
+}
+
+// syncDocumentLoader serializes the calls to a JSON-LD document loader which is not safe for concurrent use
+// (eg ld.CachingDocumentLoader).
+type syncDocumentLoader struct {
+	mutex  sync.Mutex
+	loader ld.DocumentLoader
+}
+
+func newSyncDocumentLoader(loader ld.DocumentLoader) *syncDocumentLoader {
+	if l, ok := loader.(*syncDocumentLoader); ok {
+		return l
+	}
+
+	return &syncDocumentLoader{loader: loader}
+}
+
+func (l *syncDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.loader.LoadDocument(u)
+}
@@ -0,0 +1,253 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const batchCredentialsCount = 12
+
+//nolint:funlen
+func TestVerifyCredentials(t *testing.T) {
+	edSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ecSigner, err := newCryptoSigner(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	ecJWK, err := jose.JWKFromPublicKey(ecSigner.PublicKey())
+	require.NoError(t, err)
+
+	edSuite := ed25519signature2018.New(suite.WithSigner(edSigner))
+	ecSuite := jsonwebsignature2020.New(suite.WithSigner(ecSigner))
+
+	publicKeys := map[string]*sigverifier.PublicKey{
+		"ed": {Type: kms.ED25519, Value: edSigner.PublicKeyBytes()},
+		"ec": {Type: "JwsVerificationKey2020", Value: ecSigner.PublicKeyBytes(), JWK: ecJWK},
+	}
+
+	var fetches int32
+
+	fetcher := func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+		atomic.AddInt32(&fetches, 1)
+
+		for k, pubKey := range publicKeys {
+			if strings.HasSuffix(keyID, k) {
+				return pubKey, nil
+			}
+		}
+
+		return nil, fmt.Errorf("public key %s of %s not found", keyID, issuerID)
+	}
+
+	vcs := make([][]byte, batchCredentialsCount)
+
+	for i := range vcs {
+		vc, err := parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+
+		vc.ID = fmt.Sprintf("http://example.edu/credentials/%d", i)
+
+		ldpContext := &LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   edSuite,
+			VerificationMethod:      "did:example:123456#ed",
+		}
+
+		if i%2 == 1 {
+			ldpContext = &LinkedDataProofContext{
+				SignatureType:           "JsonWebSignature2020",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   ecSuite,
+				VerificationMethod:      "did:example:123456#ec",
+			}
+		}
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(testDocumentLoader))
+		require.NoError(t, err)
+
+		vcs[i], err = json.Marshal(vc)
+		require.NoError(t, err)
+	}
+
+	// tamper the content of a credential after signing it.
+	vcs[3] = []byte(strings.Replace(string(vcs[3]), "Example University", "Other University", 1))
+
+	t.Run("verify credentials in parallel", func(t *testing.T) {
+		atomic.StoreInt32(&fetches, 0)
+
+		results := VerifyCredentials(vcs, WithBatchMaxConcurrency(4),
+			WithBatchCredentialOpts(WithPublicKeyFetcher(fetcher), WithJSONLDDocumentLoader(testDocumentLoader)))
+		require.Len(t, results, len(vcs))
+
+		for i, result := range results {
+			if i == 3 {
+				require.Error(t, result.Err)
+				require.Contains(t, result.Err.Error(), "check embedded proof")
+				require.Nil(t, result.Credential)
+
+				continue
+			}
+
+			require.NoError(t, result.Err)
+			require.NotNil(t, result.Credential)
+			require.Equal(t, fmt.Sprintf("http://example.edu/credentials/%d", i), result.Credential.ID)
+		}
+
+		// keys are resolved once.
+		require.EqualValues(t, len(publicKeys), atomic.LoadInt32(&fetches))
+	})
+
+	t.Run("verify credentials with default concurrency", func(t *testing.T) {
+		results := VerifyCredentials(vcs[:2],
+			WithBatchCredentialOpts(WithPublicKeyFetcher(fetcher), WithJSONLDDocumentLoader(testDocumentLoader)))
+		require.Len(t, results, 2)
+		require.NoError(t, results[0].Err)
+		require.NoError(t, results[1].Err)
+	})
+
+	t.Run("verify credentials with key resolution failure", func(t *testing.T) {
+		var failedFetches int32
+
+		results := VerifyCredentials(vcs, WithBatchCredentialOpts(
+			WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+				atomic.AddInt32(&failedFetches, 1)
+
+				return nil, errors.New("resolution failed")
+			}),
+			WithJSONLDDocumentLoader(testDocumentLoader)))
+
+		for _, result := range results {
+			require.Error(t, result.Err)
+			require.Contains(t, result.Err.Error(), "resolution failed")
+		}
+
+		require.EqualValues(t, len(publicKeys), atomic.LoadInt32(&failedFetches))
+	})
+
+	t.Run("parse presentation with batch verification", func(t *testing.T) {
+		var creds []interface{}
+
+		for _, vc := range vcs {
+			var vcMap map[string]interface{}
+
+			require.NoError(t, json.Unmarshal(vc, &vcMap))
+
+			creds = append(creds, vcMap)
+		}
+
+		vpBytes, err := json.Marshal(map[string]interface{}{
+			"@context":             []string{ContextURI},
+			"type":                 []string{VPType},
+			"verifiableCredential": creds,
+		})
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(vpBytes, WithPresPublicKeyFetcher(fetcher), WithPresBatchVerification(4),
+			WithPresEmbeddedCredentialProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode credential 3 of presentation: check embedded proof")
+
+		var credentialsErr *CredentialsError
+
+		require.True(t, errors.As(err, &credentialsErr))
+		require.Len(t, credentialsErr.Errs, len(vcs))
+
+		for i, e := range credentialsErr.Errs {
+			if i == 3 {
+				require.Error(t, e)
+
+				continue
+			}
+
+			require.NoError(t, e)
+		}
+
+		// the proofs of the credentials are checked the same way without batch verification.
+		_, err = newTestPresentation(vpBytes, WithPresPublicKeyFetcher(fetcher), WithPresEmbeddedCredentialProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+
+		// and are not checked without WithPresEmbeddedCredentialProofCheck(), in both modes.
+		for _, opts := range [][]PresentationOpt{
+			{WithPresPublicKeyFetcher(fetcher)},
+			{WithPresPublicKeyFetcher(fetcher), WithPresBatchVerification(4)},
+		} {
+			vp, e := newTestPresentation(vpBytes, opts...)
+			require.NoError(t, e)
+			require.Len(t, vp.Credentials(), len(vcs))
+		}
+
+		vpBytes, err = json.Marshal(map[string]interface{}{
+			"@context":             []string{ContextURI},
+			"type":                 []string{VPType},
+			"verifiableCredential": append(creds[:3:3], creds[4:]...),
+		})
+		require.NoError(t, err)
+
+		vp, err := newTestPresentation(vpBytes, WithPresPublicKeyFetcher(fetcher), WithPresBatchVerification(4),
+			WithPresEmbeddedCredentialProofCheck())
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), len(vcs)-1)
+		require.Equal(t, creds[0], vp.Credentials()[0])
+	})
+}
+
+func TestRunBatch(t *testing.T) {
+	for _, maxConcurrency := range []int{0, 1, 3} {
+		var (
+			mutex              sync.Mutex
+			active, maxActive  int
+			calls              = make([]bool, 10)
+			expectedMaxActives = maxConcurrency
+		)
+
+		if expectedMaxActives < 1 {
+			expectedMaxActives = 1
+		}
+
+		runBatch(len(calls), maxConcurrency, func(i int) {
+			mutex.Lock()
+			active++
+
+			if active > maxActive {
+				maxActive = active
+			}
+
+			calls[i] = true
+			mutex.Unlock()
+
+			mutex.Lock()
+			active--
+			mutex.Unlock()
+		})
+
+		require.LessOrEqual(t, maxActive, expectedMaxActives)
+
+		for _, called := range calls {
+			require.True(t, called)
+		}
+	}
+}
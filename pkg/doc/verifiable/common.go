@@ -22,6 +22,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
@@ -85,6 +86,19 @@ func NewDIDKeyResolver(vdr vdrapi.Registry) *DIDKeyResolver {
 }
 
 func (r *DIDKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.PublicKey, error) {
+	if didURL, ok := keyDIDURL(issuerDID, keyID); ok {
+		result, err := r.vdr.Dereference(didURL)
+		if err != nil && !errors.Is(err, vdrapi.ErrInvalidDIDURL) && !errors.Is(err, vdrapi.ErrResourceNotFound) {
+			return nil, fmt.Errorf("resolve DID %s: %w", issuerDID, err)
+		}
+
+		if err == nil && result.VerificationMethod != nil {
+			return publicKeyOf(result.VerificationMethod), nil
+		}
+	}
+
+	// the key may still be listed by the DID document of the issuer with an ID which is not a DID URL of the
+	// issuer, such as the verification method IDs without fragment of legacy documents.
 	docResolution, err := r.vdr.Resolve(issuerDID)
 	if err != nil {
 		return nil, fmt.Errorf("resolve DID %s: %w", issuerDID, err)
@@ -92,12 +106,10 @@ func (r *DIDKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 
 	for _, verifications := range docResolution.DIDDocument.VerificationMethods() {
 		for _, verification := range verifications {
-			if strings.Contains(verification.VerificationMethod.ID, keyID) {
-				return &verifier.PublicKey{
-					Type:  verification.VerificationMethod.Type,
-					Value: verification.VerificationMethod.Value,
-					JWK:   verification.VerificationMethod.JSONWebKey(),
-				}, nil
+			vm := verification.VerificationMethod
+
+			if strings.Contains(vm.ID, keyID) {
+				return publicKeyOf(&vm), nil
 			}
 		}
 	}
@@ -105,6 +117,29 @@ func (r *DIDKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
 }
 
+func publicKeyOf(vm *did.VerificationMethod) *verifier.PublicKey {
+	return &verifier.PublicKey{
+		Type:  vm.Type,
+		Value: vm.Value,
+		JWK:   vm.JSONWebKey(),
+	}
+}
+
+// keyDIDURL returns the DID URL of a key of issuerDID, whose ID is either a DID URL or the fragment of the DID URL.
+// false is returned if keyID is the DID URL of another DID, so that only the keys of the issuer can be fetched.
+func keyDIDURL(issuerDID, keyID string) (string, bool) {
+	if !strings.HasPrefix(keyID, "did:") {
+		return issuerDID + "#" + strings.TrimPrefix(keyID, "#"), true
+	}
+
+	didURL, err := did.ParseDIDURL(keyID)
+	if err != nil || didURL.DID.String() != issuerDID {
+		return "", false
+	}
+
+	return keyID, true
+}
+
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
 func (r *DIDKeyResolver) PublicKeyFetcher() PublicKeyFetcher {
 	return r.resolvePublicKey
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
@@ -271,6 +272,17 @@ func TestDIDKeyResolver_Resolve(t *testing.T) {
 	r.EqualError(err, fmt.Sprintf("public key with KID invalid key is not found for DID %s", didDoc.ID))
 	r.Nil(pubKey)
 
+	// keys of the document with IDs which are not DID URLs, as in legacy documents.
+	legacyDoc := createDIDDoc()
+	legacyDoc.VerificationMethod[0].ID = "legacy-key"
+	v.ResolveValue = legacyDoc
+
+	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, "legacy-key")
+	r.NoError(err)
+	r.Equal(publicKey.Value, pubKey.Value)
+
+	v.ResolveValue = didDoc
+
 	v.ResolveErr = errors.New("resolver error")
 	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, "")
 	r.Error(err)
@@ -278,6 +290,39 @@ func TestDIDKeyResolver_Resolve(t *testing.T) {
 	r.Nil(pubKey)
 }
 
+func TestDIDKeyResolver_KeysOfOtherDIDs(t *testing.T) {
+	docs := map[string]*did.Doc{}
+
+	for _, id := range []string{"did:test:issuer", "did:test:attacker"} {
+		doc := createDIDDoc()
+		doc.ID = id
+		doc.VerificationMethod[0].ID = id + "#keys-1"
+		doc.VerificationMethod[0].Controller = id
+		docs[id] = doc
+	}
+
+	resolver := NewDIDKeyResolver(&mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, _ ...vdrapi.ResolveOption) (*did.DocResolution, error) {
+			doc, ok := docs[didID]
+			if !ok {
+				return nil, vdrapi.ErrNotFound
+			}
+
+			return &did.DocResolution{DIDDocument: doc}, nil
+		},
+	})
+
+	pubKey, err := resolver.PublicKeyFetcher()("did:test:issuer", "did:test:issuer#keys-1")
+	require.NoError(t, err)
+	require.Equal(t, docs["did:test:issuer"].VerificationMethod[0].Value, pubKey.Value)
+
+	// a JWS kid header or a proof verification method referencing the key of another DID must not be accepted
+	// for a credential claiming to be issued by the issuer.
+	pubKey, err = resolver.PublicKeyFetcher()("did:test:issuer", "did:test:attacker#keys-1")
+	require.EqualError(t, err, "public key with KID did:test:attacker#keys-1 is not found for DID did:test:issuer")
+	require.Nil(t, pubKey)
+}
+
 //nolint:lll
 func createDIDDoc() *did.Doc {
 	didDocJSON := `{
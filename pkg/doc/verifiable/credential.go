@@ -340,7 +340,7 @@ func (b *CredentialSchemaLoaderBuilder) Build() *CredentialSchemaLoader {
 	}
 
 	if l.jsonLoader == nil {
-		l.jsonLoader = defaultSchemaLoader()
+		l.jsonLoader = defaultSchemaLoader(DataModelV1)
 	}
 
 	return l
@@ -502,6 +502,8 @@ type Credential struct {
 	Issuer         Issuer
 	Issued         *util.TimeWithTrailingZeroMsec
 	Expired        *util.TimeWithTrailingZeroMsec
+	ValidFrom      *util.TimeWithTrailingZeroMsec
+	ValidUntil     *util.TimeWithTrailingZeroMsec
 	Proofs         []Proof
 	Status         *TypedID
 	Schemas        []TypedID
@@ -520,6 +522,8 @@ type rawCredential struct {
 	Subject        json.RawMessage                `json:"credentialSubject,omitempty"`
 	Issued         *util.TimeWithTrailingZeroMsec `json:"issuanceDate,omitempty"`
 	Expired        *util.TimeWithTrailingZeroMsec `json:"expirationDate,omitempty"`
+	ValidFrom      *util.TimeWithTrailingZeroMsec `json:"validFrom,omitempty"`
+	ValidUntil     *util.TimeWithTrailingZeroMsec `json:"validUntil,omitempty"`
 	Proof          json.RawMessage                `json:"proof,omitempty"`
 	Status         *TypedID                       `json:"credentialStatus,omitempty"`
 	Issuer         json.RawMessage                `json:"issuer,omitempty"`
@@ -574,6 +578,8 @@ type credentialOpts struct {
 	disabledProofCheck    bool
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
+	dataModelVersions     dataModelVersions
+	verificationPolicy    *VerificationPolicy
 
 	jsonldCredentialOpts
 }
@@ -788,8 +794,10 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 	// Apply options.
 	vcOpts := getCredentialOpts(opts)
 
-	// Decode credential (e.g. from JWT).
-	vcDataDecoded, err := decodeRaw(vcData, vcOpts)
+	// Decode credential (e.g. from JWT) recording the keys its proofs are verified with.
+	decodeOpts, signers := recordSigners(vcOpts)
+
+	vcDataDecoded, err := decodeRaw(vcData, decodeOpts)
 	if err != nil {
 		return nil, fmt.Errorf("decode new credential: %w", err)
 	}
@@ -808,11 +816,21 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, fmt.Errorf("build new credential: %w", err)
 	}
 
+	if !vcOpts.dataModelVersions.accepts(vc.DataModel()) {
+		return nil, fmt.Errorf("verifiable credential data model %s is not accepted", vc.DataModel())
+	}
+
 	err = validateCredential(vc, vcDataDecoded, vcOpts)
 	if err != nil {
 		return nil, err
 	}
 
+	if vcOpts.verificationPolicy != nil {
+		if err = toPolicyError(vcOpts.verificationPolicy.credentialViolations(vc, signers.signers)); err != nil {
+			return nil, fmt.Errorf("check verification policy: %w", err)
+		}
+	}
+
 	return vc, nil
 }
 
@@ -850,7 +868,7 @@ func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts
 		return errors.New("violated type constraint: not base only type defined")
 	}
 
-	if len(vc.Context) > 1 || vc.Context[0] != baseContext {
+	if len(vc.Context) > 1 || vc.Context[0] != vc.DataModel().baseContext() {
 		return errors.New("violated @context constraint: not base only @context defined")
 	}
 
@@ -859,7 +877,7 @@ func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts
 
 func (vc *Credential) validateBaseContextWithExtendedValidation(vcOpts *credentialOpts, vcBytes []byte) error {
 	for _, vcContext := range vc.Context {
-		if _, ok := vcOpts.allowedCustomContexts[vcContext]; !ok {
+		if _, ok := vcOpts.allowedCustomContexts[vcContext]; !ok && vcContext != vc.DataModel().baseContext() {
 			return fmt.Errorf("not allowed @context: %s", vcContext)
 		}
 	}
@@ -973,6 +991,8 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		Issuer:         issuer,
 		Issued:         raw.Issued,
 		Expired:        raw.Expired,
+		ValidFrom:      raw.ValidFrom,
+		ValidUntil:     raw.ValidUntil,
 		Proofs:         proofs,
 		Status:         raw.Status,
 		Schemas:        schemas,
@@ -1030,6 +1050,14 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
 		return checkEmbeddedProof(vcDecodedBytes, getEmbeddedProofCheckOpts(vcOpts))
 	}
 
+	if vcJWT, enveloped, err := envelopedCredentialJWT(vcData); enveloped { // VC Data Model 2.0 enveloped JWT.
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeEnvelopedCredential(vcJWT, vcOpts)
+	}
+
 	// Embedded proof.
 	return checkEmbeddedProof(vcData, getEmbeddedProofCheckOpts(vcOpts))
 }
@@ -1066,7 +1094,7 @@ func getCredentialOpts(opts []CredentialOpt) *credentialOpts {
 func newDefaultSchemaLoader() *CredentialSchemaLoader {
 	return &CredentialSchemaLoader{
 		schemaDownloadClient: &http.Client{},
-		jsonLoader:           defaultSchemaLoader(),
+		jsonLoader:           defaultSchemaLoader(DataModelV1),
 	}
 }
 
@@ -1156,13 +1184,14 @@ func subjectMapToRaw(subject map[string]interface{}) (json.RawMessage, error) {
 }
 
 func (vc *Credential) validateJSONSchema(data []byte, opts *credentialOpts) error {
-	return validateCredentialUsingJSONSchema(data, vc.Schemas, opts)
+	return validateCredentialUsingJSONSchema(data, vc.Schemas, vc.DataModel(), opts)
 }
 
-func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *credentialOpts) error {
+func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, dataModel DataModelVersion,
+	opts *credentialOpts) error {
 	// Validate that the Verifiable Credential conforms to the serialization of the Verifiable Credential data model
 	// (https://w3c.github.io/vc-data-model/#example-1-a-simple-example-of-a-verifiable-credential)
-	schemaLoader, err := getSchemaLoader(schemas, opts)
+	schemaLoader, err := getSchemaLoader(schemas, dataModel, opts)
 	if err != nil {
 		return err
 	}
@@ -1182,14 +1211,15 @@ func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *cre
 	return nil
 }
 
-func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSONLoader, error) {
+func getSchemaLoader(schemas []TypedID, dataModel DataModelVersion,
+	opts *credentialOpts) (gojsonschema.JSONLoader, error) {
 	if opts.disabledCustomSchema {
-		return defaultSchemaLoader(), nil
+		return defaultSchemaLoader(dataModel), nil
 	}
 
 	for _, schema := range schemas {
 		switch schema.Type {
-		case jsonSchema2018Type:
+		case jsonSchema2018Type, jsonSchemaType:
 			customSchemaData, err := getJSONSchema(schema.ID, opts)
 			if err != nil {
 				return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
@@ -1202,11 +1232,11 @@ func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSON
 	}
 
 	// If no custom schema is chosen, use default one
-	return defaultSchemaLoader(), nil
+	return defaultSchemaLoader(dataModel), nil
 }
 
-func defaultSchemaLoader() gojsonschema.JSONLoader {
-	return gojsonschema.NewStringLoader(DefaultSchema)
+func defaultSchemaLoader(dataModel DataModelVersion) gojsonschema.JSONLoader {
+	return gojsonschema.NewStringLoader(dataModel.defaultSchema())
 }
 
 func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
@@ -1372,6 +1402,8 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		TermsOfUse:     rawTermsOfUse,
 		Issued:         vc.Issued,
 		Expired:        vc.Expired,
+		ValidFrom:      vc.ValidFrom,
+		ValidUntil:     vc.ValidUntil,
 		CustomFields:   vc.CustomFields,
 	}
 
@@ -20,6 +20,9 @@ const (
 	vcIssuanceDateField   = "issuanceDate"
 	vcIDField             = "id"
 	vcExpirationDateField = "expirationDate"
+	vcValidFromField      = "validFrom"
+	vcValidUntilField     = "validUntil"
+	vcContextField        = "@context"
 	vcIssuerField         = "issuer"
 	vcIssuerIDField       = "id"
 )
@@ -41,14 +44,21 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 
 	// currently jwt encoding supports only single subject (by the spec)
 	jwtClaims := &jwt.Claims{
-		Issuer:    vc.Issuer.ID,                           // iss
-		NotBefore: josejwt.NewNumericDate(vc.Issued.Time), // nbf
-		ID:        vc.ID,                                  // jti
-		Subject:   subjectID,                              // sub
-		IssuedAt:  josejwt.NewNumericDate(vc.Issued.Time), // iat (not in spec, follow the interop project approach)
+		Issuer:  vc.Issuer.ID, // iss
+		ID:      vc.ID,        // jti
+		Subject: subjectID,    // sub
 	}
-	if vc.Expired != nil {
-		jwtClaims.Expiry = josejwt.NewNumericDate(vc.Expired.Time) // exp
+
+	// validFrom and validUntil of VC Data Model 2.0 are mapped as issuanceDate and expirationDate of 1.1.
+	validFrom, validUntil := vc.ValidityPeriod()
+
+	if validFrom != nil {
+		jwtClaims.NotBefore = josejwt.NewNumericDate(validFrom.Time) // nbf
+		jwtClaims.IssuedAt = josejwt.NewNumericDate(validFrom.Time)  // iat (not in spec, follow the interop project approach)
+	}
+
+	if validUntil != nil {
+		jwtClaims.Expiry = josejwt.NewNumericDate(validUntil.Time) // exp
 	}
 
 	var raw *rawCredential
@@ -58,6 +68,8 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 		vcCopy.Expired = nil
 		vcCopy.Issuer.ID = ""
 		vcCopy.Issued = nil
+		vcCopy.ValidFrom = nil
+		vcCopy.ValidUntil = nil
 		vcCopy.ID = ""
 
 		raw, err = vcCopy.raw()
@@ -108,13 +120,18 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	vcMap := jcc.VC
 	claims := jcc.Claims
 
+	issuanceDateField, expirationDateField := vcIssuanceDateField, vcExpirationDateField
+	if rawContextDataModel(vcMap[vcContextField]) == DataModelV2 {
+		issuanceDateField, expirationDateField = vcValidFromField, vcValidUntilField
+	}
+
 	if iss := claims.Issuer; iss != "" {
 		refineVCIssuerFromJWTClaims(vcMap, iss)
 	}
 
 	if nbf := claims.NotBefore; nbf != nil {
 		nbfTime := nbf.Time().UTC()
-		vcMap[vcIssuanceDateField] = nbfTime.Format(time.RFC3339)
+		vcMap[issuanceDateField] = nbfTime.Format(time.RFC3339)
 	}
 
 	if jti := claims.ID; jti != "" {
@@ -123,12 +140,12 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 
 	if iat := claims.IssuedAt; iat != nil {
 		iatTime := iat.Time().UTC()
-		vcMap[vcIssuanceDateField] = iatTime.Format(time.RFC3339)
+		vcMap[issuanceDateField] = iatTime.Format(time.RFC3339)
 	}
 
 	if exp := claims.Expiry; exp != nil {
 		expTime := exp.Time().UTC()
-		vcMap[vcExpirationDateField] = expTime.Format(time.RFC3339)
+		vcMap[expirationDateField] = expTime.Format(time.RFC3339)
 	}
 }
 
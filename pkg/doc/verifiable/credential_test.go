@@ -130,7 +130,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = "https://www.w3.org/2018/credentials/v1"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -141,7 +141,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = "https://www.w3.org/2018/credentials/v2"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context: @context does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -153,7 +153,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		raw.Context = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context is required")
 	})
@@ -168,7 +168,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -182,7 +182,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context.0: @context.0 does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -198,7 +198,7 @@ func TestValidateVerCredContext(t *testing.T) {
 		}}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "@context.0: @context.0 does not match: \"https://www.w3.org/2018/credentials/v1\"")
 	})
@@ -211,7 +211,7 @@ func TestValidateVerCredID(t *testing.T) {
 	raw.ID = "not valid credential ID URL"
 	bytes, err := json.Marshal(raw)
 	require.NoError(t, err)
-	err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+	err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "id: Does not match format 'uri'")
 }
@@ -224,7 +224,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = []string{}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "Array must have at least 1 items")
 	})
@@ -236,7 +236,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = []string{"NotVerifiableCredential"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "Does not match pattern '^VerifiableCredential$")
 	})
@@ -248,7 +248,7 @@ func TestValidateVerCredType(t *testing.T) {
 		raw.Type = "VerifiableCredential"
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -260,7 +260,7 @@ func TestValidateVerCredType(t *testing.T) {
 			raw.Type = []string{"UniversityDegreeCredentail", "VerifiableCredential"}
 			bytes, err := json.Marshal(raw)
 			require.NoError(t, err)
-			err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+			err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 			require.NoError(t, err)
 		})
 }
@@ -273,7 +273,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		raw.Subject = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSubject is required")
 	})
@@ -285,7 +285,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(singleCredentialSubject), &raw.Subject))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -296,7 +296,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(multipleCredentialSubjects), &raw.Subject))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -309,7 +309,7 @@ func TestValidateVerCredCredentialSubject(t *testing.T) {
 		raw.Subject = invalidNumericSubject
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSubject: Invalid type.")
 	})
@@ -323,7 +323,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		raw.Issuer = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer is required")
 	})
@@ -340,7 +340,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -351,7 +351,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		require.NoError(t, json.Unmarshal([]byte(issuerAsObject), &raw.Issuer))
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -367,7 +367,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer: Invalid type")
 	})
@@ -384,7 +384,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer: Does not match format 'uri'")
 	})
@@ -405,7 +405,7 @@ func TestValidateVerCredIssuer(t *testing.T) {
 		bytes, err := json.Marshal(raw)
 
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuer.id: Does not match format 'uri'")
 	})
@@ -419,7 +419,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		raw.Issued = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuanceDate is required")
 	})
@@ -432,7 +432,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "issuanceDate: Does not match format 'date-time'")
 	})
@@ -445,7 +445,7 @@ func TestValidateVerCredIssuanceDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	}
 }
@@ -468,7 +468,7 @@ func TestValidateVerCredProof(t *testing.T) {
 		raw.Proof = proofBytes
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 	t.Run("test verifiable credential with empty proof", func(t *testing.T) {
@@ -478,7 +478,7 @@ func TestValidateVerCredProof(t *testing.T) {
 		raw.Proof = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 }
@@ -491,7 +491,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		raw.Expired = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -503,7 +503,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "expirationDate: Does not match format 'date-time'")
 	})
@@ -516,7 +516,7 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 		bytes, err := json.Marshal(vcMap)
 		require.NoError(t, err)
 
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	}
 }
@@ -529,7 +529,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -540,7 +540,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{Type: "CredentialStatusList2017"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus: id is required")
 	})
@@ -552,7 +552,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{ID: "https://example.edu/status/24"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus: type is required")
 	})
@@ -564,7 +564,7 @@ func TestValidateVerCredStatus(t *testing.T) {
 		raw.Status = &TypedID{ID: "invalid URL", Type: "CredentialStatusList2017"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialStatus.id: Does not match format 'uri'")
 	})
@@ -578,7 +578,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -589,7 +589,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{Type: "JsonSchemaValidator2018"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema: id is required")
 	})
@@ -601,7 +601,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{ID: "https://example.org/examples/degree.json"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema: type is required")
 	})
@@ -613,7 +613,7 @@ func TestValidateVerCredSchema(t *testing.T) {
 		raw.Schema = &TypedID{ID: "invalid URL", Type: "JsonSchemaValidator2018"}
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "credentialSchema.id: Does not match format 'uri'")
 	})
@@ -627,7 +627,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		raw.RefreshService = nil
 		bytes, err := json.Marshal(raw)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.NoError(t, err)
 	})
 
@@ -638,7 +638,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{Type: "ManualRefreshService2018"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService: id is required")
 	})
@@ -650,7 +650,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{ID: "https://example.edu/refresh/3732"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService: type is required")
 	})
@@ -662,7 +662,7 @@ func TestValidateVerCredRefreshService(t *testing.T) {
 		vc.RefreshService = []TypedID{{ID: "invalid URL", Type: "ManualRefreshService2018"}}
 		bytes, err := json.Marshal(vc)
 		require.NoError(t, err)
-		err = validateCredentialUsingJSONSchema(bytes, nil, &credentialOpts{})
+		err = validateCredentialUsingJSONSchema(bytes, nil, DataModelV1, &credentialOpts{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "refreshService.id: Does not match format 'uri'")
 	})
@@ -0,0 +1,311 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+)
+
+// DefaultSchemaV2 describes default schema of Verifiable Credentials of VC Data Model 2.0.
+const DefaultSchemaV2 = `{
+  "required": [
+    "@context",
+    "type",
+    "credentialSubject",
+    "issuer"
+  ],
+  "properties": {
+    "@context": {
+      "oneOf": [
+        {
+          "type": "string",
+          "const": "https://www.w3.org/ns/credentials/v2"
+        },
+        {
+          "type": "array",
+          "items": [
+            {
+              "type": "string",
+              "const": "https://www.w3.org/ns/credentials/v2"
+            }
+          ],
+          "uniqueItems": true,
+          "additionalItems": {
+            "oneOf": [
+              {
+                "type": "object"
+              },
+              {
+                "type": "string"
+              }
+            ]
+          }
+        }
+      ]
+    },
+    "id": {
+      "type": "string",
+      "format": "uri"
+    },
+    "type": {
+      "oneOf": [
+        {
+          "type": "array",
+          "minItems": 1,
+          "contains": {
+            "type": "string",
+            "pattern": "^VerifiableCredential$"
+          }
+        },
+        {
+          "type": "string",
+          "pattern": "^VerifiableCredential$"
+        }
+      ]
+    },
+    "credentialSubject": {
+      "anyOf": [
+        {
+          "type": "array"
+        },
+        {
+          "type": "object"
+        },
+        {
+          "type": "string"
+        }
+      ]
+    },
+    "issuer": {
+      "anyOf": [
+        {
+          "type": "string",
+          "format": "uri"
+        },
+        {
+          "type": "object",
+          "required": [
+            "id"
+          ],
+          "properties": {
+            "id": {
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        }
+      ]
+    },
+    "validFrom": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "validUntil": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "proof": {
+      "anyOf": [
+        {
+          "$ref": "#/definitions/proof"
+        },
+        {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/proof"
+          }
+        },
+        {
+          "type": "null"
+        }
+      ]
+    },
+    "credentialStatus": {
+      "$ref": "#/definitions/typedID"
+    },
+    "credentialSchema": {
+      "$ref": "#/definitions/typedIDs"
+    },
+    "evidence": {
+      "$ref": "#/definitions/typedIDs"
+    },
+    "refreshService": {
+      "$ref": "#/definitions/typedIDs"
+    }
+  },
+  "definitions": {
+    "typedID": {
+      "anyOf": [
+        {
+          "type": "null"
+        },
+        {
+          "type": "object",
+          "required": [
+            "type"
+          ],
+          "properties": {
+            "id": {
+              "type": "string",
+              "format": "uri"
+            },
+            "type": {
+              "anyOf": [
+                {
+                  "type": "string"
+                },
+                {
+                  "type": "array",
+                  "items": {
+                    "type": "string"
+                  }
+                }
+              ]
+            }
+          }
+        }
+      ]
+    },
+    "typedIDs": {
+      "anyOf": [
+        {
+          "$ref": "#/definitions/typedID"
+        },
+        {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/typedID"
+          }
+        },
+        {
+          "type": "null"
+        }
+      ]
+    },
+    "proof": {
+      "type": "object",
+      "required": [
+        "type"
+      ],
+      "properties": {
+        "type": {
+          "type": "string"
+        }
+      }
+    }
+  }
+}
+`
+
+// https://www.w3.org/TR/vc-data-model-2.0/#data-schemas
+const jsonSchemaType = "JsonSchema"
+
+// DataModelVersion is the version of the W3C Verifiable Credentials Data Model.
+type DataModelVersion string
+
+const (
+	// DataModelV1 is the VC Data Model 1.1 (https://www.w3.org/TR/vc-data-model/), with issuanceDate and
+	// expirationDate and the https://www.w3.org/2018/credentials/v1 base context.
+	DataModelV1 DataModelVersion = "1.1"
+
+	// DataModelV2 is the VC Data Model 2.0 (https://www.w3.org/TR/vc-data-model-2.0/), with validFrom and
+	// validUntil and the https://www.w3.org/ns/credentials/v2 base context.
+	DataModelV2 DataModelVersion = "2.0"
+)
+
+// baseContext returns the base context which must be the first context of VCs and VPs of the data model.
+func (v DataModelVersion) baseContext() string {
+	if v == DataModelV2 {
+		return ContextV2URI
+	}
+
+	return baseContext
+}
+
+// defaultSchema returns the default JSON schema of VCs of the data model.
+func (v DataModelVersion) defaultSchema() string {
+	if v == DataModelV2 {
+		return DefaultSchemaV2
+	}
+
+	return DefaultSchema
+}
+
+// WithDataModelVersions defines the versions of the VC data model accepted when decoding VC.
+// By default, only VC Data Model 1.1 is accepted.
+func WithDataModelVersions(versions ...DataModelVersion) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.dataModelVersions = newDataModelVersions(versions)
+	}
+}
+
+// WithPresDataModelVersions defines the versions of the VC data model accepted when decoding VP.
+// By default, only VC Data Model 1.1 is accepted.
+func WithPresDataModelVersions(versions ...DataModelVersion) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.dataModelVersions = newDataModelVersions(versions)
+	}
+}
+
+// dataModelVersions is a set of accepted versions of the VC data model, only VC Data Model 1.1 if empty.
+type dataModelVersions map[DataModelVersion]bool
+
+func newDataModelVersions(versions []DataModelVersion) dataModelVersions {
+	accepted := make(dataModelVersions)
+
+	for _, version := range versions {
+		accepted[version] = true
+	}
+
+	return accepted
+}
+
+func (v dataModelVersions) accepts(version DataModelVersion) bool {
+	if len(v) == 0 {
+		return version == DataModelV1
+	}
+
+	return v[version]
+}
+
+// DataModel returns the version of the VC data model of the credential, which is defined by its first context.
+func (vc *Credential) DataModel() DataModelVersion {
+	return contextDataModel(vc.Context)
+}
+
+// ValidityPeriod returns the beginning and the end of the validity period of the credential, i.e. issuanceDate and
+// expirationDate for VC Data Model 1.1 and validFrom and validUntil for VC Data Model 2.0. Any of them can be nil.
+func (vc *Credential) ValidityPeriod() (*util.TimeWithTrailingZeroMsec, *util.TimeWithTrailingZeroMsec) {
+	if vc.DataModel() == DataModelV2 {
+		return vc.ValidFrom, vc.ValidUntil
+	}
+
+	return vc.Issued, vc.Expired
+}
+
+func contextDataModel(context []string) DataModelVersion {
+	if len(context) > 0 && context[0] == ContextV2URI {
+		return DataModelV2
+	}
+
+	return DataModelV1
+}
+
+// rawContextDataModel returns the data model of a raw (i.e. unmarshalled into interface{}) context.
+func rawContextDataModel(rawContext interface{}) DataModelVersion {
+	context, _, err := decodeContext(rawContext)
+	if err != nil {
+		return DataModelV1
+	}
+
+	return contextDataModel(context)
+}
+
+// DataModel returns the version of the VC data model of the presentation, which is defined by its first context.
+func (vp *Presentation) DataModel() DataModelVersion {
+	return contextDataModel(vp.Context)
+}
@@ -0,0 +1,312 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const validCredentialV2 = `{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": [
+    "VerifiableCredential",
+    "UniversityDegreeCredential"
+  ],
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree",
+      "name": "Bachelor of Science and Arts"
+    }
+  },
+  "issuer": {
+    "id": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+    "name": "Example University"
+  },
+  "validFrom": "2010-01-01T19:23:24Z",
+  "validUntil": "2030-01-01T19:23:24Z"
+}
+`
+
+//nolint:funlen
+func TestParseCredentialV2(t *testing.T) {
+	t.Run("VC Data Model 2.0 is not accepted by default", func(t *testing.T) {
+		_, err := parseTestCredential([]byte(validCredentialV2))
+		require.EqualError(t, err, "verifiable credential data model 2.0 is not accepted")
+
+		_, err = parseTestCredential([]byte(validCredential), WithDataModelVersions(DataModelV2))
+		require.EqualError(t, err, "verifiable credential data model 1.1 is not accepted")
+	})
+
+	t.Run("parse VC of data model 2.0", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredentialV2), WithDataModelVersions(DataModelV1, DataModelV2),
+			WithStrictValidation())
+		require.NoError(t, err)
+
+		require.Equal(t, DataModelV2, vc.DataModel())
+		require.Equal(t, []string{ContextV2URI}, vc.Context)
+		require.Nil(t, vc.Issued)
+		require.Nil(t, vc.Expired)
+		require.Equal(t, time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC), vc.ValidFrom.Time)
+		require.Equal(t, time.Date(2030, 1, 1, 19, 23, 24, 0, time.UTC), vc.ValidUntil.Time)
+
+		validFrom, validUntil := vc.ValidityPeriod()
+		require.Equal(t, vc.ValidFrom, validFrom)
+		require.Equal(t, vc.ValidUntil, validUntil)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, validCredentialV2, string(vcBytes))
+	})
+
+	t.Run("validity period of VC Data Model 1.1", func(t *testing.T) {
+		vc, err := parseTestCredential([]byte(validCredential))
+		require.NoError(t, err)
+
+		require.Equal(t, DataModelV1, vc.DataModel())
+
+		validFrom, validUntil := vc.ValidityPeriod()
+		require.Equal(t, vc.Issued, validFrom)
+		require.Equal(t, vc.Expired, validUntil)
+	})
+
+	t.Run("VC of data model 2.0 is validated against its default schema", func(t *testing.T) {
+		vcMap := v2CredentialMap(t)
+		vcMap["type"] = "UniversityDegreeCredential"
+
+		_, err := parseTestCredential(toBytes(t, vcMap), WithDataModelVersions(DataModelV2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "type: Does not match pattern '^VerifiableCredential$'")
+
+		vcMap = v2CredentialMap(t)
+		delete(vcMap, "issuer")
+
+		_, err = parseTestCredential(toBytes(t, vcMap), WithDataModelVersions(DataModelV2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "issuer is required")
+
+		vcMap = v2CredentialMap(t)
+		vcMap["@context"] = []interface{}{"https://www.w3.org/2018/credentials/examples/v1", ContextV2URI}
+
+		_, err = parseTestCredential(toBytes(t, vcMap), WithDataModelVersions(DataModelV1, DataModelV2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "@context.0: @context.0 does not match")
+	})
+
+	t.Run("base context validation of VC Data Model 2.0", func(t *testing.T) {
+		vcMap := v2CredentialMap(t)
+		vcMap["type"] = "VerifiableCredential"
+
+		_, err := parseTestCredential(toBytes(t, vcMap), WithDataModelVersions(DataModelV2),
+			WithBaseContextValidation())
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(toBytes(t, vcMap), WithDataModelVersions(DataModelV2),
+			WithBaseContextExtendedValidation(nil, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("JsonSchema credential schema", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			//nolint:errcheck
+			res.Write([]byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "required": ["validUntil"]
+}`))
+		}))
+		defer testServer.Close()
+
+		vcMap := v2CredentialMap(t)
+		vcMap["credentialSchema"] = map[string]interface{}{
+			"id":   testServer.URL,
+			"type": "JsonSchema",
+		}
+
+		_, err := parseTestCredential(toBytes(t, vcMap), WithDataModelVersions(DataModelV2))
+		require.NoError(t, err)
+
+		delete(vcMap, "validUntil")
+
+		_, err = parseTestCredential(toBytes(t, vcMap), WithDataModelVersions(DataModelV2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validUntil is required")
+	})
+}
+
+func TestCredentialV2JWT(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential([]byte(validCredentialV2), WithDataModelVersions(DataModelV2))
+	require.NoError(t, err)
+
+	for _, minimizeVC := range []bool{false, true} {
+		t.Run(fmt.Sprintf("minimize VC %t", minimizeVC), func(t *testing.T) {
+			jwtClaims, err := vc.JWTClaims(minimizeVC)
+			require.NoError(t, err)
+
+			require.Equal(t, vc.ValidFrom.Unix(), jwtClaims.NotBefore.Time().Unix())
+			require.Equal(t, vc.ValidFrom.Unix(), jwtClaims.IssuedAt.Time().Unix())
+			require.Equal(t, vc.ValidUntil.Unix(), jwtClaims.Expiry.Time().Unix())
+
+			jws, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+			require.NoError(t, err)
+
+			vcFromJWT, err := parseTestCredential([]byte(jws), WithDataModelVersions(DataModelV2),
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+			require.NoError(t, err)
+
+			require.Equal(t, vc.ValidFrom.Unix(), vcFromJWT.ValidFrom.Unix())
+			require.Equal(t, vc.ValidUntil.Unix(), vcFromJWT.ValidUntil.Unix())
+			require.Nil(t, vcFromJWT.Issued)
+			require.Nil(t, vcFromJWT.Expired)
+		})
+	}
+
+	t.Run("VC without validity period", func(t *testing.T) {
+		vcCopy := *vc
+		vcCopy.ValidFrom = nil
+		vcCopy.ValidUntil = nil
+
+		jwtClaims, err := vcCopy.JWTClaims(false)
+		require.NoError(t, err)
+		require.Nil(t, jwtClaims.NotBefore)
+		require.Nil(t, jwtClaims.IssuedAt)
+		require.Nil(t, jwtClaims.Expiry)
+	})
+}
+
+func TestCredentialV2LinkedDataProof(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential([]byte(validCredentialV2), WithDataModelVersions(DataModelV2))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+	}, jsonld.WithDocumentLoader(testDocumentLoader))
+	require.NoError(t, err)
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+
+	vcWithProof, err := parseTestCredential(vcBytes, WithDataModelVersions(DataModelV2), WithPublicKeyFetcher(fetcher))
+	require.NoError(t, err)
+	require.Len(t, vcWithProof.Proofs, 1)
+
+	tamperedVC := strings.Replace(string(vcBytes), "2030-01-01T19:23:24Z", "2040-01-01T19:23:24Z", 1)
+
+	_, err = parseTestCredential([]byte(tamperedVC), WithDataModelVersions(DataModelV2), WithPublicKeyFetcher(fetcher))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "check embedded proof")
+}
+
+func TestParsePresentationV2(t *testing.T) {
+	vpBytes := toBytes(t, map[string]interface{}{
+		"@context":             ContextV2URI,
+		"type":                 VPType,
+		"verifiableCredential": []interface{}{v2CredentialMap(t)},
+	})
+
+	// presentations of VC Data Model 2.0 are not accepted by default.
+	_, err := newTestPresentation(vpBytes, WithPresStrictValidation())
+	require.EqualError(t, err, "verifiable presentation data model 2.0 is not accepted")
+
+	vp, err := newTestPresentation(vpBytes, WithPresStrictValidation(), WithPresDataModelVersions(DataModelV2))
+	require.NoError(t, err)
+	require.Equal(t, DataModelV2, vp.DataModel())
+	require.Len(t, vp.Credentials(), 1)
+
+	var v1CredentialMap map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &v1CredentialMap))
+
+	// credentials of VC Data Model 1.1 can be embedded into presentations of VC Data Model 2.0.
+	vpBytes = toBytes(t, map[string]interface{}{
+		"@context":             ContextV2URI,
+		"type":                 VPType,
+		"verifiableCredential": []interface{}{v2CredentialMap(t), v1CredentialMap},
+	})
+
+	vp, err = newTestPresentation(vpBytes, WithPresStrictValidation(),
+		WithPresDataModelVersions(DataModelV1, DataModelV2))
+	require.NoError(t, err)
+	require.Len(t, vp.Credentials(), 2)
+
+	vpBytes = toBytes(t, map[string]interface{}{
+		"@context": ContextV2URI,
+		"type":     VCType,
+	})
+
+	_, err = newTestPresentation(vpBytes, WithPresDataModelVersions(DataModelV2))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type: Does not match pattern '^VerifiablePresentation$'")
+}
+
+func TestNewPresentationV2(t *testing.T) {
+	vcV1, err := parseTestCredential([]byte(validCredential))
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithCredentials(vcV1))
+	require.NoError(t, err)
+	require.Equal(t, DataModelV1, vp.DataModel())
+
+	vcV2, err := parseTestCredential([]byte(validCredentialV2), WithDataModelVersions(DataModelV2))
+	require.NoError(t, err)
+
+	vp, err = NewPresentation(WithCredentials(vcV1, vcV2))
+	require.NoError(t, err)
+	require.Equal(t, DataModelV2, vp.DataModel())
+	require.Equal(t, []string{ContextV2URI}, vp.Context)
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(vpBytes, WithPresDisabledProofCheck(), WithPresDataModelVersions(DataModelV2))
+	require.NoError(t, err)
+}
+
+func v2CredentialMap(t *testing.T) map[string]interface{} {
+	t.Helper()
+
+	var vcMap map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(validCredentialV2), &vcMap))
+
+	return vcMap
+}
+
+func toBytes(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	bytes, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes
+}
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+// EnvelopedVCType is the type of Verifiable Credentials of VC Data Model 2.0 enveloped into a data URL
+// (https://www.w3.org/TR/vc-data-model-2.0/#enveloped-verifiable-credentials).
+const EnvelopedVCType = "EnvelopedVerifiableCredential"
+
+const (
+	// envelopedVCJWTPrefix is the data URL prefix of a VC secured as JWT.
+	envelopedVCJWTPrefix = "data:application/vc+jwt,"
+
+	// envelopedVCLDJWTPrefix is the data URL prefix of a VC secured as JWT used by former drafts of VC-JOSE-COSE.
+	envelopedVCLDJWTPrefix = "data:application/vc+ld+json+jwt;"
+)
+
+// NewEnvelopedCredential wraps a Verifiable Credential serialized as JWT (e.g. by JWTCredClaims.MarshalJWS())
+// into an EnvelopedVerifiableCredential which can be embedded into a presentation of VC Data Model 2.0.
+func NewEnvelopedCredential(vcJWT string) (map[string]interface{}, error) {
+	if !jwt.IsJWS(vcJWT) && !jwt.IsJWTUnsecured(vcJWT) {
+		return nil, errors.New("enveloped credential is not JWT")
+	}
+
+	return map[string]interface{}{
+		"@context": ContextV2URI,
+		"id":       envelopedVCJWTPrefix + vcJWT,
+		"type":     EnvelopedVCType,
+	}, nil
+}
+
+// envelopedCredentialJWT returns the JWT enveloped into vcData if it is an EnvelopedVerifiableCredential.
+func envelopedCredentialJWT(vcData []byte) (string, bool, error) {
+	if !bytes.Contains(vcData, []byte(EnvelopedVCType)) {
+		return "", false, nil
+	}
+
+	var vcMap map[string]interface{}
+
+	if err := json.Unmarshal(vcData, &vcMap); err != nil {
+		return "", false, nil //nolint:nilerr // not a JSON object, hence not an enveloped credential
+	}
+
+	return envelopedCredentialJWTFromMap(vcMap)
+}
+
+func envelopedCredentialJWTFromMap(vcMap map[string]interface{}) (string, bool, error) {
+	types, err := decodeType(vcMap["type"])
+	if err != nil || len(types) != 1 || types[0] != EnvelopedVCType {
+		return "", false, nil //nolint:nilerr // not an enveloped credential
+	}
+
+	id, ok := vcMap["id"].(string)
+	if !ok {
+		return "", true, errors.New("enveloped credential: id is not a data URL")
+	}
+
+	for _, prefix := range []string{envelopedVCJWTPrefix, envelopedVCLDJWTPrefix} {
+		if strings.HasPrefix(id, prefix) {
+			return strings.TrimPrefix(id, prefix), true, nil
+		}
+	}
+
+	return "", true, errors.New("enveloped credential: unsupported media type of data URL")
+}
+
+// decodeEnvelopedCredential decodes the JWT of an EnvelopedVerifiableCredential. The envelope only exists in
+// VC Data Model 2.0, hence the enveloped credential must be of VC Data Model 2.0 too.
+func decodeEnvelopedCredential(vcJWT string, vcOpts *credentialOpts) ([]byte, error) {
+	vcBytes, err := decodeRaw([]byte(vcJWT), vcOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var vc struct {
+		Context interface{} `json:"@context"`
+	}
+
+	if err = json.Unmarshal(vcBytes, &vc); err != nil {
+		return nil, fmt.Errorf("enveloped credential: %w", err)
+	}
+
+	if rawContextDataModel(vc.Context) != DataModelV2 {
+		return nil, errors.New("enveloped credential: credential is not of VC Data Model 2.0")
+	}
+
+	return vcBytes, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+//nolint:funlen
+func TestEnvelopedCredential(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential([]byte(validCredentialV2), WithDataModelVersions(DataModelV2))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+	require.NoError(t, err)
+
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+
+	v1VC, err := parseTestCredential([]byte(validCredential))
+	require.NoError(t, err)
+
+	v1JWTClaims, err := v1VC.JWTClaims(false)
+	require.NoError(t, err)
+
+	v1VCJWS, err := v1JWTClaims.MarshalJWS(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+	require.NoError(t, err)
+
+	t.Run("parse enveloped credential", func(t *testing.T) {
+		envelopedVC, err := NewEnvelopedCredential(vcJWS)
+		require.NoError(t, err)
+		require.Equal(t, EnvelopedVCType, envelopedVC["type"])
+		require.Equal(t, "data:application/vc+jwt,"+vcJWS, envelopedVC["id"])
+
+		parsedVC, err := parseTestCredential(toBytes(t, envelopedVC), WithDataModelVersions(DataModelV2),
+			WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, parsedVC.ID)
+		require.Equal(t, vc.ValidFrom.Unix(), parsedVC.ValidFrom.Unix())
+
+		envelopedVC["id"] = "data:application/vc+ld+json+jwt;" + vcJWS
+
+		_, err = parseTestCredential(toBytes(t, envelopedVC), WithDataModelVersions(DataModelV2),
+			WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+	})
+
+	t.Run("parse enveloped credential of VC Data Model 1.1", func(t *testing.T) {
+		envelopedVC, err := NewEnvelopedCredential(v1VCJWS)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(toBytes(t, envelopedVC), WithDataModelVersions(DataModelV1, DataModelV2),
+			WithPublicKeyFetcher(fetcher))
+		require.EqualError(t, err,
+			"decode new credential: enveloped credential: credential is not of VC Data Model 2.0")
+	})
+
+	t.Run("parse enveloped credential with invalid JWT", func(t *testing.T) {
+		envelopedVC, err := NewEnvelopedCredential(vcJWS)
+		require.NoError(t, err)
+
+		envelopedVC["id"] = envelopedVC["id"].(string) + "tampered"
+
+		_, err = parseTestCredential(toBytes(t, envelopedVC), WithDataModelVersions(DataModelV2),
+			WithPublicKeyFetcher(fetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWS decoding")
+	})
+
+	t.Run("parse enveloped credential with unsupported data URL", func(t *testing.T) {
+		envelopedVC, err := NewEnvelopedCredential(vcJWS)
+		require.NoError(t, err)
+
+		envelopedVC["id"] = "data:application/vc+cose," + vcJWS
+
+		_, err = parseTestCredential(toBytes(t, envelopedVC), WithDataModelVersions(DataModelV2))
+		require.EqualError(t, err,
+			"decode new credential: enveloped credential: unsupported media type of data URL")
+
+		envelopedVC["id"] = 1
+
+		_, err = parseTestCredential(toBytes(t, envelopedVC), WithDataModelVersions(DataModelV2))
+		require.EqualError(t, err, "decode new credential: enveloped credential: id is not a data URL")
+	})
+
+	t.Run("envelop credential which is not JWT", func(t *testing.T) {
+		_, err := NewEnvelopedCredential(validCredentialV2)
+		require.EqualError(t, err, "enveloped credential is not JWT")
+
+		_, err = NewPresentation(WithEnvelopedCredentials("not JWT"))
+		require.EqualError(t, err, "enveloped credential is not JWT")
+	})
+
+	t.Run("presentation with enveloped credentials", func(t *testing.T) {
+		vp, err := NewPresentation(WithEnvelopedCredentials(vcJWS))
+		require.NoError(t, err)
+		require.Equal(t, []string{ContextV2URI}, vp.Context)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		for _, batch := range []bool{false, true} {
+			opts := []PresentationOpt{
+				WithPresPublicKeyFetcher(fetcher), WithPresStrictValidation(), WithPresDataModelVersions(DataModelV2),
+			}
+			if batch {
+				opts = append(opts, WithPresBatchVerification(2))
+			}
+
+			parsedVP, err := newTestPresentation(vpBytes, opts...)
+			require.NoError(t, err)
+			require.Len(t, parsedVP.Credentials(), 1)
+
+			vcBytes, ok := parsedVP.Credentials()[0].([]byte)
+			require.True(t, ok)
+
+			parsedVC, err := parseTestCredential(vcBytes, WithDataModelVersions(DataModelV2),
+				WithDisabledProofCheck())
+			require.NoError(t, err)
+			require.Equal(t, vc.ID, parsedVC.ID)
+
+			tamperedVP := strings.Replace(string(vpBytes), "data:application/vc+jwt,", "data:text/plain,", 1)
+
+			_, err = newTestPresentation([]byte(tamperedVP), opts...)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "unsupported media type of data URL")
+
+			v1VP, err := NewPresentation(WithEnvelopedCredentials(v1VCJWS))
+			require.NoError(t, err)
+
+			v1VPBytes, err := v1VP.MarshalJSON()
+			require.NoError(t, err)
+
+			_, err = newTestPresentation(v1VPBytes, opts...)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "enveloped credential: credential is not of VC Data Model 2.0")
+		}
+	})
+}
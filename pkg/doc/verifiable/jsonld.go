@@ -20,6 +20,8 @@ import (
 const (
 	// ContextURI is the required JSON-LD context for VCs and VPs.
 	ContextURI = "https://www.w3.org/2018/credentials/v1"
+	// ContextV2URI is the required JSON-LD context for VCs and VPs of the VC Data Model 2.0.
+	ContextV2URI = "https://www.w3.org/ns/credentials/v2"
 	// VCType is the required Type for Verifiable Credentials.
 	VCType = "VerifiableCredential"
 	// VPType is the required Type for Verifiable Credentials.
@@ -266,17 +268,117 @@ const vcJSONLD = `
 }
 `
 
-// CachingJSONLDLoader creates JSON_LD CachingDocumentLoader with preloaded base JSON-LD document.
+const vcV2JSONLD = `
+{
+  "@context": {
+    "@version": 1.1,
+    "@protected": true,
+
+    "id": "@id",
+    "type": "@type",
+
+    "description": "https://schema.org/description",
+    "digestMultibase": {
+      "@id": "https://w3id.org/security#digestMultibase",
+      "@type": "https://w3id.org/security#multibase"
+    },
+    "digestSRI": {
+      "@id": "https://www.w3.org/2018/credentials#digestSRI",
+      "@type": "https://www.w3.org/2018/credentials#sriString"
+    },
+    "mediaType": {"@id": "https://schema.org/encodingFormat"},
+    "name": "https://schema.org/name",
+
+    "VerifiableCredential": {
+      "@id": "https://www.w3.org/2018/credentials#VerifiableCredential",
+      "@context": {
+        "@protected": true,
+
+        "id": "@id",
+        "type": "@type",
+
+        "credentialSchema": {"@id": "https://www.w3.org/2018/credentials#credentialSchema", "@type": "@id"},
+        "credentialStatus": {"@id": "https://www.w3.org/2018/credentials#credentialStatus", "@type": "@id"},
+        "credentialSubject": {"@id": "https://www.w3.org/2018/credentials#credentialSubject", "@type": "@id"},
+        "description": "https://schema.org/description",
+        "evidence": {"@id": "https://www.w3.org/2018/credentials#evidence", "@type": "@id"},
+        "issuer": {"@id": "https://www.w3.org/2018/credentials#issuer", "@type": "@id"},
+        "name": "https://schema.org/name",
+        "proof": {"@id": "https://w3id.org/security#proof", "@type": "@id", "@container": "@graph"},
+        "refreshService": {"@id": "https://www.w3.org/2018/credentials#refreshService", "@type": "@id"},
+        "relatedResource": {"@id": "https://www.w3.org/2018/credentials#relatedResource", "@type": "@id"},
+        "renderMethod": {"@id": "https://www.w3.org/2018/credentials#renderMethod", "@type": "@id"},
+        "termsOfUse": {"@id": "https://www.w3.org/2018/credentials#termsOfUse", "@type": "@id"},
+        "validFrom": {
+          "@id": "https://www.w3.org/2018/credentials#validFrom",
+          "@type": "http://www.w3.org/2001/XMLSchema#dateTime"
+        },
+        "validUntil": {
+          "@id": "https://www.w3.org/2018/credentials#validUntil",
+          "@type": "http://www.w3.org/2001/XMLSchema#dateTime"
+        }
+      }
+    },
+
+    "EnvelopedVerifiableCredential": "https://www.w3.org/2018/credentials#EnvelopedVerifiableCredential",
+
+    "VerifiablePresentation": {
+      "@id": "https://www.w3.org/2018/credentials#VerifiablePresentation",
+      "@context": {
+        "@protected": true,
+
+        "id": "@id",
+        "type": "@type",
+
+        "holder": {"@id": "https://www.w3.org/2018/credentials#holder", "@type": "@id"},
+        "proof": {"@id": "https://w3id.org/security#proof", "@type": "@id", "@container": "@graph"},
+        "termsOfUse": {"@id": "https://www.w3.org/2018/credentials#termsOfUse", "@type": "@id"},
+        "verifiableCredential": {
+          "@id": "https://www.w3.org/2018/credentials#verifiableCredential",
+          "@type": "@id",
+          "@container": "@graph",
+          "@context": null
+        }
+      }
+    },
+
+    "EnvelopedVerifiablePresentation": "https://www.w3.org/2018/credentials#EnvelopedVerifiablePresentation",
+
+    "JsonSchemaCredential": "https://www.w3.org/2018/credentials#JsonSchemaCredential",
+
+    "JsonSchema": {
+      "@id": "https://www.w3.org/2018/credentials#JsonSchema",
+      "@context": {
+        "@protected": true,
+
+        "id": "@id",
+        "type": "@type",
+
+        "jsonSchema": {"@id": "https://www.w3.org/2018/credentials#jsonSchema", "@type": "@json"}
+      }
+    },
+
+    "proof": {"@id": "https://w3id.org/security#proof", "@type": "@id", "@container": "@graph"},
+
+    "@vocab": "https://www.w3.org/ns/credentials/issuer-dependent#"
+  }
+}
+`
+
+// CachingJSONLDLoader creates JSON_LD CachingDocumentLoader with preloaded base JSON-LD documents
+// of VC Data Model 1.1 and 2.0.
 func CachingJSONLDLoader() *ld.CachingDocumentLoader {
 	// TODO: remove remote as default
 	loader := jld.NewCachingDocumentLoaderWithRemote()
 
-	reader, err := ld.DocumentFromReader(strings.NewReader(vcJSONLD))
-	if err != nil {
-		panic(err)
-	}
+	for contextURI, contextDoc := range map[string]string{ContextURI: vcJSONLD, ContextV2URI: vcV2JSONLD} {
+		reader, err := ld.DocumentFromReader(strings.NewReader(contextDoc))
+		if err != nil {
+			panic(err)
+		}
 
-	loader.AddDocument(ContextURI, reader)
+		loader.AddDocument(contextURI, reader)
+	}
 
 	return loader
 }
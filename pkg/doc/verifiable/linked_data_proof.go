@@ -8,7 +8,7 @@ package verifiable
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"net/url"
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
@@ -17,23 +17,21 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
-const (
-	resolveIDParts = 2
-)
-
 type keyResolverAdapter struct {
 	pubKeyFetcher PublicKeyFetcher
 }
 
 func (k *keyResolverAdapter) Resolve(id string) (*verifier.PublicKey, error) {
-	// id will contain didID#keyID
-	idSplit := strings.Split(id, "#")
-	if len(idSplit) != resolveIDParts {
-		return nil, fmt.Errorf("wrong id %s to resolve", idSplit)
+	// id is the URL of the key, typically a DID URL with the key ID as fragment (did#keyID)
+	keyURL, err := url.Parse(id)
+	if err != nil || keyURL.Fragment == "" {
+		return nil, fmt.Errorf("wrong id [%s] to resolve", id)
 	}
-	// idSplit[0] is didID
-	// idSplit[1] is keyID
-	pubKey, err := k.pubKeyFetcher(idSplit[0], fmt.Sprintf("#%s", idSplit[1]))
+
+	keyID := "#" + keyURL.Fragment
+	keyURL.Fragment = ""
+
+	pubKey, err := k.pubKeyFetcher(keyURL.String(), keyID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,405 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const (
+	proofTypeField               = "type"
+	proofVerificationMethodField = "verificationMethod"
+	proofCreatorField            = "creator"
+	evidenceTypeField            = "type"
+)
+
+// PolicyRule identifies a rule of the VerificationPolicy.
+type PolicyRule string
+
+const (
+	// PolicyRuleTrustedIssuer is violated when a credential is issued by an issuer not trusted for its type
+	// or when it is not secured by a verified proof or JWS of the issuer.
+	PolicyRuleTrustedIssuer PolicyRule = "trustedIssuer"
+
+	// PolicyRuleDIDMethod is violated when an issuer or a proof verification method uses a DID method
+	// which is not allowed.
+	PolicyRuleDIDMethod PolicyRule = "didMethod"
+
+	// PolicyRuleProofType is violated when a credential or presentation is secured by a proof of the type
+	// which is not allowed.
+	PolicyRuleProofType PolicyRule = "proofType"
+
+	// PolicyRuleMaxCredentialAge is violated when a credential is older than allowed.
+	PolicyRuleMaxCredentialAge PolicyRule = "maxCredentialAge"
+
+	// PolicyRuleCredentialStatus is violated when a credential has no credentialStatus.
+	PolicyRuleCredentialStatus PolicyRule = "credentialStatus"
+
+	// PolicyRuleTermsOfUse is violated when a credential misses the required terms of use or defines
+	// terms of use which are not accepted.
+	PolicyRuleTermsOfUse PolicyRule = "termsOfUse"
+
+	// PolicyRuleEvidence is violated when a credential misses the required evidence.
+	PolicyRuleEvidence PolicyRule = "evidence"
+)
+
+// VerificationPolicy is a declarative policy checked against credentials and presentations in addition
+// to the verification of their proofs and data model. The zero value of each field disables the respective rule.
+type VerificationPolicy struct {
+	// TrustedIssuers maps a credential type to the IDs of the issuers trusted to issue credentials of this type.
+	// As every credential has VerifiableCredential type, issuers listed for it are required for all credentials.
+	// A credential of such type must be secured by a proof or JWS verified with a key of the issuer when parsed,
+	// i.e. the rule is never satisfied if the proof check is disabled.
+	TrustedIssuers map[string][]string
+
+	// AllowedDIDMethods are the DID methods (e.g. "key", "web") allowed for the issuers of credentials and
+	// for the verification methods of the embedded proofs.
+	AllowedDIDMethods []string
+
+	// AllowedProofTypes are the allowed types of the embedded proofs (e.g. "Ed25519Signature2018").
+	// Credentials secured as JWT have no embedded proofs and hence are not affected by this rule.
+	AllowedProofTypes []string
+
+	// MaxCredentialAge is the maximum time elapsed since the beginning of the validity period of a credential
+	// (issuanceDate of VC Data Model 1.1 or validFrom of VC Data Model 2.0).
+	MaxCredentialAge time.Duration
+
+	// RequireCredentialStatus requires credentials to define credentialStatus (e.g. for revocation check).
+	RequireCredentialStatus bool
+
+	// RequiredTermsOfUse are the types of terms of use each credential must define.
+	RequiredTermsOfUse []string
+
+	// AcceptedTermsOfUse are the types of terms of use the verifier is able to comply with.
+	// A credential which defines terms of use of other types violates the policy.
+	AcceptedTermsOfUse []string
+
+	// RequiredEvidence are the types of evidence each credential must define.
+	RequiredEvidence []string
+}
+
+// PolicyViolation describes a violated rule of the VerificationPolicy.
+type PolicyViolation struct {
+	Rule PolicyRule `json:"rule"`
+
+	// CredentialID is the ID of the credential which violates the rule.
+	// It is empty if the rule is violated by the presentation itself.
+	CredentialID string `json:"credentialID,omitempty"`
+
+	Reason string `json:"reason"`
+}
+
+// PolicyError is returned when a credential or presentation violates the VerificationPolicy.
+type PolicyError struct {
+	Violations []PolicyViolation
+}
+
+// Error returns the reasons of all policy violations.
+func (e *PolicyError) Error() string {
+	reasons := make([]string, len(e.Violations))
+
+	for i, v := range e.Violations {
+		reasons[i] = v.Reason
+	}
+
+	return "verification policy violated: " + strings.Join(reasons, "; ")
+}
+
+// WithVerificationPolicy option is for checking of the credential against the verification policy.
+// In case of a violation, ParseCredential returns an error wrapping *PolicyError.
+func WithVerificationPolicy(policy *VerificationPolicy) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.verificationPolicy = policy
+	}
+}
+
+// WithPresVerificationPolicy option is for checking of the presentation and the credentials embedded into it
+// against the verification policy. In case of a violation, ParsePresentation returns an error wrapping *PolicyError.
+func WithPresVerificationPolicy(policy *VerificationPolicy) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.verificationPolicy = policy
+	}
+}
+
+// CheckCredential checks the credential against the policy. It returns *PolicyError listing all violations if any.
+// As the proofs of the credential are not verified here, its issuer is never trusted; use WithVerificationPolicy
+// for the TrustedIssuers rule to be checked against the verified proofs.
+func (p *VerificationPolicy) CheckCredential(vc *Credential) error {
+	return toPolicyError(p.credentialViolations(vc, nil))
+}
+
+// CheckPresentation checks the presentation and the credentials embedded into it against the policy.
+// It returns *PolicyError listing all violations if any. As for CheckCredential, the issuers of the credentials
+// are never trusted; use WithPresVerificationPolicy for the TrustedIssuers rule to be checked.
+func (p *VerificationPolicy) CheckPresentation(vp *Presentation) error {
+	return p.checkPresentation(vp, nil)
+}
+
+// checkPresentation checks the presentation against the policy given the keys which the proofs
+// of each embedded credential were verified with.
+func (p *VerificationPolicy) checkPresentation(vp *Presentation, credentialSigners [][]string) error {
+	violations := p.proofViolations(vp.Proofs, "")
+
+	for i, cred := range vp.credentials {
+		vc, err := policyCredential(cred)
+		if err != nil {
+			return fmt.Errorf("decode credential %d of presentation: %w", i, err)
+		}
+
+		var signers []string
+		if i < len(credentialSigners) {
+			signers = credentialSigners[i]
+		}
+
+		violations = append(violations, p.credentialViolations(vc, signers)...)
+	}
+
+	return toPolicyError(violations)
+}
+
+func toPolicyError(violations []PolicyViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &PolicyError{Violations: violations}
+}
+
+// credentialViolations checks the credential against the policy. signers are the DID URLs of the keys
+// which the proofs or JWS of the credential were verified with.
+func (p *VerificationPolicy) credentialViolations(vc *Credential, signers []string) []PolicyViolation {
+	var violations []PolicyViolation
+
+	violation := func(rule PolicyRule, format string, args ...interface{}) {
+		violations = append(violations, newPolicyViolation(rule, vc.ID, fmt.Sprintf(format, args...)))
+	}
+
+	issuerID := vc.Issuer.ID
+	signedByIssuer := signedBy(signers, issuerID)
+
+	for _, t := range vc.Types {
+		trusted, ok := p.TrustedIssuers[t]
+
+		switch {
+		case !ok:
+		case !contains(trusted, issuerID):
+			violation(PolicyRuleTrustedIssuer, "issuer %q is not trusted for credential type %s", issuerID, t)
+		case !signedByIssuer:
+			violation(PolicyRuleTrustedIssuer, "credential of type %s is not secured by a verified proof of issuer %q",
+				t, issuerID)
+		}
+	}
+
+	if len(p.AllowedDIDMethods) > 0 && !contains(p.AllowedDIDMethods, didMethod(issuerID)) {
+		violation(PolicyRuleDIDMethod, "DID method of issuer %q is not allowed", issuerID)
+	}
+
+	if p.MaxCredentialAge > 0 {
+		validFrom, _ := vc.ValidityPeriod()
+
+		switch {
+		case validFrom == nil:
+			violation(PolicyRuleMaxCredentialAge, "age of credential is unknown")
+		case time.Since(validFrom.Time) > p.MaxCredentialAge:
+			violation(PolicyRuleMaxCredentialAge, "credential is older than %s", p.MaxCredentialAge)
+		}
+	}
+
+	if p.RequireCredentialStatus && vc.Status == nil {
+		violation(PolicyRuleCredentialStatus, "credentialStatus is required")
+	}
+
+	termsOfUse := make([]string, len(vc.TermsOfUse))
+	for i := range vc.TermsOfUse {
+		termsOfUse[i] = vc.TermsOfUse[i].Type
+	}
+
+	for _, t := range p.RequiredTermsOfUse {
+		if !contains(termsOfUse, t) {
+			violation(PolicyRuleTermsOfUse, "terms of use of type %s are required", t)
+		}
+	}
+
+	if len(p.AcceptedTermsOfUse) > 0 {
+		for _, t := range termsOfUse {
+			if !contains(p.AcceptedTermsOfUse, t) {
+				violation(PolicyRuleTermsOfUse, "terms of use of type %s are not accepted", t)
+			}
+		}
+	}
+
+	evidence := evidenceTypes(vc.Evidence)
+
+	for _, t := range p.RequiredEvidence {
+		if !contains(evidence, t) {
+			violation(PolicyRuleEvidence, "evidence of type %s is required", t)
+		}
+	}
+
+	return append(violations, p.proofViolations(vc.Proofs, vc.ID)...)
+}
+
+func (p *VerificationPolicy) proofViolations(proofs []Proof, credentialID string) []PolicyViolation {
+	var violations []PolicyViolation
+
+	violation := func(rule PolicyRule, format string, args ...interface{}) {
+		violations = append(violations, newPolicyViolation(rule, credentialID, fmt.Sprintf(format, args...)))
+	}
+
+	for _, proof := range proofs {
+		proofType, _ := proof[proofTypeField].(string) //nolint:errcheck
+
+		if len(p.AllowedProofTypes) > 0 && !contains(p.AllowedProofTypes, proofType) {
+			violation(PolicyRuleProofType, "proof type %s is not allowed", proofType)
+		}
+
+		verificationMethod, ok := proof[proofVerificationMethodField].(string)
+		if !ok {
+			verificationMethod, _ = proof[proofCreatorField].(string) //nolint:errcheck
+		}
+
+		if len(p.AllowedDIDMethods) > 0 && !contains(p.AllowedDIDMethods, didMethod(verificationMethod)) {
+			violation(PolicyRuleDIDMethod, "DID method of proof verification method %q is not allowed", verificationMethod)
+		}
+	}
+
+	return violations
+}
+
+func newPolicyViolation(rule PolicyRule, credentialID, reason string) PolicyViolation {
+	if credentialID != "" {
+		reason = fmt.Sprintf("credential %s: %s", credentialID, reason)
+	}
+
+	return PolicyViolation{Rule: rule, CredentialID: credentialID, Reason: reason}
+}
+
+// policyCredential builds the credential embedded into presentation without its validation
+// which has been done (if requested) when decoding the presentation.
+func policyCredential(cred interface{}) (*Credential, error) {
+	credBytes, ok := cred.([]byte)
+	if !ok {
+		var err error
+
+		credBytes, err = json.Marshal(cred)
+		if err != nil {
+			return nil, fmt.Errorf("marshal credential: %w", err)
+		}
+	}
+
+	var raw rawCredential
+
+	if err := json.Unmarshal(credBytes, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	return newCredential(&raw)
+}
+
+// signerRecorder records the DID URLs of the keys fetched for the verification of the proofs or JWS
+// of a credential.
+type signerRecorder struct {
+	signers []string
+}
+
+// recordSigners returns a copy of vcOpts whose public key fetcher records the keys it fetches into the recorder.
+// As the decoding of a credential fails if any of its proofs is not valid, the keys recorded by a successful
+// decoding are the keys the credential is signed with.
+func recordSigners(vcOpts *credentialOpts) (*credentialOpts, *signerRecorder) {
+	recorder := &signerRecorder{}
+
+	if vcOpts.disabledProofCheck || vcOpts.publicKeyFetcher == nil {
+		return vcOpts, recorder
+	}
+
+	fetcher := vcOpts.publicKeyFetcher
+	recordingOpts := *vcOpts
+
+	recordingOpts.publicKeyFetcher = func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		pubKey, err := fetcher(issuerID, keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(keyID, "did:") {
+			recorder.signers = append(recorder.signers, keyID)
+		} else {
+			recorder.signers = append(recorder.signers, issuerID+"#"+strings.TrimPrefix(keyID, "#"))
+		}
+
+		return pubKey, nil
+	}
+
+	return &recordingOpts, recorder
+}
+
+// signedBy checks whether any of the signer keys is a key of the issuer.
+func signedBy(signers []string, issuerID string) bool {
+	for _, signer := range signers {
+		signerID := signer
+		if i := strings.Index(signer, "#"); i >= 0 {
+			signerID = signer[:i]
+		}
+
+		if didURL, err := did.ParseDIDURL(signer); err == nil {
+			signerID = didURL.DID.String()
+		}
+
+		if issuerID != "" && signerID == issuerID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evidenceTypes returns the types of a single evidence or an array of them.
+func evidenceTypes(evidence Evidence) []string {
+	var types []string
+
+	switch e := evidence.(type) {
+	case []interface{}:
+		for _, item := range e {
+			types = append(types, evidenceTypes(item)...)
+		}
+	case map[string]interface{}:
+		t, err := decodeType(e[evidenceTypeField])
+		if err == nil {
+			types = append(types, t...)
+		}
+	}
+
+	return types
+}
+
+// didMethod returns the method of DID or DID URL, e.g. "key" for "did:key:z6Mk...#z6Mk...".
+func didMethod(did string) string {
+	const numDIDParts = 3
+
+	parts := strings.SplitN(did, ":", numDIDParts)
+	if len(parts) != numDIDParts || parts[0] != "did" {
+		return ""
+	}
+
+	return parts[1]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
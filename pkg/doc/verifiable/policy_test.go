@@ -0,0 +1,339 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const hundredYears = 100 * 365 * 24 * time.Hour
+
+//nolint:funlen
+func TestVerificationPolicy_CheckCredential(t *testing.T) {
+	vc, err := parseTestCredential([]byte(validCredential))
+	require.NoError(t, err)
+
+	vcWithProof, _ := createVCWithLinkedDataProof()
+
+	tests := []struct {
+		name   string
+		vc     *Credential
+		policy *VerificationPolicy
+		rules  []PolicyRule
+	}{
+		{
+			name:   "empty policy",
+			vc:     vcWithProof,
+			policy: &VerificationPolicy{},
+		},
+		{
+			name: "all rules are satisfied",
+			vc:   vc,
+			policy: &VerificationPolicy{
+				AllowedDIDMethods:       []string{"example"},
+				AllowedProofTypes:       []string{"Ed25519Signature2018"},
+				MaxCredentialAge:        hundredYears,
+				RequireCredentialStatus: true,
+				RequiredTermsOfUse:      []string{"IssuerPolicy"},
+				AcceptedTermsOfUse:      []string{"IssuerPolicy"},
+				RequiredEvidence:        []string{"DocumentVerification", "SupportingActivity"},
+			},
+		},
+		{
+			name: "issuer is not trusted",
+			vc:   vc,
+			policy: &VerificationPolicy{
+				TrustedIssuers: map[string][]string{
+					VCType:                       {"did:example:other"},
+					"UniversityDegreeCredential": {"did:example:university"},
+				},
+			},
+			rules: []PolicyRule{PolicyRuleTrustedIssuer},
+		},
+		{
+			name:   "proofs of trusted issuer are not verified",
+			vc:     vc,
+			policy: &VerificationPolicy{TrustedIssuers: map[string][]string{VCType: {vc.Issuer.ID}}},
+			rules:  []PolicyRule{PolicyRuleTrustedIssuer},
+		},
+		{
+			name:   "DID method is not allowed",
+			vc:     vcWithProof,
+			policy: &VerificationPolicy{AllowedDIDMethods: []string{"key"}},
+			rules:  []PolicyRule{PolicyRuleDIDMethod, PolicyRuleDIDMethod},
+		},
+		{
+			name:   "proof type is not allowed",
+			vc:     vcWithProof,
+			policy: &VerificationPolicy{AllowedProofTypes: []string{"BbsBlsSignature2020"}},
+			rules:  []PolicyRule{PolicyRuleProofType},
+		},
+		{
+			name:   "credential is too old",
+			vc:     vc,
+			policy: &VerificationPolicy{MaxCredentialAge: time.Hour},
+			rules:  []PolicyRule{PolicyRuleMaxCredentialAge},
+		},
+		{
+			name:   "age of credential is unknown",
+			vc:     &Credential{ID: vc.ID},
+			policy: &VerificationPolicy{MaxCredentialAge: hundredYears},
+			rules:  []PolicyRule{PolicyRuleMaxCredentialAge},
+		},
+		{
+			name:   "credentialStatus is missing",
+			vc:     &Credential{ID: vc.ID},
+			policy: &VerificationPolicy{RequireCredentialStatus: true},
+			rules:  []PolicyRule{PolicyRuleCredentialStatus},
+		},
+		{
+			name: "terms of use are not accepted or missing",
+			vc:   vc,
+			policy: &VerificationPolicy{
+				RequiredTermsOfUse: []string{"HolderPolicy"},
+				AcceptedTermsOfUse: []string{"HolderPolicy"},
+			},
+			rules: []PolicyRule{PolicyRuleTermsOfUse, PolicyRuleTermsOfUse},
+		},
+		{
+			name:   "evidence is missing",
+			vc:     vc,
+			policy: &VerificationPolicy{RequiredEvidence: []string{"DocumentVerification", "Biometrics"}},
+			rules:  []PolicyRule{PolicyRuleEvidence},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.CheckCredential(tc.vc)
+			if len(tc.rules) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var policyErr *PolicyError
+
+			require.True(t, errors.As(err, &policyErr))
+			require.Len(t, policyErr.Violations, len(tc.rules))
+
+			for i, violation := range policyErr.Violations {
+				require.Equal(t, tc.rules[i], violation.Rule)
+				require.Equal(t, tc.vc.ID, violation.CredentialID)
+				require.Contains(t, err.Error(), violation.Reason)
+			}
+		})
+	}
+}
+
+func TestParseCredentialWithVerificationPolicy(t *testing.T) {
+	const issuerID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	policy := &VerificationPolicy{
+		TrustedIssuers:   map[string][]string{VCType: {"did:example:other"}},
+		MaxCredentialAge: hundredYears,
+	}
+
+	vcBytes, fetcher := createPolicyTestCredential(t, issuerID+"#key-1")
+
+	_, err := parseTestCredential(vcBytes, WithPublicKeyFetcher(fetcher), WithVerificationPolicy(policy))
+	require.EqualError(t, err, "check verification policy: verification policy violated: "+
+		"credential http://example.edu/credentials/1872: issuer \"did:example:76e12ec712ebc6f1c221ebfeb1f\" "+
+		"is not trusted for credential type VerifiableCredential")
+
+	var policyErr *PolicyError
+
+	require.True(t, errors.As(err, &policyErr))
+	require.Equal(t, []PolicyViolation{{
+		Rule:         PolicyRuleTrustedIssuer,
+		CredentialID: "http://example.edu/credentials/1872",
+		Reason: "credential http://example.edu/credentials/1872: " +
+			"issuer \"did:example:76e12ec712ebc6f1c221ebfeb1f\" is not trusted for credential type VerifiableCredential",
+	}}, policyErr.Violations)
+
+	policy.TrustedIssuers[VCType] = append(policy.TrustedIssuers[VCType], issuerID)
+
+	t.Run("signed by issuer", func(t *testing.T) {
+		_, err = parseTestCredential(vcBytes, WithPublicKeyFetcher(fetcher), WithVerificationPolicy(policy))
+		require.NoError(t, err)
+
+		vc, errParse := parseTestCredential(vcBytes, WithPublicKeyFetcher(fetcher))
+		require.NoError(t, errParse)
+
+		jwtClaims, errClaims := vc.JWTClaims(false)
+		require.NoError(t, errClaims)
+
+		signer, errSigner := newCryptoSigner(kmsapi.ED25519Type)
+		require.NoError(t, errSigner)
+
+		jws, errJWS := jwtClaims.MarshalJWS(EdDSA, signer, issuerID+"#key-2")
+		require.NoError(t, errJWS)
+
+		_, err = parseTestCredential([]byte(jws),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)), WithVerificationPolicy(policy))
+		require.NoError(t, err)
+	})
+
+	t.Run("not signed by issuer", func(t *testing.T) {
+		otherVCBytes, otherFetcher := createPolicyTestCredential(t, "did:example:other#key-1")
+
+		tests := []struct {
+			name string
+			vc   []byte
+			opts []CredentialOpt
+		}{
+			{name: "no proof", vc: []byte(validCredential)},
+			{name: "proof check disabled", vc: vcBytes, opts: []CredentialOpt{WithDisabledProofCheck()}},
+			{name: "key of other DID", vc: otherVCBytes, opts: []CredentialOpt{WithPublicKeyFetcher(otherFetcher)}},
+		}
+
+		for _, tc := range tests {
+			_, err = parseTestCredential(tc.vc, append(tc.opts, WithVerificationPolicy(policy))...)
+			require.Error(t, err, tc.name)
+			require.Contains(t, err.Error(), "credential of type VerifiableCredential is not secured by "+
+				"a verified proof of issuer \"did:example:76e12ec712ebc6f1c221ebfeb1f\"", tc.name)
+		}
+	})
+}
+
+// createPolicyTestCredential returns validCredential secured by a linked data proof with the verification method.
+func createPolicyTestCredential(t *testing.T, verificationMethod string) ([]byte, PublicKeyFetcher) {
+	t.Helper()
+
+	vc, err := parseTestCredential([]byte(validCredential))
+	require.NoError(t, err)
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		SignatureRepresentation: SignatureJWS,
+		VerificationMethod:      verificationMethod,
+	}, jsonld.WithDocumentLoader(createTestJSONLDDocumentLoader()))
+	require.NoError(t, err)
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	return vcBytes, SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)
+}
+
+func TestParsePresentationWithVerificationPolicy(t *testing.T) {
+	vc, err := parseTestCredential([]byte(validCredential))
+	require.NoError(t, err)
+
+	vcWithProof, _ := createVCWithLinkedDataProof()
+
+	vp, err := NewPresentation(WithCredentials(vc, vcWithProof))
+	require.NoError(t, err)
+
+	vp.Proofs = []Proof{{"type": "BbsBlsSignature2020", "verificationMethod": "did:key:z6Mk#z6Mk"}}
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	policy := &VerificationPolicy{
+		AllowedDIDMethods:       []string{"example"},
+		AllowedProofTypes:       []string{"Ed25519Signature2018"},
+		RequireCredentialStatus: true,
+	}
+
+	for _, batch := range []bool{false, true} {
+		opts := []PresentationOpt{WithPresDisabledProofCheck(), WithPresVerificationPolicy(policy)}
+		if batch {
+			opts = append(opts, WithPresBatchVerification(2))
+		}
+
+		_, err = newTestPresentation(vpBytes, opts...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check verification policy")
+
+		var policyErr *PolicyError
+
+		require.True(t, errors.As(err, &policyErr))
+		require.Equal(t, []PolicyViolation{
+			{Rule: PolicyRuleProofType, Reason: "proof type BbsBlsSignature2020 is not allowed"},
+			{Rule: PolicyRuleDIDMethod, Reason: "DID method of proof verification method \"did:key:z6Mk#z6Mk\" is not allowed"},
+			{
+				Rule:         PolicyRuleDIDMethod,
+				CredentialID: vc.ID,
+				Reason:       "credential " + vc.ID + ": DID method of proof verification method \"did:123#any\" is not allowed",
+			},
+		}, policyErr.Violations)
+	}
+
+	vp.Proofs = nil
+
+	vpBytes, err = vp.MarshalJSON()
+	require.NoError(t, err)
+
+	policy.AllowedDIDMethods = nil
+
+	_, err = newTestPresentation(vpBytes, WithPresDisabledProofCheck(), WithPresVerificationPolicy(policy))
+	require.NoError(t, err)
+
+	t.Run("trusted issuer", func(t *testing.T) {
+		const issuerID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+		trustPolicy := &VerificationPolicy{TrustedIssuers: map[string][]string{VCType: {issuerID}}}
+
+		vcBytes, fetcher := createPolicyTestCredential(t, issuerID+"#key-1")
+		forgedVCBytes, _ := createPolicyTestCredential(t, issuerID+"#key-1")
+		otherVCBytes, otherFetcher := createPolicyTestCredential(t, "did:example:other#key-1")
+
+		presentation := func(vcBytes []byte) []byte {
+			cred, errParse := parseTestCredential(vcBytes, WithDisabledProofCheck())
+			require.NoError(t, errParse)
+
+			credVP, errVP := NewPresentation(WithCredentials(cred))
+			require.NoError(t, errVP)
+
+			credVPBytes, errVP := credVP.MarshalJSON()
+			require.NoError(t, errVP)
+
+			return credVPBytes
+		}
+
+		for _, batch := range []bool{false, true} {
+			opts := []PresentationOpt{WithPresVerificationPolicy(trustPolicy)}
+			if batch {
+				opts = append(opts, WithPresBatchVerification(2))
+			}
+
+			_, err = newTestPresentation(presentation(vcBytes), append(opts, WithPresPublicKeyFetcher(fetcher))...)
+			require.NoError(t, err)
+
+			// the embedded credential is verified before the policy is checked against it
+			_, err = newTestPresentation(presentation(forgedVCBytes),
+				append(opts, WithPresPublicKeyFetcher(fetcher))...)
+			require.Error(t, err)
+			require.NotContains(t, err.Error(), "check verification policy")
+
+			_, err = newTestPresentation(presentation(otherVCBytes),
+				append(opts, WithPresPublicKeyFetcher(otherFetcher))...)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "is not secured by a verified proof of issuer")
+
+			_, err = newTestPresentation(presentation(vcBytes), append(opts, WithPresDisabledProofCheck())...)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "is not secured by a verified proof of issuer")
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
@@ -155,7 +156,13 @@ const basePresentationSchema = `
 `
 
 //nolint:gochecknoglobals
-var basePresentationSchemaLoader = gojsonschema.NewStringLoader(basePresentationSchema)
+var (
+	basePresentationSchemaLoader = gojsonschema.NewStringLoader(basePresentationSchema)
+
+	// the presentations of VC Data Model 2.0 differ by the base context only.
+	basePresentationSchemaV2Loader = gojsonschema.NewStringLoader(
+		strings.ReplaceAll(basePresentationSchema, baseContext, ContextV2URI))
+)
 
 // MarshalledCredential defines marshalled Verifiable Credential enclosed into Presentation.
 // MarshalledCredential can be passed to verifiable.ParseCredential().
@@ -194,11 +201,17 @@ func NewPresentation(opts ...CreatePresentationOpt) (*Presentation, error) {
 	return &p, nil
 }
 
-// WithCredentials sets the provided credentials into the presentation.
+// WithCredentials sets the provided credentials into the presentation. As credentials of VC Data Model 2.0 can't
+// be embedded into presentations of VC Data Model 1.1, the base context of the presentation is set to ContextV2URI
+// if any of the credentials is of VC Data Model 2.0.
 func WithCredentials(cs ...*Credential) CreatePresentationOpt {
 	return func(p *Presentation) error {
 		for _, c := range cs {
 			p.credentials = append(p.credentials, c)
+
+			if c != nil && c.DataModel() == DataModelV2 {
+				p.setDataModelV2()
+			}
 		}
 
 		return nil
@@ -220,6 +233,35 @@ func WithJWTCredentials(cs ...string) CreatePresentationOpt {
 	}
 }
 
+// WithEnvelopedCredentials sets the provided JWT credentials into the presentation as enveloped credentials
+// (see NewEnvelopedCredential()). As enveloped credentials are defined by VC Data Model 2.0, the base context of
+// the presentation is set to ContextV2URI.
+func WithEnvelopedCredentials(cs ...string) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		for _, c := range cs {
+			envelopedVC, err := NewEnvelopedCredential(c)
+			if err != nil {
+				return err
+			}
+
+			p.credentials = append(p.credentials, envelopedVC)
+		}
+
+		if len(cs) > 0 {
+			p.setDataModelV2()
+		}
+
+		return nil
+	}
+}
+
+// setDataModelV2 replaces the base context of VC Data Model 1.1 of the presentation by the one of 2.0.
+func (vp *Presentation) setDataModelV2() {
+	if len(vp.Context) > 0 && vp.Context[0] == baseContext {
+		vp.Context[0] = ContextV2URI
+	}
+}
+
 // MarshalJSON converts Verifiable Presentation to JSON bytes.
 func (vp *Presentation) MarshalJSON() ([]byte, error) {
 	raw, err := vp.raw()
@@ -335,6 +377,11 @@ type presentationOpts struct {
 	requireVC          bool
 	requireProof       bool
 
+	batchMaxConcurrency  int
+	verificationPolicy   *VerificationPolicy
+	credentialProofCheck bool
+	dataModelVersions    dataModelVersions
+
 	jsonldCredentialOpts
 }
 
@@ -380,6 +427,24 @@ func WithPresJSONLDDocumentLoader(documentLoader ld.DocumentLoader) Presentation
 	}
 }
 
+// WithPresBatchVerification enables the decoding in parallel of the credentials embedded into VP, decoding
+// at most maxConcurrency credentials at once (see VerifyCredentials()). The credentials are checked as in sequential
+// decoding, but ParsePresentation returns a CredentialsError holding the errors of all the invalid credentials.
+func WithPresBatchVerification(maxConcurrency int) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.batchMaxConcurrency = maxConcurrency
+	}
+}
+
+// WithPresEmbeddedCredentialProofCheck enables the check of the embedded proofs of the credentials defined as JSON
+// objects in VP, which are otherwise only checked with a verification policy (credentials in JWT format are always
+// checked).
+func WithPresEmbeddedCredentialProofCheck() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.credentialProofCheck = true
+	}
+}
+
 // ParsePresentation creates an instance of Verifiable Presentation by reading a JSON document from bytes.
 // It also applies miscellaneous options like custom decoders or settings of schema validation.
 func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, error) {
@@ -390,12 +455,12 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, err
 	}
 
-	err = validateVP(vpDataDecoded, vpOpts)
+	err = validateVP(vpDataDecoded, rawContextDataModel(vpRaw.Context), vpOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	p, err := newPresentation(vpRaw, vpOpts)
+	p, credentialSigners, err := newPresentation(vpRaw, vpOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -404,6 +469,12 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, fmt.Errorf("verifiableCredential is required")
 	}
 
+	if vpOpts.verificationPolicy != nil {
+		if err = vpOpts.verificationPolicy.checkPresentation(p, credentialSigners); err != nil {
+			return nil, fmt.Errorf("check verification policy: %w", err)
+		}
+	}
+
 	return p, nil
 }
 
@@ -421,25 +492,27 @@ func getPresentationOpts(opts []PresentationOpt) *presentationOpts {
 	return vpOpts
 }
 
-func newPresentation(vpRaw *rawPresentation, vpOpts *presentationOpts) (*Presentation, error) {
+// newPresentation builds the presentation from raw. It also returns the keys the proofs
+// of each embedded credential were verified with.
+func newPresentation(vpRaw *rawPresentation, vpOpts *presentationOpts) (*Presentation, [][]string, error) {
 	types, err := decodeType(vpRaw.Type)
 	if err != nil {
-		return nil, fmt.Errorf("fill presentation types from raw: %w", err)
+		return nil, nil, fmt.Errorf("fill presentation types from raw: %w", err)
 	}
 
 	context, customContext, err := decodeContext(vpRaw.Context)
 	if err != nil {
-		return nil, fmt.Errorf("fill presentation contexts from raw: %w", err)
+		return nil, nil, fmt.Errorf("fill presentation contexts from raw: %w", err)
 	}
 
-	creds, err := decodeCredentials(vpRaw.Credential, vpOpts)
+	creds, signers, err := decodeCredentials(vpRaw.Credential, vpOpts)
 	if err != nil {
-		return nil, fmt.Errorf("decode credentials of presentation: %w", err)
+		return nil, nil, fmt.Errorf("decode credentials of presentation: %w", err)
 	}
 
 	proofs, err := parseProof(vpRaw.Proof)
 	if err != nil {
-		return nil, fmt.Errorf("fill credential proof from raw: %w", err)
+		return nil, nil, fmt.Errorf("fill credential proof from raw: %w", err)
 	}
 
 	return &Presentation{
@@ -451,7 +524,7 @@ func newPresentation(vpRaw *rawPresentation, vpOpts *presentationOpts) (*Present
 		Holder:        vpRaw.Holder,
 		Proofs:        proofs,
 		CustomFields:  vpRaw.CustomFields,
-	}, nil
+	}, signers, nil
 }
 
 // decodeCredentials decodes credential(s) embedded into presentation.
@@ -460,59 +533,179 @@ func newPresentation(vpRaw *rawPresentation, vpOpts *presentationOpts) (*Present
 // 2) the same as 1) but as array - e.g. zero ore more JWS
 // 3) struct (should be map[string]interface{}) representing credential data model
 // 4) the same as 3) but as array - i.e. zero or more credentials structs.
-func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface{}, error) {
+// It also returns the keys the proofs of each credential were verified with.
+func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface{}, [][]string, error) {
 	// Accept the case when VP does not have any VCs.
 	if rawCred == nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 
-	marshalSingleCredFn := func(cred interface{}) (interface{}, error) {
+	marshalSingleCredFn := func(cred interface{}) (interface{}, []string, error) {
+		decodeOpts, signers := recordSigners(mapOpts(opts))
+
 		// Check the case when VC is defined in string format (e.g. JWT).
 		// Decode credential and keep result of decoding.
 		if sCred, ok := cred.(string); ok {
 			bCred := []byte(sCred)
 
-			credDecoded, err := decodeRaw(bCred, mapOpts(opts))
+			credDecoded, err := decodeRaw(bCred, decodeOpts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("decode credential of presentation: %w", err)
+			}
+
+			return credDecoded, signers.signers, nil
+		}
+
+		// Decode credential enveloped into data URL (VC Data Model 2.0) and keep result of decoding.
+		if vcJWT, enveloped, err := envelopedCredentialJWTOfRaw(cred); enveloped {
+			if err == nil {
+				cred, err = decodeEnvelopedCredential(vcJWT, decodeOpts)
+			}
+
 			if err != nil {
-				return nil, fmt.Errorf("decode credential of presentation: %w", err)
+				return nil, nil, fmt.Errorf("decode credential of presentation: %w", err)
+			}
+
+			return cred, signers.signers, nil
+		}
+
+		if opts.checkCredentialObjectProofs() {
+			decodeOpts.jsonldCredentialOpts = opts.jsonldCredentialOpts
+
+			if err := checkCredentialObjectProof(cred, decodeOpts); err != nil {
+				return nil, nil, fmt.Errorf("decode credential of presentation: %w", err)
 			}
 
-			return credDecoded, nil
+			return cred, signers.signers, nil
 		}
 
 		// return credential in a structure format as is
-		return cred, nil
+		return cred, nil, nil
 	}
 
 	switch cred := rawCred.(type) {
 	case []interface{}:
 		// Accept the case when VP does not have any VCs.
 		if len(cred) == 0 {
-			return nil, nil
+			return nil, nil, nil
+		}
+
+		if opts.batchMaxConcurrency > 0 {
+			return decodeCredentialsBatch(cred, opts)
 		}
 
 		// 1 or more credentials
 		creds := make([]interface{}, len(cred))
+		signers := make([][]string, len(cred))
 
 		for i := range cred {
-			c, err := marshalSingleCredFn(cred[i])
+			c, s, err := marshalSingleCredFn(cred[i])
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
-			creds[i] = c
+			creds[i], signers[i] = c, s
 		}
 
-		return creds, nil
+		return creds, signers, nil
 	default:
 		// single credential
-		c, err := marshalSingleCredFn(cred)
+		c, s, err := marshalSingleCredFn(cred)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		return []interface{}{c}, nil
+		return []interface{}{c}, [][]string{s}, nil
+	}
+}
+
+// checkCredentialObjectProofs returns whether the embedded proofs of the credentials defined as JSON objects are
+// checked. The verification policy is checked against verified credentials only, hence the embedded proofs are
+// checked if a public key fetcher is defined.
+func (o *presentationOpts) checkCredentialObjectProofs() bool {
+	return o.credentialProofCheck || (o.verificationPolicy != nil && o.publicKeyFetcher != nil)
+}
+
+// decodeCredentialsBatch decodes and verifies credentials embedded into presentation in parallel.
+func decodeCredentialsBatch(rawCreds []interface{}, opts *presentationOpts) ([]interface{}, [][]string, error) {
+	vcOpts := mapOpts(opts)
+	vcOpts.jsonldCredentialOpts = opts.jsonldCredentialOpts
+	vcOpts.jsonldDocumentLoader = newSyncDocumentLoader(opts.jsonldDocumentLoader)
+
+	if vcOpts.publicKeyFetcher != nil {
+		vcOpts.publicKeyFetcher = singleFetchPublicKeyFetcher(vcOpts.publicKeyFetcher)
+	}
+
+	creds := make([]interface{}, len(rawCreds))
+	signers := make([][]string, len(rawCreds))
+	errs := make([]error, len(rawCreds))
+	checkObjectProofs := opts.checkCredentialObjectProofs()
+
+	runBatch(len(rawCreds), opts.batchMaxConcurrency, func(i int) {
+		creds[i], signers[i], errs[i] = decodeBatchCredential(rawCreds[i], vcOpts, checkObjectProofs)
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, &CredentialsError{Errs: errs}
+		}
 	}
+
+	return creds, signers, nil
+}
+
+func decodeBatchCredential(rawCred interface{}, vcOpts *credentialOpts,
+	checkObjectProof bool) (interface{}, []string, error) {
+	decodeOpts, signers := recordSigners(vcOpts)
+
+	if sCred, ok := rawCred.(string); ok {
+		cred, err := decodeRaw([]byte(sCred), decodeOpts)
+
+		return cred, signers.signers, err
+	}
+
+	if vcJWT, enveloped, err := envelopedCredentialJWTOfRaw(rawCred); enveloped {
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cred, err := decodeEnvelopedCredential(vcJWT, decodeOpts)
+
+		return cred, signers.signers, err
+	}
+
+	if !checkObjectProof {
+		return rawCred, nil, nil
+	}
+
+	if err := checkCredentialObjectProof(rawCred, decodeOpts); err != nil {
+		return nil, nil, err
+	}
+
+	return rawCred, signers.signers, nil
+}
+
+// checkCredentialObjectProof checks the embedded proof of a credential embedded into presentation as JSON object.
+func checkCredentialObjectProof(rawCred interface{}, vcOpts *credentialOpts) error {
+	credBytes, err := json.Marshal(rawCred)
+	if err != nil {
+		return fmt.Errorf("marshal credential: %w", err)
+	}
+
+	_, err = checkEmbeddedProof(credBytes, getEmbeddedProofCheckOpts(vcOpts))
+
+	return err
+}
+
+// envelopedCredentialJWTOfRaw returns the JWT of a credential embedded into presentation
+// if it is an EnvelopedVerifiableCredential.
+func envelopedCredentialJWTOfRaw(rawCred interface{}) (string, bool, error) {
+	credMap, ok := rawCred.(map[string]interface{})
+	if !ok {
+		return "", false, nil
+	}
+
+	return envelopedCredentialJWTFromMap(credMap)
 }
 
 func mapOpts(vpOpts *presentationOpts) *credentialOpts {
@@ -523,8 +716,12 @@ func mapOpts(vpOpts *presentationOpts) *credentialOpts {
 	}
 }
 
-func validateVP(data []byte, opts *presentationOpts) error {
-	err := validateVPJSONSchema(data)
+func validateVP(data []byte, dataModel DataModelVersion, opts *presentationOpts) error {
+	if !opts.dataModelVersions.accepts(dataModel) {
+		return fmt.Errorf("verifiable presentation data model %s is not accepted", dataModel)
+	}
+
+	err := validateVPJSONSchema(data, dataModel)
 	if err != nil {
 		return err
 	}
@@ -536,10 +733,15 @@ func validateVPJSONLD(vpBytes []byte, opts *presentationOpts) error {
 	return compactJSONLD(string(vpBytes), &opts.jsonldCredentialOpts, opts.strictValidation)
 }
 
-func validateVPJSONSchema(data []byte) error {
+func validateVPJSONSchema(data []byte, dataModel DataModelVersion) error {
 	loader := gojsonschema.NewStringLoader(string(data))
 
-	result, err := gojsonschema.Validate(basePresentationSchemaLoader, loader)
+	schemaLoader := basePresentationSchemaLoader
+	if dataModel == DataModelV2 {
+		schemaLoader = basePresentationSchemaV2Loader
+	}
+
+	result, err := gojsonschema.Validate(schemaLoader, loader)
 	if err != nil {
 		return fmt.Errorf("validation of verifiable credential: %w", err)
 	}
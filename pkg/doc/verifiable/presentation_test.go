@@ -457,21 +457,22 @@ func TestPresentation_decodeCredentials(t *testing.T) {
 	// single credential - JWS
 	opts := defaultPresentationOpts()
 	opts.publicKeyFetcher = SingleKey(signer.PublicKeyBytes(), kms.ED25519)
-	dCreds, err := decodeCredentials(jws, opts)
+	dCreds, signers, err := decodeCredentials(jws, opts)
 	r.NoError(err)
 	r.Len(dCreds, 1)
+	r.Equal([][]string{{vc.Issuer.ID + "#k1"}}, signers)
 
 	// no credential
-	dCreds, err = decodeCredentials(nil, opts)
+	dCreds, _, err = decodeCredentials(nil, opts)
 	r.NoError(err)
 	r.Len(dCreds, 0)
-	dCreds, err = decodeCredentials([]interface{}{}, opts)
+	dCreds, _, err = decodeCredentials([]interface{}{}, opts)
 	r.NoError(err)
 	r.Len(dCreds, 0)
 
 	// single credential - JWS decoding failed (e.g. to no public key fetcher available)
 	opts.publicKeyFetcher = nil
-	_, err = decodeCredentials(jws, opts)
+	_, _, err = decodeCredentials(jws, opts)
 	r.Error(err)
 }
 
@@ -0,0 +1,366 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zcapld
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CapabilityInvocationHeader is the HTTP header giving the invoked capability and action of an invocation.
+	CapabilityInvocationHeader = "Capability-Invocation"
+
+	authorizationHeader = "Authorization"
+	digestHeader        = "Digest"
+
+	signatureScheme  = "Signature "
+	invocationPrefix = "zcap "
+	digestAlgorithm  = "SHA-256="
+	requestTarget    = "(request-target)"
+	keyIDParam       = "(key-id)"
+	createdParam     = "(created)"
+	expiresParam     = "(expires)"
+
+	defaultInvocationExpiry = 5 * time.Minute
+
+	// maxClockSkew is the tolerance for invocations created in the future.
+	maxClockSkew = time.Minute
+)
+
+// Signer signs capability invocations, for instance suite.CryptoSigner signing with a key held in a KMS.
+type Signer interface {
+	Sign(data []byte) ([]byte, error)
+}
+
+// Invoker invokes a capability by signing HTTP requests with HTTP signatures
+// (https://tools.ietf.org/html/draft-cavage-http-signatures-12). The capability and action are given in a
+// Capability-Invocation header, which embeds delegated capabilities, the body is bound with a Digest header and the
+// signature is given in an Authorization header.
+//
+// Invoker can authorize the requests of the webkms and EDV clients, which invoke capabilities through an
+// InvokeCapability(req *http.Request, action string) error method.
+type Invoker struct {
+	capability *Capability
+	keyID      string
+	signer     Signer
+	expiry     time.Duration
+}
+
+// InvokerOption configures an Invoker.
+type InvokerOption func(i *Invoker)
+
+// WithInvocationExpiry sets the validity of the signatures of invocations, 5 minutes by default.
+func WithInvocationExpiry(expiry time.Duration) InvokerOption {
+	return func(i *Invoker) {
+		i.expiry = expiry
+	}
+}
+
+// NewInvoker returns an Invoker of capability signing with signer, with the key of the verification method keyID.
+func NewInvoker(capability *Capability, keyID string, signer Signer, opts ...InvokerOption) *Invoker {
+	i := &Invoker{
+		capability: capability,
+		keyID:      keyID,
+		signer:     signer,
+		expiry:     defaultInvocationExpiry,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// InvokeCapability signs req as an invocation of the capability for action.
+func (i *Invoker) InvokeCapability(req *http.Request, action string) error {
+	invocation := fmt.Sprintf(`%sid="%s",action="%s"`, invocationPrefix, i.capability.ID, action)
+
+	if i.capability.ParentCapability != "" {
+		data, err := json.Marshal(i.capability)
+		if err != nil {
+			return fmt.Errorf("failed to marshal capability: %w", err)
+		}
+
+		invocation += fmt.Sprintf(`,capability="%s"`, base64.RawURLEncoding.EncodeToString(data))
+	}
+
+	req.Header.Set(CapabilityInvocationHeader, invocation)
+
+	headers := []string{keyIDParam, createdParam, expiresParam, requestTarget, "host",
+		strings.ToLower(CapabilityInvocationHeader)}
+
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set(digestHeader, digest(body))
+
+		headers = append(headers, "content-type", strings.ToLower(digestHeader))
+	}
+
+	now := time.Now()
+
+	params := map[string]string{
+		"keyId":   i.keyID,
+		"headers": strings.Join(headers, " "),
+		"created": strconv.FormatInt(now.Unix(), 10),
+		"expires": strconv.FormatInt(now.Add(i.expiry).Unix(), 10),
+	}
+
+	signature, err := i.signer.Sign([]byte(signingString(req, headers, params)))
+	if err != nil {
+		return fmt.Errorf("failed to sign capability invocation: %w", err)
+	}
+
+	req.Header.Set(authorizationHeader, fmt.Sprintf(
+		`%skeyId="%s",headers="%s",signature="%s",created="%s",expires="%s"`, signatureScheme, params["keyId"],
+		params["headers"], base64.StdEncoding.EncodeToString(signature), params["created"], params["expires"]))
+
+	return nil
+}
+
+// Invocation is a verified capability invocation.
+type Invocation struct {
+	// Capability is the invoked capability.
+	Capability *Capability
+	// Root is the root capability of the invoked capability.
+	Root   *Capability
+	Action string
+	// KeyID is the verification method of the signature of the invocation.
+	KeyID string
+}
+
+// VerifyInvocation verifies that req is a signed invocation of a valid capability by one of its invokers, for an
+// allowed action. Invocations of root capabilities reference them by ID, those of delegated capabilities embed them.
+// It is up to the caller to check that the root capability targets the requested resource. Errors wrapping
+// ErrUnauthorized are returned for invalid invocations.
+func (v *Verifier) VerifyInvocation(req *http.Request) (*Invocation, error) {
+	invocation, err := v.parseInvocation(req.Header.Get(CapabilityInvocationHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := v.verifyCapability(invocation.Capability, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	invocation.Root = verified.root
+
+	if invocation.Capability.ParentCapability == "" {
+		invocation.Capability = verified.root
+	}
+
+	if !allows(verified.allowedActions, invocation.Action) {
+		return nil, fmt.Errorf("%w: action %s is not allowed", ErrUnauthorized, invocation.Action)
+	}
+
+	authorization := req.Header.Get(authorizationHeader)
+	if !strings.HasPrefix(authorization, signatureScheme) {
+		return nil, fmt.Errorf("%w: missing HTTP signature", ErrUnauthorized)
+	}
+
+	params := parseParams(strings.TrimPrefix(authorization, signatureScheme))
+
+	invocation.KeyID = params["keyId"]
+
+	if !controls(invokerOf(invocation.Capability), invocation.KeyID) {
+		return nil, fmt.Errorf("%w: %s may not invoke capability %s", ErrUnauthorized, invocation.KeyID,
+			invocation.Capability.ID)
+	}
+
+	err = v.verifyHTTPSignature(req, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return invocation, nil
+}
+
+func (v *Verifier) parseInvocation(header string) (*Invocation, error) {
+	if !strings.HasPrefix(header, invocationPrefix) {
+		return nil, fmt.Errorf("%w: missing %s header", ErrUnauthorized, CapabilityInvocationHeader)
+	}
+
+	params := parseParams(strings.TrimPrefix(header, invocationPrefix))
+
+	if params["id"] == "" || params["action"] == "" {
+		return nil, fmt.Errorf("%w: capability ID and action are required", ErrUnauthorized)
+	}
+
+	invocation := &Invocation{
+		Capability: &Capability{ID: params["id"]},
+		Action:     params["action"],
+	}
+
+	if params["capability"] != "" {
+		data, err := base64.RawURLEncoding.DecodeString(params["capability"])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid capability encoding", ErrUnauthorized)
+		}
+
+		invocation.Capability, err = ParseCapability(data)
+		if err != nil || invocation.Capability.ID != params["id"] {
+			return nil, fmt.Errorf("%w: invalid capability", ErrUnauthorized)
+		}
+	}
+
+	return invocation, nil
+}
+
+func (v *Verifier) verifyHTTPSignature(req *http.Request, params map[string]string) error {
+	headers, err := v.checkSignatureParams(req, params)
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return fmt.Errorf("%w: invalid signature encoding", ErrUnauthorized)
+	}
+
+	pubKey, err := v.keys.Resolve(params["keyId"])
+	if err != nil {
+		return fmt.Errorf("%w: failed to resolve key %s: %s", ErrUnauthorized, params["keyId"], err.Error())
+	}
+
+	err = v.signatureVerifier.Verify(pubKey, []byte(signingString(req, headers, params)), signature)
+	if err != nil {
+		return fmt.Errorf("%w: invalid signature: %s", ErrUnauthorized, err.Error())
+	}
+
+	return nil
+}
+
+func (v *Verifier) checkSignatureParams(req *http.Request, params map[string]string) ([]string, error) {
+	headers := strings.Fields(params["headers"])
+
+	covered := make(map[string]bool, len(headers))
+
+	for _, header := range headers {
+		covered[header] = true
+	}
+
+	for _, required := range []string{keyIDParam, expiresParam, requestTarget,
+		strings.ToLower(CapabilityInvocationHeader)} {
+		if !covered[required] {
+			return nil, fmt.Errorf("%w: %s is not signed", ErrUnauthorized, required)
+		}
+	}
+
+	now := v.now()
+
+	expires, err := strconv.ParseInt(params["expires"], 10, 64)
+	if err != nil || now.After(time.Unix(expires, 0)) {
+		return nil, fmt.Errorf("%w: the signature has expired", ErrUnauthorized)
+	}
+
+	if created, e := strconv.ParseInt(params["created"], 10, 64); e != nil ||
+		time.Unix(created, 0).After(now.Add(maxClockSkew)) {
+		return nil, fmt.Errorf("%w: invalid signature creation time", ErrUnauthorized)
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The body must be bound by a signed digest, and a signed digest must match the body, if any.
+	if body != nil || covered[strings.ToLower(digestHeader)] {
+		if !covered[strings.ToLower(digestHeader)] || req.Header.Get(digestHeader) != digest(body) {
+			return nil, fmt.Errorf("%w: the body does not match the signed digest", ErrUnauthorized)
+		}
+	}
+
+	return headers, nil
+}
+
+// signingString returns the string signed for the given headers, as defined in
+// https://tools.ietf.org/html/draft-cavage-http-signatures-12#section-2.3.
+func signingString(req *http.Request, headers []string, params map[string]string) string {
+	lines := make([]string, len(headers))
+
+	for i, header := range headers {
+		var value string
+
+		switch header {
+		case requestTarget:
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case keyIDParam:
+			value = params["keyId"]
+		case createdParam:
+			value = params["created"]
+		case expiresParam:
+			value = params["expires"]
+		case "host":
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		default:
+			value = strings.Join(req.Header.Values(header), ", ")
+		}
+
+		lines[i] = header + ": " + value
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseParams parses comma separated name="value" parameters.
+func parseParams(s string) map[string]string {
+	params := make(map[string]string)
+
+	for _, param := range strings.Split(s, `",`) {
+		i := strings.Index(param, `="`)
+		if i < 0 {
+			continue
+		}
+
+		params[strings.TrimSpace(param[:i])] = strings.TrimSuffix(param[i+2:], `"`)
+	}
+
+	return params
+}
+
+// readBody returns the body of req, or nil if it has none, leaving it readable.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	return body, nil
+}
+
+func digest(body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return digestAlgorithm + base64.StdEncoding.EncodeToString(sum[:])
+}
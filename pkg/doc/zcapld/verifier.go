@@ -0,0 +1,409 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zcapld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// ErrUnauthorized is wrapped by the errors returned when a capability or an invocation is not valid.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// KeyResolver resolves the public keys of verification methods.
+type KeyResolver interface {
+	Resolve(keyID string) (*verifier.PublicKey, error)
+}
+
+// CapabilityResolver resolves the root capabilities trusted by a Verifier.
+type CapabilityResolver interface {
+	Resolve(id string) (*Capability, error)
+}
+
+// SimpleCapabilityResolver is a CapabilityResolver of a fixed set of capabilities, indexed by ID.
+type SimpleCapabilityResolver map[string]*Capability
+
+// Resolve resolves a capability by ID.
+func (r SimpleCapabilityResolver) Resolve(id string) (*Capability, error) {
+	c, ok := r[id]
+	if !ok {
+		return nil, fmt.Errorf("capability %s not found", id)
+	}
+
+	return c, nil
+}
+
+// Verifier verifies delegated capabilities and capability invocations.
+type Verifier struct {
+	roots             CapabilityResolver
+	keys              KeyResolver
+	suites            []verifier.SignatureSuite
+	signatureVerifier signatureVerifier
+	documentLoader    ld.DocumentLoader
+	now               func() time.Time
+}
+
+type signatureVerifier interface {
+	Verify(pubKey *verifier.PublicKey, msg, signature []byte) error
+}
+
+// defaultSignatureVerifier verifies Ed25519 signatures of raw public keys, and Ed25519 and ECDSA signatures of JWKs.
+type defaultSignatureVerifier struct{}
+
+func (d *defaultSignatureVerifier) Verify(pubKey *verifier.PublicKey, msg, signature []byte) error {
+	if pubKey.JWK == nil {
+		return verifier.NewEd25519SignatureVerifier().Verify(pubKey, msg, signature)
+	}
+
+	return verifier.NewCompositePublicKeyVerifier([]verifier.SignatureVerifier{
+		verifier.NewEd25519SignatureVerifier(),
+		verifier.NewECDSAES256SignatureVerifier(),
+		verifier.NewECDSAES384SignatureVerifier(),
+		verifier.NewECDSAES521SignatureVerifier(),
+		verifier.NewECDSASecp256k1SignatureVerifier(),
+	}).Verify(pubKey, msg, signature)
+}
+
+// VerifierOption configures a Verifier.
+type VerifierOption func(v *Verifier)
+
+// WithSignatureSuites sets the signature suites of the proofs of delegated capabilities, Ed25519Signature2018 and
+// JsonWebSignature2020 by default.
+func WithSignatureSuites(suites ...verifier.SignatureSuite) VerifierOption {
+	return func(v *Verifier) {
+		v.suites = suites
+	}
+}
+
+// WithInvocationSignatureVerifier sets the verifier of the HTTP signatures of invocations, which by default verifies
+// Ed25519 signatures, and ECDSA signatures of keys resolved as JWKs.
+func WithInvocationSignatureVerifier(sv signatureVerifier) VerifierOption {
+	return func(v *Verifier) {
+		v.signatureVerifier = sv
+	}
+}
+
+// WithDocumentLoader sets the JSON-LD document loader used to verify the proofs of delegated capabilities.
+func WithDocumentLoader(loader ld.DocumentLoader) VerifierOption {
+	return func(v *Verifier) {
+		v.documentLoader = loader
+	}
+}
+
+// NewVerifier returns a Verifier trusting the root capabilities resolved by roots, and resolving the keys of proofs
+// and invocations with keys.
+func NewVerifier(roots CapabilityResolver, keys KeyResolver, opts ...VerifierOption) *Verifier {
+	v := &Verifier{
+		roots: roots,
+		keys:  keys,
+		suites: []verifier.SignatureSuite{
+			ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())),
+			jsonwebsignature2020.New(suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier())),
+		},
+		signatureVerifier: &defaultSignatureVerifier{},
+		now:               time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	v.documentLoader = documentLoader(v.documentLoader)
+
+	return v
+}
+
+// VerifyCapability verifies that c is a trusted root capability, or a capability validly delegated from one, and
+// that none of the caveats of its delegation chain prevent its use.
+func (v *Verifier) VerifyCapability(c *Capability) error {
+	_, err := v.verifyCapability(c, nil)
+
+	return err
+}
+
+// verifiedCapability is the result of the verification of a capability.
+type verifiedCapability struct {
+	root *Capability
+	// allowedActions are the actions allowed by all the capabilities of the chain, nil if all actions are allowed.
+	allowedActions []string
+}
+
+// verifyCapability verifies c, whose ancestors from the root have the given IDs if c is the parent of another
+// capability, and returns the root of c.
+func (v *Verifier) verifyCapability(c *Capability, ancestors []string) (*verifiedCapability, error) {
+	err := v.checkCaveats(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ParentCapability == "" {
+		if len(ancestors) != 0 {
+			return nil, fmt.Errorf("%w: invalid capability chain", ErrUnauthorized)
+		}
+
+		return v.verifyRoot(c)
+	}
+
+	p, chain, err := c.delegationProof()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorized, err.Error())
+	}
+
+	ids, err := chainIDs(chain)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthorized, err.Error())
+	}
+
+	if ancestors != nil && !equal(ids, ancestors) || ids[len(ids)-1] != c.ParentCapability {
+		return nil, fmt.Errorf("%w: invalid capability chain", ErrUnauthorized)
+	}
+
+	parent, err := v.parentCapability(chain[len(chain)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := v.verifyCapability(parent, ids[:len(ids)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.verifyDelegation(c, parent, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.AllowedAction != nil {
+		for _, action := range c.AllowedAction {
+			if !allows(verified.allowedActions, action) {
+				return nil, fmt.Errorf("%w: action %s is not allowed by the parent capability", ErrUnauthorized, action)
+			}
+		}
+
+		verified.allowedActions = c.AllowedAction
+	}
+
+	return verified, nil
+}
+
+func (v *Verifier) verifyRoot(c *Capability) (*verifiedCapability, error) {
+	root, err := v.roots.Resolve(c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: untrusted root capability: %s", ErrUnauthorized, err.Error())
+	}
+
+	if root.ParentCapability != "" {
+		return nil, fmt.Errorf("%w: root capability %s has a parent", ErrUnauthorized, root.ID)
+	}
+
+	err = v.checkCaveats(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verifiedCapability{root: root, allowedActions: root.AllowedAction}, nil
+}
+
+func (v *Verifier) parentCapability(element interface{}) (*Capability, error) {
+	if id, ok := element.(string); ok {
+		return v.verifyRootID(id)
+	}
+
+	data, err := json.Marshal(element)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal parent capability: %w", err)
+	}
+
+	parent, err := ParseCapability(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid parent capability: %s", ErrUnauthorized, err.Error())
+	}
+
+	if parent.ParentCapability == "" {
+		return nil, fmt.Errorf("%w: root capabilities must be referenced by ID", ErrUnauthorized)
+	}
+
+	return parent, nil
+}
+
+func (v *Verifier) verifyRootID(id string) (*Capability, error) {
+	root, err := v.roots.Resolve(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: untrusted root capability: %s", ErrUnauthorized, err.Error())
+	}
+
+	return root, nil
+}
+
+// verifyDelegation verifies that c was delegated from parent by one of its delegators.
+func (v *Verifier) verifyDelegation(c, parent *Capability, p *proof.Proof) error {
+	if c.InvocationTarget != parent.InvocationTarget {
+		return fmt.Errorf("%w: the invocation target differs from the parent capability", ErrUnauthorized)
+	}
+
+	if !controls(delegatorOf(parent), p.VerificationMethod) {
+		return fmt.Errorf("%w: %s may not delegate capability %s", ErrUnauthorized, p.VerificationMethod, parent.ID)
+	}
+
+	doc, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal capability: %w", err)
+	}
+
+	dv, err := verifier.New(v.keys, v.suites...)
+	if err != nil {
+		return fmt.Errorf("failed to create proof verifier: %w", err)
+	}
+
+	err = dv.Verify(doc, jsonld.WithDocumentLoader(v.documentLoader))
+	if err != nil {
+		return fmt.Errorf("%w: invalid proof of capability %s: %s", ErrUnauthorized, c.ID, err.Error())
+	}
+
+	return nil
+}
+
+func (v *Verifier) checkCaveats(c *Capability) error {
+	for _, caveat := range c.Caveats {
+		switch caveat.Type {
+		case ExpirationCaveat:
+			if caveat.Expires == nil || v.now().After(*caveat.Expires) {
+				return fmt.Errorf("%w: capability %s has expired", ErrUnauthorized, c.ID)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported caveat %s", ErrUnauthorized, caveat.Type)
+		}
+	}
+
+	return nil
+}
+
+// delegationProof returns the delegation proof of c and its capability chain.
+func (c *Capability) delegationProof() (*proof.Proof, []interface{}, error) {
+	var doc map[string]interface{}
+
+	err := roundTrip(c, &doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal capability: %w", err)
+	}
+
+	proofs, err := proof.GetProofs(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid proof of capability %s: %w", c.ID, err)
+	}
+
+	for _, p := range proofs {
+		if p.ProofPurpose == CapabilityDelegationPurpose && len(p.CapabilityChain) != 0 {
+			return p, p.CapabilityChain, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("capability %s has no delegation proof", c.ID)
+}
+
+// delegationChain returns the IDs of the ancestors of c from the root.
+func (c *Capability) delegationChain() ([]interface{}, error) {
+	if c.ParentCapability == "" {
+		return nil, nil
+	}
+
+	_, chain, err := c.delegationProof()
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := chainIDs(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, len(ids))
+
+	for i, id := range ids {
+		result[i] = id
+	}
+
+	return result, nil
+}
+
+func chainIDs(chain []interface{}) ([]string, error) {
+	ids := make([]string, len(chain))
+
+	for i, element := range chain {
+		switch e := element.(type) {
+		case string:
+			ids[i] = e
+		case map[string]interface{}:
+			id, ok := e["id"].(string)
+			if !ok || i != len(chain)-1 {
+				return nil, errors.New("only the parent capability may be embedded in the capability chain")
+			}
+
+			ids[i] = id
+		default:
+			return nil, errors.New("invalid capability chain")
+		}
+	}
+
+	return ids, nil
+}
+
+func delegatorOf(c *Capability) string {
+	if c.Delegator != "" {
+		return c.Delegator
+	}
+
+	return c.Controller
+}
+
+func invokerOf(c *Capability) string {
+	if c.Invoker != "" {
+		return c.Invoker
+	}
+
+	return c.Controller
+}
+
+// allows tells whether action is one of allowedActions, nil allowing all actions.
+func allows(allowedActions []string, action string) bool {
+	if allowedActions == nil {
+		return true
+	}
+
+	for _, allowed := range allowedActions {
+		if allowed == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,251 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package zcapld implements ZCAP-LD authorization capabilities (https://w3c-ccg.github.io/zcap-ld/): root
+// capabilities, capabilities delegated with Linked Data proofs, capability invocations signed as HTTP signatures and
+// the verification of delegation chains and invocations.
+package zcapld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+)
+
+const (
+	// SecurityContextV2 is the JSON-LD context of capabilities.
+	SecurityContextV2 = "https://w3id.org/security/v2"
+
+	// CapabilityDelegationPurpose is the proof purpose of the proofs of delegated capabilities.
+	CapabilityDelegationPurpose = "capabilityDelegation"
+
+	// ExpirationCaveat is the type of the caveats restricting the use of a capability to before Caveat.Expires.
+	ExpirationCaveat = "ExpirationCaveat"
+
+	securityVocab = "https://w3id.org/security#"
+)
+
+// Capability is a ZCAP-LD authorization capability. Root capabilities have no parent capability and are not signed,
+// they are trusted by the party verifying their invocations. Delegated capabilities are signed by a delegator of
+// their parent capability, with a proof whose capability chain lists the IDs of their ancestors from the root and
+// embeds their parent.
+type Capability struct {
+	Context          []interface{} `json:"@context"`
+	ID               string        `json:"id"`
+	Controller       string        `json:"controller,omitempty"`
+	Invoker          string        `json:"invoker,omitempty"`
+	Delegator        string        `json:"delegator,omitempty"`
+	ParentCapability string        `json:"parentCapability,omitempty"`
+	InvocationTarget string        `json:"invocationTarget,omitempty"`
+	AllowedAction    []string      `json:"allowedAction,omitempty"`
+	Caveats          []Caveat      `json:"caveat,omitempty"`
+	Proof            interface{}   `json:"proof,omitempty"`
+}
+
+// Caveat restricts the use of a capability and of the capabilities delegated from it.
+type Caveat struct {
+	Type    string     `json:"type"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// CapabilityOption configures a new capability.
+type CapabilityOption func(c *Capability)
+
+// WithID sets the ID of the capability, a random URN by default.
+func WithID(id string) CapabilityOption {
+	return func(c *Capability) {
+		c.ID = id
+	}
+}
+
+// WithController sets the controller of the capability, who may both invoke and delegate it.
+func WithController(controller string) CapabilityOption {
+	return func(c *Capability) {
+		c.Controller = controller
+	}
+}
+
+// WithInvoker sets who may invoke the capability.
+func WithInvoker(invoker string) CapabilityOption {
+	return func(c *Capability) {
+		c.Invoker = invoker
+	}
+}
+
+// WithDelegator sets who may delegate the capability.
+func WithDelegator(delegator string) CapabilityOption {
+	return func(c *Capability) {
+		c.Delegator = delegator
+	}
+}
+
+// WithAllowedActions restricts the actions the capability may be invoked for. Delegated capabilities may only allow
+// actions allowed by their parent.
+func WithAllowedActions(actions ...string) CapabilityOption {
+	return func(c *Capability) {
+		c.AllowedAction = actions
+	}
+}
+
+// WithExpiry adds an ExpirationCaveat to the capability.
+func WithExpiry(expires time.Time) CapabilityOption {
+	return func(c *Capability) {
+		expires = expires.UTC().Truncate(time.Second)
+
+		c.Caveats = append(c.Caveats, Caveat{Type: ExpirationCaveat, Expires: &expires})
+	}
+}
+
+// WithCaveats adds caveats to the capability.
+func WithCaveats(caveats ...Caveat) CapabilityOption {
+	return func(c *Capability) {
+		c.Caveats = append(c.Caveats, caveats...)
+	}
+}
+
+// NewRootCapability returns the root capability of the given invocation target, controlled by controller.
+func NewRootCapability(invocationTarget, controller string, opts ...CapabilityOption) *Capability {
+	c := &Capability{
+		Context:          capabilityContext(),
+		ID:               invocationTarget,
+		Controller:       controller,
+		InvocationTarget: invocationTarget,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ProofContext holds the options of the proof of a delegated capability.
+type ProofContext struct {
+	SignatureType      string                // required
+	Suite              signer.SignatureSuite // required
+	VerificationMethod string                // required
+	Created            *time.Time            // optional
+	DocumentLoader     ld.DocumentLoader     // optional
+}
+
+// Delegate returns a capability delegated from parent, signed with a key of a delegator of parent as configured by
+// context.
+func Delegate(parent *Capability, context *ProofContext, opts ...CapabilityOption) (*Capability, error) {
+	if context.Suite == nil || context.SignatureType == "" || context.VerificationMethod == "" {
+		return nil, errors.New("signature type, suite and verification method are required")
+	}
+
+	c := &Capability{
+		Context:          capabilityContext(),
+		ID:               "urn:uuid:" + uuid.New().String(),
+		ParentCapability: parent.ID,
+		InvocationTarget: parent.InvocationTarget,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	chain, err := parent.delegationChain()
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent capability: %w", err)
+	}
+
+	if parent.ParentCapability == "" {
+		chain = append(chain, parent.ID)
+	} else {
+		chain = append(chain, parent)
+	}
+
+	doc, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal capability: %w", err)
+	}
+
+	// round-trip the chain so that the embedded parent is signed as it will be verified
+	var capabilityChain []interface{}
+
+	err = roundTrip(chain, &capabilityChain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal capability chain: %w", err)
+	}
+
+	signed, err := signer.New(context.Suite).Sign(&signer.Context{
+		SignatureType:      context.SignatureType,
+		Created:            context.Created,
+		VerificationMethod: context.VerificationMethod,
+		Purpose:            CapabilityDelegationPurpose,
+		CapabilityChain:    capabilityChain,
+	}, doc, jsonld.WithDocumentLoader(documentLoader(context.DocumentLoader)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign capability: %w", err)
+	}
+
+	return ParseCapability(signed)
+}
+
+// ParseCapability parses a capability.
+func ParseCapability(data []byte) (*Capability, error) {
+	c := &Capability{}
+
+	err := json.Unmarshal(data, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal capability: %w", err)
+	}
+
+	if c.ID == "" {
+		return nil, errors.New("capability ID is required")
+	}
+
+	return c, nil
+}
+
+// CachingJSONLDLoader returns a JSON-LD document loader with the contexts of capabilities preloaded.
+func CachingJSONLDLoader() ld.DocumentLoader {
+	return did.CachingJSONLDLoader()
+}
+
+func capabilityContext() []interface{} {
+	return []interface{}{
+		SecurityContextV2,
+		map[string]interface{}{ExpirationCaveat: securityVocab + ExpirationCaveat},
+	}
+}
+
+func documentLoader(loader ld.DocumentLoader) ld.DocumentLoader {
+	if loader == nil {
+		return CachingJSONLDLoader()
+	}
+
+	return loader
+}
+
+func roundTrip(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, dst)
+}
+
+// controls tells whether verificationMethod is a DID URL of the given controller DID.
+func controls(controller, verificationMethod string) bool {
+	didURL, err := did.ParseDIDURL(verificationMethod)
+	if err != nil {
+		return false
+	}
+
+	return didURL.DID.String() == controller
+}
@@ -0,0 +1,310 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package zcapld_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/zcapld"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const target = "https://vaults.example.com/vaults/123"
+
+func TestDelegate(t *testing.T) {
+	alice, bob, carol := newAgent(t), newAgent(t), newAgent(t)
+	keys := keyResolver{alice, bob, carol}
+
+	root := zcapld.NewRootCapability(target, alice.did)
+	verif := zcapld.NewVerifier(zcapld.SimpleCapabilityResolver{root.ID: root}, keys)
+
+	t.Run("root capability", func(t *testing.T) {
+		require.NoError(t, verif.VerifyCapability(root))
+
+		other := zcapld.NewRootCapability("https://vaults.example.com/vaults/456", alice.did)
+		require.True(t, errors.Is(verif.VerifyCapability(other), zcapld.ErrUnauthorized))
+	})
+
+	t.Run("delegation chain", func(t *testing.T) {
+		toBob, err := zcapld.Delegate(root, alice.proofContext(), zcapld.WithController(bob.did),
+			zcapld.WithAllowedActions("read", "write"), zcapld.WithExpiry(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		require.Equal(t, root.ID, toBob.ParentCapability)
+		require.Equal(t, target, toBob.InvocationTarget)
+		require.NoError(t, verif.VerifyCapability(toBob))
+
+		toCarol, err := zcapld.Delegate(toBob, bob.proofContext(), zcapld.WithInvoker(carol.did),
+			zcapld.WithAllowedActions("read"))
+		require.NoError(t, err)
+		require.NoError(t, verif.VerifyCapability(toCarol))
+
+		data, err := json.Marshal(toCarol)
+		require.NoError(t, err)
+
+		parsed, err := zcapld.ParseCapability(data)
+		require.NoError(t, err)
+		require.NoError(t, verif.VerifyCapability(parsed))
+	})
+
+	t.Run("delegation by a party who is not a delegator", func(t *testing.T) {
+		toCarol, err := zcapld.Delegate(root, bob.proofContext(), zcapld.WithController(carol.did))
+		require.NoError(t, err)
+
+		err = verif.VerifyCapability(toCarol)
+		require.True(t, errors.Is(err, zcapld.ErrUnauthorized))
+		require.Contains(t, err.Error(), "may not delegate")
+	})
+
+	t.Run("delegation of actions not allowed by the parent", func(t *testing.T) {
+		toBob, err := zcapld.Delegate(root, alice.proofContext(), zcapld.WithController(bob.did),
+			zcapld.WithAllowedActions("read"))
+		require.NoError(t, err)
+
+		toCarol, err := zcapld.Delegate(toBob, bob.proofContext(), zcapld.WithController(carol.did),
+			zcapld.WithAllowedActions("read", "write"))
+		require.NoError(t, err)
+
+		err = verif.VerifyCapability(toCarol)
+		require.True(t, errors.Is(err, zcapld.ErrUnauthorized))
+		require.Contains(t, err.Error(), "action write is not allowed")
+	})
+
+	t.Run("expired parent capability", func(t *testing.T) {
+		toBob, err := zcapld.Delegate(root, alice.proofContext(), zcapld.WithController(bob.did),
+			zcapld.WithExpiry(time.Now().Add(-time.Minute)))
+		require.NoError(t, err)
+
+		toCarol, err := zcapld.Delegate(toBob, bob.proofContext(), zcapld.WithController(carol.did))
+		require.NoError(t, err)
+
+		err = verif.VerifyCapability(toCarol)
+		require.True(t, errors.Is(err, zcapld.ErrUnauthorized))
+		require.Contains(t, err.Error(), "has expired")
+	})
+
+	t.Run("unsupported caveat", func(t *testing.T) {
+		restricted := zcapld.NewRootCapability(target, alice.did, zcapld.WithCaveats(zcapld.Caveat{Type: "UnknownCaveat"}))
+		v := zcapld.NewVerifier(zcapld.SimpleCapabilityResolver{restricted.ID: restricted}, keys)
+
+		err := v.VerifyCapability(restricted)
+		require.True(t, errors.Is(err, zcapld.ErrUnauthorized))
+		require.Contains(t, err.Error(), "unsupported caveat")
+	})
+
+	t.Run("tampered capability", func(t *testing.T) {
+		toBob, err := zcapld.Delegate(root, alice.proofContext(), zcapld.WithController(bob.did),
+			zcapld.WithAllowedActions("read"))
+		require.NoError(t, err)
+
+		toBob.AllowedAction = nil
+
+		err = verif.VerifyCapability(toBob)
+		require.True(t, errors.Is(err, zcapld.ErrUnauthorized))
+		require.Contains(t, err.Error(), "invalid proof")
+	})
+
+	t.Run("tampered parent capability", func(t *testing.T) {
+		toBob, err := zcapld.Delegate(root, alice.proofContext(), zcapld.WithController(bob.did),
+			zcapld.WithAllowedActions("read"))
+		require.NoError(t, err)
+
+		// bob signs a delegation embedding a parent he altered
+		toBob.AllowedAction = nil
+
+		toCarol, err := zcapld.Delegate(toBob, bob.proofContext(), zcapld.WithController(carol.did))
+		require.NoError(t, err)
+
+		require.True(t, errors.Is(verif.VerifyCapability(toCarol), zcapld.ErrUnauthorized))
+	})
+
+	t.Run("missing delegation proof", func(t *testing.T) {
+		c := zcapld.NewRootCapability(target, alice.did)
+		c.ID = "urn:uuid:123"
+		c.ParentCapability = root.ID
+
+		require.True(t, errors.Is(verif.VerifyCapability(c), zcapld.ErrUnauthorized))
+	})
+
+	t.Run("invalid proof context", func(t *testing.T) {
+		_, err := zcapld.Delegate(root, &zcapld.ProofContext{})
+		require.Error(t, err)
+	})
+}
+
+func TestInvoker(t *testing.T) {
+	alice, bob, carol := newAgent(t), newAgent(t), newAgent(t)
+
+	root := zcapld.NewRootCapability(target, alice.did)
+	verif := zcapld.NewVerifier(zcapld.SimpleCapabilityResolver{root.ID: root}, keyResolver{alice, bob, carol})
+
+	var invocation *zcapld.Invocation
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var err error
+
+		invocation, err = verif.VerifyInvocation(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	send := func(invoker *zcapld.Invoker, action string, body []byte, tamper func(req *http.Request)) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/vaults/123/documents", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/json")
+
+		require.NoError(t, invoker.InvokeCapability(req, action))
+
+		if tamper != nil {
+			tamper(req)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		return resp.StatusCode
+	}
+
+	toBob, err := zcapld.Delegate(root, alice.proofContext(), zcapld.WithInvoker(bob.did),
+		zcapld.WithAllowedActions("read"))
+	require.NoError(t, err)
+
+	t.Run("invocation of the root capability", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(root, alice.keyID, alice.signer)
+
+		require.Equal(t, http.StatusOK, send(invoker, "write", []byte(`{"id":"doc"}`), nil))
+		require.Equal(t, root.ID, invocation.Capability.ID)
+		require.Equal(t, root.ID, invocation.Root.ID)
+		require.Equal(t, "write", invocation.Action)
+		require.Equal(t, alice.keyID, invocation.KeyID)
+	})
+
+	t.Run("invocation of a delegated capability", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(toBob, bob.keyID, bob.signer)
+
+		require.Equal(t, http.StatusOK, send(invoker, "read", nil, nil))
+		require.Equal(t, toBob.ID, invocation.Capability.ID)
+		require.Equal(t, root.ID, invocation.Root.ID)
+		require.Equal(t, bob.keyID, invocation.KeyID)
+	})
+
+	t.Run("invocation for an action that is not allowed", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(toBob, bob.keyID, bob.signer)
+
+		require.Equal(t, http.StatusForbidden, send(invoker, "write", nil, nil))
+	})
+
+	t.Run("invocation by a party who is not an invoker", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(toBob, carol.keyID, carol.signer)
+
+		require.Equal(t, http.StatusForbidden, send(invoker, "read", nil, nil))
+	})
+
+	t.Run("invocation with a key ID that is not a DID URL", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(root, strings.TrimPrefix(alice.keyID, alice.did), alice.signer)
+
+		require.Equal(t, http.StatusForbidden, send(invoker, "read", nil, nil))
+	})
+
+	t.Run("invocation signed with another key", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(toBob, bob.keyID, carol.signer)
+
+		require.Equal(t, http.StatusForbidden, send(invoker, "read", nil, nil))
+	})
+
+	t.Run("expired invocation", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(root, alice.keyID, alice.signer, zcapld.WithInvocationExpiry(-time.Minute))
+
+		require.Equal(t, http.StatusForbidden, send(invoker, "read", nil, nil))
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		invoker := zcapld.NewInvoker(root, alice.keyID, alice.signer)
+
+		require.Equal(t, http.StatusForbidden, send(invoker, "write", []byte(`{"id":"doc"}`), func(req *http.Request) {
+			req.Body = ioutil.NopCloser(bytes.NewReader([]byte(`{"id":"bad"}`)))
+		}))
+	})
+
+	t.Run("missing invocation", func(t *testing.T) {
+		resp, err := http.Post(server.URL, "application/json", nil) //nolint:noctx
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+type agent struct {
+	did    string
+	keyID  string
+	pubKey []byte
+	signer *suite.CryptoSigner
+}
+
+func newAgent(t *testing.T) *agent {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kid, kh, err := km.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, err := km.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+
+	did, keyID := fingerprint.CreateDIDKey(pubKey)
+
+	return &agent{did: did, keyID: keyID, pubKey: pubKey, signer: suite.NewCryptoSigner(cr, kh)}
+}
+
+func (a *agent) proofContext() *zcapld.ProofContext {
+	return &zcapld.ProofContext{
+		SignatureType:      ed25519signature2018.SignatureType,
+		Suite:              ed25519signature2018.New(suite.WithSigner(a.signer)),
+		VerificationMethod: a.keyID,
+	}
+}
+
+type keyResolver []*agent
+
+func (r keyResolver) Resolve(keyID string) (*verifier.PublicKey, error) {
+	for _, a := range r {
+		if a.keyID == keyID {
+			return &verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: a.pubKey}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key %s not found", keyID)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// ErrInvalidDIDURL is returned when a DID URL to dereference is not valid.
+var ErrInvalidDIDURL = errors.New("invalid DID URL")
+
+// ErrResourceNotFound is returned when the DID of a DID URL is resolved, but the DID URL does not identify any
+// resource of its DID document. ErrNotFound is returned when the DID itself is not found.
+var ErrResourceNotFound = errors.New("DID URL resource not found")
+
+// DereferenceResult is the resource a DID URL dereferences to (https://www.w3.org/TR/did-core/#did-url-dereferencing).
+// DIDDocument and DocumentMetadata are always set. VerificationMethod is set for the fragments of verification
+// methods. Service and ServiceEndpoint are set for the fragments of services and the service parameter, in which case
+// ServiceEndpoint is the endpoint of the service, resolved against the relativeRef parameter if any.
+type DereferenceResult struct {
+	DIDDocument        *did.Doc
+	DocumentMetadata   *did.DocumentMetadata
+	VerificationMethod *did.VerificationMethod
+	Service            *did.Service
+	ServiceEndpoint    string
+}
+
+// ParseDIDURL parses a DID URL to dereference. It returns the resolve options of its versionId and versionTime
+// parameters, to resolve its DID with.
+func ParseDIDURL(didURL string) (*did.DIDURL, []ResolveOption, error) {
+	parsed, err := did.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidDIDURL, err.Error())
+	}
+
+	var opts []ResolveOption
+
+	if versionID := parsed.Queries.Get(did.VersionIDParam); versionID != "" {
+		opts = append(opts, WithVersionID(versionID))
+	}
+
+	if versionTime := parsed.Queries.Get(did.VersionTimeParam); versionTime != "" {
+		t, err := time.Parse(time.RFC3339, versionTime)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid %s %s", ErrInvalidDIDURL, did.VersionTimeParam, versionTime)
+		}
+
+		opts = append(opts, WithVersionTime(t))
+	}
+
+	return parsed, opts, nil
+}
+
+// DereferenceResolution dereferences a DID URL within the resolution of its DID. ErrResourceNotFound is returned if
+// the DID URL does not identify a resource of the DID document.
+func DereferenceResolution(docResolution *did.DocResolution, didURL *did.DIDURL) (*DereferenceResult, error) {
+	doc := docResolution.DIDDocument
+
+	result := &DereferenceResult{DIDDocument: doc, DocumentMetadata: docResolution.DocumentMetadata}
+	if result.DocumentMetadata == nil {
+		result.DocumentMetadata = &did.DocumentMetadata{}
+	}
+
+	if didURL.Path != "" {
+		return nil, fmt.Errorf("%w: dereferencing DID URL paths is not supported", ErrResourceNotFound)
+	}
+
+	serviceName := didURL.Queries.Get(did.ServiceParam)
+	relativeRef := didURL.Queries.Get(did.RelativeRefParam)
+
+	switch {
+	case serviceName != "":
+		svc, ok := lookupService(doc, didURL, serviceName)
+		if !ok {
+			return nil, fmt.Errorf("%w: service %s", ErrResourceNotFound, serviceName)
+		}
+
+		endpoint, err := serviceEndpoint(svc.ServiceEndpoint, relativeRef, didURL.Fragment)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Service = svc
+		result.ServiceEndpoint = endpoint
+	case relativeRef != "":
+		return nil, fmt.Errorf("%w: %s parameter without %s parameter", ErrInvalidDIDURL, did.RelativeRefParam,
+			did.ServiceParam)
+	case didURL.Fragment != "":
+		if vm, ok := lookupVerificationMethod(doc, didURL, didURL.Fragment); ok {
+			result.VerificationMethod = vm
+
+			return result, nil
+		}
+
+		svc, ok := lookupService(doc, didURL, didURL.Fragment)
+		if !ok {
+			return nil, fmt.Errorf("%w: fragment %s", ErrResourceNotFound, didURL.Fragment)
+		}
+
+		result.Service = svc
+		result.ServiceEndpoint = svc.ServiceEndpoint
+	}
+
+	return result, nil
+}
+
+func lookupVerificationMethod(doc *did.Doc, didURL *did.DIDURL, fragment string) (*did.VerificationMethod, bool) {
+	for i := range doc.VerificationMethod {
+		if isFragmentOf(doc.VerificationMethod[i].ID, doc, didURL, fragment) {
+			return &doc.VerificationMethod[i], true
+		}
+	}
+
+	// verification methods can also be embedded in verification relationships
+	for _, verifications := range doc.VerificationMethods() {
+		for i := range verifications {
+			if isFragmentOf(verifications[i].VerificationMethod.ID, doc, didURL, fragment) {
+				return &verifications[i].VerificationMethod, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func lookupService(doc *did.Doc, didURL *did.DIDURL, fragment string) (*did.Service, bool) {
+	for i := range doc.Service {
+		if isFragmentOf(doc.Service[i].ID, doc, didURL, fragment) {
+			return &doc.Service[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// isFragmentOf returns true if id has the fragment, and is relative or absolute to the DID of the document or of the
+// DID URL, which can differ (e.g. for DIDs with an equivalent ID).
+func isFragmentOf(id string, doc *did.Doc, didURL *did.DIDURL, fragment string) bool {
+	return did.IsFragmentOf(id, doc.ID, fragment) || did.IsFragmentOf(id, didURL.DID.String(), fragment)
+}
+
+func serviceEndpoint(endpoint, relativeRef, fragment string) (string, error) {
+	if relativeRef != "" {
+		base, err := url.Parse(endpoint)
+		if err != nil {
+			return "", fmt.Errorf("invalid service endpoint %s: %w", endpoint, err)
+		}
+
+		ref, err := url.Parse(relativeRef)
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid %s %s", ErrInvalidDIDURL, did.RelativeRefParam, relativeRef)
+		}
+
+		endpoint = base.ResolveReference(ref).String()
+	}
+
+	if fragment != "" {
+		endpoint += "#" + fragment
+	}
+
+	return endpoint, nil
+}
@@ -18,12 +18,16 @@ import (
 // ErrNotFound is returned when a DID resolver does not find the DID.
 var ErrNotFound = errors.New("DID not found")
 
+// ErrMethodNotSupported is returned when no VDR of a registry accepts the method of a DID.
+var ErrMethodNotSupported = errors.New("DID method not supported")
+
 // DIDCommServiceType default DID Communication service endpoint type.
 const DIDCommServiceType = "did-communication"
 
 // Registry vdr registry.
 type Registry interface {
 	Resolve(did string, opts ...ResolveOption) (*did.DocResolution, error)
+	Dereference(didURL string, opts ...ResolveOption) (*DereferenceResult, error)
 	Create(method string, did *did.Doc, opts ...DIDMethodOption) (*did.DocResolution, error)
 	Update(did *did.Doc, opts ...DIDMethodOption) error
 	Deactivate(did string, opts ...DIDMethodOption) error
@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
@@ -19,7 +20,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
@@ -28,6 +31,8 @@ import (
 	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	didcommtransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -56,22 +61,19 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 		frameworkOpts.storeProvider = storeProvider()
 	}
 
-	err := assignVerifiableStoreIfNeeded(frameworkOpts, frameworkOpts.storeProvider)
-	if err != nil {
-		return err
-	}
-
 	// order is important:
 	// - Route depends on MessagePickup
 	// - DIDExchange depends on Route
 	// - OutOfBand depends on DIDExchange
 	// - Introduce depends on OutOfBand
 	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
-		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(),
-		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc())
+		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(frameworkOpts.newLifecycle), newOutOfBandSvc(),
+		newIntroduceSvc(frameworkOpts.newLifecycle), newIssueCredentialSvc(frameworkOpts.newLifecycle),
+		newPresentProofSvc(frameworkOpts.newLifecycle), newTrustPingSvc(),
+		newActionMenuSvc(), newQuestionAnswerSvc(), newDiscoverFeaturesSvc())
 
 	if frameworkOpts.secretLock == nil && frameworkOpts.kmsCreator == nil {
-		err = createDefSecretLock(frameworkOpts)
+		err := createDefSecretLock(frameworkOpts)
 		if err != nil {
 			return err
 		}
@@ -86,19 +88,67 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 	return setAdditionalDefaultOpts(frameworkOpts)
 }
 
-func newExchangeSvc() api.ProtocolSvcCreator {
+type lifecycleCreator func(prv api.Provider, protocolName string) (*lifecycle.Tracker, error)
+
+// newLifecycle creates the tracker of expired and out-of-order messages and stale protocol instances.
+// The tracker is stopped when the framework is closed. There is no tracker if the protocol timeout isn't set,
+// since its records of the protocol instances would then never expire.
+func (a *Aries) newLifecycle(prv api.Provider, protocolName string) (*lifecycle.Tracker, error) {
+	if a.protocolTimeout == 0 {
+		return nil, nil
+	}
+
+	tracker, err := lifecycle.New(prv.StorageProvider(), protocolName, lifecycle.WithTimeout(a.protocolTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("create %s lifecycle: %w", protocolName, err)
+	}
+
+	a.lifecycles = append(a.lifecycles, tracker)
+
+	return tracker, nil
+}
+
+func newExchangeSvc(newLifecycle lifecycleCreator) api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
-		return didexchange.New(prv)
+		service, err := didexchange.New(prv)
+		if err != nil {
+			return nil, err
+		}
+
+		tracker, err := newLifecycle(prv, didexchange.DIDExchange)
+		if err != nil {
+			return nil, err
+		}
+
+		if tracker != nil {
+			service.UseLifecycle(tracker)
+		}
+
+		return service, nil
 	}
 }
 
-func newIntroduceSvc() api.ProtocolSvcCreator {
+func newIntroduceSvc(newLifecycle lifecycleCreator) api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
-		return introduce.New(prv)
+		service, err := introduce.New(prv)
+		if err != nil {
+			return nil, err
+		}
+
+		tracker, err := newLifecycle(prv, introduce.Introduce)
+		if err != nil {
+			return nil, err
+		}
+
+		if tracker != nil {
+			service.UseLifecycle(tracker)
+		}
+
+		return service, nil
 	}
 }
 
-func newIssueCredentialSvc() api.ProtocolSvcCreator {
+func newIssueCredentialSvc(newLifecycle lifecycleCreator) api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		service, err := issuecredential.New(prv)
 		if err != nil {
@@ -108,11 +158,20 @@ func newIssueCredentialSvc() api.ProtocolSvcCreator {
 		// sets default middleware to the service
 		service.Use(mdissuecredential.SaveCredentials(prv))
 
+		tracker, err := newLifecycle(prv, issuecredential.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if tracker != nil {
+			service.UseLifecycle(tracker)
+		}
+
 		return service, nil
 	}
 }
 
-func newPresentProofSvc() api.ProtocolSvcCreator {
+func newPresentProofSvc(newLifecycle lifecycleCreator) api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		service, err := presentproof.New(prv)
 		if err != nil {
@@ -127,6 +186,15 @@ func newPresentProofSvc() api.ProtocolSvcCreator {
 			),
 		)
 
+		tracker, err := newLifecycle(prv, presentproof.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if tracker != nil {
+			service.UseLifecycle(tracker)
+		}
+
 		return service, nil
 	}
 }
@@ -137,6 +205,35 @@ func newRouteSvc() api.ProtocolSvcCreator {
 	}
 }
 
+func newTrustPingSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return trustping.New(prv)
+	}
+}
+
+func newActionMenuSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return actionmenu.New(prv)
+	}
+}
+
+func newQuestionAnswerSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return questionanswer.New(prv)
+	}
+}
+
+func newDiscoverFeaturesSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		registry, ok := prv.(discoverfeatures.Registry)
+		if !ok {
+			return nil, errors.New("failed to cast service registry")
+		}
+
+		return discoverfeatures.New(prv, registry)
+	}
+}
+
 func newMessagePickupSvc() api.ProtocolSvcCreator {
 	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
 		tp, ok := prv.(didcommtransport.Provider)
@@ -9,10 +9,12 @@ package aries
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/lifecycle"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -26,7 +28,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/encrypted"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
@@ -61,8 +65,14 @@ type Aries struct {
 	packers                    []packer.Packer
 	vdrRegistry                vdrapi.Registry
 	vdr                        []vdrapi.VDR
+	vdrCacheSize               int
+	vdrCacheTTL                time.Duration
+	vdrCacheOpts               []vdr.Option
 	verifiableStore            verifiable.Store
+	encryptedStorage           bool
 	transportReturnRoute       string
+	protocolTimeout            time.Duration
+	lifecycles                 []*lifecycle.Tracker
 	id                         string
 }
 
@@ -108,6 +118,15 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, e
 	}
 
+	// Encrypt storage (must be done after KMS and before anything else stores data)
+	if e := createEncryptedStorage(frameworkOpts); e != nil {
+		return nil, e
+	}
+
+	if e := assignVerifiableStoreIfNeeded(frameworkOpts, frameworkOpts.storeProvider); e != nil {
+		return nil, e
+	}
+
 	// Create vdr
 	if e := createVDR(frameworkOpts); e != nil {
 		return nil, e
@@ -128,6 +147,11 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, err
 	}
 
+	// Tag connection records (must be done before the services query them)
+	if err := tagConnectionRecords(frameworkOpts); err != nil {
+		return nil, err
+	}
+
 	// Load services
 	if err := loadServices(frameworkOpts); err != nil {
 		return nil, err
@@ -182,6 +206,21 @@ func WithTransportReturnRoute(transportReturnRoute string) Option {
 	}
 }
 
+// WithProtocolTimeout sets the inactivity period after which the did-exchange, introduce, issue-credential
+// and present-proof protocol instances are abandoned. Their expired and out-of-order messages are rejected only
+// when the timeout is set. By default, the protocol instances never time out.
+func WithProtocolTimeout(timeout time.Duration) Option {
+	return func(opts *Aries) error {
+		if timeout < 0 {
+			return fmt.Errorf("invalid protocol timeout : %s", timeout)
+		}
+
+		opts.protocolTimeout = timeout
+
+		return nil
+	}
+}
+
 // WithStoreProvider injects a storage provider to the Aries framework.
 func WithStoreProvider(prov storage.Provider) Option {
 	return func(opts *Aries) error {
@@ -190,6 +229,15 @@ func WithStoreProvider(prov storage.Provider) Option {
 	}
 }
 
+// WithEncryptedStorage encrypts the values, and blinds the keys and tags, of everything the framework stores with keys
+// created in its KMS. The KMS keys themselves are stored as they are, protected by the secret lock only.
+func WithEncryptedStorage() Option {
+	return func(opts *Aries) error {
+		opts.encryptedStorage = true
+		return nil
+	}
+}
+
 // WithProtocolStateStoreProvider injects a protocol state storage provider to the Aries framework.
 func WithProtocolStateStoreProvider(prov storage.Provider) Option {
 	return func(opts *Aries) error {
@@ -238,6 +286,23 @@ func WithVDR(v vdrapi.VDR) Option {
 	}
 }
 
+// WithVDRCache caches up to size DID resolutions of the VDR registry for ttl. The cached resolutions are persisted
+// to the store of the framework. opts configure the cache further, e.g. vdr.WithMethodCacheTTL and
+// vdr.WithNegativeCacheTTL. Resolutions are not cached by default.
+func WithVDRCache(size int, ttl time.Duration, cacheOpts ...vdr.Option) Option {
+	return func(opts *Aries) error {
+		if size <= 0 || ttl <= 0 {
+			return fmt.Errorf("invalid vdr cache size %d or ttl %s", size, ttl)
+		}
+
+		opts.vdrCacheSize = size
+		opts.vdrCacheTTL = ttl
+		opts.vdrCacheOpts = cacheOpts
+
+		return nil
+	}
+}
+
 // WithMessageServiceProvider injects a message service provider to the Aries framework.
 // Message service provider returns list of message services which can be used to provide custom handle
 // functionality based on incoming messages type and purpose.
@@ -300,6 +365,11 @@ func (a *Aries) Messenger() service.Messenger {
 
 // Close frees resources being maintained by the framework.
 func (a *Aries) Close() error {
+	// the stale protocol instance checks use the stores, stop them first.
+	for _, tracker := range a.lifecycles {
+		tracker.Stop()
+	}
+
 	if a.storeProvider != nil {
 		err := a.storeProvider.Close()
 		if err != nil {
@@ -350,6 +420,33 @@ func createKMS(frameworkOpts *Aries) error {
 	return nil
 }
 
+func createEncryptedStorage(frameworkOpts *Aries) error {
+	if !frameworkOpts.encryptedStorage {
+		return nil
+	}
+
+	storeProvider, err := encrypted.New(frameworkOpts.storeProvider, frameworkOpts.kms, frameworkOpts.crypto)
+	if err != nil {
+		return fmt.Errorf("create encrypted storage failed: %w", err)
+	}
+
+	protocolStateStoreProvider := storeProvider
+
+	if frameworkOpts.protocolStateStoreProvider != frameworkOpts.storeProvider {
+		// the protocol state is encrypted with the keys of the storage, its provider (in-memory by default) can't
+		// keep key IDs across restarts.
+		protocolStateStoreProvider, err = storeProvider.Wrap(frameworkOpts.protocolStateStoreProvider)
+		if err != nil {
+			return fmt.Errorf("create encrypted protocol state storage failed: %w", err)
+		}
+	}
+
+	frameworkOpts.storeProvider = storeProvider
+	frameworkOpts.protocolStateStoreProvider = protocolStateStoreProvider
+
+	return nil
+}
+
 func createVDR(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithKMS(frameworkOpts.kms),
@@ -380,6 +477,16 @@ func createVDR(frameworkOpts *Aries) error {
 	k := key.New()
 	opts = append(opts, vdr.WithVDR(k))
 
+	if frameworkOpts.vdrCacheSize > 0 {
+		cache, err := vdr.NewPersistentCache(ctx.StorageProvider(), frameworkOpts.vdrCacheSize)
+		if err != nil {
+			return fmt.Errorf("create vdr cache failed: %w", err)
+		}
+
+		opts = append(opts, vdr.WithCache(cache, frameworkOpts.vdrCacheTTL))
+		opts = append(opts, frameworkOpts.vdrCacheOpts...)
+	}
+
 	frameworkOpts.vdrRegistry = vdr.New(ctx, opts...)
 
 	return nil
@@ -487,6 +594,25 @@ func loadServices(frameworkOpts *Aries) error {
 	return nil
 }
 
+// tagConnectionRecords tags the connection records saved by previous versions of the framework,
+// so that they are matched by the filtered connection queries.
+func tagConnectionRecords(frameworkOpts *Aries) error {
+	ctx, err := context.New(
+		context.WithStorageProvider(frameworkOpts.storeProvider),
+		context.WithProtocolStateStorageProvider(frameworkOpts.protocolStateStoreProvider),
+	)
+	if err != nil {
+		return fmt.Errorf("create connection recorder context failed: %w", err)
+	}
+
+	recorder, err := connection.NewRecorder(ctx)
+	if err != nil {
+		return fmt.Errorf("create connection recorder failed: %w", err)
+	}
+
+	return recorder.TagConnectionRecords()
+}
+
 func createPackersAndPackager(frameworkOpts *Aries) error {
 	ctx, err := context.New(
 		context.WithCrypto(frameworkOpts.crypto),
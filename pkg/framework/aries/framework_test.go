@@ -10,6 +10,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -18,6 +19,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -26,8 +28,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/actionmenu"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/questionanswer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
@@ -49,7 +55,11 @@ import (
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/encrypted"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 //nolint:lll
@@ -197,6 +207,26 @@ func TestFramework(t *testing.T) {
 
 		_, err = ctx.Service(didexchange.DIDExchange)
 		require.NoError(t, err)
+
+		_, err = ctx.Service(trustping.TrustPing)
+		require.NoError(t, err)
+
+		_, err = ctx.Service(actionmenu.Name)
+		require.NoError(t, err)
+
+		_, err = ctx.Service(questionanswer.Name)
+		require.NoError(t, err)
+
+		svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+		require.NoError(t, err)
+
+		discoverFeaturesSvc, ok := svc.(*discoverfeatures.Service)
+		require.True(t, ok)
+		require.Contains(t, discoverFeaturesSvc.Supported("*"), "https://didcomm.org/trust_ping/1.0")
+		require.Contains(t, discoverFeaturesSvc.Supported("*"), "https://didcomm.org/didexchange/1.0")
+		require.Contains(t, discoverFeaturesSvc.Supported("*"), "https://didcomm.org/action-menu/1.0")
+		require.Contains(t, discoverFeaturesSvc.Supported("*"), "https://didcomm.org/questionanswer/1.0")
+
 		err = aries.Close()
 		require.NoError(t, err)
 	})
@@ -527,6 +557,67 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, s, aries.protocolStateStoreProvider)
 	})
 
+	t.Run("test connection records are tagged", func(t *testing.T) {
+		s := mem.NewProvider()
+
+		store, err := s.OpenStore(connection.Namespace)
+		require.NoError(t, err)
+
+		// the records of previous versions are tagged only with the connection key prefix.
+		record, err := json.Marshal(&connection.Record{ConnectionID: "1", State: connection.StateNameCompleted})
+		require.NoError(t, err)
+		require.NoError(t, store.Put("conn_1", record, spi.Tag{Name: "conn_"}))
+
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}), WithStoreProvider(s))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		lookup, err := connection.NewLookup(ctx)
+		require.NoError(t, err)
+
+		records, err := lookup.QueryConnectionRecordsFiltered(connection.QueryFilter{State: connection.StateNameCompleted})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test new with encrypted storage", func(t *testing.T) {
+		s := mem.NewProvider()
+
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}), WithStoreProvider(s),
+			WithProtocolStateStoreProvider(s), WithEncryptedStorage())
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		encryptedProvider, ok := aries.storeProvider.(*encrypted.Provider)
+		require.True(t, ok)
+		require.Equal(t, encryptedProvider, aries.protocolStateStoreProvider)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		store, err := ctx.StorageProvider().OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		underlyingStore, err := s.OpenStore("store")
+		require.NoError(t, err)
+
+		_, err = underlyingStore.Get("key")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithStoreProvider(mem.NewProvider()), WithEncryptedStorage(),
+			WithKMS(func(ctx kms.Provider) (kms.KeyManager, error) {
+				return &mockkms.KeyManager{CreateKeyErr: errors.New("create failed")}, nil
+			}))
+		require.EqualError(t, err, "create encrypted storage failed: failed to create encryption key: create failed")
+	})
+
 	t.Run("test new with outbound transport service", func(t *testing.T) {
 		aries, err := New(WithOutboundTransports(&didcomm.MockOutboundTransport{ExpectedResponse: "data"},
 			&didcomm.MockOutboundTransport{ExpectedResponse: "data1"}))
@@ -603,6 +694,55 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, mockStore, aries.verifiableStore)
 	})
+
+	t.Run("test vdr cache option", func(t *testing.T) {
+		const didKey = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+		provider := mem.NewProvider()
+
+		aries, err := New(WithStoreProvider(provider), WithVDRCache(10, time.Hour, vdr.WithNegativeCacheTTL(time.Minute)))
+		require.NoError(t, err)
+
+		_, err = aries.vdrRegistry.Resolve(didKey)
+		require.NoError(t, err)
+
+		cache, err := vdr.NewPersistentCache(provider, 10)
+		require.NoError(t, err)
+
+		_, ok := cache.Get(didKey)
+		require.True(t, ok)
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithVDRCache(0, time.Hour))
+		require.EqualError(t, err, "close err: <nil> Error in option passed to New: invalid vdr cache size 0 or ttl 1h0m0s")
+	})
+
+	t.Run("test protocol timeout option", func(t *testing.T) {
+		aries, err := New(WithProtocolTimeout(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, time.Hour, aries.protocolTimeout)
+		require.Len(t, aries.lifecycles, 4)
+		require.NoError(t, aries.Close())
+
+		// closing the framework stops the stale protocol instance checks.
+		for _, tracker := range aries.lifecycles {
+			select {
+			case <-tracker.Done():
+			default:
+				t.Fatal("stale protocol instance checks are still running")
+			}
+		}
+
+		// the protocol instances are not tracked without timeout.
+		aries, err = New()
+		require.NoError(t, err)
+		require.Empty(t, aries.lifecycles)
+		require.NoError(t, aries.Close())
+
+		_, err = New(WithProtocolTimeout(-time.Hour))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid protocol timeout : -1h0m0s")
+	})
 }
 
 func Test_Packager(t *testing.T) {
@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	gocontext "context"
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/common/tracing"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	commontransport "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
@@ -98,6 +101,16 @@ func (p *Provider) Service(id string) (interface{}, error) {
 	return nil, api.ErrSvcNotFound
 }
 
+// AllServices returns all registered protocol services.
+func (p *Provider) AllServices() []dispatcher.ProtocolService {
+	return p.services
+}
+
+// MessageServiceProvider returns the provider of registered message services.
+func (p *Provider) MessageServiceProvider() api.MessageServiceProvider {
+	return p.msgSvcProvider
+}
+
 // KMS returns a Key Management Service.
 func (p *Provider) KMS() kms.KeyManager {
 	return p.kms
@@ -159,40 +172,68 @@ func (p *Provider) tryToHandle(svc service.InboundHandler, msg service.DIDCommMs
 
 // InboundMessageHandler return an inbound message handler.
 func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
+	handler := p.ContextInboundMessageHandler()
+
 	return func(message []byte, myDID, theirDID string) error {
+		return handler(gocontext.Background(), message, myDID, theirDID)
+	}
+}
+
+// ContextInboundMessageHandler return an inbound message handler continuing the trace found in the context.
+func (p *Provider) ContextInboundMessageHandler() transport.ContextInboundMessageHandler {
+	return func(ctx gocontext.Context, message []byte, myDID, theirDID string) error {
 		msg, err := service.ParseDIDCommMsgMap(message)
 		if err != nil {
 			return err
 		}
 
-		// find the service which accepts the message type
-		for _, svc := range p.services {
-			if svc.Accept(msg.Type()) {
-				_, err = svc.HandleInbound(msg, myDID, theirDID)
+		ctx, span := tracing.Start(ctx, "didcomm.dispatch.inbound")
+		defer span.End()
+
+		span.SetAttribute("didcomm.message.type", msg.Type())
+
+		err = p.dispatchInbound(ctx, msg, myDID, theirDID)
+		span.RecordError(err)
+
+		return err
+	}
+}
+
+func (p *Provider) dispatchInbound(ctx gocontext.Context, msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	// services may continue the trace from the message metadata
+	tracing.InjectMetadata(ctx, msg.Metadata())
+
+	// find the service which accepts the message type
+	for _, svc := range p.services {
+		if svc.Accept(msg.Type()) {
+			metrics.InboundMessages.Inc(svc.Name())
 
-				return err
-			}
+			_, err := svc.HandleInbound(msg, myDID, theirDID)
+
+			return err
 		}
+	}
 
-		// in case of no services are registered for given message type,
-		// find generic inbound services registered for given message header
-		for _, svc := range p.msgSvcProvider.Services() {
-			h := struct {
-				Purpose []string `json:"~purpose"`
-			}{}
-			err = msg.Decode(&h)
-
-			if err != nil {
-				return err
-			}
-
-			if svc.Accept(msg.Type(), h.Purpose) {
-				return p.tryToHandle(svc, msg, myDID, theirDID)
-			}
+	// in case of no services are registered for given message type,
+	// find generic inbound services registered for given message header
+	for _, svc := range p.msgSvcProvider.Services() {
+		h := struct {
+			Purpose []string `json:"~purpose"`
+		}{}
+
+		err := msg.Decode(&h)
+		if err != nil {
+			return err
 		}
 
-		return fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
+		if svc.Accept(msg.Type(), h.Purpose) {
+			metrics.InboundMessages.Inc(svc.Name())
+
+			return p.tryToHandle(svc, msg, myDID, theirDID)
+		}
 	}
+
+	return fmt.Errorf("no message handlers found for the message type: %s", msg.Type())
 }
 
 // OutboundMessageHandler returns a handler composed of all registered protocol services.
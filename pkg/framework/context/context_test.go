@@ -16,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
@@ -71,6 +72,31 @@ func TestNewProvider(t *testing.T) {
 
 		_, err = prov.Service("mockProtocolSvc1")
 		require.Error(t, err)
+
+		require.Len(t, prov.AllServices(), 1)
+	})
+
+	t.Run("test inbound messages are counted by service", func(t *testing.T) {
+		counter := &inboundCounter{}
+
+		metrics.Initialize(counter)
+		defer metrics.Initialize(nil)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc: func(msgType string) bool {
+				return true
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()))
+		require.NoError(t, err)
+
+		inboundHandler := ctx.InboundMessageHandler()
+
+		for _, msgType := range []string{"type-1", "type-2"} {
+			require.NoError(t, inboundHandler([]byte(`{"@type":"`+msgType+`"}`), "", ""))
+		}
+
+		require.Equal(t, []string{"mockProtocolSvc", "mockProtocolSvc"}, counter.values)
 	})
 
 	t.Run("test inbound message handlers/dispatchers", func(t *testing.T) {
@@ -234,6 +260,7 @@ func TestNewProvider(t *testing.T) {
 		mockMsgHandler := msghandler.NewMockMsgServiceProvider()
 		prov, err := New(WithMessageServiceProvider(mockMsgHandler), WithMessengerHandler(messenger))
 		require.NoError(t, err)
+		require.Equal(t, mockMsgHandler, prov.MessageServiceProvider())
 
 		err = mockMsgHandler.Register(&generic.MockMessageSvc{
 			HandleFunc: func(*service.DIDCommMsg) (string, error) {
@@ -396,3 +423,26 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, frameworkID, prov.AriesFrameworkID())
 	})
 }
+
+// inboundCounter records the label values of the inbound messages counter.
+type inboundCounter struct {
+	values []string
+}
+
+func (c *inboundCounter) Counter(name, _ string, _ ...string) metrics.Counter {
+	if name != "aries_didcomm_inbound_messages_total" {
+		return &inboundCounter{}
+	}
+
+	return c
+}
+
+func (c *inboundCounter) Histogram(string, string, []float64, ...string) metrics.Histogram {
+	return &inboundCounter{}
+}
+
+func (c *inboundCounter) Inc(labelValues ...string) {
+	c.values = append(c.values, labelValues...)
+}
+
+func (c *inboundCounter) Observe(float64, ...string) {}
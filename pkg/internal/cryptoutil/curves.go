@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cryptoutil
+
+import (
+	"crypto/elliptic"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	hybrid "github.com/google/tink/go/hybrid/subtle"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+)
+
+const (
+	// Secp256k1CurveName is the curve name of secp256k1 ECDH keys (eg the Curve field of exported ECDH public keys).
+	Secp256k1CurveName = "SECP256K1"
+
+	// Secp256k1CurveType is the curve type value of secp256k1 ECDH key protos. Tink's EllipticCurveType enum does not
+	// define secp256k1, but proto3 enums are open: this value is serialized and parsed like the defined ones.
+	Secp256k1CurveType = commonpb.EllipticCurveType(100)
+)
+
+// GetCurve returns the elliptic curve of the given curve name. It supports the NIST P curve names of Tink's
+// hybrid.GetCurve() as well as secp256k1 (both the Tink style 'SECP256K1' and the JWK 'secp256k1' names).
+func GetCurve(curve string) (elliptic.Curve, error) {
+	if strings.EqualFold(curve, Secp256k1CurveName) {
+		return btcec.S256(), nil
+	}
+
+	return hybrid.GetCurve(curve)
+}
+
+// CurveName returns the name of the curve type c, including Secp256k1CurveType.
+func CurveName(c commonpb.EllipticCurveType) string {
+	if c == Secp256k1CurveType {
+		return Secp256k1CurveName
+	}
+
+	return c.String()
+}
+
+// ECCurveName returns the name of the elliptic curve c as used in ECDH public keys. The NIST P curves keep their
+// Go names (eg 'P-256') while secp256k1, which has no name in btcec, is named Secp256k1CurveName.
+func ECCurveName(c elliptic.Curve) string {
+	if c == btcec.S256() {
+		return Secp256k1CurveName
+	}
+
+	return c.Params().Name
+}
@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cryptoutil
+
+import (
+	"crypto/elliptic"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCurve(t *testing.T) {
+	for _, name := range []string{Secp256k1CurveName, "secp256k1", CurveName(Secp256k1CurveType)} {
+		c, err := GetCurve(name)
+		require.NoError(t, err)
+		require.Equal(t, btcec.S256(), c)
+		require.Equal(t, Secp256k1CurveName, ECCurveName(c))
+	}
+
+	c, err := GetCurve(CurveName(commonpb.EllipticCurveType_NIST_P384))
+	require.NoError(t, err)
+	require.Equal(t, elliptic.P384(), c)
+	require.Equal(t, "P-384", ECCurveName(c))
+
+	_, err = GetCurve(CurveName(commonpb.EllipticCurveType_CURVE25519))
+	require.EqualError(t, err, "unsupported curve")
+}
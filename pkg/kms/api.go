@@ -116,6 +116,8 @@ const (
 	NISTP384ECDHKW = "NISTP384ECDHKW"
 	// NISTP521ECDHKW key type value.
 	NISTP521ECDHKW = "NISTP521ECDHKW"
+	// Secp256k1ECDHKW key type value.
+	Secp256k1ECDHKW = "Secp256k1ECDHKW"
 	// X25519ECDHKW key type value.
 	X25519ECDHKW = "X25519ECDHKW"
 	// BLS12381G2 BBS+ key type value.
@@ -164,6 +166,8 @@ const (
 	NISTP384ECDHKWType = KeyType(NISTP384ECDHKW)
 	// NISTP521ECDHKWType key type value.
 	NISTP521ECDHKWType = KeyType(NISTP521ECDHKW)
+	// Secp256k1ECDHKWType key type value.
+	Secp256k1ECDHKWType = KeyType(Secp256k1ECDHKW)
 	// X25519ECDHKWType key type value.
 	X25519ECDHKWType = KeyType(X25519ECDHKW)
 	// BLS12381G2Type BBS+ key type value.
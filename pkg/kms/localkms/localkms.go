@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/aead"
@@ -23,6 +24,7 @@ import (
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/signature"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
@@ -103,6 +105,8 @@ func NewWithPrefix(primaryKeyURI string, p kms.Provider, storePrefix string) (*L
 //  - handle instance (to private key)
 //  - error if failure
 func (l *LocalKMS) Create(kt kms.KeyType) (string, interface{}, error) {
+	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "create")
+
 	if kt == "" {
 		return "", nil, fmt.Errorf("failed to create new key, missing key type")
 	}
@@ -130,6 +134,8 @@ func (l *LocalKMS) Create(kt kms.KeyType) (string, interface{}, error) {
 //  - handle instance (to private key)
 //  - error if failure
 func (l *LocalKMS) Get(keyID string) (interface{}, error) {
+	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "get")
+
 	return l.getKeySet(keyID)
 }
 
@@ -140,6 +146,8 @@ func (l *LocalKMS) Get(keyID string) (interface{}, error) {
 //  - handle instance (to private key)
 //  - error if failure
 func (l *LocalKMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "rotate")
+
 	kh, err := l.getKeySet(keyID)
 	if err != nil {
 		return "", nil, fmt.Errorf("rotate: failed to getKeySet: %w", err)
@@ -212,6 +220,8 @@ func getKeyTemplate(keyType kms.KeyType) (*tinkpb.KeyTemplate, error) {
 		return ecdh.NISTP384ECDHKWKeyTemplate(), nil
 	case kms.NISTP521ECDHKWType:
 		return ecdh.NISTP521ECDHKWKeyTemplate(), nil
+	case kms.Secp256k1ECDHKWType:
+		return ecdh.Secp256k1ECDHKWKeyTemplate(), nil
 	case kms.X25519ECDHKWType:
 		return ecdh.X25519ECDHKWKeyTemplate(), nil
 	case kms.BLS12381G2Type:
@@ -305,6 +315,8 @@ func (l *LocalKMS) getKeySet(id string) (*keyset.Handle, error) {
 //  - marshalled public key []byte
 //  - error if it fails to export the public key bytes
 func (l *LocalKMS) ExportPubKeyBytes(id string) ([]byte, error) {
+	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "export_pub_key_bytes")
+
 	kh, err := l.getKeySet(id)
 	if err != nil {
 		return nil, fmt.Errorf("exportPubKeyBytes: failed to get keyset handle: %w", err)
@@ -357,6 +369,8 @@ func (l *LocalKMS) exportPubKeyBytes(kh *keyset.Handle) ([]byte, error) {
 //  - marshalled public key []byte
 //  - error if it fails to export the public key bytes
 func (l *LocalKMS) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, error) {
+	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "create_and_export_pub_key_bytes")
+
 	kid, _, err := l.Create(kt)
 	if err != nil {
 		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: failed to create new key: %w", err)
@@ -375,6 +389,8 @@ func (l *LocalKMS) CreateAndExportPubKeyBytes(kt kms.KeyType) (string, []byte, e
 // Note: The key handle created is not stored in the KMS, it's only useful to execute the crypto primitive
 // associated with it.
 func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface{}, error) {
+	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "pub_key_bytes_to_handle")
+
 	return publicKeyBytesToHandle(pubKey, kt)
 }
 
@@ -390,6 +406,8 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface
 //  - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
 func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	defer metrics.KMSOperationDuration.ObserveSince(time.Now(), "import_private_key")
+
 	switch pk := privKey.(type) {
 	case *ecdsa.PrivateKey:
 		return l.importECDSAKey(pk, kt, opts...)
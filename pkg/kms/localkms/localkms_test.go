@@ -264,6 +264,7 @@ func TestLocalKMS_Success(t *testing.T) {
 		kms.NISTP256ECDHKWType,
 		kms.NISTP384ECDHKWType,
 		kms.NISTP521ECDHKWType,
+		kms.Secp256k1ECDHKWType,
 		kms.X25519ECDHKWType,
 		kms.BLS12381G2Type,
 	}
@@ -9,6 +9,7 @@ package webkms
 import (
 	"encoding/json"
 	"net/http"
+	"path"
 
 	"github.com/bluele/gcache"
 )
@@ -16,11 +17,18 @@ import (
 // addHeaders function supports adding custom http headers.
 type addHeaders func(req *http.Request) (*http.Header, error)
 
+// CapabilityInvoker authorizes requests by invoking an authorization capability for the given action, for instance by
+// signing them as zcapld.Invoker does.
+type CapabilityInvoker interface {
+	InvokeCapability(req *http.Request, action string) error
+}
+
 // Opts represents option.
 type Opts struct {
-	HeadersFunc     addHeaders
-	ComputeMACCache gcache.Cache
-	marshal         marshalFunc
+	HeadersFunc       addHeaders
+	CapabilityInvoker CapabilityInvoker
+	ComputeMACCache   gcache.Cache
+	marshal           marshalFunc
 }
 
 // NewOpt creates a new empty option.
@@ -41,6 +49,20 @@ func WithHeaders(addHeadersFunc addHeaders) Opt {
 	}
 }
 
+// WithCapabilityInvoker option is for authorizing requests with capability invocations. It is applied after the
+// headers of WithHeaders, and the action invoked is given by CapabilityAction.
+func WithCapabilityInvoker(invoker CapabilityInvoker) Opt {
+	return func(opts *Opts) {
+		opts.CapabilityInvoker = invoker
+	}
+}
+
+// CapabilityAction returns the capability action of a request to the key server, which is the last segment of its
+// path: "keystores", "keys", "export", "import", "sign", "verify", "wrap" and so on.
+func CapabilityAction(req *http.Request) string {
+	return path.Base(req.URL.Path)
+}
+
 // WithCache add cache. if size is zero cache content will not be purged.
 func WithCache(cacheSize int) Opt {
 	return func(opts *Opts) {
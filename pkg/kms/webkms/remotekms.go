@@ -136,6 +136,13 @@ func CreateKeyStore(httpClient HTTPClient, keyserverURL, controller, vaultID str
 		}
 	}
 
+	if kOpts.CapabilityInvoker != nil {
+		e := kOpts.CapabilityInvoker.InvokeCapability(httpReq, CapabilityAction(httpReq))
+		if e != nil {
+			return "", "", fmt.Errorf("invoke capability error: %w", e)
+		}
+	}
+
 	start := time.Now()
 
 	resp, err := httpClient.Do(httpReq)
@@ -216,6 +223,13 @@ func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*htt
 		}
 	}
 
+	if r.opts.CapabilityInvoker != nil {
+		e := r.opts.CapabilityInvoker.InvokeCapability(httpReq, CapabilityAction(httpReq))
+		if e != nil {
+			return nil, fmt.Errorf("invoke capability error: %w", e)
+		}
+	}
+
 	resp, err := r.httpClient.Do(httpReq)
 
 	logger.Infof("  HTTP %s %s call duration: %s", method, destination, time.Since(start))
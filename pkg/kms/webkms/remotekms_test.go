@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -265,6 +266,46 @@ func TestRemoteKeyStoreWithHeadersFunc(t *testing.T) {
 	})
 }
 
+func TestRemoteKeyStoreWithCapabilityInvoker(t *testing.T) {
+	var actions []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actions = append(actions, r.Header.Get("Capability-Invocation"))
+		require.Equal(t, "mockController", r.Header.Get("controller"))
+
+		w.Header().Add(LocationHeader, r.URL.String()+"/"+defaultKID)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	keystoreURL := strings.ReplaceAll(KeystoreEndpoint, "{serverEndpoint}", server.URL) + "/" + defaultKeyStoreID
+
+	t.Run("success", func(t *testing.T) {
+		_, _, err := CreateKeyStore(server.Client(), server.URL, controller, "",
+			WithHeaders(mockAddHeadersFuncSuccess), WithCapabilityInvoker(&mockInvoker{}))
+		require.NoError(t, err)
+
+		remoteKMS := New(keystoreURL, server.Client(),
+			WithHeaders(mockAddHeadersFuncSuccess), WithCapabilityInvoker(&mockInvoker{}))
+
+		kid, _, err := remoteKMS.Create(kms.ED25519Type)
+		require.NoError(t, err)
+		require.Equal(t, defaultKID, kid)
+
+		require.Equal(t, []string{"keystores", "keys"}, actions)
+	})
+
+	t.Run("invocation failure", func(t *testing.T) {
+		errInvoke := errors.New("invocation failure")
+
+		remoteKMS := New(keystoreURL, server.Client(), WithCapabilityInvoker(&mockInvoker{err: errInvoke}))
+
+		_, _, err := remoteKMS.Create(kms.ED25519Type)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, errInvoke))
+	})
+}
+
 func TestImportPrivateKey(t *testing.T) {
 	secret := make([]byte, 10)
 	_, err := rand.Read(secret)
@@ -610,6 +651,20 @@ func mockAddHeadersFuncSuccess(req *http.Request) (*http.Header, error) {
 	return &req.Header, nil
 }
 
+type mockInvoker struct {
+	err error
+}
+
+func (m *mockInvoker) InvokeCapability(req *http.Request, action string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	req.Header.Set("Capability-Invocation", action)
+
+	return nil
+}
+
 var errAddHeadersFunc = errors.New("mockAddHeadersFuncError always fails")
 
 func mockAddHeadersFuncError(_ *http.Request) (*http.Header, error) {
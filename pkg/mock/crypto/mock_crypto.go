@@ -50,6 +50,13 @@ type Crypto struct {
 	DeriveProofKey    []byte
 	DeriveProofFn     DeriveProofFunc
 	DeriveProofError  error
+	BlindContextValue []byte
+	BlindingValue     []byte
+	BlindMessagesErr  error
+	BlindSignValue    []byte
+	BlindSignErr      error
+	UnblindValue      []byte
+	UnblindErr        error
 }
 
 // Encrypt returns mocked values and a mocked error.
@@ -133,3 +140,20 @@ func (c *Crypto) DeriveProof(messages [][]byte, bbsSignature, nonce []byte, reve
 
 	return c.DeriveProofValue, c.DeriveProofError
 }
+
+// BlindMessages returns mocked BBS+ blind signature context and blinding factor values and a mocked error.
+func (c *Crypto) BlindMessages(messages [][]byte, indexes []int, messagesCount int, nonce []byte,
+	signerPubKH interface{}) ([]byte, []byte, error) {
+	return c.BlindContextValue, c.BlindingValue, c.BlindMessagesErr
+}
+
+// BlindSign returns a mocked BBS+ blind signature value and a mocked error.
+func (c *Crypto) BlindSign(blindContext []byte, messages [][]byte, indexes []int, messagesCount int, nonce []byte,
+	kh interface{}) ([]byte, error) {
+	return c.BlindSignValue, c.BlindSignErr
+}
+
+// UnblindSignature returns a mocked BBS+ signature value and a mocked error.
+func (c *Crypto) UnblindSignature(blindSignature, blindingFactor []byte) ([]byte, error) {
+	return c.UnblindValue, c.UnblindErr
+}
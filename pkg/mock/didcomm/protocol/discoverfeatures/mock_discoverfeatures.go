@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+// MockDiscoverFeaturesSvc mock discover features service.
+type MockDiscoverFeaturesSvc struct {
+	ProtocolName string
+	QueryErr     error
+	QueryFunc    func(connectionID, query string,
+		options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Protocol, error)
+	SupportedFunc func(query string) []string
+}
+
+// Name return service name.
+func (m *MockDiscoverFeaturesSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return discoverfeatures.DiscoverFeatures
+}
+
+// HandleInbound msg.
+func (m *MockDiscoverFeaturesSvc) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// HandleOutbound msg.
+func (m *MockDiscoverFeaturesSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// Accept msg checks the msg type.
+func (m *MockDiscoverFeaturesSvc) Accept(msgType string) bool {
+	return true
+}
+
+// Query perform Query.
+func (m *MockDiscoverFeaturesSvc) Query(connectionID, query string,
+	options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Protocol, error) {
+	if m.QueryErr != nil {
+		return nil, m.QueryErr
+	}
+
+	if m.QueryFunc != nil {
+		return m.QueryFunc(connectionID, query, options...)
+	}
+
+	return []*discoverfeatures.Protocol{}, nil
+}
+
+// Supported returns the supported protocols matching the query.
+func (m *MockDiscoverFeaturesSvc) Supported(query string) []string {
+	if m.SupportedFunc != nil {
+		return m.SupportedFunc(query)
+	}
+
+	return []string{}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// MockTrustPingSvc mock trust ping service.
+type MockTrustPingSvc struct {
+	ProtocolName string
+	PingErr      error
+	PingFunc     func(connectionID string, options ...trustping.PingOption) (time.Duration, error)
+}
+
+// Name return service name.
+func (m *MockTrustPingSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return trustping.TrustPing
+}
+
+// HandleInbound msg.
+func (m *MockTrustPingSvc) HandleInbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// HandleOutbound msg.
+func (m *MockTrustPingSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	return "", nil
+}
+
+// Accept msg checks the msg type.
+func (m *MockTrustPingSvc) Accept(msgType string) bool {
+	return msgType == trustping.PingMsgType || msgType == trustping.PingResponseMsgType
+}
+
+// Ping perform Ping.
+func (m *MockTrustPingSvc) Ping(connectionID string, options ...trustping.PingOption) (time.Duration, error) {
+	if m.PingErr != nil {
+		return 0, m.PingErr
+	}
+
+	if m.PingFunc != nil {
+		return m.PingFunc(connectionID, options...)
+	}
+
+	return time.Millisecond, nil
+}
@@ -8,23 +8,12 @@ package storage
 import (
 	"errors"
 	"fmt"
-	"strings"
 	"sync"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
-const (
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-)
-
-var (
-	errInvalidQueryExpressionFormat = errors.New("invalid expression format. " +
-		"it must be in the following format: TagName:TagValue")
-	errIteratorExhausted = errors.New("iterator is exhausted")
-)
-
 // MockStoreProvider mock store provider.
 type MockStoreProvider struct {
 	Store              *MockStore
@@ -148,38 +137,23 @@ func (s *MockStore) GetBulk(keys ...string) ([][]byte, error) {
 	panic("implement me")
 }
 
-// Query returns all data that satisfies the expression. Expression format: TagName:TagValue.
-// If TagValue is not provided, then all data associated with the TagName will be returned.
-// For now, expression can only be a single tag Name + Value pair.
-func (s *MockStore) Query(expression string, _ ...storage.QueryOption) (storage.Iterator, error) {
-	if expression == "" {
-		return nil, errInvalidQueryExpressionFormat
+// Query returns all data that satisfies the expression (see storage.Store Query).
+func (s *MockStore) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	q, err := query.New(expression, options...)
+	if err != nil {
+		return nil, err
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName := expressionSplit[0]
-
-		s.lock.RLock()
-		defer s.lock.RUnlock()
-
-		keys, dbEntries := s.getMatchingKeysAndDBEntries(expressionTagName, "")
-
-		return &iterator{keys: keys, dbEntries: dbEntries}, nil
-	case expressionTagNameAndValueLength:
-		expressionTagName := expressionSplit[0]
-		expressionTagValue := expressionSplit[1]
-
-		s.lock.RLock()
-		defer s.lock.RUnlock()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 
-		keys, dbEntries := s.getMatchingKeysAndDBEntries(expressionTagName, expressionTagValue)
+	entries := make([]query.Entry, 0, len(s.Store))
 
-		return &iterator{keys: keys, dbEntries: dbEntries}, nil
-	default:
-		return nil, errInvalidQueryExpressionFormat
+	for key, dbEntry := range s.Store {
+		entries = append(entries, query.Entry{Key: key, Value: dbEntry.Value, Tags: dbEntry.Tags})
 	}
+
+	return query.NewIterator(q, entries), nil
 }
 
 // Delete will delete record with k key.
@@ -205,76 +179,3 @@ func (s *MockStore) Flush() error {
 func (s *MockStore) Close() error {
 	panic("implement me")
 }
-
-func (s *MockStore) getMatchingKeysAndDBEntries(tagName, tagValue string) ([]string, []DBEntry) {
-	var matchAnyValue bool
-	if tagValue == "" {
-		matchAnyValue = true
-	}
-
-	var keys []string
-
-	var dbEntries []DBEntry
-
-	for key, dbEntry := range s.Store {
-		for _, tag := range dbEntry.Tags {
-			if tag.Name == tagName && (matchAnyValue || tag.Value == tagValue) {
-				keys = append(keys, key)
-				dbEntries = append(dbEntries, dbEntry)
-
-				break
-			}
-		}
-	}
-
-	return keys, dbEntries
-}
-
-type iterator struct {
-	currentIndex   int
-	currentKey     string
-	currentDBEntry DBEntry
-	keys           []string
-	dbEntries      []DBEntry
-}
-
-func (m *iterator) Next() (bool, error) {
-	if len(m.dbEntries) == m.currentIndex || len(m.dbEntries) == 0 {
-		m.dbEntries = nil
-		return false, nil
-	}
-
-	m.currentKey = m.keys[m.currentIndex]
-	m.currentDBEntry = m.dbEntries[m.currentIndex]
-	m.currentIndex++
-
-	return true, nil
-}
-
-func (m *iterator) Key() (string, error) {
-	if len(m.dbEntries) == 0 {
-		return "", errIteratorExhausted
-	}
-
-	return m.currentKey, nil
-}
-
-func (m *iterator) Value() ([]byte, error) {
-	if len(m.dbEntries) == 0 {
-		return nil, errIteratorExhausted
-	}
-
-	return m.currentDBEntry.Value, nil
-}
-
-func (m *iterator) Tags() ([]storage.Tag, error) {
-	if len(m.dbEntries) == 0 {
-		return nil, errIteratorExhausted
-	}
-
-	return m.currentDBEntry.Tags, nil
-}
-
-func (m *iterator) Close() error {
-	return nil
-}
@@ -18,14 +18,15 @@ import (
 // MockVDRegistry mock implementation of vdr
 // to be used only for unit tests.
 type MockVDRegistry struct {
-	CreateErr      error
-	CreateValue    *did.Doc
-	CreateFunc     func(string, *did.Doc, ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
-	UpdateFunc     func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error
-	DeactivateFunc func(did string, opts ...vdrapi.DIDMethodOption) error
-	ResolveErr     error
-	ResolveValue   *did.Doc
-	ResolveFunc    func(didID string, opts ...vdrapi.ResolveOption) (*did.DocResolution, error)
+	CreateErr       error
+	CreateValue     *did.Doc
+	CreateFunc      func(string, *did.Doc, ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
+	UpdateFunc      func(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error
+	DeactivateFunc  func(did string, opts ...vdrapi.DIDMethodOption) error
+	ResolveErr      error
+	ResolveValue    *did.Doc
+	ResolveFunc     func(didID string, opts ...vdrapi.ResolveOption) (*did.DocResolution, error)
+	DereferenceFunc func(didURL string, opts ...vdrapi.ResolveOption) (*vdrapi.DereferenceResult, error)
 }
 
 // Create mock implementation of create DID.
@@ -64,6 +65,35 @@ func (m *MockVDRegistry) Resolve(didID string, opts ...vdrapi.ResolveOption) (*d
 	return &did.DocResolution{DIDDocument: m.ResolveValue}, nil
 }
 
+// Dereference mock implementation of DID URL dereferencing, within the resolution of Resolve by default. Like Resolve,
+// it accepts IDs which are not DIDs, which are dereferenced to the whole resolved document.
+func (m *MockVDRegistry) Dereference(didURL string,
+	opts ...vdrapi.ResolveOption) (*vdrapi.DereferenceResult, error) {
+	if m.DereferenceFunc != nil {
+		return m.DereferenceFunc(didURL, opts...)
+	}
+
+	parsed, versionOpts, err := vdrapi.ParseDIDURL(didURL)
+	if err != nil {
+		docResolution, resolveErr := m.Resolve(didURL, opts...)
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+
+		return &vdrapi.DereferenceResult{
+			DIDDocument:      docResolution.DIDDocument,
+			DocumentMetadata: docResolution.DocumentMetadata,
+		}, nil
+	}
+
+	docResolution, err := m.Resolve(parsed.DID.String(), append(opts, versionOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return vdrapi.DereferenceResolution(docResolution, parsed)
+}
+
 // Update did.
 func (m *MockVDRegistry) Update(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
 	if m.UpdateFunc != nil {
@@ -0,0 +1,516 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sidetree provides an in-process mock Sidetree node, to be used only for unit tests.
+package sidetree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/sidetree"
+)
+
+const (
+	didContext           = "https://www.w3.org/ns/did/v1"
+	didResolutionContext = "https://w3id.org/did-resolution/v1"
+	operationsPath       = "/operations"
+	identifiersPath      = "/identifiers/"
+)
+
+// Node is a mock Sidetree node applying operations as soon as they are received, without anchoring them.
+type Node struct {
+	*httptest.Server
+
+	method string
+	mutex  sync.RWMutex
+	dids   map[string]*didState
+}
+
+type didState struct {
+	document           *sidetree.Document
+	updateCommitment   string
+	recoveryCommitment string
+	deactivated        bool
+}
+
+// NewNode starts a mock Sidetree node of the given DID method. It must be closed after use.
+func NewNode(method string) *Node {
+	n := &Node{
+		method: method,
+		dids:   make(map[string]*didState),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(operationsPath, n.operationsHandler)
+	mux.HandleFunc(identifiersPath, n.identifiersHandler)
+
+	n.Server = httptest.NewServer(mux)
+
+	return n
+}
+
+func (n *Node) operationsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	req := &sidetree.Request{}
+
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	var did string
+
+	switch req.Type {
+	case sidetree.OperationCreate:
+		did, err = n.create(req)
+	case sidetree.OperationUpdate:
+		did, err = n.update(req)
+	case sidetree.OperationRecover:
+		did, err = n.recover(req)
+	case sidetree.OperationDeactivate:
+		did, err = n.deactivate(req)
+	default:
+		err = fmt.Errorf("unsupported operation type %s", req.Type)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if req.Type != sidetree.OperationCreate {
+		w.WriteHeader(http.StatusOK)
+
+		return
+	}
+
+	writeResolution(w, resolution(did, n.dids[strings.TrimPrefix(did, n.prefix())], true))
+}
+
+func (n *Node) identifiersHandler(w http.ResponseWriter, r *http.Request) {
+	did, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), identifiersPath))
+	if err != nil || !strings.HasPrefix(did, n.prefix()) {
+		http.Error(w, "invalid DID", http.StatusBadRequest)
+
+		return
+	}
+
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	suffix := strings.TrimPrefix(did, n.prefix())
+
+	if !strings.Contains(suffix, ":") {
+		state, ok := n.dids[suffix]
+		if !ok {
+			http.Error(w, "DID not found", http.StatusNotFound)
+
+			return
+		}
+
+		writeResolution(w, resolution(did, state, true))
+
+		return
+	}
+
+	shortFormDID, req, err := sidetree.ParseLongFormDID(did)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if state, ok := n.dids[strings.TrimPrefix(shortFormDID, n.prefix())]; ok {
+		writeResolution(w, resolution(shortFormDID, state, true))
+
+		return
+	}
+
+	state, err := initialState(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	writeResolution(w, resolution(did, state, false))
+}
+
+func (n *Node) create(req *sidetree.Request) (string, error) {
+	if req.SuffixData == nil {
+		return "", errors.New("missing suffix data")
+	}
+
+	state, err := initialState(req)
+	if err != nil {
+		return "", err
+	}
+
+	suffix, err := sidetree.DIDSuffix(req.SuffixData)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := n.dids[suffix]; ok {
+		return "", fmt.Errorf("DID suffix %s already exists", suffix)
+	}
+
+	n.dids[suffix] = state
+
+	return n.prefix() + suffix, nil
+}
+
+func (n *Node) update(req *sidetree.Request) (string, error) {
+	state, err := n.activeState(req.DIDSuffix)
+	if err != nil {
+		return "", err
+	}
+
+	signedData, err := verifySignedData(req.SignedData, func(s *sidetree.SignedData) *jose.JWK { return s.UpdateKey })
+	if err != nil {
+		return "", err
+	}
+
+	err = checkReveal(req.RevealValue, signedData.UpdateKey, state.updateCommitment)
+	if err != nil {
+		return "", err
+	}
+
+	err = checkDelta(req.Delta, signedData.DeltaHash)
+	if err != nil {
+		return "", err
+	}
+
+	document, err := applyPatches(state.document, req.Delta.Patches)
+	if err != nil {
+		return "", err
+	}
+
+	state.document = document
+	state.updateCommitment = req.Delta.UpdateCommitment
+
+	return n.prefix() + req.DIDSuffix, nil
+}
+
+func (n *Node) recover(req *sidetree.Request) (string, error) {
+	state, err := n.activeState(req.DIDSuffix)
+	if err != nil {
+		return "", err
+	}
+
+	signedData, err := verifySignedData(req.SignedData, func(s *sidetree.SignedData) *jose.JWK { return s.RecoveryKey })
+	if err != nil {
+		return "", err
+	}
+
+	err = checkReveal(req.RevealValue, signedData.RecoveryKey, state.recoveryCommitment)
+	if err != nil {
+		return "", err
+	}
+
+	err = checkDelta(req.Delta, signedData.DeltaHash)
+	if err != nil {
+		return "", err
+	}
+
+	document, err := applyPatches(&sidetree.Document{}, req.Delta.Patches)
+	if err != nil {
+		return "", err
+	}
+
+	state.document = document
+	state.updateCommitment = req.Delta.UpdateCommitment
+	state.recoveryCommitment = signedData.RecoveryCommitment
+
+	return n.prefix() + req.DIDSuffix, nil
+}
+
+func (n *Node) deactivate(req *sidetree.Request) (string, error) {
+	state, err := n.activeState(req.DIDSuffix)
+	if err != nil {
+		return "", err
+	}
+
+	signedData, err := verifySignedData(req.SignedData, func(s *sidetree.SignedData) *jose.JWK { return s.RecoveryKey })
+	if err != nil {
+		return "", err
+	}
+
+	if signedData.DIDSuffix != req.DIDSuffix {
+		return "", errors.New("signed DID suffix does not match")
+	}
+
+	err = checkReveal(req.RevealValue, signedData.RecoveryKey, state.recoveryCommitment)
+	if err != nil {
+		return "", err
+	}
+
+	state.document = &sidetree.Document{}
+	state.deactivated = true
+	state.updateCommitment = ""
+	state.recoveryCommitment = ""
+
+	return n.prefix() + req.DIDSuffix, nil
+}
+
+func (n *Node) activeState(suffix string) (*didState, error) {
+	state, ok := n.dids[suffix]
+	if !ok {
+		return nil, fmt.Errorf("DID suffix %s not found", suffix)
+	}
+
+	if state.deactivated {
+		return nil, fmt.Errorf("DID suffix %s is deactivated", suffix)
+	}
+
+	return state, nil
+}
+
+func (n *Node) prefix() string {
+	return "did:" + n.method + ":"
+}
+
+func initialState(req *sidetree.Request) (*didState, error) {
+	err := checkDelta(req.Delta, req.SuffixData.DeltaHash)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := applyPatches(&sidetree.Document{}, req.Delta.Patches)
+	if err != nil {
+		return nil, err
+	}
+
+	return &didState{
+		document:           document,
+		updateCommitment:   req.Delta.UpdateCommitment,
+		recoveryCommitment: req.SuffixData.RecoveryCommitment,
+	}, nil
+}
+
+// verifySignedData verifies the compact JWS of signed data with the key it holds.
+func verifySignedData(jws string, key func(*sidetree.SignedData) *jose.JWK) (*sidetree.SignedData, error) {
+	signedData := &sidetree.SignedData{}
+
+	verifier := jose.SignatureVerifierFunc(func(_ jose.Headers, payload, signingInput, signature []byte) error {
+		err := json.Unmarshal(payload, signedData)
+		if err != nil {
+			return fmt.Errorf("invalid signed data: %w", err)
+		}
+
+		jwk := key(signedData)
+		if jwk == nil {
+			return errors.New("missing signing key in signed data")
+		}
+
+		return sigverifier.NewCompositePublicKeyVerifier([]sigverifier.SignatureVerifier{
+			sigverifier.NewEd25519SignatureVerifier(),
+			sigverifier.NewECDSAES256SignatureVerifier(),
+		}).Verify(&sigverifier.PublicKey{Type: "JsonWebKey2020", JWK: jwk}, signingInput, signature)
+	})
+
+	_, err := jose.ParseJWS(jws, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return signedData, nil
+}
+
+func checkReveal(revealValue string, key *jose.JWK, commitment string) error {
+	keyRevealValue, err := sidetree.RevealValue(key)
+	if err != nil {
+		return err
+	}
+
+	if keyRevealValue != revealValue {
+		return errors.New("reveal value does not match signing key")
+	}
+
+	revealedCommitment, err := sidetree.CommitmentFromRevealValue(revealValue)
+	if err != nil {
+		return err
+	}
+
+	if revealedCommitment != commitment {
+		return errors.New("reveal value does not match commitment")
+	}
+
+	return nil
+}
+
+func checkDelta(delta *sidetree.Delta, deltaHash string) error {
+	if delta == nil {
+		return errors.New("missing delta")
+	}
+
+	hash, err := sidetree.Hash(delta)
+	if err != nil {
+		return err
+	}
+
+	if hash != deltaHash {
+		return errors.New("delta hash does not match")
+	}
+
+	return nil
+}
+
+func applyPatches(document *sidetree.Document, patches []sidetree.Patch) (*sidetree.Document, error) {
+	doc := &sidetree.Document{
+		PublicKeys: append([]sidetree.PublicKey(nil), document.PublicKeys...),
+		Services:   append([]sidetree.Service(nil), document.Services...),
+	}
+
+	for _, patch := range patches {
+		switch patch.Action {
+		case sidetree.PatchReplace:
+			if patch.Document == nil {
+				return nil, errors.New("missing replace document")
+			}
+
+			doc = &sidetree.Document{PublicKeys: patch.Document.PublicKeys, Services: patch.Document.Services}
+		case sidetree.PatchAddPublicKeys:
+			doc.PublicKeys = append(doc.PublicKeys, patch.PublicKeys...)
+		case sidetree.PatchRemovePublicKeys:
+			var keys []sidetree.PublicKey
+
+			for _, key := range doc.PublicKeys {
+				if !contains(patch.IDs, key.ID) {
+					keys = append(keys, key)
+				}
+			}
+
+			doc.PublicKeys = keys
+		case sidetree.PatchAddServices:
+			doc.Services = append(doc.Services, patch.Services...)
+		case sidetree.PatchRemoveServices:
+			var services []sidetree.Service
+
+			for _, s := range doc.Services {
+				if !contains(patch.IDs, s.ID) {
+					services = append(services, s)
+				}
+			}
+
+			doc.Services = services
+		default:
+			return nil, fmt.Errorf("unsupported patch action %s", patch.Action)
+		}
+	}
+
+	return doc, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolution returns the DID resolution of a DID, with relative IDs for its verification methods and services.
+func resolution(did string, state *didState, published bool) map[string]interface{} {
+	didDoc := map[string]interface{}{
+		"@context": []interface{}{didContext, map[string]interface{}{"@base": did}},
+		"id":       did,
+	}
+
+	var verificationMethods []interface{}
+
+	relationships := make(map[string][]interface{})
+
+	for _, key := range state.document.PublicKeys {
+		id := "#" + key.ID
+
+		verificationMethods = append(verificationMethods, map[string]interface{}{
+			"id":           id,
+			"type":         key.Type,
+			"controller":   did,
+			"publicKeyJwk": key.PublicKeyJwk,
+		})
+
+		for _, purpose := range key.Purposes {
+			relationships[purpose] = append(relationships[purpose], id)
+		}
+	}
+
+	if len(verificationMethods) != 0 {
+		didDoc["verificationMethod"] = verificationMethods
+	}
+
+	for purpose, ids := range relationships {
+		didDoc[purpose] = ids
+	}
+
+	var services []interface{}
+
+	for _, s := range state.document.Services {
+		services = append(services, map[string]interface{}{
+			"id":              "#" + s.ID,
+			"type":            s.Type,
+			"serviceEndpoint": s.ServiceEndpoint,
+		})
+	}
+
+	if len(services) != 0 {
+		didDoc["service"] = services
+	}
+
+	metadata := map[string]interface{}{
+		"method": map[string]interface{}{
+			"published":          published,
+			"updateCommitment":   state.updateCommitment,
+			"recoveryCommitment": state.recoveryCommitment,
+		},
+	}
+
+	if published {
+		metadata["canonicalId"] = did
+	}
+
+	if state.deactivated {
+		metadata["deactivated"] = true
+	}
+
+	return map[string]interface{}{
+		"@context":            didResolutionContext,
+		"didDocument":         didDoc,
+		"didDocumentMetadata": metadata,
+	}
+}
+
+func writeResolution(w http.ResponseWriter, docResolution map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/did+ld+json")
+
+	err := json.NewEncoder(w).Encode(docResolution)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -10,11 +10,14 @@
 package connection
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -30,10 +33,23 @@ const (
 	didConnMapKeyPrefix = "didconn"
 	keySeparator        = "_"
 	stateIDEmptyErr     = "stateID can't be empty"
+
+	// tags of the connection records, the values of the DID and ID tags are hashed (see hashTagValue).
+	stateTagName          = "state"
+	myDIDTagName          = "mydid"
+	theirDIDTagName       = "theirdid"
+	invitationIDTagName   = "invid"
+	parentThreadIDTagName = "pthid"
+
+	// recordsTaggedKey marks the stores whose connection records saved by previous versions have been tagged.
+	recordsTaggedKey = "conntagged"
 )
 
 var logger = log.New("aries-framework/store/connection")
 
+// nolint:gochecknoglobals
+var recordTagNames = []string{stateTagName, myDIDTagName, theirDIDTagName, invitationIDTagName, parentThreadIDTagName}
+
 // KeyPrefix is prefix builder for storage keys.
 type KeyPrefix func(...string) string
 
@@ -68,7 +84,8 @@ func NewLookup(p provider) (*Lookup, error) {
 		return nil, fmt.Errorf("failed to open permanent store to create new connection recorder: %w", err)
 	}
 
-	err = p.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{TagNames: []string{connIDKeyPrefix}})
+	err = p.StorageProvider().SetStoreConfig(Namespace,
+		storage.StoreConfiguration{TagNames: append([]string{connIDKeyPrefix}, recordTagNames...)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config in permanent store: %w", err)
 	}
@@ -79,7 +96,7 @@ func NewLookup(p provider) (*Lookup, error) {
 	}
 
 	err = p.ProtocolStateStorageProvider().SetStoreConfig(Namespace,
-		storage.StoreConfiguration{TagNames: []string{connIDKeyPrefix, connStateKeyPrefix}})
+		storage.StoreConfiguration{TagNames: append([]string{connIDKeyPrefix, connStateKeyPrefix}, recordTagNames...)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config in protocol state store: %w", err)
 	}
@@ -115,15 +132,72 @@ func (c *Lookup) GetConnectionRecord(connectionID string) (*Record, error) {
 // QueryConnectionRecords returns connection records found in underlying store
 // for given query criteria.
 func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 query criteria to be added as part of issue
-	searchKey := getConnectionKeyPrefix()("")
+	return c.QueryConnectionRecordsFiltered()
+}
+
+// QueryFilter selects the connection records by their fields. The empty fields are not filtered.
+type QueryFilter struct {
+	State          string
+	MyDID          string
+	TheirDID       string
+	InvitationID   string
+	ParentThreadID string
+}
+
+// expression returns the store query expression (a conjunction of tag conditions) of the filter.
+func (f *QueryFilter) expression() (string, error) {
+	conditions := []string{getConnectionKeyPrefix()("")}
+
+	if f.State != "" {
+		// the state is the only value which isn't hashed, it must not add conditions to the expression.
+		if err := query.CheckTagValue(f.State); err != nil {
+			return "", fmt.Errorf("state filter: %w", err)
+		}
+
+		conditions = append(conditions, stateTagName+":"+f.State)
+	}
+
+	for _, tag := range []storage.Tag{
+		{Name: myDIDTagName, Value: f.MyDID},
+		{Name: theirDIDTagName, Value: f.TheirDID},
+		{Name: invitationIDTagName, Value: f.InvitationID},
+		{Name: parentThreadIDTagName, Value: f.ParentThreadID},
+	} {
+		if tag.Value != "" {
+			conditions = append(conditions, tag.Name+":"+hashTagValue(tag.Value))
+		}
+	}
+
+	return strings.Join(conditions, "&&"), nil
+}
+
+// QueryConnectionRecordsFiltered returns the connection records found in the underlying stores which match any
+// of the filters, or all of them if no filter is given. The records are selected by the stores with tag queries.
+func (c *Lookup) QueryConnectionRecordsFiltered(filters ...QueryFilter) ([]*Record, error) {
+	expression := getConnectionKeyPrefix()("")
+
+	if len(filters) > 0 {
+		terms := make([]string, len(filters))
+
+		for i := range filters {
+			term, err := filters[i].expression()
+			if err != nil {
+				return nil, fmt.Errorf("invalid query filter: %w", err)
+			}
+
+			terms[i] = term
+		}
+
+		expression = strings.Join(terms, "||")
+	}
 
-	persistentStoreRecords, persistentStoreKeys, err := c.getDataFromPersistentStore(searchKey)
+	persistentStoreRecords, persistentStoreKeys, err := c.getDataFromPersistentStore(expression)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get data from persistent store: %w", err)
 	}
 
-	allRecords, err := c.addDataFromProtocolStateStoreToRecords(searchKey, persistentStoreKeys, persistentStoreRecords)
+	allRecords, err := c.addDataFromProtocolStateStoreToRecords(expression, persistentStoreKeys,
+		persistentStoreRecords)
 	if err != nil {
 		return nil, fmt.Errorf("failed to augment records from persistent store with records "+
 			"from the protocol state store: %w", err)
@@ -132,9 +206,9 @@ func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
 	return allRecords, nil
 }
 
-func (c *Lookup) addDataFromProtocolStateStoreToRecords(searchKey string, keys map[string]struct{},
+func (c *Lookup) addDataFromProtocolStateStoreToRecords(expression string, keys map[string]struct{},
 	records []*Record) ([]*Record, error) {
-	protocolStateStoreItr, err := c.protocolStateStore.Query(searchKey)
+	protocolStateStoreItr, err := c.protocolStateStore.Query(expression)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query protocol state store: %w", err)
 	}
@@ -146,7 +220,22 @@ func (c *Lookup) addDataFromProtocolStateStoreToRecords(searchKey string, keys m
 		}
 	}()
 
-	records, err = addRecordsFromProtocolStateStoreIterator(protocolStateStoreItr, keys, records)
+	// the record of a connection found in the permanent store is not taken from the protocol state store,
+	// even if the former does not match the query.
+	inPermanentStore := func(key string) (bool, error) {
+		if _, ok := keys[key]; ok {
+			return true, nil
+		}
+
+		_, errGet := c.store.Get(key)
+		if errors.Is(errGet, storage.ErrDataNotFound) {
+			return false, nil
+		}
+
+		return errGet == nil, errGet
+	}
+
+	records, err = addRecordsFromProtocolStateStoreIterator(protocolStateStoreItr, inPermanentStore, records)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add records from protocol state storage iterator: %w", err)
 	}
@@ -217,8 +306,8 @@ func (c *Recorder) GetEvent(connectionID string) ([]byte, error) {
 	return c.protocolStateStore.Get(getEventDataKeyPrefix()(connectionID))
 }
 
-func (c *Lookup) getDataFromPersistentStore(searchKey string) ([]*Record, map[string]struct{}, error) {
-	itr, errQuery := c.store.Query(searchKey)
+func (c *Lookup) getDataFromPersistentStore(expression string) ([]*Record, map[string]struct{}, error) {
+	itr, errQuery := c.store.Query(expression)
 	if errQuery != nil {
 		return nil, nil, fmt.Errorf("failed to query permanent store: %w", errQuery)
 	}
@@ -326,6 +415,38 @@ func getDIDConnMapKeyPrefix() KeyPrefix {
 	}
 }
 
+// recordTags returns the tags of a connection record saved under the connection key.
+func recordTags(record *Record) []storage.Tag {
+	tags := []storage.Tag{{
+		Name:  getConnectionKeyPrefix()(""),
+		Value: getConnectionKeyPrefix()(record.ConnectionID),
+	}}
+
+	if record.State != "" {
+		tags = append(tags, storage.Tag{Name: stateTagName, Value: record.State})
+	}
+
+	for _, tag := range []storage.Tag{
+		{Name: myDIDTagName, Value: record.MyDID},
+		{Name: theirDIDTagName, Value: record.TheirDID},
+		{Name: invitationIDTagName, Value: record.InvitationID},
+		{Name: parentThreadIDTagName, Value: record.ParentThreadID},
+	} {
+		if tag.Value != "" {
+			tags = append(tags, storage.Tag{Name: tag.Name, Value: hashTagValue(tag.Value)})
+		}
+	}
+
+	return tags
+}
+
+// hashTagValue returns the tag value of a DID or ID, which can contain colons (not allowed in tag values).
+func hashTagValue(value string) string {
+	hash := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(hash[:])
+}
+
 // CreateNamespaceKey creates key prefix for namespace related data.
 func CreateNamespaceKey(prefix, thID string) (string, error) {
 	key, err := computeHash([]byte(thID))
@@ -336,8 +457,8 @@ func CreateNamespaceKey(prefix, thID string) (string, error) {
 	return getNamespaceKeyPrefix(prefix)(key), nil
 }
 
-func addRecordsFromProtocolStateStoreIterator(protocolStateStoreItr storage.Iterator, keys map[string]struct{},
-	records []*Record) ([]*Record, error) {
+func addRecordsFromProtocolStateStoreIterator(protocolStateStoreItr storage.Iterator,
+	inPermanentStore func(key string) (bool, error), records []*Record) ([]*Record, error) {
 	more, err := protocolStateStoreItr.Next()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get next set of data from protocol state store iterator: %w", err)
@@ -349,8 +470,13 @@ func addRecordsFromProtocolStateStoreIterator(protocolStateStoreItr storage.Iter
 			return nil, fmt.Errorf("failed to get key from iterator: %w", err)
 		}
 
+		skip, err := inPermanentStore(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get record from permanent store: %w", err)
+		}
+
 		// don't fetch data from protocol state store if same record is present in permanent store
-		if _, ok := keys[key]; ok {
+		if skip {
 			more, err = protocolStateStoreItr.Next()
 			if err != nil {
 				return nil, fmt.Errorf("failed to get next set of data from protocol state store iterator: %w", err)
@@ -11,12 +11,15 @@ package connection
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -338,6 +341,112 @@ func TestConnectionRecorder_QueryConnectionRecord(t *testing.T) {
 		require.Len(t, result, storeCount+protocolStateStoreCount)
 	})
 
+	t.Run("test query connection records filtered", func(t *testing.T) {
+		store, err := mem.NewProvider().OpenStore(Namespace)
+		require.NoError(t, err)
+
+		protocolStateStore, err := mem.NewProvider().OpenStore(Namespace)
+		require.NoError(t, err)
+
+		recorder, err := NewRecorder(&mockProvider{store: store, protocolStateStore: protocolStateStore})
+		require.NoError(t, err)
+
+		for _, record := range []*Record{
+			{
+				ConnectionID: "1", State: StateNameCompleted, MyDID: "did:example:me", TheirDID: "did:example:alice",
+				InvitationID: "invitation",
+			},
+			{
+				ConnectionID: "2", State: "requested", MyDID: "did:example:me", TheirDID: "did:example:bob",
+				ParentThreadID: "parent:thread",
+			},
+			{ConnectionID: "3", State: StateNameCompleted, TheirDID: "did:example:bob"},
+		} {
+			require.NoError(t, recorder.SaveConnectionRecord(record))
+		}
+
+		// records saved by previous versions are not tagged with their fields.
+		val, err := json.Marshal(&Record{ConnectionID: "4", State: StateNameCompleted, TheirDID: "did:example:bob"})
+		require.NoError(t, err)
+		require.NoError(t, protocolStateStore.Put(getConnectionKeyPrefix()("4"), val,
+			storage.Tag{Name: getConnectionKeyPrefix()("")}))
+
+		ids := func(filters ...QueryFilter) []string {
+			records, e := recorder.QueryConnectionRecordsFiltered(filters...)
+			require.NoError(t, e)
+
+			var connectionIDs []string
+			for _, record := range records {
+				connectionIDs = append(connectionIDs, record.ConnectionID)
+			}
+
+			sort.Strings(connectionIDs)
+
+			return connectionIDs
+		}
+
+		require.Equal(t, []string{"1", "2", "3", "4"}, ids())
+		require.Equal(t, []string{"1", "2", "3", "4"}, ids(QueryFilter{}))
+		require.Equal(t, []string{"3"}, ids(QueryFilter{State: StateNameCompleted, TheirDID: "did:example:bob"}))
+		require.Equal(t, []string{"1", "2"}, ids(QueryFilter{MyDID: "did:example:me"}))
+		require.Equal(t, []string{"1", "2"},
+			ids(QueryFilter{InvitationID: "invitation"}, QueryFilter{ParentThreadID: "parent:thread"}))
+		require.Empty(t, ids(QueryFilter{State: "requested", TheirDID: "did:example:alice"}))
+
+		require.NoError(t, recorder.TagConnectionRecords())
+		require.Equal(t, []string{"3", "4"}, ids(QueryFilter{State: StateNameCompleted, TheirDID: "did:example:bob"}))
+
+		// the records are tagged only once.
+		require.NoError(t, protocolStateStore.Put(getConnectionKeyPrefix()("5"), val,
+			storage.Tag{Name: getConnectionKeyPrefix()("")}))
+		require.NoError(t, recorder.TagConnectionRecords())
+		require.Equal(t, []string{"3", "4"}, ids(QueryFilter{State: StateNameCompleted, TheirDID: "did:example:bob"}))
+
+		// the record of the permanent store takes precedence even if only the protocol state store record matches.
+		require.NoError(t, marshalAndSave(getConnectionKeyPrefix()("1"), &Record{ConnectionID: "1", State: "requested"},
+			protocolStateStore, recordTags(&Record{ConnectionID: "1", State: "requested"})...))
+		require.Equal(t, []string{"2"}, ids(QueryFilter{State: "requested"}))
+
+		// the state filter can't add conditions to the query.
+		_, err = recorder.QueryConnectionRecordsFiltered(QueryFilter{State: "invalid||" + getConnectionKeyPrefix()("")})
+		require.True(t, errors.Is(err, query.ErrInvalidTagValue))
+	})
+
+	t.Run("test tag connection records failure", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+		err := store.Put(getConnectionKeyPrefix()("1"), []byte("-----"), storage.Tag{Name: "conn_"})
+		require.NoError(t, err)
+
+		recorder, err := NewRecorder(&mockProvider{store: store})
+		require.NoError(t, err)
+
+		store.ErrGet = errors.New(sampleErrMsg)
+		require.EqualError(t, recorder.TagConnectionRecords(),
+			"tag connection records: get tagging marker: "+sampleErrMsg)
+
+		store.ErrGet = nil
+		store.ErrPut = errors.New(sampleErrMsg)
+		require.EqualError(t, recorder.TagConnectionRecords(),
+			"tag connection records: save tagging marker: "+sampleErrMsg)
+
+		store.ErrPut = nil
+
+		val, err := json.Marshal(&Record{ConnectionID: "2"})
+		require.NoError(t, err)
+		require.NoError(t, store.Put(getConnectionKeyPrefix()("2"), val, storage.Tag{Name: "conn_"}))
+
+		store.ErrPut = errors.New(sampleErrMsg)
+		require.EqualError(t, recorder.TagConnectionRecords(),
+			"tag connection records: save connection record conn_2: "+sampleErrMsg)
+
+		// malformed records are not tagged, and the records are not tagged again once tagged.
+		store.ErrPut = nil
+		require.NoError(t, recorder.TagConnectionRecords())
+
+		store.ErrPut = errors.New(sampleErrMsg)
+		require.NoError(t, recorder.TagConnectionRecords())
+	})
+
 	t.Run("test query connection record failure", func(t *testing.T) {
 		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
 		err := store.Put(fmt.Sprintf("%s_abc123", connIDKeyPrefix), []byte("-----"), storage.Tag{Name: "conn_"})
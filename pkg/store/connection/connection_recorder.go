@@ -60,10 +60,7 @@ func (c *Recorder) SaveInvitation(id string, invitation interface{}) error {
 // SaveConnectionRecord saves given connection records in underlying store.
 func (c *Recorder) SaveConnectionRecord(record *Record) error {
 	if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-		record, c.protocolStateStore, storage.Tag{
-			Name:  getConnectionKeyPrefix()(""),
-			Value: getConnectionKeyPrefix()(record.ConnectionID),
-		}); err != nil {
+		record, c.protocolStateStore, recordTags(record)...); err != nil {
 		return fmt.Errorf("save connection record in protocol state store: %w", err)
 	}
 
@@ -80,10 +77,7 @@ func (c *Recorder) SaveConnectionRecord(record *Record) error {
 
 	if record.State == StateNameCompleted {
 		if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-			record, c.store, storage.Tag{
-				Name:  getConnectionKeyPrefix()(""),
-				Value: getConnectionKeyPrefix()(record.ConnectionID),
-			}); err != nil {
+			record, c.store, recordTags(record)...); err != nil {
 			return fmt.Errorf("save connection record in permanent store: %w", err)
 		}
 
@@ -181,6 +175,79 @@ func (c *Recorder) RemoveConnection(connectionID string) error {
 	return nil
 }
 
+// TagConnectionRecords tags the connection records saved by previous versions, which did not tag them with their
+// fields, so that they are matched by QueryConnectionRecordsFiltered. The framework calls it when it starts,
+// the records of each store are tagged only once.
+func (c *Recorder) TagConnectionRecords() error {
+	for _, store := range []storage.Store{c.store, c.protocolStateStore} {
+		if err := tagConnectionRecords(store); err != nil {
+			return fmt.Errorf("tag connection records: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// tagConnectionRecords tags the connection records of the store once.
+func tagConnectionRecords(store storage.Store) error {
+	_, err := store.Get(recordsTaggedKey)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get tagging marker: %w", err)
+	}
+
+	itr, err := store.Query(getConnectionKeyPrefix()(""))
+	if err != nil {
+		return fmt.Errorf("query store: %w", err)
+	}
+
+	defer storage.Close(itr, logger)
+
+	// the records are saved once read, as some stores can't be written while they are iterated over.
+	values := make(map[string][]byte)
+
+	more, err := itr.Next()
+
+	for ; err == nil && more; more, err = itr.Next() {
+		key, e := itr.Key()
+		if e != nil {
+			return fmt.Errorf("get key from iterator: %w", e)
+		}
+
+		values[key], e = itr.Value()
+		if e != nil {
+			return fmt.Errorf("get value from iterator: %w", e)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("iterate over store: %w", err)
+	}
+
+	for key, value := range values {
+		var record Record
+
+		if e := json.Unmarshal(value, &record); e != nil {
+			logger.Warnf("failed to tag connection record %s: %v", key, e)
+
+			continue
+		}
+
+		if err = store.Put(key, value, recordTags(&record)...); err != nil {
+			return fmt.Errorf("save connection record %s: %w", key, err)
+		}
+	}
+
+	if err = store.Put(recordsTaggedKey, []byte("true")); err != nil {
+		return fmt.Errorf("save tagging marker: %w", err)
+	}
+
+	return nil
+}
+
 func marshalAndSave(k string, v interface{}, store storage.Store, tags ...storage.Tag) error {
 	bytes, err := json.Marshal(v)
 	if err != nil {
@@ -63,6 +63,8 @@ type Store interface {
 	GetPresentationIDByName(name string) (string, error)
 	GetCredentials() ([]*Record, error)
 	GetPresentations() ([]*Record, error)
+	GetCredentialsPage(pageSize int, bookmark string) ([]*Record, string, error)
+	GetPresentationsPage(pageSize int, bookmark string) ([]*Record, string, error)
 	RemoveCredentialByName(name string) error
 	RemovePresentationByName(name string) error
 }
@@ -203,7 +205,8 @@ func (s *StoreImplementation) GetCredential(id string) (*verifiable.Credential,
 		return nil, fmt.Errorf("failed to get vc: %w", err)
 	}
 
-	vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithDisabledProofCheck())
+	vc, err := verifiable.ParseCredential(vcBytes, verifiable.WithDisabledProofCheck(),
+		verifiable.WithDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2))
 	if err != nil {
 		return nil, fmt.Errorf("new credential failed: %w", err)
 	}
@@ -221,6 +224,7 @@ func (s *StoreImplementation) GetPresentation(id string) (*verifiable.Presentati
 	vp, err := verifiable.ParsePresentation(vpBytes,
 		verifiable.WithPresDisabledProofCheck(),
 		verifiable.WithPresJSONLDDocumentLoader(presexch.CachingJSONLDLoader()),
+		verifiable.WithPresDataModelVersions(verifiable.DataModelV1, verifiable.DataModelV2),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("new presentation failed: %w", err)
@@ -273,6 +277,20 @@ func (s *StoreImplementation) GetPresentations() ([]*Record, error) {
 	return s.getAllRecords(presentationNameDataKey(""))
 }
 
+// GetCredentialsPage retrieves up to pageSize verifiable credential records, ordered by name, starting after the
+// given bookmark (from the first record if it is empty). The returned bookmark resumes with the next page,
+// it is empty if there are no more records.
+func (s *StoreImplementation) GetCredentialsPage(pageSize int, bookmark string) ([]*Record, string, error) {
+	return s.getRecordsPage(credentialNameDataKey(""), pageSize, bookmark)
+}
+
+// GetPresentationsPage retrieves up to pageSize verifiable presentation records, ordered by name, starting after the
+// given bookmark (from the first record if it is empty). The returned bookmark resumes with the next page,
+// it is empty if there are no more records.
+func (s *StoreImplementation) GetPresentationsPage(pageSize int, bookmark string) ([]*Record, string, error) {
+	return s.getRecordsPage(presentationNameDataKey(""), pageSize, bookmark)
+}
+
 // RemoveCredentialByName removes the verifiable credential and its records containing given name.
 func (s *StoreImplementation) RemoveCredentialByName(name string) error {
 	if name == "" {
@@ -369,6 +387,65 @@ func (s *StoreImplementation) getAllRecords(searchKey string) ([]*Record, error)
 	return records, nil
 }
 
+func (s *StoreImplementation) getRecordsPage(searchKey string, pageSize int, bookmark string) ([]*Record, string,
+	error) {
+	if pageSize < 1 {
+		return nil, "", errors.New("page size must be positive")
+	}
+
+	itr, err := s.store.Query(searchKey, storage.WithPageSize(pageSize), storage.WithBookmark(bookmark))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query store: %w", err)
+	}
+
+	defer func() {
+		errClose := itr.Close()
+		if errClose != nil {
+			logger.Errorf("failed to close iterator: %s", errClose.Error())
+		}
+	}()
+
+	var (
+		records []*Record
+		next    string
+	)
+
+	for {
+		more, err := itr.Next()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get next set of data from iterator: %w", err)
+		}
+
+		if !more {
+			// this page is the last one.
+			return records, "", nil
+		}
+
+		if len(records) == pageSize {
+			return records, next, nil
+		}
+
+		var r *Record
+
+		value, err := itr.Value()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get value from iterator: %w", err)
+		}
+
+		err = json.Unmarshal(value, &r)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal record : %w", err)
+		}
+
+		next, err = itr.Bookmark()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get bookmark from iterator: %w", err)
+		}
+
+		records = append(records, r)
+	}
+}
+
 func getVCSubjectID(vc *verifiable.Credential) string {
 	if subjectID, err := verifiable.SubjectID(vc.Subject); err == nil {
 		return subjectID
@@ -473,6 +473,74 @@ func TestGetCredentials(t *testing.T) {
 	})
 }
 
+func TestGetCredentialsPage(t *testing.T) {
+	s, err := New(&mockprovider.Provider{StorageProviderValue: mockstore.NewMockStoreProvider()})
+	require.NoError(t, err)
+
+	t.Run("test invalid page size", func(t *testing.T) {
+		records, next, err := s.GetCredentialsPage(0, "")
+		require.EqualError(t, err, "page size must be positive")
+		require.Empty(t, records)
+		require.Empty(t, next)
+	})
+
+	t.Run("test get credentials page by page", func(t *testing.T) {
+		const n = 5
+
+		for i := 0; i < n; i++ {
+			err = s.SaveCredential(sampleCredentialName+strconv.Itoa(i),
+				&verifiable.Credential{ID: sampleCredentialID + strconv.Itoa(i)})
+			require.NoError(t, err)
+
+			err = s.SavePresentation(samplePresentationName+strconv.Itoa(i),
+				&verifiable.Presentation{ID: samplePresentationID + strconv.Itoa(i)})
+			require.NoError(t, err)
+		}
+
+		var (
+			names []string
+			next  string
+			pages int
+		)
+
+		for {
+			var records []*Record
+
+			records, next, err = s.GetCredentialsPage(2, next)
+			require.NoError(t, err)
+
+			pages++
+
+			for _, record := range records {
+				names = append(names, record.Name)
+			}
+
+			if next == "" {
+				break
+			}
+		}
+
+		require.Equal(t, 3, pages)
+		require.Equal(t, []string{
+			sampleCredentialName + "0", sampleCredentialName + "1", sampleCredentialName + "2",
+			sampleCredentialName + "3", sampleCredentialName + "4",
+		}, names)
+
+		records, next, err := s.GetPresentationsPage(n, "")
+		require.NoError(t, err)
+		require.Len(t, records, n)
+		require.Empty(t, next)
+	})
+
+	t.Run("test invalid bookmark", func(t *testing.T) {
+		records, next, err := s.GetCredentialsPage(2, "invalid bookmark")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to query store")
+		require.Empty(t, records)
+		require.Empty(t, next)
+	})
+}
+
 func TestSaveVP(t *testing.T) {
 	t.Run("test save vp - success", func(t *testing.T) {
 		s, err := New(&mockprovider.Provider{
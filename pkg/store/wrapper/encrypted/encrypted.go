@@ -0,0 +1,530 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package encrypted offers a storage.Provider wrapper that keeps data encrypted at rest in any underlying provider.
+// Values are encrypted with an AES-256-GCM key, and keys and tags are blinded with an HMAC-SHA256 key, both created
+// in a kms.KeyManager. The IDs of these keys are kept in the KeyStoreName store of the underlying provider.
+//
+// The encryption key can be rotated with RotateKey: new values are encrypted with the new key while existing values
+// remain readable with the key they were encrypted with, until they are re-encrypted with ReEncrypt.
+package encrypted
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/formattedstore"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// KeyStoreName is the name of the store, in the underlying provider, holding the IDs of the keys in use.
+	KeyStoreName = "encryptedstoragekeys"
+
+	keyIDsKey        = "keyids"
+	storeKeyPrefix   = "store-"
+	storeNameTagName = "storename"
+
+	// configStoreSuffix is the suffix of the stores in which formattedstore keeps store configurations.
+	configStoreSuffix = "_formattedstore_storeconfig"
+
+	reEncryptPageSize = 100
+)
+
+var logger = log.New("aries-framework/store/encrypted")
+
+type keyIDs struct {
+	EncryptionKeyID string `json:"encryptionKeyID"`
+	MACKeyID        string `json:"macKeyID"`
+}
+
+// Provider is a storage.Provider encrypting the data it stores in an underlying provider.
+type Provider struct {
+	*formattedstore.FormattedProvider
+	underlying storage.Provider
+	// keyStore holds the names of the stores opened through the provider, keyIDsStore the IDs of the keys in use.
+	keyStore    storage.Store
+	keyIDsStore storage.Store
+	formatter   *formatter
+	storeNames  map[string]struct{}
+	lock        sync.Mutex
+}
+
+// New returns a Provider encrypting the data it stores in the given provider. The keys are created in keyManager the
+// first time, and are found again through the IDs kept in the underlying provider afterwards.
+func New(provider storage.Provider, keyManager kms.KeyManager, cr crypto.Crypto) (*Provider, error) {
+	keyStore, err := provider.OpenStore(KeyStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key store: %w", err)
+	}
+
+	ids, err := getKeyIDs(keyStore, keyManager)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptionKH, err := keyManager.Get(ids.EncryptionKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+
+	macKH, err := keyManager.Get(ids.MACKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MAC key: %w", err)
+	}
+
+	f := &formatter{
+		keyManager:      keyManager,
+		crypto:          cr,
+		macKH:           macKH,
+		encryptionKeyID: ids.EncryptionKeyID,
+		encryptionKHs:   map[string]interface{}{ids.EncryptionKeyID: encryptionKH},
+	}
+
+	return &Provider{
+		FormattedProvider: formattedstore.NewProvider(provider, f),
+		underlying:        provider,
+		keyStore:          keyStore,
+		keyIDsStore:       keyStore,
+		formatter:         f,
+		storeNames:        make(map[string]struct{}),
+	}, nil
+}
+
+// Wrap returns a Provider encrypting the data it stores in the given provider with the keys of p, including the
+// keys p rotates to. It suits providers whose data doesn't outlive the data of p (e.g. in-memory providers), for
+// which New would create new keys in the kms.KeyManager every time they are wrapped.
+func (p *Provider) Wrap(provider storage.Provider) (*Provider, error) {
+	keyStore, err := provider.OpenStore(KeyStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open key store: %w", err)
+	}
+
+	return &Provider{
+		FormattedProvider: formattedstore.NewProvider(provider, p.formatter),
+		underlying:        provider,
+		keyStore:          keyStore,
+		keyIDsStore:       p.keyIDsStore,
+		formatter:         p.formatter,
+		storeNames:        make(map[string]struct{}),
+	}, nil
+}
+
+func getKeyIDs(keyStore storage.Store, keyManager kms.KeyManager) (*keyIDs, error) {
+	idsBytes, err := keyStore.Get(keyIDsKey)
+	if err == nil {
+		ids := &keyIDs{}
+
+		err = json.Unmarshal(idsBytes, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal key IDs: %w", err)
+		}
+
+		return ids, nil
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("failed to get key IDs: %w", err)
+	}
+
+	ids := &keyIDs{}
+
+	ids.EncryptionKeyID, _, err = keyManager.Create(kms.AES256GCMType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encryption key: %w", err)
+	}
+
+	ids.MACKeyID, _, err = keyManager.Create(kms.HMACSHA256Tag256Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MAC key: %w", err)
+	}
+
+	err = putKeyIDs(keyStore, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func putKeyIDs(keyStore storage.Store, ids *keyIDs) error {
+	idsBytes, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key IDs: %w", err)
+	}
+
+	err = keyStore.Put(keyIDsKey, idsBytes)
+	if err != nil {
+		return fmt.Errorf("failed to save key IDs: %w", err)
+	}
+
+	return nil
+}
+
+// RotateKey creates a new encryption key, with which all values are encrypted from then on. The MAC key blinding
+// keys and tags is not rotated, since blinded keys and tags could not be found again otherwise.
+func (p *Provider) RotateKey() error {
+	keyID, kh, err := p.formatter.keyManager.Create(kms.AES256GCMType)
+	if err != nil {
+		return fmt.Errorf("failed to create encryption key: %w", err)
+	}
+
+	p.formatter.lock.Lock()
+	defer p.formatter.lock.Unlock()
+
+	ids, err := getKeyIDs(p.keyIDsStore, p.formatter.keyManager)
+	if err != nil {
+		return err
+	}
+
+	ids.EncryptionKeyID = keyID
+
+	err = putKeyIDs(p.keyIDsStore, ids)
+	if err != nil {
+		return err
+	}
+
+	p.formatter.encryptionKeyID = keyID
+	p.formatter.encryptionKHs[keyID] = kh
+
+	return nil
+}
+
+// ReEncrypt re-encrypts, with the current encryption key, the values of the given stores that were encrypted with
+// an earlier one. All the stores ever opened through an encrypted Provider are re-encrypted if no store name is
+// given. The entries of stores are found as described in the migrate package.
+func (p *Provider) ReEncrypt(storeNames ...string) error {
+	names, err := p.storesToReEncrypt(storeNames)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		err = p.reEncryptStore(name)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt store %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// OpenStore opens a store with the given name and returns a handle. The name is recorded so that ReEncrypt can find
+// the store later.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.FormattedProvider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.storeNames[name]; !ok {
+		err = p.keyStore.Put(storeKeyPrefix+name, []byte(name), storage.Tag{Name: storeNameTagName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record store name: %w", err)
+		}
+
+		p.storeNames[name] = struct{}{}
+	}
+
+	return store, nil
+}
+
+func (p *Provider) storesToReEncrypt(storeNames []string) ([]string, error) {
+	if len(storeNames) == 0 {
+		iterator, err := p.keyStore.Query(storeNameTagName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query store names: %w", err)
+		}
+
+		defer storage.Close(iterator, logger)
+
+		for {
+			ok, err := iterator.Next()
+			if err != nil {
+				return nil, fmt.Errorf("failed to query store names: %w", err)
+			}
+
+			if !ok {
+				break
+			}
+
+			name, err := iterator.Value()
+			if err != nil {
+				return nil, fmt.Errorf("failed to query store names: %w", err)
+			}
+
+			storeNames = append(storeNames, string(name))
+		}
+	}
+
+	names := make([]string, 0, 2*len(storeNames))
+
+	for _, name := range storeNames {
+		names = append(names, strings.ToLower(name), strings.ToLower(name)+configStoreSuffix)
+	}
+
+	return names, nil
+}
+
+func (p *Provider) reEncryptStore(name string) error {
+	store, err := p.underlying.OpenStore(name)
+	if err != nil {
+		return fmt.Errorf("failed to open store: %w", err)
+	}
+
+	config, err := p.underlying.GetStoreConfig(name)
+	if err != nil && !errors.Is(err, storage.ErrDataNotFound) && !errors.Is(err, storage.ErrStoreNotFound) {
+		return fmt.Errorf("failed to get store configuration: %w", err)
+	}
+
+	keys, err := migrate.ListKeys(store, config, reEncryptPageSize)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err = p.reEncryptEntry(store, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) reEncryptEntry(store storage.Store, key string) error {
+	formattedValue, err := store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil // Deleted since the keys were listed.
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", key, err)
+	}
+
+	reEncryptedValue, err := p.formatter.reEncrypt(formattedValue)
+	if err != nil || reEncryptedValue == nil {
+		return err
+	}
+
+	formattedTags, err := store.GetTags(key)
+	if err != nil {
+		return fmt.Errorf("failed to get tags of %s: %w", key, err)
+	}
+
+	err = store.Put(key, reEncryptedValue, formattedTags...)
+	if err != nil {
+		return fmt.Errorf("failed to put %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// encryptedValue is a formatted value.
+type encryptedValue struct {
+	KeyID      string `json:"kid"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// entry is the plaintext of an encrypted value. Blinded keys and tags cannot be reversed, so they are kept along
+// with the value.
+type entry struct {
+	Key   string        `json:"key"`
+	Value []byte        `json:"value"`
+	Tags  []storage.Tag `json:"tags,omitempty"`
+}
+
+// formatter is the formattedstore.Formatter encrypting values and blinding keys and tags.
+type formatter struct {
+	keyManager      kms.KeyManager
+	crypto          crypto.Crypto
+	macKH           interface{}
+	encryptionKeyID string
+	encryptionKHs   map[string]interface{}
+	lock            sync.RWMutex
+}
+
+func (f *formatter) Format(key string, value []byte, tags ...storage.Tag) (string, []byte, []storage.Tag, error) {
+	var (
+		formattedKey string
+		err          error
+	)
+
+	if key != "" {
+		formattedKey, err = f.blind("key", key)
+		if err != nil {
+			return "", nil, nil, err
+		}
+	}
+
+	formattedTags := make([]storage.Tag, len(tags))
+
+	for i, tag := range tags {
+		formattedTags[i].Name, err = f.blind("tag name", tag.Name)
+		if err != nil {
+			return "", nil, nil, err
+		}
+
+		if tag.Value != "" {
+			formattedTags[i].Value, err = f.blind("tag value", tag.Name, tag.Value)
+			if err != nil {
+				return "", nil, nil, err
+			}
+		}
+	}
+
+	if value == nil {
+		return formattedKey, nil, formattedTags, nil
+	}
+
+	formattedValue, err := f.encrypt(entry{Key: key, Value: value, Tags: tags})
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return formattedKey, formattedValue, formattedTags, nil
+}
+
+func (f *formatter) Deformat(_ string, formattedValue []byte, _ ...storage.Tag) (string, []byte, []storage.Tag,
+	error) {
+	if formattedValue == nil {
+		return "", nil, nil, errors.New("the formatted value is required to deformat keys and tags")
+	}
+
+	_, decrypted, err := f.decrypt(formattedValue)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return decrypted.Key, decrypted.Value, decrypted.Tags, nil
+}
+
+// blind computes the MAC of the given parts, length-prefixed so that they cannot run into each other, and encodes
+// it so that it can be used in a query expression.
+func (f *formatter) blind(parts ...string) (string, error) {
+	var data []byte
+
+	for _, part := range parts {
+		var length [4]byte
+
+		binary.BigEndian.PutUint32(length[:], uint32(len(part)))
+
+		data = append(data, length[:]...)
+		data = append(data, part...)
+	}
+
+	mac, err := f.crypto.ComputeMAC(data, f.macKH)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute %s MAC: %w", parts[0], err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mac), nil
+}
+
+func (f *formatter) encrypt(plaintext entry) ([]byte, error) {
+	plaintextBytes, err := json.Marshal(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal entry: %w", err)
+	}
+
+	f.lock.RLock()
+	keyID, kh := f.encryptionKeyID, f.encryptionKHs[f.encryptionKeyID]
+	f.lock.RUnlock()
+
+	ciphertext, nonce, err := f.crypto.Encrypt(plaintextBytes, []byte(keyID), kh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt entry: %w", err)
+	}
+
+	formattedValue, err := json.Marshal(encryptedValue{KeyID: keyID, Nonce: nonce, Ciphertext: ciphertext})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal encrypted value: %w", err)
+	}
+
+	return formattedValue, nil
+}
+
+func (f *formatter) decrypt(formattedValue []byte) (string, *entry, error) {
+	var value encryptedValue
+
+	err := json.Unmarshal(formattedValue, &value)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to unmarshal encrypted value: %w", err)
+	}
+
+	kh, err := f.getEncryptionKey(value.KeyID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	plaintextBytes, err := f.crypto.Decrypt(value.Ciphertext, []byte(value.KeyID), value.Nonce, kh)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decrypt entry: %w", err)
+	}
+
+	var plaintext entry
+
+	err = json.Unmarshal(plaintextBytes, &plaintext)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to unmarshal entry: %w", err)
+	}
+
+	return value.KeyID, &plaintext, nil
+}
+
+// reEncrypt returns the value re-encrypted with the current encryption key, or nil if it already is.
+func (f *formatter) reEncrypt(formattedValue []byte) ([]byte, error) {
+	keyID, decrypted, err := f.decrypt(formattedValue)
+	if err != nil {
+		return nil, err
+	}
+
+	f.lock.RLock()
+	current := keyID == f.encryptionKeyID
+	f.lock.RUnlock()
+
+	if current {
+		return nil, nil
+	}
+
+	return f.encrypt(*decrypted)
+}
+
+func (f *formatter) getEncryptionKey(keyID string) (interface{}, error) {
+	f.lock.RLock()
+	kh, ok := f.encryptionKHs[keyID]
+	f.lock.RUnlock()
+
+	if ok {
+		return kh, nil
+	}
+
+	kh, err := f.keyManager.Get(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key %s: %w", keyID, err)
+	}
+
+	f.lock.Lock()
+	f.encryptionKHs[keyID] = kh
+	f.lock.Unlock()
+
+	return kh, nil
+}
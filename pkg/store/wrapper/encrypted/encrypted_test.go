@@ -0,0 +1,331 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encrypted_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/migrate"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/wrapper/encrypted"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestProvider(t *testing.T) {
+	t.Run("Put, get, query and delete", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		provider := newProvider(t, underlying, newKMS(t))
+
+		store, err := provider.OpenStore("connections")
+		require.NoError(t, err)
+
+		require.NoError(t, provider.SetStoreConfig("connections",
+			storage.StoreConfiguration{TagNames: []string{"state"}}))
+
+		config, err := provider.GetStoreConfig("connections")
+		require.NoError(t, err)
+		require.Equal(t, []string{"state"}, config.TagNames)
+
+		require.NoError(t, store.Put("conn1", []byte("connection1"), storage.Tag{Name: "state", Value: "completed"}))
+		require.NoError(t, store.Put("conn2", []byte("connection2"), storage.Tag{Name: "state", Value: "requested"}))
+
+		value, err := store.Get("conn1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("connection1"), value)
+
+		tags, err := store.GetTags("conn1")
+		require.NoError(t, err)
+		require.Equal(t, []storage.Tag{{Name: "state", Value: "completed"}}, tags)
+
+		iterator, err := store.Query("state:requested")
+		require.NoError(t, err)
+
+		ok, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+		require.Equal(t, "conn2", key)
+
+		value, err = iterator.Value()
+		require.NoError(t, err)
+		require.Equal(t, []byte("connection2"), value)
+
+		ok, err = iterator.Next()
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.NoError(t, iterator.Close())
+
+		require.NoError(t, store.Delete("conn1"))
+
+		_, err = store.Get("conn1")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		// Nothing but blinded keys, blinded tags and encrypted values reaches the underlying provider.
+		underlyingStore, err := underlying.OpenStore("connections")
+		require.NoError(t, err)
+
+		_, err = underlyingStore.Get("conn2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		keys, err := underlyingStore.(migrate.KeyLister).Keys()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+
+		formattedValue, err := underlyingStore.Get(keys[0])
+		require.NoError(t, err)
+		require.NotContains(t, string(formattedValue), "conn2")
+		require.NotContains(t, string(formattedValue), "connection2")
+		require.NotContains(t, string(formattedValue), "requested")
+
+		formattedTags, err := underlyingStore.GetTags(keys[0])
+		require.NoError(t, err)
+		require.Len(t, formattedTags, 1)
+		require.NotEqual(t, "state", formattedTags[0].Name)
+		require.NotEqual(t, "requested", formattedTags[0].Value)
+	})
+	t.Run("Reopen with the same KMS", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		keyManager := newKMS(t)
+
+		store, err := newProvider(t, underlying, keyManager).OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		store, err = newProvider(t, underlying, keyManager).OpenStore("store")
+		require.NoError(t, err)
+
+		value, err := store.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		// Another KMS has none of the keys.
+		_, err = encrypted.New(underlying, newKMS(t), newCrypto(t))
+		require.Contains(t, err.Error(), "failed to get encryption key")
+	})
+	t.Run("Rotate the key and re-encrypt", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		keyManager := newKMS(t)
+		provider := newProvider(t, underlying, keyManager)
+
+		store, err := provider.OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, provider.SetStoreConfig("store", storage.StoreConfiguration{TagNames: []string{"tag"}}))
+		require.NoError(t, store.Put("old", []byte("old value"), storage.Tag{Name: "tag", Value: "old"}))
+
+		keyIDs := distinctKeyIDs(t, underlying, "store")
+		require.Len(t, keyIDs, 1)
+
+		oldKeyID := keyIDs[0]
+
+		require.NoError(t, provider.RotateKey())
+		require.NoError(t, store.Put("new", []byte("new value")))
+		require.Len(t, distinctKeyIDs(t, underlying, "store"), 2)
+
+		// Values encrypted with the previous key remain readable, also after reopening.
+		store, err = newProvider(t, underlying, keyManager).OpenStore("store")
+		require.NoError(t, err)
+
+		value, err := store.Get("old")
+		require.NoError(t, err)
+		require.Equal(t, []byte("old value"), value)
+
+		require.NoError(t, provider.ReEncrypt())
+
+		keyIDs = distinctKeyIDs(t, underlying, "store")
+		require.Len(t, keyIDs, 1)
+		require.NotEqual(t, oldKeyID, keyIDs[0])
+
+		// Store configurations are re-encrypted along with their store.
+		require.Equal(t, keyIDs, distinctKeyIDs(t, underlying, "store_formattedstore_storeconfig"))
+
+		tags, err := store.GetTags("old")
+		require.NoError(t, err)
+		require.Equal(t, []storage.Tag{{Name: "tag", Value: "old"}}, tags)
+
+		iterator, err := store.Query("tag:old")
+		require.NoError(t, err)
+
+		ok, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, iterator.Close())
+
+		require.NoError(t, provider.ReEncrypt("store"))
+	})
+	t.Run("Wrap another provider", func(t *testing.T) {
+		underlying := mem.NewProvider()
+		provider := newProvider(t, underlying, newKMS(t))
+
+		_, err := provider.OpenStore("store")
+		require.NoError(t, err)
+
+		otherUnderlying := mem.NewProvider()
+
+		other, err := provider.Wrap(otherUnderlying)
+		require.NoError(t, err)
+
+		store, err := other.OpenStore("other")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		value, err := store.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		// No key is created for the wrapped provider.
+		keyStore, err := otherUnderlying.OpenStore(encrypted.KeyStoreName)
+		require.NoError(t, err)
+
+		_, err = keyStore.Get("keyids")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		keyIDs := distinctKeyIDs(t, otherUnderlying, "other")
+		require.Len(t, keyIDs, 1)
+
+		// The rotations of either provider apply to both.
+		require.NoError(t, other.RotateKey())
+		require.NoError(t, other.ReEncrypt())
+
+		rotatedKeyIDs := distinctKeyIDs(t, otherUnderlying, "other")
+		require.Len(t, rotatedKeyIDs, 1)
+		require.NotEqual(t, keyIDs, rotatedKeyIDs)
+
+		_, err = keyStore.Get("keyids")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		store, err = provider.OpenStore("store")
+		require.NoError(t, err)
+		require.NoError(t, store.Put("key", []byte("value")))
+		require.Equal(t, rotatedKeyIDs, distinctKeyIDs(t, underlying, "store"))
+
+		_, err = provider.Wrap(&failingProvider{Provider: mem.NewProvider()})
+		require.EqualError(t, err, "failed to open key store: open failed")
+	})
+	t.Run("Fail to open the key store", func(t *testing.T) {
+		_, err := encrypted.New(&failingProvider{Provider: mem.NewProvider()}, newKMS(t), newCrypto(t))
+		require.EqualError(t, err, "failed to open key store: open failed")
+	})
+	t.Run("Fail to create keys", func(t *testing.T) {
+		_, err := encrypted.New(mem.NewProvider(), &mockkms.KeyManager{CreateKeyErr: errors.New("create failed")},
+			newCrypto(t))
+		require.EqualError(t, err, "failed to create encryption key: create failed")
+	})
+	t.Run("Fail to decrypt a tampered value", func(t *testing.T) {
+		underlying := mem.NewProvider()
+
+		store, err := newProvider(t, underlying, newKMS(t)).OpenStore("store")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		underlyingStore, err := underlying.OpenStore("store")
+		require.NoError(t, err)
+
+		keys, err := underlyingStore.(migrate.KeyLister).Keys()
+		require.NoError(t, err)
+
+		formattedValue, err := underlyingStore.Get(keys[0])
+		require.NoError(t, err)
+
+		var value map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(formattedValue, &value))
+
+		value["ciphertext"] = "AAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+
+		formattedValue, err = json.Marshal(value)
+		require.NoError(t, err)
+
+		require.NoError(t, underlyingStore.Put(keys[0], formattedValue))
+
+		_, err = store.Get("key")
+		require.Contains(t, err.Error(), "failed to decrypt entry")
+	})
+}
+
+func newProvider(t *testing.T, underlying storage.Provider, keyManager kms.KeyManager) *encrypted.Provider {
+	t.Helper()
+
+	provider, err := encrypted.New(underlying, keyManager, newCrypto(t))
+	require.NoError(t, err)
+
+	return provider
+}
+
+func newKMS(t *testing.T) kms.KeyManager {
+	t.Helper()
+
+	keyManager, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return keyManager
+}
+
+func newCrypto(t *testing.T) *tinkcrypto.Crypto {
+	t.Helper()
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return cr
+}
+
+// distinctKeyIDs returns the IDs of the keys with which the entries of the underlying store were encrypted.
+func distinctKeyIDs(t *testing.T, underlying storage.Provider, name string) []string {
+	t.Helper()
+
+	store, err := underlying.OpenStore(name)
+	require.NoError(t, err)
+
+	keys, err := store.(migrate.KeyLister).Keys()
+	require.NoError(t, err)
+
+	var keyIDs []string
+
+	seen := make(map[string]bool)
+
+	for _, key := range keys {
+		formattedValue, err := store.Get(key)
+		require.NoError(t, err)
+
+		var value struct {
+			KeyID string `json:"kid"`
+		}
+
+		require.NoError(t, json.Unmarshal(formattedValue, &value))
+
+		if !seen[value.KeyID] {
+			seen[value.KeyID] = true
+			keyIDs = append(keyIDs, value.KeyID)
+		}
+	}
+
+	return keyIDs
+}
+
+type failingProvider struct {
+	*mem.Provider
+}
+
+func (f *failingProvider) OpenStore(string) (storage.Store, error) {
+	return nil, errors.New("open failed")
+}
@@ -0,0 +1,324 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// CacheStoreName is the name of the store of the resolutions persisted by NewPersistentCache.
+	CacheStoreName = "vdrcache"
+
+	cacheTagName = "resolution"
+)
+
+// ResolutionCache caches the DID resolutions of a Registry (see WithCache). Implementations must be safe for
+// concurrent use.
+type ResolutionCache interface {
+	// Get returns the cache entry of a DID, if it has not expired.
+	Get(did string) (*CacheEntry, bool)
+	// Put adds or replaces the cache entry of a DID.
+	Put(did string, entry *CacheEntry)
+	// Delete removes the cache entry of a DID, if any.
+	Delete(did string)
+}
+
+// CacheEntry is a cached DID resolution, valid until Expiry. DocResolution is nil for the DIDs which were not found
+// (negative caching).
+type CacheEntry struct {
+	DocResolution *diddoc.DocResolution
+	Expiry        time.Time
+}
+
+func (e *CacheEntry) expired() bool {
+	return !time.Now().Before(e.Expiry)
+}
+
+// cacheRecord is a persisted resolution. Its key is the hash of the DID, as DIDs (e.g. long-form Sidetree DIDs) can
+// exceed the key size of some stores.
+type cacheRecord struct {
+	DID           string          `json:"did"`
+	DocResolution json.RawMessage `json:"resolution,omitempty"`
+	Expiry        time.Time       `json:"expiry"`
+}
+
+type cacheItem struct {
+	did   string
+	entry *CacheEntry
+}
+
+// Cache is a ResolutionCache holding the most recently used resolutions in memory, which can also be persisted to a
+// store to survive restarts. Cached resolutions are shared by the callers of Registry.Resolve and must not be
+// modified.
+type Cache struct {
+	mutex   sync.Mutex
+	size    int
+	items   map[string]*list.Element
+	recency *list.List
+	store   storage.Store
+	// storeMutex orders the writes to the store, which are done without holding mutex so that a slow store doesn't
+	// block the resolutions.
+	storeMutex sync.Mutex
+}
+
+// NewCache returns a Cache holding up to size resolutions in memory.
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:    size,
+		items:   make(map[string]*list.Element),
+		recency: list.New(),
+	}
+}
+
+// NewPersistentCache returns a Cache holding up to size resolutions, which are persisted to the CacheStoreName store
+// of the storage provider. The unexpired resolutions of the store are loaded into the cache.
+func NewPersistentCache(provider storage.Provider, size int) (*Cache, error) {
+	store, err := provider.OpenStore(CacheStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open %s store: %w", CacheStoreName, err)
+	}
+
+	err = provider.SetStoreConfig(CacheStoreName, storage.StoreConfiguration{TagNames: []string{cacheTagName}})
+	if err != nil {
+		return nil, fmt.Errorf("set store config of %s store: %w", CacheStoreName, err)
+	}
+
+	cache := NewCache(size)
+	cache.store = store
+
+	if err := cache.load(); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// Get returns the cache entry of a DID, if it has not expired.
+func (c *Cache) Get(did string) (*CacheEntry, bool) {
+	c.mutex.Lock()
+
+	elem, ok := c.items[did]
+	if !ok {
+		c.mutex.Unlock()
+
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheItem).entry
+	if entry.expired() {
+		c.remove(elem)
+		c.mutex.Unlock()
+
+		c.sync(did)
+
+		return nil, false
+	}
+
+	c.recency.MoveToFront(elem)
+	c.mutex.Unlock()
+
+	return entry, true
+}
+
+// Put adds or replaces the cache entry of a DID, evicting the least recently used entry if the cache is full.
+func (c *Cache) Put(did string, entry *CacheEntry) {
+	c.mutex.Lock()
+	evicted := c.add(did, entry)
+	c.mutex.Unlock()
+
+	c.sync(append(evicted, did)...)
+}
+
+// Delete removes the cache entry of a DID, if any.
+func (c *Cache) Delete(did string) {
+	c.mutex.Lock()
+
+	elem, ok := c.items[did]
+	if ok {
+		c.remove(elem)
+	}
+
+	c.mutex.Unlock()
+
+	if ok {
+		c.sync(did)
+	}
+}
+
+// add adds or replaces the cache entry of a DID in memory, and returns the DIDs of the evicted entries.
+func (c *Cache) add(did string, entry *CacheEntry) []string {
+	if elem, ok := c.items[did]; ok {
+		elem.Value.(*cacheItem).entry = entry
+		c.recency.MoveToFront(elem)
+
+		return nil
+	}
+
+	c.items[did] = c.recency.PushFront(&cacheItem{did: did, entry: entry})
+
+	var evicted []string
+
+	for c.recency.Len() > c.size {
+		evicted = append(evicted, c.remove(c.recency.Back()))
+	}
+
+	return evicted
+}
+
+// remove removes an entry from memory and returns its DID.
+func (c *Cache) remove(elem *list.Element) string {
+	did := c.recency.Remove(elem).(*cacheItem).did
+	delete(c.items, did)
+
+	return did
+}
+
+// sync writes the in-memory state of the DIDs to the store: the resolutions of the cached DIDs are persisted and the
+// others are deleted. The entries are read again under storeMutex, so the store ends up with the latest state of each
+// DID whatever the order in which concurrent calls acquire it.
+func (c *Cache) sync(dids ...string) {
+	if c.store == nil {
+		return
+	}
+
+	c.storeMutex.Lock()
+	defer c.storeMutex.Unlock()
+
+	for _, did := range dids {
+		c.mutex.Lock()
+
+		var entry *CacheEntry
+
+		if elem, ok := c.items[did]; ok {
+			entry = elem.Value.(*cacheItem).entry
+		}
+
+		c.mutex.Unlock()
+
+		if entry == nil {
+			if err := c.store.Delete(cacheKey(did)); err != nil {
+				logger.Warnf("failed to delete the persisted resolution of %s: %s", did, err)
+			}
+
+			continue
+		}
+
+		if err := c.persist(did, entry); err != nil {
+			logger.Warnf("failed to persist the cached resolution of %s: %s", did, err)
+		}
+	}
+}
+
+func (c *Cache) persist(did string, entry *CacheEntry) error {
+	record := &cacheRecord{DID: did, Expiry: entry.Expiry}
+
+	if entry.DocResolution != nil {
+		resolution, err := entry.DocResolution.JSONBytes()
+		if err != nil {
+			return fmt.Errorf("marshal resolution: %w", err)
+		}
+
+		record.DocResolution = resolution
+	}
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal cache record: %w", err)
+	}
+
+	return c.store.Put(cacheKey(did), recordBytes, storage.Tag{Name: cacheTagName})
+}
+
+// cacheKey returns the store key of the persisted resolution of a DID.
+func cacheKey(did string) string {
+	hash := sha256.Sum256([]byte(did))
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func (c *Cache) load() error {
+	iterator, err := c.store.Query(cacheTagName)
+	if err != nil {
+		return fmt.Errorf("query %s store: %w", CacheStoreName, err)
+	}
+
+	defer storage.Close(iterator, logger)
+
+	// expired or evicted records
+	var stale []string
+
+	more, err := iterator.Next()
+
+	for ; err == nil && more; more, err = iterator.Next() {
+		key, did, entry, e := readCacheRecord(iterator)
+		if e != nil {
+			return e
+		}
+
+		if entry == nil || entry.expired() {
+			stale = append(stale, key)
+
+			continue
+		}
+
+		for _, evictedDID := range c.add(did, entry) {
+			stale = append(stale, cacheKey(evictedDID))
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("iterate over %s store: %w", CacheStoreName, err)
+	}
+
+	for _, key := range stale {
+		if err := c.store.Delete(key); err != nil {
+			return fmt.Errorf("delete stale resolution %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// readCacheRecord reads the key, the DID and the entry of the current cache record of the iterator. The entry is nil
+// if the record is not valid anymore (e.g. persisted by a previous version).
+func readCacheRecord(iterator storage.Iterator) (string, string, *CacheEntry, error) {
+	key, err := iterator.Key()
+	if err != nil {
+		return "", "", nil, fmt.Errorf("get key of cache record: %w", err)
+	}
+
+	value, err := iterator.Value()
+	if err != nil {
+		return "", "", nil, fmt.Errorf("get value of cache record: %w", err)
+	}
+
+	record := &cacheRecord{}
+	if err := json.Unmarshal(value, record); err != nil || record.DID == "" || cacheKey(record.DID) != key {
+		return key, "", nil, nil //nolint:nilerr // invalid records are dropped
+	}
+
+	entry := &CacheEntry{Expiry: record.Expiry}
+
+	if len(record.DocResolution) > 0 {
+		entry.DocResolution, err = diddoc.ParseDocumentResolution(record.DocResolution)
+		if err != nil {
+			return key, "", nil, nil //nolint:nilerr // invalid records are dropped
+		}
+	}
+
+	return key, record.DID, entry, nil
+}
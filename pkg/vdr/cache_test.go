@@ -0,0 +1,260 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("test get, put and delete", func(t *testing.T) {
+		cache := NewCache(10)
+
+		_, ok := cache.Get("did:example:1")
+		require.False(t, ok)
+
+		resolution := &did.DocResolution{DIDDocument: &did.Doc{ID: "did:example:1"}}
+		cache.Put("did:example:1", &CacheEntry{DocResolution: resolution, Expiry: time.Now().Add(time.Hour)})
+
+		entry, ok := cache.Get("did:example:1")
+		require.True(t, ok)
+		require.Equal(t, resolution, entry.DocResolution)
+
+		cache.Delete("did:example:1")
+
+		_, ok = cache.Get("did:example:1")
+		require.False(t, ok)
+	})
+
+	t.Run("test expiry", func(t *testing.T) {
+		cache := NewCache(10)
+		cache.Put("did:example:1", &CacheEntry{Expiry: time.Now().Add(-time.Second)})
+
+		_, ok := cache.Get("did:example:1")
+		require.False(t, ok)
+		require.Empty(t, cache.items)
+	})
+
+	t.Run("test least recently used entries are evicted", func(t *testing.T) {
+		cache := NewCache(2)
+		expiry := time.Now().Add(time.Hour)
+
+		cache.Put("did:example:1", &CacheEntry{Expiry: expiry})
+		cache.Put("did:example:2", &CacheEntry{Expiry: expiry})
+
+		_, ok := cache.Get("did:example:1")
+		require.True(t, ok)
+
+		cache.Put("did:example:3", &CacheEntry{Expiry: expiry})
+
+		_, ok = cache.Get("did:example:2")
+		require.False(t, ok)
+
+		_, ok = cache.Get("did:example:1")
+		require.True(t, ok)
+
+		_, ok = cache.Get("did:example:3")
+		require.True(t, ok)
+	})
+}
+
+func TestPersistentCache(t *testing.T) {
+	t.Run("test resolutions are persisted", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		cache, err := NewPersistentCache(provider, 3)
+		require.NoError(t, err)
+
+		doc, err := did.ParseDocument([]byte(`{"@context":"https://www.w3.org/ns/did/v1","id":"did:example:1"}`))
+		require.NoError(t, err)
+
+		expiry := time.Now().Add(time.Hour)
+
+		cache.Put("did:example:1", &CacheEntry{
+			DocResolution: &did.DocResolution{DIDDocument: doc, DocumentMetadata: &did.DocumentMetadata{}},
+			Expiry:        expiry,
+		})
+		cache.Put("did:example:2", &CacheEntry{Expiry: expiry})
+		cache.Put("did:example:3", &CacheEntry{Expiry: time.Now().Add(time.Millisecond)})
+
+		_, ok := cache.Get("did:example:1")
+		require.True(t, ok)
+
+		cache.Put("did:example:4", &CacheEntry{Expiry: expiry})
+
+		time.Sleep(10 * time.Millisecond)
+
+		cache, err = NewPersistentCache(provider, 3)
+		require.NoError(t, err)
+
+		// did:example:2 was evicted and did:example:3 expired
+		for _, id := range []string{"did:example:2", "did:example:3"} {
+			_, ok := cache.Get(id)
+			require.False(t, ok, id)
+		}
+
+		entry, ok := cache.Get("did:example:4")
+		require.True(t, ok)
+		require.Nil(t, entry.DocResolution)
+
+		entry, ok = cache.Get("did:example:1")
+		require.True(t, ok)
+		require.Equal(t, "did:example:1", entry.DocResolution.DIDDocument.ID)
+		require.True(t, expiry.Equal(entry.Expiry))
+
+		store, err := provider.OpenStore(CacheStoreName)
+		require.NoError(t, err)
+
+		_, err = store.Get(cacheKey("did:example:3"))
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+
+		_, err = store.Get(cacheKey("did:example:1"))
+		require.NoError(t, err)
+
+		cache.Delete("did:example:1")
+
+		_, err = store.Get(cacheKey("did:example:1"))
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("test resolutions of long DIDs are persisted with short keys", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		cache, err := NewPersistentCache(provider, 1)
+		require.NoError(t, err)
+
+		longDID := "did:sidetree:EiDyOQbbZAa3aiRzeCkV7LOx3SERjjH93EXoIM3UoN4oWg:" + strings.Repeat("a", 2048)
+
+		cache.Put(longDID, &CacheEntry{Expiry: time.Now().Add(time.Hour)})
+
+		store, err := provider.OpenStore(CacheStoreName)
+		require.NoError(t, err)
+
+		iterator, err := store.Query(cacheTagName)
+		require.NoError(t, err)
+
+		more, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+		require.Len(t, key, 43)
+		require.NoError(t, iterator.Close())
+
+		cache, err = NewPersistentCache(provider, 1)
+		require.NoError(t, err)
+
+		_, ok := cache.Get(longDID)
+		require.True(t, ok)
+	})
+
+	t.Run("test invalid records are dropped", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		store, err := provider.OpenStore(CacheStoreName)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put(cacheKey("did:example:1"), []byte("{"), storage.Tag{Name: cacheTagName}))
+		require.NoError(t, store.Put(cacheKey("did:example:2"),
+			[]byte(`{"did":"did:example:2","resolution":{},"expiry":"2100-01-01T00:00:00Z"}`),
+			storage.Tag{Name: cacheTagName}))
+		// records of a previous version were keyed by DID
+		require.NoError(t, store.Put("did:example:3", []byte(`{"expiry":"2100-01-01T00:00:00Z"}`),
+			storage.Tag{Name: cacheTagName}))
+
+		cache, err := NewPersistentCache(provider, 3)
+		require.NoError(t, err)
+		require.Empty(t, cache.items)
+
+		for _, key := range []string{cacheKey("did:example:1"), cacheKey("did:example:2"), "did:example:3"} {
+			_, err = store.Get(key)
+			require.ErrorIs(t, err, storage.ErrDataNotFound)
+		}
+	})
+
+	t.Run("test store writes don't block the cache", func(t *testing.T) {
+		provider := mem.NewProvider()
+
+		cache, err := NewPersistentCache(provider, 2)
+		require.NoError(t, err)
+
+		expiry := time.Now().Add(time.Hour)
+		cache.Put("did:example:1", &CacheEntry{Expiry: expiry})
+
+		release := make(chan struct{})
+		cache.store = &blockingStore{Store: cache.store, release: release}
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			cache.Put("did:example:2", &CacheEntry{Expiry: expiry})
+		}()
+
+		require.Eventually(t, func() bool {
+			_, ok := cache.Get("did:example:2")
+
+			return ok
+		}, time.Second, time.Millisecond)
+
+		_, ok := cache.Get("did:example:1")
+		require.True(t, ok)
+
+		close(release)
+		<-done
+
+		store, err := provider.OpenStore(CacheStoreName)
+		require.NoError(t, err)
+
+		_, err = store.Get(cacheKey("did:example:2"))
+		require.NoError(t, err)
+	})
+
+	t.Run("test store errors", func(t *testing.T) {
+		_, err := NewPersistentCache(&mockstorage.MockStoreProvider{
+			FailNamespace: CacheStoreName,
+			Store:         &mockstorage.MockStore{Store: map[string]mockstorage.DBEntry{}},
+		}, 2)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "open vdrcache store")
+
+		_, err = NewPersistentCache(&mockstorage.MockStoreProvider{
+			Store: &mockstorage.MockStore{
+				Store: map[string]mockstorage.DBEntry{
+					"did:example:1": {Value: []byte("{}"), Tags: []storage.Tag{{Name: cacheTagName}}},
+				},
+				ErrDelete: errors.New("delete error"),
+			},
+		}, 2)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "delete error")
+	})
+}
+
+// blockingStore is a store whose writes wait until release is closed.
+type blockingStore struct {
+	storage.Store
+	release chan struct{}
+}
+
+func (s *blockingStore) Put(key string, value []byte, tags ...storage.Tag) error {
+	<-s.release
+
+	return s.Store.Put(key, value, tags...)
+}
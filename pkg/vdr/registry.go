@@ -10,13 +10,17 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 )
 
+var logger = log.New("aries-framework/vdr")
+
 // Option is a vdr instance option.
 type Option func(opts *Registry)
 
@@ -31,11 +35,15 @@ type Registry struct {
 	kms                kms.KeyManager
 	defServiceEndpoint string
 	defServiceType     string
+	cache              ResolutionCache
+	cacheTTL           time.Duration
+	methodCacheTTL     map[string]time.Duration
+	negativeCacheTTL   time.Duration
 }
 
 // New return new instance of vdr.
 func New(ctx provider, opts ...Option) *Registry {
-	baseVDR := &Registry{kms: ctx.KMS()}
+	baseVDR := &Registry{kms: ctx.KMS(), methodCacheTTL: make(map[string]time.Duration)}
 
 	// Apply options
 	for _, opt := range opts {
@@ -45,7 +53,8 @@ func New(ctx provider, opts ...Option) *Registry {
 	return baseVDR
 }
 
-// Resolve did document.
+// Resolve did document. Resolutions are cached if the registry has a cache (see WithCache), unless a specific
+// version is requested; vdrapi.WithNoCache(true) bypasses the cached resolution and refreshes it.
 func (r *Registry) Resolve(did string, opts ...vdrapi.ResolveOption) (*diddoc.DocResolution, error) {
 	didMethod, err := GetDidMethod(did)
 	if err != nil {
@@ -58,20 +67,64 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.ResolveOption) (*diddoc.Do
 		return nil, err
 	}
 
+	resolveOpts := &vdrapi.ResolveOpts{}
+	for _, opt := range opts {
+		opt(resolveOpts)
+	}
+
+	cached := r.cache != nil && resolveOpts.VersionID == nil && resolveOpts.VersionTime == ""
+
+	if cached && !resolveOpts.NoCache {
+		if entry, ok := r.cache.Get(did); ok {
+			if entry.DocResolution == nil {
+				return nil, fmt.Errorf("cached resolution of %s: %w", did, vdrapi.ErrNotFound)
+			}
+
+			return entry.DocResolution, nil
+		}
+	}
+
 	// Obtain the DID Document
 	didDocResolution, err := method.Read(did, opts...)
 	if err != nil {
 		if errors.Is(err, vdrapi.ErrNotFound) {
+			if cached && r.negativeCacheTTL > 0 {
+				r.cache.Put(did, &CacheEntry{Expiry: time.Now().Add(r.negativeCacheTTL)})
+			}
+
 			return nil, err
 		}
 
 		return nil, fmt.Errorf("did method read failed failed: %w", err)
 	}
 
+	if ttl := r.cacheTTLOf(didMethod); cached && ttl > 0 && didDocResolution != nil {
+		r.cache.Put(did, &CacheEntry{DocResolution: didDocResolution, Expiry: time.Now().Add(ttl)})
+	}
+
 	return didDocResolution, nil
 }
 
-// Update did document.
+// Dereference dereferences a DID URL (https://www.w3.org/TR/did-core/#did-url-dereferencing): its DID is resolved,
+// with the versionId and versionTime parameters of the DID URL if any, then the fragment and the service and
+// relativeRef parameters select a verification method or a service of the DID document. vdrapi.ErrInvalidDIDURL,
+// vdrapi.ErrNotFound and vdrapi.ErrResourceNotFound are returned if the DID URL is not valid, if its DID is not
+// found and if it does not identify any resource of the DID document.
+func (r *Registry) Dereference(didURL string, opts ...vdrapi.ResolveOption) (*vdrapi.DereferenceResult, error) {
+	parsed, versionOpts, err := vdrapi.ParseDIDURL(didURL)
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution, err := r.Resolve(parsed.DID.String(), append(opts, versionOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return vdrapi.DereferenceResolution(docResolution, parsed)
+}
+
+// Update did document. The cached resolution of the DID is invalidated.
 func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 	didMethod, err := GetDidMethod(didDoc.ID)
 	if err != nil {
@@ -84,10 +137,12 @@ func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) er
 		return err
 	}
 
+	defer r.invalidate(didDoc.ID)
+
 	return method.Update(didDoc, opts...)
 }
 
-// Deactivate did document.
+// Deactivate did document. The cached resolution of the DID is invalidated.
 func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
 	didMethod, err := GetDidMethod(did)
 	if err != nil {
@@ -100,6 +155,8 @@ func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error
 		return err
 	}
 
+	defer r.invalidate(did)
+
 	return method.Deactivate(did, opts...)
 }
 
@@ -124,9 +181,28 @@ func (r *Registry) Create(didMethod string, did *diddoc.Doc,
 		return nil, err
 	}
 
+	// the DID may have been cached as not found
+	if didDocResolution != nil && didDocResolution.DIDDocument != nil {
+		r.invalidate(didDocResolution.DIDDocument.ID)
+	}
+
 	return didDocResolution, nil
 }
 
+func (r *Registry) invalidate(did string) {
+	if r.cache != nil {
+		r.cache.Delete(did)
+	}
+}
+
+func (r *Registry) cacheTTLOf(method string) time.Duration {
+	if ttl, ok := r.methodCacheTTL[method]; ok {
+		return ttl
+	}
+
+	return r.cacheTTL
+}
+
 // applyDefaultDocOpts applies default creator options to doc options.
 func (r *Registry) applyDefaultDocOpts(docOpts *vdrapi.DIDMethodOpts,
 	opts ...vdrapi.DIDMethodOption) []vdrapi.DIDMethodOption {
@@ -159,7 +235,7 @@ func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("did method %s not supported for vdr", method)
+	return nil, fmt.Errorf("did method %s not supported for vdr: %w", method, vdrapi.ErrMethodNotSupported)
 }
 
 // WithVDR adds did method implementation for store.
@@ -183,6 +259,31 @@ func WithDefaultServiceEndpoint(serviceEndpoint string) Option {
 	}
 }
 
+// WithCache caches resolutions in the given cache for ttl, which can be overridden per method with
+// WithMethodCacheTTL. Resolutions are not cached by default.
+func WithCache(cache ResolutionCache, ttl time.Duration) Option {
+	return func(opts *Registry) {
+		opts.cache = cache
+		opts.cacheTTL = ttl
+	}
+}
+
+// WithMethodCacheTTL caches the resolutions of the DIDs of a method for ttl, instead of the ttl of WithCache.
+// The resolutions of the method are not cached if ttl is zero.
+func WithMethodCacheTTL(method string, ttl time.Duration) Option {
+	return func(opts *Registry) {
+		opts.methodCacheTTL[method] = ttl
+	}
+}
+
+// WithNegativeCacheTTL caches for ttl that DIDs were not found (vdrapi.ErrNotFound), so that they are not resolved
+// again until then or until they are created with the registry.
+func WithNegativeCacheTTL(ttl time.Duration) Option {
+	return func(opts *Registry) {
+		opts.negativeCacheTTL = ttl
+	}
+}
+
 // GetDidMethod get did method.
 func GetDidMethod(didID string) (string, error) {
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/20 Validate that the input DID conforms to
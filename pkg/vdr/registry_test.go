@@ -9,6 +9,7 @@ package vdr
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -115,6 +116,254 @@ func TestRegistry_Resolve(t *testing.T) {
 	})
 }
 
+func TestRegistry_ResolveCache(t *testing.T) {
+	newRegistry := func(opts ...Option) (*Registry, *int) {
+		reads := 0
+
+		return New(&mockprovider.Provider{}, append([]Option{WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.ResolveOption) (*did.DocResolution, error) {
+				reads++
+
+				if didID == "did:example:404" {
+					return nil, vdrapi.ErrNotFound
+				}
+
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+			CreateFunc: func(_ kms.KeyManager, doc *did.Doc,
+				_ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		})}, opts...)...), &reads
+	}
+
+	t.Run("test resolutions are cached", func(t *testing.T) {
+		registry, reads := newRegistry(WithCache(NewCache(10), time.Hour))
+
+		for i := 0; i < 3; i++ {
+			docResolution, err := registry.Resolve("did:example:123")
+			require.NoError(t, err)
+			require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+		}
+
+		require.Equal(t, 1, *reads)
+
+		_, err := registry.Resolve("did:example:123", vdrapi.WithNoCache(true))
+		require.NoError(t, err)
+		require.Equal(t, 2, *reads)
+
+		_, err = registry.Resolve("did:example:123", vdrapi.WithVersionID("1"))
+		require.NoError(t, err)
+		require.Equal(t, 3, *reads)
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 3, *reads)
+	})
+
+	t.Run("test no cache", func(t *testing.T) {
+		registry, reads := newRegistry()
+
+		for i := 0; i < 3; i++ {
+			_, err := registry.Resolve("did:example:123")
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 3, *reads)
+	})
+
+	t.Run("test TTL", func(t *testing.T) {
+		registry, reads := newRegistry(WithCache(NewCache(10), time.Millisecond))
+
+		_, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 2, *reads)
+	})
+
+	t.Run("test method TTL", func(t *testing.T) {
+		registry, reads := newRegistry(WithCache(NewCache(10), time.Hour), WithMethodCacheTTL("peer", 0))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:peer:123")
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, *reads)
+	})
+
+	t.Run("test negative caching", func(t *testing.T) {
+		registry, reads := newRegistry(WithCache(NewCache(10), time.Hour), WithNegativeCacheTTL(time.Hour))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:404")
+			require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		}
+
+		require.Equal(t, 1, *reads)
+
+		_, err := registry.Create("example", &did.Doc{ID: "did:example:404"})
+		require.NoError(t, err)
+
+		// the DID is not cached as not found anymore
+		_, err = registry.Resolve("did:example:404")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		require.Equal(t, 2, *reads)
+
+		registry, reads = newRegistry(WithCache(NewCache(10), time.Hour))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve("did:example:404")
+			require.ErrorIs(t, err, vdrapi.ErrNotFound)
+		}
+
+		require.Equal(t, 2, *reads)
+	})
+
+	t.Run("test update and deactivate invalidate cached resolutions", func(t *testing.T) {
+		registry, reads := newRegistry(WithCache(NewCache(10), time.Hour))
+
+		_, err := registry.Resolve("did:example:123")
+		require.NoError(t, err)
+
+		require.NoError(t, registry.Update(&did.Doc{ID: "did:example:123"}))
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 2, *reads)
+
+		require.NoError(t, registry.Deactivate("did:example:123"))
+
+		_, err = registry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 3, *reads)
+	})
+}
+
+func TestRegistry_Dereference(t *testing.T) {
+	doc, err := did.ParseDocument([]byte(`{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123",
+  "verificationMethod": [{
+    "id": "did:example:123#key-1",
+    "type": "Ed25519VerificationKey2018",
+    "controller": "did:example:123",
+    "publicKeyBase58": "H3C2AVvLMv6gmMNam3uVAjZpfkcJCwDwnZn6z3wXmqPV"
+  }],
+  "authentication": [{
+    "id": "#key-2",
+    "type": "Ed25519VerificationKey2018",
+    "controller": "did:example:123",
+    "publicKeyBase58": "4BWwfeqdp1obQptLLMvPNgBw48p7og1ie6Hf9p5nTpNN"
+  }],
+  "service": [{
+    "id": "did:example:123#agent",
+    "type": "did-communication",
+    "serviceEndpoint": "https://agent.example.com/path/"
+  }]
+}`))
+	require.NoError(t, err)
+
+	var resolveOpts *vdrapi.ResolveOpts
+
+	registry := New(&mockprovider.Provider{}, WithVDR(&mockvdr.MockVDR{
+		AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.ResolveOption) (*did.DocResolution, error) {
+			if didID != doc.ID {
+				return nil, vdrapi.ErrNotFound
+			}
+
+			resolveOpts = &vdrapi.ResolveOpts{}
+			for _, opt := range opts {
+				opt(resolveOpts)
+			}
+
+			return &did.DocResolution{DIDDocument: doc}, nil
+		},
+	}))
+
+	t.Run("test DID", func(t *testing.T) {
+		result, err := registry.Dereference("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, doc, result.DIDDocument)
+		require.NotNil(t, result.DocumentMetadata)
+		require.Nil(t, result.VerificationMethod)
+		require.Nil(t, result.Service)
+	})
+
+	t.Run("test verification method fragments", func(t *testing.T) {
+		result, err := registry.Dereference("did:example:123#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123#key-1", result.VerificationMethod.ID)
+
+		result, err = registry.Dereference("did:example:123#key-2")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123#key-2", result.VerificationMethod.ID)
+	})
+
+	t.Run("test service fragment", func(t *testing.T) {
+		result, err := registry.Dereference("did:example:123#agent")
+		require.NoError(t, err)
+		require.Nil(t, result.VerificationMethod)
+		require.Equal(t, "did:example:123#agent", result.Service.ID)
+		require.Equal(t, "https://agent.example.com/path/", result.ServiceEndpoint)
+	})
+
+	t.Run("test service parameter", func(t *testing.T) {
+		result, err := registry.Dereference("did:example:123?service=agent")
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com/path/", result.ServiceEndpoint)
+
+		result, err = registry.Dereference("did:example:123?service=agent&relativeRef=%2Finbox%3Fa%3D1#frag")
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com/inbox?a=1#frag", result.ServiceEndpoint)
+
+		result, err = registry.Dereference("did:example:123?service=agent&relativeRef=messages")
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com/path/messages", result.ServiceEndpoint)
+	})
+
+	t.Run("test version parameters", func(t *testing.T) {
+		_, err := registry.Dereference("did:example:123?versionId=2&versionTime=2021-01-02T03:04:05Z#key-1",
+			vdrapi.WithNoCache(true))
+		require.NoError(t, err)
+		require.Equal(t, "2", resolveOpts.VersionID)
+		require.Equal(t, "2021-01-02T03:04:05Z", resolveOpts.VersionTime)
+		require.True(t, resolveOpts.NoCache)
+	})
+
+	t.Run("test invalid DID URL", func(t *testing.T) {
+		for _, didURL := range []string{
+			"example:123#key-1",
+			"did:example:123?versionTime=yesterday",
+			"did:example:123?relativeRef=%2Finbox",
+			"did:example:123?service=agent&relativeRef=%",
+		} {
+			_, err := registry.Dereference(didURL)
+			require.ErrorIs(t, err, vdrapi.ErrInvalidDIDURL, didURL)
+		}
+	})
+
+	t.Run("test DID not found", func(t *testing.T) {
+		_, err := registry.Dereference("did:example:456#key-1")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+	})
+
+	t.Run("test resource not found", func(t *testing.T) {
+		for _, didURL := range []string{
+			"did:example:123#key-3",
+			"did:example:123?service=other",
+			"did:example:123/path",
+		} {
+			_, err := registry.Dereference(didURL)
+			require.ErrorIs(t, err, vdrapi.ErrResourceNotFound, didURL)
+		}
+	})
+}
+
 func TestRegistry_Update(t *testing.T) {
 	t.Run("test invalid did input", func(t *testing.T) {
 		registry := New(&mockprovider.Provider{})
@@ -0,0 +1,318 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package resolverservice serves DID resolution over HTTP with the interface of the drivers of the Universal Resolver
+// (https://github.com/decentralized-identity/universal-resolver), so that DIDs can be resolved with the VDRs of
+// the framework by services which do not embed it.
+package resolverservice
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	// IdentifiersPath is the path under which DIDs are resolved: GET IdentifiersPath + {did}.
+	IdentifiersPath = "/1.0/identifiers/"
+
+	// DIDLDJSONType is the media type of the DID document representation.
+	DIDLDJSONType = "application/did+ld+json"
+
+	// ResolutionResultType is the media type of the DID resolution result, holding the DID document along with the
+	// DID resolution and DID document metadata.
+	ResolutionResultType = `application/ld+json;profile="https://w3id.org/did-resolution"`
+
+	// ResolutionContext is the JSON-LD context of DID resolution results.
+	ResolutionContext = "https://w3id.org/did-resolution/v1"
+
+	resolutionProfile = "https://w3id.org/did-resolution"
+	ldJSONType        = "application/ld+json"
+	jsonType          = "application/json"
+
+	versionIDParam   = "versionId"
+	versionTimeParam = "versionTime"
+)
+
+// DID resolution errors, reported in the DID resolution metadata.
+const (
+	ErrorInvalidDID                 = "invalidDid"
+	ErrorNotFound                   = "notFound"
+	ErrorMethodNotSupported         = "methodNotSupported"
+	ErrorRepresentationNotSupported = "representationNotSupported"
+	ErrorInvalidOptions             = "invalidOptions"
+	ErrorInternal                   = "internalError"
+)
+
+var logger = log.New("aries-framework/vdr/resolverservice")
+
+// Resolver resolves DIDs, typically with the VDRs of a vdr.Registry.
+type Resolver interface {
+	Resolve(did string, opts ...vdrapi.ResolveOption) (*diddoc.DocResolution, error)
+}
+
+// Service is an HTTP handler resolving DIDs with a Resolver.
+type Service struct {
+	resolver Resolver
+	maxAge   time.Duration
+}
+
+// Option configures the resolver service.
+type Option func(opts *Service)
+
+// WithMaxAge option sets how long successful resolutions may be cached by clients, with the max-age directive of
+// the Cache-Control header. By default clients must revalidate them, which they can do with the ETag of responses.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(opts *Service) {
+		opts.maxAge = maxAge
+	}
+}
+
+// New returns a resolver service resolving DIDs with resolver.
+func New(resolver Resolver, opts ...Option) *Service {
+	s := &Service{resolver: resolver}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// resolutionResult is the DID resolution result (https://w3c-ccg.github.io/did-resolution/#did-resolution-result).
+type resolutionResult struct {
+	Context               string                   `json:"@context"`
+	DIDDocument           json.RawMessage          `json:"didDocument"`
+	DIDResolutionMetadata *resolutionMetadata      `json:"didResolutionMetadata"`
+	DIDDocumentMetadata   *diddoc.DocumentMetadata `json:"didDocumentMetadata"`
+}
+
+type resolutionMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ServeHTTP resolves the DID of a GET IdentifiersPath + {did} request. The DID document is returned if the request
+// accepts DIDLDJSONType only, the DID resolution result otherwise.
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Vary", "Accept")
+
+	contentType, ok := negotiate(r.Header.Get("Accept"))
+	if !ok {
+		s.writeError(w, http.StatusNotAcceptable, ErrorRepresentationNotSupported)
+
+		return
+	}
+
+	did, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), IdentifiersPath))
+	if err != nil || !strings.HasPrefix(r.URL.Path, IdentifiersPath) {
+		s.writeError(w, http.StatusBadRequest, ErrorInvalidDID)
+
+		return
+	}
+
+	if _, err = diddoc.Parse(did); err != nil {
+		s.writeError(w, http.StatusBadRequest, ErrorInvalidDID)
+
+		return
+	}
+
+	opts, err := resolveOptions(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, ErrorInvalidOptions)
+
+		return
+	}
+
+	docResolution, err := s.resolver.Resolve(did, opts...)
+
+	switch {
+	case errors.Is(err, vdrapi.ErrNotFound):
+		s.writeError(w, http.StatusNotFound, ErrorNotFound)
+	case errors.Is(err, vdrapi.ErrMethodNotSupported):
+		s.writeError(w, http.StatusNotImplemented, ErrorMethodNotSupported)
+	case err != nil:
+		logger.Warnf("failed to resolve %s: %v", did, err)
+
+		s.writeError(w, http.StatusInternalServerError, ErrorInternal)
+	default:
+		s.writeResolution(w, r, contentType, docResolution)
+	}
+}
+
+func (s *Service) writeResolution(w http.ResponseWriter, r *http.Request, contentType string,
+	docResolution *diddoc.DocResolution) {
+	body, err := representation(contentType, docResolution)
+	if err != nil {
+		logger.Errorf("failed to represent the resolution of %s: %v", docResolution.DIDDocument.ID, err)
+
+		s.writeError(w, http.StatusInternalServerError, ErrorInternal)
+
+		return
+	}
+
+	status := http.StatusOK
+
+	// deactivated DIDs are resolved, but reported as gone (https://w3c-ccg.github.io/did-resolution/#bindings-https)
+	if docResolution.DocumentMetadata != nil && docResolution.DocumentMetadata.Deactivated {
+		status = http.StatusGone
+	}
+
+	etag := entityTag(body)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", s.cacheControl())
+
+	if status == http.StatusOK && r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	w.WriteHeader(status)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if _, err = w.Write(body); err != nil {
+		logger.Errorf("failed to write the resolution of %s: %v", docResolution.DIDDocument.ID, err)
+	}
+}
+
+// writeError writes a DID resolution result reporting a resolution error, whatever the requested representation.
+func (s *Service) writeError(w http.ResponseWriter, status int, resolutionError string) {
+	body, err := json.Marshal(&resolutionResult{
+		Context:               ResolutionContext,
+		DIDDocument:           json.RawMessage("null"),
+		DIDResolutionMetadata: &resolutionMetadata{Error: resolutionError},
+		DIDDocumentMetadata:   &diddoc.DocumentMetadata{},
+	})
+	if err != nil {
+		http.Error(w, resolutionError, status)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", ResolutionResultType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	if _, err = w.Write(body); err != nil {
+		logger.Errorf("failed to write resolution error: %v", err)
+	}
+}
+
+func (s *Service) cacheControl() string {
+	if s.maxAge <= 0 {
+		return "no-cache"
+	}
+
+	return fmt.Sprintf("max-age=%d", int64(s.maxAge/time.Second))
+}
+
+// representation returns the body of the response to a resolution.
+func representation(contentType string, docResolution *diddoc.DocResolution) ([]byte, error) {
+	didDocument, err := docResolution.DIDDocument.JSONBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType == DIDLDJSONType {
+		return didDocument, nil
+	}
+
+	documentMetadata := docResolution.DocumentMetadata
+	if documentMetadata == nil {
+		documentMetadata = &diddoc.DocumentMetadata{}
+	}
+
+	return json.Marshal(&resolutionResult{
+		Context:               ResolutionContext,
+		DIDDocument:           didDocument,
+		DIDResolutionMetadata: &resolutionMetadata{ContentType: DIDLDJSONType},
+		DIDDocumentMetadata:   documentMetadata,
+	})
+}
+
+// negotiate returns the media type of the response to a request with the given Accept header, which is the first
+// supported media type it lists. Any media type but the DID document one selects the DID resolution result.
+func negotiate(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return ResolutionResultType, true
+	}
+
+	for _, accepted := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(accepted)
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case DIDLDJSONType:
+			return DIDLDJSONType, true
+		case ldJSONType:
+			if profile, ok := params["profile"]; !ok || profile == resolutionProfile {
+				return ResolutionResultType, true
+			}
+		case jsonType, "application/*", "*/*":
+			return ResolutionResultType, true
+		}
+	}
+
+	return "", false
+}
+
+func resolveOptions(r *http.Request) ([]vdrapi.ResolveOption, error) {
+	var opts []vdrapi.ResolveOption
+
+	query := r.URL.Query()
+
+	if versionID := query.Get(versionIDParam); versionID != "" {
+		opts = append(opts, vdrapi.WithVersionID(versionID))
+	}
+
+	if versionTime := query.Get(versionTimeParam); versionTime != "" {
+		t, err := time.Parse(time.RFC3339, versionTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", versionTimeParam, err)
+		}
+
+		opts = append(opts, vdrapi.WithVersionTime(t))
+	}
+
+	if strings.Contains(r.Header.Get("Cache-Control"), "no-cache") {
+		opts = append(opts, vdrapi.WithNoCache(true))
+	}
+
+	return opts, nil
+}
+
+// entityTag returns the strong entity tag of a response body.
+func entityTag(body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+}
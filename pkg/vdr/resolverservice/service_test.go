@@ -0,0 +1,233 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resolverservice_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/resolverservice"
+)
+
+const didKey = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+type resolutionResult struct {
+	Context               string                 `json:"@context"`
+	DIDDocument           map[string]interface{} `json:"didDocument"`
+	DIDResolutionMetadata map[string]interface{} `json:"didResolutionMetadata"`
+	DIDDocumentMetadata   map[string]interface{} `json:"didDocumentMetadata"`
+}
+
+func TestService(t *testing.T) {
+	server := httptest.NewServer(resolverservice.New(vdr.New(&mockprovider.Provider{}, vdr.WithVDR(key.New())),
+		resolverservice.WithMaxAge(time.Minute)))
+	defer server.Close()
+
+	t.Run("resolution result", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/json", resolverservice.ResolutionResultType,
+			"text/html, application/ld+json"} {
+			resp, body := get(t, server.URL+resolverservice.IdentifiersPath+didKey, accept, "")
+			require.Equal(t, http.StatusOK, resp.StatusCode, accept)
+			require.Equal(t, resolverservice.ResolutionResultType, resp.Header.Get("Content-Type"))
+			require.Equal(t, "max-age=60", resp.Header.Get("Cache-Control"))
+			require.NotEmpty(t, resp.Header.Get("ETag"))
+
+			result := &resolutionResult{}
+			require.NoError(t, json.Unmarshal(body, result))
+			require.Equal(t, resolverservice.ResolutionContext, result.Context)
+			require.Equal(t, didKey, result.DIDDocument["id"])
+			require.Equal(t, resolverservice.DIDLDJSONType, result.DIDResolutionMetadata["contentType"])
+			require.NotNil(t, result.DIDDocumentMetadata)
+		}
+	})
+
+	t.Run("DID document", func(t *testing.T) {
+		resp, body := get(t, server.URL+resolverservice.IdentifiersPath+didKey,
+			"application/did+ld+json, application/ld+json;q=0.9", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, resolverservice.DIDLDJSONType, resp.Header.Get("Content-Type"))
+
+		doc, err := did.ParseDocument(body)
+		require.NoError(t, err)
+		require.Equal(t, didKey, doc.ID)
+	})
+
+	t.Run("escaped DID", func(t *testing.T) {
+		resp, _ := get(t, server.URL+resolverservice.IdentifiersPath+url.PathEscape(didKey), "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			path   string
+			accept string
+			status int
+			error  string
+		}{
+			{"invalid DID", "did:key", "", http.StatusBadRequest, resolverservice.ErrorInvalidDID},
+			{"method not supported", "did:example:123", "", http.StatusNotImplemented,
+				resolverservice.ErrorMethodNotSupported},
+			{"representation not supported", didKey, "text/html", http.StatusNotAcceptable,
+				resolverservice.ErrorRepresentationNotSupported},
+			{"other JSON-LD profile", didKey, `application/ld+json;profile="https://example.com"`,
+				http.StatusNotAcceptable, resolverservice.ErrorRepresentationNotSupported},
+			{"invalid version time", didKey + "?versionTime=yesterday", "", http.StatusBadRequest,
+				resolverservice.ErrorInvalidOptions},
+			{"internal error", "did:key:invalid", resolverservice.DIDLDJSONType, http.StatusInternalServerError,
+				resolverservice.ErrorInternal},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				resp, body := get(t, server.URL+resolverservice.IdentifiersPath+tc.path, tc.accept, "")
+				require.Equal(t, tc.status, resp.StatusCode)
+				require.Equal(t, resolverservice.ResolutionResultType, resp.Header.Get("Content-Type"))
+				require.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+
+				result := &resolutionResult{}
+				require.NoError(t, json.Unmarshal(body, result))
+				require.Nil(t, result.DIDDocument)
+				require.Equal(t, tc.error, result.DIDResolutionMetadata["error"])
+			})
+		}
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		resp, err := http.Post(server.URL+resolverservice.IdentifiersPath+didKey, "application/json", nil)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
+
+func TestService_Resolver(t *testing.T) {
+	doc, err := did.ParseDocument([]byte(fmt.Sprintf(`{"@context":"https://www.w3.org/ns/did/v1","id":"%s"}`,
+		"did:example:123")))
+	require.NoError(t, err)
+
+	t.Run("not found", func(t *testing.T) {
+		server := httptest.NewServer(resolverservice.New(&mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.ResolveOption) (*did.DocResolution, error) {
+				return nil, fmt.Errorf("read failed: %w", vdrapi.ErrNotFound)
+			},
+		}))
+		defer server.Close()
+
+		resp, body := get(t, server.URL+resolverservice.IdentifiersPath+"did:example:123", "", "")
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		require.Contains(t, string(body), resolverservice.ErrorNotFound)
+	})
+
+	t.Run("deactivated", func(t *testing.T) {
+		server := httptest.NewServer(resolverservice.New(&mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.ResolveOption) (*did.DocResolution, error) {
+				return &did.DocResolution{
+					DIDDocument:      doc,
+					DocumentMetadata: &did.DocumentMetadata{Deactivated: true},
+				}, nil
+			},
+		}))
+		defer server.Close()
+
+		resp, body := get(t, server.URL+resolverservice.IdentifiersPath+"did:example:123", "", "")
+		require.Equal(t, http.StatusGone, resp.StatusCode)
+		require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+		result := &resolutionResult{}
+		require.NoError(t, json.Unmarshal(body, result))
+		require.Equal(t, true, result.DIDDocumentMetadata["deactivated"])
+	})
+
+	t.Run("conditional request", func(t *testing.T) {
+		server := httptest.NewServer(resolverservice.New(&mockvdr.MockVDRegistry{ResolveValue: doc}))
+		defer server.Close()
+
+		u := server.URL + resolverservice.IdentifiersPath + "did:example:123"
+
+		resp, _ := get(t, u, "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, body := get(t, u, "", resp.Header.Get("ETag"))
+		require.Equal(t, http.StatusNotModified, resp.StatusCode)
+		require.Empty(t, body)
+
+		// the DID document representation has its own entity tag
+		resp, _ = get(t, u, resolverservice.DIDLDJSONType, resp.Header.Get("ETag"))
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("resolve options", func(t *testing.T) {
+		server := httptest.NewServer(resolverservice.New(&mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.ResolveOption) (*did.DocResolution, error) {
+				resolveOpts := &vdrapi.ResolveOpts{}
+
+				for _, opt := range opts {
+					opt(resolveOpts)
+				}
+
+				if resolveOpts.VersionID != "2" || resolveOpts.VersionTime != "2021-01-02T03:04:05Z" ||
+					!resolveOpts.NoCache {
+					return nil, errors.New("unexpected options")
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		}))
+		defer server.Close()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+resolverservice.IdentifiersPath+
+			"did:example:123?versionId=2&versionTime=2021-01-02T03:04:05Z", nil)
+		require.NoError(t, err)
+
+		req.Header.Set("Cache-Control", "no-cache")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func get(t *testing.T, u, accept, etag string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	require.NoError(t, err)
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	return resp, body
+}
@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"fmt"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// Create creates a DID with the verification methods and services of didDoc. The update and recovery keys of the DID
+// are created with the key manager of the VDR, the keyManager argument is not used.
+// The long-form DID, which can be resolved before the create operation is anchored, is returned as the equivalent ID
+// of the document metadata.
+func (v *VDR) Create(_ kms.KeyManager, didDoc *diddoc.Doc,
+	_ ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	doc, err := documentFromDID(didDoc)
+	if err != nil {
+		return nil, fmt.Errorf("create: %w", err)
+	}
+
+	updateKeyID, updateKey, err := v.createKey()
+	if err != nil {
+		return nil, fmt.Errorf("create update key: %w", err)
+	}
+
+	recoveryKeyID, recoveryKey, err := v.createKey()
+	if err != nil {
+		return nil, fmt.Errorf("create recovery key: %w", err)
+	}
+
+	req, err := createRequest(doc, updateKey, recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix, err := DIDSuffix(req.SuffixData)
+	if err != nil {
+		return nil, fmt.Errorf("create: %w", err)
+	}
+
+	longFormDID, err := LongFormDID(v.method, req)
+	if err != nil {
+		return nil, fmt.Errorf("create: %w", err)
+	}
+
+	// the key IDs are saved first so that the DID can be updated even if the response of the node is lost
+	err = v.putKeyIDs(suffix, &keyIDs{UpdateKeyID: updateKeyID, RecoveryKeyID: recoveryKeyID})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := v.sendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	docResolution, err := diddoc.ParseDocumentResolution(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse create response: %w", err)
+	}
+
+	if docResolution.DocumentMetadata == nil {
+		docResolution.DocumentMetadata = &diddoc.DocumentMetadata{}
+	}
+
+	docResolution.DocumentMetadata.EquivalentID = longFormDID
+
+	return docResolution, nil
+}
+
+// createRequest returns the create operation of a DID with the initial state doc, committing to the given update and
+// recovery keys.
+func createRequest(doc *Document, updateKey, recoveryKey *jose.JWK) (*Request, error) {
+	recoveryCommitment, err := Commitment(recoveryKey)
+	if err != nil {
+		return nil, fmt.Errorf("create recovery commitment: %w", err)
+	}
+
+	delta, deltaHash, err := newDelta([]Patch{{Action: PatchReplace, Document: doc}}, updateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		Type: OperationCreate,
+		SuffixData: &SuffixData{
+			DeltaHash:          deltaHash,
+			RecoveryCommitment: recoveryCommitment,
+		},
+		Delta: delta,
+	}, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"reflect"
+	"strings"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+const (
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+)
+
+//nolint:gochecknoglobals
+var purposes = map[diddoc.VerificationRelationship]string{
+	diddoc.Authentication:       PurposeAuthentication,
+	diddoc.AssertionMethod:      PurposeAssertionMethod,
+	diddoc.KeyAgreement:         PurposeKeyAgreement,
+	diddoc.CapabilityDelegation: PurposeCapabilityDelegation,
+	diddoc.CapabilityInvocation: PurposeCapabilityInvocation,
+}
+
+// documentFromDID returns the Sidetree document of the verification methods and services of a DID document. The
+// purposes of the keys are the verification relationships they are used in.
+func documentFromDID(didDoc *diddoc.Doc) (*Document, error) {
+	doc := &Document{}
+	index := make(map[string]int)
+
+	for relationship, verifications := range didDoc.VerificationMethods() {
+		for i := range verifications {
+			vm := &verifications[i].VerificationMethod
+			id := fragment(vm.ID)
+
+			if _, ok := index[id]; !ok {
+				jwk, err := verificationMethodJWK(vm)
+				if err != nil {
+					return nil, err
+				}
+
+				index[id] = len(doc.PublicKeys)
+				doc.PublicKeys = append(doc.PublicKeys, PublicKey{ID: id, Type: vm.Type, PublicKeyJwk: jwk})
+			}
+
+			if purpose, ok := purposes[relationship]; ok {
+				key := &doc.PublicKeys[index[id]]
+				key.Purposes = append(key.Purposes, purpose)
+			}
+		}
+	}
+
+	for _, s := range didDoc.Service {
+		doc.Services = append(doc.Services, Service{
+			ID:              fragment(s.ID),
+			Type:            s.Type,
+			ServiceEndpoint: s.ServiceEndpoint,
+		})
+	}
+
+	return doc, nil
+}
+
+func verificationMethodJWK(vm *diddoc.VerificationMethod) (*jose.JWK, error) {
+	if jwk := vm.JSONWebKey(); jwk != nil {
+		return jwk, nil
+	}
+
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return jose.JWKFromPublicKey(ed25519.PublicKey(vm.Value))
+	case x25519KeyAgreementKey2019:
+		return jose.JWEFromX25519Key(vm.Value)
+	default:
+		return nil, fmt.Errorf("verification method %s of type %s has no JWK", vm.ID, vm.Type)
+	}
+}
+
+// patchesTo returns the patches updating current into target: the keys and services that were removed or changed
+// are removed, then the ones that were added or changed are added.
+func patchesTo(current, target *Document) ([]Patch, error) {
+	var patches []Patch
+
+	removedKeys, addedKeys, err := diffKeys(current.PublicKeys, target.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(removedKeys) != 0 {
+		patches = append(patches, Patch{Action: PatchRemovePublicKeys, IDs: removedKeys})
+	}
+
+	if len(addedKeys) != 0 {
+		patches = append(patches, Patch{Action: PatchAddPublicKeys, PublicKeys: addedKeys})
+	}
+
+	removedServices, addedServices := diffServices(current.Services, target.Services)
+
+	if len(removedServices) != 0 {
+		patches = append(patches, Patch{Action: PatchRemoveServices, IDs: removedServices})
+	}
+
+	if len(addedServices) != 0 {
+		patches = append(patches, Patch{Action: PatchAddServices, Services: addedServices})
+	}
+
+	return patches, nil
+}
+
+func diffKeys(current, target []PublicKey) ([]string, []PublicKey, error) {
+	currentKeys := make(map[string]string, len(current))
+
+	for i := range current {
+		hash, err := Hash(&current[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		currentKeys[current[i].ID] = hash
+	}
+
+	var (
+		removed []string
+		added   []PublicKey
+	)
+
+	targetIDs := make(map[string]bool, len(target))
+
+	for i := range target {
+		targetIDs[target[i].ID] = true
+
+		hash, err := Hash(&target[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		currentHash, exists := currentKeys[target[i].ID]
+
+		switch {
+		case !exists:
+			added = append(added, target[i])
+		case currentHash != hash:
+			removed = append(removed, target[i].ID)
+			added = append(added, target[i])
+		}
+	}
+
+	for i := range current {
+		if !targetIDs[current[i].ID] {
+			removed = append(removed, current[i].ID)
+		}
+	}
+
+	return removed, added, nil
+}
+
+func diffServices(current, target []Service) ([]string, []Service) {
+	currentServices := make(map[string]Service, len(current))
+
+	for _, s := range current {
+		currentServices[s.ID] = s
+	}
+
+	var (
+		removed []string
+		added   []Service
+	)
+
+	targetIDs := make(map[string]bool, len(target))
+
+	for _, s := range target {
+		targetIDs[s.ID] = true
+
+		currentService, exists := currentServices[s.ID]
+
+		switch {
+		case !exists:
+			added = append(added, s)
+		case !reflect.DeepEqual(currentService, s):
+			removed = append(removed, s.ID)
+			added = append(added, s)
+		}
+	}
+
+	for _, s := range current {
+		if !targetIDs[s.ID] {
+			removed = append(removed, s.ID)
+		}
+	}
+
+	return removed, added
+}
+
+// fragment returns the fragment of a DID URL, or the DID URL itself if it has none.
+func fragment(didURL string) string {
+	if i := strings.LastIndex(didURL, "#"); i >= 0 {
+		return didURL[i+1:]
+	}
+
+	return didURL
+}
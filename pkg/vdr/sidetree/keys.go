@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// createKey creates an update or recovery key and returns its ID and public JWK.
+func (v *VDR) createKey() (string, *jose.JWK, error) {
+	keyID, pubKey, err := v.keyManager.CreateAndExportPubKeyBytes(v.keyType)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create key: %w", err)
+	}
+
+	jwk, err := pubKeyJWK(pubKey, v.keyType)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyID, jwk, nil
+}
+
+// publicKeyJWK returns the public JWK of an update or recovery key.
+func (v *VDR) publicKeyJWK(keyID string) (*jose.JWK, error) {
+	pubKey, err := v.keyManager.ExportPubKeyBytes(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export public key %s: %w", keyID, err)
+	}
+
+	return pubKeyJWK(pubKey, v.keyType)
+}
+
+// signData returns the compact JWS of the canonicalized data, signed with the given update or recovery key.
+func (v *VDR) signData(keyID string, data *SignedData) (string, error) {
+	kh, err := v.keyManager.Get(keyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get key %s: %w", keyID, err)
+	}
+
+	alg, err := jwsAlgorithm(v.keyType)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := Canonicalize(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize signed data: %w", err)
+	}
+
+	jws, err := jose.NewJWS(nil, nil, payload, &kmsSigner{crypto: v.crypto, kh: kh, alg: alg})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign data: %w", err)
+	}
+
+	return jws.SerializeCompact(false)
+}
+
+// kmsSigner is a jose.Signer signing with a key handle of a KMS.
+type kmsSigner struct {
+	crypto crypto.Crypto
+	kh     interface{}
+	alg    string
+}
+
+func (s *kmsSigner) Sign(data []byte) ([]byte, error) {
+	return s.crypto.Sign(data, s.kh)
+}
+
+func (s *kmsSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: s.alg}
+}
+
+func jwsAlgorithm(keyType kms.KeyType) (string, error) {
+	switch keyType {
+	case kms.ED25519Type:
+		return "EdDSA", nil
+	case kms.ECDSAP256TypeIEEEP1363:
+		return "ES256", nil
+	default:
+		return "", fmt.Errorf("unsupported key type %s", keyType)
+	}
+}
+
+func pubKeyJWK(pubKey []byte, keyType kms.KeyType) (*jose.JWK, error) {
+	switch keyType {
+	case kms.ED25519Type:
+		return jose.JWKFromPublicKey(ed25519.PublicKey(pubKey))
+	case kms.ECDSAP256TypeIEEEP1363:
+		x, y := elliptic.Unmarshal(elliptic.P256(), pubKey)
+		if x == nil {
+			return nil, errors.New("invalid P-256 public key")
+		}
+
+		return jose.JWKFromPublicKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", keyType)
+	}
+}
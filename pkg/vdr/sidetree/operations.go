@@ -0,0 +1,222 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"fmt"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// Update updates a DID to the verification methods and services of didDoc, rotating its update key.
+// With the RecoverOpt option set to true, the DID is recovered instead: its document is replaced and both its update
+// and recovery keys are rotated.
+func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
+	didMethodOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+	for _, opt := range opts {
+		opt(didMethodOpts)
+	}
+
+	suffix, err := v.suffixOf(didDoc.ID)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	doc, err := documentFromDID(didDoc)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	ids, err := v.getKeyIDs(suffix)
+	if err != nil {
+		return err
+	}
+
+	if recovery, ok := didMethodOpts.Values[RecoverOpt].(bool); ok && recovery {
+		return v.recover(suffix, doc, ids)
+	}
+
+	return v.update(suffix, doc, ids)
+}
+
+func (v *VDR) update(suffix string, doc *Document, ids *keyIDs) error {
+	docResolution, err := v.Read(v.shortFormDID(suffix))
+	if err != nil {
+		return fmt.Errorf("update: failed to resolve current document: %w", err)
+	}
+
+	current, err := documentFromDID(docResolution.DIDDocument)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	patches, err := patchesTo(current, doc)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	updateKey, err := v.publicKeyJWK(ids.UpdateKeyID)
+	if err != nil {
+		return err
+	}
+
+	revealValue, err := RevealValue(updateKey)
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	nextUpdateKeyID, nextUpdateKey, err := v.createKey()
+	if err != nil {
+		return fmt.Errorf("create update key: %w", err)
+	}
+
+	delta, deltaHash, err := newDelta(patches, nextUpdateKey)
+	if err != nil {
+		return err
+	}
+
+	signedData, err := v.signData(ids.UpdateKeyID, &SignedData{UpdateKey: updateKey, DeltaHash: deltaHash})
+	if err != nil {
+		return err
+	}
+
+	_, err = v.sendRequest(&Request{
+		Type:        OperationUpdate,
+		DIDSuffix:   suffix,
+		RevealValue: revealValue,
+		Delta:       delta,
+		SignedData:  signedData,
+	})
+	if err != nil {
+		return err
+	}
+
+	return v.putKeyIDs(suffix, &keyIDs{UpdateKeyID: nextUpdateKeyID, RecoveryKeyID: ids.RecoveryKeyID})
+}
+
+func (v *VDR) recover(suffix string, doc *Document, ids *keyIDs) error {
+	recoveryKey, err := v.publicKeyJWK(ids.RecoveryKeyID)
+	if err != nil {
+		return err
+	}
+
+	revealValue, err := RevealValue(recoveryKey)
+	if err != nil {
+		return fmt.Errorf("recover: %w", err)
+	}
+
+	nextUpdateKeyID, nextUpdateKey, err := v.createKey()
+	if err != nil {
+		return fmt.Errorf("create update key: %w", err)
+	}
+
+	nextRecoveryKeyID, nextRecoveryKey, err := v.createKey()
+	if err != nil {
+		return fmt.Errorf("create recovery key: %w", err)
+	}
+
+	recoveryCommitment, err := Commitment(nextRecoveryKey)
+	if err != nil {
+		return fmt.Errorf("create recovery commitment: %w", err)
+	}
+
+	delta, deltaHash, err := newDelta([]Patch{{Action: PatchReplace, Document: doc}}, nextUpdateKey)
+	if err != nil {
+		return err
+	}
+
+	signedData, err := v.signData(ids.RecoveryKeyID, &SignedData{
+		RecoveryKey:        recoveryKey,
+		DeltaHash:          deltaHash,
+		RecoveryCommitment: recoveryCommitment,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = v.sendRequest(&Request{
+		Type:        OperationRecover,
+		DIDSuffix:   suffix,
+		RevealValue: revealValue,
+		Delta:       delta,
+		SignedData:  signedData,
+	})
+	if err != nil {
+		return err
+	}
+
+	return v.putKeyIDs(suffix, &keyIDs{UpdateKeyID: nextUpdateKeyID, RecoveryKeyID: nextRecoveryKeyID})
+}
+
+// Deactivate deactivates a DID, revealing its recovery key. The DID can not be updated or recovered afterwards.
+func (v *VDR) Deactivate(did string, _ ...vdrapi.DIDMethodOption) error {
+	suffix, err := v.suffixOf(did)
+	if err != nil {
+		return fmt.Errorf("deactivate: %w", err)
+	}
+
+	ids, err := v.getKeyIDs(suffix)
+	if err != nil {
+		return err
+	}
+
+	recoveryKey, err := v.publicKeyJWK(ids.RecoveryKeyID)
+	if err != nil {
+		return err
+	}
+
+	revealValue, err := RevealValue(recoveryKey)
+	if err != nil {
+		return fmt.Errorf("deactivate: %w", err)
+	}
+
+	signedData, err := v.signData(ids.RecoveryKeyID, &SignedData{DIDSuffix: suffix, RecoveryKey: recoveryKey})
+	if err != nil {
+		return err
+	}
+
+	_, err = v.sendRequest(&Request{
+		Type:        OperationDeactivate,
+		DIDSuffix:   suffix,
+		RevealValue: revealValue,
+		SignedData:  signedData,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = v.store.Delete(suffix)
+	if err != nil {
+		return fmt.Errorf("failed to delete the keys of DID suffix %s: %w", suffix, err)
+	}
+
+	return nil
+}
+
+// newDelta returns the delta of patches committing to the next update key, and its hash.
+func newDelta(patches []Patch, nextUpdateKey *jose.JWK) (*Delta, string, error) {
+	updateCommitment, err := Commitment(nextUpdateKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("create update commitment: %w", err)
+	}
+
+	delta := &Delta{Patches: patches, UpdateCommitment: updateCommitment}
+
+	deltaHash, err := Hash(delta)
+	if err != nil {
+		return nil, "", fmt.Errorf("hash delta: %w", err)
+	}
+
+	return delta, deltaHash, nil
+}
+
+func (v *VDR) shortFormDID(suffix string) string {
+	return "did:" + v.method + ":" + suffix
+}
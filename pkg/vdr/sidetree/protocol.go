@@ -0,0 +1,255 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/multiformats/go-multihash"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+// Sidetree operation types.
+const (
+	OperationCreate     = "create"
+	OperationUpdate     = "update"
+	OperationRecover    = "recover"
+	OperationDeactivate = "deactivate"
+)
+
+// Sidetree patch actions.
+const (
+	PatchReplace          = "replace"
+	PatchAddPublicKeys    = "add-public-keys"
+	PatchRemovePublicKeys = "remove-public-keys"
+	PatchAddServices      = "add-services"
+	PatchRemoveServices   = "remove-services"
+)
+
+// Public key purposes, which are the verification relationships of the keys in the resolved DID document.
+const (
+	PurposeAuthentication       = "authentication"
+	PurposeAssertionMethod      = "assertionMethod"
+	PurposeKeyAgreement         = "keyAgreement"
+	PurposeCapabilityDelegation = "capabilityDelegation"
+	PurposeCapabilityInvocation = "capabilityInvocation"
+)
+
+// PublicKey is a public key of a Sidetree document.
+type PublicKey struct {
+	ID           string    `json:"id"`
+	Type         string    `json:"type"`
+	Purposes     []string  `json:"purposes,omitempty"`
+	PublicKeyJwk *jose.JWK `json:"publicKeyJwk"`
+}
+
+// Service is a service of a Sidetree document.
+type Service struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// Document is the state of a DID in Sidetree, from which the DID document is resolved.
+type Document struct {
+	PublicKeys []PublicKey `json:"publicKeys,omitempty"`
+	Services   []Service   `json:"services,omitempty"`
+}
+
+// Patch is a modification of a Document.
+type Patch struct {
+	Action     string      `json:"action"`
+	Document   *Document   `json:"document,omitempty"`
+	PublicKeys []PublicKey `json:"publicKeys,omitempty"`
+	Services   []Service   `json:"services,omitempty"`
+	IDs        []string    `json:"ids,omitempty"`
+}
+
+// Delta holds the patches of an operation, and the commitment to the key of the next update.
+type Delta struct {
+	Patches          []Patch `json:"patches"`
+	UpdateCommitment string  `json:"updateCommitment"`
+}
+
+// SuffixData is the data of a create operation from which the DID suffix is computed.
+type SuffixData struct {
+	DeltaHash          string `json:"deltaHash"`
+	RecoveryCommitment string `json:"recoveryCommitment"`
+}
+
+// Request is an operation request sent to a Sidetree node.
+type Request struct {
+	Type        string      `json:"type"`
+	DIDSuffix   string      `json:"didSuffix,omitempty"`
+	RevealValue string      `json:"revealValue,omitempty"`
+	SuffixData  *SuffixData `json:"suffixData,omitempty"`
+	Delta       *Delta      `json:"delta,omitempty"`
+	SignedData  string      `json:"signedData,omitempty"`
+}
+
+// SignedData is the payload of the compact JWS of update, recover and deactivate operations. It is signed with
+// the update key for updates and with the recovery key otherwise.
+type SignedData struct {
+	DIDSuffix          string    `json:"didSuffix,omitempty"`
+	UpdateKey          *jose.JWK `json:"updateKey,omitempty"`
+	RecoveryKey        *jose.JWK `json:"recoveryKey,omitempty"`
+	DeltaHash          string    `json:"deltaHash,omitempty"`
+	RecoveryCommitment string    `json:"recoveryCommitment,omitempty"`
+}
+
+// longFormSuffix is the initial state encoded in long-form DIDs.
+type longFormSuffix struct {
+	SuffixData *SuffixData `json:"suffixData"`
+	Delta      *Delta      `json:"delta"`
+}
+
+// Canonicalize returns the JSON Canonicalization Scheme (RFC 8785) serialization of v.
+func Canonicalize(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&generic)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+
+	// maps are encoded with sorted keys
+	err = encoder.Encode(generic)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Hash returns the base64url encoded SHA-256 multihash of the canonicalized v.
+func Hash(v interface{}) (string, error) {
+	data, err := Canonicalize(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize: %w", err)
+	}
+
+	return encodeMultihash(data)
+}
+
+// RevealValue returns the value revealing the key committed to by Commitment(key).
+func RevealValue(key *jose.JWK) (string, error) {
+	return Hash(key)
+}
+
+// Commitment returns the commitment to key, which is the multihash of the digest of its reveal value.
+func Commitment(key *jose.JWK) (string, error) {
+	revealValue, err := RevealValue(key)
+	if err != nil {
+		return "", err
+	}
+
+	return CommitmentFromRevealValue(revealValue)
+}
+
+// CommitmentFromRevealValue returns the commitment revealed by revealValue.
+func CommitmentFromRevealValue(revealValue string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(revealValue)
+	if err != nil {
+		return "", fmt.Errorf("invalid reveal value: %w", err)
+	}
+
+	decoded, err := multihash.Decode(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid reveal value: %w", err)
+	}
+
+	return encodeMultihash(decoded.Digest)
+}
+
+// DIDSuffix returns the DID suffix of a create operation.
+func DIDSuffix(suffixData *SuffixData) (string, error) {
+	return Hash(suffixData)
+}
+
+// LongFormDID returns the long-form DID of a create operation, which embeds its initial state so that it can be
+// resolved before the operation is anchored.
+func LongFormDID(method string, req *Request) (string, error) {
+	suffix, err := DIDSuffix(req.SuffixData)
+	if err != nil {
+		return "", err
+	}
+
+	initialState, err := Canonicalize(&longFormSuffix{SuffixData: req.SuffixData, Delta: req.Delta})
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize initial state: %w", err)
+	}
+
+	return fmt.Sprintf("did:%s:%s:%s", method, suffix, base64.RawURLEncoding.EncodeToString(initialState)), nil
+}
+
+// ParseLongFormDID returns the short-form DID and the create operation of a long-form DID.
+func ParseLongFormDID(longFormDID string) (string, *Request, error) {
+	i := strings.LastIndex(longFormDID, ":")
+	if i < 0 {
+		return "", nil, errors.New("invalid long-form DID")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(longFormDID[i+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid long-form DID: %w", err)
+	}
+
+	initialState := &longFormSuffix{}
+
+	err = json.Unmarshal(data, initialState)
+	if err != nil || initialState.SuffixData == nil || initialState.Delta == nil {
+		return "", nil, errors.New("invalid long-form DID initial state")
+	}
+
+	shortFormDID := longFormDID[:i]
+
+	suffix, err := DIDSuffix(initialState.SuffixData)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !strings.HasSuffix(shortFormDID, ":"+suffix) {
+		return "", nil, errors.New("long-form DID suffix does not match its initial state")
+	}
+
+	return shortFormDID, &Request{
+		Type:       OperationCreate,
+		SuffixData: initialState.SuffixData,
+		Delta:      initialState.Delta,
+	}, nil
+}
+
+func encodeMultihash(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+
+	mh, err := multihash.Encode(sum[:], multihash.SHA2_256)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode multihash: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mh), nil
+}
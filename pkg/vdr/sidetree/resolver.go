@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// Read resolves a short-form or long-form DID with the Sidetree node.
+func (v *VDR) Read(did string, _ ...vdrapi.ResolveOption) (*diddoc.DocResolution, error) {
+	resp, err := v.client.Get(v.endpointURL + identifiersPath + url.PathEscape(did))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP Get request failed: %w", err)
+	}
+
+	body, err := readResponse(resp)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", vdrapi.ErrNotFound, did)
+		}
+
+		return nil, err
+	}
+
+	return diddoc.ParseDocumentResolution(body)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sidetree implements a VDR for Sidetree based DID methods (https://identity.foundation/sidetree/spec/).
+// Create, update, recover and deactivate operations are signed with update and recovery keys held in a KMS, which are
+// rotated by each operation that reveals them.
+package sidetree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// StoreNamespace is the namespace of the store of the IDs of the update and recovery keys of DIDs.
+	StoreNamespace = "sidetree"
+
+	// DefaultMethod is the DID method accepted by default.
+	DefaultMethod = "sidetree"
+
+	// RecoverOpt is the DIDMethodOption making Update recover the DID: its document is replaced and its recovery key
+	// is rotated.
+	RecoverOpt = "recover"
+
+	operationsPath  = "/operations"
+	identifiersPath = "/identifiers/"
+)
+
+var logger = log.New("aries-framework/vdr/sidetree")
+
+// VDR is a VDR of a Sidetree based DID method, sending operations to and resolving DIDs with a Sidetree node.
+type VDR struct {
+	endpointURL string
+	method      string
+	keyType     kms.KeyType
+	client      *http.Client
+	keyManager  kms.KeyManager
+	crypto      crypto.Crypto
+	store       storage.Store
+}
+
+// Option configures the sidetree vdr.
+type Option func(opts *VDR)
+
+// WithMethod option sets the DID method of the Sidetree node, DefaultMethod by default.
+func WithMethod(method string) Option {
+	return func(opts *VDR) {
+		opts.method = method
+	}
+}
+
+// WithHTTPClient option sets the HTTP client used to reach the Sidetree node.
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *VDR) {
+		opts.client = client
+	}
+}
+
+// WithKeyType option sets the type of the update and recovery keys, kms.ED25519Type by default.
+// kms.ECDSAP256TypeIEEEP1363 is also supported.
+func WithKeyType(keyType kms.KeyType) Option {
+	return func(opts *VDR) {
+		opts.keyType = keyType
+	}
+}
+
+// New returns a VDR using the Sidetree node at endpointURL. The update and recovery keys are created with keyManager
+// and used with cr, and their IDs are saved in the StoreNamespace store of storageProvider.
+func New(endpointURL string, keyManager kms.KeyManager, cr crypto.Crypto, storageProvider storage.Provider,
+	opts ...Option) (*VDR, error) {
+	_, err := url.ParseRequestURI(endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("base URL invalid: %w", err)
+	}
+
+	store, err := storageProvider.OpenStore(StoreNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("open store : %w", err)
+	}
+
+	v := &VDR{
+		endpointURL: strings.TrimSuffix(endpointURL, "/"),
+		method:      DefaultMethod,
+		keyType:     kms.ED25519Type,
+		client:      &http.Client{},
+		keyManager:  keyManager,
+		crypto:      cr,
+		store:       store,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
+}
+
+// Accept did method.
+func (v *VDR) Accept(method string) bool {
+	return method == v.method
+}
+
+// Close frees resources being maintained by vdr.
+func (v *VDR) Close() error {
+	return nil
+}
+
+// keyIDs are the IDs of the current update and recovery keys of a DID.
+type keyIDs struct {
+	UpdateKeyID   string `json:"updateKeyID"`
+	RecoveryKeyID string `json:"recoveryKeyID"`
+}
+
+func (v *VDR) getKeyIDs(suffix string) (*keyIDs, error) {
+	data, err := v.store.Get(suffix)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, fmt.Errorf("the update and recovery keys of DID suffix %s are unknown", suffix)
+		}
+
+		return nil, fmt.Errorf("failed to get the keys of DID suffix %s: %w", suffix, err)
+	}
+
+	ids := &keyIDs{}
+
+	err = json.Unmarshal(data, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the keys of DID suffix %s: %w", suffix, err)
+	}
+
+	return ids, nil
+}
+
+func (v *VDR) putKeyIDs(suffix string, ids *keyIDs) error {
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the keys of DID suffix %s: %w", suffix, err)
+	}
+
+	err = v.store.Put(suffix, data)
+	if err != nil {
+		return fmt.Errorf("failed to save the keys of DID suffix %s: %w", suffix, err)
+	}
+
+	return nil
+}
+
+// sendRequest sends an operation request to the Sidetree node and returns the response body.
+func (v *VDR) sendRequest(req *Request) ([]byte, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s request: %w", req.Type, err)
+	}
+
+	resp, err := v.client.Post(v.endpointURL+operationsPath, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s request: %w", req.Type, err)
+	}
+
+	body, err := readResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", req.Type, err)
+	}
+
+	return body, nil
+}
+
+// suffixOf returns the suffix of a short-form or long-form DID of the method of the VDR.
+func (v *VDR) suffixOf(did string) (string, error) {
+	if shortFormDID, _, err := ParseLongFormDID(did); err == nil {
+		did = shortFormDID
+	}
+
+	prefix := "did:" + v.method + ":"
+
+	if !strings.HasPrefix(did, prefix) {
+		return "", fmt.Errorf("%s is not a did:%s DID", did, v.method)
+	}
+
+	return did[strings.LastIndex(did, ":")+1:], nil
+}
+
+// readResponse reads the body of resp, returning an error if its status is not successful.
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body failed: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("unexpected response from Sidetree node [%d]: %s", resp.StatusCode, body)
+	}
+
+	return body, nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
@@ -0,0 +1,299 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sidetree_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mocksidetree "github.com/hyperledger/aries-framework-go/pkg/mock/vdr/sidetree"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/sidetree"
+)
+
+const (
+	method          = "example"
+	serviceEndpoint = "https://agent.example.com"
+)
+
+func TestVDR(t *testing.T) {
+	for _, keyType := range []kms.KeyType{kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363} {
+		t.Run(string(keyType), func(t *testing.T) {
+			node := mocksidetree.NewNode(method)
+			defer node.Close()
+
+			v := newVDR(t, node.URL, sidetree.WithKeyType(keyType))
+
+			require.True(t, v.Accept(method))
+			require.False(t, v.Accept(sidetree.DefaultMethod))
+
+			key1 := newKey(t)
+
+			created, err := v.Create(nil, newDoc(t, "key1", key1, "hub"))
+			require.NoError(t, err)
+
+			didID := created.DIDDocument.ID
+			require.Contains(t, didID, "did:"+method+":")
+			require.Equal(t, didID, created.DocumentMetadata.CanonicalID)
+			requireKeys(t, created.DIDDocument, didID, key1)
+
+			longForm := created.DocumentMetadata.EquivalentID
+			require.Contains(t, longForm, didID+":")
+
+			t.Run("read short-form and long-form DIDs", func(t *testing.T) {
+				resolved, err := v.Read(didID)
+				require.NoError(t, err)
+				require.True(t, resolved.DocumentMetadata.Method.Published)
+				requireKeys(t, resolved.DIDDocument, didID, key1)
+				require.Len(t, resolved.DIDDocument.Service, 1)
+				require.Equal(t, serviceEndpoint, resolved.DIDDocument.Service[0].ServiceEndpoint)
+
+				resolved, err = v.Read(longForm)
+				require.NoError(t, err)
+				require.Equal(t, didID, resolved.DIDDocument.ID)
+			})
+
+			t.Run("update rotates keys", func(t *testing.T) {
+				key2 := newKey(t)
+
+				err := v.Update(newDoc(t, "key2", key2, "hub2"))
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "update")
+
+				doc := newDoc(t, "key2", key2, "hub2")
+				doc.ID = didID
+
+				before, err := v.Read(didID)
+				require.NoError(t, err)
+
+				require.NoError(t, v.Update(doc))
+
+				resolved, err := v.Read(didID)
+				require.NoError(t, err)
+				requireKeys(t, resolved.DIDDocument, didID, key2)
+				require.Len(t, resolved.DIDDocument.Service, 1)
+				require.Equal(t, didID+"#hub2", resolved.DIDDocument.Service[0].ID)
+				require.NotEqual(t, before.DocumentMetadata.Method.UpdateCommitment,
+					resolved.DocumentMetadata.Method.UpdateCommitment)
+				require.Equal(t, before.DocumentMetadata.Method.RecoveryCommitment,
+					resolved.DocumentMetadata.Method.RecoveryCommitment)
+
+				// the rotated update key is used for the next update
+				doc.Service = nil
+				require.NoError(t, v.Update(doc))
+
+				resolved, err = v.Read(didID)
+				require.NoError(t, err)
+				require.Empty(t, resolved.DIDDocument.Service)
+			})
+
+			t.Run("recover", func(t *testing.T) {
+				key3 := newKey(t)
+
+				doc := newDoc(t, "key3", key3, "hub")
+				doc.ID = didID
+
+				before, err := v.Read(didID)
+				require.NoError(t, err)
+
+				require.NoError(t, v.Update(doc, vdrapi.WithOption(sidetree.RecoverOpt, true)))
+
+				resolved, err := v.Read(didID)
+				require.NoError(t, err)
+				requireKeys(t, resolved.DIDDocument, didID, key3)
+				require.NotEqual(t, before.DocumentMetadata.Method.RecoveryCommitment,
+					resolved.DocumentMetadata.Method.RecoveryCommitment)
+
+				// the keys rotated by the recovery can update the DID
+				doc.Service = nil
+				require.NoError(t, v.Update(doc))
+			})
+
+			t.Run("deactivate", func(t *testing.T) {
+				require.NoError(t, v.Deactivate(didID))
+
+				resolved, err := v.Read(didID)
+				require.NoError(t, err)
+				require.True(t, resolved.DocumentMetadata.Deactivated)
+				require.Empty(t, resolved.DIDDocument.VerificationMethod)
+
+				err = v.Deactivate(didID)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "keys of DID suffix")
+			})
+		})
+	}
+}
+
+func TestVDR_Read(t *testing.T) {
+	node := mocksidetree.NewNode(method)
+	defer node.Close()
+
+	v := newVDR(t, node.URL)
+
+	t.Run("unpublished long-form DID", func(t *testing.T) {
+		other := mocksidetree.NewNode(method)
+		defer other.Close()
+
+		key1 := newKey(t)
+
+		created, err := newVDR(t, other.URL).Create(nil, newDoc(t, "key1", key1, "hub"))
+		require.NoError(t, err)
+
+		longForm := created.DocumentMetadata.EquivalentID
+
+		resolved, err := v.Read(longForm)
+		require.NoError(t, err)
+		require.False(t, resolved.DocumentMetadata.Method.Published)
+		require.Equal(t, longForm, resolved.DIDDocument.ID)
+		requireKeys(t, resolved.DIDDocument, longForm, key1)
+
+		_, err = v.Read(created.DIDDocument.ID)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("invalid long-form DID", func(t *testing.T) {
+		_, err := v.Read("did:" + method + ":abc:def")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected response from Sidetree node [400]")
+	})
+
+	t.Run("node unreachable", func(t *testing.T) {
+		_, err := newVDR(t, "http://localhost:1").Read("did:" + method + ":abc")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP Get request failed")
+	})
+}
+
+func TestVDR_Errors(t *testing.T) {
+	node := mocksidetree.NewNode(method)
+	defer node.Close()
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		_, err := sidetree.New("invalid", nil, nil, mem.NewProvider())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "base URL invalid")
+	})
+
+	t.Run("unknown keys", func(t *testing.T) {
+		created, err := newVDR(t, node.URL).Create(nil, newDoc(t, "key1", newKey(t), "hub"))
+		require.NoError(t, err)
+
+		other := newVDR(t, node.URL)
+
+		doc := newDoc(t, "key1", newKey(t), "hub")
+		doc.ID = created.DIDDocument.ID
+
+		err = other.Update(doc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "update and recovery keys of DID suffix")
+
+		err = other.Deactivate(created.DIDDocument.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "update and recovery keys of DID suffix")
+	})
+
+	t.Run("DID of another method", func(t *testing.T) {
+		err := newVDR(t, node.URL).Deactivate("did:other:abc")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not a did:example DID")
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		_, err := newVDR(t, node.URL, sidetree.WithKeyType(kms.BLS12381G2Type)).
+			Create(nil, newDoc(t, "key1", newKey(t), "hub"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported key type")
+	})
+
+	t.Run("verification method without JWK", func(t *testing.T) {
+		doc := &did.Doc{
+			Authentication: []did.Verification{*did.NewReferencedVerification(
+				did.NewVerificationMethodFromBytes("#key1", "Bls12381G2Key2020", "", []byte("key")),
+				did.Authentication)},
+		}
+
+		_, err := newVDR(t, node.URL).Create(nil, doc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "has no JWK")
+	})
+
+	t.Run("operation rejected by node", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "rejected", http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		_, err := newVDR(t, server.URL).Create(nil, newDoc(t, "key1", newKey(t), "hub"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create request failed: unexpected response from Sidetree node [500]")
+	})
+}
+
+func newVDR(t *testing.T, endpointURL string, opts ...sidetree.Option) *sidetree.VDR {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	v, err := sidetree.New(endpointURL, km, cr, mem.NewProvider(), append(opts, sidetree.WithMethod(method))...)
+	require.NoError(t, err)
+
+	return v
+}
+
+func newKey(t *testing.T) ed25519.PublicKey {
+	t.Helper()
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return pubKey
+}
+
+func newDoc(t *testing.T, keyID string, pubKey ed25519.PublicKey, serviceID string) *did.Doc {
+	t.Helper()
+
+	vm := did.NewVerificationMethodFromBytes("#"+keyID, "Ed25519VerificationKey2018", "", pubKey)
+
+	return &did.Doc{
+		Authentication:  []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+		AssertionMethod: []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+		Service: []did.Service{{
+			ID:              "#" + serviceID,
+			Type:            "DIDCommMessaging",
+			ServiceEndpoint: serviceEndpoint,
+		}},
+	}
+}
+
+func requireKeys(t *testing.T, doc *did.Doc, didID string, pubKey ed25519.PublicKey) {
+	t.Helper()
+
+	require.Equal(t, didID, doc.ID)
+	require.Len(t, doc.Authentication, 1)
+	require.Len(t, doc.AssertionMethod, 1)
+	require.Equal(t, []byte(pubKey), doc.Authentication[0].VerificationMethod.Value)
+	require.Equal(t, doc.Authentication[0].VerificationMethod.ID, doc.AssertionMethod[0].VerificationMethod.ID)
+}
@@ -7,15 +7,214 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
-// Create creates a did:web diddoc (unsupported at the moment).
+const (
+	// DomainOpt is the option of Create with the domain of the DID, which can include a port (e.g. localhost:8080).
+	// It is not needed if the document to create has a did:web ID.
+	DomainOpt = "domain"
+	// PathOpt is the option of Create with the optional path of the DID (e.g. user/alice), in which case the
+	// document must be published at https://domain/path/did.json instead of https://domain/.well-known/did.json.
+	PathOpt = "path"
+	// KeyTypeOpt is the option of Create with the kms.KeyType of the key created if the document to create has no
+	// verification method (kms.ED25519Type by default).
+	KeyTypeOpt = "keyType"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	bls12381G2Key2020          = "Bls12381G2Key2020"
+	jsonWebKey2020             = "JsonWebKey2020"
+
+	documentDirPerm  = 0755
+	documentFilePerm = 0644
+)
+
+// nolint:gochecknoglobals
+var ecdsaCurves = map[kms.KeyType]elliptic.Curve{
+	kms.ECDSAP256TypeIEEEP1363: elliptic.P256(),
+	kms.ECDSAP384TypeIEEEP1363: elliptic.P384(),
+	kms.ECDSAP521TypeIEEEP1363: elliptic.P521(),
+}
+
+// Create builds a did:web document from the given document, with the did:web ID of the domain and path options (see
+// DomainOpt and PathOpt). If the document has no verification method, a key is created with the KMS and used for
+// authentication and assertions. The document is hosted if the VDR has a host (see WithHost), it must be published at
+// its DocumentPath on the domain of the DID otherwise (see WriteDocument).
 func (v *VDR) Create(keyManager kms.KeyManager, didDoc *did.Doc,
 	opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
-	return nil, fmt.Errorf("error building did:web did doc --> build not supported in http binding vdr")
+	docOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+	for _, opt := range opts {
+		opt(docOpts)
+	}
+
+	if didDoc == nil {
+		didDoc = &did.Doc{}
+	}
+
+	id, err := createDID(didDoc, docOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error building did:web did doc --> %w", err)
+	}
+
+	doc, err := buildDoc(keyManager, id, didDoc, docOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error building did:web did doc --> %w", err)
+	}
+
+	if v.host != nil {
+		if _, err := v.host.Get(id); err == nil {
+			return nil, fmt.Errorf("error building did:web did doc --> %s already exists", id)
+		}
+
+		if err := v.host.Put(doc); err != nil {
+			return nil, fmt.Errorf("error hosting did:web did doc --> %w", err)
+		}
+	}
+
+	return &did.DocResolution{DIDDocument: doc}, nil
+}
+
+// WriteDocument writes a did:web document to its DocumentPath under the given directory (e.g. the root of the
+// website of the domain of the DID), and returns the path of the file.
+func WriteDocument(dir string, doc *did.Doc) (string, error) {
+	docPath, err := DocumentPath(doc.ID)
+	if err != nil {
+		return "", err
+	}
+
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return "", fmt.Errorf("marshal did doc: %w", err)
+	}
+
+	file := filepath.Join(dir, filepath.FromSlash(docPath))
+
+	if err := os.MkdirAll(filepath.Dir(file), documentDirPerm); err != nil {
+		return "", fmt.Errorf("create directory of did doc: %w", err)
+	}
+
+	// the document is public, it is published as is
+	if err := ioutil.WriteFile(file, docBytes, documentFilePerm); err != nil { //nolint:gosec
+		return "", fmt.Errorf("write did doc: %w", err)
+	}
+
+	return file, nil
+}
+
+func createDID(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (string, error) {
+	if didDoc.ID != "" {
+		if _, err := DocumentPath(didDoc.ID); err != nil {
+			return "", err
+		}
+
+		return didDoc.ID, nil
+	}
+
+	domain, ok := docOpts.Values[DomainOpt].(string)
+	if !ok || domain == "" {
+		return "", fmt.Errorf("missing %s option", DomainOpt)
+	}
+
+	var path []string
+
+	if p, ok := docOpts.Values[PathOpt].(string); ok && strings.Trim(p, "/") != "" {
+		path = strings.Split(strings.Trim(p, "/"), "/")
+	}
+
+	return DID(domain, path...), nil
+}
+
+func buildDoc(keyManager kms.KeyManager, id string, didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.Doc, error) {
+	doc := *didDoc
+	doc.ID = id
+
+	if len(doc.Context) == 0 {
+		doc.Context = []string{did.Context}
+	}
+
+	doc.VerificationMethod = append([]did.VerificationMethod(nil), didDoc.VerificationMethod...)
+
+	for i := range doc.VerificationMethod {
+		if doc.VerificationMethod[i].Controller == "" {
+			doc.VerificationMethod[i].Controller = id
+		}
+	}
+
+	if len(doc.VerificationMethod) == 0 && len(doc.VerificationMethods()) == 0 {
+		keyType := kms.ED25519Type
+
+		if kt, ok := docOpts.Values[KeyTypeOpt].(kms.KeyType); ok {
+			keyType = kt
+		}
+
+		vm, err := createVerificationMethod(keyManager, id, keyType)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.VerificationMethod = []did.VerificationMethod{*vm}
+		doc.Authentication = append(doc.Authentication, *did.NewReferencedVerification(vm, did.Authentication))
+		doc.AssertionMethod = append(doc.AssertionMethod, *did.NewReferencedVerification(vm, did.AssertionMethod))
+	}
+
+	if doc.Created == nil {
+		now := time.Now()
+		doc.Created = &now
+	}
+
+	return &doc, nil
+}
+
+func createVerificationMethod(keyManager kms.KeyManager, id string, keyType kms.KeyType) (*did.VerificationMethod,
+	error) {
+	if _, ok := ecdsaCurves[keyType]; !ok && keyType != kms.ED25519Type && keyType != kms.BLS12381G2Type {
+		return nil, fmt.Errorf("key type %s not supported", keyType)
+	}
+
+	if keyManager == nil {
+		return nil, fmt.Errorf("a key manager is required to create the key of the document")
+	}
+
+	kid, pubKey, err := keyManager.CreateAndExportPubKeyBytes(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("create key: %w", err)
+	}
+
+	vmID := id + "#" + kid
+
+	switch keyType { //nolint:exhaustive
+	case kms.ED25519Type:
+		return did.NewVerificationMethodFromBytes(vmID, ed25519VerificationKey2018, id, pubKey), nil
+	case kms.BLS12381G2Type:
+		return did.NewVerificationMethodFromBytes(vmID, bls12381G2Key2020, id, pubKey), nil
+	default:
+		curve := ecdsaCurves[keyType]
+
+		x, y := elliptic.Unmarshal(curve, pubKey)
+		if x == nil {
+			return nil, fmt.Errorf("invalid %s public key", keyType)
+		}
+
+		jwk, err := jose.JWKFromPublicKey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+		if err != nil {
+			return nil, fmt.Errorf("create JWK: %w", err)
+		}
+
+		jwk.KeyID = kid
+
+		return did.NewVerificationMethodFromJWK(vmID, jsonWebKey2020, id, jwk)
+	}
 }
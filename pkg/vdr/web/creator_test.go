@@ -7,17 +7,157 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 )
 
 func TestCreateDID(t *testing.T) {
+	km := newKMS(t)
+
+	t.Run("test create did with KMS key", func(t *testing.T) {
+		v := New()
+		docResolution, err := v.Create(km, &did.Doc{}, vdrapi.WithOption(DomainOpt, "localhost:8080"),
+			vdrapi.WithOption(PathOpt, "/user/alice/"))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "did:web:localhost%3A8080:user:alice", doc.ID)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, ed25519VerificationKey2018, doc.VerificationMethod[0].Type)
+		require.Equal(t, doc.ID, doc.VerificationMethod[0].Controller)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Equal(t, doc.VerificationMethod[0].ID, doc.AssertionMethod[0].VerificationMethod.ID)
+		require.NotNil(t, doc.Created)
+	})
+
+	t.Run("test create did with key types", func(t *testing.T) {
+		for keyType, vmType := range map[kms.KeyType]string{
+			kms.ECDSAP256TypeIEEEP1363: jsonWebKey2020,
+			kms.ECDSAP384TypeIEEEP1363: jsonWebKey2020,
+			kms.BLS12381G2Type:         bls12381G2Key2020,
+		} {
+			docResolution, err := New().Create(km, nil, vdrapi.WithOption(DomainOpt, "example.com"),
+				vdrapi.WithOption(KeyTypeOpt, keyType))
+			require.NoError(t, err, keyType)
+
+			vm := docResolution.DIDDocument.VerificationMethod[0]
+			require.Equal(t, vmType, vm.Type)
+
+			if vmType == jsonWebKey2020 {
+				require.NotNil(t, vm.JSONWebKey())
+			}
+		}
+
+		_, err := New().Create(km, nil, vdrapi.WithOption(DomainOpt, "example.com"),
+			vdrapi.WithOption(KeyTypeOpt, kms.AES128GCMType))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key type AES128GCM not supported")
+	})
+
+	t.Run("test create did with document keys", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, "", []byte("key"))
+
+		docResolution, err := New().Create(nil, &did.Doc{
+			ID:                 "did:web:example.com",
+			VerificationMethod: []did.VerificationMethod{*vm},
+		})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "did:web:example.com", doc.ID)
+		require.Equal(t, "#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, "did:web:example.com", doc.VerificationMethod[0].Controller)
+		require.Empty(t, vm.Controller)
+	})
+
+	t.Run("test create hosted did", func(t *testing.T) {
+		host, err := NewHost(mem.NewProvider())
+		require.NoError(t, err)
+
+		v := New(WithHost(host))
+
+		docResolution, err := v.Create(km, nil, vdrapi.WithOption(DomainOpt, "example.com"))
+		require.NoError(t, err)
+
+		doc, err := host.Get("did:web:example.com")
+		require.NoError(t, err)
+		require.Equal(t, docResolution.DIDDocument.VerificationMethod[0].ID, doc.VerificationMethod[0].ID)
+
+		_, err = v.Create(km, nil, vdrapi.WithOption(DomainOpt, "example.com"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did:web:example.com already exists")
+	})
+
 	t.Run("test create did failure", func(t *testing.T) {
 		v := New()
-		d, err := v.Create(nil, nil)
-		require.Nil(t, d)
+
+		_, err := v.Create(km, nil)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "build not supported in http binding vdr")
+		require.Contains(t, err.Error(), "missing domain option")
+
+		_, err = v.Create(km, &did.Doc{ID: "did:example:123"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not a did:web did")
+
+		_, err = v.Create(nil, nil, vdrapi.WithOption(DomainOpt, "example.com"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "a key manager is required")
+
+		_, err = v.Create(&mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("kms error")}, nil,
+			vdrapi.WithOption(DomainOpt, "example.com"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kms error")
 	})
 }
+
+func TestWriteDocument(t *testing.T) {
+	dir := t.TempDir()
+
+	for id, path := range map[string]string{
+		"did:web:example.com":            ".well-known/did.json",
+		"did:web:example.com:user:alice": "user/alice/did.json",
+	} {
+		file, err := WriteDocument(dir, &did.Doc{Context: []string{did.Context}, ID: id})
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, filepath.FromSlash(path)), file)
+
+		docBytes, err := ioutil.ReadFile(file) //nolint:gosec
+		require.NoError(t, err)
+
+		doc, err := did.ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, id, doc.ID)
+	}
+
+	_, err := WriteDocument(dir, &did.Doc{ID: "did:example:123"})
+	require.Error(t, err)
+
+	for _, id := range []string{"did:web:example.com:..:..:etc", "did:web:example.com:user:.", "did:web:example.com::a"} {
+		_, err = WriteDocument(dir, &did.Doc{ID: id})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid path segment")
+	}
+}
+
+func newKMS(t *testing.T) kms.KeyManager {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(mem.NewProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	return km
+}
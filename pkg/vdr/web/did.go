@@ -15,10 +15,47 @@ import (
 )
 
 const (
-	defaultPath  = "/.well-known/doc.json"
-	documentPath = "/doc.json"
+	defaultPath  = "/.well-known/did.json"
+	documentPath = "/did.json"
+
+	// legacy paths of earlier versions of the did:web specification.
+	legacyDefaultPath  = "/.well-known/doc.json"
+	legacyDocumentPath = "/doc.json"
 )
 
+// DID returns the did:web DID of a domain, which can include a port (e.g. localhost:8080), and of an optional path
+// (e.g. "user", "alice" for the document hosted at https://domain/user/alice/did.json).
+func DID(domain string, path ...string) string {
+	return "did:" + namespace + ":" + strings.Join(append([]string{url.QueryEscape(domain)}, path...), ":")
+}
+
+// DocumentPath returns the path of the document of a did:web DID on its domain (/.well-known/did.json or
+// /path/did.json), where it must be published.
+func DocumentPath(id string) (string, error) {
+	parsedDID, err := did.Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid did, does not conform to generic did standard --> %w", err)
+	}
+
+	if parsedDID.Method != namespace {
+		return "", fmt.Errorf("not a did:web did: %s", id)
+	}
+
+	pathComponents := strings.Split(parsedDID.MethodSpecificID, ":")
+	if len(pathComponents) == 1 {
+		return defaultPath, nil
+	}
+
+	// the path is used to write the document under a directory, it must not escape it.
+	for _, component := range pathComponents[1:] {
+		if component == "" || component == "." || component == ".." || strings.ContainsAny(component, `/\`) {
+			return "", fmt.Errorf("invalid path segment %q in did:web did: %s", component, id)
+		}
+	}
+
+	return "/" + strings.Join(pathComponents[1:], "/") + documentPath, nil
+}
+
 // parseDIDWeb consumes a did:web identifier and returns the URL location of the did Doc.
 func parseDIDWeb(id string) (string, string, error) {
 	var address, host string
@@ -46,3 +83,13 @@ func parseDIDWeb(id string) (string, string, error) {
 
 	return address, host, nil
 }
+
+// legacyAddress returns the URL location of the did Doc of an address according to earlier versions of the did:web
+// specification.
+func legacyAddress(address string) string {
+	if strings.HasSuffix(address, defaultPath) {
+		return strings.TrimSuffix(address, defaultPath) + legacyDefaultPath
+	}
+
+	return strings.TrimSuffix(address, documentPath) + legacyDocumentPath
+}
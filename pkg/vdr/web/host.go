@@ -0,0 +1,161 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// HostStoreName is the name of the store of the documents and DID configurations of a Host.
+	HostStoreName = "didweb"
+
+	// DIDConfigurationPath is the path of the DID configuration of a domain
+	// (https://identity.foundation/.well-known/resources/did-configuration/).
+	DIDConfigurationPath = "/.well-known/did-configuration.json"
+
+	didLDJSONType          = "application/did+ld+json"
+	jsonType               = "application/json"
+	configurationKeyPrefix = "didconfiguration_"
+)
+
+// Host hosts did:web documents and the DID configurations of their domains. It is an http.Handler serving them at
+// their did:web paths, for the domain of the Host header of the requests: it can serve several domains, and must be
+// reachable at the domains of the hosted DIDs over HTTPS (e.g. behind a TLS terminating proxy).
+type Host struct {
+	store storage.Store
+}
+
+// NewHost returns a Host storing its documents in the HostStoreName store of the storage provider.
+func NewHost(provider storage.Provider) (*Host, error) {
+	store, err := provider.OpenStore(HostStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open %s store: %w", HostStoreName, err)
+	}
+
+	return &Host{store: store}, nil
+}
+
+// Put hosts a did:web document, replacing the current document of the DID if any.
+func (h *Host) Put(doc *did.Doc) error {
+	if _, err := DocumentPath(doc.ID); err != nil {
+		return err
+	}
+
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("marshal did doc: %w", err)
+	}
+
+	if err := h.store.Put(doc.ID, docBytes); err != nil {
+		return fmt.Errorf("store did doc: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the document of a hosted did:web DID, or an error wrapping vdrapi.ErrNotFound.
+func (h *Host) Get(id string) (*did.Doc, error) {
+	docBytes, err := h.store.Get(id)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("get did doc %s: %w", id, vdrapi.ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get did doc %s: %w", id, err)
+	}
+
+	return did.ParseDocument(docBytes)
+}
+
+// Delete stops hosting the document of a did:web DID, which deactivates the DID.
+func (h *Host) Delete(id string) error {
+	if err := h.store.Delete(id); err != nil {
+		return fmt.Errorf("delete did doc %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// PutConfiguration hosts the DID configuration of a domain (see didconfig.Configuration), which can include a port
+// (e.g. localhost:8080).
+func (h *Host) PutConfiguration(domain string, configuration []byte) error {
+	if err := h.store.Put(configurationKeyPrefix+domain, configuration); err != nil {
+		return fmt.Errorf("store did configuration: %w", err)
+	}
+
+	return nil
+}
+
+// ServeHTTP serves the hosted documents at /.well-known/did.json and /path/did.json, and the DID configurations at
+// /.well-known/did-configuration.json.
+func (h *Host) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodHead)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	key, contentType, ok := hostedKey(r)
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	data, err := h.store.Get(key)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	if err != nil {
+		logger.Errorf("failed to get hosted document %s: %s", key, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+
+	if _, err := w.Write(data); err != nil {
+		logger.Errorf("failed to write hosted document %s: %s", key, err)
+	}
+}
+
+// hostedKey returns the store key and the content type of the document requested at the domain of the Host header.
+func hostedKey(r *http.Request) (string, string, bool) {
+	domain := r.Host
+
+	switch {
+	case r.URL.Path == DIDConfigurationPath:
+		return configurationKeyPrefix + domain, jsonType, true
+	case r.URL.Path == defaultPath:
+		return DID(domain), didLDJSONType, true
+	case strings.HasSuffix(r.URL.Path, documentPath):
+		path := strings.Split(strings.Trim(strings.TrimSuffix(r.URL.Path, documentPath), "/"), "/")
+
+		for _, segment := range path {
+			if segment == "" || segment == ".well-known" {
+				return "", "", false
+			}
+		}
+
+		return DID(domain, path...), didLDJSONType, true
+	default:
+		return "", "", false
+	}
+}
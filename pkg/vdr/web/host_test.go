@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package web
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	urlapi "net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestHost(t *testing.T) {
+	host, err := NewHost(mem.NewProvider())
+	require.NoError(t, err)
+
+	s := httptest.NewTLSServer(host)
+	defer s.Close()
+
+	domain := strings.TrimPrefix(s.URL, "https://")
+	v := New(WithHost(host))
+
+	t.Run("test resolve hosted dids", func(t *testing.T) {
+		for _, path := range []string{"", "user/alice"} {
+			docResolution, err := v.Create(newKMS(t), nil, vdrapi.WithOption(DomainOpt, domain),
+				vdrapi.WithOption(PathOpt, path))
+			require.NoError(t, err)
+
+			resolved, err := v.Read(docResolution.DIDDocument.ID, vdrapi.WithHTTPClient(s.Client()))
+			require.NoError(t, err)
+			require.Equal(t, docResolution.DIDDocument.VerificationMethod[0].Value,
+				resolved.DIDDocument.VerificationMethod[0].Value)
+		}
+
+		_, err := v.Read(DID(domain, "user", "bob"), vdrapi.WithHTTPClient(s.Client()))
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+	})
+
+	t.Run("test DID configuration", func(t *testing.T) {
+		require.NoError(t, host.PutConfiguration(domain, []byte(`{"linked_dids":[]}`)))
+
+		resp, err := s.Client().Get(s.URL + DIDConfigurationPath)
+		require.NoError(t, err)
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.Equal(t, `{"linked_dids":[]}`, string(body))
+	})
+
+	t.Run("test not found", func(t *testing.T) {
+		for _, path := range []string{"/", "/did.json", "/.well-known/user/did.json", "/user//did.json", "/doc.json"} {
+			resp, err := s.Client().Get(s.URL + path)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+		}
+	})
+
+	t.Run("test method not allowed", func(t *testing.T) {
+		resp, err := s.Client().Post(s.URL+defaultPath, "application/json", nil)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("test store errors", func(t *testing.T) {
+		_, err := NewHost(&mockstorage.MockStoreProvider{FailNamespace: HostStoreName})
+		require.Error(t, err)
+
+		h, err := NewHost(&mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+			Store: map[string]mockstorage.DBEntry{}, ErrGet: errors.New("get error"),
+		}})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "https://"+urlapi.PathEscape(domain)+defaultPath, nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -35,31 +36,54 @@ func (v *VDR) Read(didID string, opts ...vdrapi.ResolveOption) (*did.DocResoluti
 		return nil, fmt.Errorf("error resolving did:web did --> could not parse did:web did --> %w", err)
 	}
 
-	resp, err := docOpts.HTTPClient.Get(address)
+	body, err := fetch(docOpts.HTTPClient, address, host)
+	if errors.Is(err, vdrapi.ErrNotFound) {
+		// documents may still be published at the paths of earlier versions of the specification
+		body, err = fetch(docOpts.HTTPClient, legacyAddress(address), host)
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("error resolving did:web did --> http request unsuccessful --> %w", err)
+		return nil, fmt.Errorf("error resolving did:web did --> %w", err)
 	}
 
-	for _, i := range resp.TLS.PeerCertificates {
-		err = (*i).VerifyHostname(host)
-		if err != nil {
-			return nil, fmt.Errorf("error resolving did:web did --> identifier does not match TLS host --> %w", err)
-		}
+	doc, err := did.ParseDocument(body)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:web did --> error parsing did doc --> %w", err)
 	}
 
-	defer closeResponseBody(resp.Body)
+	return &did.DocResolution{DIDDocument: doc}, nil
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+func fetch(client *http.Client, address, host string) ([]byte, error) {
+	resp, err := client.Get(address)
 	if err != nil {
-		return nil, fmt.Errorf("error resolving did:web did --> error reading http response body: %s --> %w", body, err)
+		return nil, fmt.Errorf("http request unsuccessful --> %w", err)
 	}
 
-	doc, err := did.ParseDocument(body)
+	defer closeResponseBody(resp.Body)
+
+	if resp.TLS != nil {
+		for _, i := range resp.TLS.PeerCertificates {
+			err = (*i).VerifyHostname(host)
+			if err != nil {
+				return nil, fmt.Errorf("identifier does not match TLS host --> %w", err)
+			}
+		}
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error resolving did:web did --> error parsing did doc --> %w", err)
+		return nil, fmt.Errorf("error reading http response body: %s --> %w", body, err)
 	}
 
-	return &did.DocResolution{DIDDocument: doc}, nil
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("http request unsuccessful --> %w", vdrapi.ErrNotFound)
+	default:
+		return nil, fmt.Errorf("http request unsuccessful --> status code %d: %s", resp.StatusCode, body)
+	}
 }
 
 func closeResponseBody(respBody io.Closer) {
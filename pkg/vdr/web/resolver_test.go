@@ -53,11 +53,11 @@ func TestParseDID(t *testing.T) {
 		require.Equal(t, validURL, host)
 		address, host, err = parseDIDWeb(validDIDWithHost)
 		require.NoError(t, err)
-		require.Equal(t, "https://localhost:8080/.well-known/doc.json", address)
+		require.Equal(t, "https://localhost:8080/.well-known/did.json", address)
 		require.Equal(t, "localhost", host)
 		address, host, err = parseDIDWeb(validDIDWithHostAndPath)
 		require.NoError(t, err)
-		require.Equal(t, "https://localhost:8080/user/example/doc.json", address)
+		require.Equal(t, "https://localhost:8080/user/example/did.json", address)
 		require.Equal(t, "localhost", host)
 	})
 
@@ -115,6 +115,45 @@ func TestResolveDID(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, expectedDoc, docResolution.DIDDocument)
 	})
+	t.Run("test resolve did at legacy path", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != legacyDefaultPath {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			_, err := w.Write([]byte(validDoc))
+			require.NoError(t, err)
+		}))
+		defer s.Close()
+		did := fmt.Sprintf("did:web:%s", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+		v := New()
+		docResolution, err := v.Read(did, vdrapi.WithHTTPClient(s.Client()))
+		require.NoError(t, err)
+		require.Equal(t, "did:web:www.example.org", docResolution.DIDDocument.ID)
+	})
+	t.Run("test resolve did not found", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.NotFoundHandler())
+		defer s.Close()
+		did := fmt.Sprintf("did:web:%s:user:example", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+		v := New()
+		doc, err := v.Read(did, vdrapi.WithHTTPClient(s.Client()))
+		require.Nil(t, doc)
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+	})
+	t.Run("test resolve did with server error", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}))
+		defer s.Close()
+		did := fmt.Sprintf("did:web:%s", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+		v := New()
+		doc, err := v.Read(did, vdrapi.WithHTTPClient(s.Client()))
+		require.Nil(t, doc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status code 500")
+	})
 	t.Run("test resolve did with path success", func(t *testing.T) {
 		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			_, err := w.Write([]byte(validDoc))
@@ -18,11 +18,29 @@ const (
 )
 
 // VDR implements the VDR interface.
-type VDR struct{}
+type VDR struct {
+	host *Host
+}
+
+// Option configures the did:web vdr.
+type Option func(opts *VDR)
 
 // New creates a new VDR struct.
-func New() *VDR {
-	return &VDR{}
+func New(opts ...Option) *VDR {
+	v := &VDR{}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// WithHost hosts the documents created and updated by the VDR with the given host.
+func WithHost(host *Host) Option {
+	return func(opts *VDR) {
+		opts.host = host
+	}
 }
 
 // Accept method of the VDR interface.
@@ -30,14 +48,32 @@ func (v *VDR) Accept(method string) bool {
 	return method == namespace
 }
 
-// Update did doc.
+// Update did doc. The document is replaced in the host of the VDR (see WithHost); did:web documents are updated by
+// publishing them again otherwise.
 func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	if v.host == nil {
+		return fmt.Errorf("not supported without a host")
+	}
+
+	if _, err := v.host.Get(didDoc.ID); err != nil {
+		return fmt.Errorf("update did:web did: %w", err)
+	}
+
+	return v.host.Put(didDoc)
 }
 
-// Deactivate did doc.
+// Deactivate did doc. The document is removed from the host of the VDR (see WithHost); did:web documents are
+// deactivated by removing them from their domain otherwise.
 func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	if v.host == nil {
+		return fmt.Errorf("not supported without a host")
+	}
+
+	if _, err := v.host.Get(did); err != nil {
+		return fmt.Errorf("deactivate did:web did: %w", err)
+	}
+
+	return v.host.Delete(did)
 }
 
 // Close method of the VDR interface.
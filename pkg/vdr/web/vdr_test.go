@@ -10,6 +10,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 func TestVDRMethods(t *testing.T) {
@@ -29,6 +33,27 @@ func TestUpdate(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "not supported")
 	})
+
+	t.Run("test update hosted did", func(t *testing.T) {
+		host, err := NewHost(mem.NewProvider())
+		require.NoError(t, err)
+
+		v := New(WithHost(host))
+
+		doc := &did.Doc{Context: []string{did.Context}, ID: "did:web:example.com"}
+
+		err = v.Update(doc)
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+
+		require.NoError(t, host.Put(doc))
+
+		doc.Service = []did.Service{{ID: "#agent", Type: "did-communication", ServiceEndpoint: "https://example.com"}}
+		require.NoError(t, v.Update(doc))
+
+		updated, err := host.Get(doc.ID)
+		require.NoError(t, err)
+		require.Len(t, updated.Service, 1)
+	})
 }
 
 func TestDeactivate(t *testing.T) {
@@ -38,4 +63,20 @@ func TestDeactivate(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "not supported")
 	})
+
+	t.Run("test deactivate hosted did", func(t *testing.T) {
+		host, err := NewHost(mem.NewProvider())
+		require.NoError(t, err)
+
+		v := New(WithHost(host))
+
+		err = v.Deactivate("did:web:example.com")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+
+		require.NoError(t, host.Put(&did.Doc{Context: []string{did.Context}, ID: "did:web:example.com"}))
+		require.NoError(t, v.Deactivate("did:web:example.com"))
+
+		_, err = host.Get("did:web:example.com")
+		require.ErrorIs(t, err, vdrapi.ErrNotFound)
+	})
 }
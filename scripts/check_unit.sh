@@ -76,6 +76,12 @@ PKGS=$(go list github.com/hyperledger/aries-framework-go/component/storage/level
 $GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
 amend_coverage_file
 
+# Running storage/sqldb unit tests
+cd ../sqldb/
+PKGS=$(go list github.com/hyperledger/aries-framework-go/component/storage/sqldb/... 2> /dev/null)
+$GO_TEST_CMD $PKGS -count=1 -race -coverprofile=profile.out -covermode=atomic -timeout=10m
+amend_coverage_file
+
 if [ "$SKIP_DOCKER" = true ]; then
     echo "Skipping edv unit tests"
 else
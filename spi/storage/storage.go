@@ -32,10 +32,35 @@ type StoreConfiguration struct {
 	TagNames []string `json:"tagNames,omitempty"`
 }
 
+// SortOrder specifies the sort order of query results.
+type SortOrder int
+
+const (
+	// SortAscending sorts the query results in ascending order.
+	SortAscending SortOrder = iota
+	// SortDescending sorts the query results in descending order.
+	SortDescending
+)
+
+// SortOptions sets the order of query results.
+type SortOptions struct {
+	// Order is the sort order (ascending by default).
+	Order SortOrder
+	// TagName is the name of the tag whose values the results are sorted by. Numeric values come first
+	// (sorted numerically), followed by dates (sorted chronologically) and other values. Results without the tag
+	// come last. Ties are sorted by key. If TagName is empty, then the results are sorted by key only.
+	TagName string
+}
+
 // QueryOptions represents various options for Query calls in a store.
 type QueryOptions struct {
 	// PageSize sets the page size used by the Store.Query method.
 	PageSize int
+	// SortOptions sets the order of the results. If nil, then the results are sorted by key in ascending order.
+	SortOptions *SortOptions
+	// Bookmark resumes a previous query after the entry the bookmark was returned for (see Iterator.Bookmark).
+	// The query must use the same expression and sort options as the query the bookmark was returned by.
+	Bookmark string
 }
 
 // QueryOption represents an option for a Query call in a store.
@@ -50,6 +75,21 @@ func WithPageSize(size int) QueryOption {
 	}
 }
 
+// WithSortingOrder sets the order of the results returned by the Query call.
+func WithSortingOrder(sortOptions *SortOptions) QueryOption {
+	return func(opts *QueryOptions) {
+		opts.SortOptions = sortOptions
+	}
+}
+
+// WithBookmark resumes a previous Query call after the entry the bookmark was returned for.
+// This allows resuming the iteration across calls (e.g. to serve a paged REST API) without keeping an Iterator open.
+func WithBookmark(bookmark string) QueryOption {
+	return func(opts *QueryOptions) {
+		opts.Bookmark = bookmark
+	}
+}
+
 // Tag represents a Name + Value pair that can be associated with a key + value pair for querying later.
 type Tag struct {
 	// Name can be used to tag a given key + value pair as belonging to a group.
@@ -118,7 +158,15 @@ type Store interface {
 
 	// Query returns all data that satisfies the expression. Expression format: TagName:TagValue.
 	// If TagValue is not provided, then all data associated with the TagName will be returned.
-	// For now, expression can only be a single tag Name + Value pair.
+	// TagValue cannot contain colons.
+	//
+	// Range comparisons are expressed with TagName<Value, TagName<=Value, TagName>Value and TagName>=Value.
+	// Both the tag value and Value must be numbers, or both must be dates (RFC 3339 or YYYY-MM-DD), otherwise
+	// the data does not match.
+	//
+	// Conditions can be combined with && (AND) and || (OR), && takes precedence over ||. Parentheses are not
+	// supported. For example: "type:credential&&issued>=2021-01-01||favourite:true".
+	//
 	// If no options are provided, then defaults will be used.
 	Query(expression string, options ...QueryOption) (Iterator, error)
 
@@ -155,6 +203,10 @@ type Iterator interface {
 	// Tags returns the tags associated with the key of the current entry.
 	Tags() ([]Tag, error)
 
+	// Bookmark returns an opaque string which resumes the query after the current entry when passed to
+	// a Query call with the WithBookmark option.
+	Bookmark() (string, error)
+
 	// Close closes this iterator object, freeing resources.
 	Close() error
 }
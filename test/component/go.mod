@@ -8,6 +8,10 @@ go 1.15
 
 require (
 	github.com/google/uuid v1.1.2
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20210224230531-58e1368e5661
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261019084901-66f4e01095f2
 	github.com/stretchr/testify v1.6.1
 )
+
+replace (
+	github.com/hyperledger/aries-framework-go/spi => ../../spi
+)
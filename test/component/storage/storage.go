@@ -42,6 +42,9 @@ func TestAll(t *testing.T, provider spi.Provider) {
 		t.Run("Query", func(t *testing.T) {
 			TestStoreQuery(t, provider)
 		})
+		t.Run("Query with compound expressions, sorting and bookmarks", func(t *testing.T) {
+			TestStoreQueryWithSortingAndBookmark(t, provider)
+		})
 		t.Run("Batch", func(t *testing.T) {
 			TestStoreBatch(t, provider)
 		})
@@ -947,6 +950,179 @@ func TestStoreQuery(t *testing.T, provider spi.Provider) { // nolint: funlen //
 	})
 }
 
+// TestStoreQueryWithSortingAndBookmark tests common Store Query functionality with compound expressions,
+// range conditions, sorting and bookmarks.
+func TestStoreQueryWithSortingAndBookmark(t *testing.T, provider spi.Provider) { // nolint: funlen // Test file
+	keysToPut := []string{"key1", "key2", "key3", "key4", "key5"}
+	valuesToPut := [][]byte{[]byte("value1"), []byte("value2"), []byte("value3"), []byte("value4"), []byte("value5")}
+	tagsToPut := [][]spi.Tag{
+		{{Name: "type", Value: "credential"}, {Name: "age", Value: "30"}, {Name: "issued", Value: "2021-03-01"}},
+		{{Name: "type", Value: "credential"}, {Name: "age", Value: "4"}, {Name: "issued", Value: "2020-12-31"}},
+		{{Name: "type", Value: "connection"}, {Name: "age", Value: "-2.5"}},
+		{{Name: "type", Value: "credential"}, {Name: "issued", Value: "2021-01-15T10:00:00Z"}},
+		{{Name: "type", Value: "connection"}, {Name: "age", Value: "100"}, {Name: "state", Value: "completed"}},
+	}
+
+	setUp := func(t *testing.T) spi.Store {
+		storeName := randomStoreName()
+
+		store, err := provider.OpenStore(storeName)
+		require.NoError(t, err)
+		require.NotNil(t, store)
+
+		err = provider.SetStoreConfig(storeName,
+			spi.StoreConfiguration{TagNames: []string{"type", "age", "issued", "state"}})
+		require.NoError(t, err)
+
+		putData(t, store, keysToPut, valuesToPut, tagsToPut)
+
+		return store
+	}
+
+	store := setUp(t)
+
+	t.Run("AND expression", func(t *testing.T) {
+		iterator, err := store.Query("type:credential&&age")
+		require.NoError(t, err)
+
+		verifyExpectedIterator(t, iterator, keysToPut[:2], valuesToPut[:2], tagsToPut[:2])
+	})
+	t.Run("OR expression", func(t *testing.T) {
+		iterator, err := store.Query("state:completed||type:connection&&age<0||issued:2021-03-01")
+		require.NoError(t, err)
+
+		verifyExpectedIterator(t, iterator,
+			[]string{keysToPut[0], keysToPut[2], keysToPut[4]},
+			[][]byte{valuesToPut[0], valuesToPut[2], valuesToPut[4]},
+			[][]spi.Tag{tagsToPut[0], tagsToPut[2], tagsToPut[4]})
+	})
+	t.Run("Numeric range", func(t *testing.T) {
+		iterator, err := store.Query("age>=4&&age<100")
+		require.NoError(t, err)
+
+		verifyExpectedIterator(t, iterator, keysToPut[:2], valuesToPut[:2], tagsToPut[:2])
+
+		iterator, err = store.Query("age>-3&&age<=4")
+		require.NoError(t, err)
+
+		verifyExpectedIterator(t, iterator, keysToPut[1:3], valuesToPut[1:3], tagsToPut[1:3])
+	})
+	t.Run("Date range", func(t *testing.T) {
+		iterator, err := store.Query("issued>2021-01-01")
+		require.NoError(t, err)
+
+		verifyExpectedIterator(t, iterator,
+			[]string{keysToPut[0], keysToPut[3]},
+			[][]byte{valuesToPut[0], valuesToPut[3]},
+			[][]spi.Tag{tagsToPut[0], tagsToPut[3]})
+
+		iterator, err = store.Query("issued<2021-01-15T10:00:00Z")
+		require.NoError(t, err)
+
+		verifyExpectedIterator(t, iterator, keysToPut[1:2], valuesToPut[1:2], tagsToPut[1:2])
+	})
+	t.Run("Range on a value that is neither a number nor a date", func(t *testing.T) {
+		iterator, err := store.Query("type>credential")
+		require.NoError(t, err)
+
+		verifyExpectedIterator(t, iterator, nil, nil, nil)
+	})
+	t.Run("Sorted by key when no sort options are given", func(t *testing.T) {
+		iterator, err := store.Query("type")
+		require.NoError(t, err)
+
+		verifyOrderedKeys(t, iterator, keysToPut)
+	})
+	t.Run("Sorted by numeric tag value", func(t *testing.T) {
+		iterator, err := store.Query("type", spi.WithSortingOrder(&spi.SortOptions{TagName: "age"}))
+		require.NoError(t, err)
+
+		// Entries without the sort tag come last.
+		verifyOrderedKeys(t, iterator, []string{"key3", "key2", "key1", "key5", "key4"})
+
+		iterator, err = store.Query("type",
+			spi.WithSortingOrder(&spi.SortOptions{Order: spi.SortDescending, TagName: "age"}))
+		require.NoError(t, err)
+
+		verifyOrderedKeys(t, iterator, []string{"key4", "key5", "key1", "key2", "key3"})
+	})
+	t.Run("Sorted by date tag value", func(t *testing.T) {
+		iterator, err := store.Query("issued", spi.WithSortingOrder(&spi.SortOptions{TagName: "issued"}))
+		require.NoError(t, err)
+
+		verifyOrderedKeys(t, iterator, []string{"key2", "key4", "key1"})
+	})
+	t.Run("Paginate with bookmarks", func(t *testing.T) {
+		sortOptions := &spi.SortOptions{Order: spi.SortDescending, TagName: "age"}
+
+		var (
+			keys     []string
+			bookmark string
+		)
+
+		for page := 0; page < len(keysToPut); page++ {
+			iterator, err := store.Query("type", spi.WithSortingOrder(sortOptions),
+				spi.WithPageSize(2), spi.WithBookmark(bookmark))
+			require.NoError(t, err)
+
+			var pageKeys int
+
+			for ; pageKeys < 2; pageKeys++ {
+				ok, err := iterator.Next()
+				require.NoError(t, err)
+
+				if !ok {
+					break
+				}
+
+				key, err := iterator.Key()
+				require.NoError(t, err)
+
+				keys = append(keys, key)
+
+				bookmark, err = iterator.Bookmark()
+				require.NoError(t, err)
+				require.NotEmpty(t, bookmark)
+			}
+
+			require.NoError(t, iterator.Close())
+
+			if pageKeys < 2 {
+				break
+			}
+		}
+
+		require.Equal(t, []string{"key4", "key5", "key1", "key2", "key3"}, keys)
+	})
+	t.Run("Bookmark is stable when entries are added", func(t *testing.T) {
+		store := setUp(t)
+
+		iterator, err := store.Query("type")
+		require.NoError(t, err)
+
+		ok, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		bookmark, err := iterator.Bookmark()
+		require.NoError(t, err)
+		require.NoError(t, iterator.Close())
+
+		err = store.Put("key0", []byte("value0"), spi.Tag{Name: "type", Value: "credential"})
+		require.NoError(t, err)
+
+		iterator, err = store.Query("type", spi.WithBookmark(bookmark))
+		require.NoError(t, err)
+
+		verifyOrderedKeys(t, iterator, keysToPut[1:])
+	})
+	t.Run("Invalid bookmark", func(t *testing.T) {
+		iterator, err := store.Query("type", spi.WithBookmark("not a bookmark"))
+		require.Error(t, err)
+		require.Empty(t, iterator)
+	})
+}
+
 // TestStoreBatch tests common Store Batch functionality.
 func TestStoreBatch(t *testing.T, provider spi.Provider) { // nolint:funlen // Test file
 	t.Run("Success: put three new values", func(t *testing.T) {
@@ -1236,6 +1412,26 @@ func putData(t *testing.T, store spi.Store, keys []string, values [][]byte, tags
 	}
 }
 
+func verifyOrderedKeys(t *testing.T, iterator spi.Iterator, expectedKeys []string) {
+	var keys []string
+
+	ok, err := iterator.Next()
+	require.NoError(t, err)
+
+	for ok {
+		key, err := iterator.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+
+		ok, err = iterator.Next()
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, iterator.Close())
+	require.Equal(t, expectedKeys, keys)
+}
+
 func verifyExpectedIterator(t *testing.T, // nolint:gocyclo,funlen // Test file
 	actualResultsItr spi.Iterator,
 	expectedKeys []string, expectedValues [][]byte, expectedTags [][]spi.Tag) {